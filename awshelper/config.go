@@ -21,6 +21,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/sts"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/ratelimit"
 	"github.com/gruntwork-io/terragrunt/options"
 )
 
@@ -46,6 +47,19 @@ var addUserAgent = request.NamedHandler{
 		"terragrunt", version.GetVersion()),
 }
 
+// rateLimitHandler throttles the AWS API calls with the rate limiter of the given options, shared by all the units, see
+// --api-rate-limit. It runs before the signing of each attempt, so the retries are throttled as well.
+func rateLimitHandler(opts *options.TerragruntOptions) request.NamedHandler {
+	return request.NamedHandler{
+		Name: "terragrunt.RateLimitHandler",
+		Fn: func(r *request.Request) {
+			if err := opts.RateLimiter.Wait(r.Context(), ratelimit.ProviderAWS, r.ClientInfo.ServiceName); err != nil {
+				r.Error = err
+			}
+		},
+	}
+}
+
 // CreateAwsSessionFromConfig returns an AWS session object for the given config region (required), profile name (optional), and IAM role to assume
 // (optional), ensuring that the credentials are available.
 func CreateAwsSessionFromConfig(config *AwsSessionConfig, opts *options.TerragruntOptions) (*session.Session, error) {
@@ -89,6 +103,7 @@ func CreateAwsSessionFromConfig(config *AwsSessionConfig, opts *options.Terragru
 	}
 
 	sess.Handlers.Build.PushFrontNamed(addUserAgent)
+	sess.Handlers.Sign.PushFrontNamed(rateLimitHandler(opts))
 
 	// Merge the config based IAMRole options into the original one, as the config has higher precedence than CLI.
 	iamRoleOptions := opts.IAMRoleOptions
@@ -221,6 +236,7 @@ func CreateAwsSession(l log.Logger, config *AwsSessionConfig, opts *options.Terr
 		}
 
 		sess.Handlers.Build.PushFrontNamed(addUserAgent)
+		sess.Handlers.Sign.PushFrontNamed(rateLimitHandler(opts))
 
 		if opts.IAMRoleOptions.RoleARN != "" {
 			if opts.IAMRoleOptions.WebIdentityToken != "" {
@@ -0,0 +1,48 @@
+package check
+
+import (
+	"context"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+// Run checks the drift of the remote state bucket of the unit from its config, i.e. its encryption, versioning, public
+// access block, policies and tags, and applies the drift policy of `--drift-policy`, else the `bucket_drift_policy` of
+// the config, else `fail`.
+func Run(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) error {
+	remoteState, err := config.ParseRemoteState(ctx, l, opts)
+	if err != nil || remoteState == nil {
+		return err
+	}
+
+	if !remoteState.CanCheckBucketDrift() {
+		l.Infof("Skipping unit %s, checking the bucket of the %s backend is not supported", opts.WorkingDir, remoteState.BackendName)
+		return nil
+	}
+
+	policy := opts.BackendDriftPolicy
+	if policy == "" {
+		policy = remoteState.BackendConfig.DriftPolicy()
+	}
+
+	if policy == "" {
+		policy = string(backend.DriftPolicyFail)
+	}
+
+	drift, err := remoteState.CheckBucketDrift(ctx, l, policy, opts)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case drift == nil:
+		l.Infof("Remote state %s bucket of unit %s does not exist yet", remoteState.BackendName, opts.WorkingDir)
+	case len(drift.Settings) == 0:
+		l.Infof("Remote state %s bucket %s is up to date", remoteState.BackendName, drift.Bucket)
+	}
+
+	return nil
+}
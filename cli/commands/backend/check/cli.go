@@ -0,0 +1,48 @@
+// Package check provides the `backend check` command, checking the drift of the remote state buckets from their
+// config.
+package check
+
+import (
+	"github.com/gruntwork-io/terragrunt/cli/commands/common/runall"
+	"github.com/gruntwork-io/terragrunt/cli/commands/run"
+	"github.com/gruntwork-io/terragrunt/cli/flags"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+const (
+	CommandName = "check"
+
+	DriftPolicyFlagName = "drift-policy"
+)
+
+func NewFlags(l log.Logger, opts *options.TerragruntOptions, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	flags := cli.Flags{
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        DriftPolicyFlagName,
+			EnvVars:     tgPrefix.EnvVars(DriftPolicyFlagName),
+			Usage:       "The policy applied to the buckets that drifted from their config: warn, fail or remediate. Defaults to the bucket_drift_policy of the config, else fail.",
+			Destination: &opts.BackendDriftPolicy,
+		}),
+	}
+
+	return append(flags, run.NewFlags(l, opts, nil).Filter(run.ConfigFlagName, run.DownloadDirFlagName)...)
+}
+
+func NewCommand(l log.Logger, opts *options.TerragruntOptions) *cli.Command {
+	cmd := &cli.Command{
+		Name:  CommandName,
+		Usage: "Check that the remote state bucket matches its config.",
+		Flags: NewFlags(l, opts, nil),
+		Action: func(ctx *cli.Context) error {
+			return Run(ctx, l, opts.OptionsFromContext(ctx))
+		},
+	}
+
+	cmd = runall.WrapCommand(l, opts, cmd, run.Run, true)
+
+	return cmd
+}
@@ -3,8 +3,11 @@ package backend
 
 import (
 	"github.com/gruntwork-io/terragrunt/cli/commands/backend/bootstrap"
+	"github.com/gruntwork-io/terragrunt/cli/commands/backend/check"
 	"github.com/gruntwork-io/terragrunt/cli/commands/backend/delete"
 	"github.com/gruntwork-io/terragrunt/cli/commands/backend/migrate"
+	migratelocks "github.com/gruntwork-io/terragrunt/cli/commands/backend/migrate-locks"
+	"github.com/gruntwork-io/terragrunt/cli/commands/backend/reencrypt"
 	"github.com/gruntwork-io/terragrunt/internal/cli"
 	"github.com/gruntwork-io/terragrunt/options"
 	"github.com/gruntwork-io/terragrunt/pkg/log"
@@ -18,8 +21,11 @@ func NewCommand(l log.Logger, opts *options.TerragruntOptions) *cli.Command {
 		Usage: "Interact with OpenTofu/Terraform backend infrastructure.",
 		Subcommands: cli.Commands{
 			bootstrap.NewCommand(l, opts),
+			check.NewCommand(l, opts),
 			delete.NewCommand(l, opts),
 			migrate.NewCommand(l, opts),
+			migratelocks.NewCommand(l, opts),
+			reencrypt.NewCommand(l, opts),
 		},
 		Action: cli.ShowCommandHelp,
 	}
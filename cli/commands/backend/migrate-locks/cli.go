@@ -0,0 +1,50 @@
+// Package migratelocks provides the `backend migrate-locks` command, switching the states of the units of a stack off
+// their DynamoDB lock tables to S3 native locking.
+package migratelocks
+
+import (
+	"github.com/gruntwork-io/terragrunt/cli/commands/run"
+	"github.com/gruntwork-io/terragrunt/cli/flags"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+const (
+	CommandName = "migrate-locks"
+
+	DeleteLockTableFlagName      = "delete-lock-table"
+	ForceDeleteLockTableFlagName = "force-delete-table"
+)
+
+func NewFlags(l log.Logger, opts *options.TerragruntOptions, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	flags := cli.Flags{
+		flags.NewFlag(&cli.SliceFlag[string]{
+			Name:        DeleteLockTableFlagName,
+			EnvVars:     tgPrefix.EnvVars(DeleteLockTableFlagName),
+			Usage:       "Delete the given DynamoDB lock table once no unit is locked with it anymore. Can be specified multiple times.",
+			Destination: &opts.DeleteLockTables,
+		}),
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        ForceDeleteLockTableFlagName,
+			EnvVars:     tgPrefix.EnvVars(ForceDeleteLockTableFlagName),
+			Usage:       "Delete the lock tables even if they hold the digests of states outside of the stack, e.g. of other stacks sharing them.",
+			Destination: &opts.ForceDeleteLockTable,
+		}),
+	}
+
+	return append(flags, run.NewFlags(l, opts, nil).Filter(run.ConfigFlagName, run.DownloadDirFlagName)...)
+}
+
+func NewCommand(l log.Logger, opts *options.TerragruntOptions) *cli.Command {
+	return &cli.Command{
+		Name:  CommandName,
+		Usage: "Migrate the states of the units of the stack off their DynamoDB lock tables to S3 native locking.",
+		Flags: NewFlags(l, opts, nil),
+		Action: func(ctx *cli.Context) error {
+			return Run(ctx, l, opts.OptionsFromContext(ctx))
+		},
+	}
+}
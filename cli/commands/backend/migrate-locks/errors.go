@@ -0,0 +1,27 @@
+package migratelocks
+
+import (
+	"fmt"
+	"strings"
+)
+
+type LockTableStillUsedError struct {
+	tableName string
+	units     []string
+}
+
+func (err LockTableStillUsedError) Error() string {
+	return fmt.Sprintf("DynamoDB table %s is still used by the units %s, remove dynamodb_table from their remote_state config before deleting it.", err.tableName, strings.Join(err.units, ", "))
+}
+
+type NoLockMigratorError string
+
+func (tableName NoLockMigratorError) Error() string {
+	return fmt.Sprintf("Can't delete DynamoDB table %s, as no unit of the stack stores its state in S3.", string(tableName))
+}
+
+type UnitsNotReadyError []string
+
+func (units UnitsNotReadyError) Error() string {
+	return fmt.Sprintf("The units %s can't be switched off their DynamoDB lock tables yet.", strings.Join(units, ", "))
+}
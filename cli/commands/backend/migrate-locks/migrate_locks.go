@@ -0,0 +1,104 @@
+package migratelocks
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/remotestate"
+	"github.com/gruntwork-io/terragrunt/internal/runner"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+// Run checks the units of the stack in the working dir, to switch their states off their DynamoDB lock tables safely:
+// a unit is ready once its state is also locked natively, with `use_lockfile`, and isn't currently locked, so
+// removing `dynamodb_table` from its config never leaves it unlocked. The lock tables to delete are deleted once no
+// unit is configured with them, and they hold no lock nor any item of the states of units outside of the stack, as
+// the tables may be shared with other stacks. It returns an error if any unit still locked with a DynamoDB
+// table isn't ready.
+func Run(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) error {
+	stack, err := runner.FindStackInSubfolders(ctx, l, opts)
+	if err != nil {
+		return err
+	}
+
+	var (
+		// tableUnits are the units configured with each lock table.
+		tableUnits = map[string][]string{}
+		// stateIDs are the IDs of the states of the units in the lock tables.
+		stateIDs []string
+		notReady UnitsNotReadyError
+		// migrator is the remote state of a unit whose credentials the lock tables are deleted with.
+		migrator *remotestate.RemoteState
+	)
+
+	for _, unit := range stack.GetStack().Units {
+		remoteState, err := config.ParseRemoteState(ctx, l, unit.TerragruntOptions)
+		if err != nil {
+			return err
+		}
+
+		if remoteState == nil || !remoteState.CanMigrateLocks() {
+			continue
+		}
+
+		if migrator == nil {
+			migrator = remoteState
+		}
+
+		status, err := remoteState.LockMigrationStatus(ctx, l, unit.TerragruntOptions)
+		if err != nil {
+			return err
+		}
+
+		stateIDs = append(stateIDs, status.StateID)
+
+		name := unit.Path
+		if rel, err := filepath.Rel(opts.WorkingDir, unit.Path); err == nil {
+			name = rel
+		}
+
+		if status.LockTable != "" {
+			tableUnits[status.LockTable] = append(tableUnits[status.LockTable], name)
+		}
+
+		switch {
+		case status.LockTable == "" && status.NativeLocking:
+			l.Infof("Unit %s is locked natively, without a DynamoDB table", name)
+		case status.LockTable == "":
+			l.Warnf("Unit %s isn't locked, set use_lockfile = true in its remote_state config to lock it natively", name)
+		case !status.NativeLocking:
+			l.Warnf("Unit %s is only locked with DynamoDB table %s, set use_lockfile = true in its remote_state config to also lock it natively", name, status.LockTable)
+
+			notReady = append(notReady, name)
+		case status.Locked:
+			l.Warnf("Unit %s is currently locked, retry once its lock is released", name)
+
+			notReady = append(notReady, name)
+		default:
+			l.Infof("Unit %s is ready to switch off DynamoDB table %s, remove dynamodb_table from its remote_state config", name, status.LockTable)
+		}
+	}
+
+	for _, tableName := range opts.DeleteLockTables {
+		if units := tableUnits[tableName]; len(units) > 0 {
+			return errors.New(LockTableStillUsedError{tableName: tableName, units: units})
+		}
+
+		if migrator == nil {
+			return errors.New(NoLockMigratorError(tableName))
+		}
+
+		if err := migrator.DeleteLockTable(ctx, l, tableName, stateIDs, opts); err != nil {
+			return err
+		}
+	}
+
+	if len(notReady) > 0 {
+		return errors.New(notReady)
+	}
+
+	return nil
+}
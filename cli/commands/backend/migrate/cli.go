@@ -1,6 +1,7 @@
 package migrate
 
 import (
+	"github.com/gruntwork-io/terragrunt/cli/commands/common/runall"
 	"github.com/gruntwork-io/terragrunt/cli/commands/run"
 	"github.com/gruntwork-io/terragrunt/cli/flags"
 	"github.com/gruntwork-io/terragrunt/internal/cli"
@@ -13,8 +14,9 @@ const (
 	CommandName = "migrate"
 
 	ForceBackendMigrateFlagName = "force"
+	LockSourceFlagName          = "lock-source"
 
-	usageText = "terragrunt backend migrate [options] <src-unit> <dst-unit>"
+	usageText = "terragrunt backend migrate [options] [<src-unit> <dst-unit>]"
 )
 
 func NewFlags(l log.Logger, opts *options.TerragruntOptions, prefix flags.Prefix) cli.Flags {
@@ -27,6 +29,12 @@ func NewFlags(l log.Logger, opts *options.TerragruntOptions, prefix flags.Prefix
 			Usage:       "Force the backend to be migrated, even if the bucket is not versioned.",
 			Destination: &opts.ForceBackendMigrate,
 		}),
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        LockSourceFlagName,
+			EnvVars:     tgPrefix.EnvVars(LockSourceFlagName),
+			Usage:       "Lock the state left at the previous backend of the unit once migrated, so that it isn't changed anymore.",
+			Destination: &opts.LockBackendMigrateSource,
+		}),
 	}
 
 	return append(flags, run.NewFlags(l, opts, nil).Filter(run.ConfigFlagName, run.DownloadDirFlagName)...)
@@ -39,6 +47,12 @@ func NewCommand(l log.Logger, opts *options.TerragruntOptions) *cli.Command {
 		UsageText: usageText,
 		Flags:     NewFlags(l, opts, nil),
 		Action: func(ctx *cli.Context) error {
+			// Without units, the state of the unit is migrated from the backend its working dir is initialized with
+			// to the backend of its remote_state config.
+			if !ctx.Args().Present() {
+				return RunBackendMigration(ctx, l, opts.OptionsFromContext(ctx))
+			}
+
 			srcPath := ctx.Args().First()
 			if srcPath == "" {
 				return errors.New(usageText)
@@ -53,5 +67,16 @@ func NewCommand(l log.Logger, opts *options.TerragruntOptions) *cli.Command {
 		},
 	}
 
+	cmd = runall.WrapCommand(l, opts, cmd, run.Run, true)
+
+	// The states of all the units are only migrated between backends, not between units.
+	cmd = cmd.WrapAction(func(ctx *cli.Context, action cli.ActionFunc) error {
+		if opts.RunAll && ctx.Args().Present() {
+			return errors.New(usageText)
+		}
+
+		return action(ctx)
+	})
+
 	return cmd
 }
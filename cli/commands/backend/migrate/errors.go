@@ -0,0 +1,27 @@
+package migrate
+
+import (
+	"fmt"
+
+	"github.com/gruntwork-io/terragrunt/internal/remotestate"
+)
+
+type StateLineageMismatchError struct {
+	unit       string
+	lineage    string
+	dstLineage string
+}
+
+func (err StateLineageMismatchError) Error() string {
+	return fmt.Sprintf("The new backend of unit %s already stores a state of lineage %s, refusing to overwrite it with the state of lineage %s.", err.unit, err.dstLineage, err.lineage)
+}
+
+type StateVerificationError struct {
+	src  *remotestate.TerraformState
+	dst  *remotestate.TerraformState
+	unit string
+}
+
+func (err StateVerificationError) Error() string {
+	return fmt.Sprintf("The state of unit %s pushed to its new backend has the lineage %s and serial %d, instead of the lineage %s and serial %d.", err.unit, err.dst.Lineage, err.dst.Serial, err.src.Lineage, err.src.Serial)
+}
@@ -0,0 +1,260 @@
+package migrate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gruntwork-io/go-commons/version"
+
+	"github.com/gruntwork-io/terragrunt/cli/commands/run"
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/remotestate"
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend"
+	"github.com/gruntwork-io/terragrunt/internal/report"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/tf"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+const (
+	localBackendName = "local"
+
+	lockOperation = "migrate"
+)
+
+// RunBackendMigration migrates the state of the unit from the backend its working dir is initialized with, recorded in
+// its data dir, to the backend of its remote_state config, once the backend config is generated.
+func RunBackendMigration(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) error {
+	target := run.NewTarget(run.TargetPointGenerateConfig, migrateBackend)
+
+	return run.RunWithTarget(ctx, l, opts, report.NewReport(), target)
+}
+
+// migrateBackend pulls the state from the previous backend of the unit, pushes it to its new backend, bootstrapped if
+// needed, verifies that the pushed state has the serial and lineage of the pulled state, and initializes the working
+// dir of the unit with the new backend. The states already migrated, with the same lineage and a serial at least as
+// recent, aren't pushed again, and the states of another lineage are never overwritten.
+func migrateBackend(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, cfg *config.TerragruntConfig) error {
+	dstRemote := cfg.RemoteState
+	if dstRemote == nil || dstRemote.DisableInit {
+		l.Infof("Skipping unit %s, without remote state backend", opts.WorkingDir)
+		return nil
+	}
+
+	tfBackend, err := initializedBackend(opts)
+	if err != nil {
+		return err
+	}
+
+	if tfBackend != nil && !dstRemote.DiffersFrom(tfBackend) {
+		l.Infof("Unit %s already uses the %s backend of its remote_state config", opts.WorkingDir, dstRemote.BackendName)
+		return nil
+	}
+
+	var (
+		srcRemote *remotestate.RemoteState
+		srcOpts   = opts.Clone()
+		srcState  []byte
+	)
+
+	if tfBackend == nil || tfBackend.Type == localBackendName {
+		if srcState, err = readLocalState(opts, tfBackend); err != nil {
+			return err
+		}
+	} else {
+		srcRemote = remotestate.New(&remotestate.Config{
+			BackendName:   tfBackend.Type,
+			BackendConfig: tfBackend.Settings(),
+			// The encryption of the previous backend isn't recorded, the state is decrypted as it is encrypted.
+			Encryption: dstRemote.Encryption,
+		})
+
+		if err := srcRemote.InjectCredentials(ctx, l, srcOpts); err != nil {
+			return err
+		}
+
+		if srcState, err = srcRemote.PullBackendState(ctx, l, srcOpts); err != nil {
+			return err
+		}
+	}
+
+	src, err := remotestate.ParseTerraformState(srcState)
+	if err != nil {
+		return err
+	}
+
+	pushed, err := pushState(ctx, l, opts, dstRemote, srcState, src)
+	if err != nil {
+		return err
+	}
+
+	l.Infof("Initializing unit %s with the %s backend", opts.WorkingDir, dstRemote.BackendName)
+
+	initArgs := append([]string{tf.CommandNameInit, "-input=false", "-reconfigure"}, dstRemote.GetTFInitArgs()...)
+	if err := tf.RunCommand(ctx, l, opts, initArgs...); err != nil {
+		return err
+	}
+
+	if !opts.LockBackendMigrateSource || !pushed {
+		return nil
+	}
+
+	if srcRemote == nil || !srcRemote.CanLockState() {
+		l.Warnf("Unable to lock the previous state of unit %s, locking the state of the %s backend is not supported", opts.WorkingDir, sourceBackendName(tfBackend))
+		return nil
+	}
+
+	lock := &backend.StateLock{
+		ID:        uuid.New().String(),
+		Operation: lockOperation,
+		Info:      fmt.Sprintf("State migrated to the %s backend by terragrunt backend migrate", dstRemote.BackendName),
+		Who:       lockOwner(),
+		Version:   version.GetVersion(),
+		Created:   time.Now().UTC(),
+	}
+
+	if err := srcRemote.LockState(ctx, l, lock, srcOpts); err != nil {
+		return err
+	}
+
+	l.Infof("Locked the previous state of unit %s at %s", opts.WorkingDir, lock.Path)
+
+	return nil
+}
+
+// pushState pushes the given state to the given backend, bootstrapped if needed, unless there is no state to push or
+// it is already migrated, and returns true if it was pushed. The credentials of the backend are set in the env of the
+// given options, to initialize the unit with it.
+func pushState(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, dstRemote *remotestate.RemoteState, state []byte, src *remotestate.TerraformState) (bool, error) {
+	if needsBootstrap, err := dstRemote.NeedsBootstrap(ctx, l, opts); err != nil {
+		return false, err
+	} else if needsBootstrap {
+		if err := dstRemote.Bootstrap(ctx, l, opts); err != nil {
+			return false, err
+		}
+	}
+
+	if err := dstRemote.InjectCredentials(ctx, l, opts); err != nil {
+		return false, err
+	}
+
+	if err := dstRemote.CheckConnectivity(ctx, l, opts); err != nil {
+		return false, err
+	}
+
+	if src.Lineage == "" {
+		l.Infof("Unit %s has no state to migrate", opts.WorkingDir)
+		return false, nil
+	}
+
+	dst, err := pullState(ctx, l, opts, dstRemote)
+	if err != nil {
+		return false, err
+	}
+
+	switch {
+	case dst.Lineage == "":
+	case dst.Lineage != src.Lineage:
+		return false, errors.New(StateLineageMismatchError{unit: opts.WorkingDir, lineage: src.Lineage, dstLineage: dst.Lineage})
+	case dst.Serial >= src.Serial:
+		l.Infof("State of unit %s is already migrated to the %s backend", opts.WorkingDir, dstRemote.BackendName)
+		return false, nil
+	}
+
+	l.Infof("Migrating state of unit %s, serial %d, to the %s backend", opts.WorkingDir, src.Serial, dstRemote.BackendName)
+
+	if err := dstRemote.PushBackendState(ctx, l, opts, state); err != nil {
+		return false, err
+	}
+
+	if dst, err = pullState(ctx, l, opts, dstRemote); err != nil {
+		return false, err
+	}
+
+	if dst.Lineage != src.Lineage || dst.Serial != src.Serial {
+		return false, errors.New(StateVerificationError{unit: opts.WorkingDir, src: src, dst: dst})
+	}
+
+	return true, nil
+}
+
+func pullState(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, remote *remotestate.RemoteState) (*remotestate.TerraformState, error) {
+	state, err := remote.PullBackendState(ctx, l, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return remotestate.ParseTerraformState(state)
+}
+
+// initializedBackend returns the backend the working dir of the unit is initialized with, or nil if it isn't
+// initialized.
+func initializedBackend(opts *options.TerragruntOptions) (*remotestate.TerraformBackend, error) {
+	stateFile := filepath.Join(opts.DataDir(), remotestate.DefaultPathToRemoteStateFile)
+	if !util.FileExists(stateFile) {
+		return nil, nil
+	}
+
+	state, err := remotestate.ParseTerraformStateFile(stateFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return state.Backend, nil
+}
+
+// readLocalState returns the content of the state of the local backend, or nil if there is no state.
+func readLocalState(opts *options.TerragruntOptions, tfBackend *remotestate.TerraformBackend) ([]byte, error) {
+	stateFile := remotestate.DefaultPathToLocalStateFile
+
+	if tfBackend != nil {
+		if path := tfBackend.Settings().Path(); path != "" {
+			stateFile = path
+		}
+	}
+
+	if !filepath.IsAbs(stateFile) {
+		stateFile = filepath.Join(opts.WorkingDir, stateFile)
+	}
+
+	if !util.FileExists(stateFile) {
+		return nil, nil
+	}
+
+	state, err := os.ReadFile(stateFile)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return state, nil
+}
+
+func sourceBackendName(tfBackend *remotestate.TerraformBackend) string {
+	if tfBackend == nil {
+		return localBackendName
+	}
+
+	return tfBackend.Type
+}
+
+// lockOwner returns the owner of the locks, as reported by OpenTofu/Terraform: `user@hostname`.
+func lockOwner() string {
+	var name, host string
+
+	if current, err := user.Current(); err == nil {
+		name = current.Username
+	}
+
+	if hostname, err := os.Hostname(); err == nil {
+		host = hostname
+	}
+
+	return name + "@" + host
+}
@@ -0,0 +1,154 @@
+package migrate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/internal/remotestate"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+	"github.com/gruntwork-io/terragrunt/tf"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+const (
+	srcState = `{"version": 4, "serial": 3, "lineage": "8f2a"}`
+
+	initializedBackendState = `{"version": 3, "backend": {"type": "local", "config": {"path": "old.tfstate", "workspace_dir": null}}}`
+)
+
+func TestMigrateBackend(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		dstState       string
+		expectedErr    string
+		expectedSerial int
+		expectedPushed bool
+	}{
+		{
+			name:           "migrated",
+			expectedPushed: true,
+			expectedSerial: 3,
+		},
+		{
+			name:           "older serial",
+			dstState:       `{"version": 4, "serial": 1, "lineage": "8f2a"}`,
+			expectedPushed: true,
+			expectedSerial: 3,
+		},
+		{
+			name:           "already migrated",
+			dstState:       `{"version": 4, "serial": 3, "lineage": "8f2a"}`,
+			expectedSerial: 3,
+		},
+		{
+			name:        "lineage mismatch",
+			dstState:    `{"version": 4, "serial": 7, "lineage": "c41d"}`,
+			expectedErr: "refusing to overwrite it",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			workingDir := t.TempDir()
+
+			require.NoError(t, os.MkdirAll(filepath.Join(workingDir, ".terraform"), os.ModePerm))
+			require.NoError(t, os.WriteFile(filepath.Join(workingDir, ".terraform", "terraform.tfstate"), []byte(initializedBackendState), 0600))
+			require.NoError(t, os.WriteFile(filepath.Join(workingDir, "old.tfstate"), []byte(srcState), 0600))
+
+			opts, err := options.NewTerragruntOptionsForTest(filepath.Join(workingDir, "terragrunt.hcl"))
+			require.NoError(t, err)
+
+			var (
+				dstState = []byte(tc.dstState)
+				pushed   bool
+				initArgs cli.Args
+			)
+
+			ctx := tf.ContextWithTerraformCommandHook(t.Context(), func(_ context.Context, _ log.Logger, opts *options.TerragruntOptions, args cli.Args) (*util.CmdOutput, error) {
+				output := new(util.CmdOutput)
+
+				switch {
+				case args.First() == tf.CommandNameInit && opts.WorkingDir == workingDir:
+					initArgs = args
+				case args.First() == tf.CommandNameInit:
+					assert.FileExists(t, filepath.Join(opts.WorkingDir, "backend.tf"))
+				case args.Second() == tf.CommandNamePull:
+					output.Stdout.Write(dstState)
+				case args.Second() == tf.CommandNamePush:
+					state, err := os.ReadFile(args.Get(2))
+					require.NoError(t, err)
+
+					dstState = state
+					pushed = true
+				}
+
+				return output, nil
+			})
+
+			cfg := &config.TerragruntConfig{
+				RemoteState: remotestate.New(&remotestate.Config{
+					BackendName:   "local",
+					BackendConfig: map[string]any{"path": "new.tfstate"},
+				}),
+			}
+
+			err = migrateBackend(ctx, logger.CreateLogger(), opts, cfg)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				assert.False(t, pushed)
+				assert.Nil(t, initArgs)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedPushed, pushed)
+			assert.Equal(t, cli.Args{"init", "-input=false", "-reconfigure", "-backend-config=path=new.tfstate"}, initArgs)
+
+			state, err := remotestate.ParseTerraformState(dstState)
+			require.NoError(t, err)
+			assert.Equal(t, "8f2a", state.Lineage)
+			assert.Equal(t, tc.expectedSerial, state.Serial)
+		})
+	}
+}
+
+func TestMigrateBackendAlreadyInitialized(t *testing.T) {
+	t.Parallel()
+
+	workingDir := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(workingDir, ".terraform"), os.ModePerm))
+	require.NoError(t, os.WriteFile(filepath.Join(workingDir, ".terraform", "terraform.tfstate"), []byte(initializedBackendState), 0600))
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(workingDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	ctx := tf.ContextWithTerraformCommandHook(t.Context(), func(_ context.Context, _ log.Logger, _ *options.TerragruntOptions, args cli.Args) (*util.CmdOutput, error) {
+		t.Errorf("unexpected command %v", args)
+
+		return new(util.CmdOutput), nil
+	})
+
+	cfg := &config.TerragruntConfig{
+		RemoteState: remotestate.New(&remotestate.Config{
+			BackendName:   "local",
+			BackendConfig: map[string]any{"path": "old.tfstate"},
+		}),
+	}
+
+	require.NoError(t, migrateBackend(ctx, logger.CreateLogger(), opts, cfg))
+}
@@ -0,0 +1,33 @@
+// Package reencrypt provides the `backend reencrypt` command, re-encrypting the states with the KMS key of their
+// remote_state config once it changes.
+package reencrypt
+
+import (
+	"github.com/gruntwork-io/terragrunt/cli/commands/common/runall"
+	"github.com/gruntwork-io/terragrunt/cli/commands/run"
+	"github.com/gruntwork-io/terragrunt/cli/flags"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+const CommandName = "reencrypt"
+
+func NewFlags(l log.Logger, opts *options.TerragruntOptions, prefix flags.Prefix) cli.Flags {
+	return run.NewFlags(l, opts, nil).Filter(run.ConfigFlagName, run.DownloadDirFlagName)
+}
+
+func NewCommand(l log.Logger, opts *options.TerragruntOptions) *cli.Command {
+	cmd := &cli.Command{
+		Name:  CommandName,
+		Usage: "Re-encrypt the state with the KMS key of the remote_state config.",
+		Flags: NewFlags(l, opts, nil),
+		Action: func(ctx *cli.Context) error {
+			return Run(ctx, l, opts.OptionsFromContext(ctx))
+		},
+	}
+
+	cmd = runall.WrapCommand(l, opts, cmd, run.Run, true)
+
+	return cmd
+}
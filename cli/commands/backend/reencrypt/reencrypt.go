@@ -0,0 +1,25 @@
+package reencrypt
+
+import (
+	"context"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+// Run re-encrypts the state of the unit, of all its workspaces, with the KMS key of its remote_state config, once the
+// key changed, and updates the default encryption of its bucket to the key.
+func Run(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) error {
+	remoteState, err := config.ParseRemoteState(ctx, l, opts)
+	if err != nil || remoteState == nil {
+		return err
+	}
+
+	if !remoteState.CanReencryptState() {
+		l.Infof("Skipping unit %s, re-encrypting the state of the %s backend is not supported", opts.WorkingDir, remoteState.BackendName)
+		return nil
+	}
+
+	return remoteState.ReencryptState(ctx, l, opts)
+}
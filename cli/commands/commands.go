@@ -13,6 +13,7 @@ import (
 	"github.com/gruntwork-io/go-commons/env"
 	"github.com/gruntwork-io/terragrunt/internal/experiment"
 	"github.com/gruntwork-io/terragrunt/internal/providercache"
+	"github.com/gruntwork-io/terragrunt/internal/runs"
 	"github.com/gruntwork-io/terragrunt/options"
 	"github.com/gruntwork-io/terragrunt/pkg/log"
 	"github.com/gruntwork-io/terragrunt/tf"
@@ -22,6 +23,8 @@ import (
 	"github.com/gruntwork-io/terragrunt/cli/commands/backend"
 	"github.com/gruntwork-io/terragrunt/cli/commands/catalog"
 	"github.com/gruntwork-io/terragrunt/cli/commands/dag"
+	"github.com/gruntwork-io/terragrunt/cli/commands/dependents"
+	"github.com/gruntwork-io/terragrunt/cli/commands/encrypt"
 	execCmd "github.com/gruntwork-io/terragrunt/cli/commands/exec"
 	"github.com/gruntwork-io/terragrunt/cli/commands/find"
 	"github.com/gruntwork-io/terragrunt/cli/commands/hcl"
@@ -31,6 +34,7 @@ import (
 	outputmodulegroups "github.com/gruntwork-io/terragrunt/cli/commands/output-module-groups"
 	"github.com/gruntwork-io/terragrunt/cli/commands/render"
 	runCmd "github.com/gruntwork-io/terragrunt/cli/commands/run"
+	runsCmd "github.com/gruntwork-io/terragrunt/cli/commands/runs"
 	"github.com/gruntwork-io/terragrunt/cli/commands/scaffold"
 	"github.com/gruntwork-io/terragrunt/cli/commands/stack"
 	versionCmd "github.com/gruntwork-io/terragrunt/cli/commands/version"
@@ -65,6 +69,7 @@ func New(l log.Logger, opts *options.TerragruntOptions) cli.Commands {
 		stack.NewCommand(l, opts),   // stack
 		execCmd.NewCommand(l, opts), // exec
 		backend.NewCommand(l, opts), // backend
+		runsCmd.NewCommand(l, opts), // runs
 	}.SetCategory(
 		&cli.Category{
 			Name:  MainCommandsCategoryName,
@@ -83,8 +88,9 @@ func New(l log.Logger, opts *options.TerragruntOptions) cli.Commands {
 	)
 
 	discoveryCommands := cli.Commands{
-		find.NewCommand(l, opts), // find
-		list.NewCommand(l, opts), // list
+		find.NewCommand(l, opts),       // find
+		list.NewCommand(l, opts),       // list
+		dependents.NewCommand(l, opts), // dependents
 	}.SetCategory(
 		&cli.Category{
 			Name:  DiscoveryCommandsCategoryName,
@@ -97,6 +103,7 @@ func New(l log.Logger, opts *options.TerragruntOptions) cli.Commands {
 		info.NewCommand(l, opts),               // info
 		dag.NewCommand(l, opts),                // dag
 		render.NewCommand(l, opts),             // render
+		encrypt.NewCommand(l, opts),            // encrypt
 		helpCmd.NewCommand(l, opts),            // help (hidden)
 		versionCmd.NewCommand(opts),            // version (hidden)
 		awsproviderpatch.NewCommand(l, opts),   // aws-provider-patch (hidden)
@@ -345,6 +352,29 @@ func initialSetup(cliCtx *cli.Context, l log.Logger, opts *options.TerragruntOpt
 
 	opts.DownloadDir = filepath.ToSlash(downloadDir)
 
+	// --- Runs Dir
+	if opts.RunsDir == "" {
+		opts.RunsDir = util.JoinPath(opts.DownloadDir, runs.DefaultDirName)
+	}
+
+	runsDir, err := filepath.Abs(opts.RunsDir)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	opts.RunsDir = filepath.ToSlash(runsDir)
+
+	// --- Run Bundle
+	if opts.RecordBundle != "" {
+		if !filepath.IsAbs(opts.RecordBundle) {
+			opts.RecordBundle = util.JoinPath(opts.WorkingDir, opts.RecordBundle)
+		}
+
+		if opts.RunBundle, err = runs.NewRecorder(opts.RecordBundle); err != nil {
+			return err
+		}
+	}
+
 	// --- Terragrunt ConfigPath
 	if opts.TerragruntConfigPath == "" {
 		opts.TerragruntConfigPath = config.GetDefaultConfigPath(opts.WorkingDir)
@@ -394,6 +424,33 @@ func initialSetup(cliCtx *cli.Context, l log.Logger, opts *options.TerragruntOpt
 		return err
 	}
 
+	stackDirs := []string{}
+
+	for _, stackDir := range opts.StackDirs {
+		matches, err := util.GlobCanonicalPath(opts.WorkingDir, stackDir)
+		if err != nil {
+			return err
+		}
+
+		matches = slices.DeleteFunc(matches, func(match string) bool { return !util.IsDir(match) })
+		if len(matches) == 0 {
+			return errors.Errorf("the --stack %s matches no directory", stackDir)
+		}
+
+		stackDirs = append(stackDirs, matches...)
+	}
+
+	opts.StackDirs = stackDirs
+
+	if len(opts.QueueIncludeUnits) == 0 && (opts.QueueIncludeDependencies != 0 || opts.QueueIncludeDependents != 0) {
+		return errors.Errorf("the --%s and --%s flags can only be used with --%s", runCmd.QueueIncludeDependenciesFlagName, runCmd.QueueIncludeDependentsFlagName, runCmd.QueueIncludeUnitFlagName)
+	}
+
+	opts.QueueIncludeUnits, err = util.GlobCanonicalPath(opts.WorkingDir, opts.QueueIncludeUnits...)
+	if err != nil {
+		return err
+	}
+
 	excludeDirs, err := util.GetExcludeDirsFromFile(opts.WorkingDir, opts.ExcludesFile)
 	if err != nil {
 		return err
@@ -401,6 +458,35 @@ func initialSetup(cliCtx *cli.Context, l log.Logger, opts *options.TerragruntOpt
 
 	opts.ExcludeDirs = append(opts.ExcludeDirs, excludeDirs...)
 
+	if opts.IncludeExternalDependenciesFile != "" {
+		includeExternalFile, err := util.CanonicalPath(opts.IncludeExternalDependenciesFile, opts.WorkingDir)
+		if err != nil {
+			return err
+		}
+
+		if !util.IsFile(includeExternalFile) {
+			return errors.Errorf("the --queue-include-external-file %s does not exist", includeExternalFile)
+		}
+
+		if opts.IncludeExternalDirs, err = util.GetExcludeDirsFromFile(opts.WorkingDir, includeExternalFile); err != nil {
+			return err
+		}
+	}
+
+	if opts.DependencyOutputCacheKMSKeyID != "" && len(opts.DependencyOutputCacheAgeRecipients) > 0 {
+		return errors.Errorf("only one of --%s or --%s can be set", runCmd.DependencyOutputCacheKMSKeyIDFlagName, runCmd.DependencyOutputCacheAgeRecipientFlagName)
+	}
+
+	if opts.DependencyPolicyFile != "" {
+		if opts.DependencyPolicyFile, err = util.CanonicalPath(opts.DependencyPolicyFile, opts.WorkingDir); err != nil {
+			return err
+		}
+
+		if !util.IsFile(opts.DependencyPolicyFile) {
+			return errors.Errorf("the --%s %s does not exist", runCmd.DependencyPolicyFlagName, opts.DependencyPolicyFile)
+		}
+	}
+
 	// --- Terragrunt Version
 	terragruntVersion, err := version.NewVersion(cliCtx.App.Version)
 	if err != nil {
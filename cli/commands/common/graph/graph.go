@@ -76,6 +76,14 @@ func Run(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) err
 			defer r.WriteToFile(opts.ReportFile) //nolint:errcheck
 		}
 
+		if opts.ReportHTMLFile != "" {
+			defer r.WriteHTMLToFile(opts.ReportHTMLFile) //nolint:errcheck
+		}
+
+		if opts.ReportJUnitFile != "" {
+			defer r.WriteJUnitToFile(opts.ReportJUnitFile) //nolint:errcheck
+		}
+
 		if !opts.SummaryDisable {
 			defer r.WriteSummary(opts.Writer) //nolint:errcheck
 		}
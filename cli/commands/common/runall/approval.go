@@ -0,0 +1,61 @@
+package runall
+
+import (
+	"context"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/approval"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/tf"
+)
+
+// awaitApproval submits the summary of run --all apply to the approval gate of --approval-gate, if any, and waits for
+// the run to be approved, returning an error if it is rejected or isn't approved within --approval-gate-timeout.
+func awaitApproval(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, units common.Units) error {
+	if opts.ApprovalGate == "" || opts.TerraformCommand != tf.CommandNameApply {
+		return nil
+	}
+
+	gate, err := approval.NewGate(opts.ApprovalGate, opts.ApprovalGateToken, opts.Env)
+	if err != nil {
+		return err
+	}
+
+	summary := &approval.Summary{
+		Command:    opts.TerraformCommand,
+		WorkingDir: opts.WorkingDir,
+		Groups:     approvalGroups(opts, units),
+	}
+
+	timeout := time.Duration(opts.ApprovalGateTimeout) * time.Second
+
+	l.Infof("Waiting for the approval of the run by the approval gate %s", opts.ApprovalGate)
+
+	token, err := approval.Await(ctx, gate, summary, timeout)
+	if err != nil {
+		return err
+	}
+
+	l.Infof("The run was approved by the approval gate, with the approval token %s", token)
+
+	return nil
+}
+
+// approvalGroups returns the paths of the units that run, relative to the working dir, in the groups of the run queue.
+func approvalGroups(opts *options.TerragruntOptions, units common.Units) [][]string {
+	groups := [][]string{}
+
+	for _, group := range queueGroups(opts, units) {
+		paths := make([]string, 0, len(group))
+
+		for _, unit := range group {
+			paths = append(paths, relPath(opts, unit.Path))
+		}
+
+		groups = append(groups, paths)
+	}
+
+	return groups
+}
@@ -0,0 +1,72 @@
+package runall
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/internal/approval"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+)
+
+func TestApprovalGroups(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(rootDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	opts.WorkingDir = rootDir
+
+	external := &common.Unit{Path: filepath.Join(rootDir, "external"), AssumeAlreadyApplied: true}
+	vpc := &common.Unit{Path: filepath.Join(rootDir, "vpc"), Dependencies: common.Units{external}}
+	db := &common.Unit{Path: filepath.Join(rootDir, "db"), Dependencies: common.Units{vpc}}
+	app := &common.Unit{Path: filepath.Join(rootDir, "app"), Dependencies: common.Units{vpc, db}}
+	dns := &common.Unit{Path: filepath.Join(rootDir, "dns"), Dependencies: common.Units{vpc}}
+	excluded := &common.Unit{Path: filepath.Join(rootDir, "excluded"), FlagExcluded: true}
+
+	groups := approvalGroups(opts, common.Units{app, db, dns, excluded, external, vpc})
+	assert.Equal(t, [][]string{{"vpc"}, {"db", "dns"}, {"app"}}, groups)
+}
+
+func TestAwaitApproval(t *testing.T) {
+	t.Parallel()
+
+	var status string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(status))
+	}))
+	defer server.Close()
+
+	rootDir := t.TempDir()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(rootDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	opts.WorkingDir = rootDir
+	opts.TerraformCommand = "apply"
+	opts.ApprovalGate = server.URL
+
+	units := common.Units{{Path: filepath.Join(rootDir, "vpc")}}
+
+	status = `{"status": "approved", "token": "CHG0042"}`
+	require.NoError(t, awaitApproval(t.Context(), logger.CreateLogger(), opts, units))
+
+	status = `{"status": "rejected"}`
+	err = awaitApproval(t.Context(), logger.CreateLogger(), opts, units)
+
+	var rejectedErr approval.RejectedError
+	require.ErrorAs(t, err, &rejectedErr)
+
+	// Only apply is gated.
+	opts.TerraformCommand = "plan"
+	require.NoError(t, awaitApproval(t.Context(), logger.CreateLogger(), opts, units))
+}
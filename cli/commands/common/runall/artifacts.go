@@ -0,0 +1,219 @@
+package runall
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/tf"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// artifactsManifestFileName is the name of the file in the out dir of run --all plan, listing the plans saved by the
+// run, so they can be applied with --from-artifacts.
+const artifactsManifestFileName = "manifest.json"
+
+// artifactsManifest is the metadata of the plans of the units saved by run --all plan with --out-dir.
+type artifactsManifest struct {
+	Created time.Time `json:"created"`
+	// Units are the units whose plan was saved, by path relative to the working dir.
+	Units   map[string]*artifactsUnit `json:"units"`
+	Command string                    `json:"command"`
+	Args    []string                  `json:"args"`
+}
+
+type artifactsUnit struct {
+	// Plan is the path of the plan file, relative to the out dir.
+	Plan string `json:"plan"`
+	// Hash is the hash of the content of the unit of the config of the unit when it was planned, see `common.Unit.ConfigHash`.
+	Hash string `json:"hash"`
+}
+
+// trackArtifacts returns a function writing the manifest of the plans saved by run --all plan with --out-dir, once the
+// run finished, or nil if the run doesn't save plans. The hashes of the units are computed before the run, so the
+// manifest records the configs of the units that were planned.
+func trackArtifacts(opts *options.TerragruntOptions, units common.Units) (func(l log.Logger) error, error) {
+	if opts.TerraformCommand != tf.CommandNamePlan || opts.OutputFolder == "" {
+		return nil, nil
+	}
+
+	manifest := &artifactsManifest{
+		Created: time.Now().UTC(),
+		Units:   map[string]*artifactsUnit{},
+		Command: opts.TerraformCommand,
+		Args:    slices.Clone(opts.TerraformCliArgs),
+	}
+
+	hashes := map[*common.Unit]string{}
+
+	for _, unit := range units {
+		if unit.FlagExcluded || unit.AssumeAlreadyApplied {
+			continue
+		}
+
+		hash, err := unit.ConfigHash()
+		if err != nil {
+			return nil, err
+		}
+
+		hashes[unit] = hash
+	}
+
+	outDir := artifactsDir(opts, opts.OutputFolder)
+
+	return func(l log.Logger) error {
+		for unit, hash := range hashes {
+			planFile := unit.OutputFile(l, opts)
+
+			// A plan file older than the run was left by a previous run, the plan of the unit having failed.
+			if !writtenSince(planFile, manifest.Created) {
+				continue
+			}
+
+			path, err := filepath.Rel(opts.WorkingDir, unit.Path)
+			if err != nil {
+				return errors.New(err)
+			}
+
+			plan, err := filepath.Rel(outDir, planFile)
+			if err != nil {
+				return errors.New(err)
+			}
+
+			manifest.Units[filepath.ToSlash(path)] = &artifactsUnit{Plan: filepath.ToSlash(plan), Hash: hash}
+		}
+
+		data, err := json.MarshalIndent(manifest, "", "  ")
+		if err != nil {
+			return errors.New(err)
+		}
+
+		if err := os.MkdirAll(outDir, os.ModePerm); err != nil {
+			return errors.New(err)
+		}
+
+		if err := os.WriteFile(filepath.Join(outDir, artifactsManifestFileName), data, 0644); err != nil { //nolint:mnd
+			return errors.New(err)
+		}
+
+		l.Infof("Saved the plans of %d units to %s", len(manifest.Units), outDir)
+
+		return nil
+	}, nil
+}
+
+// applyArtifacts prepares run --all apply with --from-artifacts to apply the plans saved by run --all plan in the
+// given dir: the units without a saved plan are skipped, and if any unit changed since it was planned, the run is
+// refused, as its plan may no longer match its config.
+func applyArtifacts(l log.Logger, opts *options.TerragruntOptions, units common.Units) error {
+	if opts.TerraformCommand != tf.CommandNameApply {
+		return errors.New(FromArtifactsUnsupportedCommandErr{command: opts.TerraformCommand})
+	}
+
+	dir := artifactsDir(opts, opts.FromArtifacts)
+
+	manifest, err := readArtifactsManifest(filepath.Join(dir, artifactsManifestFileName))
+	if err != nil {
+		return err
+	}
+
+	changed := []string{}
+	planned := map[string]bool{}
+
+	for _, unit := range units {
+		if unit.FlagExcluded || unit.AssumeAlreadyApplied {
+			continue
+		}
+
+		rel, err := filepath.Rel(opts.WorkingDir, unit.Path)
+		if err != nil {
+			return errors.New(err)
+		}
+
+		path := filepath.ToSlash(rel)
+
+		artifact, ok := manifest.Units[path]
+		if !ok {
+			l.Debugf("Skipping unit %s, which has no saved plan in %s", path, dir)
+
+			unit.AssumeAlreadyApplied = true
+
+			continue
+		}
+
+		planned[path] = true
+
+		hash, err := unit.ConfigHash()
+		if err != nil {
+			return err
+		}
+
+		if hash != artifact.Hash {
+			changed = append(changed, path)
+		}
+
+		if !util.FileExists(filepath.Join(dir, filepath.FromSlash(artifact.Plan))) {
+			return errors.Errorf("the plan of unit %s is missing from %s", path, dir)
+		}
+	}
+
+	if len(changed) > 0 {
+		sort.Strings(changed)
+
+		return errors.New(ArtifactsChangedErr{dir: dir, units: changed})
+	}
+
+	for path := range manifest.Units {
+		if !planned[path] {
+			l.Warnf("Unit %s has a saved plan in %s but isn't in the run queue, it won't be applied", path, dir)
+		}
+	}
+
+	// The plans are applied from where they were saved, see `common.Unit.PlanFile`.
+	opts.OutputFolder = dir
+
+	return nil
+}
+
+// artifactsDir returns the absolute path of the given artifacts dir, relative to the working dir.
+func artifactsDir(opts *options.TerragruntOptions, dir string) string {
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(opts.WorkingDir, dir)
+	}
+
+	return filepath.Clean(dir)
+}
+
+// readArtifactsManifest reads the artifacts manifest at the given path.
+func readArtifactsManifest(path string) (*artifactsManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.Errorf("no saved plans in %s, run `terragrunt run --all plan --out-dir %s` first", filepath.Dir(path), filepath.Dir(path))
+		}
+
+		return nil, errors.New(err)
+	}
+
+	manifest := &artifactsManifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, errors.Errorf("invalid artifacts manifest %s: %w", path, err)
+	}
+
+	return manifest, nil
+}
+
+// writtenSince returns true if the given file exists and was written since the given time, e.g. by the current run,
+// rather than left by a previous one. File systems may only record the modification time of files to the second.
+func writtenSince(file string, since time.Time) bool {
+	info, err := os.Stat(file)
+
+	return err == nil && !info.ModTime().Before(since.Truncate(time.Second))
+}
@@ -0,0 +1,110 @@
+package runall
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+)
+
+func TestPlanArtifacts(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	outDir := filepath.Join(t.TempDir(), "plans")
+
+	for _, name := range []string{"vpc", "app", "db"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(rootDir, name), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(rootDir, name, "terragrunt.hcl"), []byte(""), 0644))
+	}
+
+	l := logger.CreateLogger()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(rootDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	opts.WorkingDir = rootDir
+
+	newUnits := func() common.Units {
+		vpc := &common.Unit{Path: filepath.Join(rootDir, "vpc")}
+		app := &common.Unit{Path: filepath.Join(rootDir, "app"), Dependencies: common.Units{vpc}}
+		db := &common.Unit{Path: filepath.Join(rootDir, "db")}
+		units := common.Units{vpc, app, db}
+
+		for _, unit := range units {
+			unit.TerragruntOptions = opts.Clone()
+			unit.TerragruntOptions.TerragruntConfigPath = filepath.Join(unit.Path, "terragrunt.hcl")
+		}
+
+		return units
+	}
+
+	planOpts := opts.Clone()
+	planOpts.TerraformCommand = "plan"
+	planOpts.OutputFolder = outDir
+
+	units := newUnits()
+
+	saveArtifacts, err := trackArtifacts(planOpts, units)
+	require.NoError(t, err)
+
+	// The plan of db fails, so only vpc and app have a plan.
+	for _, unit := range units[:2] {
+		planFile := unit.OutputFile(l, planOpts)
+		require.NoError(t, os.MkdirAll(filepath.Dir(planFile), 0755))
+		require.NoError(t, os.WriteFile(planFile, []byte("plan"), 0644))
+
+		// The files generated into the unit by its plan don't change it.
+		require.NoError(t, os.WriteFile(filepath.Join(unit.Path, "backend.tf"), []byte("terraform {}"), 0644))
+	}
+
+	require.NoError(t, saveArtifacts(l))
+
+	manifest, err := readArtifactsManifest(filepath.Join(outDir, artifactsManifestFileName))
+	require.NoError(t, err)
+	assert.Equal(t, "plan", manifest.Command)
+	assert.Equal(t, "vpc/tfplan.tfplan", manifest.Units["vpc"].Plan)
+	assert.Equal(t, "app/tfplan.tfplan", manifest.Units["app"].Plan)
+	assert.NotContains(t, manifest.Units, "db")
+
+	applyOpts := opts.Clone()
+	applyOpts.TerraformCommand = "apply"
+	applyOpts.FromArtifacts = outDir
+
+	units = newUnits()
+
+	require.NoError(t, applyArtifacts(l, applyOpts, units))
+	assert.Equal(t, outDir, applyOpts.OutputFolder)
+	assert.False(t, units[0].AssumeAlreadyApplied)
+	assert.False(t, units[1].AssumeAlreadyApplied)
+	assert.True(t, units[2].AssumeAlreadyApplied, "db has no plan to apply")
+
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "app", "terragrunt.hcl"), []byte("# changed"), 0644))
+
+	applyOpts = opts.Clone()
+	applyOpts.TerraformCommand = "apply"
+	applyOpts.FromArtifacts = outDir
+
+	err = applyArtifacts(l, applyOpts, newUnits())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "these units changed since they were planned: app")
+
+	applyOpts.TerraformCommand = "destroy"
+
+	err = applyArtifacts(l, applyOpts, newUnits())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only apply can apply saved plans")
+
+	applyOpts.TerraformCommand = "apply"
+	applyOpts.FromArtifacts = t.TempDir()
+
+	err = applyArtifacts(l, applyOpts, newUnits())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no saved plans in")
+}
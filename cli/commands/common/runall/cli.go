@@ -15,8 +15,42 @@ import (
 )
 
 const (
-	AllFlagName  = "all"
-	AllFlagAlias = "a"
+	AllFlagName         = "all"
+	StackFlagName       = "stack"
+	AllFlagAlias        = "a"
+	WatchFlagName       = "watch"
+	ResumeFlagName      = "resume"
+	RetryFailedFlagName = "retry-failed"
+
+	SkipUnchangedFlagName = "skip-unchanged"
+
+	FromArtifactsFlagName = "from-artifacts"
+
+	DetailedExitCodeFlagName       = "detailed-exit-code"
+	DetailedExitCodePolicyFlagName = "detailed-exit-code-policy"
+
+	PreventDestroyPolicyFlagName = "prevent-destroy-policy"
+
+	SpeculativePlanFlagName = "speculative-plan"
+
+	PlanSummaryFlagName     = "plan-summary"
+	PlanSummaryFileFlagName = "plan-summary-file"
+
+	CostEstimateFlagName       = "cost-estimate"
+	CostEstimateBudgetFlagName = "cost-estimate-budget"
+	InfracostPathFlagName      = "infracost-path"
+
+	DriftCheckFlagName      = "drift-check"
+	DriftReportFileFlagName = "drift-report-file"
+	DriftWebhookFlagName    = "drift-webhook"
+
+	QueueDryRunFlagName       = "queue-dry-run"
+	QueueDryRunFormatFlagName = "queue-dry-run-format"
+
+	UnitLogFileFlagName              = "unit-log-file"
+	UnitLogFileLevelFlagName         = "unit-log-file-level"
+	UnitLogPrefixFlagName            = "unit-log-prefix"
+	UnitLogSuppressNoChangesFlagName = "unit-log-suppress-no-changes"
 )
 
 func NewFlags(opts *options.TerragruntOptions, commandName string, prefix flags.Prefix) cli.Flags {
@@ -37,6 +71,295 @@ func NewFlags(opts *options.TerragruntOptions, commandName string, prefix flags.
 				return nil
 			},
 		}),
+
+		flags.NewFlag(&cli.SliceFlag[string]{
+			Name:        StackFlagName,
+			EnvVars:     tgPrefix.EnvVars(StackFlagName),
+			Destination: &opts.StackDirs,
+			Usage:       `Run the specified command on the units of the stacks in the given dirs, rather than in the current directory, together, in the order of the dependencies between the units of all the stacks.`,
+		}),
+	}
+}
+
+// NewWatchFlags returns the flags of the watch mode of run --all, only supported by the `run` command.
+func NewWatchFlags(opts *options.TerragruntOptions, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	return cli.Flags{
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        WatchFlagName,
+			EnvVars:     tgPrefix.EnvVars(WatchFlagName),
+			Destination: &opts.Watch,
+			Usage:       `Keep running after run --all, and run the command again on the changed units and their dependents whenever their files change.`,
+		}),
+	}
+}
+
+// NewResumeFlags returns the flags resuming an interrupted or failed run --all, and skipping its unchanged units, only
+// supported by the `run` command.
+func NewResumeFlags(opts *options.TerragruntOptions, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	return cli.Flags{
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        ResumeFlagName,
+			EnvVars:     tgPrefix.EnvVars(ResumeFlagName),
+			Destination: &opts.Resume,
+			Usage:       `Resume the last run --all of the same command, skipping the units that succeeded in it and haven't changed since.`,
+		}),
+
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        RetryFailedFlagName,
+			EnvVars:     tgPrefix.EnvVars(RetryFailedFlagName),
+			Destination: &opts.RetryFailed,
+			Usage:       `Run only the units that failed, or exited early, in the last run --all written to the --report-file.`,
+		}),
+
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        SkipUnchangedFlagName,
+			EnvVars:     tgPrefix.EnvVars(SkipUnchangedFlagName),
+			Destination: &opts.SkipUnchanged,
+			Usage:       `Skip the units of run --all plan and apply whose inputs, generated files, source and OpenTofu/Terraform and provider versions, and those of their dependencies, didn't change since their last successful apply.`,
+		}),
+	}
+}
+
+// NewArtifactsFlags returns the flags applying the plans saved by run --all plan, only supported by the `run` command.
+func NewArtifactsFlags(opts *options.TerragruntOptions, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	return cli.Flags{
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        FromArtifactsFlagName,
+			EnvVars:     tgPrefix.EnvVars(FromArtifactsFlagName),
+			Destination: &opts.FromArtifacts,
+			Usage:       `Apply the plans saved by run --all plan with --out-dir in the given dir, refusing to run if units changed since they were planned.`,
+		}),
+	}
+}
+
+// NewDetailedExitCodeFlags returns the flags setting the exit code of run --all plan from the outcomes of the units,
+// only supported by the `run` command.
+func NewDetailedExitCodeFlags(opts *options.TerragruntOptions, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	return cli.Flags{
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        DetailedExitCodeFlagName,
+			EnvVars:     tgPrefix.EnvVars(DetailedExitCodeFlagName),
+			Destination: &opts.DetailedExitCode,
+			Usage:       `Exit run --all plan with 0 if no unit has changes, with 2 if some units have changes, and with 1 if some units failed.`,
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        DetailedExitCodePolicyFlagName,
+			EnvVars:     tgPrefix.EnvVars(DetailedExitCodePolicyFlagName),
+			Destination: &opts.DetailedExitCodePolicy,
+			Usage:       `Exit code of --detailed-exit-code when some units have changes and others failed: 1 with all-clean, the default, or 2 with any-changes.`,
+			Action: func(_ *cli.Context, value string) error {
+				if value != DetailedExitCodePolicyAllClean && value != DetailedExitCodePolicyAnyChanges {
+					return errors.New(DetailedExitCodeInvalidPolicyErr{policy: value})
+				}
+
+				return nil
+			},
+		}),
+	}
+}
+
+// NewPreventDestroyFlags returns the flags protecting the units of run --all destroy that set prevent_destroy, only
+// supported by the `run` command.
+func NewPreventDestroyFlags(opts *options.TerragruntOptions, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	return cli.Flags{
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        PreventDestroyPolicyFlagName,
+			EnvVars:     tgPrefix.EnvVars(PreventDestroyPolicyFlagName),
+			Destination: &opts.PreventDestroyPolicy,
+			Usage:       `What run --all destroy does with the units that set prevent_destroy before destroying anything: fail, or skip them along with the units they depend on.`,
+			Action: func(_ *cli.Context, value string) error {
+				if value != PreventDestroyPolicyFail && value != PreventDestroyPolicySkip {
+					return errors.New(PreventDestroyInvalidPolicyErr{policy: value})
+				}
+
+				return nil
+			},
+		}),
+	}
+}
+
+// NewSpeculativePlanFlags returns the flags planning the units of run --all plan with the planned outputs of their
+// dependencies, only supported by the `run` command.
+func NewSpeculativePlanFlags(opts *options.TerragruntOptions, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	return cli.Flags{
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        SpeculativePlanFlagName,
+			EnvVars:     tgPrefix.EnvVars(SpeculativePlanFlagName),
+			Destination: &opts.SpeculativePlan,
+			Usage:       `Plan the units of run --all plan with the outputs their dependencies plan to have, rather than with their current outputs.`,
+		}),
+	}
+}
+
+// NewPlanSummaryFlags returns the flags summarizing the changes planned by run --all plan, only supported by the `run`
+// command.
+func NewPlanSummaryFlags(opts *options.TerragruntOptions, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	return cli.Flags{
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        PlanSummaryFlagName,
+			EnvVars:     tgPrefix.EnvVars(PlanSummaryFlagName),
+			Destination: &opts.PlanSummary,
+			Usage:       `Output the units with changes, and the total numbers of resources to add, change and destroy, once run --all plan finished.`,
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        PlanSummaryFileFlagName,
+			EnvVars:     tgPrefix.EnvVars(PlanSummaryFileFlagName),
+			Destination: &opts.PlanSummaryFile,
+			Usage:       `Write the changes planned by each unit of run --all plan to the given JSON file, with the changed resources of the plans saved with --json-out-dir.`,
+		}),
+	}
+}
+
+// NewCostEstimateFlags returns the flags estimating the costs of the plans of run --all plan with Infracost, only
+// supported by the `run` command.
+func NewCostEstimateFlags(opts *options.TerragruntOptions, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	return cli.Flags{
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        CostEstimateFlagName,
+			EnvVars:     tgPrefix.EnvVars(CostEstimateFlagName),
+			Destination: &opts.CostEstimate,
+			Usage:       `Estimate the monthly cost of the plan of each unit of run --all plan with Infracost, from the JSON plans saved with --json-out-dir, and add the estimates to the run report.`,
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        CostEstimateBudgetFlagName,
+			EnvVars:     tgPrefix.EnvVars(CostEstimateBudgetFlagName),
+			Destination: &opts.CostEstimateBudget,
+			Usage:       `Fail run --all plan --cost-estimate when the plans of the units increase the total monthly cost by more than the given amount, e.g. 500.`,
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        InfracostPathFlagName,
+			EnvVars:     tgPrefix.EnvVars(InfracostPathFlagName),
+			Destination: &opts.InfracostPath,
+			Usage:       `Path to the Infracost binary run by --cost-estimate. Default is infracost (on PATH).`,
+		}),
+	}
+}
+
+// NewDriftCheckFlags returns the flags detecting the drift of the units of run --all plan, only supported by the `run`
+// command.
+func NewDriftCheckFlags(opts *options.TerragruntOptions, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	return cli.Flags{
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        DriftCheckFlagName,
+			EnvVars:     tgPrefix.EnvVars(DriftCheckFlagName),
+			Destination: &opts.DriftCheck,
+			Usage:       `Plan the units of run --all plan with -refresh-only, output the units whose infrastructure drifted from their state, and exit with 2 if any of them drifted.`,
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        DriftReportFileFlagName,
+			EnvVars:     tgPrefix.EnvVars(DriftReportFileFlagName),
+			Destination: &opts.DriftReportFile,
+			Usage:       `Write the drift detected by run --all plan --drift-check in each unit to the given JSON file.`,
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        DriftWebhookFlagName,
+			EnvVars:     tgPrefix.EnvVars(DriftWebhookFlagName),
+			Destination: &opts.DriftWebhook,
+			Usage:       `Post the drift report of run --all plan --drift-check as JSON to the given URL when any unit drifted.`,
+		}),
+	}
+}
+
+// NewQueueDryRunFlags returns the flags outputting the execution plan of run --all, only supported by the `run`
+// command.
+func NewQueueDryRunFlags(opts *options.TerragruntOptions, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	return cli.Flags{
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        QueueDryRunFlagName,
+			EnvVars:     tgPrefix.EnvVars(QueueDryRunFlagName),
+			Destination: &opts.QueueDryRun,
+			Usage:       `Output the units run --all would run, in their groups, with the commands and the working dirs they would run in, without running anything.`,
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        QueueDryRunFormatFlagName,
+			EnvVars:     tgPrefix.EnvVars(QueueDryRunFormatFlagName),
+			Destination: &opts.QueueDryRunFormat,
+			Usage:       `Format of the output of --queue-dry-run. Valid values: table, json.`,
+			Action: func(_ *cli.Context, value string) error {
+				if value != QueueDryRunFormatTable && value != QueueDryRunFormatJSON {
+					return errors.New(QueueDryRunInvalidFormatErr{format: value})
+				}
+
+				return nil
+			},
+		}),
+	}
+}
+
+// NewUnitLogFlags returns the flags routing the logs of the units of run --all, only supported by the `run` command.
+func NewUnitLogFlags(opts *options.TerragruntOptions, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	return cli.Flags{
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        UnitLogFileFlagName,
+			EnvVars:     tgPrefix.EnvVars(UnitLogFileFlagName),
+			Destination: &opts.UnitLogFile,
+			Usage:       `Also write the logs of each unit to its own file, at the given path template, e.g. logs/{run_id}/{unit}.log. Placeholders: {unit}, {name}, {command}, {run_id}.`,
+			Action: func(_ *cli.Context, value string) error {
+				_, err := renderUnitLogTemplate(UnitLogFileFlagName, value, unitLogVars{})
+
+				return err
+			},
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        UnitLogFileLevelFlagName,
+			EnvVars:     tgPrefix.EnvVars(UnitLogFileLevelFlagName),
+			Destination: &opts.UnitLogFileLevel,
+			Usage:       `Log level of the files of --unit-log-file, the log level of the console by default.`,
+			Action: func(_ *cli.Context, value string) error {
+				_, err := log.ParseLevel(value)
+
+				return err
+			},
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        UnitLogPrefixFlagName,
+			EnvVars:     tgPrefix.EnvVars(UnitLogPrefixFlagName),
+			Destination: &opts.UnitLogPrefix,
+			Usage:       `Template of the prefix of the logs of each unit on the console, e.g. {name}. Placeholders: {unit}, {name}, {command}, {run_id}.`,
+			Action: func(_ *cli.Context, value string) error {
+				_, err := renderUnitLogTemplate(UnitLogPrefixFlagName, value, unitLogVars{})
+
+				return err
+			},
+		}),
+
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        UnitLogSuppressNoChangesFlagName,
+			EnvVars:     tgPrefix.EnvVars(UnitLogSuppressNoChangesFlagName),
+			Destination: &opts.UnitLogSuppressNoChanges,
+			Usage:       `Replace the OpenTofu/Terraform output of the units without changes by a single line on the console.`,
+		}),
 	}
 }
 
@@ -54,9 +377,65 @@ func WrapCommand(
 		}
 
 		if !opts.RunAll {
+			if len(opts.StackDirs) > 0 {
+				return errors.New(StackWithoutAllErr{})
+			}
+
+			if opts.Watch {
+				return errors.New(WatchWithoutAllErr{})
+			}
+
+			if opts.Resume {
+				return errors.New(ResumeWithoutAllErr{})
+			}
+
+			if opts.RetryFailed {
+				return errors.New(RetryFailedWithoutAllErr{})
+			}
+
+			if opts.SkipUnchanged {
+				return errors.New(SkipUnchangedWithoutAllErr{})
+			}
+
+			if opts.FromArtifacts != "" {
+				return errors.New(FromArtifactsWithoutAllErr{})
+			}
+
+			if opts.QueueDryRun {
+				return errors.New(QueueDryRunWithoutAllErr{})
+			}
+
+			if opts.DetailedExitCode {
+				return errors.New(DetailedExitCodeWithoutAllErr{})
+			}
+
+			if opts.SpeculativePlan {
+				return errors.New(SpeculativePlanWithoutAllErr{})
+			}
+
+			if opts.PlanSummary || opts.PlanSummaryFile != "" {
+				return errors.New(PlanSummaryWithoutAllErr{})
+			}
+
+			if opts.CostEstimate || opts.CostEstimateBudget != "" {
+				return errors.New(CostEstimateWithoutAllErr{})
+			}
+
+			if opts.DriftCheck || opts.DriftReportFile != "" || opts.DriftWebhook != "" {
+				return errors.New(DriftCheckWithoutAllErr{})
+			}
+
+			if opts.UnitLogFile != "" || opts.UnitLogPrefix != "" || opts.UnitLogSuppressNoChanges {
+				return errors.New(UnitLogWithoutAllErr{})
+			}
+
 			return action(cliCtx)
 		}
 
+		if opts.RetryFailed && opts.ReportFile == "" {
+			return errors.New(RetryFailedWithoutReportFileErr{})
+		}
+
 		opts.RunTerragrunt = func(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, r *report.Report) error {
 			if opts.TerraformCommand == cmd.Name {
 				cliCtx := cliCtx.WithValue(options.ContextKey, opts)
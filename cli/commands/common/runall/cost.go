@@ -0,0 +1,219 @@
+package runall
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/report"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/shell"
+	"github.com/gruntwork-io/terragrunt/tf"
+)
+
+// DefaultInfracostPath is the Infracost binary run by --cost-estimate, unless --infracost-path is set.
+const DefaultInfracostPath = "infracost"
+
+// infracostBreakdown is the part of the JSON output of `infracost breakdown` read by --cost-estimate. The costs are
+// decimal strings, null when Infracost can't estimate them.
+type infracostBreakdown struct {
+	Currency             string  `json:"currency"`
+	TotalMonthlyCost     *string `json:"totalMonthlyCost"`
+	PastTotalMonthlyCost *string `json:"pastTotalMonthlyCost"`
+	DiffTotalMonthlyCost *string `json:"diffTotalMonthlyCost"`
+}
+
+// trackCostEstimate records the units of run --all plan whose plan succeeded, and returns a function estimating the
+// monthly costs of their JSON plans with Infracost once the run finished, or nil if --cost-estimate isn't set. The
+// estimates are added to the runs of the units in the report, and the function returns an error if the total increase
+// of the monthly cost exceeds --cost-estimate-budget.
+func trackCostEstimate(opts *options.TerragruntOptions, units common.Units) (func(ctx context.Context, l log.Logger) error, error) {
+	if !opts.CostEstimate {
+		if opts.CostEstimateBudget != "" {
+			return nil, errors.New(CostEstimateBudgetWithoutCostEstimateErr{})
+		}
+
+		return nil, nil
+	}
+
+	if opts.TerraformCommand != tf.CommandNamePlan {
+		return nil, errors.New(CostEstimateUnsupportedCommandErr{command: opts.TerraformCommand})
+	}
+
+	// Infracost estimates the costs of the JSON plans, only saved with --json-out-dir.
+	if opts.JSONOutputFolder == "" {
+		return nil, errors.New(CostEstimateWithoutJSONOutDirErr{})
+	}
+
+	budget, hasBudget, err := costEstimateBudget(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		mu      sync.Mutex
+		planned = common.Units{}
+		// r is the report of the run, passed to the runs of the units, if the report experiment is enabled.
+		r *report.Report
+	)
+
+	for _, unit := range units {
+		if unit.FlagExcluded || unit.AssumeAlreadyApplied {
+			continue
+		}
+
+		wrapUnitRun(unit, func(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, unitReport *report.Report, next runTerragruntFunc) error {
+			err := next(ctx, l, opts, unitReport)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			r = unitReport
+
+			if err == nil {
+				planned = append(planned, unit)
+			}
+
+			return err
+		})
+	}
+
+	started := time.Now()
+
+	return func(ctx context.Context, l log.Logger) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		slices.SortFunc(planned, func(a, b *common.Unit) int {
+			return strings.Compare(a.Path, b.Path)
+		})
+
+		var (
+			total   = &report.CostEstimate{}
+			missing = []string{}
+		)
+
+		for _, unit := range planned {
+			name := relPath(opts, unit.Path)
+
+			// A JSON plan older than the run was left by a previous run, e.g. as saving the JSON plan failed.
+			jsonFile := unit.OutputJSONFile(l, opts)
+			if !writtenSince(jsonFile, started) {
+				l.Warnf("Failed to estimate the cost of unit %s, its JSON plan %s wasn't saved by the run", name, jsonFile)
+
+				missing = append(missing, name)
+
+				continue
+			}
+
+			cost, err := estimateCost(ctx, l, opts, unit, jsonFile)
+			if err != nil {
+				l.Warnf("Failed to estimate the cost of unit %s: %v", name, err)
+
+				missing = append(missing, name)
+
+				continue
+			}
+
+			l.Debugf("Estimated the monthly cost of unit %s at %.2f %s, %+.2f %s", name, cost.MonthlyCost, cost.Currency, cost.DiffMonthlyCost, cost.Currency)
+
+			if r != nil {
+				if run, err := r.GetRun(unit.Path); err == nil {
+					run.SetCost(cost)
+				}
+			}
+
+			total.Currency = cost.Currency
+			total.MonthlyCost += cost.MonthlyCost
+			total.PastMonthlyCost += cost.PastMonthlyCost
+			total.DiffMonthlyCost += cost.DiffMonthlyCost
+		}
+
+		l.Infof("Estimated the monthly cost of %d units at %.2f %s, %+.2f %s", len(planned)-len(missing), total.MonthlyCost, total.Currency, total.DiffMonthlyCost, total.Currency)
+
+		if !hasBudget {
+			return nil
+		}
+
+		// The budget can't be verified without the costs of all the units.
+		if len(missing) > 0 {
+			return errors.New(CostEstimateIncompleteErr{units: missing})
+		}
+
+		if total.DiffMonthlyCost > budget {
+			return errors.New(CostBudgetExceededErr{increase: total.DiffMonthlyCost, budget: budget, currency: total.Currency})
+		}
+
+		return nil
+	}, nil
+}
+
+// estimateCost runs `infracost breakdown` on the given JSON plan of the given unit.
+func estimateCost(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, unit *common.Unit, jsonFile string) (*report.CostEstimate, error) {
+	infracostPath := opts.InfracostPath
+	if infracostPath == "" {
+		infracostPath = DefaultInfracostPath
+	}
+
+	output, err := shell.RunCommandWithOutput(ctx, l, opts, unit.Path, true, false, infracostPath, "breakdown", "--path", jsonFile, "--format", "json", "--no-color")
+	if err != nil {
+		return nil, err
+	}
+
+	return parseInfracostBreakdown(output.Stdout.Bytes())
+}
+
+// parseInfracostBreakdown parses the monthly costs of the JSON output of `infracost breakdown`.
+func parseInfracostBreakdown(data []byte) (*report.CostEstimate, error) {
+	var breakdown infracostBreakdown
+
+	if err := json.Unmarshal(data, &breakdown); err != nil {
+		return nil, errors.Errorf("invalid output of infracost breakdown: %w", err)
+	}
+
+	cost := &report.CostEstimate{Currency: breakdown.Currency}
+
+	for _, field := range []struct {
+		value *string
+		dest  *float64
+	}{
+		{breakdown.TotalMonthlyCost, &cost.MonthlyCost},
+		{breakdown.PastTotalMonthlyCost, &cost.PastMonthlyCost},
+		{breakdown.DiffTotalMonthlyCost, &cost.DiffMonthlyCost},
+	} {
+		if field.value == nil || *field.value == "" {
+			continue
+		}
+
+		value, err := strconv.ParseFloat(*field.value, 64)
+		if err != nil {
+			return nil, errors.Errorf("invalid cost %q in the output of infracost breakdown: %w", *field.value, err)
+		}
+
+		*field.dest = value
+	}
+
+	return cost, nil
+}
+
+// costEstimateBudget returns the budget of the increase of the monthly cost of --cost-estimate-budget, and false if
+// there is none.
+func costEstimateBudget(opts *options.TerragruntOptions) (float64, bool, error) {
+	if opts.CostEstimateBudget == "" {
+		return 0, false, nil
+	}
+
+	budget, err := strconv.ParseFloat(opts.CostEstimateBudget, 64)
+	if err != nil || budget < 0 {
+		return 0, false, errors.New(CostEstimateInvalidBudgetErr{budget: opts.CostEstimateBudget})
+	}
+
+	return budget, true, nil
+}
@@ -0,0 +1,148 @@
+package runall
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/internal/report"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+)
+
+func TestParseInfracostBreakdown(t *testing.T) {
+	t.Parallel()
+
+	cost, err := parseInfracostBreakdown([]byte(`{"currency": "USD", "totalMonthlyCost": "150.5", "pastTotalMonthlyCost": "100", "diffTotalMonthlyCost": "50.5"}`))
+	require.NoError(t, err)
+	assert.Equal(t, &report.CostEstimate{Currency: "USD", MonthlyCost: 150.5, PastMonthlyCost: 100, DiffMonthlyCost: 50.5}, cost)
+
+	cost, err = parseInfracostBreakdown([]byte(`{"currency": "EUR", "totalMonthlyCost": null, "pastTotalMonthlyCost": null, "diffTotalMonthlyCost": null}`))
+	require.NoError(t, err)
+	assert.Equal(t, &report.CostEstimate{Currency: "EUR"}, cost)
+
+	_, err = parseInfracostBreakdown([]byte(`{"totalMonthlyCost": "lots"}`))
+	require.Error(t, err)
+}
+
+func TestCostEstimate(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake infracost is a shell script")
+	}
+
+	tests := []struct {
+		budget      string
+		expectedErr any
+	}{
+		{budget: ""},
+		{budget: "100"},
+		{budget: "20", expectedErr: &CostBudgetExceededErr{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.budget, func(t *testing.T) {
+			t.Parallel()
+
+			rootDir := t.TempDir()
+
+			// The fake infracost increases the cost of each unit by 25 USD.
+			infracost := filepath.Join(rootDir, "infracost")
+			script := "#!/bin/sh\necho '{\"currency\": \"USD\", \"totalMonthlyCost\": \"125\", \"pastTotalMonthlyCost\": \"100\", \"diffTotalMonthlyCost\": \"25\"}'\n"
+			require.NoError(t, os.WriteFile(infracost, []byte(script), 0755)) //nolint:gosec
+
+			l := logger.CreateLogger()
+
+			opts, err := options.NewTerragruntOptionsForTest(filepath.Join(rootDir, "terragrunt.hcl"))
+			require.NoError(t, err)
+
+			opts.WorkingDir = rootDir
+			opts.TerraformCommand = "plan"
+			opts.TerraformCliArgs = []string{"plan"}
+			opts.JSONOutputFolder = filepath.Join(rootDir, "json")
+			opts.CostEstimate = true
+			opts.CostEstimateBudget = tt.budget
+			opts.InfracostPath = infracost
+
+			r := report.NewReport().WithWorkingDir(rootDir)
+			units := common.Units{}
+
+			for _, name := range []string{"app", "db"} {
+				unit := &common.Unit{Path: filepath.Join(rootDir, name)}
+				require.NoError(t, os.MkdirAll(unit.Path, os.ModePerm))
+
+				unit.TerragruntOptions = opts.Clone()
+				unit.TerragruntOptions.RunTerragrunt = func(_ context.Context, l log.Logger, _ *options.TerragruntOptions, _ *report.Report) error {
+					jsonFile := unit.OutputJSONFile(l, opts)
+					require.NoError(t, os.MkdirAll(filepath.Dir(jsonFile), os.ModePerm))
+
+					return os.WriteFile(jsonFile, []byte("{}"), 0644) //nolint:gosec
+				}
+
+				units = append(units, unit)
+			}
+
+			estimateCosts, err := trackCostEstimate(opts, units)
+			require.NoError(t, err)
+
+			for _, unit := range units {
+				run, err := report.NewRun(unit.Path)
+				require.NoError(t, err)
+				require.NoError(t, r.AddRun(run))
+				require.NoError(t, unit.TerragruntOptions.RunTerragrunt(t.Context(), l, unit.TerragruntOptions, r))
+			}
+
+			err = estimateCosts(t.Context(), l)
+			if tt.expectedErr != nil {
+				require.ErrorAs(t, err, tt.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			for _, unit := range units {
+				run, err := r.GetRun(unit.Path)
+				require.NoError(t, err)
+				assert.Equal(t, &report.CostEstimate{Currency: "USD", MonthlyCost: 125, PastMonthlyCost: 100, DiffMonthlyCost: 25}, run.Cost)
+			}
+
+			assert.Equal(t, &report.CostEstimate{Currency: "USD", MonthlyCost: 250, PastMonthlyCost: 200, DiffMonthlyCost: 50}, r.Summarize().Cost())
+		})
+	}
+}
+
+func TestCostEstimateErrors(t *testing.T) {
+	t.Parallel()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(t.TempDir(), "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	opts.TerraformCommand = "plan"
+	opts.CostEstimateBudget = "100"
+
+	_, err = trackCostEstimate(opts, nil)
+	require.ErrorAs(t, err, &CostEstimateBudgetWithoutCostEstimateErr{})
+
+	opts.CostEstimate = true
+
+	_, err = trackCostEstimate(opts, nil)
+	require.ErrorAs(t, err, &CostEstimateWithoutJSONOutDirErr{})
+
+	opts.JSONOutputFolder = "json"
+	opts.CostEstimateBudget = "lots"
+
+	_, err = trackCostEstimate(opts, nil)
+	require.ErrorAs(t, err, &CostEstimateInvalidBudgetErr{})
+
+	opts.TerraformCommand = "apply"
+
+	_, err = trackCostEstimate(opts, nil)
+	require.ErrorAs(t, err, &CostEstimateUnsupportedCommandErr{})
+}
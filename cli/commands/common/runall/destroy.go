@@ -0,0 +1,220 @@
+package runall
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/report"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/tf"
+)
+
+const (
+	// PreventDestroyPolicyFail fails run --all destroy before destroying anything if any unit of the queue sets
+	// prevent_destroy.
+	PreventDestroyPolicyFail = "fail"
+	// PreventDestroyPolicySkip skips the units of run --all destroy that set prevent_destroy, along with the units they
+	// depend on, which they would break.
+	PreventDestroyPolicySkip = "skip"
+)
+
+// protectDestroy enforces --prevent-destroy-policy on the given units of run --all destroy, the units that set
+// prevent_destroy failing the run, or being skipped along with their dependencies. Without a policy, the protected
+// units fail when their turn comes, as with a single unit.
+func protectDestroy(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, units common.Units) error {
+	if opts.PreventDestroyPolicy == "" || opts.TerraformCommand != tf.CommandNameDestroy {
+		return nil
+	}
+
+	protected := map[string]bool{}
+
+	for _, unit := range units {
+		if unit.FlagExcluded || unit.AssumeAlreadyApplied {
+			continue
+		}
+
+		parseCtx := config.NewParsingContext(ctx, l, unit.TerragruntOptions).WithDecodeList(config.TerragruntFlags)
+
+		cfg, err := config.PartialParseConfigFile(parseCtx, l, unit.TerragruntOptions.TerragruntConfigPath, nil)
+		if err != nil {
+			return err
+		}
+
+		if cfg.PreventDestroy != nil && *cfg.PreventDestroy {
+			protected[unit.Path] = true
+		}
+	}
+
+	return skipProtectedUnits(l, opts, units, protected)
+}
+
+// skipProtectedUnits fails the run if any of the given protected units is to be destroyed and the policy is fail, or
+// marks them as already applied, along with the units they depend on, if the policy is skip.
+func skipProtectedUnits(l log.Logger, opts *options.TerragruntOptions, units common.Units, protected map[string]bool) error {
+	if len(protected) == 0 {
+		return nil
+	}
+
+	if opts.PreventDestroyPolicy == PreventDestroyPolicyFail {
+		paths := []string{}
+
+		for _, unit := range units {
+			if protected[unit.Path] {
+				paths = append(paths, relPath(opts, unit.Path))
+			}
+		}
+
+		return errors.New(PreventDestroyProtectedUnitsErr{units: paths})
+	}
+
+	selection := &common.QueueSelection{DependenciesDepth: -1}
+
+	for _, unit := range units {
+		if protected[unit.Path] {
+			selection.Units = append(selection.Units, unit.Path)
+		}
+	}
+
+	skipped := selection.Includes(units)
+
+	for _, unit := range units {
+		if !skipped[unit.Path] || unit.FlagExcluded || unit.AssumeAlreadyApplied {
+			continue
+		}
+
+		unit.AssumeAlreadyApplied = true
+
+		if protected[unit.Path] {
+			l.Warnf("Skipping the destroy of unit %s, protected by prevent_destroy", relPath(opts, unit.Path))
+		} else {
+			l.Warnf("Skipping the destroy of unit %s, which units protected by prevent_destroy depend on", relPath(opts, unit.Path))
+		}
+	}
+
+	return nil
+}
+
+// previewDestroy outputs the units run --all destroy is about to destroy, in the groups they are destroyed in, with the
+// number of resources in the state of each unit, before anything is destroyed.
+func previewDestroy(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, units common.Units) error {
+	if opts.TerraformCommand != tf.CommandNameDestroy {
+		return nil
+	}
+
+	groups := queueGroups(opts, units)
+	counts := map[string]int{}
+
+	var mu sync.Mutex
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(max(opts.Parallelism, 1))
+
+	for _, group := range groups {
+		for _, unit := range group {
+			g.Go(func() error {
+				count, err := countStateResources(ctx, l, unit)
+				if err != nil {
+					// The preview still lists the unit, the destroy itself reporting the error, if any.
+					l.Debugf("Failed to count the resources in the state of unit %s: %v", relPath(opts, unit.Path), err)
+					return nil
+				}
+
+				mu.Lock()
+				counts[unit.Path] = count
+				mu.Unlock()
+
+				return nil
+			})
+		}
+	}
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	return writeDestroyPreview(opts.Writer, opts, groups, counts)
+}
+
+// countStateResources returns the number of resources in the state of the given unit, as listed by `state list`.
+func countStateResources(ctx context.Context, l log.Logger, unit *common.Unit) (int, error) {
+	l, stateOpts, err := unit.TerragruntOptions.CloneWithConfigPath(l, unit.TerragruntOptions.TerragruntConfigPath)
+	if err != nil {
+		return 0, err
+	}
+
+	var stdout bytes.Buffer
+
+	stateOpts.ForwardTFStdout = true
+	stateOpts.JSONLogFormat = false
+	stateOpts.Writer = &stdout
+	stateOpts.ErrWriter = io.Discard
+	stateOpts.TerraformCommand = tf.CommandNameState
+	stateOpts.TerraformCliArgs = []string{tf.CommandNameState, "list"}
+
+	if err := stateOpts.RunTerragrunt(ctx, l, stateOpts, report.NewReport()); err != nil {
+		return 0, err
+	}
+
+	count := 0
+
+	scanner := bufio.NewScanner(&stdout)
+	for scanner.Scan() {
+		if strings.TrimSpace(scanner.Text()) != "" {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// writeDestroyPreview writes the preview of the destroy of the given groups of units, with the given numbers of
+// resources by unit path, the units whose resources couldn't be counted being listed with `?`.
+func writeDestroyPreview(w io.Writer, opts *options.TerragruntOptions, groups []common.Units, counts map[string]int) error {
+	total, unitCount := 0, 0
+
+	for _, group := range groups {
+		unitCount += len(group)
+	}
+
+	for _, count := range counts {
+		total += count
+	}
+
+	fmt.Fprintf(w, "\n❯❯ Destroy Preview  %d resources in %d units, destroyed group by group\n\n", total, unitCount)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0) //nolint:mnd
+
+	fmt.Fprintln(tw, "   GROUP\tUNIT\tRESOURCES")
+
+	for i, group := range groups {
+		for _, unit := range group {
+			count := "?"
+			if n, ok := counts[unit.Path]; ok {
+				count = strconv.Itoa(n)
+			}
+
+			fmt.Fprintf(tw, "   %d\t%s\t%s\n", i+1, relPath(opts, unit.Path), count)
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return errors.New(err)
+	}
+
+	fmt.Fprintln(w)
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package runall
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+)
+
+func TestSkipProtectedUnits(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+
+	l := logger.CreateLogger()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(rootDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	opts.WorkingDir = rootDir
+	opts.TerraformCommand = "destroy"
+
+	vpc := &common.Unit{Path: filepath.Join(rootDir, "vpc")}
+	db := &common.Unit{Path: filepath.Join(rootDir, "db"), Dependencies: common.Units{vpc}}
+	app := &common.Unit{Path: filepath.Join(rootDir, "app"), Dependencies: common.Units{db}}
+	logs := &common.Unit{Path: filepath.Join(rootDir, "logs")}
+	units := common.Units{vpc, db, app, logs}
+
+	protected := map[string]bool{db.Path: true}
+
+	// With the fail policy, nothing is destroyed.
+	opts.PreventDestroyPolicy = PreventDestroyPolicyFail
+
+	err = skipProtectedUnits(l, opts, units, protected)
+
+	var protectedErr PreventDestroyProtectedUnitsErr
+	require.ErrorAs(t, err, &protectedErr)
+	assert.Equal(t, []string{"db"}, protectedErr.units)
+
+	for _, unit := range units {
+		assert.False(t, unit.AssumeAlreadyApplied, unit.Path)
+	}
+
+	// With the skip policy, db is skipped along with vpc, which db depends on, while app, which depends on db, is
+	// destroyed.
+	opts.PreventDestroyPolicy = PreventDestroyPolicySkip
+
+	require.NoError(t, skipProtectedUnits(l, opts, units, protected))
+
+	assert.True(t, vpc.AssumeAlreadyApplied)
+	assert.True(t, db.AssumeAlreadyApplied)
+	assert.False(t, app.AssumeAlreadyApplied)
+	assert.False(t, logs.AssumeAlreadyApplied)
+
+	// Without protected units, there is nothing to enforce.
+	opts.PreventDestroyPolicy = PreventDestroyPolicyFail
+
+	require.NoError(t, skipProtectedUnits(l, opts, units, nil))
+}
+
+func TestWriteDestroyPreview(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(rootDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	opts.WorkingDir = rootDir
+
+	vpc := &common.Unit{Path: filepath.Join(rootDir, "vpc")}
+	app := &common.Unit{Path: filepath.Join(rootDir, "app")}
+	logs := &common.Unit{Path: filepath.Join(rootDir, "logs")}
+
+	groups := []common.Units{{app, logs}, {vpc}}
+	counts := map[string]int{vpc.Path: 3, app.Path: 1}
+
+	var out bytes.Buffer
+
+	require.NoError(t, writeDestroyPreview(&out, opts, groups, counts))
+	assert.Equal(t, `
+❯❯ Destroy Preview  4 resources in 3 units, destroyed group by group
+
+   GROUP  UNIT  RESOURCES
+   1      app   1
+   1      logs  ?
+   2      vpc   3
+
+`, out.String())
+}
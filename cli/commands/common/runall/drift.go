@@ -0,0 +1,252 @@
+package runall
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/report"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/tf"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+const (
+	// DriftStatusDrifted is the status of a unit whose infrastructure drifted from its state.
+	DriftStatusDrifted = "drifted"
+	// DriftStatusClean is the status of a unit whose infrastructure matches its state.
+	DriftStatusClean = "clean"
+	// DriftStatusFailed is the status of a unit whose plan failed, so its drift is unknown.
+	DriftStatusFailed = "failed"
+	// DriftStatusNotChecked is the status of a unit that didn't run, e.g. as one of its dependencies failed.
+	DriftStatusNotChecked = "not checked"
+)
+
+// driftWebhookTimeout is how long the drift webhook has to respond.
+const driftWebhookTimeout = 30 * time.Second
+
+// driftReport is the drift detected by run --all plan --drift-check, written to --drift-report-file and posted to
+// --drift-webhook.
+type driftReport struct {
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	WorkingDir string    `json:"working_dir"`
+	// Units are the units of the run, by path relative to the working dir.
+	Units         []*driftReportUnit `json:"units"`
+	DriftedUnits  int                `json:"drifted_units"`
+	FailedUnits   int                `json:"failed_units"`
+	CheckedUnits  int                `json:"checked_units"`
+	DriftDetected bool               `json:"drift_detected"`
+}
+
+type driftReportUnit struct {
+	Unit   string `json:"unit"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// trackDrift runs the plans of the given units with `-refresh-only` and `-detailed-exitcode`, recording which units
+// drifted, and returns a function reporting the drift once the run finished, or nil if --drift-check isn't set. The
+// exit codes of the units, and of the run, are tracked by --detailed-exit-code, which --drift-check implies, so the
+// drift must be tracked before it.
+func trackDrift(opts *options.TerragruntOptions, units common.Units) (func(l log.Logger) error, error) {
+	if !opts.DriftCheck {
+		if opts.DriftReportFile != "" || opts.DriftWebhook != "" {
+			return nil, errors.New(DriftReportWithoutDriftCheckErr{})
+		}
+
+		return nil, nil
+	}
+
+	if opts.TerraformCommand != tf.CommandNamePlan {
+		return nil, errors.New(DriftCheckUnsupportedCommandErr{command: opts.TerraformCommand})
+	}
+
+	opts.DetailedExitCode = true
+
+	// The runner copies the arguments of the run to the units as it runs them.
+	if !util.ListContainsElement(opts.TerraformCliArgs, tf.FlagNameRefreshOnly) {
+		opts.TerraformCliArgs = append(slices.Clone(opts.TerraformCliArgs), tf.FlagNameRefreshOnly)
+	}
+
+	var (
+		mu      sync.Mutex
+		results = map[*common.Unit]*driftReportUnit{}
+	)
+
+	for _, unit := range units {
+		if unit.FlagExcluded || unit.AssumeAlreadyApplied {
+			continue
+		}
+
+		result := &driftReportUnit{Unit: relPath(opts, unit.Path), Status: DriftStatusNotChecked}
+		results[unit] = result
+
+		wrapUnitRun(unit, func(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, r *report.Report, next runTerragruntFunc) error {
+			err := next(ctx, l, opts, r)
+
+			// The exit code of the unit is recorded in the context by --detailed-exit-code.
+			var code int
+			if exitCode := tf.DetailedExitCodeFromContext(ctx); exitCode != nil {
+				code = exitCode.Get()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			switch {
+			case err != nil:
+				result.Status = DriftStatusFailed
+				result.Error = err.Error()
+			case code == tf.DetailedExitCodeError:
+				result.Status = DriftStatusFailed
+			case code == detailedExitCodeChanges:
+				result.Status = DriftStatusDrifted
+			default:
+				result.Status = DriftStatusClean
+			}
+
+			return err
+		})
+	}
+
+	started := time.Now()
+
+	return func(l log.Logger) error {
+		mu.Lock()
+
+		drift := &driftReport{
+			StartedAt:  started.UTC(),
+			FinishedAt: time.Now().UTC(),
+			WorkingDir: opts.WorkingDir,
+			Units:      []*driftReportUnit{},
+		}
+
+		for _, result := range results {
+			drift.add(result)
+		}
+
+		mu.Unlock()
+
+		slices.SortFunc(drift.Units, func(a, b *driftReportUnit) int {
+			return strings.Compare(a.Unit, b.Unit)
+		})
+
+		drift.write(opts.Writer)
+
+		if opts.DriftReportFile != "" {
+			path := opts.DriftReportFile
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(opts.WorkingDir, path)
+			}
+
+			if err := drift.writeFile(path); err != nil {
+				return err
+			}
+
+			l.Infof("Saved the drift report of %d units to %s", len(drift.Units), path)
+		}
+
+		if opts.DriftWebhook != "" && drift.DriftDetected {
+			if err := drift.post(opts.DriftWebhook); err != nil {
+				return err
+			}
+
+			l.Infof("Posted the drift report to %s", opts.DriftWebhook)
+		}
+
+		return nil
+	}, nil
+}
+
+// add adds the given unit to the report.
+func (drift *driftReport) add(unit *driftReportUnit) {
+	drift.Units = append(drift.Units, unit)
+
+	switch unit.Status {
+	case DriftStatusDrifted:
+		drift.DriftedUnits++
+		drift.CheckedUnits++
+		drift.DriftDetected = true
+	case DriftStatusClean:
+		drift.CheckedUnits++
+	case DriftStatusFailed:
+		drift.FailedUnits++
+	}
+}
+
+// write writes the summary of the report to the given writer, listing the drifted and failed units only.
+func (drift *driftReport) write(w io.Writer) {
+	fmt.Fprintf(w, "\n❯❯ Drift Check  %d of %d units drifted\n", drift.DriftedUnits, len(drift.Units))
+
+	if drift.FailedUnits > 0 {
+		fmt.Fprintf(w, "   %d units failed to check for drift\n", drift.FailedUnits)
+	}
+
+	for _, unit := range drift.Units {
+		if unit.Status == DriftStatusDrifted || unit.Status == DriftStatusFailed {
+			fmt.Fprintf(w, "   %s  %s\n", unit.Unit, unit.Status)
+		}
+	}
+}
+
+// writeFile writes the report as JSON to the file at the given path.
+func (drift *driftReport) writeFile(path string) error {
+	data, err := json.MarshalIndent(drift, "", "  ")
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return errors.New(err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil { //nolint:mnd
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// post posts the report as JSON to the webhook at the given URL.
+func (drift *driftReport) post(url string) error {
+	data, err := json.Marshal(drift)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), driftWebhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return errors.New(err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return errors.New(DriftWebhookStatusErr{url: url, statusCode: resp.StatusCode})
+	}
+
+	return nil
+}
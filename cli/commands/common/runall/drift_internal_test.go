@@ -0,0 +1,136 @@
+package runall
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/report"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+	"github.com/gruntwork-io/terragrunt/tf"
+)
+
+func TestDriftCheck(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+
+	var posted driftReport
+
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&posted))
+	}))
+	defer webhook.Close()
+
+	l := logger.CreateLogger()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(rootDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	opts.WorkingDir = rootDir
+	opts.TerraformCommand = "plan"
+	opts.TerraformCliArgs = []string{"plan"}
+	opts.DriftCheck = true
+	opts.DriftReportFile = "drift.json"
+	opts.DriftWebhook = webhook.URL
+
+	// The exit codes of the plans of the units, and -1 for units that don't run.
+	codes := map[string]int{"clean": 0, "drifted": 2, "failed": 1, "skipped": -1}
+
+	units := common.Units{}
+
+	for name, code := range codes {
+		unit := &common.Unit{Path: filepath.Join(rootDir, name)}
+		unit.TerragruntOptions = opts.Clone()
+		unit.TerragruntOptions.RunTerragrunt = func(ctx context.Context, _ log.Logger, _ *options.TerragruntOptions, _ *report.Report) error {
+			if code == tf.DetailedExitCodeError {
+				return errors.New("plan failed")
+			}
+
+			tf.DetailedExitCodeFromContext(ctx).Set(code)
+
+			return nil
+		}
+
+		units = append(units, unit)
+	}
+
+	reportDrift, err := trackDrift(opts, units)
+	require.NoError(t, err)
+	assert.True(t, opts.DetailedExitCode)
+	assert.Equal(t, []string{"plan", tf.FlagNameRefreshOnly}, []string(opts.TerraformCliArgs))
+
+	setExitCode, err := trackDetailedExitCode(opts, units)
+	require.NoError(t, err)
+
+	exitCode := &tf.DetailedExitCode{}
+	ctx := tf.ContextWithDetailedExitCode(t.Context(), exitCode)
+
+	for _, unit := range units {
+		if codes[filepath.Base(unit.Path)] < 0 {
+			continue
+		}
+
+		if err := unit.TerragruntOptions.RunTerragrunt(ctx, l, unit.TerragruntOptions, nil); err != nil {
+			exitCode.Set(tf.DetailedExitCodeError)
+		}
+	}
+
+	require.NoError(t, reportDrift(l))
+
+	data, err := os.ReadFile(filepath.Join(rootDir, "drift.json"))
+	require.NoError(t, err)
+
+	var drift driftReport
+	require.NoError(t, json.Unmarshal(data, &drift))
+
+	statuses := map[string]string{}
+	for _, unit := range drift.Units {
+		statuses[unit.Unit] = unit.Status
+	}
+
+	assert.Equal(t, map[string]string{
+		"clean":   DriftStatusClean,
+		"drifted": DriftStatusDrifted,
+		"failed":  DriftStatusFailed,
+		"skipped": DriftStatusNotChecked,
+	}, statuses)
+	assert.True(t, drift.DriftDetected)
+	assert.Equal(t, 1, drift.DriftedUnits)
+	assert.Equal(t, 1, drift.FailedUnits)
+	assert.Equal(t, 2, drift.CheckedUnits)
+	assert.Equal(t, drift.Units, posted.Units)
+
+	require.NoError(t, setExitCode(ctx, l, nil))
+	assert.Equal(t, tf.DetailedExitCodeError, exitCode.Get())
+}
+
+func TestDriftCheckErrors(t *testing.T) {
+	t.Parallel()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(t.TempDir(), "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	opts.TerraformCommand = "plan"
+	opts.DriftReportFile = "drift.json"
+
+	_, err = trackDrift(opts, nil)
+	require.ErrorAs(t, err, &DriftReportWithoutDriftCheckErr{})
+
+	opts.TerraformCommand = "apply"
+	opts.DriftCheck = true
+
+	_, err = trackDrift(opts, nil)
+	require.ErrorAs(t, err, &DriftCheckUnsupportedCommandErr{})
+}
@@ -0,0 +1,245 @@
+package runall
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/experiment"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/tf"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+const (
+	// QueueDryRunFormatTable outputs the execution plan of --queue-dry-run as a table, the default.
+	QueueDryRunFormatTable = "table"
+	// QueueDryRunFormatJSON outputs the execution plan of --queue-dry-run as JSON.
+	QueueDryRunFormatJSON = "json"
+)
+
+// queueDryRunPlan is the execution plan of run --all output by --queue-dry-run.
+type queueDryRunPlan struct {
+	Command    string `json:"command"`
+	WorkingDir string `json:"working_dir"`
+	// Groups are the units in the groups they run in, the units of a group running once the previous groups finished.
+	Groups [][]*queueDryRunUnit `json:"groups"`
+}
+
+type queueDryRunUnit struct {
+	// Path is the path of the unit, relative to the working dir of the run.
+	Path string `json:"path"`
+	// WorkingDir is the dir OpenTofu/Terraform runs in, the dir the source of the unit is downloaded to, if any.
+	WorkingDir string `json:"working_dir"`
+	// Args are the arguments OpenTofu/Terraform runs with, extra_arguments included.
+	Args         []string `json:"args"`
+	Dependencies []string `json:"dependencies"`
+	// Priority is the queue priority of the unit, the units of a group being dispatched the highest priority first.
+	Priority int `json:"priority"`
+}
+
+// queueDryRun outputs the execution plan of run --all, without running anything: the units to run in their groups,
+// the arguments OpenTofu/Terraform would run with, and the dirs it would run in.
+func queueDryRun(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, units common.Units) error {
+	plan := &queueDryRunPlan{
+		Command:    opts.TerraformCommand,
+		WorkingDir: opts.WorkingDir,
+		Groups:     [][]*queueDryRunUnit{},
+	}
+
+	for _, group := range queueGroups(opts, units) {
+		planGroup := make([]*queueDryRunUnit, 0, len(group))
+
+		for _, unit := range group {
+			planUnit, err := newQueueDryRunUnit(ctx, l, opts, unit)
+			if err != nil {
+				return err
+			}
+
+			planGroup = append(planGroup, planUnit)
+		}
+
+		plan.Groups = append(plan.Groups, planGroup)
+	}
+
+	if opts.QueueDryRunFormat == QueueDryRunFormatJSON {
+		data, err := json.MarshalIndent(plan, "", "  ")
+		if err != nil {
+			return errors.New(err)
+		}
+
+		if _, err := fmt.Fprintln(opts.Writer, string(data)); err != nil {
+			return errors.New(err)
+		}
+
+		return nil
+	}
+
+	w := tabwriter.NewWriter(opts.Writer, 0, 0, 2, ' ', 0) //nolint:mnd
+
+	fmt.Fprintln(w, "GROUP\tUNIT\tPRIORITY\tCOMMAND\tWORKING DIR")
+
+	for i, group := range plan.Groups {
+		for _, unit := range group {
+			fmt.Fprintf(w, "%d\t%s\t%d\t%s\t%s\n", i+1, unit.Path, unit.Priority, strings.Join(append([]string{filepath.Base(opts.TFPath)}, unit.Args...), " "), unit.WorkingDir)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// newQueueDryRunUnit resolves the arguments and the working dir of OpenTofu/Terraform in the given unit, the way the
+// run would, the terraform block of its config parsed to resolve its extra_arguments and its source.
+func newQueueDryRunUnit(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, unit *common.Unit) (*queueDryRunUnit, error) {
+	unitOpts := unit.TerragruntOptions.Clone()
+
+	// The arguments run --all adds to the arguments of every unit, see `configstack.Runner.Run`.
+	if slices.Contains(config.TerraformCommandsNeedInput, opts.TerraformCommand) {
+		unitOpts.TerraformCliArgs = util.StringListInsert(unitOpts.TerraformCliArgs, "-input=false", 1)
+	}
+
+	if (opts.TerraformCommand == tf.CommandNameApply || opts.TerraformCommand == tf.CommandNameDestroy) && opts.RunAllAutoApprove {
+		unitOpts.TerraformCliArgs = util.StringListInsert(unitOpts.TerraformCliArgs, "-auto-approve", 1)
+	}
+
+	parseCtx := config.NewParsingContext(ctx, l, unitOpts).WithDecodeList(config.TerraformBlock)
+
+	cfg, err := config.PartialParseConfigFile(parseCtx, l, unitOpts.TerragruntConfigPath, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Terraform != nil && len(cfg.Terraform.ExtraArgs) > 0 {
+		unitOpts.InsertTerraformCliArgs(config.FilterTerraformExtraArgs(l, unitOpts, cfg)...)
+	}
+
+	workingDir := unit.Path
+
+	sourceURL, err := config.GetTerraformSourceURL(unitOpts, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if sourceURL != "" {
+		source, err := tf.NewSource(l, sourceURL, unitOpts.DownloadDir, unitOpts.WorkingDir, unitOpts.Experiments.Evaluate(experiment.Symlinks))
+		if err != nil {
+			return nil, err
+		}
+
+		workingDir = source.WorkingDir
+	}
+
+	dependencies := make([]string, 0, len(unit.Dependencies))
+
+	for _, dep := range unit.Dependencies {
+		dependencies = append(dependencies, relPath(opts, dep.Path))
+	}
+
+	slices.Sort(dependencies)
+
+	return &queueDryRunUnit{
+		Path:         relPath(opts, unit.Path),
+		WorkingDir:   workingDir,
+		Args:         unitOpts.TerraformCliArgs,
+		Dependencies: dependencies,
+		Priority:     unit.QueuePriority(),
+	}, nil
+}
+
+// queueGroups returns the units that run, in the groups of the run queue, in the order they are dispatched in: the
+// highest queue priority first, then by path. The units of a group run
+// once all the units of the previous groups finished: the units they depend on, or, for destroy, the units depending
+// on them. Ignoring the dependency order, all the units are in a single group.
+func queueGroups(opts *options.TerragruntOptions, units common.Units) []common.Units {
+	// The units each unit waits for, its dependencies, or its dependents for destroy.
+	waitsFor := make(map[*common.Unit]common.Units, len(units))
+
+	for _, unit := range units {
+		if opts.IgnoreDependencyOrder {
+			continue
+		}
+
+		if opts.TerraformCommand != tf.CommandNameDestroy {
+			waitsFor[unit] = unit.Dependencies
+			continue
+		}
+
+		for _, dep := range unit.Dependencies {
+			waitsFor[dep] = append(waitsFor[dep], unit)
+		}
+	}
+
+	levels := make(map[*common.Unit]int, len(units))
+
+	var level func(unit *common.Unit) int
+
+	level = func(unit *common.Unit) int {
+		if lvl, ok := levels[unit]; ok {
+			return lvl
+		}
+
+		lvl := 0
+
+		for _, dep := range waitsFor[unit] {
+			lvl = max(lvl, level(dep)+1)
+		}
+
+		levels[unit] = lvl
+
+		return lvl
+	}
+
+	var groups []common.Units
+
+	for _, unit := range units {
+		if unit.FlagExcluded || unit.AssumeAlreadyApplied {
+			continue
+		}
+
+		lvl := level(unit)
+
+		for len(groups) <= lvl {
+			groups = append(groups, common.Units{})
+		}
+
+		groups[lvl] = append(groups[lvl], unit)
+	}
+
+	// Levels made only of units that don't run leave empty groups.
+	groups = slices.DeleteFunc(groups, func(group common.Units) bool { return len(group) == 0 })
+
+	for _, group := range groups {
+		slices.SortFunc(group, func(a, b *common.Unit) int {
+			if diff := cmp.Compare(b.QueuePriority(), a.QueuePriority()); diff != 0 {
+				return diff
+			}
+
+			return strings.Compare(a.Path, b.Path)
+		})
+	}
+
+	return groups
+}
+
+// relPath returns the given path relative to the working dir of the run, in slash form.
+func relPath(opts *options.TerragruntOptions, path string) string {
+	rel, err := filepath.Rel(opts.WorkingDir, path)
+	if err != nil {
+		return path
+	}
+
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,143 @@
+package runall
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+)
+
+func TestQueueDryRun(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+
+	configs := map[string]string{
+		"vpc": `
+terraform {
+  source = "../modules/vpc"
+
+  extra_arguments "lock" {
+    commands  = ["apply"]
+    arguments = ["-lock-timeout=20m"]
+  }
+
+  extra_arguments "plan" {
+    commands  = ["plan"]
+    arguments = ["-refresh=false"]
+  }
+}
+`,
+		"app": ``,
+	}
+
+	for name, cfg := range configs {
+		require.NoError(t, os.MkdirAll(filepath.Join(rootDir, name), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(rootDir, name, "terragrunt.hcl"), []byte(cfg), 0644))
+	}
+
+	require.NoError(t, os.MkdirAll(filepath.Join(rootDir, "modules", "vpc"), 0755))
+
+	l := logger.CreateLogger()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(rootDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	opts.WorkingDir = rootDir
+	opts.TerraformCommand = "apply"
+	opts.TerraformCliArgs = []string{"apply"}
+	opts.RunAllAutoApprove = true
+	opts.QueueDryRunFormat = QueueDryRunFormatJSON
+
+	newUnit := func(name string, dependencies ...*common.Unit) *common.Unit {
+		_, unitOpts, err := opts.CloneWithConfigPath(l, filepath.Join(rootDir, name, "terragrunt.hcl"))
+		require.NoError(t, err)
+
+		return &common.Unit{Path: filepath.Join(rootDir, name), TerragruntOptions: unitOpts, Dependencies: dependencies}
+	}
+
+	vpc := newUnit("vpc")
+	app := newUnit("app", vpc)
+
+	var out bytes.Buffer
+
+	opts.Writer = &out
+
+	require.NoError(t, queueDryRun(t.Context(), l, opts, common.Units{app, vpc}))
+
+	var plan queueDryRunPlan
+
+	require.NoError(t, json.Unmarshal(out.Bytes(), &plan))
+	assert.Equal(t, "apply", plan.Command)
+	require.Len(t, plan.Groups, 2)
+	require.Len(t, plan.Groups[0], 1)
+	require.Len(t, plan.Groups[1], 1)
+
+	assert.Equal(t, "vpc", plan.Groups[0][0].Path)
+	assert.Equal(t, []string{"apply", "-lock-timeout=20m", "-auto-approve", "-input=false"}, plan.Groups[0][0].Args)
+	// The source of the unit is downloaded to the download dir.
+	assert.True(t, strings.HasPrefix(plan.Groups[0][0].WorkingDir, vpc.TerragruntOptions.DownloadDir), plan.Groups[0][0].WorkingDir)
+
+	assert.Equal(t, "app", plan.Groups[1][0].Path)
+	assert.Equal(t, []string{"apply", "-auto-approve", "-input=false"}, plan.Groups[1][0].Args)
+	assert.Equal(t, filepath.Join(rootDir, "app"), plan.Groups[1][0].WorkingDir)
+	assert.Equal(t, []string{"vpc"}, plan.Groups[1][0].Dependencies)
+
+	// Destroy runs the dependents first.
+	opts.TerraformCommand = "destroy"
+	opts.QueueDryRunFormat = ""
+	out.Reset()
+
+	require.NoError(t, queueDryRun(t.Context(), l, opts, common.Units{app, vpc}))
+
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	require.Len(t, lines, 3)
+	assert.Regexp(t, `^GROUP\s+UNIT\s+PRIORITY\s+COMMAND\s+WORKING DIR$`, string(lines[0]))
+	assert.Regexp(t, `^1\s+app\s+0\s+`, string(lines[1]))
+	assert.Regexp(t, `^2\s+vpc\s+0\s+`, string(lines[2]))
+}
+
+func TestQueueGroupsPriorities(t *testing.T) {
+	t.Parallel()
+
+	opts, err := options.NewTerragruntOptionsForTest("/live/terragrunt.hcl")
+	require.NoError(t, err)
+
+	opts.WorkingDir = "/live"
+	opts.TerraformCommand = "apply"
+	opts.QueueTagPriorities = map[string]int{"critical": 5, "slow": 1}
+
+	priority := 10
+
+	vpc := &common.Unit{Path: "/live/vpc", TerragruntOptions: opts}
+	eks := &common.Unit{Path: "/live/eks", TerragruntOptions: opts, Config: config.TerragruntConfig{Tags: []string{"slow", "critical"}}}
+	dns := &common.Unit{Path: "/live/dns", TerragruntOptions: opts, Config: config.TerragruntConfig{QueuePriority: &priority, Tags: []string{"critical"}}}
+	app := &common.Unit{Path: "/live/app", TerragruntOptions: opts, Dependencies: common.Units{vpc}}
+	api := &common.Unit{Path: "/live/api", TerragruntOptions: opts, Dependencies: common.Units{vpc}, Config: config.TerragruntConfig{Tags: []string{"slow"}}}
+
+	var paths [][]string
+
+	for _, group := range queueGroups(opts, common.Units{app, api, dns, eks, vpc}) {
+		groupPaths := make([]string, 0, len(group))
+
+		for _, unit := range group {
+			groupPaths = append(groupPaths, relPath(opts, unit.Path))
+		}
+
+		paths = append(paths, groupPaths)
+	}
+
+	// The queue_priority attribute takes precedence over the priorities of the tags, the highest of which counts, and a
+	// priority never moves a unit before its dependencies.
+	assert.Equal(t, [][]string{{"dns", "eks", "vpc"}, {"api", "app"}}, paths)
+}
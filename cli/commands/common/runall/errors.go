@@ -1,6 +1,9 @@
 package runall
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type RunAllDisabledErr struct {
 	command string
@@ -16,3 +19,294 @@ type MissingCommand struct{}
 func (err MissingCommand) Error() string {
 	return "Missing run --all command argument (Example: terragrunt run --all plan)"
 }
+
+type WatchUnsupportedCommandErr struct {
+	command string
+}
+
+func (err WatchUnsupportedCommandErr) Error() string {
+	return fmt.Sprintf("%s with run --all --watch is not supported, only %s can be watched", err.command, strings.Join(watchCommands, " and "))
+}
+
+type StackWithoutAllErr struct{}
+
+func (err StackWithoutAllErr) Error() string {
+	return "the --stack flag can only be used with run --all"
+}
+
+type WatchWithoutAllErr struct{}
+
+func (err WatchWithoutAllErr) Error() string {
+	return "the --watch flag can only be used with run --all"
+}
+
+type ResumeWithoutAllErr struct{}
+
+func (err ResumeWithoutAllErr) Error() string {
+	return "the --resume flag can only be used with run --all"
+}
+
+type RetryFailedWithoutAllErr struct{}
+
+func (err RetryFailedWithoutAllErr) Error() string {
+	return "the --retry-failed flag can only be used with run --all"
+}
+
+type RetryFailedWithoutReportFileErr struct{}
+
+func (err RetryFailedWithoutReportFileErr) Error() string {
+	return "the --retry-failed flag requires --report-file, the report of the last run"
+}
+
+type SkipUnchangedWithoutAllErr struct{}
+
+func (err SkipUnchangedWithoutAllErr) Error() string {
+	return "the --skip-unchanged flag can only be used with run --all"
+}
+
+type SkipUnchangedUnsupportedCommandErr struct {
+	command string
+}
+
+func (err SkipUnchangedUnsupportedCommandErr) Error() string {
+	return fmt.Sprintf("%s with run --all --skip-unchanged is not supported, only plan and apply can skip the unchanged units", err.command)
+}
+
+type SkipUnchangedWithJSONOutDirErr struct{}
+
+func (err SkipUnchangedWithJSONOutDirErr) Error() string {
+	return "the --skip-unchanged flag can't be used with --json-out-dir, as the skipped units have no plans to save as JSON"
+}
+
+type SkipUnchangedWithDriftCheckErr struct{}
+
+func (err SkipUnchangedWithDriftCheckErr) Error() string {
+	return "the --skip-unchanged flag can't be used with --drift-check, as the unchanged units can still drift"
+}
+
+type FromArtifactsWithoutAllErr struct{}
+
+func (err FromArtifactsWithoutAllErr) Error() string {
+	return "the --from-artifacts flag can only be used with run --all"
+}
+
+type QueueDryRunWithoutAllErr struct{}
+
+func (err QueueDryRunWithoutAllErr) Error() string {
+	return "the --queue-dry-run flag can only be used with run --all"
+}
+
+type DetailedExitCodeWithoutAllErr struct{}
+
+func (err DetailedExitCodeWithoutAllErr) Error() string {
+	return "the --detailed-exit-code flag can only be used with run --all, use -detailed-exitcode to plan a single unit"
+}
+
+type DetailedExitCodeUnsupportedCommandErr struct {
+	command string
+}
+
+func (err DetailedExitCodeUnsupportedCommandErr) Error() string {
+	return fmt.Sprintf("%s with run --all --detailed-exit-code is not supported, only plan has a detailed exit code", err.command)
+}
+
+// DetailedExitCodeErr is the error of a failed run, exiting with the code chosen by --detailed-exit-code-policy instead
+// of the code of the error.
+type DetailedExitCodeErr struct {
+	err  error
+	code int
+}
+
+func (err DetailedExitCodeErr) Error() string {
+	return err.err.Error()
+}
+
+func (err DetailedExitCodeErr) Unwrap() error {
+	return err.err
+}
+
+// ExitStatus returns the exit code of the run, see `util.GetExitCode`.
+func (err DetailedExitCodeErr) ExitStatus() (int, error) {
+	return err.code, nil
+}
+
+type DetailedExitCodeInvalidPolicyErr struct {
+	policy string
+}
+
+func (err DetailedExitCodeInvalidPolicyErr) Error() string {
+	return fmt.Sprintf("invalid --detailed-exit-code-policy %q, expected all-clean or any-changes", err.policy)
+}
+
+type PreventDestroyInvalidPolicyErr struct {
+	policy string
+}
+
+func (err PreventDestroyInvalidPolicyErr) Error() string {
+	return fmt.Sprintf("invalid --prevent-destroy-policy %q, expected fail or skip", err.policy)
+}
+
+type PreventDestroyProtectedUnitsErr struct {
+	units []string
+}
+
+func (err PreventDestroyProtectedUnitsErr) Error() string {
+	return fmt.Sprintf("run --all destroy would destroy the units protected by prevent_destroy: %s, set --prevent-destroy-policy skip to skip them", strings.Join(err.units, ", "))
+}
+
+type SpeculativePlanWithoutAllErr struct{}
+
+func (err SpeculativePlanWithoutAllErr) Error() string {
+	return "the --speculative-plan flag can only be used with run --all"
+}
+
+type SpeculativePlanUnsupportedCommandErr struct {
+	command string
+}
+
+func (err SpeculativePlanUnsupportedCommandErr) Error() string {
+	return fmt.Sprintf("%s with run --all --speculative-plan is not supported, only plan can be speculative", err.command)
+}
+
+type PlanSummaryWithoutAllErr struct{}
+
+func (err PlanSummaryWithoutAllErr) Error() string {
+	return "the --plan-summary and --plan-summary-file flags can only be used with run --all"
+}
+
+type PlanSummaryUnsupportedCommandErr struct {
+	command string
+}
+
+func (err PlanSummaryUnsupportedCommandErr) Error() string {
+	return fmt.Sprintf("%s with run --all --plan-summary is not supported, only plan can be summarized", err.command)
+}
+
+type CostEstimateWithoutAllErr struct{}
+
+func (err CostEstimateWithoutAllErr) Error() string {
+	return "the --cost-estimate and --cost-estimate-budget flags can only be used with run --all"
+}
+
+type CostEstimateBudgetWithoutCostEstimateErr struct{}
+
+func (err CostEstimateBudgetWithoutCostEstimateErr) Error() string {
+	return "the --cost-estimate-budget flag can only be used with --cost-estimate"
+}
+
+type CostEstimateUnsupportedCommandErr struct {
+	command string
+}
+
+func (err CostEstimateUnsupportedCommandErr) Error() string {
+	return fmt.Sprintf("%s with run --all --cost-estimate is not supported, only the costs of plan can be estimated", err.command)
+}
+
+type CostEstimateWithoutJSONOutDirErr struct{}
+
+func (err CostEstimateWithoutJSONOutDirErr) Error() string {
+	return "the --cost-estimate flag requires --json-out-dir, as Infracost estimates the costs of the JSON plans"
+}
+
+type CostEstimateInvalidBudgetErr struct {
+	budget string
+}
+
+func (err CostEstimateInvalidBudgetErr) Error() string {
+	return fmt.Sprintf("invalid --cost-estimate-budget %q, expected a positive amount, e.g. 500", err.budget)
+}
+
+type CostEstimateIncompleteErr struct {
+	units []string
+}
+
+func (err CostEstimateIncompleteErr) Error() string {
+	return fmt.Sprintf("failed to verify the --cost-estimate-budget, the costs of the units %s couldn't be estimated", strings.Join(err.units, ", "))
+}
+
+type CostBudgetExceededErr struct {
+	currency string
+	increase float64
+	budget   float64
+}
+
+func (err CostBudgetExceededErr) Error() string {
+	return fmt.Sprintf("the plans increase the monthly cost by %.2f %s, exceeding the --cost-estimate-budget of %.2f %s", err.increase, err.currency, err.budget, err.currency)
+}
+
+type DriftCheckWithoutAllErr struct{}
+
+func (err DriftCheckWithoutAllErr) Error() string {
+	return "the --drift-check, --drift-report-file and --drift-webhook flags can only be used with run --all"
+}
+
+type DriftReportWithoutDriftCheckErr struct{}
+
+func (err DriftReportWithoutDriftCheckErr) Error() string {
+	return "the --drift-report-file and --drift-webhook flags can only be used with --drift-check"
+}
+
+type DriftCheckUnsupportedCommandErr struct {
+	command string
+}
+
+func (err DriftCheckUnsupportedCommandErr) Error() string {
+	return fmt.Sprintf("%s with run --all --drift-check is not supported, only plan can check for drift", err.command)
+}
+
+type DriftWebhookStatusErr struct {
+	url        string
+	statusCode int
+}
+
+func (err DriftWebhookStatusErr) Error() string {
+	return fmt.Sprintf("the drift webhook %s responded with status %d", err.url, err.statusCode)
+}
+
+type QueueDryRunInvalidFormatErr struct {
+	format string
+}
+
+func (err QueueDryRunInvalidFormatErr) Error() string {
+	return fmt.Sprintf("invalid --queue-dry-run-format %q, expected table or json", err.format)
+}
+
+type FromArtifactsUnsupportedCommandErr struct {
+	command string
+}
+
+func (err FromArtifactsUnsupportedCommandErr) Error() string {
+	return fmt.Sprintf("%s with run --all --from-artifacts is not supported, only apply can apply saved plans", err.command)
+}
+
+type ArtifactsChangedErr struct {
+	dir   string
+	units []string
+}
+
+func (err ArtifactsChangedErr) Error() string {
+	return fmt.Sprintf("refusing to apply the plans saved in %s, as these units changed since they were planned: %s", err.dir, strings.Join(err.units, ", "))
+}
+
+type RunAbortedErr struct {
+	id string
+}
+
+func (err RunAbortedErr) Error() string {
+	return fmt.Sprintf("run %s was aborted", err.id)
+}
+
+type UnitLogWithoutAllErr struct{}
+
+func (err UnitLogWithoutAllErr) Error() string {
+	return "the --unit-log-file, --unit-log-prefix and --unit-log-suppress-no-changes flags can only be used with run --all"
+}
+
+type UnitLogInvalidTemplateErr struct {
+	flag        string
+	placeholder string
+}
+
+func (err UnitLogInvalidTemplateErr) Error() string {
+	return fmt.Sprintf("invalid --%s, unknown placeholder {%s}, expected {unit}, {name}, {command} or {run_id}", err.flag, err.placeholder)
+}
@@ -0,0 +1,124 @@
+package runall
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/report"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/tf"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+const (
+	// DetailedExitCodePolicyAllClean exits with 0 only if all the units succeeded without changes, with 1 if any unit
+	// failed, and with 2 otherwise, the default.
+	DetailedExitCodePolicyAllClean = "all-clean"
+	// DetailedExitCodePolicyAnyChanges exits with 2 if any unit has changes, even if other units failed, with 1 if any
+	// unit failed, and with 0 otherwise.
+	DetailedExitCodePolicyAnyChanges = "any-changes"
+)
+
+// detailedExitCodeChanges is the exit code of `plan -detailed-exitcode` when the plan has changes.
+const detailedExitCodeChanges = 2
+
+// unitOutcomes are the outcomes of the units of a run, for the detailed exit code of the run.
+type unitOutcomes struct {
+	failed  bool
+	changed bool
+	mu      sync.Mutex
+}
+
+// exitCode returns the exit code of the run with the given policy.
+func (outcomes *unitOutcomes) exitCode(policy string) int {
+	outcomes.mu.Lock()
+	defer outcomes.mu.Unlock()
+
+	switch {
+	case outcomes.changed && (!outcomes.failed || policy == DetailedExitCodePolicyAnyChanges):
+		return detailedExitCodeChanges
+	case outcomes.failed:
+		return tf.DetailedExitCodeError
+	default:
+		return tf.DetailedExitCodeSuccess
+	}
+}
+
+// trackDetailedExitCode runs the plans of the given units with `-detailed-exitcode`, recording which units failed and
+// which have changes, and returns a function setting the exit code of the run from them, following
+// --detailed-exit-code-policy, once the run finished, or nil if --detailed-exit-code isn't set. The function is given
+// the error of the run, if any, and returns it with the exit code of the policy, as the exit code of an error takes
+// precedence over the one of the context.
+func trackDetailedExitCode(opts *options.TerragruntOptions, units common.Units) (func(ctx context.Context, l log.Logger, err error) error, error) {
+	if !opts.DetailedExitCode {
+		return nil, nil
+	}
+
+	if opts.TerraformCommand != tf.CommandNamePlan {
+		return nil, errors.New(DetailedExitCodeUnsupportedCommandErr{command: opts.TerraformCommand})
+	}
+
+	// The runner copies the arguments of the run to the units as it runs them.
+	if !util.ListContainsElement(opts.TerraformCliArgs, tf.FlagNameDetailedExitCode) {
+		opts.TerraformCliArgs = append(slices.Clone(opts.TerraformCliArgs), tf.FlagNameDetailedExitCode)
+	}
+
+	outcomes := &unitOutcomes{}
+
+	for _, unit := range units {
+		if unit.FlagExcluded || unit.AssumeAlreadyApplied {
+			continue
+		}
+
+		wrapUnitRun(unit, func(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, r *report.Report, next runTerragruntFunc) error {
+			// The plan of the unit records its exit code in the context, see `tf.RunCommandWithOutput`.
+			unitExitCode := &tf.DetailedExitCode{}
+
+			err := next(tf.ContextWithDetailedExitCode(ctx, unitExitCode), l, opts, r)
+
+			outcomes.mu.Lock()
+			outcomes.failed = outcomes.failed || err != nil || unitExitCode.Get() == tf.DetailedExitCodeError
+			outcomes.changed = outcomes.changed || err == nil && unitExitCode.Get() == detailedExitCodeChanges
+			outcomes.mu.Unlock()
+
+			return err
+		})
+	}
+
+	return func(ctx context.Context, l log.Logger, err error) error {
+		if err != nil {
+			outcomes.mu.Lock()
+			outcomes.failed = true
+			outcomes.mu.Unlock()
+		}
+
+		policy := detailedExitCodePolicy(opts)
+		code := outcomes.exitCode(policy)
+
+		l.Debugf("Exiting with code %d, following the %s policy of --detailed-exit-code", code, policy)
+
+		if exitCode := tf.DetailedExitCodeFromContext(ctx); exitCode != nil {
+			exitCode.ResetSuccess()
+			exitCode.Set(code)
+		}
+
+		if err != nil {
+			return errors.New(DetailedExitCodeErr{err: err, code: code})
+		}
+
+		return nil
+	}, nil
+}
+
+// detailedExitCodePolicy returns the policy of --detailed-exit-code, all-clean by default.
+func detailedExitCodePolicy(opts *options.TerragruntOptions) string {
+	if opts.DetailedExitCodePolicy == "" {
+		return DetailedExitCodePolicyAllClean
+	}
+
+	return opts.DetailedExitCodePolicy
+}
@@ -0,0 +1,183 @@
+package runall
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/report"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+	"github.com/gruntwork-io/terragrunt/tf"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+func TestDetailedExitCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		policy   string
+		codes    []int
+		expected int
+	}{
+		{codes: []int{0, 0}, expected: 0},
+		{codes: []int{0, 2}, expected: 2},
+		{codes: []int{2, 1}, expected: 1},
+		{policy: DetailedExitCodePolicyAllClean, codes: []int{2, 1}, expected: 1},
+		{policy: DetailedExitCodePolicyAnyChanges, codes: []int{2, 1}, expected: 2},
+		{policy: DetailedExitCodePolicyAnyChanges, codes: []int{0, 1}, expected: 1},
+		{policy: DetailedExitCodePolicyAnyChanges, codes: []int{0, 0}, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			t.Parallel()
+
+			rootDir := t.TempDir()
+
+			l := logger.CreateLogger()
+
+			opts, err := options.NewTerragruntOptionsForTest(filepath.Join(rootDir, "terragrunt.hcl"))
+			require.NoError(t, err)
+
+			opts.WorkingDir = rootDir
+			opts.RunsDir = filepath.Join(rootDir, "runs")
+			opts.TerraformCommand = "plan"
+			opts.TerraformCliArgs = []string{"plan"}
+			opts.DetailedExitCode = true
+			opts.DetailedExitCodePolicy = tt.policy
+
+			units := common.Units{}
+
+			for i, code := range tt.codes {
+				unit := &common.Unit{Path: filepath.Join(rootDir, "unit", string(rune('a'+i)))}
+				unit.TerragruntOptions = opts.Clone()
+				unit.TerragruntOptions.TerragruntConfigPath = filepath.Join(unit.Path, "terragrunt.hcl")
+				require.NoError(t, os.MkdirAll(unit.Path, 0755))
+				require.NoError(t, os.WriteFile(unit.TerragruntOptions.TerragruntConfigPath, []byte(""), 0644))
+				unit.TerragruntOptions.RunTerragrunt = func(ctx context.Context, _ log.Logger, _ *options.TerragruntOptions, _ *report.Report) error {
+					if code == tf.DetailedExitCodeError {
+						return errors.New("plan failed")
+					}
+
+					tf.DetailedExitCodeFromContext(ctx).Set(code)
+
+					return nil
+				}
+
+				units = append(units, unit)
+			}
+
+			exitCode := &tf.DetailedExitCode{}
+			ctx := tf.ContextWithDetailedExitCode(t.Context(), exitCode)
+
+			err = runStack(ctx, l, opts, &fakeStackRunner{stack: &common.Stack{TerragruntOptions: opts, Units: units}})
+			require.NoError(t, err)
+			assert.Equal(t, []string{"plan", tf.FlagNameDetailedExitCode}, []string(opts.TerraformCliArgs))
+			assert.Equal(t, tt.expected, exitCode.Get())
+		})
+	}
+}
+
+func TestDetailedExitCodeRunError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		policy   string
+		expected int
+	}{
+		{policy: DetailedExitCodePolicyAllClean, expected: 1},
+		{policy: DetailedExitCodePolicyAnyChanges, expected: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			t.Parallel()
+
+			rootDir := t.TempDir()
+
+			l := logger.CreateLogger()
+
+			opts, err := options.NewTerragruntOptionsForTest(filepath.Join(rootDir, "terragrunt.hcl"))
+			require.NoError(t, err)
+
+			opts.WorkingDir = rootDir
+			opts.RunsDir = filepath.Join(rootDir, "runs")
+			opts.TerraformCommand = "plan"
+			opts.TerraformCliArgs = []string{"plan"}
+			opts.DetailedExitCode = true
+			opts.DetailedExitCodePolicy = tt.policy
+
+			// The run fails after the plan of the unit, as the manifest of the plans can't be written over a file.
+			opts.OutputFolder = filepath.Join(rootDir, "plans")
+			require.NoError(t, os.WriteFile(opts.OutputFolder, []byte(""), 0644))
+
+			unit := &common.Unit{Path: filepath.Join(rootDir, "unit")}
+			unit.TerragruntOptions = opts.Clone()
+			unit.TerragruntOptions.TerragruntConfigPath = filepath.Join(unit.Path, "terragrunt.hcl")
+			require.NoError(t, os.MkdirAll(unit.Path, 0755))
+			require.NoError(t, os.WriteFile(unit.TerragruntOptions.TerragruntConfigPath, []byte(""), 0644))
+			unit.TerragruntOptions.RunTerragrunt = func(ctx context.Context, _ log.Logger, _ *options.TerragruntOptions, _ *report.Report) error {
+				tf.DetailedExitCodeFromContext(ctx).Set(2)
+				return nil
+			}
+
+			exitCode := &tf.DetailedExitCode{}
+			ctx := tf.ContextWithDetailedExitCode(t.Context(), exitCode)
+
+			err = runStack(ctx, l, opts, &fakeStackRunner{stack: &common.Stack{TerragruntOptions: opts, Units: common.Units{unit}}})
+			require.ErrorContains(t, err, "plans")
+
+			code, err := util.GetExitCode(err)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, code)
+			assert.Equal(t, tt.expected, exitCode.Get())
+		})
+	}
+}
+
+// fakeStackRunner runs the units of its stack one after the other.
+type fakeStackRunner struct {
+	common.StackRunner
+	stack *common.Stack
+}
+
+func (runner *fakeStackRunner) GetStack() *common.Stack {
+	return runner.stack
+}
+
+func (runner *fakeStackRunner) LogUnitDeployOrder(log.Logger, string) error {
+	return nil
+}
+
+func (runner *fakeStackRunner) Run(ctx context.Context, l log.Logger, _ *options.TerragruntOptions) error {
+	var errs error
+
+	for _, unit := range runner.stack.Units {
+		if err := unit.TerragruntOptions.RunTerragrunt(ctx, l, unit.TerragruntOptions, nil); err != nil {
+			errs = errors.Join(errs, err)
+		}
+	}
+
+	return errs
+}
+
+func TestDetailedExitCodeUnsupportedCommand(t *testing.T) {
+	t.Parallel()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(t.TempDir(), "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	opts.TerraformCommand = "apply"
+	opts.DetailedExitCode = true
+
+	_, err = trackDetailedExitCode(opts, nil)
+	require.EqualError(t, err, "apply with run --all --detailed-exit-code is not supported, only plan has a detailed exit code")
+}
@@ -0,0 +1,185 @@
+package runall
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/experiment"
+	"github.com/gruntwork-io/terragrunt/internal/report"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/tf"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// fingerprintsFileName is the name of the file in the download dir the fingerprints of the units are persisted to
+// after their successful applies, so that run --all --skip-unchanged skips the units that haven't changed since.
+const fingerprintsFileName = "unit-fingerprints.json"
+
+// unitFingerprints are the fingerprints of the last successful applies of the units, see `common.UnitFingerprint`.
+type unitFingerprints struct {
+	// Units are the fingerprints of the units, by path relative to the working dir.
+	Units map[string]string `json:"units"`
+}
+
+// trackFingerprints checks the fingerprints of the given units as they run, and returns a function persisting the
+// fingerprints of the units that were applied successfully, or nil if neither --skip-unchanged is set nor the
+// fingerprints are persisted. With --skip-unchanged, the units whose fingerprint is the one of their last successful
+// apply, and whose dependencies were all skipped, are skipped. Once --skip-unchanged was used, the applies of run --all
+// without it keep the fingerprints up to date, so the units they change aren't skipped by the next runs.
+func trackFingerprints(opts *options.TerragruntOptions, units common.Units) (func(l log.Logger) error, error) {
+	path := filepath.Join(opts.DownloadDir, fingerprintsFileName)
+
+	if !opts.SkipUnchanged {
+		if opts.TerraformCommand != tf.CommandNameApply || !util.FileExists(path) {
+			return nil, nil
+		}
+	} else {
+		if opts.TerraformCommand != tf.CommandNamePlan && opts.TerraformCommand != tf.CommandNameApply {
+			return nil, errors.New(SkipUnchangedUnsupportedCommandErr{command: opts.TerraformCommand})
+		}
+
+		if opts.JSONOutputFolder != "" {
+			return nil, errors.New(SkipUnchangedWithJSONOutDirErr{})
+		}
+
+		if opts.DriftCheck {
+			return nil, errors.New(SkipUnchangedWithDriftCheckErr{})
+		}
+	}
+
+	previous, err := readFingerprints(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		mu      sync.Mutex
+		skipped = map[*common.Unit]bool{}
+		// applied are the fingerprints of the units applied by the run, empty for the units whose apply failed.
+		applied = map[string]string{}
+		tracked = map[*common.Unit]string{}
+	)
+
+	for _, unit := range units {
+		if unit.FlagExcluded || unit.AssumeAlreadyApplied {
+			continue
+		}
+
+		tracked[unit] = relPath(opts, unit.Path)
+	}
+
+	for unit, name := range tracked {
+		wrapUnitRun(unit, func(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, r *report.Report, next runTerragruntFunc) error {
+			check := common.NewFingerprintCheck(opts.TerragruntConfigPath, previous.Units[name], opts.SkipUnchanged && dependenciesSkipped(&mu, unit, tracked, skipped))
+
+			err := next(common.ContextWithFingerprintCheck(ctx, check), l, opts, r)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			switch {
+			case check.Skipped():
+				skipped[unit] = true
+
+				endUnchangedRun(l, opts, r, unit)
+			case opts.TerraformCommand == tf.CommandNameApply && err == nil:
+				applied[name] = check.Fingerprint()
+			case opts.TerraformCommand == tf.CommandNameApply:
+				// The failed apply may have changed some of the resources of the unit, so it must run again.
+				applied[name] = ""
+			}
+
+			return err
+		})
+	}
+
+	return func(l log.Logger) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if opts.SkipUnchanged {
+			l.Infof("Skipped %d units unchanged since their last successful apply", len(skipped))
+		}
+
+		if len(applied) == 0 {
+			return nil
+		}
+
+		for name, fingerprint := range applied {
+			if fingerprint == "" {
+				delete(previous.Units, name)
+				continue
+			}
+
+			previous.Units[name] = fingerprint
+		}
+
+		data, err := json.MarshalIndent(previous, "", "  ")
+		if err != nil {
+			return errors.New(err)
+		}
+
+		return writeFileAtomically(path, data)
+	}, nil
+}
+
+// dependenciesSkipped returns true if all the tracked dependencies of the given unit were skipped, as unchanged.
+func dependenciesSkipped(mu *sync.Mutex, unit *common.Unit, tracked map[*common.Unit]string, skipped map[*common.Unit]bool) bool {
+	mu.Lock()
+	defer mu.Unlock()
+
+	for _, dep := range unit.Dependencies {
+		if _, ok := tracked[dep]; ok && !skipped[dep] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// endUnchangedRun records in the report that the given unit was skipped, as it is unchanged.
+func endUnchangedRun(l log.Logger, opts *options.TerragruntOptions, r *report.Report, unit *common.Unit) {
+	if !opts.Experiments.Evaluate(experiment.Report) {
+		return
+	}
+
+	run, err := r.EnsureRun(unit.Path)
+	if err != nil {
+		l.Errorf("Error ensuring run for unit %s: %v", unit.Path, err)
+		return
+	}
+
+	if err := r.EndRun(run.Path, report.WithResult(report.ResultExcluded), report.WithReason(report.ReasonUnchanged)); err != nil {
+		l.Errorf("Error ending run for unit %s: %v", unit.Path, err)
+	}
+}
+
+// readFingerprints reads the fingerprints at the given path, empty if there are none.
+func readFingerprints(path string) (*unitFingerprints, error) {
+	fingerprints := &unitFingerprints{Units: map[string]string{}}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fingerprints, nil
+		}
+
+		return nil, errors.New(err)
+	}
+
+	if err := json.Unmarshal(data, fingerprints); err != nil {
+		return nil, errors.Errorf("invalid fingerprints %s: %w", path, err)
+	}
+
+	if fingerprints.Units == nil {
+		fingerprints.Units = map[string]string{}
+	}
+
+	return fingerprints, nil
+}
@@ -0,0 +1,171 @@
+package runall
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/report"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+)
+
+func TestSkipUnchanged(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+
+	for _, name := range []string{"vpc", "app", "db"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(rootDir, name), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(rootDir, name, "terragrunt.hcl"), []byte(""), 0644))
+	}
+
+	l := logger.CreateLogger()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(rootDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	opts.WorkingDir = rootDir
+	opts.DownloadDir = filepath.Join(rootDir, ".terragrunt-cache")
+
+	failing := ""
+
+	// run runs the units of the stack as run.Run does, checking their fingerprints once their configs are parsed, and
+	// returns the units that weren't skipped.
+	run := func(command string, skipUnchanged bool) []string {
+		t.Helper()
+
+		vpc := &common.Unit{Path: filepath.Join(rootDir, "vpc")}
+		app := &common.Unit{Path: filepath.Join(rootDir, "app"), Dependencies: common.Units{vpc}}
+		db := &common.Unit{Path: filepath.Join(rootDir, "db")}
+		units := common.Units{vpc, app, db}
+
+		ran := []string{}
+
+		for _, unit := range units {
+			name := filepath.Base(unit.Path)
+
+			unit.TerragruntOptions = opts.Clone()
+			unit.TerragruntOptions.TerraformCommand = command
+			unit.TerragruntOptions.SkipUnchanged = skipUnchanged
+			unit.TerragruntOptions.TerragruntConfigPath = filepath.Join(unit.Path, "terragrunt.hcl")
+			unit.TerragruntOptions.WorkingDir = unit.Path
+			unit.TerragruntOptions.RunTerragrunt = func(ctx context.Context, _ log.Logger, opts *options.TerragruntOptions, _ *report.Report) error {
+				if check := common.FingerprintCheckFromContext(ctx); check != nil {
+					skip, err := check.Skip(opts.TerragruntConfigPath, opts, &config.TerragruntConfig{})
+					if err != nil || skip {
+						return err
+					}
+				}
+
+				ran = append(ran, name)
+
+				if name == failing {
+					return errors.New(name + " failed")
+				}
+
+				return nil
+			}
+		}
+
+		runOpts := opts.Clone()
+		runOpts.TerraformCommand = command
+		runOpts.SkipUnchanged = skipUnchanged
+
+		saveFingerprints, err := trackFingerprints(runOpts, units)
+		require.NoError(t, err)
+
+		for _, unit := range units {
+			_ = unit.TerragruntOptions.RunTerragrunt(t.Context(), l, unit.TerragruntOptions, report.NewReport())
+		}
+
+		if saveFingerprints != nil {
+			require.NoError(t, saveFingerprints(l))
+		}
+
+		return ran
+	}
+
+	// Without fingerprints, all the units run.
+	assert.Equal(t, []string{"vpc", "app", "db"}, run("apply", true))
+	assert.Equal(t, []string{}, run("apply", true))
+	assert.Equal(t, []string{}, run("plan", true))
+
+	// Changed units are run, along with their dependents.
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "vpc", "main.tf"), []byte(""), 0644))
+	assert.Equal(t, []string{"vpc", "app"}, run("plan", true))
+	assert.Equal(t, []string{"vpc", "app"}, run("apply", true))
+	assert.Equal(t, []string{}, run("apply", true))
+
+	// The units whose apply failed run again.
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "db", "main.tf"), []byte(""), 0644))
+
+	failing = "db"
+
+	assert.Equal(t, []string{"db"}, run("apply", true))
+	require.NoError(t, os.Remove(filepath.Join(rootDir, "db", "main.tf")))
+	assert.Equal(t, []string{"db"}, run("apply", true))
+
+	failing = ""
+
+	assert.Equal(t, []string{"db"}, run("apply", true))
+
+	// The applies without --skip-unchanged keep the fingerprints up to date.
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "db", "main.tf"), []byte(""), 0644))
+	assert.Equal(t, []string{"vpc", "app", "db"}, run("apply", false))
+	assert.Equal(t, []string{}, run("apply", true))
+
+	fingerprints, err := readFingerprints(filepath.Join(opts.DownloadDir, fingerprintsFileName))
+	require.NoError(t, err)
+	assert.Len(t, fingerprints.Units, 3)
+}
+
+func TestSkipUnchangedErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		expected error
+		setup    func(opts *options.TerragruntOptions)
+		name     string
+	}{
+		{
+			name:     "unsupported command",
+			setup:    func(opts *options.TerragruntOptions) { opts.TerraformCommand = "destroy" },
+			expected: SkipUnchangedUnsupportedCommandErr{command: "destroy"},
+		},
+		{
+			name:     "json out dir",
+			setup:    func(opts *options.TerragruntOptions) { opts.JSONOutputFolder = "json" },
+			expected: SkipUnchangedWithJSONOutDirErr{},
+		},
+		{
+			name:     "drift check",
+			setup:    func(opts *options.TerragruntOptions) { opts.DriftCheck = true },
+			expected: SkipUnchangedWithDriftCheckErr{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts, err := options.NewTerragruntOptionsForTest(filepath.Join(t.TempDir(), "terragrunt.hcl"))
+			require.NoError(t, err)
+
+			opts.TerraformCommand = "plan"
+			opts.SkipUnchanged = true
+			tc.setup(opts)
+
+			_, err = trackFingerprints(opts, common.Units{})
+			require.ErrorIs(t, err, tc.expected)
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package runall
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/report"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/tf"
+)
+
+// planSummary is the summary of the changes planned by the units of run --all plan, written to --plan-summary-file.
+type planSummary struct {
+	// Units are the units whose plan reported its changes, by path relative to the working dir, the units whose plan
+	// failed being left out.
+	Units            []*planSummaryUnit `json:"units"`
+	Totals           planSummaryChanges `json:"totals"`
+	UnitsWithChanges int                `json:"units_with_changes"`
+}
+
+type planSummaryUnit struct {
+	// Resources are the resources the unit plans to change, read from its JSON plan, written with --json-out-dir.
+	Resources []*planSummaryResource `json:"resources,omitempty"`
+	Unit      string                 `json:"unit"`
+	Changes   planSummaryChanges     `json:"changes"`
+}
+
+type planSummaryChanges struct {
+	Add     int `json:"add"`
+	Change  int `json:"change"`
+	Destroy int `json:"destroy"`
+	Import  int `json:"import"`
+}
+
+type planSummaryResource struct {
+	Address string   `json:"address"`
+	Actions []string `json:"actions"`
+}
+
+// hasChanges returns true if the unit plans to change any resource.
+func (unit *planSummaryUnit) hasChanges() bool {
+	return unit.Changes != planSummaryChanges{}
+}
+
+// trackPlanSummary records the changes the given units plan to make, as reported by OpenTofu/Terraform, and returns a
+// function summarizing them once the run finished, or nil if no summary is requested.
+func trackPlanSummary(opts *options.TerragruntOptions, units common.Units) (func(l log.Logger) error, error) {
+	if !opts.PlanSummary && opts.PlanSummaryFile == "" {
+		return nil, nil
+	}
+
+	if opts.TerraformCommand != tf.CommandNamePlan {
+		return nil, errors.New(PlanSummaryUnsupportedCommandErr{command: opts.TerraformCommand})
+	}
+
+	runs := map[*common.Unit]*report.Run{}
+
+	for _, unit := range units {
+		if unit.FlagExcluded || unit.AssumeAlreadyApplied {
+			continue
+		}
+
+		run, err := report.NewRun(unit.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		runs[unit] = run
+
+		wrapUnitRun(unit, func(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, r *report.Report, next runTerragruntFunc) error {
+			out := opts.Writer
+			opts.Writer = report.NewChangesWriter(out, run)
+			err := next(ctx, l, opts, r)
+			opts.Writer = out
+
+			return err
+		})
+	}
+
+	started := time.Now()
+
+	return func(l log.Logger) error {
+		summary := &planSummary{Units: []*planSummaryUnit{}}
+
+		for unit, run := range runs {
+			if run.Changes == nil {
+				continue
+			}
+
+			summaryUnit := &planSummaryUnit{
+				Unit: relPath(opts, unit.Path),
+				Changes: planSummaryChanges{
+					Add:     run.Changes.Add,
+					Change:  run.Changes.Change,
+					Destroy: run.Changes.Destroy,
+					Import:  run.Changes.Import,
+				},
+			}
+
+			if jsonFile := unit.OutputJSONFile(l, opts); jsonFile != "" {
+				// A JSON plan older than the run was left by a previous run.
+				if writtenSince(jsonFile, started) {
+					resources, err := readPlanResourceChanges(jsonFile)
+					if err != nil {
+						l.Warnf("Failed to read the resource changes of unit %s from %s: %v", summaryUnit.Unit, jsonFile, err)
+					}
+
+					summaryUnit.Resources = resources
+				}
+			}
+
+			summary.add(summaryUnit)
+		}
+
+		slices.SortFunc(summary.Units, func(a, b *planSummaryUnit) int {
+			return strings.Compare(a.Unit, b.Unit)
+		})
+
+		if opts.PlanSummary {
+			if err := summary.write(opts.Writer); err != nil {
+				return err
+			}
+		}
+
+		if opts.PlanSummaryFile != "" {
+			path := opts.PlanSummaryFile
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(opts.WorkingDir, path)
+			}
+
+			if err := summary.writeFile(path); err != nil {
+				return err
+			}
+
+			l.Infof("Saved the summary of the plans of %d units to %s", len(summary.Units), path)
+		}
+
+		return nil
+	}, nil
+}
+
+// add adds the given unit to the summary.
+func (summary *planSummary) add(unit *planSummaryUnit) {
+	summary.Units = append(summary.Units, unit)
+
+	if !unit.hasChanges() {
+		return
+	}
+
+	summary.UnitsWithChanges++
+	summary.Totals.Add += unit.Changes.Add
+	summary.Totals.Change += unit.Changes.Change
+	summary.Totals.Destroy += unit.Changes.Destroy
+	summary.Totals.Import += unit.Changes.Import
+}
+
+// write writes the summary to the given writer, listing the units with changes only.
+func (summary *planSummary) write(w io.Writer) error {
+	totals := summary.Totals
+
+	fmt.Fprintf(w, "\n❯❯ Plan Summary  %d of %d units with changes\n", summary.UnitsWithChanges, len(summary.Units))
+	fmt.Fprintf(w, "   %d to add, %d to change, %d to destroy", totals.Add, totals.Change, totals.Destroy)
+
+	if totals.Import > 0 {
+		fmt.Fprintf(w, ", %d to import", totals.Import)
+	}
+
+	fmt.Fprintln(w)
+
+	if summary.UnitsWithChanges == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(w)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0) //nolint:mnd
+
+	for _, unit := range summary.Units {
+		if unit.hasChanges() {
+			fmt.Fprintf(tw, "   %s\t+%d\t~%d\t-%d\n", unit.Unit, unit.Changes.Add, unit.Changes.Change, unit.Changes.Destroy)
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// writeFile writes the summary as JSON to the given path.
+func (summary *planSummary) writeFile(path string) error {
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return errors.New(err)
+	}
+
+	if err := os.WriteFile(path, data, 0644); err != nil { //nolint:mnd
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// noOpActions are the actions of the resources of a JSON plan that don't change them.
+var noOpActions = [][]string{{"no-op"}, {"read"}}
+
+// readPlanResourceChanges reads the resources the JSON plan at the given path, written by `show -json`, changes or
+// imports.
+func readPlanResourceChanges(path string) ([]*planSummaryResource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	var plan struct {
+		ResourceChanges []struct {
+			Change struct {
+				Importing any      `json:"importing"`
+				Actions   []string `json:"actions"`
+			} `json:"change"`
+			Address string `json:"address"`
+		} `json:"resource_changes"`
+	}
+
+	if err := json.Unmarshal(data, &plan); err != nil {
+		return nil, errors.Errorf("invalid JSON plan %s: %w", path, err)
+	}
+
+	resources := []*planSummaryResource{}
+
+	for _, change := range plan.ResourceChanges {
+		isNoOp := slices.ContainsFunc(noOpActions, func(actions []string) bool {
+			return slices.Equal(actions, change.Change.Actions)
+		})
+
+		if isNoOp && change.Change.Importing == nil {
+			continue
+		}
+
+		resources = append(resources, &planSummaryResource{Address: change.Address, Actions: change.Change.Actions})
+	}
+
+	return resources, nil
+}
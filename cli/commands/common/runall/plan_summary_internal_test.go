@@ -0,0 +1,129 @@
+package runall
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/internal/report"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+)
+
+func TestPlanSummary(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+
+	l := logger.CreateLogger()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(rootDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+
+	opts.WorkingDir = rootDir
+	opts.TerraformCommand = "plan"
+	opts.JSONOutputFolder = filepath.Join(rootDir, "json")
+	opts.PlanSummary = true
+	opts.PlanSummaryFile = "plan-summary.json"
+	opts.Writer = &out
+
+	outputs := map[string]string{
+		"vpc": "No changes. Your infrastructure matches the configuration.",
+		"db":  "Plan: 1 to import, 2 to add, 1 to change, 0 to destroy.",
+		"app": "Plan: 1 to add, 0 to change, 1 to destroy.",
+		// The plan of the failed unit reported nothing.
+		"failed": "Error: boom",
+	}
+
+	units := common.Units{}
+
+	for name, output := range outputs {
+		unit := &common.Unit{Path: filepath.Join(rootDir, name)}
+		unit.TerragruntOptions = opts.Clone()
+		unit.TerragruntOptions.RunTerragrunt = func(_ context.Context, _ log.Logger, opts *options.TerragruntOptions, _ *report.Report) error {
+			_, err := fmt.Fprintln(opts.Writer, output)
+			return err
+		}
+
+		units = append(units, unit)
+	}
+
+	summarize, err := trackPlanSummary(opts, units)
+	require.NoError(t, err)
+
+	// The JSON plan of app, written with --json-out-dir.
+	jsonPlan := `{"resource_changes": [
+		{"address": "aws_instance.app", "change": {"actions": ["create"]}},
+		{"address": "aws_s3_bucket.logs", "change": {"actions": ["delete"]}},
+		{"address": "aws_iam_role.app", "change": {"actions": ["no-op"]}}
+	]}`
+	require.NoError(t, os.MkdirAll(filepath.Join(rootDir, "json", "app"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "json", "app", "tfplan.json"), []byte(jsonPlan), 0644))
+
+	for _, unit := range units {
+		require.NoError(t, unit.TerragruntOptions.RunTerragrunt(t.Context(), l, unit.TerragruntOptions, nil))
+	}
+
+	out.Reset()
+	require.NoError(t, summarize(l))
+
+	assert.Equal(t, `
+❯❯ Plan Summary  2 of 3 units with changes
+   3 to add, 1 to change, 1 to destroy, 1 to import
+
+   app  +1  ~0  -1
+   db   +2  ~1  -0
+`, out.String())
+
+	data, err := os.ReadFile(filepath.Join(rootDir, "plan-summary.json"))
+	require.NoError(t, err)
+
+	summary := &planSummary{}
+	require.NoError(t, json.Unmarshal(data, summary))
+
+	assert.Equal(t, &planSummary{
+		Units: []*planSummaryUnit{
+			{
+				Unit:    "app",
+				Changes: planSummaryChanges{Add: 1, Destroy: 1},
+				Resources: []*planSummaryResource{
+					{Address: "aws_instance.app", Actions: []string{"create"}},
+					{Address: "aws_s3_bucket.logs", Actions: []string{"delete"}},
+				},
+			},
+			{Unit: "db", Changes: planSummaryChanges{Add: 2, Change: 1, Import: 1}},
+			{Unit: "vpc"},
+		},
+		Totals:           planSummaryChanges{Add: 3, Change: 1, Destroy: 1, Import: 1},
+		UnitsWithChanges: 2,
+	}, summary)
+}
+
+func TestPlanSummaryUnsupportedCommand(t *testing.T) {
+	t.Parallel()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(t.TempDir(), "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	opts.TerraformCommand = "apply"
+
+	summarize, err := trackPlanSummary(opts, nil)
+	require.NoError(t, err)
+	assert.Nil(t, summarize)
+
+	opts.PlanSummary = true
+
+	_, err = trackPlanSummary(opts, nil)
+	require.EqualError(t, err, "apply with run --all --plan-summary is not supported, only plan can be summarized")
+}
@@ -0,0 +1,228 @@
+package runall
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/report"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/tf"
+)
+
+// runStateFileName is the name of the file in the download dir the progress of a run --all is persisted to, so the
+// run can be resumed with --resume if it is interrupted.
+const runStateFileName = "run-state.json"
+
+// The statuses of the units in the run state.
+const (
+	unitStatusPending   = "pending"
+	unitStatusSucceeded = "succeeded"
+	unitStatusFailed    = "failed"
+)
+
+// runState is the progress of a run --all, persisted whenever a unit finishes.
+type runState struct {
+	// Units are the units of the run, by path relative to the working dir.
+	Units   map[string]*runStateUnit `json:"units"`
+	Command string                   `json:"command"`
+	Args    []string                 `json:"args"`
+
+	path string
+	mu   sync.Mutex
+}
+
+type runStateUnit struct {
+	Status string `json:"status"`
+	// Hash is the hash of the config of the unit when the run started, see `common.Unit.ConfigHash`.
+	Hash string `json:"hash"`
+}
+
+// trackRunState persists the progress of the run of the given units to the run state file, as the units finish. With
+// --resume, the units that succeeded in the last run of the same command and haven't changed since are skipped, along
+// with their dependencies being skipped for commands other than destroy, as the outputs of re-run dependencies may
+// change their inputs.
+func trackRunState(l log.Logger, opts *options.TerragruntOptions, units common.Units) error {
+	state := &runState{
+		Units:   map[string]*runStateUnit{},
+		Command: opts.TerraformCommand,
+		Args:    slices.Clone(opts.TerraformCliArgs),
+		path:    filepath.Join(opts.DownloadDir, runStateFileName),
+	}
+
+	tracked := map[*common.Unit]string{}
+
+	for _, unit := range units {
+		if unit.FlagExcluded || unit.AssumeAlreadyApplied {
+			continue
+		}
+
+		rel, err := filepath.Rel(opts.WorkingDir, unit.Path)
+		if err != nil {
+			return errors.New(err)
+		}
+
+		hash, err := unit.ConfigHash()
+		if err != nil {
+			return err
+		}
+
+		tracked[unit] = filepath.ToSlash(rel)
+		state.Units[tracked[unit]] = &runStateUnit{Status: unitStatusPending, Hash: hash}
+	}
+
+	if opts.Resume {
+		previous, err := readRunState(state.path)
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case previous == nil:
+			l.Warnf("No run to resume in %s, running all the units", opts.WorkingDir)
+		case previous.Command != state.Command || !slices.Equal(previous.Args, state.Args):
+			l.Warnf("The last run in %s was of a different command, running all the units", opts.WorkingDir)
+		default:
+			state.resume(l, previous, tracked, opts.TerraformCommand != tf.CommandNameDestroy)
+		}
+	}
+
+	for unit, path := range tracked {
+		if unit.AssumeAlreadyApplied {
+			continue
+		}
+
+		wrapUnitRun(unit, func(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, r *report.Report, next runTerragruntFunc) error {
+			err := next(ctx, l, opts, r)
+
+			status := unitStatusSucceeded
+			if err != nil {
+				status = unitStatusFailed
+			}
+
+			if saveErr := state.setStatus(path, status); saveErr != nil {
+				l.Warnf("Failed to save the progress of the run to %s: %v", state.path, saveErr)
+			}
+
+			return err
+		})
+	}
+
+	return state.save()
+}
+
+// resume marks the given units that succeeded in the previous run, and whose hash is the same, as already applied, so
+// they are skipped. If checkDependencies is set, a unit is only skipped if its tracked dependencies are skipped too.
+func (state *runState) resume(l log.Logger, previous *runState, tracked map[*common.Unit]string, checkDependencies bool) {
+	skipped := map[*common.Unit]bool{}
+
+	var canSkip func(unit *common.Unit) bool
+
+	canSkip = func(unit *common.Unit) bool {
+		if skip, ok := skipped[unit]; ok {
+			return skip
+		}
+
+		path := tracked[unit]
+		prev, ok := previous.Units[path]
+		skip := ok && prev.Status == unitStatusSucceeded && prev.Hash == state.Units[path].Hash
+
+		if skip && checkDependencies {
+			for _, dep := range unit.Dependencies {
+				if _, ok := tracked[dep]; ok && !canSkip(dep) {
+					skip = false
+					break
+				}
+			}
+		}
+
+		skipped[unit] = skip
+
+		return skip
+	}
+
+	count := 0
+
+	for unit, path := range tracked {
+		if canSkip(unit) {
+			unit.AssumeAlreadyApplied = true
+			state.Units[path].Status = unitStatusSucceeded
+			count++
+		}
+	}
+
+	l.Infof("Resuming the last run, skipping %d units that already succeeded and haven't changed", count)
+}
+
+// setStatus sets the status of the unit at the given path, and saves the run state.
+func (state *runState) setStatus(path, status string) error {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	state.Units[path].Status = status
+
+	return state.writeFile()
+}
+
+// save saves the run state.
+func (state *runState) save() error {
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	return state.writeFile()
+}
+
+// writeFile writes the run state to its file.
+func (state *runState) writeFile() error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return errors.New(err)
+	}
+
+	return writeFileAtomically(state.path, data)
+}
+
+// writeFileAtomically writes the given data to a temporary file renamed over the file at the given path, so an
+// interrupted write doesn't corrupt it.
+func writeFileAtomically(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return errors.New(err)
+	}
+
+	tmpPath := path + ".tmp"
+
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil { //nolint:mnd
+		return errors.New(err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// readRunState reads the run state at the given path, or returns nil if there is none.
+func readRunState(path string) (*runState, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, errors.New(err)
+	}
+
+	state := &runState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, errors.Errorf("invalid run state %s: %w", path, err)
+	}
+
+	return state, nil
+}
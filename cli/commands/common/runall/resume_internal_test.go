@@ -0,0 +1,99 @@
+package runall
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/report"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+)
+
+func TestResumeRunAll(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+
+	for _, name := range []string{"vpc", "app", "db"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(rootDir, name), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(rootDir, name, "terragrunt.hcl"), []byte(""), 0644))
+	}
+
+	l := logger.CreateLogger()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(rootDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	opts.WorkingDir = rootDir
+	opts.DownloadDir = filepath.Join(rootDir, ".terragrunt-cache")
+	opts.TerraformCommand = "apply"
+
+	// run runs the units of the stack, with the db unit failing, and returns the units that were run.
+	run := func(command string, resume bool) []string {
+		t.Helper()
+
+		vpc := &common.Unit{Path: filepath.Join(rootDir, "vpc")}
+		app := &common.Unit{Path: filepath.Join(rootDir, "app"), Dependencies: common.Units{vpc}}
+		db := &common.Unit{Path: filepath.Join(rootDir, "db")}
+		units := common.Units{vpc, app, db}
+
+		ran := []string{}
+
+		for _, unit := range units {
+			unit.TerragruntOptions = opts.Clone()
+			unit.TerragruntOptions.TerragruntConfigPath = filepath.Join(unit.Path, "terragrunt.hcl")
+			unit.TerragruntOptions.RunTerragrunt = func(_ context.Context, _ log.Logger, _ *options.TerragruntOptions, _ *report.Report) error {
+				if unit == db {
+					return errors.New("db failed")
+				}
+
+				// The files generated into the unit by its runs don't change it.
+				return os.WriteFile(filepath.Join(unit.Path, "backend.tf"), []byte(unit.Path), 0644)
+			}
+		}
+
+		runOpts := opts.Clone()
+		runOpts.TerraformCommand = command
+		runOpts.Resume = resume
+
+		require.NoError(t, trackRunState(l, runOpts, units))
+
+		for _, unit := range units {
+			if unit.AssumeAlreadyApplied {
+				continue
+			}
+
+			ran = append(ran, filepath.Base(unit.Path))
+			_ = unit.TerragruntOptions.RunTerragrunt(t.Context(), l, unit.TerragruntOptions, nil)
+		}
+
+		return ran
+	}
+
+	assert.Equal(t, []string{"vpc", "app", "db"}, run("apply", false))
+
+	// Only the failed unit is re-run.
+	assert.Equal(t, []string{"db"}, run("apply", true))
+
+	// Other commands don't resume the run.
+	assert.Equal(t, []string{"vpc", "app", "db"}, run("plan", true))
+	assert.Equal(t, []string{"vpc", "app", "db"}, run("apply", true))
+
+	// Changed units are re-run, along with their dependents.
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "vpc", "terragrunt.hcl"), []byte("# changed"), 0644))
+	assert.Equal(t, []string{"vpc", "app", "db"}, run("apply", true))
+	assert.Equal(t, []string{"db"}, run("apply", true))
+
+	state, err := readRunState(filepath.Join(opts.DownloadDir, runStateFileName))
+	require.NoError(t, err)
+	assert.Equal(t, unitStatusSucceeded, state.Units["app"].Status)
+	assert.Equal(t, unitStatusFailed, state.Units["db"].Status)
+}
@@ -0,0 +1,59 @@
+package runall
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/gruntwork-io/terragrunt/internal/report"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+// retryResults are the results, in the report of the last run, of the units --retry-failed runs again: the units that
+// failed, and the units that exited early, e.g. as a dependency of theirs failed, or the run was halted.
+var retryResults = []report.Result{report.ResultFailed, report.ResultEarlyExit}
+
+// retryFailed marks the given units as already applied, so they are skipped, except for the units that failed or
+// exited early in the last run written to the report file. If there is no report, all the units are run.
+func retryFailed(l log.Logger, opts *options.TerragruntOptions, units common.Units) error {
+	reportFile := opts.ReportFile
+	if !filepath.IsAbs(reportFile) {
+		reportFile = filepath.Join(opts.WorkingDir, reportFile)
+	}
+
+	format := opts.ReportFormat
+	if format == "" {
+		format = report.FormatCSV
+	}
+
+	results, err := report.ReadResults(reportFile, format, opts.WorkingDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			l.Warnf("No report of the last run in %s, running all the units", reportFile)
+			return nil
+		}
+
+		return err
+	}
+
+	count := 0
+
+	for _, unit := range units {
+		if unit.FlagExcluded || unit.AssumeAlreadyApplied {
+			continue
+		}
+
+		if result, ok := results[unit.Path]; ok && slices.Contains(retryResults, result) {
+			count++
+			continue
+		}
+
+		unit.AssumeAlreadyApplied = true
+	}
+
+	l.Infof("Retrying the %d units that failed or exited early in the last run", count)
+
+	return nil
+}
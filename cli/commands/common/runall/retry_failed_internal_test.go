@@ -0,0 +1,71 @@
+package runall
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/internal/report"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+)
+
+func TestRetryFailed(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+
+	l := logger.CreateLogger()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(rootDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	opts.WorkingDir = rootDir
+	opts.ReportFile = "report.json"
+	opts.ReportFormat = report.FormatJSON
+
+	vpc := &common.Unit{Path: filepath.Join(rootDir, "vpc")}
+	db := &common.Unit{Path: filepath.Join(rootDir, "db"), Dependencies: common.Units{vpc}}
+	app := &common.Unit{Path: filepath.Join(rootDir, "app"), Dependencies: common.Units{db}}
+	logs := &common.Unit{Path: filepath.Join(rootDir, "logs")}
+	added := &common.Unit{Path: filepath.Join(rootDir, "added")}
+	units := common.Units{vpc, db, app, logs, added}
+
+	// Without a report, all the units are run.
+	require.NoError(t, retryFailed(l, opts, units))
+
+	for _, unit := range units {
+		assert.False(t, unit.AssumeAlreadyApplied, unit.Path)
+	}
+
+	// The last run failed on db, and so exited early on app, which depends on it.
+	r := report.NewReport().WithWorkingDir(rootDir).WithFormat(report.FormatJSON)
+
+	for unit, result := range map[*common.Unit]report.Result{
+		vpc:  report.ResultSucceeded,
+		db:   report.ResultFailed,
+		app:  report.ResultEarlyExit,
+		logs: report.ResultExcluded,
+	} {
+		run, err := report.NewRun(unit.Path)
+		require.NoError(t, err)
+		require.NoError(t, r.AddRun(run))
+		require.NoError(t, r.EndRun(run.Path, report.WithResult(result)))
+	}
+
+	require.NoError(t, r.WriteToFile(opts.ReportFile))
+	require.NoError(t, retryFailed(l, opts, units))
+
+	retried := []string{}
+
+	for _, unit := range units {
+		if !unit.AssumeAlreadyApplied {
+			retried = append(retried, filepath.Base(unit.Path))
+		}
+	}
+
+	assert.Equal(t, []string{"db", "app"}, retried)
+}
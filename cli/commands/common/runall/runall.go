@@ -3,10 +3,14 @@ package runall
 import (
 	"context"
 	"os"
+	"path/filepath"
+	"slices"
 
 	"github.com/gruntwork-io/terragrunt/internal/runner"
 	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+	"github.com/gruntwork-io/terragrunt/internal/runs"
 
+	"github.com/gruntwork-io/terragrunt/config"
 	"github.com/gruntwork-io/terragrunt/internal/cli"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/internal/experiment"
@@ -50,6 +54,20 @@ func Run(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) err
 		}
 	}
 
+	if opts.Watch && !slices.Contains(watchCommands, opts.TerraformCommand) {
+		return errors.New(WatchUnsupportedCommandErr{command: opts.TerraformCommand})
+	}
+
+	// Dry runs output the execution plan only, neither reporting nor recording the run.
+	if opts.QueueDryRun {
+		stack, err := runner.FindStackInSubfolders(ctx, l, opts)
+		if err != nil {
+			return err
+		}
+
+		return queueDryRun(ctx, l, opts, stack.GetStack().Units)
+	}
+
 	stackOpts := []common.Option{}
 
 	if opts.Experiments.Evaluate(experiment.Report) {
@@ -77,9 +95,22 @@ func Run(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) err
 			defer r.WriteToFile(opts.ReportFile) //nolint:errcheck
 		}
 
+		if opts.ReportHTMLFile != "" {
+			defer r.WriteHTMLToFile(opts.ReportHTMLFile) //nolint:errcheck
+		}
+
+		if opts.ReportJUnitFile != "" {
+			defer r.WriteJUnitToFile(opts.ReportJUnitFile) //nolint:errcheck
+		}
+
 		if !opts.SummaryDisable {
 			defer r.WriteSummary(opts.Writer) //nolint:errcheck
 		}
+
+		if opts.DependencyOffline {
+			// Deferred after the summary is, so the mocked outputs are added to the report before it is written.
+			defer addOfflineMockedOutputs(r)
+		}
 	}
 
 	stack, err := runner.FindStackInSubfolders(ctx, l, opts, stackOpts...)
@@ -87,7 +118,148 @@ func Run(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) err
 		return err
 	}
 
-	return RunAllOnStack(ctx, l, opts, stack)
+	// Watched runs are neither tracked nor recorded, as they never finish, and their units log to the console only.
+	if opts.Watch {
+		if err := RunAllOnStack(ctx, l, opts, stack); err != nil {
+			return err
+		}
+
+		return Watch(ctx, l, opts, stack, stackOpts...)
+	}
+
+	return runStack(ctx, l, opts, stack)
+}
+
+// runStack runs the given stack, tracking the run, its units and their plans as set by the flags of the run, and
+// reporting on them once the run finished.
+func runStack(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, stack common.StackRunner) error {
+	if opts.FromArtifacts != "" {
+		if err := applyArtifacts(l, opts, stack.GetStack().Units); err != nil {
+			return err
+		}
+	}
+
+	if opts.RetryFailed {
+		if err := retryFailed(l, opts, stack.GetStack().Units); err != nil {
+			return err
+		}
+	}
+
+	if err := protectDestroy(ctx, l, opts, stack.GetStack().Units); err != nil {
+		return err
+	}
+
+	if err := previewDestroy(ctx, l, opts, stack.GetStack().Units); err != nil {
+		return err
+	}
+
+	if err := trackRunState(l, opts, stack.GetStack().Units); err != nil {
+		return err
+	}
+
+	saveFingerprints, err := trackFingerprints(opts, stack.GetStack().Units)
+	if err != nil {
+		return err
+	}
+
+	saveArtifacts, err := trackArtifacts(opts, stack.GetStack().Units)
+	if err != nil {
+		return err
+	}
+
+	removeSpeculativePlans, err := trackSpeculativePlan(opts, stack.GetStack().Units)
+	if err != nil {
+		return err
+	}
+
+	defer removeSpeculativePlans()
+
+	estimateCosts, err := trackCostEstimate(opts, stack.GetStack().Units)
+	if err != nil {
+		return err
+	}
+
+	reportDrift, err := trackDrift(opts, stack.GetStack().Units)
+	if err != nil {
+		return err
+	}
+
+	setExitCode, err := trackDetailedExitCode(opts, stack.GetStack().Units)
+	if err != nil {
+		return err
+	}
+
+	summarizePlans, err := trackPlanSummary(opts, stack.GetStack().Units)
+	if err != nil {
+		return err
+	}
+
+	runID, err := runs.NewID()
+	if err != nil {
+		return err
+	}
+
+	closeUnitLogs, err := routeUnitLogs(l, opts, runID, stack.GetStack().Units)
+	if err != nil {
+		return err
+	}
+
+	defer closeUnitLogs()
+
+	runCtx, finishRun, err := trackRun(ctx, l, opts, runID, stack.GetStack().Units)
+	if err != nil {
+		return err
+	}
+
+	err = RunAllOnStack(runCtx, l, opts, stack)
+	finishRun(err)
+
+	if saveFingerprints != nil {
+		if saveErr := saveFingerprints(l); saveErr != nil {
+			l.Warnf("Failed to save the fingerprints of the units: %v", saveErr)
+		}
+	}
+
+	// Without its manifest, the saved plans can't be applied with --from-artifacts, so failing to write it fails the
+	// run, unless it already failed.
+	if saveArtifacts != nil {
+		if saveErr := saveArtifacts(l); saveErr != nil {
+			if err != nil {
+				l.Warnf("Failed to save the manifest of the plans: %v", saveErr)
+			} else {
+				err = saveErr
+			}
+		}
+	}
+
+	if setExitCode != nil {
+		err = setExitCode(ctx, l, err)
+	}
+
+	if summarizePlans != nil {
+		if summaryErr := summarizePlans(l); summaryErr != nil {
+			l.Warnf("Failed to summarize the plans: %v", summaryErr)
+		}
+	}
+
+	// Exceeding the cost budget fails the run, unless it already failed.
+	if estimateCosts != nil {
+		if costErr := estimateCosts(ctx, l); costErr != nil {
+			if err != nil {
+				l.Warnf("Failed to estimate the costs: %v", costErr)
+			} else {
+				err = costErr
+			}
+		}
+	}
+
+	if reportDrift != nil {
+		if driftErr := reportDrift(l); driftErr != nil {
+			l.Warnf("Failed to report the drift: %v", driftErr)
+		}
+	}
+
+	return err
 }
 
 func RunAllOnStack(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, runner common.StackRunner) error {
@@ -120,6 +292,10 @@ func RunAllOnStack(ctx context.Context, l log.Logger, opts *options.TerragruntOp
 		}
 	}
 
+	if err := awaitApproval(ctx, l, opts, runner.GetStack().Units); err != nil {
+		return err
+	}
+
 	return telemetry.TelemeterFromContext(ctx).Collect(ctx, "run_all_on_stack", map[string]any{
 		"terraform_command": opts.TerraformCommand,
 		"working_dir":       opts.WorkingDir,
@@ -147,3 +323,33 @@ func RunAllOnStack(ctx context.Context, l log.Logger, opts *options.TerragruntOp
 		return nil
 	})
 }
+
+// addOfflineMockedOutputs adds the dependency outputs mocked while resolving dependencies offline to the report.
+func addOfflineMockedOutputs(r *report.Report) {
+	for _, mocked := range config.OfflineMockedDependencyOutputs() {
+		r.AddMockedOutputs(report.MockedOutputs{
+			Path:       filepath.Dir(mocked.ConfigPath),
+			Dependency: mocked.Dependency,
+			Outputs:    mocked.Outputs,
+		})
+	}
+}
+
+// runTerragruntFunc runs Terragrunt with the given options, as `options.TerragruntOptions.RunTerragrunt`.
+type runTerragruntFunc func(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, r *report.Report) error
+
+// wrapUnitRun wraps the run of the given unit with the given function, which is passed the wrapped run as next. The
+// unit's options are also cloned to run other commands, e.g. to fetch the outputs of its dependencies, which aren't the
+// run of the unit, so these are run as they are.
+func wrapUnitRun(unit *common.Unit, fn func(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, r *report.Report, next runTerragruntFunc) error) {
+	unitOpts := unit.TerragruntOptions
+	next := unitOpts.RunTerragrunt
+
+	unitOpts.RunTerragrunt = func(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, r *report.Report) error {
+		if opts != unitOpts {
+			return next(ctx, l, opts, r)
+		}
+
+		return fn(ctx, l, opts, r, next)
+	}
+}
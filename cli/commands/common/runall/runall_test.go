@@ -28,3 +28,20 @@ func TestMissingRunAllArguments(t *testing.T) {
 	fmt.Println(err, errors.Unwrap(err))
 	assert.True(t, ok)
 }
+
+func TestRunAllWatchUnsupportedCommand(t *testing.T) {
+	t.Parallel()
+
+	tgOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	tgOptions.TerraformCommand = "apply"
+	tgOptions.Watch = true
+
+	err = runall.Run(t.Context(), logger.CreateLogger(), tgOptions)
+	require.Error(t, err)
+
+	var unsupportedCommand runall.WatchUnsupportedCommandErr
+
+	require.ErrorAs(t, err, &unsupportedCommand)
+}
@@ -0,0 +1,217 @@
+package runall
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/report"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+	"github.com/gruntwork-io/terragrunt/internal/runs"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+// abortPollInterval is how often a run checks whether it was requested to be aborted.
+const abortPollInterval = time.Second
+
+// trackRun records the run of the given units, of the given ID, and the start, finish and outcome of each of them, in
+// the runs dir, so the run can be inspected, and aborted, with the runs command. The record is only saved once the first unit starts,
+// so a run declined at its prompt leaves none. With --status-address, the live status of the run is also served over
+// HTTP while it runs. The returned context is cancelled when the run is requested to be
+// aborted, and the returned function must be called with the error of the run once it finishes.
+func trackRun(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, id string, units common.Units) (context.Context, func(err error), error) {
+	runsDir := opts.RunsDir
+	if runsDir == "" {
+		runsDir = filepath.Join(opts.DownloadDir, runs.DefaultDirName)
+	}
+
+	store := runs.NewStore(runsDir)
+
+	run := &runs.Run{
+		ID:         id,
+		Command:    opts.TerraformCommand,
+		Args:       slices.Clone(opts.TerraformCliArgs),
+		WorkingDir: opts.WorkingDir,
+		Status:     runs.StatusRunning,
+		PID:        os.Getpid(),
+		Units:      []*runs.Unit{},
+	}
+
+	tracked := map[*common.Unit]*runs.Unit{}
+
+	for _, unit := range units {
+		if unit.FlagExcluded || unit.AssumeAlreadyApplied {
+			continue
+		}
+
+		rel, err := filepath.Rel(opts.WorkingDir, unit.Path)
+		if err != nil {
+			return nil, nil, errors.New(err)
+		}
+
+		tracked[unit] = &runs.Unit{Path: filepath.ToSlash(rel), Outcome: runs.OutcomePending}
+		run.Units = append(run.Units, tracked[unit])
+	}
+
+	slices.SortFunc(run.Units, func(a, b *runs.Unit) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	var (
+		mu      sync.Mutex
+		started bool
+		aborted bool
+		done    = make(chan struct{})
+	)
+
+	// save and start must be called with mu locked.
+	save := func() {
+		if err := store.Save(run); err != nil {
+			l.Warnf("Failed to save the record of run %s to %s: %v", run.ID, store.Dir, err)
+		}
+	}
+
+	start := func() {
+		if started {
+			return
+		}
+
+		started = true
+		run.StartedAt = time.Now()
+
+		l.Infof("Starting run %s, run `terragrunt runs show %s` to inspect it", run.ID, run.ID)
+
+		go func() {
+			ticker := time.NewTicker(abortPollInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-done:
+					return
+				case <-ticker.C:
+					if store.AbortRequested(run.ID) {
+						mu.Lock()
+						aborted = true
+						mu.Unlock()
+
+						l.Warnf("Aborting run %s", run.ID)
+						cancel()
+
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	// Serve the live status of the run on --status-address, if any, until it finishes.
+	var statusServer *runs.StatusServer
+
+	if opts.StatusAddress != "" {
+		var err error
+
+		statusServer, err = runs.ServeStatus(opts.StatusAddress, func() *runs.LiveStatus {
+			mu.Lock()
+			defer mu.Unlock()
+
+			return runs.NewLiveStatus(run, time.Now())
+		})
+		if err != nil {
+			cancel()
+			return nil, nil, err
+		}
+
+		l.Infof("Serving the status of run %s on http://%s", run.ID, statusServer.Addr())
+	}
+
+	for unit, record := range tracked {
+		wrapUnitRun(unit, func(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, r *report.Report, next runTerragruntFunc) error {
+			mu.Lock()
+
+			if aborted {
+				mu.Unlock()
+				return errors.New(RunAbortedErr{id: run.ID})
+			}
+
+			start()
+
+			startedAt := time.Now()
+			record.StartedAt = &startedAt
+			record.Outcome = runs.OutcomeRunning
+
+			save()
+			mu.Unlock()
+
+			err := next(ctx, l, opts, r)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			finishedAt := time.Now()
+			record.FinishedAt = &finishedAt
+			record.Outcome = runs.OutcomeSucceeded
+
+			if err != nil {
+				record.Outcome = runs.OutcomeFailed
+				record.Error = err.Error()
+			}
+
+			save()
+
+			return err
+		})
+	}
+
+	finish := func(err error) {
+		if statusServer != nil {
+			defer func() {
+				if err := statusServer.Close(); err != nil {
+					l.Warnf("Failed to stop serving the status of run %s: %v", run.ID, err)
+				}
+			}()
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		close(done)
+		cancel()
+
+		if !started {
+			return
+		}
+
+		finishedAt := time.Now()
+		run.FinishedAt = &finishedAt
+
+		failed := slices.ContainsFunc(run.Units, func(unit *runs.Unit) bool {
+			return unit.Outcome == runs.OutcomeFailed
+		})
+
+		switch {
+		case aborted:
+			run.Status = runs.StatusAborted
+		case err != nil || failed:
+			run.Status = runs.StatusFailed
+		default:
+			run.Status = runs.StatusSucceeded
+		}
+
+		save()
+
+		if err := store.ClearAbort(run.ID); err != nil {
+			l.Warnf("Failed to clear the request to abort run %s: %v", run.ID, err)
+		}
+	}
+
+	return ctx, finish, nil
+}
@@ -0,0 +1,115 @@
+package runall
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/internal/report"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+	"github.com/gruntwork-io/terragrunt/internal/runs"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+)
+
+func TestTrackRun(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	l := logger.CreateLogger()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(rootDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	opts.WorkingDir = rootDir
+	opts.RunsDir = filepath.Join(rootDir, "runs")
+	opts.TerraformCommand = "apply"
+
+	store := runs.NewStore(opts.RunsDir)
+
+	newUnits := func(runVpc func(ctx context.Context) error) common.Units {
+		vpc := &common.Unit{Path: filepath.Join(rootDir, "vpc"), TerragruntOptions: opts.Clone()}
+		vpc.TerragruntOptions.RunTerragrunt = func(ctx context.Context, _ log.Logger, _ *options.TerragruntOptions, _ *report.Report) error {
+			return runVpc(ctx)
+		}
+
+		app := &common.Unit{Path: filepath.Join(rootDir, "app"), TerragruntOptions: opts.Clone(), Dependencies: common.Units{vpc}}
+		app.TerragruntOptions.RunTerragrunt = func(context.Context, log.Logger, *options.TerragruntOptions, *report.Report) error {
+			return nil
+		}
+
+		return common.Units{vpc, app}
+	}
+
+	runUnit := func(ctx context.Context, unit *common.Unit) error {
+		return unit.TerragruntOptions.RunTerragrunt(ctx, l, unit.TerragruntOptions, nil)
+	}
+
+	// A successful run.
+	units := newUnits(func(context.Context) error { return nil })
+
+	ctx, finish, err := trackRun(t.Context(), l, opts, newRunID(t), units)
+	require.NoError(t, err)
+	require.NoError(t, runUnit(ctx, units[0]))
+	require.NoError(t, runUnit(ctx, units[1]))
+	finish(nil)
+
+	list, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, runs.StatusSucceeded, list[0].Status)
+	assert.Equal(t, runs.OutcomeSucceeded, list[0].Unit("vpc").Outcome)
+	assert.Equal(t, runs.OutcomeSucceeded, list[0].Unit("app").Outcome)
+	assert.NotNil(t, list[0].FinishedAt)
+
+	// An aborted run cancels its running units, and doesn't start any more.
+	vpcStarted := make(chan struct{})
+	units = newUnits(func(ctx context.Context) error {
+		close(vpcStarted)
+		<-ctx.Done()
+
+		return ctx.Err()
+	})
+
+	ctx, finish, err = trackRun(t.Context(), l, opts, newRunID(t), units)
+	require.NoError(t, err)
+
+	vpcErr := make(chan error)
+
+	go func() {
+		vpcErr <- runUnit(ctx, units[0])
+	}()
+
+	<-vpcStarted
+
+	list, err = store.List()
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, runs.StatusRunning, list[0].Status)
+	assert.Equal(t, runs.OutcomeRunning, list[0].Unit("vpc").Outcome)
+
+	require.NoError(t, store.RequestAbort(list[0].ID))
+	require.ErrorIs(t, <-vpcErr, context.Canceled)
+	require.ErrorAs(t, runUnit(ctx, units[1]), &RunAbortedErr{})
+	finish(nil)
+
+	run, err := store.Load(list[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, runs.StatusAborted, run.Status)
+	assert.Equal(t, runs.OutcomeFailed, run.Unit("vpc").Outcome)
+	assert.Equal(t, runs.OutcomePending, run.Unit("app").Outcome)
+	assert.False(t, store.AbortRequested(run.ID))
+}
+
+func newRunID(t *testing.T) string {
+	t.Helper()
+
+	id, err := runs.NewID()
+	require.NoError(t, err)
+
+	return id
+}
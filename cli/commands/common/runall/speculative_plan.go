@@ -0,0 +1,166 @@
+package runall
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/puzpuzpuz/xsync/v3"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/report"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/tf"
+)
+
+// trackSpeculativePlan records the outputs the given units plan to have, from the JSON of their plans, so the units
+// planned after them get the planned outputs of their dependencies rather than their current outputs, see
+// `config.Dependency.getSpeculativeOutputs`. The plans of the units that aren't saved, without --out-dir, are saved
+// to a temporary dir, removed by the returned function once the run finished.
+func trackSpeculativePlan(opts *options.TerragruntOptions, units common.Units) (func(), error) {
+	if !opts.SpeculativePlan {
+		return func() {}, nil
+	}
+
+	if opts.TerraformCommand != tf.CommandNamePlan {
+		return nil, errors.New(SpeculativePlanUnsupportedCommandErr{command: opts.TerraformCommand})
+	}
+
+	planDir, err := os.MkdirTemp("", "terragrunt-speculative-plan-*")
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	removePlanDir := func() {
+		os.RemoveAll(planDir) //nolint:errcheck
+	}
+
+	outputs := xsync.NewMapOf[string, []byte]()
+
+	for _, unit := range units {
+		// Units that aren't planned still get the planned outputs of their dependencies, e.g. in the stage gates.
+		unit.TerragruntOptions.SpeculativeOutputs = outputs
+
+		if unit.FlagExcluded || unit.AssumeAlreadyApplied {
+			continue
+		}
+
+		wrapUnitRun(unit, func(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, r *report.Report, next runTerragruntFunc) error {
+			planFile := unit.PlanFile(l, opts)
+			if planFile == "" {
+				planFile = filepath.Join(planDir, relPath(opts, unit.Path), tf.TerraformPlanFile)
+
+				if err := os.MkdirAll(filepath.Dir(planFile), os.ModePerm); err != nil {
+					return errors.New(err)
+				}
+
+				opts.TerraformCliArgs = append(slices.Clone(opts.TerraformCliArgs), "-out="+planFile)
+			}
+
+			if err := next(ctx, l, opts, r); err != nil {
+				return err
+			}
+
+			planned, err := showPlannedOutputs(ctx, l, opts, planFile, r)
+			if err != nil {
+				// The units planned after it get the current outputs of the unit instead.
+				l.Warnf("Failed to read the planned outputs of unit %s, its dependents are planned with its current outputs: %v", relPath(opts, unit.Path), err)
+				return nil
+			}
+
+			outputs.Store(opts.TerragruntConfigPath, planned)
+
+			return nil
+		})
+	}
+
+	return removePlanDir, nil
+}
+
+// showPlannedOutputs returns the outputs planned by the given plan file of the unit of the given options, in the format
+// of `output -json`.
+func showPlannedOutputs(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, planFile string, r *report.Report) ([]byte, error) {
+	l, showOpts, err := opts.CloneWithConfigPath(l, opts.TerragruntConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout bytes.Buffer
+
+	showOpts.ForwardTFStdout = true
+	showOpts.JSONLogFormat = false
+	showOpts.Writer = &stdout
+	showOpts.TerraformCommand = tf.CommandNameShow
+	showOpts.TerraformCliArgs = []string{tf.CommandNameShow, "-json", planFile}
+
+	if err := showOpts.RunTerragrunt(ctx, l, showOpts, r); err != nil {
+		return nil, err
+	}
+
+	return plannedOutputsJSON(stdout.Bytes())
+}
+
+// plannedOutputsJSON converts the planned outputs of the given JSON plan, written by `show -json`, to the format of
+// `output -json`. The outputs that are unknown until apply have no planned value, and are left out.
+func plannedOutputsJSON(planJSON []byte) ([]byte, error) {
+	// Providers may log before the JSON plan.
+	if i := bytes.IndexByte(planJSON, '{'); i > 0 {
+		planJSON = planJSON[i:]
+	}
+
+	var plan struct {
+		PlannedValues struct {
+			Outputs map[string]struct {
+				Type      json.RawMessage `json:"type"`
+				Value     json.RawMessage `json:"value"`
+				Sensitive bool            `json:"sensitive"`
+			} `json:"outputs"`
+		} `json:"planned_values"`
+	}
+
+	if err := json.Unmarshal(planJSON, &plan); err != nil {
+		return nil, errors.Errorf("invalid JSON plan: %w", err)
+	}
+
+	type output struct {
+		Type      json.RawMessage `json:"type"`
+		Value     json.RawMessage `json:"value"`
+		Sensitive bool            `json:"sensitive"`
+	}
+
+	outputs := map[string]output{}
+
+	for name, planned := range plan.PlannedValues.Outputs {
+		if planned.Value == nil {
+			continue
+		}
+
+		// Older versions of OpenTofu/Terraform don't write the types of the outputs in the plan.
+		outputType := planned.Type
+		if outputType == nil {
+			impliedType, err := ctyjson.ImpliedType(planned.Value)
+			if err != nil {
+				return nil, errors.Errorf("invalid value of output %s: %w", name, err)
+			}
+
+			if outputType, err = ctyjson.MarshalType(impliedType); err != nil {
+				return nil, errors.New(err)
+			}
+		}
+
+		outputs[name] = output{Type: outputType, Value: planned.Value, Sensitive: planned.Sensitive}
+	}
+
+	data, err := json.Marshal(outputs)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return data, nil
+}
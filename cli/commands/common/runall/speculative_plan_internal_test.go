@@ -0,0 +1,52 @@
+package runall
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlannedOutputsJSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		plan     string
+		expected string
+	}{
+		{
+			name:     "typed",
+			plan:     `{"planned_values":{"outputs":{"id":{"sensitive":false,"type":"string","value":"vpc-1"}}}}`,
+			expected: `{"id":{"type":"string","value":"vpc-1","sensitive":false}}`,
+		},
+		{
+			name:     "implied type",
+			plan:     `{"planned_values":{"outputs":{"ids":{"sensitive":true,"value":["a","b"]}}}}`,
+			expected: `{"ids":{"type":["tuple",["string","string"]],"value":["a","b"],"sensitive":true}}`,
+		},
+		{
+			name:     "unknown",
+			plan:     `{"planned_values":{"outputs":{"arn":{"sensitive":false}}}}`,
+			expected: `{}`,
+		},
+		{
+			name:     "logs before the plan",
+			plan:     "provider log\n" + `{"planned_values":{}}`,
+			expected: `{}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			outputs, err := plannedOutputsJSON([]byte(tt.plan))
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(outputs))
+		})
+	}
+
+	_, err := plannedOutputsJSON([]byte("not a plan"))
+	require.Error(t, err)
+}
@@ -0,0 +1,334 @@
+package runall
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sync"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/report"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/pkg/log/format"
+	"github.com/gruntwork-io/terragrunt/pkg/log/format/placeholders"
+	"github.com/gruntwork-io/terragrunt/tf"
+)
+
+var unitLogPlaceholderRegexp = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// unitLogVars are the values of the placeholders of the templates of --unit-log-file and --unit-log-prefix.
+type unitLogVars struct {
+	// Unit is the path of the unit, relative to the working dir of the run.
+	Unit    string
+	Name    string
+	Command string
+	RunID   string
+}
+
+// renderUnitLogTemplate replaces the placeholders of the given template of the given flag with the given values.
+func renderUnitLogTemplate(flag, template string, vars unitLogVars) (string, error) {
+	values := map[string]string{
+		"unit":    vars.Unit,
+		"name":    vars.Name,
+		"command": vars.Command,
+		"run_id":  vars.RunID,
+	}
+
+	var err error
+
+	rendered := unitLogPlaceholderRegexp.ReplaceAllStringFunc(template, func(placeholder string) string {
+		name := placeholder[1 : len(placeholder)-1]
+
+		value, ok := values[name]
+		if !ok && err == nil {
+			err = errors.New(UnitLogInvalidTemplateErr{flag: flag, placeholder: name})
+		}
+
+		return value
+	})
+
+	return rendered, err
+}
+
+// routeUnitLogs sets up the loggers of the given units of the run of the given ID: the prefix of their logs on the
+// console, the files their logs are also written to, and the suppression of the output of the units without changes
+// on the console. The returned function closes the files once the run finished.
+func routeUnitLogs(l log.Logger, opts *options.TerragruntOptions, runID string, units common.Units) (func(), error) {
+	if opts.UnitLogFile == "" && opts.UnitLogPrefix == "" && !opts.UnitLogSuppressNoChanges {
+		return func() {}, nil
+	}
+
+	consoleLevel := l.Level()
+	fileLevel := consoleLevel
+
+	if opts.UnitLogFileLevel != "" {
+		level, err := log.ParseLevel(opts.UnitLogFileLevel)
+		if err != nil {
+			return nil, err
+		}
+
+		fileLevel = level
+	}
+
+	var files []*unitLogFile
+
+	closeFiles := func() {
+		for _, file := range files {
+			if err := file.Close(); err != nil {
+				l.Warnf("Failed to write the logs of unit %s to %s: %v", file.unit, file.path, err)
+			}
+		}
+	}
+
+	for _, unit := range units {
+		if unit.FlagExcluded || unit.AssumeAlreadyApplied {
+			continue
+		}
+
+		vars := unitLogVars{
+			Unit:    relPath(opts, unit.Path),
+			Name:    filepath.Base(unit.Path),
+			Command: opts.TerraformCommand,
+			RunID:   runID,
+		}
+
+		formatter := &unitLogFormatter{
+			Formatter:    unit.Logger.Formatter(),
+			unitPath:     unit.Path,
+			consoleLevel: consoleLevel,
+			fileLevel:    fileLevel,
+		}
+
+		if opts.UnitLogPrefix != "" {
+			prefix, err := renderUnitLogTemplate(UnitLogPrefixFlagName, opts.UnitLogPrefix, vars)
+			if err != nil {
+				return nil, err
+			}
+
+			formatter.prefix = prefix
+		}
+
+		if opts.UnitLogFile != "" {
+			path, err := renderUnitLogTemplate(UnitLogFileFlagName, opts.UnitLogFile, vars)
+			if err != nil {
+				return nil, err
+			}
+
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(opts.WorkingDir, path)
+			}
+
+			fileFormatter := format.NewFormatter(format.NewPrettyFormatPlaceholders())
+			fileFormatter.SetDisabledColors(true)
+
+			if err := fileFormatter.SetBaseDir(opts.RootWorkingDir); err != nil {
+				return nil, err
+			}
+
+			file := &unitLogFile{unit: vars.Unit, path: path}
+			files = append(files, file)
+
+			formatter.file = file
+			formatter.fileFormatter = fileFormatter
+		}
+
+		if opts.UnitLogPrefix != "" || opts.UnitLogFile != "" {
+			// The logger logs the entries of both levels, each destination dropping the entries above its own level.
+			unit.Logger = unit.Logger.WithOptions(log.WithLevel(max(consoleLevel, fileLevel)), log.WithFormatter(formatter))
+		}
+
+		if opts.UnitLogSuppressNoChanges && slices.Contains(noChangesCommands, opts.TerraformCommand) {
+			suppressNoChanges(unit)
+		}
+	}
+
+	return closeFiles, nil
+}
+
+// unitLogFormatter formats the log entries of a unit for the console, with the prefix of the unit, if any, and also
+// writes them to the log file of the unit, if any, without colors.
+type unitLogFormatter struct {
+	log.Formatter
+	file          io.Writer
+	fileFormatter log.Formatter
+	unitPath      string
+	prefix        string
+	consoleLevel  log.Level
+	fileLevel     log.Level
+}
+
+// Format implements log.Formatter.
+func (formatter *unitLogFormatter) Format(entry *log.Entry) ([]byte, error) {
+	// The prefix replaces the working dir of the entries of the unit only, the unit also logging the parsing of the
+	// configs of its dependencies, in their own working dirs.
+	if formatter.prefix != "" && entry.Fields[placeholders.WorkDirKeyName] == formatter.unitPath {
+		fields := maps.Clone(entry.Fields)
+		fields[placeholders.WorkDirKeyName] = formatter.prefix
+
+		entry = &log.Entry{Entry: entry.Entry, Fields: fields, Level: entry.Level}
+	}
+
+	if formatter.file != nil && entry.Level <= formatter.fileLevel {
+		// The entry is formatted into its own buffer, the buffer of the entry being written to the console.
+		fileEntry := *entry.Entry
+		fileEntry.Buffer = nil
+
+		data, err := formatter.fileFormatter.Format(&log.Entry{Entry: &fileEntry, Fields: entry.Fields, Level: entry.Level})
+		if err != nil {
+			return nil, err
+		}
+
+		// The errors are returned when the file is closed, so a failed write doesn't fail the run.
+		formatter.file.Write([]byte(log.RemoveAllASCISeq(string(data)))) //nolint:errcheck
+	}
+
+	if entry.Level > formatter.consoleLevel {
+		return nil, nil
+	}
+
+	return formatter.Formatter.Format(entry)
+}
+
+// unitLogFile is the log file of a unit, created once the unit logs anything, so units that don't run leave none.
+type unitLogFile struct {
+	file *os.File
+	err  error
+	unit string
+	path string
+	mu   sync.Mutex
+}
+
+// Write implements io.Writer.
+func (file *unitLogFile) Write(p []byte) (int, error) {
+	file.mu.Lock()
+	defer file.mu.Unlock()
+
+	if file.err != nil {
+		return 0, file.err
+	}
+
+	if file.file == nil {
+		if err := os.MkdirAll(filepath.Dir(file.path), os.ModePerm); err != nil {
+			file.err = errors.New(err)
+			return 0, file.err
+		}
+
+		f, err := os.Create(file.path)
+		if err != nil {
+			file.err = errors.New(err)
+			return 0, file.err
+		}
+
+		file.file = f
+	}
+
+	n, err := file.file.Write(p)
+	if err != nil {
+		file.err = errors.New(err)
+	}
+
+	return n, file.err
+}
+
+// Close closes the file, and returns the first error creating or writing to it, if any.
+func (file *unitLogFile) Close() error {
+	file.mu.Lock()
+	defer file.mu.Unlock()
+
+	if file.file != nil {
+		if err := file.file.Close(); err != nil && file.err == nil {
+			file.err = errors.New(err)
+		}
+	}
+
+	return file.err
+}
+
+// noChangesCommands are the commands whose output --unit-log-suppress-no-changes suppresses when they report no
+// changes.
+var noChangesCommands = []string{tf.CommandNamePlan, tf.CommandNameApply, tf.CommandNameDestroy}
+
+// suppressNoChanges buffers the output of the given unit until its run finished, and drops it if
+// OpenTofu/Terraform reported no changes, logging a single line instead. The errors are never buffered.
+func suppressNoChanges(unit *common.Unit) {
+	wrapUnitRun(unit, func(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, r *report.Report, next runTerragruntFunc) error {
+		out := opts.Writer
+		writer := &noChangesWriter{out: out}
+
+		opts.Writer = writer
+		err := next(ctx, l, opts, r)
+		opts.Writer = out
+
+		if err == nil && writer.noChanges() {
+			l.Infof("No changes, suppressed the output of %s", opts.TerraformCommand)
+			return nil
+		}
+
+		if flushErr := writer.flush(); flushErr != nil && err == nil {
+			err = flushErr
+		}
+
+		return err
+	})
+}
+
+// noChangesWriter buffers the output of a run, recording the numbers of changed resources it reports, the last
+// numbers reported winning, like report.ChangesWriter.
+type noChangesWriter struct {
+	out     io.Writer
+	changes *report.ResourceChanges
+	buffer  bytes.Buffer
+	line    []byte
+	mu      sync.Mutex
+}
+
+// Write implements io.Writer.
+func (writer *noChangesWriter) Write(p []byte) (int, error) {
+	writer.mu.Lock()
+	defer writer.mu.Unlock()
+
+	writer.line = append(writer.line, p...)
+
+	for {
+		i := bytes.IndexByte(writer.line, '\n')
+		if i < 0 {
+			break
+		}
+
+		if changes, ok := report.ParseResourceChanges(string(writer.line[:i])); ok {
+			writer.changes = &changes
+		}
+
+		writer.line = writer.line[i+1:]
+	}
+
+	return writer.buffer.Write(p)
+}
+
+// noChanges returns true if the run reported that it changes no resources.
+func (writer *noChangesWriter) noChanges() bool {
+	writer.mu.Lock()
+	defer writer.mu.Unlock()
+
+	return writer.changes != nil && *writer.changes == report.ResourceChanges{}
+}
+
+func (writer *noChangesWriter) flush() error {
+	writer.mu.Lock()
+	defer writer.mu.Unlock()
+
+	if _, err := writer.out.Write(writer.buffer.Bytes()); err != nil {
+		return errors.New(err)
+	}
+
+	writer.buffer.Reset()
+
+	return nil
+}
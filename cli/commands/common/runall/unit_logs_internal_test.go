@@ -0,0 +1,102 @@
+package runall
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/pkg/log/format"
+	"github.com/gruntwork-io/terragrunt/pkg/log/format/placeholders"
+)
+
+func TestRenderUnitLogTemplate(t *testing.T) {
+	t.Parallel()
+
+	vars := unitLogVars{Unit: "prod/app", Name: "app", Command: "plan", RunID: "20260102T030405-a1b2c3"}
+
+	rendered, err := renderUnitLogTemplate(UnitLogFileFlagName, "logs/{run_id}/{unit}-{command}.log", vars)
+	require.NoError(t, err)
+	assert.Equal(t, "logs/20260102T030405-a1b2c3/prod/app-plan.log", rendered)
+
+	rendered, err = renderUnitLogTemplate(UnitLogPrefixFlagName, "{name}", vars)
+	require.NoError(t, err)
+	assert.Equal(t, "app", rendered)
+
+	_, err = renderUnitLogTemplate(UnitLogPrefixFlagName, "{name}:{env}", vars)
+	require.EqualError(t, err, "invalid --unit-log-prefix, unknown placeholder {env}, expected {unit}, {name}, {command} or {run_id}")
+}
+
+func TestUnitLogFormatter(t *testing.T) {
+	t.Parallel()
+
+	fileFormatter := format.NewFormatter(format.NewPrettyFormatPlaceholders())
+	fileFormatter.SetDisabledColors(true)
+
+	var (
+		console bytes.Buffer
+		file    bytes.Buffer
+	)
+
+	consoleFormatter := format.NewFormatter(format.NewPrettyFormatPlaceholders())
+	consoleFormatter.SetDisabledColors(true)
+
+	formatter := &unitLogFormatter{
+		Formatter:     consoleFormatter,
+		file:          &file,
+		fileFormatter: fileFormatter,
+		unitPath:      "/live/app",
+		prefix:        "plan:app",
+		consoleLevel:  log.InfoLevel,
+		fileLevel:     log.DebugLevel,
+	}
+
+	for _, entry := range []*log.Entry{
+		{Entry: &logrus.Entry{Message: "Running"}, Fields: log.Fields{placeholders.WorkDirKeyName: "/live/app"}, Level: log.InfoLevel},
+		{Entry: &logrus.Entry{Message: "Parsing"}, Fields: log.Fields{placeholders.WorkDirKeyName: "/live/vpc"}, Level: log.DebugLevel},
+	} {
+		data, err := formatter.Format(entry)
+		require.NoError(t, err)
+
+		console.Write(data)
+	}
+
+	// The console gets the entries within its level only, the file all of them, the prefix replacing the working dir
+	// of the unit only.
+	assert.Equal(t, 1, strings.Count(console.String(), "\n"))
+	assert.Contains(t, console.String(), "[plan:app] Running")
+	assert.Equal(t, 2, strings.Count(file.String(), "\n"))
+	assert.Contains(t, file.String(), "[plan:app] Running")
+	assert.NotContains(t, file.String(), "[plan:app] Parsing")
+	assert.Contains(t, file.String(), "Parsing")
+}
+
+func TestNoChangesWriter(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+
+	writer := &noChangesWriter{out: &out}
+
+	_, err := writer.Write([]byte("Refreshing state...\nNo changes. Your infrastructure matches the configuration.\n"))
+	require.NoError(t, err)
+	assert.True(t, writer.noChanges())
+	assert.Empty(t, out.String())
+
+	// The last numbers reported win, even when written in several chunks.
+	_, err = writer.Write([]byte("Plan: 1 to add, 0 to change, "))
+	require.NoError(t, err)
+	_, err = writer.Write([]byte("0 to destroy.\n"))
+	require.NoError(t, err)
+	assert.False(t, writer.noChanges())
+
+	require.NoError(t, writer.flush())
+	assert.Equal(t, "Refreshing state...\nNo changes. Your infrastructure matches the configuration.\nPlan: 1 to add, 0 to change, 0 to destroy.\n", out.String())
+
+	// A run that reports nothing isn't a run without changes.
+	assert.False(t, (&noChangesWriter{out: &out}).noChanges())
+}
@@ -0,0 +1,225 @@
+package runall
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/runner"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/tf"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// watchPollInterval is the interval at which the files of the units are checked for changes in watch mode.
+const watchPollInterval = time.Second
+
+// Commands that can be run in watch mode. Commands changing the state of the units are deliberately not supported, as
+// they would run on every saved file.
+var watchCommands = []string{
+	tf.CommandNamePlan,
+	tf.CommandNameValidate,
+}
+
+// fileState is the state of a watched file, compared between polls to detect changes.
+type fileState struct {
+	modTime int64
+	size    int64
+}
+
+// watchedFiles maps the path of each watched file to its state and to the paths of the units affected by its changes.
+type watchedFiles map[string]watchedFile
+
+type watchedFile struct {
+	state fileState
+	units []string
+}
+
+// Watch watches the files of the units of the given stack, and runs the command again on the changed units and their
+// dependents whenever files change, until the context is canceled.
+func Watch(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, stack common.StackRunner, stackOpts ...common.Option) error {
+	files, err := findWatchedFiles(stack.GetStack())
+	if err != nil {
+		return err
+	}
+
+	l.Infof("Watching %d units for changes, press Ctrl+C to stop", len(stack.GetStack().Units))
+
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		newFiles, err := findWatchedFiles(stack.GetStack())
+		if err != nil {
+			return err
+		}
+
+		changed := changedUnits(files, newFiles)
+		if len(changed) == 0 {
+			continue
+		}
+
+		// The changes may have added or removed dependencies, so the stack is built again before looking up the
+		// dependents of the changed units.
+		newStack, err := runner.FindStackInSubfolders(ctx, l, opts, stackOpts...)
+		if err != nil {
+			l.Errorf("Failed to build the stack after changes to %s: %v", strings.Join(changed, ", "), err)
+
+			files = newFiles
+
+			continue
+		}
+
+		stack = newStack
+
+		if files, err = findWatchedFiles(stack.GetStack()); err != nil {
+			return err
+		}
+
+		if err := runAffectedUnits(ctx, l, opts, stack, changed, stackOpts...); err != nil {
+			return err
+		}
+	}
+}
+
+// runAffectedUnits runs the command on the given changed units and on the units depending on them.
+func runAffectedUnits(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, stack common.StackRunner, changed []string, stackOpts ...common.Option) error {
+	dependents := stack.ListStackDependentUnits()
+	affected := slices.Clone(changed)
+
+	for _, path := range changed {
+		affected = append(affected, dependents[path]...)
+	}
+
+	affected = util.RemoveDuplicatesFromList(affected)
+	slices.Sort(affected)
+
+	l.Infof("Detected changes in %s, running %s on %d units", strings.Join(changed, ", "), opts.TerraformCommand, len(affected))
+
+	watchOpts := opts.Clone()
+	watchOpts.IncludeDirs = affected
+	watchOpts.ExcludeByDefault = true
+	watchOpts.StrictInclude = true
+
+	affectedStack, err := runner.FindStackInSubfolders(ctx, l, watchOpts, stackOpts...)
+	if err != nil {
+		l.Errorf("Failed to build the stack of the changed units: %v", err)
+
+		return nil
+	}
+
+	return RunAllOnStack(ctx, l, watchOpts, affectedStack)
+}
+
+// findWatchedFiles returns the files of the units of the given stack, and the configs they include. Hidden files and
+// directories, e.g. `.terragrunt-cache` or `.terraform`, and the files of nested units are skipped.
+func findWatchedFiles(stack *common.Stack) (watchedFiles, error) {
+	files := watchedFiles{}
+
+	unitPaths := make(map[string]bool, len(stack.Units))
+	for _, unit := range stack.Units {
+		unitPaths[unit.Path] = true
+	}
+
+	add := func(path, unitPath string) error {
+		info, err := os.Stat(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+
+			return errors.New(err)
+		}
+
+		file := files[path]
+		file.state = fileState{modTime: info.ModTime().UnixNano(), size: info.Size()}
+		file.units = append(file.units, unitPath)
+		files[path] = file
+
+		return nil
+	}
+
+	for _, unit := range stack.Units {
+		err := filepath.WalkDir(unit.Path, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+
+				return err
+			}
+
+			if path != unit.Path && strings.HasPrefix(d.Name(), ".") {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+
+			if d.IsDir() {
+				if path != unit.Path && unitPaths[path] {
+					return filepath.SkipDir
+				}
+
+				return nil
+			}
+
+			return add(path, unit.Path)
+		})
+		if err != nil {
+			return nil, errors.New(err)
+		}
+
+		for _, include := range unit.Config.ProcessedIncludes {
+			includePath, err := util.CanonicalPath(include.Path, unit.Path)
+			if err != nil {
+				return nil, err
+			}
+
+			if !strings.HasPrefix(includePath, unit.Path+string(filepath.Separator)) {
+				if err := add(includePath, unit.Path); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return files, nil
+}
+
+// changedUnits returns the sorted paths of the units affected by the files that were added, removed or modified
+// between the given polls.
+func changedUnits(oldFiles, newFiles watchedFiles) []string {
+	var changed []string
+
+	for path, file := range newFiles {
+		if oldFile, ok := oldFiles[path]; !ok || oldFile.state != file.state {
+			changed = append(changed, file.units...)
+		}
+	}
+
+	for path, file := range oldFiles {
+		if _, ok := newFiles[path]; !ok {
+			changed = append(changed, file.units...)
+		}
+	}
+
+	changed = util.RemoveDuplicatesFromList(changed)
+	slices.Sort(changed)
+
+	return changed
+}
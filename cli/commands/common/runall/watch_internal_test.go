@@ -0,0 +1,88 @@
+package runall
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+)
+
+func TestWatchChangedUnits(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	rootConfigPath := filepath.Join(rootDir, "root.hcl")
+	vpcDir := filepath.Join(rootDir, "vpc")
+	appDir := filepath.Join(rootDir, "app")
+	nestedDir := filepath.Join(appDir, "nested")
+
+	for _, path := range []string{
+		rootConfigPath,
+		filepath.Join(vpcDir, "terragrunt.hcl"),
+		filepath.Join(vpcDir, ".terragrunt-cache", "main.tf"),
+		filepath.Join(appDir, "terragrunt.hcl"),
+		filepath.Join(appDir, "main.tf"),
+		filepath.Join(nestedDir, "terragrunt.hcl"),
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(""), 0644))
+	}
+
+	stack := &common.Stack{
+		Units: common.Units{
+			{Path: vpcDir},
+			{
+				Path: appDir,
+				Config: config.TerragruntConfig{
+					ProcessedIncludes: config.IncludeConfigsMap{
+						"root": {Path: rootConfigPath},
+					},
+				},
+			},
+			{Path: nestedDir},
+		},
+	}
+
+	files, err := findWatchedFiles(stack)
+	require.NoError(t, err)
+	assert.Len(t, files, 5)
+	assert.Equal(t, []string{appDir}, files[rootConfigPath].units)
+
+	touch := func(path string) {
+		t.Helper()
+
+		modTime := time.Now().Add(time.Hour)
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+
+	// Files of the cache directory of a unit aren't watched.
+	touch(filepath.Join(vpcDir, ".terragrunt-cache", "main.tf"))
+
+	newFiles, err := findWatchedFiles(stack)
+	require.NoError(t, err)
+	assert.Empty(t, changedUnits(files, newFiles))
+
+	// The files of a nested unit only affect the nested unit, and included configs affect the units including them.
+	touch(filepath.Join(nestedDir, "terragrunt.hcl"))
+	touch(rootConfigPath)
+
+	newFiles, err = findWatchedFiles(stack)
+	require.NoError(t, err)
+	assert.Equal(t, []string{appDir, nestedDir}, changedUnits(files, newFiles))
+
+	// Added and removed files are changes.
+	files = newFiles
+
+	require.NoError(t, os.Remove(filepath.Join(appDir, "main.tf")))
+	require.NoError(t, os.WriteFile(filepath.Join(vpcDir, "main.tf"), []byte(""), 0644))
+
+	newFiles, err = findWatchedFiles(stack)
+	require.NoError(t, err)
+	assert.Equal(t, []string{appDir, vpcDir}, changedUnits(files, newFiles))
+}
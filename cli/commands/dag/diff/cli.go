@@ -0,0 +1,92 @@
+// Package diff implements the terragrunt dag diff command which compares the Terragrunt dependency graph, and the
+// content of the units, between git refs.
+package diff
+
+import (
+	"github.com/gruntwork-io/terragrunt/cli/flags"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+const (
+	CommandName = "diff"
+
+	BaseFlagName   = "base"
+	HeadFlagName   = "head"
+	FormatFlagName = "format"
+
+	// FormatText outputs the diff as text.
+	FormatText = "text"
+
+	// FormatJSON outputs the diff in JSON format.
+	FormatJSON = "json"
+)
+
+// Options are the options of the dag diff command.
+type Options struct {
+	*options.TerragruntOptions
+
+	// Base is the git ref the graph is compared against.
+	Base string
+
+	// Head is the git ref of the graph compared against Base. The working tree is used if it is empty.
+	Head string
+
+	// Format determines the format of the output.
+	Format string
+}
+
+func NewFlags(opts *Options, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	return cli.Flags{
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        BaseFlagName,
+			EnvVars:     tgPrefix.EnvVars(BaseFlagName),
+			Destination: &opts.Base,
+			Usage:       "Git ref to compare the graph against, e.g. origin/main.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        HeadFlagName,
+			EnvVars:     tgPrefix.EnvVars(HeadFlagName),
+			Destination: &opts.Head,
+			Usage:       "Git ref of the graph compared against the base. Defaults to the working tree.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        FormatFlagName,
+			EnvVars:     tgPrefix.EnvVars(FormatFlagName),
+			Destination: &opts.Format,
+			Usage:       "Output format of the diff. Valid values: text, json.",
+			DefaultText: FormatText,
+		}),
+	}
+}
+
+func NewCommand(l log.Logger, opts *options.TerragruntOptions, _ flags.Prefix) *cli.Command {
+	cmdOpts := &Options{TerragruntOptions: opts, Format: FormatText}
+
+	return &cli.Command{
+		Name:      CommandName,
+		Usage:     "Compare the Directed Acyclic Graph (DAG), and the content of the units, between git refs.",
+		UsageText: "terragrunt dag diff --base <ref> [--head <ref>] [--format text|json]",
+		Flags:     NewFlags(cmdOpts, nil),
+		Before: func(ctx *cli.Context) error {
+			if cmdOpts.Format != FormatText && cmdOpts.Format != FormatJSON {
+				return cli.NewExitError(errors.New("invalid format: "+cmdOpts.Format), cli.ExitCodeGeneralError)
+			}
+
+			if cmdOpts.Base == "" {
+				return cli.NewExitError(errors.Errorf("the --%s ref must be set", BaseFlagName), cli.ExitCodeGeneralError)
+			}
+
+			return nil
+		},
+		Action: func(ctx *cli.Context) error {
+			return Run(ctx, l, cmdOpts)
+		},
+	}
+}
@@ -0,0 +1,112 @@
+package diff
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	dependencygraph "github.com/gruntwork-io/terragrunt/pkg/graph"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/shell"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// Run compares the graph of the units of the working dir, and their content, at the base ref with the ones at the head
+// ref, or in the working tree, and outputs the units and the dependencies added, removed or changed.
+func Run(ctx context.Context, l log.Logger, opts *Options) error {
+	workingDir, err := filepath.EvalSymlinks(opts.WorkingDir)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	repoDir, err := shell.GitTopLevelDir(ctx, l, opts.TerragruntOptions, workingDir)
+	if err != nil {
+		return err
+	}
+
+	relDir, err := filepath.Rel(repoDir, workingDir)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	base, err := snapshotAtRef(ctx, l, opts.TerragruntOptions, repoDir, relDir, opts.Base)
+	if err != nil {
+		return err
+	}
+
+	var head *dependencygraph.Snapshot
+
+	if opts.Head == "" {
+		head, err = snapshotOfDir(ctx, l, opts.TerragruntOptions, workingDir)
+	} else {
+		head, err = snapshotAtRef(ctx, l, opts.TerragruntOptions, repoDir, relDir, opts.Head)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	diff := dependencygraph.DiffSnapshots(base, head)
+
+	if opts.Format == FormatJSON {
+		return diff.WriteJSON(opts.Writer)
+	}
+
+	return diff.WriteText(opts.Writer)
+}
+
+// snapshotAtRef returns the snapshot of the units of the given dir, relative to the git repository at repoDir, at the
+// given ref. The ref is checked out in a temporary worktree, removed once the snapshot is taken. The snapshot is empty
+// if the dir doesn't exist at the ref.
+func snapshotAtRef(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, repoDir, relDir, ref string) (*dependencygraph.Snapshot, error) {
+	tempDir, err := os.MkdirTemp("", "terragrunt-dag-diff-*")
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			l.Warnf("Failed to remove %s: %v", tempDir, err)
+		}
+	}()
+
+	worktreeDir := filepath.Join(tempDir, "worktree")
+
+	l.Debugf("Checking out %s in %s", ref, worktreeDir)
+
+	if err := shell.GitWorktreeAdd(ctx, l, opts, repoDir, worktreeDir, ref); err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := shell.GitWorktreeRemove(ctx, l, opts, repoDir, worktreeDir); err != nil {
+			l.Warnf("Failed to remove the worktree of %s: %v", ref, err)
+		}
+	}()
+
+	dir := filepath.Join(worktreeDir, relDir)
+	if !util.IsDir(dir) {
+		l.Debugf("%s doesn't exist at %s", relDir, ref)
+
+		return &dependencygraph.Snapshot{Graph: &dependencygraph.Graph{Units: []dependencygraph.Unit{}}, Hashes: map[string]string{}}, nil
+	}
+
+	return snapshotOfDir(ctx, l, opts, dir)
+}
+
+// snapshotOfDir returns the snapshot of the units of the given dir.
+func snapshotOfDir(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, dir string) (*dependencygraph.Snapshot, error) {
+	dirOpts := opts.Clone()
+	dirOpts.WorkingDir = dir
+	dirOpts.TerragruntConfigPath = filepath.Join(dir, config.DefaultTerragruntConfigPath)
+
+	// The default download dir is in the working dir, so it must follow it.
+	if opts.DownloadDir == filepath.Join(opts.WorkingDir, util.TerragruntCacheDir) {
+		dirOpts.DownloadDir = filepath.Join(dir, util.TerragruntCacheDir)
+	}
+
+	return dependencygraph.BuildSnapshot(ctx, l, dirOpts)
+}
@@ -0,0 +1,122 @@
+package diff_test
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/cli/commands/dag/diff"
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	repoDir := t.TempDir()
+	stackDir := filepath.Join(repoDir, "live")
+
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = repoDir
+
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	writeUnit := func(name, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Join(stackDir, name), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(stackDir, name, config.DefaultTerragruntConfigPath), []byte(content), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(stackDir, name, "main.tf"), []byte(""), 0644))
+	}
+
+	writeUnit("vpc", "")
+	writeUnit("db", "")
+	writeUnit("app", `
+dependencies {
+  paths = ["../vpc"]
+}
+`)
+
+	git("init", "--quiet")
+	git("add", "-A")
+	git("commit", "--quiet", "-m", "base")
+
+	// Change the dependencies and the content of app, add cache, and remove db.
+	writeUnit("app", `
+dependencies {
+  paths = ["../vpc", "../cache"]
+}
+`)
+	writeUnit("cache", "")
+	require.NoError(t, os.RemoveAll(filepath.Join(stackDir, "db")))
+
+	// Files in hidden dirs, such as the Terragrunt cache, don't change the content of a unit.
+	require.NoError(t, os.MkdirAll(filepath.Join(stackDir, "vpc", ".terragrunt-cache"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(stackDir, "vpc", ".terragrunt-cache", "state"), []byte("{}"), 0644))
+
+	testCases := []struct {
+		name     string
+		format   string
+		expected string
+	}{
+		{
+			name:   "text",
+			format: diff.FormatText,
+			expected: `Units:
+  + cache
+  - db
+  ~ app
+Dependencies:
+  + app -> cache
+`,
+		},
+		{
+			name:   "json",
+			format: diff.FormatJSON,
+			expected: `{
+  "added_units": ["cache"],
+  "removed_units": ["db"],
+  "changed_units": ["app"],
+  "added_edges": [{"from": "app", "to": "cache"}],
+  "removed_edges": []
+}`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts, err := options.NewTerragruntOptionsForTest(filepath.Join(stackDir, config.DefaultTerragruntConfigPath))
+			require.NoError(t, err)
+
+			var out bytes.Buffer
+
+			opts.WorkingDir = stackDir
+			opts.Writer = &out
+
+			require.NoError(t, diff.Run(t.Context(), logger.CreateLogger(), &diff.Options{
+				TerragruntOptions: opts,
+				Base:              "HEAD",
+				Format:            tc.format,
+			}))
+
+			if tc.format == diff.FormatJSON {
+				assert.JSONEq(t, tc.expected, out.String())
+			} else {
+				assert.Equal(t, tc.expected, out.String())
+			}
+		})
+	}
+}
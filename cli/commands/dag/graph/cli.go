@@ -1,29 +1,74 @@
-// Package graph implements the terragrunt dag graph command which generates a visual
-// representation of the Terragrunt dependency graph in DOT language format.
+// Package graph implements the terragrunt dag graph command which generates a representation of the
+// Terragrunt dependency graph in DOT language, JSON, Mermaid or HTML format.
 package graph
 
 import (
+	"slices"
+
 	"github.com/gruntwork-io/terragrunt/cli/commands/common/graph"
 	"github.com/gruntwork-io/terragrunt/cli/commands/common/runall"
 	"github.com/gruntwork-io/terragrunt/cli/commands/run"
 	"github.com/gruntwork-io/terragrunt/cli/flags"
 	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/internal/runner"
 	"github.com/gruntwork-io/terragrunt/options"
+	dependencygraph "github.com/gruntwork-io/terragrunt/pkg/graph"
 	"github.com/gruntwork-io/terragrunt/pkg/log"
 )
 
 const (
 	CommandName = "graph"
+
+	FormatFlagName  = "format"
+	GroupByFlagName = "group-by"
+
+	// FormatDot outputs the graph in DOT language.
+	FormatDot = "dot"
+
+	// FormatJSON outputs the graph, with the metadata of the units, in JSON.
+	FormatJSON = "json"
+
+	// FormatMermaid outputs the graph as a Mermaid flowchart.
+	FormatMermaid = "mermaid"
+
+	// FormatHTML outputs the graph as a standalone HTML page to explore it in a browser.
+	FormatHTML = "html"
 )
 
+func NewFlags(format, groupBy *string, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	return cli.Flags{
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        FormatFlagName,
+			EnvVars:     tgPrefix.EnvVars(FormatFlagName),
+			Destination: format,
+			Usage:       "Output format of the graph. Valid values: dot, json, mermaid, html.",
+			DefaultText: FormatDot,
+		}),
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        GroupByFlagName,
+			EnvVars:     tgPrefix.EnvVars(GroupByFlagName),
+			Destination: groupBy,
+			Usage:       "Group the units of the dot and mermaid graphs by directory or tag. Valid values: directory, tag.",
+		}),
+	}
+}
+
 func NewCommand(l log.Logger, opts *options.TerragruntOptions, _ flags.Prefix) *cli.Command {
+	var (
+		format  = FormatDot
+		groupBy string
+	)
+
 	cmd := &cli.Command{
 		Name:      CommandName,
-		Usage:     "Graph the Directed Acyclic Graph (DAG) in DOT language.",
-		UsageText: "terragrunt dag graph",
+		Usage:     "Graph the Directed Acyclic Graph (DAG) in DOT language, JSON, Mermaid or HTML format.",
+		UsageText: "terragrunt dag graph [--format dot|json|mermaid|html] [--group-by directory|tag] [--dependency-policy <path>]",
+		Flags:     append(NewFlags(&format, &groupBy, nil), run.NewFlags(l, opts, nil).Filter(run.DependencyPolicyFlagName)...),
 		Action: func(ctx *cli.Context) error {
-			return Run(ctx, l, opts)
+			return Run(ctx, l, opts, format, groupBy)
 		},
 	}
 
@@ -33,15 +78,60 @@ func NewCommand(l log.Logger, opts *options.TerragruntOptions, _ flags.Prefix) *
 	return cmd
 }
 
-func Run(ctx *cli.Context, l log.Logger, opts *options.TerragruntOptions) error {
-	stack, err := runner.FindStackInSubfolders(ctx, l, opts)
-	if err != nil {
-		return err
-	}
+func Run(ctx *cli.Context, l log.Logger, opts *options.TerragruntOptions, format, groupBy string) error {
+	if groupBy != "" {
+		if !slices.Contains(dependencygraph.GroupBys, groupBy) {
+			return errors.Errorf("invalid group by: %s, must be one of %v", groupBy, dependencygraph.GroupBys)
+		}
 
-	if err := stack.GetStack().Units.WriteDot(l, opts.Writer, opts); err != nil {
-		l.Warnf("Failed to graph dot: %v", err)
+		if format != FormatDot && format != FormatMermaid {
+			return errors.Errorf("--%s is only supported with the %s and %s formats", GroupByFlagName, FormatDot, FormatMermaid)
+		}
 	}
 
-	return nil
+	switch format {
+	case FormatDot:
+		if groupBy != "" {
+			graph, err := dependencygraph.Build(ctx, l, opts)
+			if err != nil {
+				return err
+			}
+
+			return graph.WriteDot(opts.Writer, groupBy)
+		}
+
+		stack, err := runner.FindStackInSubfolders(ctx, l, opts)
+		if err != nil {
+			return err
+		}
+
+		if err := stack.GetStack().Units.WriteDot(l, opts.Writer, opts); err != nil {
+			l.Warnf("Failed to graph dot: %v", err)
+		}
+
+		return nil
+	case FormatJSON:
+		graph, err := dependencygraph.Build(ctx, l, opts)
+		if err != nil {
+			return err
+		}
+
+		return graph.WriteJSON(opts.Writer)
+	case FormatMermaid:
+		graph, err := dependencygraph.Build(ctx, l, opts)
+		if err != nil {
+			return err
+		}
+
+		return graph.WriteMermaid(opts.Writer, groupBy)
+	case FormatHTML:
+		graph, err := dependencygraph.Build(ctx, l, opts)
+		if err != nil {
+			return err
+		}
+
+		return graph.WriteHTML(opts.Writer)
+	default:
+		return errors.New("invalid format: " + format)
+	}
 }
@@ -0,0 +1,79 @@
+// Package dependents provides the ability to list the units depending on a unit, directly or transitively, via the
+// `terragrunt dependents` command.
+package dependents
+
+import (
+	"github.com/gruntwork-io/terragrunt/cli/flags"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+const (
+	CommandName = "dependents"
+
+	FormatFlagName = "format"
+	DirectFlagName = "direct"
+
+	// FormatTable outputs the dependents as a table.
+	FormatTable = "table"
+
+	// FormatJSON outputs the dependents in JSON format.
+	FormatJSON = "json"
+
+	usageText = "terragrunt dependents [options] <unit>"
+)
+
+// Options are the options of the dependents command.
+type Options struct {
+	*options.TerragruntOptions
+
+	// Format determines the format of the output.
+	Format string
+
+	// Direct determines whether only the units depending directly on the unit are listed.
+	Direct bool
+}
+
+func NewFlags(opts *Options, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	return cli.Flags{
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        FormatFlagName,
+			EnvVars:     tgPrefix.EnvVars(FormatFlagName),
+			Destination: &opts.Format,
+			Usage:       "Output format for the dependents. Valid values: table, json.",
+			DefaultText: FormatTable,
+		}),
+
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        DirectFlagName,
+			EnvVars:     tgPrefix.EnvVars(DirectFlagName),
+			Destination: &opts.Direct,
+			Usage:       "Only list the units depending directly on the unit.",
+		}),
+	}
+}
+
+func NewCommand(l log.Logger, opts *options.TerragruntOptions) *cli.Command {
+	cmdOpts := &Options{TerragruntOptions: opts, Format: FormatTable}
+
+	return &cli.Command{
+		Name:      CommandName,
+		Usage:     "List the units depending on a unit, directly or transitively.",
+		UsageText: usageText,
+		Flags:     NewFlags(cmdOpts, nil),
+		Before: func(ctx *cli.Context) error {
+			if cmdOpts.Format != FormatTable && cmdOpts.Format != FormatJSON {
+				return cli.NewExitError(errors.New("invalid format: "+cmdOpts.Format), cli.ExitCodeGeneralError)
+			}
+
+			return nil
+		},
+		Action: func(ctx *cli.Context) error {
+			return Run(ctx, l, cmdOpts, ctx.Args().First())
+		},
+	}
+}
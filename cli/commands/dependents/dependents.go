@@ -0,0 +1,175 @@
+package dependents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gruntwork-io/terragrunt/internal/discovery"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/telemetry"
+)
+
+// Dependent is a unit depending, directly or transitively, on the unit the command is run for.
+type Dependent struct {
+	// Path is the path of the dependent, relative to the working dir.
+	Path string `json:"path"`
+	// Via is the path of the unit the dependent depends on, on the shortest path to the unit, relative to the working
+	// dir. It is the unit itself for a direct dependent.
+	Via string `json:"via"`
+	// Depth is the number of dependencies between the dependent and the unit, 1 for a direct dependent.
+	Depth int `json:"depth"`
+}
+
+// Run lists the units of the working dir depending on the unit at the given path.
+func Run(ctx context.Context, l log.Logger, opts *Options, unitPath string) error {
+	if unitPath == "" {
+		return errors.Errorf("the path of a unit must be given, e.g. %s", usageText)
+	}
+
+	if !filepath.IsAbs(unitPath) {
+		unitPath = filepath.Join(opts.WorkingDir, unitPath)
+	}
+
+	unitPath = filepath.Clean(unitPath)
+
+	d := discovery.
+		NewDiscovery(opts.WorkingDir).
+		WithSuppressParseErrors().
+		WithDiscoverDependencies()
+
+	var cfgs discovery.DiscoveredConfigs
+
+	err := telemetry.TelemeterFromContext(ctx).Collect(ctx, "dependents_discover", map[string]any{
+		"working_dir": opts.WorkingDir,
+	}, func(ctx context.Context) error {
+		var discoverErr error
+
+		cfgs, discoverErr = d.Discover(ctx, l, opts.TerragruntOptions)
+
+		return discoverErr
+	})
+	if err != nil {
+		l.Debugf("Errors encountered while discovering configurations:\n%s", err)
+	}
+
+	if !slices.ContainsFunc(cfgs, func(cfg *discovery.DiscoveredConfig) bool {
+		return cfg.Type == discovery.ConfigTypeUnit && cfg.Path == unitPath
+	}) {
+		return errors.Errorf("no unit found at %s in %s", unitPath, opts.WorkingDir)
+	}
+
+	dependents, err := findDependents(cfgs, unitPath, opts)
+	if err != nil {
+		return err
+	}
+
+	if opts.Format == FormatJSON {
+		return outputJSON(opts, dependents)
+	}
+
+	return outputTable(opts, dependents)
+}
+
+// findDependents returns the dependents of the unit at the given path, sorted by depth then path. Each dependent is
+// listed once, at the depth of the shortest path to the unit.
+func findDependents(cfgs discovery.DiscoveredConfigs, unitPath string, opts *Options) ([]Dependent, error) {
+	// dependentsOf maps the path of each unit to the paths of the units depending directly on it.
+	dependentsOf := map[string][]string{}
+
+	for _, cfg := range cfgs {
+		for _, dep := range cfg.Dependencies {
+			dependentsOf[dep.Path] = append(dependentsOf[dep.Path], cfg.Path)
+		}
+	}
+
+	relPath := func(path string) (string, error) {
+		rel, err := filepath.Rel(opts.WorkingDir, path)
+		if err != nil {
+			return "", errors.New(err)
+		}
+
+		return filepath.ToSlash(rel), nil
+	}
+
+	var (
+		dependents = []Dependent{}
+		visited    = map[string]bool{unitPath: true}
+		current    = []string{unitPath}
+	)
+
+	for depth := 1; len(current) > 0 && (depth == 1 || !opts.Direct); depth++ {
+		var next []string
+
+		for _, path := range current {
+			via, err := relPath(path)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, dependentPath := range dependentsOf[path] {
+				if visited[dependentPath] {
+					continue
+				}
+
+				visited[dependentPath] = true
+
+				rel, err := relPath(dependentPath)
+				if err != nil {
+					return nil, err
+				}
+
+				dependents = append(dependents, Dependent{Path: rel, Via: via, Depth: depth})
+				next = append(next, dependentPath)
+			}
+		}
+
+		current = next
+	}
+
+	slices.SortFunc(dependents, func(a, b Dependent) int {
+		if a.Depth != b.Depth {
+			return a.Depth - b.Depth
+		}
+
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return dependents, nil
+}
+
+// outputJSON outputs the dependents in JSON format.
+func outputJSON(opts *Options, dependents []Dependent) error {
+	jsonBytes, err := json.MarshalIndent(dependents, "", "  ")
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if _, err := opts.Writer.Write(append(jsonBytes, '\n')); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// outputTable outputs the dependents as a table with a row per dependent.
+func outputTable(opts *Options, dependents []Dependent) error {
+	w := tabwriter.NewWriter(opts.Writer, 0, 0, 2, ' ', 0) //nolint:mnd
+
+	fmt.Fprintln(w, "PATH\tDEPTH\tVIA")
+
+	for _, dependent := range dependents {
+		fmt.Fprintf(w, "%s\t%d\t%s\n", dependent.Path, dependent.Depth, dependent.Via)
+	}
+
+	if err := w.Flush(); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package dependents_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/cli/commands/dependents"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	units := map[string]string{
+		"vpc": ``,
+		"db": `
+dependency "vpc" {
+  config_path = "../vpc"
+}
+`,
+		"app": `
+dependency "db" {
+  config_path = "../db"
+}
+
+dependencies {
+  paths = ["../vpc"]
+}
+`,
+		"worker": `
+dependencies {
+  paths = ["../app"]
+}
+`,
+		"dns": ``,
+	}
+
+	for name, content := range units {
+		require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, name), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, name, "terragrunt.hcl"), []byte(content), 0644))
+	}
+
+	tests := []struct {
+		name     string
+		unit     string
+		expected []dependents.Dependent
+		direct   bool
+	}{
+		{
+			name: "transitive dependents",
+			unit: "vpc",
+			expected: []dependents.Dependent{
+				{Path: "app", Via: "vpc", Depth: 1},
+				{Path: "db", Via: "vpc", Depth: 1},
+				{Path: "worker", Via: "app", Depth: 2},
+			},
+		},
+		{
+			name:   "direct dependents",
+			unit:   "db",
+			direct: true,
+			expected: []dependents.Dependent{
+				{Path: "app", Via: "db", Depth: 1},
+			},
+		},
+		{
+			name:     "no dependents",
+			unit:     "dns",
+			expected: []dependents.Dependent{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tgOpts := options.NewTerragruntOptions()
+			tgOpts.WorkingDir = tmpDir
+
+			var out bytes.Buffer
+
+			tgOpts.Writer = &out
+
+			opts := &dependents.Options{TerragruntOptions: tgOpts, Format: dependents.FormatJSON, Direct: tt.direct}
+
+			require.NoError(t, dependents.Run(t.Context(), logger.CreateLogger(), opts, tt.unit))
+
+			var actual []dependents.Dependent
+
+			require.NoError(t, json.Unmarshal(out.Bytes(), &actual))
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestRunTable(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "vpc"), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(tmpDir, "app"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "vpc", "terragrunt.hcl"), []byte(""), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "app", "terragrunt.hcl"), []byte(`
+dependencies {
+  paths = ["../vpc"]
+}
+`), 0644))
+
+	tgOpts := options.NewTerragruntOptions()
+	tgOpts.WorkingDir = tmpDir
+
+	var out bytes.Buffer
+
+	tgOpts.Writer = &out
+
+	opts := &dependents.Options{TerragruntOptions: tgOpts, Format: dependents.FormatTable}
+
+	require.NoError(t, dependents.Run(t.Context(), logger.CreateLogger(), opts, "vpc"))
+	assert.Equal(t, "PATH  DEPTH  VIA\napp   1      vpc\n", out.String())
+
+	err := dependents.Run(t.Context(), logger.CreateLogger(), opts, "missing")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no unit found at")
+}
@@ -0,0 +1,63 @@
+// Package encrypt provides the command to encrypt values to be declared inline in Terragrunt configurations.
+package encrypt
+
+import (
+	"github.com/gruntwork-io/terragrunt/cli/flags"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+const (
+	CommandName = "encrypt"
+
+	AgeRecipientFlagName = "age-recipient"
+	KMSKeyIDFlagName     = "kms-key-id"
+
+	usageText = "terragrunt encrypt [options] [<value>]"
+)
+
+// Options are the options of the encrypt command.
+type Options struct {
+	*options.TerragruntOptions
+
+	// KMSKeyID is the AWS KMS key to encrypt the value with.
+	KMSKeyID string
+
+	// AgeRecipients are the age recipients to encrypt the value to.
+	AgeRecipients []string
+}
+
+func NewFlags(opts *Options, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	return cli.Flags{
+		flags.NewFlag(&cli.SliceFlag[string]{
+			Name:        AgeRecipientFlagName,
+			EnvVars:     tgPrefix.EnvVars(AgeRecipientFlagName),
+			Destination: &opts.AgeRecipients,
+			Usage:       "Encrypt the value with age to the given recipient. Can be specified multiple times.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        KMSKeyIDFlagName,
+			EnvVars:     tgPrefix.EnvVars(KMSKeyIDFlagName),
+			Destination: &opts.KMSKeyID,
+			Usage:       "Encrypt the value with the given AWS KMS key ID, ARN or alias.",
+		}),
+	}
+}
+
+func NewCommand(l log.Logger, opts *options.TerragruntOptions) *cli.Command {
+	cmdOpts := &Options{TerragruntOptions: opts}
+
+	return &cli.Command{
+		Name:      CommandName,
+		Usage:     "Encrypt a value to be declared inline in the inputs or locals of a Terragrunt configuration.",
+		UsageText: usageText,
+		Flags:     NewFlags(cmdOpts, nil),
+		Action: func(ctx *cli.Context) error {
+			return Run(ctx, l, cmdOpts, ctx.Args().First())
+		},
+	}
+}
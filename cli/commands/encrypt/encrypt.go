@@ -0,0 +1,53 @@
+package encrypt
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/internal/encryption"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+// Run encrypts the given value, or the standard input when no value is given, and prints the envelope to declare in
+// the Terragrunt configuration.
+func Run(ctx context.Context, l log.Logger, opts *Options, value string) error {
+	if (len(opts.AgeRecipients) == 0) == (opts.KMSKeyID == "") {
+		return errors.Errorf("exactly one of --%s or --%s must be set", AgeRecipientFlagName, KMSKeyIDFlagName)
+	}
+
+	plaintext := []byte(value)
+
+	if value == "" {
+		input, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return errors.New(err)
+		}
+
+		plaintext = []byte(strings.TrimSuffix(string(input), "\n"))
+	}
+
+	var (
+		envelope *encryption.Envelope
+		err      error
+	)
+
+	if opts.KMSKeyID != "" {
+		envelope, err = encryption.EncryptKMS(ctx, l, opts.TerragruntOptions, opts.KMSKeyID, plaintext)
+	} else {
+		envelope, err = encryption.EncryptAge(plaintext, opts.AgeRecipients)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintln(opts.Writer, envelope.String()); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
@@ -25,6 +25,7 @@ const (
 	External       = "external"
 	Exclude        = "exclude"
 	Include        = "include"
+	Tags           = "tags"
 
 	QueueConstructAsFlagName  = "queue-construct-as"
 	QueueConstructAsFlagAlias = "as"
@@ -78,6 +79,12 @@ func NewFlags(opts *Options, prefix flags.Prefix) cli.Flags {
 			Destination: &opts.Include,
 			Usage:       "Display include configurations in the results (only when using --format=json).",
 		}),
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        Tags,
+			EnvVars:     tgPrefix.EnvVars(Tags),
+			Destination: &opts.Tags,
+			Usage:       "Display tags in the results (only when using --format=json).",
+		}),
 		flags.NewFlag(&cli.BoolFlag{
 			Name:        External,
 			EnvVars:     tgPrefix.EnvVars(External),
@@ -43,6 +43,10 @@ func Run(ctx context.Context, l log.Logger, opts *Options) error {
 		d = d.WithParseInclude()
 	}
 
+	if opts.Tags {
+		d = d.WithParseTags()
+	}
+
 	if opts.QueueConstructAs != "" {
 		d = d.WithParseExclude()
 		d = d.WithDiscoveryContext(&discovery.DiscoveryContext{
@@ -132,6 +136,8 @@ type FoundConfig struct {
 	Exclude *config.ExcludeConfig `json:"exclude,omitempty"`
 	Include map[string]string     `json:"include,omitempty"`
 
+	Tags []string `json:"tags,omitempty"`
+
 	Dependencies []string `json:"dependencies,omitempty"`
 }
 
@@ -178,6 +184,10 @@ func discoveredToFound(configs discovery.DiscoveredConfigs, opts *Options) (Foun
 			}
 		}
 
+		if opts.Tags && config.Parsed != nil {
+			foundCfg.Tags = config.Parsed.Tags
+		}
+
 		if !opts.Dependencies || len(config.Dependencies) == 0 {
 			foundCfgs = append(foundCfgs, foundCfg)
 
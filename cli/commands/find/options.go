@@ -56,6 +56,9 @@ type Options struct {
 	// Include determines if Include configurations should be included in the output.
 	Include bool
 
+	// Tags determines if the tags of the units should be included in the output.
+	Tags bool
+
 	// External determines if external dependencies should be included in the output.
 	External bool
 }
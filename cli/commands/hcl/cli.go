@@ -1,8 +1,9 @@
-// Package hcl provides commands for formatting and validating HCL configurations.
+// Package hcl provides commands for formatting, validating and linting HCL configurations.
 package hcl
 
 import (
 	"github.com/gruntwork-io/terragrunt/cli/commands/hcl/format"
+	"github.com/gruntwork-io/terragrunt/cli/commands/hcl/lint"
 	"github.com/gruntwork-io/terragrunt/cli/commands/hcl/validate"
 	"github.com/gruntwork-io/terragrunt/internal/cli"
 	"github.com/gruntwork-io/terragrunt/options"
@@ -19,6 +20,7 @@ func NewCommand(l log.Logger, opts *options.TerragruntOptions) *cli.Command {
 		Subcommands: cli.Commands{
 			format.NewCommand(l, opts),
 			validate.NewCommand(l, opts),
+			lint.NewCommand(l, opts),
 		},
 		Action: cli.ShowCommandHelp,
 	}
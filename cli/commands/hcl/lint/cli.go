@@ -0,0 +1,52 @@
+// Package lint provides the command to lint Terragrunt configurations against a set of rules.
+package lint
+
+import (
+	"github.com/gruntwork-io/terragrunt/cli/flags"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/internal/lint"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+const (
+	CommandName = "lint"
+
+	FormatFlagName     = "format"
+	LintConfigFlagName = "lint-config"
+)
+
+func NewFlags(opts *options.TerragruntOptions, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	return cli.Flags{
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        FormatFlagName,
+			EnvVars:     tgPrefix.EnvVars("lint-format"),
+			Destination: &opts.HCLLintFormat,
+			Usage:       "The output format of the findings: text, json or sarif.",
+			Action: func(ctx *cli.Context, value string) error {
+				_, err := lint.ParseFormat(value)
+				return err
+			},
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        LintConfigFlagName,
+			EnvVars:     tgPrefix.EnvVars(LintConfigFlagName),
+			Destination: &opts.HCLLintConfigFile,
+			Usage:       "The path to the lint config file, overriding the built-in rules and defining custom rules. Defaults to " + lint.DefaultConfigFileName + " in the working directory, if present.",
+		}),
+	}
+}
+
+func NewCommand(l log.Logger, opts *options.TerragruntOptions) *cli.Command {
+	return &cli.Command{
+		Name:  CommandName,
+		Usage: "Recursively find HashiCorp Configuration Language (HCL) files and check them against the lint rules.",
+		Flags: NewFlags(opts, nil),
+		Action: func(ctx *cli.Context) error {
+			return Run(ctx, l, opts.OptionsFromContext(ctx))
+		},
+	}
+}
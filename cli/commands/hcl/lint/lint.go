@@ -0,0 +1,138 @@
+package lint
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/mattn/go-zglob"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/lint"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// toolName is the name of the tool written to the SARIF log.
+const toolName = "terragrunt"
+
+var excludePaths = []string{
+	util.TerragruntCacheDir,
+	util.DefaultBoilerplateDir,
+	config.StackDir,
+	".terraform",
+}
+
+// configFilePatterns are the patterns of the names of the files linted, the HCL files and the configs in the other
+// formats supported by the parser.
+var configFilePatterns = []string{
+	"*.hcl",
+	"*.hcl.json",
+	config.DefaultTerragruntYAMLConfigPath,
+	config.DefaultTerragruntYMLConfigPath,
+}
+
+func Run(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) error {
+	format := lint.FormatText
+
+	if opts.HCLLintFormat != "" {
+		var err error
+		if format, err = lint.ParseFormat(opts.HCLLintFormat); err != nil {
+			return err
+		}
+	}
+
+	cfg, err := readLintConfig(opts)
+	if err != nil {
+		return err
+	}
+
+	linter, err := lint.NewLinter(cfg)
+	if err != nil {
+		return err
+	}
+
+	files, err := findHCLFiles(l, opts.WorkingDir)
+	if err != nil {
+		return err
+	}
+
+	var findings []lint.Finding
+
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			return errors.New(err)
+		}
+
+		findings = append(findings, linter.Lint(file, src)...)
+	}
+
+	writer := lint.NewWriter(opts.Writer, format, opts.WorkingDir, toolName)
+	if err := writer.Write(linter.Rules(), findings); err != nil {
+		return err
+	}
+
+	// Return an error, so Terragrunt exits with a non-zero code when any of the findings is an error.
+	if count := lint.ErrorCount(findings); count > 0 {
+		return errors.Errorf("%d lint error(s) found", count)
+	}
+
+	return nil
+}
+
+// readLintConfig reads the lint config set with the `--lint-config` flag, or the default lint config of the working
+// directory, if present.
+func readLintConfig(opts *options.TerragruntOptions) (*lint.Config, error) {
+	path := opts.HCLLintConfigFile
+
+	if path == "" {
+		path = filepath.Join(opts.WorkingDir, lint.DefaultConfigFileName)
+		if !util.FileExists(path) {
+			return nil, nil
+		}
+	} else if !filepath.IsAbs(path) {
+		path = filepath.Join(opts.WorkingDir, path)
+	}
+
+	return lint.ReadConfig(path)
+}
+
+// findHCLFiles returns the HCL files of the directory tree, including the JSON and YAML configs, except the hidden files
+// and the generated files.
+func findHCLFiles(l log.Logger, dir string) ([]string, error) {
+	var files []string
+
+	for _, pattern := range configFilePatterns {
+		// zglob normalizes paths to "/"
+		matches, err := zglob.Glob(util.JoinPath(dir, "**", pattern))
+		if err != nil {
+			return nil, errors.New(err)
+		}
+
+		files = append(files, matches...)
+	}
+
+	var filtered []string
+
+	for _, file := range files {
+		pathList := strings.Split(file, "/")
+
+		if strings.HasPrefix(filepath.Base(file), ".") || slices.ContainsFunc(excludePaths, func(path string) bool {
+			return slices.Contains(pathList, path)
+		}) {
+			l.Debugf("%s was ignored", file)
+			continue
+		}
+
+		filtered = append(filtered, filepath.FromSlash(file))
+	}
+
+	l.Debugf("Found %d config files to lint", len(filtered))
+
+	return filtered, nil
+}
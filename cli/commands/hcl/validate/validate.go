@@ -5,22 +5,15 @@ package validate
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"os"
-	"path/filepath"
 	"sort"
-	"strings"
 
 	"github.com/gruntwork-io/terragrunt/internal/runner"
 	"github.com/gruntwork-io/terragrunt/internal/runner/common"
 
-	"github.com/google/shlex"
 	"github.com/hashicorp/hcl/v2"
 	"golang.org/x/exp/slices"
 
-	"maps"
-
 	"github.com/gruntwork-io/terragrunt/cli/commands/run"
 	"github.com/gruntwork-io/terragrunt/config"
 	"github.com/gruntwork-io/terragrunt/config/hclparse"
@@ -31,11 +24,8 @@ import (
 	"github.com/gruntwork-io/terragrunt/options"
 	"github.com/gruntwork-io/terragrunt/pkg/log"
 	"github.com/gruntwork-io/terragrunt/tf"
-	"github.com/gruntwork-io/terragrunt/util"
 )
 
-const splitCount = 2
-
 func Run(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) error {
 	if opts.HCLValidateInputs {
 		if opts.HCLValidateShowConfigPath {
@@ -145,7 +135,7 @@ func runValidateInputs(ctx context.Context, l log.Logger, opts *options.Terragru
 
 	allVars := append(required, optional...)
 
-	allInputs, err := getDefinedTerragruntInputs(l, opts, cfg)
+	allInputs, err := run.DefinedTerragruntInputs(l, opts, cfg)
 	if err != nil {
 		return err
 	}
@@ -206,264 +196,3 @@ func runValidateInputs(ctx context.Context, l log.Logger, opts *options.Terragru
 
 	return nil
 }
-
-// getDefinedTerragruntInputs will return a list of names of all variables that are configured by terragrunt to be
-// passed into terraform. Terragrunt can pass in inputs from:
-// - var files defined on terraform.extra_arguments blocks.
-// - -var and -var-file args passed in on extra_arguments CLI args.
-// - env vars defined on terraform.extra_arguments blocks.
-// - env vars from the external runtime calling terragrunt.
-// - inputs blocks.
-// - automatically injected terraform vars (terraform.tfvars, terraform.tfvars.json, *.auto.tfvars, *.auto.tfvars.json)
-func getDefinedTerragruntInputs(l log.Logger, opts *options.TerragruntOptions, cfg *config.TerragruntConfig) ([]string, error) {
-	envVarTFVars := getTerraformInputNamesFromEnvVar(opts, cfg)
-	inputsTFVars := getTerraformInputNamesFromConfig(cfg)
-
-	varFileTFVars, err := getTerraformInputNamesFromVarFiles(l, opts, cfg)
-	if err != nil {
-		return nil, err
-	}
-
-	cliArgsTFVars, err := getTerraformInputNamesFromCLIArgs(l, opts, cfg)
-	if err != nil {
-		return nil, err
-	}
-
-	autoVarFileTFVars, err := getTerraformInputNamesFromAutomaticVarFiles(l, opts)
-	if err != nil {
-		return nil, err
-	}
-
-	// Dedupe the input vars. We use a map as a set to accomplish this.
-	tmpOut := map[string]bool{}
-	for _, varName := range envVarTFVars {
-		tmpOut[varName] = true
-	}
-
-	for _, varName := range inputsTFVars {
-		tmpOut[varName] = true
-	}
-
-	for _, varName := range varFileTFVars {
-		tmpOut[varName] = true
-	}
-
-	for _, varName := range cliArgsTFVars {
-		tmpOut[varName] = true
-	}
-
-	for _, varName := range autoVarFileTFVars {
-		tmpOut[varName] = true
-	}
-
-	out := []string{}
-	for varName := range tmpOut {
-		out = append(out, varName)
-	}
-
-	return out, nil
-}
-
-// getTerraformInputNamesFromEnvVar will check the runtime environment variables and the configured environment
-// variables from extra_arguments blocks to see if there are any TF_VAR environment variables that set terraform
-// variables. This will return the list of names of variables that are set in this way by the given terragrunt
-// configuration.
-func getTerraformInputNamesFromEnvVar(opts *options.TerragruntOptions, terragruntConfig *config.TerragruntConfig) []string {
-	envVars := opts.Env
-
-	// Make sure to check if there are configured env vars in the parsed terragrunt config.
-	if terragruntConfig.Terraform != nil {
-		for _, arg := range terragruntConfig.Terraform.ExtraArgs {
-			if arg.EnvVars != nil {
-				maps.Copy(envVars, *arg.EnvVars)
-			}
-		}
-	}
-
-	var (
-		out         = []string{}
-		tfVarPrefix = fmt.Sprintf(tf.EnvNameTFVarFmt, "")
-	)
-
-	for envName := range envVars {
-		if after, ok := strings.CutPrefix(envName, tfVarPrefix); ok {
-			inputName := after
-			out = append(out, inputName)
-		}
-	}
-
-	return out
-}
-
-// getTerraformInputNamesFromConfig will return the list of names of variables configured by the inputs block in the
-// terragrunt config.
-func getTerraformInputNamesFromConfig(terragruntConfig *config.TerragruntConfig) []string {
-	out := []string{}
-	for inputName := range terragruntConfig.Inputs {
-		out = append(out, inputName)
-	}
-
-	return out
-}
-
-// getTerraformInputNamesFromVarFiles will return the list of names of variables configured by var files set in the
-// extra_arguments block required_var_files and optional_var_files settings of the given terragrunt config.
-func getTerraformInputNamesFromVarFiles(l log.Logger, opts *options.TerragruntOptions, terragruntConfig *config.TerragruntConfig) ([]string, error) {
-	if terragruntConfig.Terraform == nil {
-		return nil, nil
-	}
-
-	varFiles := []string{}
-	for _, arg := range terragruntConfig.Terraform.ExtraArgs {
-		varFiles = append(varFiles, arg.GetVarFiles(l)...)
-	}
-
-	return getVarNamesFromVarFiles(l, opts, varFiles)
-}
-
-// getTerraformInputNamesFromCLIArgs will return the list of names of variables configured by -var and -var-file CLI
-// args that are passed in via the configured arguments attribute in the extra_arguments block of the given terragrunt
-// config and those that are directly passed in via the CLI.
-func getTerraformInputNamesFromCLIArgs(l log.Logger, opts *options.TerragruntOptions, terragruntConfig *config.TerragruntConfig) ([]string, error) {
-	inputNames, varFiles, err := GetVarFlagsFromArgList(opts.TerraformCliArgs)
-	if err != nil {
-		return inputNames, err
-	}
-
-	if terragruntConfig.Terraform != nil {
-		for _, arg := range terragruntConfig.Terraform.ExtraArgs {
-			if arg.Arguments != nil {
-				vars, rawVarFiles, err := GetVarFlagsFromArgList(*arg.Arguments)
-				if err != nil {
-					return inputNames, err
-				}
-
-				inputNames = append(inputNames, vars...)
-				varFiles = append(varFiles, rawVarFiles...)
-			}
-		}
-	}
-
-	fileVars, err := getVarNamesFromVarFiles(l, opts, varFiles)
-	if err != nil {
-		return inputNames, err
-	}
-
-	inputNames = append(inputNames, fileVars...)
-
-	return inputNames, nil
-}
-
-// getTerraformInputNamesFromAutomaticVarFiles returns all the variables names
-func getTerraformInputNamesFromAutomaticVarFiles(l log.Logger, opts *options.TerragruntOptions) ([]string, error) {
-	base := opts.WorkingDir
-	automaticVarFiles := []string{}
-
-	tfTFVarsFile := filepath.Join(base, "terraform.tfvars")
-	if util.FileExists(tfTFVarsFile) {
-		automaticVarFiles = append(automaticVarFiles, tfTFVarsFile)
-	}
-
-	tfTFVarsJSONFile := filepath.Join(base, "terraform.tfvars.json")
-	if util.FileExists(tfTFVarsJSONFile) {
-		automaticVarFiles = append(automaticVarFiles, tfTFVarsJSONFile)
-	}
-
-	varFiles, err := filepath.Glob(filepath.Join(base, "*.auto.tfvars"))
-	if err != nil {
-		return nil, err
-	}
-
-	automaticVarFiles = append(automaticVarFiles, varFiles...)
-
-	jsonVarFiles, err := filepath.Glob(filepath.Join(base, "*.auto.tfvars.json"))
-	if err != nil {
-		return nil, err
-	}
-
-	automaticVarFiles = append(automaticVarFiles, jsonVarFiles...)
-
-	return getVarNamesFromVarFiles(l, opts, automaticVarFiles)
-}
-
-// getVarNamesFromVarFiles will parse all the given var files and returns a list of names of variables that are
-// configured in all of them combined together.
-func getVarNamesFromVarFiles(l log.Logger, opts *options.TerragruntOptions, varFiles []string) ([]string, error) {
-	inputNames := []string{}
-
-	for _, varFile := range varFiles {
-		fileVars, err := getVarNamesFromVarFile(l, opts, varFile)
-		if err != nil {
-			return inputNames, err
-		}
-
-		inputNames = append(inputNames, fileVars...)
-	}
-
-	return inputNames, nil
-}
-
-// getVarNamesFromVarFile will parse the given terraform var file and return a list of names of variables that are
-// configured in that var file.
-func getVarNamesFromVarFile(l log.Logger, opts *options.TerragruntOptions, varFile string) ([]string, error) {
-	fileContents, err := os.ReadFile(varFile)
-	if err != nil {
-		return nil, err
-	}
-
-	var variables map[string]any
-	if strings.HasSuffix(varFile, "json") {
-		if err := json.Unmarshal(fileContents, &variables); err != nil {
-			return nil, err
-		}
-	} else {
-		if err := config.ParseAndDecodeVarFile(l, opts, varFile, fileContents, &variables); err != nil {
-			return nil, err
-		}
-	}
-
-	out := []string{}
-	for varName := range variables {
-		out = append(out, varName)
-	}
-
-	return out, nil
-}
-
-// GetVarFlagsFromArgList returns the CLI flags defined on the provided arguments list that correspond to -var and -var-file.
-// Returns two slices, one for `-var` args (the first one) and one for `-var-file` args (the second one).
-func GetVarFlagsFromArgList(argList []string) ([]string, []string, error) {
-	vars := []string{}
-	varFiles := []string{}
-
-	for _, arg := range argList {
-		// Use shlex to handle shell style quoting rules. This will reduce quoted args to remove quoting rules. For
-		// example, the string:
-		// -var="'"foo"'"='bar'
-		// becomes:
-		// -var='foo'=bar
-		shlexedArgSlice, err := shlex.Split(arg)
-		if err != nil {
-			return vars, varFiles, err
-		}
-		// Since we expect each element in extra_args.arguments to correspond to a single arg for terraform, we join
-		// back the shlex split slice even if it thinks there are multiple.
-		shlexedArg := strings.Join(shlexedArgSlice, " ")
-
-		if strings.HasPrefix(shlexedArg, "-var=") {
-			// -var is passed in in the format -var=VARNAME=VALUE, so we split on '=' and take the middle value.
-			splitArg := strings.Split(shlexedArg, "=")
-			if len(splitArg) < splitCount {
-				return vars, varFiles, fmt.Errorf("unexpected -var arg format in terraform.extra_arguments.arguments. Expected '-var=VARNAME=VALUE', got %s", arg)
-			}
-
-			vars = append(vars, splitArg[1])
-		}
-
-		if after, ok := strings.CutPrefix(shlexedArg, "-var-file="); ok {
-			varFiles = append(varFiles, after)
-		}
-	}
-
-	return vars, varFiles, nil
-}
@@ -24,6 +24,7 @@ const (
 	HiddenFlagName       = "hidden"
 	DependenciesFlagName = "dependencies"
 	ExternalFlagName     = "external"
+	TagsFlagName         = "tags"
 
 	DAGFlagName = "dag"
 
@@ -60,6 +61,12 @@ func NewFlags(opts *Options, prefix flags.Prefix) cli.Flags {
 			Destination: &opts.External,
 			Usage:       "Discover external dependencies from initial results, and add them to top-level results.",
 		}),
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        TagsFlagName,
+			EnvVars:     tgPrefix.EnvVars(TagsFlagName),
+			Destination: &opts.Tags,
+			Usage:       "Include tags in list results (only when using --long).",
+		}),
 		flags.NewFlag(&cli.BoolFlag{
 			Name:        TreeFlagName,
 			EnvVars:     tgPrefix.EnvVars(TreeFlagName),
@@ -38,6 +38,10 @@ func Run(ctx context.Context, l log.Logger, opts *Options) error {
 		d = d.WithDiscoverExternalDependencies()
 	}
 
+	if opts.Tags {
+		d = d.WithParseTags()
+	}
+
 	if opts.QueueConstructAs != "" {
 		d = d.WithParseExclude()
 		d = d.WithDiscoverDependencies()
@@ -146,6 +150,8 @@ type ListedConfig struct {
 	Type discovery.ConfigType
 	Path string
 
+	Tags []string
+
 	Dependencies []*ListedConfig
 }
 
@@ -200,6 +206,10 @@ func discoveredToListed(configs discovery.DiscoveredConfigs, opts *Options) (Lis
 			Path: relPath,
 		}
 
+		if opts.Tags && config.Parsed != nil {
+			listedCfg.Tags = config.Parsed.Tags
+		}
+
 		if len(config.Dependencies) == 0 {
 			listedCfgs = append(listedCfgs, listedCfg)
 
@@ -328,9 +338,10 @@ func shouldColor(l log.Logger) bool {
 
 // renderLong renders the configurations in a long format.
 func renderLong(opts *Options, configs ListedConfigs, c *Colorizer) error {
-	longestPathLen := getLongestPathLen(configs)
+	longestPathLen := max(getLongestPathLen(configs), len("Path"))
+	longestTagsLen := getLongestTagsLen(configs)
 
-	err := renderLongHeadings(opts, c, longestPathLen)
+	err := renderLongHeadings(opts, c, longestPathLen, longestTagsLen)
 	if err != nil {
 		return errors.New(err)
 	}
@@ -346,6 +357,24 @@ func renderLong(opts *Options, configs ListedConfigs, c *Colorizer) error {
 			return errors.New(err)
 		}
 
+		// The length of the last written column, used to pad the next one.
+		lastColumnLen, lastColumnWidth := len(config.Path), longestPathLen
+
+		if opts.Tags {
+			tags := strings.Join(config.Tags, ", ")
+
+			if err := renderLongPadding(opts, lastColumnWidth-lastColumnLen); err != nil {
+				return err
+			}
+
+			_, err = opts.Writer.Write([]byte(tags))
+			if err != nil {
+				return errors.New(err)
+			}
+
+			lastColumnLen, lastColumnWidth = len(tags), longestTagsLen
+		}
+
 		if opts.Dependencies && len(config.Dependencies) > 0 {
 			colorizedDeps := []string{}
 
@@ -353,14 +382,8 @@ func renderLong(opts *Options, configs ListedConfigs, c *Colorizer) error {
 				colorizedDeps = append(colorizedDeps, c.Colorize(dep))
 			}
 
-			const extraDependenciesPadding = 2
-
-			dependenciesPadding := (longestPathLen - len(config.Path)) + extraDependenciesPadding
-			for range dependenciesPadding {
-				_, err := opts.Writer.Write([]byte(" "))
-				if err != nil {
-					return errors.New(err)
-				}
+			if err := renderLongPadding(opts, lastColumnWidth-lastColumnLen); err != nil {
+				return err
 			}
 
 			_, err = opts.Writer.Write([]byte(strings.Join(colorizedDeps, ", ")))
@@ -379,21 +402,30 @@ func renderLong(opts *Options, configs ListedConfigs, c *Colorizer) error {
 }
 
 // renderLongHeadings renders the headings for the long format.
-func renderLongHeadings(opts *Options, c *Colorizer, longestPathLen int) error {
+func renderLongHeadings(opts *Options, c *Colorizer, longestPathLen, longestTagsLen int) error {
 	_, err := opts.Writer.Write([]byte(c.ColorizeHeading("Type  Path")))
 	if err != nil {
 		return errors.New(err)
 	}
 
-	if opts.Dependencies {
-		const extraDependenciesPadding = 2
+	lastColumnLen, lastColumnWidth := len("Path"), longestPathLen
 
-		dependenciesPadding := (longestPathLen - len("Path")) + extraDependenciesPadding
-		for range dependenciesPadding {
-			_, err := opts.Writer.Write([]byte(" "))
-			if err != nil {
-				return errors.New(err)
-			}
+	if opts.Tags {
+		if err := renderLongPadding(opts, lastColumnWidth-lastColumnLen); err != nil {
+			return err
+		}
+
+		_, err = opts.Writer.Write([]byte(c.ColorizeHeading("Tags")))
+		if err != nil {
+			return errors.New(err)
+		}
+
+		lastColumnLen, lastColumnWidth = len("Tags"), longestTagsLen
+	}
+
+	if opts.Dependencies {
+		if err := renderLongPadding(opts, lastColumnWidth-lastColumnLen); err != nil {
+			return err
 		}
 
 		_, err = opts.Writer.Write([]byte(c.ColorizeHeading("Dependencies")))
@@ -410,6 +442,20 @@ func renderLongHeadings(opts *Options, c *Colorizer, longestPathLen int) error {
 	return nil
 }
 
+// renderLongPadding writes the given amount of spaces, along with the padding between the columns of the long format.
+func renderLongPadding(opts *Options, padding int) error {
+	const extraColumnPadding = 2
+
+	for range padding + extraColumnPadding {
+		_, err := opts.Writer.Write([]byte(" "))
+		if err != nil {
+			return errors.New(err)
+		}
+	}
+
+	return nil
+}
+
 // renderTabular renders the configurations in a tabular format.
 func renderTabular(opts *Options, configs ListedConfigs, c *Colorizer) error {
 	maxCols, colWidth := getMaxCols(configs)
@@ -684,3 +730,18 @@ func getLongestPathLen(configs ListedConfigs) int {
 
 	return longest
 }
+
+// getLongestTagsLen returns the length of the longest
+// comma separated list of tags in the list of configurations,
+// which is at least the length of the tags heading.
+func getLongestTagsLen(configs ListedConfigs) int {
+	longest := len("Tags")
+
+	for _, config := range configs {
+		if tagsLen := len(strings.Join(config.Tags, ", ")); tagsLen > longest {
+			longest = tagsLen
+		}
+	}
+
+	return longest
+}
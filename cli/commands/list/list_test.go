@@ -1,6 +1,7 @@
 package list_test
 
 import (
+	"bytes"
 	"io"
 	"os"
 	"path/filepath"
@@ -479,6 +480,56 @@ dependency "C" {
 	assert.Less(t, cIndex, fIndex, "C should come before F")
 }
 
+func TestLongFormatTags(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	testFiles := map[string]string{
+		"vpc/terragrunt.hcl": `tags = ["networking", "prod"]`,
+		"app/terragrunt.hcl": `
+tags = ["team-core"]
+
+dependency "vpc" {
+  config_path = "../vpc"
+}
+`,
+		"db/terragrunt.hcl": "",
+	}
+
+	for path, content := range testFiles {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(tmpDir, path)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(tmpDir, path), []byte(content), 0644))
+	}
+
+	tgOpts := options.NewTerragruntOptions()
+	tgOpts.WorkingDir = tmpDir
+
+	var output bytes.Buffer
+
+	opts := list.NewOptions(tgOpts)
+	opts.Format = "long"
+	opts.Mode = "normal"
+	opts.Dependencies = true
+	opts.Tags = true
+	opts.Writer = &output
+
+	l := logger.CreateLogger()
+
+	l.Formatter().SetDisabledColors(true)
+
+	err := list.Run(t.Context(), l, opts)
+	require.NoError(t, err)
+
+	expected := "" +
+		"Type  Path  Tags              Dependencies\n" +
+		"unit  app   team-core         vpc\n" +
+		"unit  db    \n" +
+		"unit  vpc   networking, prod\n"
+
+	assert.Equal(t, expected, output.String())
+}
+
 func TestColorizer(t *testing.T) {
 	t.Parallel()
 
@@ -49,6 +49,9 @@ type Options struct {
 	// External determines whether to include external dependencies in the output.
 	External bool
 
+	// Tags determines whether to include the tags of the units in the output.
+	Tags bool
+
 	// Tree determines whether to output in tree format.
 	Tree bool
 
@@ -34,7 +34,7 @@ func NewFlags(opts *Options, prefix flags.Prefix) cli.Flags {
 			Name:        FormatFlagName,
 			EnvVars:     tgPrefix.EnvVars(FormatFlagName),
 			Destination: &opts.Format,
-			Usage:       "The output format to render the config in. Currently supports: json",
+			Usage:       "The output format to render the config in. Currently supports: hcl, json",
 			Action: func(ctx *cli.Context, value string) error {
 				// Set the default output path based on the format.
 				switch value {
@@ -94,7 +94,7 @@ func NewFlags(opts *Options, prefix flags.Prefix) cli.Flags {
 			Name:        WithMetadataFlagName,
 			EnvVars:     tgPrefix.EnvVars(WithMetadataFlagName),
 			Destination: &opts.RenderMetadata,
-			Usage:       "Add metadata to the rendered output file.",
+			Usage:       "Add metadata to the rendered output file. In HCL format, the blocks and attributes are annotated with the file they came from.",
 		},
 			flags.WithDeprecatedEnvVars(tgPrefix.EnvVars("render-json-with-metadata"), terragruntPrefixControl), // `TG_RENDER_JSON_WITH_METADATA`
 			flags.WithDeprecatedNames(terragruntPrefix.FlagNames("with-metadata"), terragruntPrefixControl),     // `--terragrunt-with-metadata`, `TERRAGRUNT_WITH_METADATA`
@@ -6,6 +6,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 
@@ -51,10 +52,19 @@ func newRunRenderFunc(opts *Options) func(ctx context.Context, l log.Logger, ter
 }
 
 func renderHCL(_ context.Context, l log.Logger, opts *Options, cfg *config.TerragruntConfig) error {
+	// With metadata, the rendered config is annotated with comments indicating the file each block and attribute
+	// came from, which helps to debug deep include trees.
+	writeTo := cfg.WriteTo
+	if opts.RenderMetadata {
+		writeTo = func(w io.Writer) (int64, error) {
+			return cfg.WriteToWithProvenance(w, opts.TerragruntConfigPath)
+		}
+	}
+
 	if opts.Write {
 		buf := new(bytes.Buffer)
 
-		_, err := cfg.WriteTo(buf)
+		_, err := writeTo(buf)
 		if err != nil {
 			return err
 		}
@@ -64,7 +74,7 @@ func renderHCL(_ context.Context, l log.Logger, opts *Options, cfg *config.Terra
 
 	l.Infof("Rendering config %s", opts.TerragruntConfigPath)
 
-	_, err := cfg.WriteTo(opts.Writer)
+	_, err := writeTo(opts.Redactor.Writer(opts.Writer))
 	if err != nil {
 		return err
 	}
@@ -114,7 +124,7 @@ func renderJSON(ctx context.Context, l log.Logger, opts *Options, cfg *config.Te
 
 	l.Infof("Rendering config %s", opts.TerragruntConfigPath)
 
-	_, err = opts.Writer.Write(jsonBytes)
+	_, err = opts.Redactor.Writer(opts.Writer).Write(jsonBytes)
 	if err != nil {
 		return errors.New(err)
 	}
@@ -136,7 +146,7 @@ func writeRendered(l log.Logger, opts *Options, data []byte) error {
 	l.Debugf("Rendering config %s to %s", opts.TerragruntConfigPath, outPath)
 
 	const ownerWriteGlobalReadPerms = 0644
-	if err := os.WriteFile(outPath, data, ownerWriteGlobalReadPerms); err != nil {
+	if err := os.WriteFile(outPath, []byte(opts.Redactor.Redact(string(data))), ownerWriteGlobalReadPerms); err != nil {
 		return errors.New(err)
 	}
 
@@ -110,6 +110,76 @@ func TestRenderJSON_HCLFormat(t *testing.T) {
 	assert.Equal(t, testTerragruntConfigFixture, renderedBuffer.String())
 }
 
+func TestRenderHCL_WithMetadata(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "root.hcl"), []byte(`
+inputs = {
+  region = "us-east-1"
+  env    = "dev"
+}
+
+generate "provider" {
+  path      = "provider.tf"
+  if_exists = "overwrite_terragrunt"
+  contents  = "# provider"
+}
+`), 0644))
+
+	unitDir := filepath.Join(tmpDir, "app")
+	require.NoError(t, os.MkdirAll(unitDir, 0755))
+
+	configPath := filepath.Join(unitDir, "terragrunt.hcl")
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+
+terraform {
+  source = "test"
+}
+
+inputs = {
+  env = "prod"
+}
+`), 0644))
+
+	tgOptions, err := options.NewTerragruntOptionsForTest(configPath)
+	require.NoError(t, err)
+
+	opts := render.NewOptions(tgOptions)
+	opts.Format = render.FormatHCL
+	opts.RenderMetadata = true
+
+	var renderedBuffer bytes.Buffer
+	opts.TerragruntOptions.Writer = &renderedBuffer
+
+	err = render.Run(t.Context(), logger.CreateLogger(), opts)
+	require.NoError(t, err)
+
+	assert.Equal(t, `# Rendered from terragrunt.hcl, ../root.hcl
+
+# From terragrunt.hcl
+terraform {
+  source = "test"
+}
+# From ../root.hcl
+generate "provider" {
+  path        = "provider.tf"
+  if_exists   = "overwrite_terragrunt"
+  if_disabled = "skip"
+  contents    = "# provider"
+}
+inputs = {
+  # From terragrunt.hcl
+  env = "prod"
+  # From ../root.hcl
+  region = "us-east-1"
+}
+`, renderedBuffer.String())
+}
+
 // setupTest creates a temporary directory with a terragrunt config file and returns the necessary test setup
 func setupTest(t *testing.T) (*render.Options, string) {
 	t.Helper()
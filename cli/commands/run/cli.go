@@ -48,6 +48,17 @@ func NewCommand(l log.Logger, opts *options.TerragruntOptions) *cli.Command {
 	}
 
 	cmd = runall.WrapCommand(l, opts, cmd, Run, false)
+	cmd.Flags = append(cmd.Flags, runall.NewWatchFlags(opts, nil)...)
+	cmd.Flags = append(cmd.Flags, runall.NewResumeFlags(opts, nil)...)
+	cmd.Flags = append(cmd.Flags, runall.NewArtifactsFlags(opts, nil)...)
+	cmd.Flags = append(cmd.Flags, runall.NewDetailedExitCodeFlags(opts, nil)...)
+	cmd.Flags = append(cmd.Flags, runall.NewPreventDestroyFlags(opts, nil)...)
+	cmd.Flags = append(cmd.Flags, runall.NewSpeculativePlanFlags(opts, nil)...)
+	cmd.Flags = append(cmd.Flags, runall.NewPlanSummaryFlags(opts, nil)...)
+	cmd.Flags = append(cmd.Flags, runall.NewCostEstimateFlags(opts, nil)...)
+	cmd.Flags = append(cmd.Flags, runall.NewDriftCheckFlags(opts, nil)...)
+	cmd.Flags = append(cmd.Flags, runall.NewQueueDryRunFlags(opts, nil)...)
+	cmd.Flags = append(cmd.Flags, runall.NewUnitLogFlags(opts, nil)...)
 	cmd = graph.WrapCommand(l, opts, cmd, Run, false)
 	cmd = wrapWithStackGenerate(l, opts, cmd)
 
@@ -50,6 +50,15 @@ func (err ModuleIsProtected) Error() string {
 	return fmt.Sprintf("Unit is protected by the prevent_destroy flag in %s. Set it to false or remove it to allow destruction of the unit.", err.Opts.TerragruntConfigPath)
 }
 
+type InvalidInputsError struct {
+	ConfigPath string
+	Problems   []string
+}
+
+func (err InvalidInputsError) Error() string {
+	return fmt.Sprintf("The inputs of %s do not match the variables of the module:\n\t- %s", err.ConfigPath, strings.Join(err.Problems, "\n\t- "))
+}
+
 type MaxRetriesExceeded struct {
 	Opts *options.TerragruntOptions
 }
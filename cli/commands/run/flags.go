@@ -4,11 +4,16 @@ package run
 import (
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/gruntwork-io/terragrunt/cli/flags"
+	"github.com/gruntwork-io/terragrunt/config"
 	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/internal/ratelimit"
 	"github.com/gruntwork-io/terragrunt/internal/report"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
 	"github.com/gruntwork-io/terragrunt/internal/strict/controls"
 	"github.com/gruntwork-io/terragrunt/options"
 	"github.com/gruntwork-io/terragrunt/pkg/log"
@@ -16,22 +21,37 @@ import (
 )
 
 const (
-	ConfigFlagName                         = "config"
-	NoAutoInitFlagName                     = "no-auto-init"
-	NoAutoRetryFlagName                    = "no-auto-retry"
-	NoAutoApproveFlagName                  = "no-auto-approve"
-	NoAutoProviderCacheDirFlagName         = "no-auto-provider-cache-dir"
-	DownloadDirFlagName                    = "download-dir"
-	TFForwardStdoutFlagName                = "tf-forward-stdout"
-	TFPathFlagName                         = "tf-path"
-	FeatureFlagName                        = "feature"
-	ParallelismFlagName                    = "parallelism"
-	InputsDebugFlagName                    = "inputs-debug"
-	UnitsThatIncludeFlagName               = "units-that-include"
-	DependencyFetchOutputFromStateFlagName = "dependency-fetch-output-from-state"
-	UsePartialParseConfigCacheFlagName     = "use-partial-parse-config-cache"
-	SummaryPerUnitFlagName                 = "summary-per-unit"
-	VersionManagerFileNameFlagName         = "version-manager-file-name"
+	ConfigFlagName                            = "config"
+	NoAutoInitFlagName                        = "no-auto-init"
+	NoAutoRetryFlagName                       = "no-auto-retry"
+	NoAutoApproveFlagName                     = "no-auto-approve"
+	NoAutoProviderCacheDirFlagName            = "no-auto-provider-cache-dir"
+	DownloadDirFlagName                       = "download-dir"
+	TFForwardStdoutFlagName                   = "tf-forward-stdout"
+	TFPathFlagName                            = "tf-path"
+	FeatureFlagName                           = "feature"
+	FeatureSourceFlagName                     = "feature-source"
+	FeatureSourceTokenFlagName                = "feature-source-token"
+	FunctionPluginFlagName                    = "function-plugin"
+	AgeIdentityFileFlagName                   = "age-identity-file"
+	ParallelismFlagName                       = "parallelism"
+	InputsDebugFlagName                       = "inputs-debug"
+	ValidateInputsStrictFlagName              = "validate-inputs-strict"
+	PromptMissingInputsFlagName               = "prompt-missing-inputs"
+	UnitsThatIncludeFlagName                  = "units-that-include"
+	DependencyFetchOutputFromStateFlagName    = "dependency-fetch-output-from-state"
+	DependencyOutputCacheFlagName             = "dependency-output-cache"
+	NoDependencyOutputCacheFlagName           = "no-dependency-output-cache"
+	DependencyOutputCacheAgeRecipientFlagName = "dependency-output-cache-age-recipient"
+	DependencyOutputCacheKMSKeyIDFlagName     = "dependency-output-cache-kms-key-id"
+	DependencyOfflineFlagName                 = "dependency-offline"
+	DependencyFetchParallelismFlagName        = "dependency-fetch-parallelism"
+	DependencyFetchTimeoutFlagName            = "dependency-fetch-timeout"
+	DependencyRecordMockOutputsFlagName       = "dependency-record-mock-outputs"
+	DependencyPolicyFlagName                  = "dependency-policy"
+	UsePartialParseConfigCacheFlagName        = "use-partial-parse-config-cache"
+	SummaryPerUnitFlagName                    = "summary-per-unit"
+	VersionManagerFileNameFlagName            = "version-manager-file-name"
 
 	BackendBootstrapFlagName        = "backend-bootstrap"
 	BackendRequireBootstrapFlagName = "backend-require-bootstrap"
@@ -56,15 +76,27 @@ const (
 
 	// Queue related flags.
 
-	QueueIgnoreErrorsFlagName        = "queue-ignore-errors"
-	QueueIgnoreDAGOrderFlagName      = "queue-ignore-dag-order"
-	QueueExcludeExternalFlagName     = "queue-exclude-external"
-	QueueExcludeDirFlagName          = "queue-exclude-dir"
-	QueueExcludesFileFlagName        = "queue-excludes-file"
-	QueueIncludeDirFlagName          = "queue-include-dir"
-	QueueIncludeExternalFlagName     = "queue-include-external"
-	QueueStrictIncludeFlagName       = "queue-strict-include"
-	QueueIncludeUnitsReadingFlagName = "queue-include-units-reading"
+	QueueIgnoreErrorsFlagName         = "queue-ignore-errors"
+	QueueIgnoreDAGOrderFlagName       = "queue-ignore-dag-order"
+	QueueExcludeExternalFlagName      = "queue-exclude-external"
+	QueueExcludeDirFlagName           = "queue-exclude-dir"
+	QueueExcludesFileFlagName         = "queue-excludes-file"
+	QueueIncludeDirFlagName           = "queue-include-dir"
+	QueueExcludeTagFlagName           = "queue-exclude-tag"
+	QueueIncludeTagFlagName           = "queue-include-tag"
+	QueueFilterFlagName               = "queue-filter"
+	QueueIncludeChangedFlagName       = "queue-include-changed"
+	QueueShardFlagName                = "queue-shard"
+	QueueIncludeUnitFlagName          = "queue-include-unit"
+	QueueIncludeDependenciesFlagName  = "queue-include-dependencies"
+	QueueIncludeDependentsFlagName    = "queue-include-dependents"
+	QueueTagPriorityFlagName          = "queue-tag-priority"
+	QueueIncludeExternalFlagName      = "queue-include-external"
+	QueueIncludeExternalDepthFlagName = "queue-include-external-depth"
+	QueueIncludeExternalFileFlagName  = "queue-include-external-file"
+	QueueStrictIncludeFlagName        = "queue-strict-include"
+	QueueIncludeUnitsReadingFlagName  = "queue-include-units-reading"
+	QueueMaxFailuresFlagName          = "queue-max-failures"
 
 	// Terragrunt Provider Cache related flags.
 
@@ -88,25 +120,55 @@ const (
 	ReportFileFlagName     = "report-file"
 	ReportFormatFlagName   = "report-format"
 	ReportSchemaFlagName   = "report-schema-file"
+	ReportHTMLFlagName     = "report-html-file"
+	ReportJUnitFlagName    = "report-junit-file"
 
 	// `--all` related flags.
 
 	OutDirFlagName     = "out-dir"
 	JSONOutDirFlagName = "json-out-dir"
+	RunsDirFlagName    = "runs-dir"
+
+	RecordBundleFlagName  = "record-bundle"
+	StatusAddressFlagName = "status-address"
 
 	// `--graph` related flags.
 
 	GraphRootFlagName = "graph-root"
 
-	FailFastFlagName = "fail-fast"
+	FailFastFlagName      = "fail-fast"
+	FailurePolicyFlagName = "failure-policy"
+
+	UnitTimeoutFlagName            = "unit-timeout"
+	UnitTimeoutGracePeriodFlagName = "unit-timeout-grace-period"
+
+	StageGateFlagName     = "stage-gate"
+	ApproveGroupsFlagName = "approve-groups"
+
+	ApprovalGateFlagName        = "approval-gate"
+	ApprovalGateTokenFlagName   = "approval-gate-token"
+	ApprovalGateTimeoutFlagName = "approval-gate-timeout"
+
+	APIRateLimitFlagName = "api-rate-limit"
 )
 
+// DefaultQueueIncludeChangedRef is the git ref the changes are computed since when the queue-include-changed flag is
+// specified without a value.
+const DefaultQueueIncludeChangedRef = "HEAD"
+
+// DefaultQueueIncludeDepth is the depth of the dependencies, or dependents, of the units of the queue-include-unit
+// flag included when the queue-include-dependencies, or queue-include-dependents, flag is set without a value: all the
+// levels.
+const DefaultQueueIncludeDepth = -1
+
 // NewFlags creates and returns global flags.
 func NewFlags(l log.Logger, opts *options.TerragruntOptions, prefix flags.Prefix) cli.Flags {
 	tgPrefix := flags.Prefix{flags.TgPrefix}
 	terragruntPrefix := flags.Prefix{flags.TerragruntPrefix}
 	terragruntPrefixControl := flags.StrictControlsByCommand(opts.StrictControls, CommandName)
 	legacyLogsControl := flags.StrictControlsByCommand(opts.StrictControls, CommandName, controls.LegacyLogs)
+	defaultQueueIncludeChangedRef := DefaultQueueIncludeChangedRef
+	defaultQueueIncludeDepth := DefaultQueueIncludeDepth
 
 	flags := cli.Flags{
 		// `--all` related flags.
@@ -289,6 +351,13 @@ func NewFlags(l log.Logger, opts *options.TerragruntOptions, prefix flags.Prefix
 		},
 			flags.WithDeprecatedNames(terragruntPrefix.FlagNames("ignore-dependency-order"), terragruntPrefixControl)),
 
+		flags.NewFlag(&cli.GenericFlag[int]{
+			Name:        QueueMaxFailuresFlagName,
+			EnvVars:     tgPrefix.EnvVars(QueueMaxFailuresFlagName),
+			Destination: &opts.QueueMaxFailures,
+			Usage:       "Stop dispatching Units once the given number of Units failed, the running Units finishing, for --all commands.",
+		}),
+
 		flags.NewFlag(&cli.BoolFlag{
 			Name:        QueueExcludeExternalFlagName,
 			EnvVars:     tgPrefix.EnvVars(QueueExcludeExternalFlagName),
@@ -305,6 +374,20 @@ func NewFlags(l log.Logger, opts *options.TerragruntOptions, prefix flags.Prefix
 		},
 			flags.WithDeprecatedNames(terragruntPrefix.FlagNames("include-external-dependencies"), terragruntPrefixControl)),
 
+		flags.NewFlag(&cli.GenericFlag[int]{
+			Name:        QueueIncludeExternalDepthFlagName,
+			EnvVars:     tgPrefix.EnvVars(QueueIncludeExternalDepthFlagName),
+			Destination: &opts.IncludeExternalDependenciesDepth,
+			Usage:       "Max levels of external dependencies to include for --all commands. External dependencies deeper than this are assumed already applied.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        QueueIncludeExternalFileFlagName,
+			EnvVars:     tgPrefix.EnvVars(QueueIncludeExternalFileFlagName),
+			Destination: &opts.IncludeExternalDependenciesFile,
+			Usage:       "Path to a file with a list of the external dependencies to include without asking for --all commands.",
+		}),
+
 		flags.NewFlag(&cli.GenericFlag[int]{
 			Name:        ParallelismFlagName,
 			EnvVars:     tgPrefix.EnvVars(ParallelismFlagName),
@@ -337,6 +420,78 @@ func NewFlags(l log.Logger, opts *options.TerragruntOptions, prefix flags.Prefix
 		},
 			flags.WithDeprecatedNames(terragruntPrefix.FlagNames("include-dir"), terragruntPrefixControl)),
 
+		flags.NewFlag(&cli.SliceFlag[string]{
+			Name:        QueueExcludeTagFlagName,
+			EnvVars:     tgPrefix.EnvVars(QueueExcludeTagFlagName),
+			Destination: &opts.ExcludeTags,
+			Usage:       "Exclude the Units with the given tag from the queue of Units to run.",
+		}),
+
+		flags.NewFlag(&cli.SliceFlag[string]{
+			Name:        QueueIncludeTagFlagName,
+			EnvVars:     tgPrefix.EnvVars(QueueIncludeTagFlagName),
+			Destination: &opts.IncludeTags,
+			Usage:       "Only include the Units with the given tag in the queue of Units to run.",
+		}),
+
+		flags.NewFlag(&cli.SliceFlag[string]{
+			Name:        QueueFilterFlagName,
+			EnvVars:     tgPrefix.EnvVars(QueueFilterFlagName),
+			Destination: &opts.QueueFilters,
+			Usage:       "Only include the Units matching the given expression, e.g. 'tag == \"networking\" && env != \"prod\"', in the queue of Units to run.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        QueueIncludeChangedFlagName,
+			EnvVars:     tgPrefix.EnvVars(QueueIncludeChangedFlagName),
+			Destination: &opts.QueueIncludeChanged,
+			NoValue:     &defaultQueueIncludeChangedRef,
+			Usage:       "Only include the Units affected by the changes since the given git ref, HEAD by default, and their dependents in the queue of Units to run.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        QueueShardFlagName,
+			EnvVars:     tgPrefix.EnvVars(QueueShardFlagName),
+			Destination: &opts.QueueShard,
+			Usage:       "Only include the Units of the given shard, e.g. 2/4, in the queue of Units to run, the Units connected by their dependencies being in the same shard, to split a stack across parallel CI jobs.",
+			Action: func(_ *cli.Context, value string) error {
+				_, err := common.ParseQueueShard(value)
+
+				return err
+			},
+		}),
+
+		flags.NewFlag(&cli.SliceFlag[string]{
+			Name:        QueueIncludeUnitFlagName,
+			EnvVars:     tgPrefix.EnvVars(QueueIncludeUnitFlagName),
+			Destination: &opts.QueueIncludeUnits,
+			Usage:       "Unix-style glob of the directories of the Units to include in the queue of Units to run, without their dependencies, unless queue-include-dependencies is set.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[int]{
+			Name:        QueueIncludeDependenciesFlagName,
+			EnvVars:     tgPrefix.EnvVars(QueueIncludeDependenciesFlagName),
+			Destination: &opts.QueueIncludeDependencies,
+			NoValue:     &defaultQueueIncludeDepth,
+			Usage:       "Also include the dependencies of the Units of queue-include-unit in the queue of Units to run, up to the given number of levels, all of them by default.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[int]{
+			Name:        QueueIncludeDependentsFlagName,
+			EnvVars:     tgPrefix.EnvVars(QueueIncludeDependentsFlagName),
+			Destination: &opts.QueueIncludeDependents,
+			NoValue:     &defaultQueueIncludeDepth,
+			Usage:       "Also include the Units depending on the Units of queue-include-unit in the queue of Units to run, up to the given number of levels, all of them by default.",
+		}),
+
+		flags.NewFlag(&cli.MapFlag[string, int]{
+			Name:        QueueTagPriorityFlagName,
+			EnvVars:     tgPrefix.EnvVars(QueueTagPriorityFlagName),
+			Destination: &opts.QueueTagPriorities,
+			Usage:       "Priority of the Units with the given tag in the queue, e.g. critical=10, for the Units that don't set a queue_priority attribute. Of the Units ready to run, the highest priority Units are dispatched first.",
+			Splitter:    util.SplitComma,
+		}),
+
 		flags.NewFlag(&cli.BoolFlag{
 			Name:        InputsDebugFlagName,
 			EnvVars:     tgPrefix.EnvVars(InputsDebugFlagName),
@@ -368,6 +523,69 @@ func NewFlags(l log.Logger, opts *options.TerragruntOptions, prefix flags.Prefix
 		},
 			flags.WithDeprecatedNames(terragruntPrefix.FlagNames("fetch-dependency-output-from-state"), terragruntPrefixControl)),
 
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        DependencyOutputCacheFlagName,
+			EnvVars:     tgPrefix.EnvVars(DependencyOutputCacheFlagName),
+			Destination: &opts.DependencyOutputCache,
+			Usage:       "Cache the outputs of dependencies on disk, so they are reused across runs until the dependency is applied again.",
+		}),
+
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        NoDependencyOutputCacheFlagName,
+			EnvVars:     tgPrefix.EnvVars(NoDependencyOutputCacheFlagName),
+			Destination: &opts.NoDependencyOutputCache,
+			Usage:       "Never cache the outputs of dependencies on disk, even if --dependency-output-cache is set.",
+		}),
+
+		flags.NewFlag(&cli.SliceFlag[string]{
+			Name:        DependencyOutputCacheAgeRecipientFlagName,
+			EnvVars:     tgPrefix.EnvVars(DependencyOutputCacheAgeRecipientFlagName),
+			Destination: &opts.DependencyOutputCacheAgeRecipients,
+			Usage:       "Encrypt the outputs of dependencies cached on disk with age to the given recipient. Can be specified multiple times.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        DependencyOutputCacheKMSKeyIDFlagName,
+			EnvVars:     tgPrefix.EnvVars(DependencyOutputCacheKMSKeyIDFlagName),
+			Destination: &opts.DependencyOutputCacheKMSKeyID,
+			Usage:       "Encrypt the outputs of dependencies cached on disk with the given AWS KMS key ID, ARN or alias.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        DependencyPolicyFlagName,
+			EnvVars:     tgPrefix.EnvVars(DependencyPolicyFlagName),
+			Destination: &opts.DependencyPolicyFile,
+			Usage:       "Path to a dependency policy file forbidding some dependencies between units. The stack fails to build if any dependency violates the policy.",
+		}),
+
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        DependencyOfflineFlagName,
+			EnvVars:     tgPrefix.EnvVars(DependencyOfflineFlagName),
+			Destination: &opts.DependencyOffline,
+			Usage:       "Resolve the outputs of dependencies only from the outputs cached on disk and the mock outputs, without accessing their backends.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[int]{
+			Name:        DependencyFetchParallelismFlagName,
+			EnvVars:     tgPrefix.EnvVars(DependencyFetchParallelismFlagName),
+			Destination: &opts.DependencyFetchParallelism,
+			Usage:       "Maximum number of dependency outputs of a unit to fetch concurrently.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[int]{
+			Name:        DependencyFetchTimeoutFlagName,
+			EnvVars:     tgPrefix.EnvVars(DependencyFetchTimeoutFlagName),
+			Destination: &opts.DependencyFetchTimeout,
+			Usage:       "Timeout in seconds on fetching the outputs of each dependency. Can be overridden with the fetch_timeout_sec attribute of a dependency.",
+		}),
+
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        DependencyRecordMockOutputsFlagName,
+			EnvVars:     tgPrefix.EnvVars(DependencyRecordMockOutputsFlagName),
+			Destination: &opts.RecordDependencyMockOutputs,
+			Usage:       "Record the outputs of dependencies to the files set by their mock_outputs_file attribute.",
+		}),
+
 		flags.NewFlag(&cli.BoolFlag{
 			Name:        TFForwardStdoutFlagName,
 			EnvVars:     tgPrefix.EnvVars(TFForwardStdoutFlagName),
@@ -522,6 +740,49 @@ func NewFlags(l log.Logger, opts *options.TerragruntOptions, prefix flags.Prefix
 		},
 			flags.WithDeprecatedNames(terragruntPrefix.FlagNames("feature"), terragruntPrefixControl)),
 
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        FeatureSourceFlagName,
+			EnvVars:     tgPrefix.EnvVars(FeatureSourceFlagName),
+			Destination: &opts.FeatureFlagSource,
+			Usage:       "External source to resolve feature flags from: a JSON file path, an http(s):// endpoint, an s3://bucket/key object or a launchdarkly://<client-side-id> project.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        FeatureSourceTokenFlagName,
+			EnvVars:     tgPrefix.EnvVars(FeatureSourceTokenFlagName),
+			Destination: &opts.FeatureFlagSourceToken,
+			Usage:       "Bearer token sent to the HTTP feature flag source.",
+		}),
+
+		flags.NewFlag(&cli.MapFlag[string, string]{
+			Name:        FunctionPluginFlagName,
+			EnvVars:     tgPrefix.EnvVars(FunctionPluginFlagName),
+			Destination: &opts.FunctionPlugins,
+			Usage:       "Register a custom HCL function implemented by a plugin executable, in the form name=path.",
+			Splitter:    util.SplitComma,
+		}),
+
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        ValidateInputsStrictFlagName,
+			EnvVars:     tgPrefix.EnvVars(ValidateInputsStrictFlagName),
+			Destination: &opts.ValidateInputsStrict,
+			Usage:       "Fail when the inputs of a unit don't match the variables of its module.",
+		}),
+
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        PromptMissingInputsFlagName,
+			EnvVars:     tgPrefix.EnvVars(PromptMissingInputsFlagName),
+			Destination: &opts.PromptMissingInputs,
+			Usage:       "Prompt for the values of the required variables of the module that are not set by the inputs of the unit.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        AgeIdentityFileFlagName,
+			EnvVars:     tgPrefix.EnvVars(AgeIdentityFileFlagName),
+			Destination: &opts.AgeIdentityFile,
+			Usage:       "Path to the age identities used to decrypt the ENC[age,...] encrypted values of the configs.",
+		}),
+
 		// Terragrunt engine flags.
 
 		flags.NewFlag(&cli.BoolFlag{
@@ -633,12 +894,141 @@ func NewFlags(l log.Logger, opts *options.TerragruntOptions, prefix flags.Prefix
 			Destination: &opts.ReportSchemaFile,
 		}),
 
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        ReportHTMLFlagName,
+			EnvVars:     tgPrefix.EnvVars(ReportHTMLFlagName),
+			Usage:       `Path to generate a self-contained HTML summary of the report in.`,
+			Destination: &opts.ReportHTMLFile,
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        ReportJUnitFlagName,
+			EnvVars:     tgPrefix.EnvVars(ReportJUnitFlagName),
+			Usage:       `Path to generate a JUnit XML report in, each unit being a test case, for the test summaries of CI systems.`,
+			Destination: &opts.ReportJUnitFile,
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        RunsDirFlagName,
+			EnvVars:     tgPrefix.EnvVars(RunsDirFlagName),
+			Usage:       "Directory to store the records of the runs of run --all in, to inspect and abort them with the runs command. Defaults to the runs directory of the download dir.",
+			Destination: &opts.RunsDir,
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        RecordBundleFlagName,
+			EnvVars:     tgPrefix.EnvVars(RecordBundleFlagName),
+			Usage:       "Record every invocation of OpenTofu/Terraform, with its args, env, working dir and output, into the given run bundle, to replay it with runs replay.",
+			Destination: &opts.RecordBundle,
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        StatusAddressFlagName,
+			EnvVars:     tgPrefix.EnvVars(StatusAddressFlagName),
+			Usage:       "Serve the live status of the run of run --all, its queue and the status and duration of each unit, as JSON and as an HTML page, on the given address, e.g. 127.0.0.1:7777.",
+			Destination: &opts.StatusAddress,
+		}),
+
 		flags.NewFlag(&cli.BoolFlag{
 			Name:        FailFastFlagName,
 			EnvVars:     tgPrefix.EnvVars(FailFastFlagName),
 			Destination: &opts.FailFast,
 			Usage:       "Fail the run if any unit fails. This will make it so that any unit failing causes the whole run to fail.",
 		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:    FailurePolicyFlagName,
+			EnvVars: tgPrefix.EnvVars(FailurePolicyFlagName),
+			Usage:   "What happens to the other units when a unit fails, for the units that don't set a mode in their failure_policy block: continue, halt-dependents or halt-run. Defaults to halt-dependents.",
+			Setter: func(value string) error {
+				if !slices.Contains(config.FailurePolicyModes, value) {
+					return fmt.Errorf("unsupported failure policy: %s, must be one of %s", value, strings.Join(config.FailurePolicyModes, ", "))
+				}
+
+				opts.FailurePolicy = value
+
+				return nil
+			},
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[int]{
+			Name:        UnitTimeoutFlagName,
+			EnvVars:     tgPrefix.EnvVars(UnitTimeoutFlagName),
+			Destination: &opts.UnitTimeout,
+			Usage:       "Timeout in seconds on running each unit. Can be overridden with the run_timeout_sec attribute of a unit.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[int]{
+			Name:    UnitTimeoutGracePeriodFlagName,
+			EnvVars: tgPrefix.EnvVars(UnitTimeoutGracePeriodFlagName),
+			Usage:   "Time in seconds OpenTofu/Terraform is given to exit once interrupted at the timeout of a unit, before it is killed.",
+			Setter: func(value int) error {
+				// A command that is never killed would hang the run past the timeout of the unit.
+				if value < 1 {
+					return fmt.Errorf("invalid unit timeout grace period: %d, must be at least 1 second", value)
+				}
+
+				opts.UnitTimeoutGracePeriod = value
+
+				return nil
+			},
+		}),
+
+		flags.NewFlag(&cli.BoolFlag{
+			Name:        StageGateFlagName,
+			EnvVars:     tgPrefix.EnvVars(StageGateFlagName),
+			Destination: &opts.StageGate,
+			Usage:       "Pause run --all apply before each group of units of the run queue, showing the changes the group plans to make, until the group is approved.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[int]{
+			Name:        ApproveGroupsFlagName,
+			EnvVars:     tgPrefix.EnvVars(ApproveGroupsFlagName),
+			Destination: &opts.ApproveGroups,
+			Usage:       "Approve the first groups of units of run --all apply without prompting, enabling --stage-gate. With --non-interactive, the run stops after these groups.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        ApprovalGateFlagName,
+			EnvVars:     tgPrefix.EnvVars(ApprovalGateFlagName),
+			Destination: &opts.ApprovalGate,
+			Usage:       "External approval gate run --all apply waits for the approval of before running: an http(s):// webhook or a github://<owner>/<repo>/pull/<number> pull request.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        ApprovalGateTokenFlagName,
+			EnvVars:     tgPrefix.EnvVars(ApprovalGateTokenFlagName),
+			Destination: &opts.ApprovalGateToken,
+			Usage:       "Bearer token sent to the approval gate. The GitHub approval gate defaults to the GITHUB_TOKEN environment variable.",
+		}),
+
+		flags.NewFlag(&cli.GenericFlag[int]{
+			Name:        ApprovalGateTimeoutFlagName,
+			EnvVars:     tgPrefix.EnvVars(ApprovalGateTimeoutFlagName),
+			Destination: &opts.ApprovalGateTimeout,
+			Usage:       "Time in seconds run --all apply waits for the approval of the approval gate.",
+		}),
+
+		flags.NewFlag(&cli.MapFlag[string, string]{
+			Name:     APIRateLimitFlagName,
+			EnvVars:  tgPrefix.EnvVars(APIRateLimitFlagName),
+			Usage:    "Throttle the calls of all the units to the APIs of a provider or a service, e.g. aws=20 or aws/sts=5:10, in calls per second, with an optional burst.",
+			Splitter: util.SplitComma,
+			Action: func(_ *cli.Context, value map[string]string) error {
+				for key, val := range value {
+					limit, err := ratelimit.ParseLimit(val)
+					if err != nil {
+						return err
+					}
+
+					if err := opts.RateLimiter.SetLimit(key, limit); err != nil {
+						return err
+					}
+				}
+
+				return nil
+			},
+		}),
 	}
 
 	return flags.Sort()
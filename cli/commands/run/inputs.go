@@ -0,0 +1,444 @@
+package run
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/shlex"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-config-inspect/tfconfig"
+	"github.com/zclconf/go-cty/cty/convert"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/tf"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+const splitCount = 2
+
+// ValidateInputs checks the inputs of the unit against the variables of its module before running a command that
+// needs the variables, reporting the inputs that are not variables of the module, the required variables that are not
+// set, and the inputs that can't be converted to the type of their variable. The type mismatches are logged as warnings,
+// and the other problems at debug level, as the inputs shared by many units are often not variables of all their
+// modules, unless the `--validate-inputs-strict` flag is set, in which case an error is returned for all of them. If the
+// `--prompt-missing-inputs` flag is set, the user is prompted for the values of the missing required variables first.
+func ValidateInputs(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, cfg *config.TerragruntConfig) error {
+	if !slices.Contains(config.TerraformCommandsNeedVars, opts.TerraformCliArgs.First()) {
+		return nil
+	}
+
+	module, diags := tfconfig.LoadModule(opts.WorkingDir)
+	if diags.HasErrors() {
+		if opts.ValidateInputsStrict {
+			return errors.New(diags)
+		}
+
+		l.Debugf("Skipping the validation of the inputs, failed to load the module in %s: %v", opts.WorkingDir, diags)
+
+		return nil
+	}
+
+	definedInputs, err := DefinedTerragruntInputs(l, opts, cfg)
+	if err != nil {
+		return err
+	}
+
+	if missing := missingRequiredVariables(definedInputs, module.Variables); len(missing) > 0 && shouldPromptForMissingInputs(opts) {
+		prompted, err := promptForMissingInputs(ctx, l, opts, missing)
+		if err != nil {
+			return err
+		}
+
+		if cfg.Inputs == nil {
+			cfg.Inputs = map[string]any{}
+		}
+
+		maps.Copy(cfg.Inputs, prompted)
+		definedInputs = append(definedInputs, slices.Collect(maps.Keys(prompted))...)
+	}
+
+	problems := inputsProblems(cfg.Inputs, definedInputs, module.Variables)
+	if len(problems) == 0 {
+		return nil
+	}
+
+	if opts.ValidateInputsStrict {
+		messages := make([]string, 0, len(problems))
+		for _, problem := range problems {
+			messages = append(messages, problem.message)
+		}
+
+		return errors.New(InvalidInputsError{ConfigPath: opts.TerragruntConfigPath, Problems: messages})
+	}
+
+	for _, problem := range problems {
+		if problem.typeMismatch {
+			l.Warnf("Invalid input: %s", problem.message)
+		} else {
+			l.Debugf("Invalid input: %s", problem.message)
+		}
+	}
+
+	return nil
+}
+
+// inputProblem is a problem of an input of a unit against the variables of its module.
+type inputProblem struct {
+	message string
+	// typeMismatch is set if the input can't be converted to the type of its variable.
+	typeMismatch bool
+}
+
+// inputsProblems returns the problems of the given inputs, with the names of all the variables set for the module,
+// including the ones set with var files and env vars, against the given variables of the module.
+func inputsProblems(inputs map[string]any, definedInputs []string, variables map[string]*tfconfig.Variable) []inputProblem {
+	var problems []inputProblem
+
+	for _, name := range slices.Sorted(maps.Keys(inputs)) {
+		variable, ok := variables[name]
+		if !ok {
+			problems = append(problems, inputProblem{message: fmt.Sprintf("%s is not a variable of the module", name)})
+			continue
+		}
+
+		if err := checkInputType(inputs[name], variable.Type); err != nil {
+			typeConstraint := strings.Join(strings.Fields(variable.Type), " ")
+			problems = append(problems, inputProblem{
+				message:      fmt.Sprintf("%s does not match the type %s of the variable: %s", name, typeConstraint, err),
+				typeMismatch: true,
+			})
+		}
+	}
+
+	for _, variable := range missingRequiredVariables(definedInputs, variables) {
+		problems = append(problems, inputProblem{message: fmt.Sprintf("%s is a required variable of the module, but is not set", variable.Name)})
+	}
+
+	return problems
+}
+
+// missingRequiredVariables returns the required variables, sorted by name, that are not in the given defined inputs.
+func missingRequiredVariables(definedInputs []string, variables map[string]*tfconfig.Variable) []*tfconfig.Variable {
+	var missing []*tfconfig.Variable
+
+	for _, name := range slices.Sorted(maps.Keys(variables)) {
+		if variables[name].Required && !slices.Contains(definedInputs, name) {
+			missing = append(missing, variables[name])
+		}
+	}
+
+	return missing
+}
+
+// checkInputType checks the given input value can be converted to the given type constraint of a variable, the same
+// way as OpenTofu/Terraform does, e.g. `list(object({ name = string, port = optional(number) }))`.
+func checkInputType(value any, typeConstraint string) error {
+	if value == nil || typeConstraint == "" {
+		return nil
+	}
+
+	expr, diags := hclsyntax.ParseExpression([]byte(typeConstraint), "", hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil
+	}
+
+	ty, _, diags := typeexpr.TypeConstraintWithDefaults(expr)
+	if diags.HasErrors() {
+		// An invalid type is reported by OpenTofu/Terraform itself.
+		return nil
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	impliedType, err := ctyjson.ImpliedType(encoded)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	val, err := ctyjson.Unmarshal(encoded, impliedType)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	_, err = convert.Convert(val, ty)
+
+	return err
+}
+
+// DefinedTerragruntInputs will return a list of names of all variables that are configured by terragrunt to be
+// passed into terraform. Terragrunt can pass in inputs from:
+// - var files defined on terraform.extra_arguments blocks.
+// - -var and -var-file args passed in on extra_arguments CLI args.
+// - env vars defined on terraform.extra_arguments blocks.
+// - env vars from the external runtime calling terragrunt.
+// - inputs blocks.
+// - automatically injected terraform vars (terraform.tfvars, terraform.tfvars.json, *.auto.tfvars, *.auto.tfvars.json)
+func DefinedTerragruntInputs(l log.Logger, opts *options.TerragruntOptions, cfg *config.TerragruntConfig) ([]string, error) {
+	envVarTFVars := getTerraformInputNamesFromEnvVar(opts, cfg)
+	inputsTFVars := getTerraformInputNamesFromConfig(cfg)
+
+	varFileTFVars, err := getTerraformInputNamesFromVarFiles(l, opts, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	cliArgsTFVars, err := getTerraformInputNamesFromCLIArgs(l, opts, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	autoVarFileTFVars, err := getTerraformInputNamesFromAutomaticVarFiles(l, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Dedupe the input vars. We use a map as a set to accomplish this.
+	tmpOut := map[string]bool{}
+	for _, varName := range envVarTFVars {
+		tmpOut[varName] = true
+	}
+
+	for _, varName := range inputsTFVars {
+		tmpOut[varName] = true
+	}
+
+	for _, varName := range varFileTFVars {
+		tmpOut[varName] = true
+	}
+
+	for _, varName := range cliArgsTFVars {
+		tmpOut[varName] = true
+	}
+
+	for _, varName := range autoVarFileTFVars {
+		tmpOut[varName] = true
+	}
+
+	out := []string{}
+	for varName := range tmpOut {
+		out = append(out, varName)
+	}
+
+	return out, nil
+}
+
+// getTerraformInputNamesFromEnvVar will check the runtime environment variables and the configured environment
+// variables from extra_arguments blocks to see if there are any TF_VAR environment variables that set terraform
+// variables. This will return the list of names of variables that are set in this way by the given terragrunt
+// configuration.
+func getTerraformInputNamesFromEnvVar(opts *options.TerragruntOptions, terragruntConfig *config.TerragruntConfig) []string {
+	// Copy the env vars, so the env vars of extra_arguments blocks that don't apply to the command are not set.
+	envVars := map[string]string{}
+	maps.Copy(envVars, opts.Env)
+
+	// Make sure to check if there are configured env vars in the parsed terragrunt config.
+	if terragruntConfig.Terraform != nil {
+		for _, arg := range terragruntConfig.Terraform.ExtraArgs {
+			if arg.EnvVars != nil {
+				maps.Copy(envVars, *arg.EnvVars)
+			}
+		}
+	}
+
+	var (
+		out         = []string{}
+		tfVarPrefix = fmt.Sprintf(tf.EnvNameTFVarFmt, "")
+	)
+
+	for envName := range envVars {
+		if after, ok := strings.CutPrefix(envName, tfVarPrefix); ok {
+			inputName := after
+			out = append(out, inputName)
+		}
+	}
+
+	return out
+}
+
+// getTerraformInputNamesFromConfig will return the list of names of variables configured by the inputs block in the
+// terragrunt config.
+func getTerraformInputNamesFromConfig(terragruntConfig *config.TerragruntConfig) []string {
+	out := []string{}
+	for inputName := range terragruntConfig.Inputs {
+		out = append(out, inputName)
+	}
+
+	return out
+}
+
+// getTerraformInputNamesFromVarFiles will return the list of names of variables configured by var files set in the
+// extra_arguments block required_var_files and optional_var_files settings of the given terragrunt config.
+func getTerraformInputNamesFromVarFiles(l log.Logger, opts *options.TerragruntOptions, terragruntConfig *config.TerragruntConfig) ([]string, error) {
+	if terragruntConfig.Terraform == nil {
+		return nil, nil
+	}
+
+	varFiles := []string{}
+	for _, arg := range terragruntConfig.Terraform.ExtraArgs {
+		varFiles = append(varFiles, arg.GetVarFiles(l)...)
+	}
+
+	return getVarNamesFromVarFiles(l, opts, varFiles)
+}
+
+// getTerraformInputNamesFromCLIArgs will return the list of names of variables configured by -var and -var-file CLI
+// args that are passed in via the configured arguments attribute in the extra_arguments block of the given terragrunt
+// config and those that are directly passed in via the CLI.
+func getTerraformInputNamesFromCLIArgs(l log.Logger, opts *options.TerragruntOptions, terragruntConfig *config.TerragruntConfig) ([]string, error) {
+	inputNames, varFiles, err := GetVarFlagsFromArgList(opts.TerraformCliArgs)
+	if err != nil {
+		return inputNames, err
+	}
+
+	if terragruntConfig.Terraform != nil {
+		for _, arg := range terragruntConfig.Terraform.ExtraArgs {
+			if arg.Arguments != nil {
+				vars, rawVarFiles, err := GetVarFlagsFromArgList(*arg.Arguments)
+				if err != nil {
+					return inputNames, err
+				}
+
+				inputNames = append(inputNames, vars...)
+				varFiles = append(varFiles, rawVarFiles...)
+			}
+		}
+	}
+
+	fileVars, err := getVarNamesFromVarFiles(l, opts, varFiles)
+	if err != nil {
+		return inputNames, err
+	}
+
+	inputNames = append(inputNames, fileVars...)
+
+	return inputNames, nil
+}
+
+// getTerraformInputNamesFromAutomaticVarFiles returns all the variables names
+func getTerraformInputNamesFromAutomaticVarFiles(l log.Logger, opts *options.TerragruntOptions) ([]string, error) {
+	base := opts.WorkingDir
+	automaticVarFiles := []string{}
+
+	tfTFVarsFile := filepath.Join(base, "terraform.tfvars")
+	if util.FileExists(tfTFVarsFile) {
+		automaticVarFiles = append(automaticVarFiles, tfTFVarsFile)
+	}
+
+	tfTFVarsJSONFile := filepath.Join(base, "terraform.tfvars.json")
+	if util.FileExists(tfTFVarsJSONFile) {
+		automaticVarFiles = append(automaticVarFiles, tfTFVarsJSONFile)
+	}
+
+	varFiles, err := filepath.Glob(filepath.Join(base, "*.auto.tfvars"))
+	if err != nil {
+		return nil, err
+	}
+
+	automaticVarFiles = append(automaticVarFiles, varFiles...)
+
+	jsonVarFiles, err := filepath.Glob(filepath.Join(base, "*.auto.tfvars.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	automaticVarFiles = append(automaticVarFiles, jsonVarFiles...)
+
+	return getVarNamesFromVarFiles(l, opts, automaticVarFiles)
+}
+
+// getVarNamesFromVarFiles will parse all the given var files and returns a list of names of variables that are
+// configured in all of them combined together.
+func getVarNamesFromVarFiles(l log.Logger, opts *options.TerragruntOptions, varFiles []string) ([]string, error) {
+	inputNames := []string{}
+
+	for _, varFile := range varFiles {
+		fileVars, err := getVarNamesFromVarFile(l, opts, varFile)
+		if err != nil {
+			return inputNames, err
+		}
+
+		inputNames = append(inputNames, fileVars...)
+	}
+
+	return inputNames, nil
+}
+
+// getVarNamesFromVarFile will parse the given terraform var file and return a list of names of variables that are
+// configured in that var file.
+func getVarNamesFromVarFile(l log.Logger, opts *options.TerragruntOptions, varFile string) ([]string, error) {
+	fileContents, err := os.ReadFile(varFile)
+	if err != nil {
+		return nil, err
+	}
+
+	var variables map[string]any
+	if strings.HasSuffix(varFile, "json") {
+		if err := json.Unmarshal(fileContents, &variables); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := config.ParseAndDecodeVarFile(l, opts, varFile, fileContents, &variables); err != nil {
+			return nil, err
+		}
+	}
+
+	out := []string{}
+	for varName := range variables {
+		out = append(out, varName)
+	}
+
+	return out, nil
+}
+
+// GetVarFlagsFromArgList returns the CLI flags defined on the provided arguments list that correspond to -var and -var-file.
+// Returns two slices, one for `-var` args (the first one) and one for `-var-file` args (the second one).
+func GetVarFlagsFromArgList(argList []string) ([]string, []string, error) {
+	vars := []string{}
+	varFiles := []string{}
+
+	for _, arg := range argList {
+		// Use shlex to handle shell style quoting rules. This will reduce quoted args to remove quoting rules. For
+		// example, the string:
+		// -var="'"foo"'"='bar'
+		// becomes:
+		// -var='foo'=bar
+		shlexedArgSlice, err := shlex.Split(arg)
+		if err != nil {
+			return vars, varFiles, err
+		}
+		// Since we expect each element in extra_args.arguments to correspond to a single arg for terraform, we join
+		// back the shlex split slice even if it thinks there are multiple.
+		shlexedArg := strings.Join(shlexedArgSlice, " ")
+
+		if strings.HasPrefix(shlexedArg, "-var=") {
+			// -var is passed in in the format -var=VARNAME=VALUE, so we split on '=' and take the middle value.
+			splitArg := strings.Split(shlexedArg, "=")
+			if len(splitArg) < splitCount {
+				return vars, varFiles, fmt.Errorf("unexpected -var arg format in terraform.extra_arguments.arguments. Expected '-var=VARNAME=VALUE', got %s", arg)
+			}
+
+			vars = append(vars, splitArg[1])
+		}
+
+		if after, ok := strings.CutPrefix(shlexedArg, "-var-file="); ok {
+			varFiles = append(varFiles, after)
+		}
+	}
+
+	return vars, varFiles, nil
+}
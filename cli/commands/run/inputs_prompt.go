@@ -0,0 +1,247 @@
+package run
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-config-inspect/tfconfig"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+	"golang.org/x/term"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/shell"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// shouldPromptForMissingInputs returns true if the user is prompted for the values of the missing required variables:
+// only when --prompt-missing-inputs is set, in an interactive session, and never for the units of a run --all, which
+// run concurrently.
+func shouldPromptForMissingInputs(opts *options.TerragruntOptions) bool {
+	return opts.PromptMissingInputs && !opts.RunAll && !opts.NonInteractive && term.IsTerminal(int(os.Stdin.Fd()))
+}
+
+// promptForMissingInputs prompts the user for the values of the given missing required variables, the same way as
+// OpenTofu/Terraform does, and offers to write them back to the inputs of the unit config. Returns the entered values.
+func promptForMissingInputs(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, variables []*tfconfig.Variable) (map[string]any, error) {
+	if _, err := fmt.Fprintf(opts.ErrWriter, "The unit %s is missing required inputs.\n", filepath.Dir(opts.TerragruntConfigPath)); err != nil {
+		return nil, errors.New(err)
+	}
+
+	values := make(map[string]any, len(variables))
+	ctyValues := make(map[string]cty.Value, len(variables))
+
+	for _, variable := range variables {
+		prompt := "\nvar." + variable.Name + "\n"
+		if variable.Description != "" {
+			prompt += "  " + variable.Description + "\n"
+		}
+
+		if variable.Type != "" {
+			prompt += "  Type: " + strings.Join(strings.Fields(variable.Type), " ") + "\n"
+		}
+
+		prompt += "\n  Enter a value: "
+
+		input, err := shell.PromptUserForInput(ctx, l, prompt, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		val, err := parsePromptedValue(input, variable.Type)
+		if err != nil {
+			return nil, errors.Errorf("invalid value for var.%s: %w", variable.Name, err)
+		}
+
+		goVal, err := ctyValueToGo(val)
+		if err != nil {
+			return nil, err
+		}
+
+		ctyValues[variable.Name] = val
+		values[variable.Name] = goVal
+	}
+
+	configPath := opts.TerragruntConfigPath
+	if filepath.Ext(configPath) != ".hcl" {
+		return values, nil
+	}
+
+	save, err := shell.PromptUserForYesNo(ctx, l, "\nSave the entered values to the inputs of "+configPath+"?", opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if save {
+		if err := writeInputsToConfig(configPath, ctyValues); err != nil {
+			return nil, err
+		}
+
+		l.Infof("Saved the entered values to %s", configPath)
+	}
+
+	return values, nil
+}
+
+// parsePromptedValue parses the value entered by the user for a variable of the given type. Like OpenTofu/Terraform,
+// the value is taken literally for string variables and variables without a type, and parsed as an HCL expression, e.g.
+// `["a", "b"]` or `{ name = "vpc" }`, for the other types.
+func parsePromptedValue(input, typeConstraint string) (cty.Value, error) {
+	var (
+		ty           = cty.DynamicPseudoType
+		typeDefaults *typeexpr.Defaults
+	)
+
+	if typeConstraint != "" {
+		expr, diags := hclsyntax.ParseExpression([]byte(typeConstraint), "", hcl.InitialPos)
+		if diags.HasErrors() {
+			return cty.NilVal, errors.New(diags)
+		}
+
+		if ty, typeDefaults, diags = typeexpr.TypeConstraintWithDefaults(expr); diags.HasErrors() {
+			return cty.NilVal, errors.New(diags)
+		}
+	}
+
+	if ty == cty.String || ty == cty.DynamicPseudoType {
+		return cty.StringVal(input), nil
+	}
+
+	expr, diags := hclsyntax.ParseExpression([]byte(input), "input", hcl.InitialPos)
+	if diags.HasErrors() {
+		return cty.NilVal, errors.New(diags)
+	}
+
+	val, diags := expr.Value(nil)
+	if diags.HasErrors() {
+		return cty.NilVal, errors.New(diags)
+	}
+
+	// The defaults of the optional attributes are applied before the conversion, as OpenTofu/Terraform does.
+	if typeDefaults != nil {
+		val = typeDefaults.Apply(val)
+	}
+
+	val, err := convert.Convert(val, ty)
+	if err != nil {
+		return cty.NilVal, errors.New(err)
+	}
+
+	return val, nil
+}
+
+// ctyValueToGo converts the given value to the Go value stored in the inputs of a config.
+func ctyValueToGo(val cty.Value) (any, error) {
+	encoded, err := ctyjson.SimpleJSONValue{Value: val}.MarshalJSON()
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	var goVal any
+	if err := json.Unmarshal(encoded, &goVal); err != nil {
+		return nil, errors.New(err)
+	}
+
+	return goVal, nil
+}
+
+// writeInputsToConfig adds the given values to the inputs attribute of the config at the given path, creating the
+// attribute if the config doesn't have one. If the inputs are not an object literal, e.g. the result of a function call,
+// they are merged with the given values.
+func writeInputsToConfig(configPath string, values map[string]cty.Value) error {
+	src, err := os.ReadFile(configPath)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	file, diags := hclwrite.ParseConfig(src, configPath, hcl.InitialPos)
+	if diags.HasErrors() {
+		return errors.New(diags)
+	}
+
+	attrs := make([]hclwrite.ObjectAttrTokens, 0, len(values))
+
+	for _, name := range slices.Sorted(maps.Keys(values)) {
+		attrs = append(attrs, hclwrite.ObjectAttrTokens{
+			Name:  hclwrite.TokensForIdentifier(name),
+			Value: hclwrite.TokensForValue(values[name]),
+		})
+	}
+
+	body := file.Body()
+	newInputs := hclwrite.TokensForObject(attrs)
+
+	if attr := body.GetAttribute("inputs"); attr != nil {
+		existing := attr.Expr().BuildTokens(nil)
+
+		if isObjectLiteral(existing) {
+			// Insert the new attributes before the closing brace of the existing object.
+			closing := len(existing) - 1
+			for existing[closing].Type != hclsyntax.TokenCBrace {
+				closing--
+			}
+
+			// The new attributes start with a newline, omitted if the existing object already ends with one.
+			newAttrs := newInputs[1 : len(newInputs)-1]
+			if prev := existing[closing-1]; prev.Type == hclsyntax.TokenNewline || prev.Type == hclsyntax.TokenComment {
+				newAttrs = newAttrs[1:]
+			}
+
+			tokens := slices.Concat(existing[:closing], newAttrs, existing[closing:])
+			body.SetAttributeRaw("inputs", tokens)
+		} else {
+			body.SetAttributeRaw("inputs", hclwrite.TokensForFunctionCall("merge", existing, newInputs))
+		}
+	} else {
+		body.AppendNewline()
+		body.SetAttributeRaw("inputs", newInputs)
+	}
+
+	return util.WriteFileWithSamePermissions(configPath, configPath, hclwrite.Format(file.Bytes()))
+}
+
+// isObjectLiteral returns true if the given expression tokens are an object constructor, e.g. `{ name = "vpc" }`.
+func isObjectLiteral(tokens hclwrite.Tokens) bool {
+	var significant hclwrite.Tokens
+
+	for _, token := range tokens {
+		if token.Type != hclsyntax.TokenNewline && token.Type != hclsyntax.TokenComment {
+			significant = append(significant, token)
+		}
+	}
+
+	if len(significant) < 2 || significant[0].Type != hclsyntax.TokenOBrace || significant[len(significant)-1].Type != hclsyntax.TokenCBrace {
+		return false
+	}
+
+	// Make sure the braces are the ones of a single object, and not e.g. `{ a = 1 }...{ b = 2 }`.
+	depth := 0
+
+	for i, token := range significant {
+		switch token.Type {
+		case hclsyntax.TokenOBrace:
+			depth++
+		case hclsyntax.TokenCBrace:
+			depth--
+
+			if depth == 0 && i != len(significant)-1 {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,159 @@
+package run
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+func TestShouldPromptForMissingInputs(t *testing.T) {
+	t.Parallel()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(t.TempDir(), "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	// The prompts are opt-in.
+	assert.False(t, shouldPromptForMissingInputs(opts))
+
+	// The units of a run --all are never prompted for, even with the flag.
+	opts.PromptMissingInputs = true
+	opts.RunAll = true
+	assert.False(t, shouldPromptForMissingInputs(opts))
+
+	opts.RunAll = false
+	opts.NonInteractive = true
+	assert.False(t, shouldPromptForMissingInputs(opts))
+}
+
+func TestParsePromptedValue(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		expected       cty.Value
+		name           string
+		input          string
+		typeConstraint string
+		expectedErr    string
+	}{
+		{
+			name:     "untyped",
+			input:    `["taken", "literally"]`,
+			expected: cty.StringVal(`["taken", "literally"]`),
+		},
+		{
+			name:           "string",
+			input:          "vpc",
+			typeConstraint: "string",
+			expected:       cty.StringVal("vpc"),
+		},
+		{
+			name:           "number",
+			input:          "443",
+			typeConstraint: "number",
+			expected:       cty.NumberIntVal(443),
+		},
+		{
+			name:           "list",
+			input:          `["a", "b"]`,
+			typeConstraint: "list(string)",
+			expected:       cty.ListVal([]cty.Value{cty.StringVal("a"), cty.StringVal("b")}),
+		},
+		{
+			name:           "object with defaults",
+			input:          `{ cidr = "10.0.0.0/16" }`,
+			typeConstraint: `object({ cidr = string, az = optional(string, "us-east-1a") })`,
+			expected: cty.ObjectVal(map[string]cty.Value{
+				"cidr": cty.StringVal("10.0.0.0/16"),
+				"az":   cty.StringVal("us-east-1a"),
+			}),
+		},
+		{
+			name:           "type mismatch",
+			input:          "yes",
+			typeConstraint: "bool",
+			expectedErr:    "Variables not allowed",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			val, err := parsePromptedValue(tc.input, tc.typeConstraint)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.True(t, tc.expected.RawEquals(val), "expected %#v, got %#v", tc.expected, val)
+		})
+	}
+}
+
+func TestWriteInputsToConfig(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		config   string
+		expected string
+	}{
+		{
+			name:   "no inputs",
+			config: "terraform {\n  source = \"../modules/vpc\"\n}\n",
+			expected: `terraform {
+  source = "../modules/vpc"
+}
+
+inputs = {
+  name = "vpc"
+  port = 443
+}
+`,
+		},
+		{
+			name:   "object inputs",
+			config: "inputs = {\n  region = \"us-east-1\" # the region\n}\n",
+			expected: `inputs = {
+  region = "us-east-1" # the region
+  name   = "vpc"
+  port   = 443
+}
+`,
+		},
+		{
+			name:   "merged inputs",
+			config: "inputs = merge(local.common, { region = \"us-east-1\" })\n",
+			expected: `inputs = merge(merge(local.common, { region = "us-east-1" }), {
+  name = "vpc"
+  port = 443
+})
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			configPath := filepath.Join(t.TempDir(), "terragrunt.hcl")
+			require.NoError(t, os.WriteFile(configPath, []byte(tc.config), 0644))
+
+			require.NoError(t, writeInputsToConfig(configPath, map[string]cty.Value{
+				"port": cty.NumberIntVal(443),
+				"name": cty.StringVal("vpc"),
+			}))
+
+			content, err := os.ReadFile(configPath)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, string(content))
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package run_test
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/cli/commands/run"
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetVarFlagsFromExtraArgs(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name             string
+		args             []string
+		expectedVars     []string
+		expectedVarFiles []string
+	}{
+		{
+			"VarsWithQuotes",
+			[]string{`-var='hello=world'`, `-var="foo=bar"`, `-var="'"enabled"'"=false`},
+			[]string{"'enabled'", "foo", "hello"},
+			[]string{},
+		},
+		{
+			"VarFilesWithQuotes",
+			[]string{`-var-file='terraform.tfvars'`, `-var-file="other_vars.tfvars"`},
+			[]string{},
+			[]string{"other_vars.tfvars", "terraform.tfvars"},
+		},
+		{
+			"MixedWithOtherIrrelevantArgs",
+			[]string{"-lock=true", "-var=enabled=true", "-refresh=false"},
+			[]string{"enabled"},
+			[]string{},
+		},
+		{
+			"None",
+			[]string{"-lock=true", "-refresh=false"},
+			[]string{},
+			[]string{},
+		},
+		{
+			"SpaceInVarFileName",
+			[]string{"-var-file='this is a test.tfvars'"},
+			[]string{},
+			[]string{"this is a test.tfvars"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			vars, varFiles, err := run.GetVarFlagsFromArgList(tc.args)
+			require.NoError(t, err)
+			sort.Strings(vars)
+			sort.Strings(varFiles)
+			assert.Equal(t, tc.expectedVars, vars)
+			assert.Equal(t, tc.expectedVarFiles, varFiles)
+		})
+	}
+
+}
+
+func TestValidateInputs(t *testing.T) {
+	t.Parallel()
+
+	workingDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(workingDir, "variables.tf"), []byte(`
+variable "name" {
+  type = string
+}
+
+variable "port" {
+  type = number
+}
+
+variable "subnets" {
+  type = list(object({
+    cidr = string
+    az   = optional(string)
+  }))
+  default = []
+}
+
+variable "region" {
+  type = string
+}
+`), 0644))
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(workingDir, config.DefaultTerragruntConfigPath))
+	require.NoError(t, err)
+
+	opts.WorkingDir = workingDir
+	opts.TerraformCliArgs = []string{"plan"}
+	opts.Env = map[string]string{"TF_VAR_region": "us-east-1"}
+
+	cfg := &config.TerragruntConfig{
+		Inputs: map[string]any{
+			"name":    "vpc",
+			"port":    "not-a-number",
+			"subnets": []any{map[string]any{"cidr": "10.0.0.0/24"}, map[string]any{"az": "us-east-1a"}},
+			"tags":    map[string]any{"env": "prod"},
+		},
+	}
+
+	// The problems are only logged as warnings by default.
+	require.NoError(t, run.ValidateInputs(t.Context(), logger.CreateLogger(), opts, cfg))
+
+	opts.ValidateInputsStrict = true
+
+	err = run.ValidateInputs(t.Context(), logger.CreateLogger(), opts, cfg)
+	require.Error(t, err)
+
+	var inputsErr run.InvalidInputsError
+	require.True(t, errors.As(err, &inputsErr))
+	assert.Equal(t, []string{
+		"port does not match the type number of the variable: a number is required",
+		`subnets does not match the type list(object({ cidr = string az = optional(string) })) of the variable: element 1: attribute "cidr" is required`,
+		"tags is not a variable of the module",
+	}, inputsErr.Problems)
+
+	cfg.Inputs = map[string]any{"name": "vpc", "port": 443}
+	require.NoError(t, run.ValidateInputs(t.Context(), logger.CreateLogger(), opts, cfg))
+
+	// The inputs are only validated for the commands using the variables.
+	opts.TerraformCliArgs = []string{"output"}
+	cfg.Inputs = map[string]any{"tags": "unknown"}
+	require.NoError(t, run.ValidateInputs(t.Context(), logger.CreateLogger(), opts, cfg))
+}
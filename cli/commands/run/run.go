@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/gruntwork-io/terragrunt/internal/runner"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
 
 	"github.com/gruntwork-io/terragrunt/cli/commands/run/creds"
 	"github.com/gruntwork-io/terragrunt/cli/commands/run/creds/providers/amazonsts"
@@ -67,6 +68,17 @@ var TerraformCommandsThatUseState = []string{
 	"state",
 }
 
+// TerraformCommandsThatChangeState is the list of commands that can change the state, and so the outputs, of a unit.
+var TerraformCommandsThatChangeState = []string{
+	"apply",
+	"destroy",
+	"import",
+	"refresh",
+	"taint",
+	"untaint",
+	"state",
+}
+
 var TerraformCommandsThatDoNotNeedInit = []string{
 	"version",
 	"terragrunt-info",
@@ -116,6 +128,9 @@ func run(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, r *
 		return target.runErrorCallback(l, opts, terragruntConfig, err)
 	}
 
+	// Override the inputs with the ones of the inputs_overrides block of the command being run.
+	terragruntConfig.ApplyInputsOverrides(opts)
+
 	if target.isPoint(TargetPointParseConfig) {
 		return target.runCallback(ctx, l, opts, terragruntConfig)
 	}
@@ -127,6 +142,7 @@ func run(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, r *
 	}
 
 	opts.Engine = engine
+	opts.Executor = terragruntConfig.ExecutorOptions()
 
 	errConfig, err := terragruntConfig.ErrorsConfig()
 	if err != nil {
@@ -157,6 +173,11 @@ func run(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, r *
 		return nil
 	}
 
+	// Fail before anything is run if an assert block of the config doesn't hold.
+	if err := terragruntConfig.CheckAssertions(); err != nil {
+		return target.runErrorCallback(l, opts, terragruntConfig, err)
+	}
+
 	// We merge the OriginalIAMRoleOptions into the one from the config, because the CLI passed IAMRoleOptions has
 	// precedence.
 	opts.IAMRoleOptions = options.MergeIAMRoleOptions(
@@ -251,6 +272,10 @@ func run(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, r *
 		return target.runErrorCallback(l, opts, terragruntConfig, err)
 	}
 
+	if err := ValidateInputs(ctx, l, updatedTerragruntOptions, terragruntConfig); err != nil {
+		return target.runErrorCallback(l, opts, terragruntConfig, err)
+	}
+
 	if opts.CheckDependentModules {
 		allowDestroy := confirmActionWithDependentModules(ctx, l, opts, terragruntConfig)
 		if !allowDestroy {
@@ -325,6 +350,34 @@ func runTerragruntWithConfig(
 		return target.runCallback(ctx, l, opts, cfg)
 	}
 
+	// With run --all --skip-unchanged, the unit is skipped if neither it nor its dependencies changed since its last
+	// successful apply.
+	if check := common.FingerprintCheckFromContext(ctx); check != nil {
+		skip, err := check.Skip(originalOpts.TerragruntConfigPath, opts, cfg)
+		if err != nil {
+			return err
+		}
+
+		if skip {
+			l.Infof("Skipping unit %s, unchanged since its last successful apply", originalOpts.WorkingDir)
+			return nil
+		}
+	}
+
+	if cfg.RemoteState != nil {
+		if err := cfg.RemoteState.InjectCredentials(ctx, l, opts); err != nil {
+			return err
+		}
+
+		// The bucket is checked once per run, not again by the auto-init of the command.
+		command := opts.TerraformCliArgs.First()
+		if command == originalOpts.TerraformCliArgs.First() && util.ListContainsElement(TerraformCommandsThatUseState, command) {
+			if _, err := cfg.RemoteState.CheckBucketDrift(ctx, l, "", opts); err != nil {
+				return err
+			}
+		}
+	}
+
 	if opts.TerraformCliArgs.First() == tf.CommandNameInit {
 		if err := prepareInitCommand(ctx, l, opts, cfg); err != nil {
 			return err
@@ -362,6 +415,12 @@ func runTerragruntWithConfig(
 	return RunActionWithHooks(ctx, l, "terraform", opts, cfg, func(ctx context.Context) error {
 		runTerraformError := RunTerraformWithRetry(ctx, l, opts, r)
 
+		if util.ListContainsElement(TerraformCommandsThatChangeState, opts.TerraformCliArgs.First()) {
+			// The outputs of the unit may have changed, even if the command failed, so they must be fetched again
+			// by the dependents of the unit.
+			config.InvalidateDependencyOutputCache(l, originalOpts.TerragruntConfigPath)
+		}
+
 		var lockFileError error
 		if ShouldCopyLockFile(opts.TerraformCliArgs, cfg.Terraform) {
 			// Copy the lock file from the Terragrunt working dir (e.g., .terragrunt-cache/xxx/<some-module>) to the
@@ -507,6 +566,7 @@ func RunTerraformWithRetry(ctx context.Context, l log.Logger, opts *options.Terr
 							opts.WorkingDir,
 							report.WithResult(report.ResultSucceeded),
 							report.WithReason(report.ReasonRetrySucceeded),
+							report.WithRetry(),
 						); err != nil {
 							l.Errorf("Error ending run for unit %s: %v", opts.WorkingDir, err)
 						}
@@ -552,6 +612,10 @@ func prepareInitCommand(ctx context.Context, l log.Logger, terragruntOptions *op
 			}
 		}
 
+		if err := terragruntConfig.RemoteState.CheckConnectivity(ctx, l, terragruntOptions); err != nil {
+			return err
+		}
+
 		// Add backend config arguments to the command
 		terragruntOptions.InsertTerraformCliArgs(terragruntConfig.RemoteState.GetTFInitArgs()...)
 	}
@@ -659,6 +723,12 @@ func prepareNonInitCommand(
 		return err
 	}
 
+	// A retry block with the `retry-with-init` action requested init to be run before retrying.
+	if originalOpts.ForceInit && !util.ListContainsElement(TerraformCommandsThatDoNotNeedInit, opts.TerraformCliArgs.First()) {
+		originalOpts.ForceInit = false
+		needsInit = true
+	}
+
 	if needsInit {
 		if err := runTerraformInit(ctx, l, originalOpts, opts, cfg, r); err != nil {
 			return err
@@ -837,44 +907,10 @@ func checkProtectedModule(terragruntOptions *options.TerragruntOptions, terragru
 	return nil
 }
 
+// FilterTerraformExtraArgs returns the arguments of the extra_arguments blocks of the config that apply to the command
+// of the given options, see `config.FilterTerraformExtraArgs`.
 func FilterTerraformExtraArgs(l log.Logger, opts *options.TerragruntOptions, terragruntConfig *config.TerragruntConfig) []string {
-	out := []string{}
-	cmd := opts.TerraformCliArgs.First()
-
-	for _, arg := range terragruntConfig.Terraform.ExtraArgs {
-		for _, argCmd := range arg.Commands {
-			if cmd == argCmd {
-				lastArg := opts.TerraformCliArgs.Last()
-				skipVars := (cmd == tf.CommandNameApply || cmd == tf.CommandNameDestroy) && util.IsFile(lastArg)
-
-				// The following is a fix for GH-493.
-				// If the first argument is "apply" and the second argument is a file (plan),
-				// we don't add any -var-file to the command.
-				if arg.Arguments != nil {
-					if skipVars {
-						// If we have to skip vars, we need to iterate over all elements of array...
-						for _, a := range *arg.Arguments {
-							if !strings.HasPrefix(a, "-var") {
-								out = append(out, a)
-							}
-						}
-					} else {
-						// ... Otherwise, let's add all the arguments
-						out = append(out, *arg.Arguments...)
-					}
-				}
-
-				if !skipVars {
-					varFiles := arg.GetVarFiles(l)
-					for _, file := range varFiles {
-						out = append(out, "-var-file="+file)
-					}
-				}
-			}
-		}
-	}
-
-	return out
+	return config.FilterTerraformExtraArgs(l, opts, terragruntConfig)
 }
 
 func filterTerraformEnvVarsFromExtraArgs(terragruntOptions *options.TerragruntOptions, terragruntConfig *config.TerragruntConfig) map[string]string {
@@ -9,6 +9,7 @@ import (
 
 	"encoding/hex"
 
+	"github.com/gruntwork-io/terragrunt/config"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/options"
 	"github.com/gruntwork-io/terragrunt/pkg/log"
@@ -72,6 +73,12 @@ func CheckVersionConstraints(ctx context.Context, l log.Logger, terragruntOption
 		// update feature flags for evaluation
 		for _, flag := range partialTerragruntConfig.FeatureFlags {
 			flagName := flag.Name
+
+			// The values of the external source are kept out of the CLI overrides, and resolved when parsing the config.
+			if config.FeatureFlagServedBySource(ctx, l, terragruntOptions, flagName) {
+				continue
+			}
+
 			defaultValue, err := flag.DefaultAsString()
 
 			if err != nil {
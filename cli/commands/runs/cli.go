@@ -0,0 +1,130 @@
+// Package runs provides the `terragrunt runs` commands to inspect and abort the runs of run --all from their records,
+// and to replay the run bundles recorded with --record-bundle.
+package runs
+
+import (
+	"github.com/gruntwork-io/terragrunt/cli/commands/run"
+	"github.com/gruntwork-io/terragrunt/cli/flags"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+const (
+	CommandName = "runs"
+
+	FormatFlagName = "format"
+
+	// FormatTable outputs the runs as a table.
+	FormatTable = "table"
+
+	// FormatJSON outputs the records of the runs in JSON format.
+	FormatJSON = "json"
+
+	listCommandName   = "list"
+	showCommandName   = "show"
+	abortCommandName  = "abort"
+	replayCommandName = "replay"
+
+	showUsageText   = "terragrunt runs show [options] <run-id>"
+	abortUsageText  = "terragrunt runs abort [options] <run-id>"
+	replayUsageText = "terragrunt runs replay [options] <bundle>"
+)
+
+// Options are the options of the runs commands.
+type Options struct {
+	*options.TerragruntOptions
+
+	// Format determines the format of the output.
+	Format string
+}
+
+func NewFlags(l log.Logger, opts *Options, prefix flags.Prefix) cli.Flags {
+	tgPrefix := prefix.Prepend(flags.TgPrefix)
+
+	flags := cli.Flags{
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:        FormatFlagName,
+			EnvVars:     tgPrefix.EnvVars(FormatFlagName),
+			Destination: &opts.Format,
+			Usage:       "Output format of the runs. Valid values: table, json.",
+			DefaultText: FormatTable,
+		}),
+	}
+
+	return append(flags, run.NewFlags(l, opts.TerragruntOptions, nil).Filter(run.RunsDirFlagName)...)
+}
+
+func NewCommand(l log.Logger, opts *options.TerragruntOptions) *cli.Command {
+	cmdOpts := &Options{TerragruntOptions: opts, Format: FormatTable}
+
+	validateFormat := func(ctx *cli.Context) error {
+		if cmdOpts.Format != FormatTable && cmdOpts.Format != FormatJSON {
+			return cli.NewExitError(errors.New("invalid format: "+cmdOpts.Format), cli.ExitCodeGeneralError)
+		}
+
+		return nil
+	}
+
+	return &cli.Command{
+		Name:  CommandName,
+		Usage: "Inspect, abort and replay runs.",
+		Subcommands: cli.Commands{
+			&cli.Command{
+				Name:   listCommandName,
+				Usage:  "List the runs of run --all, the latest first.",
+				Flags:  NewFlags(l, cmdOpts, nil),
+				Before: validateFormat,
+				Action: func(ctx *cli.Context) error {
+					return RunList(ctx, l, cmdOpts)
+				},
+			},
+			&cli.Command{
+				Name:      showCommandName,
+				Usage:     "Show a run of run --all, and the outcome of each of its units.",
+				UsageText: showUsageText,
+				Flags:     NewFlags(l, cmdOpts, nil),
+				Before:    validateFormat,
+				Action: func(ctx *cli.Context) error {
+					id := ctx.Args().First()
+					if id == "" {
+						return errors.New(showUsageText)
+					}
+
+					return RunShow(ctx, l, cmdOpts, id)
+				},
+			},
+			&cli.Command{
+				Name:      abortCommandName,
+				Usage:     "Abort a run of run --all. Running units are cancelled, and no more units are started.",
+				UsageText: abortUsageText,
+				Flags:     run.NewFlags(l, opts, nil).Filter(run.RunsDirFlagName),
+				Action: func(ctx *cli.Context) error {
+					id := ctx.Args().First()
+					if id == "" {
+						return errors.New(abortUsageText)
+					}
+
+					return RunAbort(ctx, l, opts, id)
+				},
+			},
+			&cli.Command{
+				Name:      replayCommandName,
+				Usage:     "Replay a run bundle recorded with --record-bundle, re-printing each invocation of OpenTofu/Terraform and its output in sequence.",
+				UsageText: replayUsageText,
+				Flags:     NewFlags(l, cmdOpts, nil).Filter(FormatFlagName),
+				Before:    validateFormat,
+				Action: func(ctx *cli.Context) error {
+					bundle := ctx.Args().First()
+					if bundle == "" {
+						return errors.New(replayUsageText)
+					}
+
+					return RunReplay(ctx, l, cmdOpts, bundle)
+				},
+			},
+		},
+		Action: cli.ShowCommandHelp,
+	}
+}
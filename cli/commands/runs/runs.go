@@ -0,0 +1,174 @@
+package runs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/runs"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+// RunList outputs the runs recorded in the runs dir, the latest first.
+func RunList(_ context.Context, _ log.Logger, opts *Options) error {
+	records, err := runs.NewStore(opts.RunsDir).List()
+	if err != nil {
+		return err
+	}
+
+	if opts.Format == FormatJSON {
+		if records == nil {
+			records = []*runs.Run{}
+		}
+
+		return outputJSON(opts, records)
+	}
+
+	w := tabwriter.NewWriter(opts.Writer, 0, 0, 2, ' ', 0) //nolint:mnd
+
+	fmt.Fprintln(w, "ID\tSTATUS\tCOMMAND\tSTARTED\tDURATION\tUNITS")
+
+	for _, run := range records {
+		finished := 0
+
+		for _, unit := range run.Units {
+			if unit.FinishedAt != nil {
+				finished++
+			}
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%d/%d\n", run.ID, run.Status, run.Command, run.StartedAt.Local().Format(time.DateTime), duration(&run.StartedAt, run.FinishedAt), finished, len(run.Units))
+	}
+
+	if err := w.Flush(); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// RunShow outputs the run with the given ID, and the outcome of each of its units.
+func RunShow(_ context.Context, _ log.Logger, opts *Options, id string) error {
+	run, err := runs.NewStore(opts.RunsDir).Load(id)
+	if err != nil {
+		return err
+	}
+
+	if opts.Format == FormatJSON {
+		return outputJSON(opts, run)
+	}
+
+	w := tabwriter.NewWriter(opts.Writer, 0, 0, 2, ' ', 0) //nolint:mnd
+
+	fmt.Fprintf(w, "ID:\t%s\n", run.ID)
+	fmt.Fprintf(w, "Status:\t%s\n", run.Status)
+	fmt.Fprintf(w, "Command:\t%s\n", strings.Join(append([]string{run.Command}, run.Args...), " "))
+	fmt.Fprintf(w, "Working dir:\t%s\n", run.WorkingDir)
+	fmt.Fprintf(w, "Started:\t%s\n", run.StartedAt.Local().Format(time.DateTime))
+	fmt.Fprintf(w, "Duration:\t%s\n", duration(&run.StartedAt, run.FinishedAt))
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "UNIT\tOUTCOME\tDURATION\tERROR")
+
+	for _, unit := range run.Units {
+		// Only the first line of the error is shown, the full error is in the record.
+		errLine, _, _ := strings.Cut(unit.Error, "\n")
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", unit.Path, unit.Outcome, duration(unit.StartedAt, unit.FinishedAt), errLine)
+	}
+
+	if err := w.Flush(); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// RunAbort requests the run with the given ID to be aborted. The process running it cancels its running units, and
+// doesn't start any more, once it picks up the request.
+func RunAbort(_ context.Context, l log.Logger, opts *options.TerragruntOptions, id string) error {
+	if err := runs.NewStore(opts.RunsDir).RequestAbort(id); err != nil {
+		return err
+	}
+
+	l.Infof("Requested run %s to abort", id)
+
+	return nil
+}
+
+// RunReplay re-prints the invocations of OpenTofu/Terraform recorded in the given run bundle, in the order they
+// finished in, each with its args, working dir and exit code, followed by its output.
+func RunReplay(_ context.Context, _ log.Logger, opts *Options, bundle string) error {
+	invocations, err := runs.ReadBundle(bundle)
+	if err != nil {
+		return err
+	}
+
+	if opts.Format == FormatJSON {
+		return outputJSON(opts, invocations)
+	}
+
+	for _, invocation := range invocations {
+		writeInvocation(opts.Writer, invocation, len(invocations))
+
+		if _, err := io.WriteString(opts.Writer, invocation.Stdout); err != nil {
+			return errors.New(err)
+		}
+
+		if _, err := io.WriteString(opts.ErrWriter, invocation.Stderr); err != nil {
+			return errors.New(err)
+		}
+	}
+
+	return nil
+}
+
+// writeInvocation writes the header of the given invocation, out of the given total, when replaying a run bundle.
+func writeInvocation(w io.Writer, invocation *runs.Invocation, total int) {
+	took := (time.Duration(invocation.DurationMS) * time.Millisecond).Round(time.Millisecond)
+
+	fmt.Fprintf(w, "\n❯❯ Invocation %d/%d  %s  exit code %d in %s\n", invocation.Sequence, total, strings.Join(append([]string{invocation.Command}, invocation.Args...), " "), invocation.ExitCode, took)
+	fmt.Fprintf(w, "   Started:     %s\n", invocation.StartedAt.Local().Format(time.DateTime))
+	fmt.Fprintf(w, "   Working dir: %s\n", invocation.WorkingDir)
+	fmt.Fprintf(w, "   Content:     %s\n", invocation.ContentHash)
+
+	if invocation.Error != "" {
+		errLine, _, _ := strings.Cut(invocation.Error, "\n")
+		fmt.Fprintf(w, "   Error:       %s\n", errLine)
+	}
+
+	fmt.Fprintln(w)
+}
+
+// duration returns the time between start and finish, or since start if it isn't finished yet, and an empty string
+// if it didn't start.
+func duration(start, finish *time.Time) string {
+	if start == nil || start.IsZero() {
+		return ""
+	}
+
+	end := time.Now()
+	if finish != nil {
+		end = *finish
+	}
+
+	return end.Sub(*start).Round(time.Second).String()
+}
+
+func outputJSON(opts *Options, v any) error {
+	jsonBytes, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if _, err := opts.Writer.Write(append(jsonBytes, '\n')); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
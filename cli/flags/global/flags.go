@@ -4,6 +4,7 @@ package global
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"slices"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/gruntwork-io/terragrunt/cli/commands/version"
 	"github.com/gruntwork-io/terragrunt/cli/flags"
 	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/internal/strict"
 	"github.com/gruntwork-io/terragrunt/internal/strict/controls"
 	"github.com/gruntwork-io/terragrunt/options"
@@ -32,8 +34,9 @@ const (
 	LogCustomFormatFlagName = "log-custom-format"
 	NoColorFlagName         = "no-color"
 
-	NonInteractiveFlagName = "non-interactive"
-	WorkingDirFlagName     = "working-dir"
+	NonInteractiveFlagName  = "non-interactive"
+	WorkingDirFlagName      = "working-dir"
+	FrozenTimestampFlagName = "frozen-timestamp"
 
 	// Strict Mode related flags.
 
@@ -152,6 +155,22 @@ func NewFlags(l log.Logger, opts *options.TerragruntOptions, prefix flags.Prefix
 		},
 			flags.WithDeprecatedNames(terragruntPrefix.FlagNames(DeprecatedWorkingDirFlagName), terragruntPrefixControl)),
 
+		flags.NewFlag(&cli.GenericFlag[string]{
+			Name:    FrozenTimestampFlagName,
+			EnvVars: tgPrefix.EnvVars(FrozenTimestampFlagName),
+			Usage:   "The RFC 3339 timestamp returned by the timestamp() function in the configs. Default is the start time of the run.",
+			Setter: func(val string) error {
+				timestamp, err := time.Parse(time.RFC3339, val)
+				if err != nil {
+					return errors.Errorf("invalid value %q for the --%s flag, expected an RFC 3339 timestamp: %w", val, FrozenTimestampFlagName, err)
+				}
+
+				opts.FrozenTimestamp = timestamp.UTC()
+
+				return nil
+			},
+		}),
+
 		flags.NewFlag(&cli.BoolFlag{
 			Name:    LogDisableFlagName,
 			EnvVars: tgPrefix.EnvVars(LogDisableFlagName),
@@ -0,0 +1,186 @@
+package config
+
+import (
+	"path"
+	"slices"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/schedule"
+)
+
+const (
+	// ApplyWindowOutsideSkip skips the units matching an apply window while it is closed. It is the default.
+	ApplyWindowOutsideSkip = "skip"
+	// ApplyWindowOutsideWait makes the units matching an apply window wait for it to open while it is closed.
+	ApplyWindowOutsideWait = "wait"
+)
+
+// ApplyWindows represents a list of apply windows.
+type ApplyWindows []*ApplyWindow
+
+// ApplyWindow is an `apply_window` block, restricting the runs of apply and destroy of the units it matches in
+// run --all to a maintenance window, e.g.:
+//
+//	apply_window "weeknights" {
+//	  schedule = "0 22 * * 1-5"
+//	  duration = "6h"
+//	  timezone = "Europe/Berlin"
+//	  tags     = ["production"]
+//	  outside  = "wait"
+//	}
+//
+// The window opens at the times matching the cron expression `schedule`, in `timezone`, UTC by default, and stays
+// open for `duration`. A unit matches the window if its path matches one of `paths`, or it has one of `tags`, and a
+// window with neither matches the units whose configs define it, or include it. While the window is closed, the units
+// it matches are skipped, or wait for it to open with `outside = "wait"`.
+type ApplyWindow struct {
+	Timezone *string  `hcl:"timezone,optional" cty:"timezone"`
+	Outside  *string  `hcl:"outside,optional" cty:"outside"`
+	Paths    []string `hcl:"paths,optional" cty:"paths"`
+	Tags     []string `hcl:"tags,optional" cty:"tags"`
+	Name     string   `hcl:",label" cty:"name"`
+	Schedule string   `hcl:"schedule,attr" cty:"schedule"`
+	Duration string   `hcl:"duration,attr" cty:"duration"`
+}
+
+// Validate returns an error if the window, defined in the config at the given path, has a malformed schedule,
+// duration, timezone or glob, or an unknown outside mode.
+func (window *ApplyWindow) Validate(configPath string) error {
+	invalid := func(reason string) error {
+		return errors.New(InvalidApplyWindowError{Path: configPath, Name: window.Name, Reason: reason})
+	}
+
+	if _, err := schedule.Parse(window.Schedule); err != nil {
+		return invalid(err.Error())
+	}
+
+	if duration, err := time.ParseDuration(window.Duration); err != nil || duration <= 0 {
+		return invalid("duration must be a positive duration, e.g. 4h or 90m")
+	}
+
+	if _, err := window.Location(); err != nil {
+		return invalid("unknown timezone " + *window.Timezone)
+	}
+
+	if outside := window.OutsideMode(); outside != ApplyWindowOutsideSkip && outside != ApplyWindowOutsideWait {
+		return invalid("outside must be " + ApplyWindowOutsideSkip + " or " + ApplyWindowOutsideWait)
+	}
+
+	// doublestar only reports malformed patterns when matching reaches them, see ParseDependencyPolicyFile.
+	for _, pattern := range window.Paths {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return invalid("invalid glob " + pattern + ": " + err.Error())
+		}
+	}
+
+	return nil
+}
+
+// OutsideMode returns what the units matching the window do while it is closed, ApplyWindowOutsideSkip by default.
+func (window *ApplyWindow) OutsideMode() string {
+	if window.Outside == nil {
+		return ApplyWindowOutsideSkip
+	}
+
+	return *window.Outside
+}
+
+// Location returns the location of the timezone of the window, UTC by default.
+func (window *ApplyWindow) Location() (*time.Location, error) {
+	if window.Timezone == nil {
+		return time.UTC, nil
+	}
+
+	location, err := time.LoadLocation(*window.Timezone)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return location, nil
+}
+
+// Matches returns true if the unit at the given path, relative to the working dir, with the given tags, is restricted
+// by the window. A window with neither paths nor tags matches all the units defining it.
+func (window *ApplyWindow) Matches(unitPath string, tags []string) bool {
+	if len(window.Paths) == 0 && len(window.Tags) == 0 {
+		return true
+	}
+
+	if matchesAnyGlob(window.Paths, unitPath) {
+		return true
+	}
+
+	return slices.ContainsFunc(window.Tags, func(tag string) bool { return slices.Contains(tags, tag) })
+}
+
+// OpenAt returns true if the window is open at the given time, and otherwise the time it next opens at.
+func (window *ApplyWindow) OpenAt(now time.Time) (bool, time.Time, error) {
+	cron, err := schedule.Parse(window.Schedule)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+
+	duration, err := time.ParseDuration(window.Duration)
+	if err != nil {
+		return false, time.Time{}, errors.New(err)
+	}
+
+	location, err := window.Location()
+	if err != nil {
+		return false, time.Time{}, err
+	}
+
+	// The window is open if it last opened less than its duration ago, i.e. if it opens after the time its duration
+	// ago, and not after now.
+	opensAt, ok := cron.Next(now.In(location).Add(-duration).Add(time.Nanosecond))
+	if !ok {
+		return false, time.Time{}, errors.New(ApplyWindowNeverOpensError{Name: window.Name, Schedule: window.Schedule})
+	}
+
+	if !opensAt.After(now) {
+		return true, time.Time{}, nil
+	}
+
+	return false, opensAt, nil
+}
+
+// validateApplyWindows returns an error if one of the given windows, defined in the config at the given path, is
+// invalid, or if multiple windows have the same name.
+func validateApplyWindows(configPath string, windows ApplyWindows) error {
+	names := map[string]bool{}
+
+	for _, window := range windows {
+		if names[window.Name] {
+			return errors.New(InvalidApplyWindowError{Path: configPath, Name: window.Name, Reason: "multiple apply_window blocks have this name"})
+		}
+
+		names[window.Name] = true
+
+		if err := window.Validate(configPath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Merge merges the given windows into the windows by name, the given windows replacing the ones with the same name.
+func (windows ApplyWindows) Merge(sourceWindows ApplyWindows) ApplyWindows {
+	if windows == nil && sourceWindows == nil {
+		return nil
+	}
+
+	merged := slices.Clone(windows)
+
+	for _, source := range sourceWindows {
+		if i := slices.IndexFunc(merged, func(window *ApplyWindow) bool { return window.Name == source.Name }); i >= 0 {
+			merged[i] = source
+			continue
+		}
+
+		merged = append(merged, source)
+	}
+
+	return merged
+}
@@ -0,0 +1,186 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+)
+
+func TestParseApplyWindows(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	unitDir := filepath.Join(rootDir, "prod", "vpc")
+	configPath := filepath.Join(unitDir, config.DefaultTerragruntConfigPath)
+
+	require.NoError(t, os.MkdirAll(unitDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "root.hcl"), []byte(`
+apply_window "weeknights" {
+  schedule = "0 22 * * 1-5"
+  duration = "6h"
+  tags     = ["production"]
+}
+
+apply_window "weekends" {
+  schedule = "0 8 * * 6,0"
+  duration = "10h"
+}
+`), 0644))
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+
+apply_window "weeknights" {
+  schedule = "0 23 * * 1-5"
+  duration = "4h"
+  timezone = "Europe/Berlin"
+  outside  = "wait"
+}
+`), 0644))
+
+	l := createLogger()
+	opts := mockOptionsForTestWithConfigPath(t, configPath)
+
+	cfg, err := config.ParseConfigFile(config.NewParsingContext(t.Context(), l, opts), l, configPath, nil)
+	require.NoError(t, err)
+
+	timezone := "Europe/Berlin"
+	outside := config.ApplyWindowOutsideWait
+
+	assert.ElementsMatch(t, config.ApplyWindows{
+		{Name: "weeknights", Schedule: "0 23 * * 1-5", Duration: "4h", Timezone: &timezone, Outside: &outside},
+		{Name: "weekends", Schedule: "0 8 * * 6,0", Duration: "10h"},
+	}, cfg.ApplyWindows)
+}
+
+func TestParseApplyWindowsInvalid(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		cfg    string
+		reason string
+	}{
+		{
+			name: "schedule",
+			cfg: `
+apply_window "weeknights" {
+  schedule = "0 25 * * *"
+  duration = "6h"
+}
+`,
+			reason: `invalid cron expression "0 25 * * *"`,
+		},
+		{
+			name: "duration",
+			cfg: `
+apply_window "weeknights" {
+  schedule = "0 22 * * *"
+  duration = "6 hours"
+}
+`,
+			reason: "duration must be a positive duration",
+		},
+		{
+			name: "timezone",
+			cfg: `
+apply_window "weeknights" {
+  schedule = "0 22 * * *"
+  duration = "6h"
+  timezone = "Mars/Olympus_Mons"
+}
+`,
+			reason: "unknown timezone Mars/Olympus_Mons",
+		},
+		{
+			name: "outside",
+			cfg: `
+apply_window "weeknights" {
+  schedule = "0 22 * * *"
+  duration = "6h"
+  outside  = "fail"
+}
+`,
+			reason: "outside must be skip or wait",
+		},
+		{
+			name: "duplicate",
+			cfg: `
+apply_window "weeknights" {
+  schedule = "0 22 * * *"
+  duration = "6h"
+}
+
+apply_window "weeknights" {
+  schedule = "0 23 * * *"
+  duration = "6h"
+}
+`,
+			reason: "multiple apply_window blocks have this name",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := createLogger()
+			ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTest(t))
+
+			_, err := config.ParseConfigString(ctx, l, config.DefaultTerragruntConfigPath, tc.cfg, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.reason)
+		})
+	}
+}
+
+func TestApplyWindowOpenAt(t *testing.T) {
+	t.Parallel()
+
+	timezone := "Europe/Berlin"
+	window := &config.ApplyWindow{Name: "weeknights", Schedule: "0 22 * * 1-5", Duration: "6h", Timezone: &timezone}
+
+	berlin, err := time.LoadLocation(timezone)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		now     time.Time
+		opensAt time.Time
+		open    bool
+	}{
+		// Wednesday 21:00 in Berlin, before the window opens.
+		{now: time.Date(2025, 1, 1, 21, 0, 0, 0, berlin), opensAt: time.Date(2025, 1, 1, 22, 0, 0, 0, berlin)},
+		// Thursday 02:00 in Berlin, in the window opened on Wednesday.
+		{now: time.Date(2025, 1, 2, 1, 0, 0, 0, time.UTC), open: true},
+		// Thursday 04:00 in Berlin, as the window closes.
+		{now: time.Date(2025, 1, 2, 4, 0, 0, 0, berlin), opensAt: time.Date(2025, 1, 2, 22, 0, 0, 0, berlin)},
+		// Saturday noon, the window next opening on Monday.
+		{now: time.Date(2025, 1, 4, 12, 0, 0, 0, berlin), opensAt: time.Date(2025, 1, 6, 22, 0, 0, 0, berlin)},
+	}
+
+	for _, tc := range testCases {
+		open, opensAt, err := window.OpenAt(tc.now)
+		require.NoError(t, err)
+		assert.Equal(t, tc.open, open, tc.now)
+		assert.True(t, tc.opensAt.Equal(opensAt), "%s: expected %s, got %s", tc.now, tc.opensAt, opensAt)
+	}
+}
+
+func TestApplyWindowMatches(t *testing.T) {
+	t.Parallel()
+
+	window := &config.ApplyWindow{Paths: []string{"prod/**"}, Tags: []string{"production"}}
+
+	assert.True(t, window.Matches("prod/vpc", nil))
+	assert.True(t, window.Matches("shared/dns", []string{"production"}))
+	assert.False(t, window.Matches("dev/vpc", []string{"dev"}))
+
+	assert.True(t, (&config.ApplyWindow{}).Matches("dev/vpc", nil), "a window without selectors matches the units defining it")
+}
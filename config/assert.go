@@ -0,0 +1,84 @@
+package config
+
+import (
+	"slices"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// AssertConfig is an assertion of the configuration, declared with an `assert` block, e.g.
+//
+//	assert "prod_vpc" {
+//	  condition     = local.env != "prod" || dependency.vpc.outputs.env == "prod"
+//	  error_message = "prod must use the prod VPC dependency"
+//	}
+//
+// The assertions of the unit and its includes are checked once the configuration is parsed, with the outputs of the
+// dependencies, so the unit fails before OpenTofu/Terraform is run.
+type AssertConfig struct {
+	Name         string `hcl:",label" cty:"name"`
+	ErrorMessage string `hcl:"error_message,attr" cty:"error_message"`
+	Condition    bool   `hcl:"condition,attr" cty:"condition"`
+}
+
+// CheckAssertions returns an error for each assertion of the config whose condition is false.
+func (cfg *TerragruntConfig) CheckAssertions() error {
+	errs := &errors.MultiError{}
+
+	for _, assertion := range cfg.Assertions {
+		if assertion.Condition {
+			continue
+		}
+
+		err := AssertionFailedError{Name: assertion.Name, ErrorMessage: assertion.ErrorMessage}
+
+		if metadata, found := cfg.GetMapFieldMetadata(MetadataAssert, assertion.Name); found {
+			err.ConfigPath = metadata[FoundInFile]
+		}
+
+		errs = errs.Append(errors.New(err))
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// validateAssertions returns an error if multiple assert blocks have the same name.
+func validateAssertions(assertions []AssertConfig) error {
+	var (
+		names           = map[string]bool{}
+		duplicatedNames []string
+	)
+
+	for _, assertion := range assertions {
+		if names[assertion.Name] {
+			duplicatedNames = append(duplicatedNames, assertion.Name)
+			continue
+		}
+
+		names[assertion.Name] = true
+	}
+
+	if len(duplicatedNames) != 0 {
+		return errors.New(DuplicatedAssertBlocksError{Names: duplicatedNames})
+	}
+
+	return nil
+}
+
+// mergeAssertions returns the included assertions with the given ones of the including config, which replace the
+// included assertions with the same name.
+func mergeAssertions(assertions, includedAssertions []AssertConfig) []AssertConfig {
+	merged := make([]AssertConfig, 0, len(assertions)+len(includedAssertions))
+
+	for _, included := range includedAssertions {
+		overridden := slices.ContainsFunc(assertions, func(assertion AssertConfig) bool {
+			return assertion.Name == included.Name
+		})
+
+		if !overridden {
+			merged = append(merged, included)
+		}
+	}
+
+	return append(merged, assertions...)
+}
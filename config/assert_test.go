@@ -0,0 +1,104 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+func TestAssertions(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	unitDir := filepath.Join(rootDir, "prod", "app")
+	configPath := filepath.Join(unitDir, config.DefaultTerragruntConfigPath)
+	rootConfigPath := filepath.Join(rootDir, config.RecommendedParentConfigName)
+
+	require.NoError(t, os.MkdirAll(unitDir, 0755))
+	require.NoError(t, os.WriteFile(rootConfigPath, []byte(`
+locals {
+  env = basename(dirname(get_terragrunt_dir()))
+}
+
+assert "known_env" {
+  condition     = contains(["dev", "prod"], local.env)
+  error_message = "units must be in a dev or prod folder"
+}
+
+assert "prod_vpc" {
+  condition     = true
+  error_message = "overridden by the unit"
+}
+`), 0644))
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+include "root" {
+  path   = find_in_parent_folders("root.hcl")
+  expose = true
+}
+
+locals {
+  vpc_env = "dev"
+}
+
+assert "prod_vpc" {
+  condition     = include.root.locals.env != "prod" || local.vpc_env == "prod"
+  error_message = "prod must use the prod VPC dependency"
+}
+`), 0644))
+
+	l := createLogger()
+	ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, configPath))
+
+	cfg, err := config.ParseConfigFile(ctx, l, configPath, nil)
+	require.NoError(t, err)
+	require.Len(t, cfg.Assertions, 2)
+	assert.Equal(t, "known_env", cfg.Assertions[0].Name)
+	assert.True(t, cfg.Assertions[0].Condition)
+	assert.Equal(t, "prod_vpc", cfg.Assertions[1].Name)
+	assert.False(t, cfg.Assertions[1].Condition)
+
+	err = cfg.CheckAssertions()
+	require.Error(t, err)
+
+	var assertionErr config.AssertionFailedError
+
+	require.ErrorAs(t, err, &assertionErr)
+	assert.Equal(t, config.AssertionFailedError{
+		Name:         "prod_vpc",
+		ErrorMessage: "prod must use the prod VPC dependency",
+		ConfigPath:   configPath,
+	}, assertionErr)
+
+	var multiErr *errors.MultiError
+
+	require.ErrorAs(t, err, &multiErr)
+	assert.Len(t, multiErr.WrappedErrors(), 1)
+}
+
+func TestAssertionsDuplicatedNames(t *testing.T) {
+	t.Parallel()
+
+	cfg := `
+assert "env" {
+  condition     = true
+  error_message = "first"
+}
+
+assert "env" {
+  condition     = true
+  error_message = "second"
+}
+`
+
+	l := createLogger()
+	ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTest(t))
+
+	_, err := config.ParseConfigString(ctx, l, config.DefaultTerragruntConfigPath, cfg, nil)
+	require.ErrorContains(t, err, "Detected multiple assert blocks with the same name: [env]")
+}
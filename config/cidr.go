@@ -0,0 +1,127 @@
+package config
+
+import (
+	"math/big"
+	"net"
+	"net/netip"
+	"strings"
+
+	"github.com/apparentlymart/go-cidr/cidr"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	"github.com/zclconf/go-cty/cty/gocty"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// CidrContains returns true if the given IP address or prefix is within the given containing prefix, the same way as the
+// OpenTofu `cidrcontains` function, e.g. `cidrcontains("10.0.0.0/16", "10.0.1.0/24")`.
+func CidrContains(ctx *ParsingContext, args []string) (bool, error) {
+	if len(args) != matchedPats {
+		return false, errors.New(WrongNumberOfParamsError{Func: FuncNameCidrContains, Expected: "2", Actual: len(args)})
+	}
+
+	containing, err := netip.ParsePrefix(args[0])
+	if err != nil {
+		return false, errors.Errorf("invalid CIDR expression %s: %w", args[0], err)
+	}
+
+	var contained netip.Prefix
+
+	if strings.Contains(args[1], "/") {
+		if contained, err = netip.ParsePrefix(args[1]); err != nil {
+			return false, errors.Errorf("invalid CIDR expression %s: %w", args[1], err)
+		}
+	} else {
+		addr, err := netip.ParseAddr(args[1])
+		if err != nil {
+			return false, errors.Errorf("invalid IP address %s: %w", args[1], err)
+		}
+
+		contained = netip.PrefixFrom(addr, addr.BitLen())
+	}
+
+	if containing.Addr().Is4() != contained.Addr().Is4() {
+		return false, errors.Errorf("address family mismatch: %s and %s", args[0], args[1])
+	}
+
+	return contained.Bits() >= containing.Bits() && containing.Contains(contained.Addr()), nil
+}
+
+// CidrAllocate carves the given supernet into subnets with the given number of additional bits, allocating them to the
+// given names in order, e.g. `cidr_allocate("10.0.0.0/16", 8, ["vpc", "app", "db"])` returns `10.0.0.0/24` for `vpc`,
+// `10.0.1.0/24` for `app` and `10.0.2.0/24` for `db`. As the allocation only depends on the order of the names, new
+// names must be appended to the list so the subnets of the existing ones do not change.
+func CidrAllocate(prefix string, newbits int, names []string) (map[string]string, error) {
+	_, network, err := net.ParseCIDR(prefix)
+	if err != nil {
+		return nil, errors.Errorf("invalid CIDR expression %s: %w", prefix, err)
+	}
+
+	ones, bits := network.Mask.Size()
+	if newbits < 0 || ones+newbits > bits {
+		return nil, errors.Errorf("insufficient address space to extend prefix of %d by %d bits", ones, newbits)
+	}
+
+	capacity := new(big.Int).Lsh(big.NewInt(1), uint(newbits))
+	if big.NewInt(int64(len(names))).Cmp(capacity) > 0 {
+		return nil, errors.Errorf("%s can only be split into %s subnets with %d additional bits, but %d names were given", prefix, capacity, newbits, len(names))
+	}
+
+	subnets := make(map[string]string, len(names))
+
+	for i, name := range names {
+		if _, ok := subnets[name]; ok {
+			return nil, errors.Errorf("duplicate name %s passed to the %s function", name, FuncNameCidrAllocate)
+		}
+
+		subnet, err := cidr.Subnet(network, newbits, i)
+		if err != nil {
+			return nil, errors.New(err)
+		}
+
+		subnets[name] = subnet.String()
+	}
+
+	return subnets, nil
+}
+
+// cidrAllocateAsFuncImpl creates a cty Function for calling cidr_allocate, which returns a map of the given names to
+// their subnets.
+func cidrAllocateAsFuncImpl() function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{Name: "prefix", Type: cty.String},
+			{Name: "newbits", Type: cty.Number},
+			{Name: "names", Type: cty.List(cty.String)},
+		},
+		Type: function.StaticReturnType(cty.Map(cty.String)),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			var newbits int
+			if err := gocty.FromCtyValue(args[1], &newbits); err != nil {
+				return cty.NilVal, errors.New(err)
+			}
+
+			names, err := ctySliceToStringSlice(args[2].AsValueSlice())
+			if err != nil {
+				return cty.NilVal, err
+			}
+
+			subnets, err := CidrAllocate(args[0].AsString(), newbits, names)
+			if err != nil {
+				return cty.NilVal, err
+			}
+
+			if len(subnets) == 0 {
+				return cty.MapValEmpty(cty.String), nil
+			}
+
+			vals := make(map[string]cty.Value, len(subnets))
+			for name, subnet := range subnets {
+				vals[name] = cty.StringVal(subnet)
+			}
+
+			return cty.MapVal(vals), nil
+		},
+	})
+}
@@ -2,6 +2,7 @@
 package config
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -11,6 +12,7 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -25,6 +27,7 @@ import (
 	"github.com/gruntwork-io/terragrunt/internal/strict/controls"
 	"github.com/gruntwork-io/terragrunt/telemetry"
 
+	"github.com/mattn/go-zglob"
 	"github.com/mitchellh/mapstructure"
 
 	"github.com/hashicorp/go-getter"
@@ -47,6 +50,8 @@ const (
 	DefaultTerragruntConfigPath     = "terragrunt.hcl"
 	DefaultStackFile                = "terragrunt.stack.hcl"
 	DefaultTerragruntJSONConfigPath = "terragrunt.hcl.json"
+	DefaultTerragruntYAMLConfigPath = "terragrunt.yaml"
+	DefaultTerragruntYMLConfigPath  = "terragrunt.yml"
 	RecommendedParentConfigName     = "root.hcl"
 
 	FoundInFile = "found_in_file"
@@ -71,6 +76,7 @@ const (
 	MetadataIamAssumeRoleSessionName    = "iam_assume_role_session_name"
 	MetadataIamWebIdentityToken         = "iam_web_identity_token"
 	MetadataInputs                      = "inputs"
+	MetadataInputsOverrides             = "inputs_overrides"
 	MetadataLocals                      = "locals"
 	MetadataLocal                       = "local"
 	MetadataCatalog                     = "catalog"
@@ -79,6 +85,9 @@ const (
 	MetadataRetryableErrors             = "retryable_errors"
 	MetadataRetryMaxAttempts            = "retry_max_attempts"
 	MetadataRetrySleepIntervalSec       = "retry_sleep_interval_sec"
+	MetadataWeight                      = "weight"
+	MetadataRunTimeoutSec               = "run_timeout_sec"
+	MetadataQueuePriority               = "queue_priority"
 	MetadataDependentModules            = "dependent_modules"
 	MetadataInclude                     = "include"
 	MetadataFeatureFlag                 = "feature"
@@ -87,14 +96,26 @@ const (
 	MetadataRetry                       = "retry"
 	MetadataIgnore                      = "ignore"
 	MetadataValues                      = "values"
+	MetadataImport                      = "import"
+	MetadataValue                       = "value"
+	MetadataEnvironment                 = "environment"
+	MetadataTags                        = "tags"
 	MetadataStack                       = "stack"
 	MetadataUnit                        = "unit"
+	MetadataAssert                      = "assert"
+	MetadataParallelismLimit            = "parallelism_limit"
+	MetadataApplyWindow                 = "apply_window"
+	MetadataMutexGroups                 = "mutex_groups"
+	MetadataFailurePolicy               = "failure_policy"
+	MetadataExecutor                    = "executor"
 )
 
 var (
 	// Order matters, for example if none of the files are found `GetDefaultConfigPath` func returns the last element.
 	DefaultTerragruntConfigPaths = []string{
 		DefaultTerragruntJSONConfigPath,
+		DefaultTerragruntYAMLConfigPath,
+		DefaultTerragruntYMLConfigPath,
 		DefaultTerragruntConfigPath,
 	}
 
@@ -108,6 +129,7 @@ var (
 		parseOpts := []hclparse.Option{
 			hclparse.WithDiagnosticsWriter(writer, l.Formatter().DisabledColors()),
 			hclparse.WithLogger(l),
+			hclparse.WithForEachBlocks(MetadataDependency),
 		}
 
 		strictControl := opts.StrictControls.Find(controls.BareInclude)
@@ -136,6 +158,9 @@ type DecodedBaseBlocks struct {
 	TrackInclude *TrackInclude
 	Locals       *cty.Value
 	FeatureFlags *cty.Value
+	Imports      *cty.Value
+	// defaults are the defaults of the unit, read from the defaults file.
+	defaults *unitDefaults
 }
 
 // TerragruntConfig represents a parsed and expanded configuration
@@ -155,21 +180,38 @@ type TerragruntConfig struct {
 	GenerateConfigs             map[string]codegen.GenerateConfig
 	IamAssumeRoleDuration       *int64
 	RetrySleepIntervalSec       *int
+	Weight                      *int
+	RunTimeoutSec               *int
+	QueuePriority               *int
 	Inputs                      map[string]any
+	InputsOverrides             map[string]map[string]any
+	IncludeLocals               map[string]any
 	Engine                      *EngineConfig
 	Catalog                     *CatalogConfig
 	IamWebIdentityToken         string
 	IamAssumeRoleSessionName    string
 	IamRole                     string
 	DownloadDir                 string
+	Environment                 string
 	TerragruntVersionConstraint string
 	TerraformVersionConstraint  string
 	TerraformBinary             string
 	TerragruntDependencies      Dependencies
 	RetryableErrors             []string
+	Tags                        []string
+	MutexGroups                 []string
+	Assertions                  []AssertConfig
+	ParallelismLimits           ParallelismLimits
+	ApplyWindows                ApplyWindows
+	FailurePolicy               *FailurePolicyConfig
+	Executor                    *ExecutorConfig
 	FeatureFlags                FeatureFlags
 	DependentModulesPath        []*string
 	IsPartial                   bool
+
+	// environmentMergeStrategy is the `environment_merge_strategy` of the unit, merged in from its includes like
+	// Environment, to layer the environment overlay onto the unit config once the includes are merged in.
+	environmentMergeStrategy string
 }
 
 func (cfg *TerragruntConfig) GetRemoteState(l log.Logger, opts *options.TerragruntOptions) (*remotestate.RemoteState, error) {
@@ -219,6 +261,16 @@ func (cfg *TerragruntConfig) GetIAMRoleOptions() options.IAMRoleOptions {
 
 // WriteTo writes the terragrunt config to a writer
 func (cfg *TerragruntConfig) WriteTo(w io.Writer) (int64, error) {
+	return cfg.writeHCL(w, "", nil)
+}
+
+// WriteToWithProvenance writes the terragrunt config to a writer, with comments indicating the file each block and
+// attribute came from, relative to the directory of the given config path.
+func (cfg *TerragruntConfig) WriteToWithProvenance(w io.Writer, configPath string) (int64, error) {
+	return cfg.writeHCL(w, configPath, &hclProvenance{cfg: cfg, dir: filepath.Dir(configPath)})
+}
+
+func (cfg *TerragruntConfig) writeHCL(w io.Writer, configPath string, prov *hclProvenance) (int64, error) {
 	cfgAsCty, err := TerragruntConfigAsCty(cfg)
 	if err != nil {
 		return 0, err
@@ -227,6 +279,8 @@ func (cfg *TerragruntConfig) WriteTo(w io.Writer) (int64, error) {
 	f := hclwrite.NewFile()
 	rootBody := f.Body()
 
+	prov.header(rootBody, configPath)
+
 	// Handle blocks first
 	if len(cfg.Locals) > 0 {
 		localsBlock := hclwrite.NewBlock("locals", nil)
@@ -234,7 +288,8 @@ func (cfg *TerragruntConfig) WriteTo(w io.Writer) (int64, error) {
 
 		localsAsCty := cfgAsCty.GetAttr("locals")
 
-		for k := range cfg.Locals {
+		for _, k := range slices.Sorted(maps.Keys(cfg.Locals)) {
+			prov.annotate(localsBody, MetadataLocals, k)
 			localsBody.SetAttributeValue(k, localsAsCty.GetAttr(k))
 		}
 
@@ -306,6 +361,10 @@ func (cfg *TerragruntConfig) WriteTo(w io.Writer) (int64, error) {
 				beforeHookBody.SetAttributeValue("working_dir", beforeHookAsCty.GetAttr("working_dir"))
 			}
 
+			if beforeHook.Priority != nil {
+				beforeHookBody.SetAttributeValue("priority", beforeHookAsCty.GetAttr("priority"))
+			}
+
 			terraformBody.AppendBlock(beforeHookBlock)
 		}
 
@@ -330,6 +389,10 @@ func (cfg *TerragruntConfig) WriteTo(w io.Writer) (int64, error) {
 				afterHookBody.SetAttributeValue("working_dir", afterHookAsCty.GetAttr("working_dir"))
 			}
 
+			if afterHook.Priority != nil {
+				afterHookBody.SetAttributeValue("priority", afterHookAsCty.GetAttr("priority"))
+			}
+
 			terraformBody.AppendBlock(afterHookBlock)
 		}
 
@@ -347,9 +410,14 @@ func (cfg *TerragruntConfig) WriteTo(w io.Writer) (int64, error) {
 				errorHookBody.SetAttributeValue("working_dir", errorHookAsCty.GetAttr("working_dir"))
 			}
 
+			if errorHook.Priority != nil {
+				errorHookBody.SetAttributeValue("priority", errorHookAsCty.GetAttr("priority"))
+			}
+
 			terraformBody.AppendBlock(errorHookBlock)
 		}
 
+		prov.annotate(rootBody, MetadataTerraform)
 		rootBody.AppendBlock(terraformBlock)
 	}
 
@@ -372,6 +440,7 @@ func (cfg *TerragruntConfig) WriteTo(w io.Writer) (int64, error) {
 			remoteStateBody.SetAttributeValue("config", remoteStateAsCty.GetAttr("config"))
 		}
 
+		prov.annotate(rootBody, MetadataRemoteState)
 		rootBody.AppendBlock(remoteStateBlock)
 	}
 
@@ -382,6 +451,7 @@ func (cfg *TerragruntConfig) WriteTo(w io.Writer) (int64, error) {
 		dependenciesAsCty := cfgAsCty.GetAttr("dependencies")
 
 		dependenciesBody.SetAttributeValue("paths", dependenciesAsCty.GetAttr("paths"))
+		prov.annotate(rootBody, MetadataDependencies, cfg.Dependencies.Paths...)
 		rootBody.AppendBlock(dependenciesBlock)
 	}
 
@@ -390,7 +460,33 @@ func (cfg *TerragruntConfig) WriteTo(w io.Writer) (int64, error) {
 		depBlock := hclwrite.NewBlock("dependency", []string{dep.Name})
 		depBody := depBlock.Body()
 		depAsCty := cfgAsCty.GetAttr("dependency").GetAttr(dep.Name)
-		depBody.SetAttributeValue("config_path", depAsCty.GetAttr("config_path"))
+
+		// Instances of blocks declared with for_each are written as a block declaring only that instance.
+		if key, ok := dep.ForEachKey(); ok {
+			depAsCty = depAsCty.GetAttr(key)
+			depBody.SetAttributeValue(hclparse.ForEachAttr, cty.ObjectVal(map[string]cty.Value{key: dep.Each.GetAttr("value")}))
+		}
+
+		switch {
+		case dep.IsTFEWorkspace():
+			depBody.SetAttributeValue("tfe_workspace", depAsCty.GetAttr("tfe_workspace"))
+		case dep.IsCloudFormationStack():
+			depBody.SetAttributeValue("cloudformation_stack", depAsCty.GetAttr("cloudformation_stack"))
+		default:
+			depBody.SetAttributeValue("config_path", depAsCty.GetAttr("config_path"))
+		}
+
+		if dep.Region != nil {
+			depBody.SetAttributeValue("region", depAsCty.GetAttr("region"))
+		}
+
+		if dep.IAMRole != nil {
+			depBody.SetAttributeValue("iam_role", depAsCty.GetAttr("iam_role"))
+		}
+
+		if dep.Profile != nil {
+			depBody.SetAttributeValue("profile", depAsCty.GetAttr("profile"))
+		}
 
 		if dep.Enabled != nil {
 			depBody.SetAttributeValue("enabled", goboolToCty(*dep.Enabled))
@@ -404,6 +500,10 @@ func (cfg *TerragruntConfig) WriteTo(w io.Writer) (int64, error) {
 			depBody.SetAttributeValue("mock_outputs", depAsCty.GetAttr("mock_outputs"))
 		}
 
+		if dep.MockOutputsFile != nil {
+			depBody.SetAttributeValue("mock_outputs_file", depAsCty.GetAttr("mock_outputs_file"))
+		}
+
 		if dep.MockOutputsAllowedTerraformCommands != nil {
 			depBody.SetAttributeValue("mock_outputs_allowed_terraform_commands", depAsCty.GetAttr("mock_outputs_allowed_terraform_commands"))
 		}
@@ -412,11 +512,33 @@ func (cfg *TerragruntConfig) WriteTo(w io.Writer) (int64, error) {
 			depBody.SetAttributeValue("mock_outputs_merge_strategy_with_state", depAsCty.GetAttr("mock_outputs_merge_strategy_with_state"))
 		}
 
+		if dep.Outputs != nil {
+			outputs := cty.ListValEmpty(cty.String)
+
+			if len(*dep.Outputs) > 0 {
+				names := make([]cty.Value, len(*dep.Outputs))
+
+				for i, name := range *dep.Outputs {
+					names[i] = cty.StringVal(name)
+				}
+
+				outputs = cty.ListVal(names)
+			}
+
+			depBody.SetAttributeValue("outputs", outputs)
+		}
+
+		if dep.FetchTimeoutSec != nil {
+			depBody.SetAttributeValue("fetch_timeout_sec", cty.NumberIntVal(int64(*dep.FetchTimeoutSec)))
+		}
+
+		prov.annotate(rootBody, MetadataDependency, dep.Name)
 		rootBody.AppendBlock(depBlock)
 	}
 
 	// Handle generate blocks
-	for name, gen := range cfg.GenerateConfigs {
+	for _, name := range slices.Sorted(maps.Keys(cfg.GenerateConfigs)) {
+		gen := cfg.GenerateConfigs[name]
 		genBlock := hclwrite.NewBlock("generate", []string{name})
 		genBody := genBlock.Body()
 		genBody.SetAttributeValue("path", gostringToCty(gen.Path))
@@ -436,6 +558,7 @@ func (cfg *TerragruntConfig) WriteTo(w io.Writer) (int64, error) {
 			genBody.SetAttributeValue("disable", goboolToCty(gen.Disable))
 		}
 
+		prov.annotate(rootBody, MetadataGenerateConfigs, name)
 		rootBody.AppendBlock(genBlock)
 	}
 
@@ -449,9 +572,107 @@ func (cfg *TerragruntConfig) WriteTo(w io.Writer) (int64, error) {
 			flagBody.SetAttributeValue("default", flagAsCty.GetAttr("default"))
 		}
 
+		prov.annotate(rootBody, MetadataFeatureFlag, flag.Name)
 		rootBody.AppendBlock(flagBlock)
 	}
 
+	// Handle parallelism limits
+	for _, limit := range cfg.ParallelismLimits {
+		limitBlock := hclwrite.NewBlock(MetadataParallelismLimit, []string{limit.Name})
+		limitBody := limitBlock.Body()
+		limitAsCty := cfgAsCty.GetAttr(MetadataParallelismLimit).GetAttr(limit.Name)
+
+		limitBody.SetAttributeValue("max", limitAsCty.GetAttr("max"))
+
+		if len(limit.Paths) > 0 {
+			limitBody.SetAttributeValue("paths", limitAsCty.GetAttr("paths"))
+		}
+
+		if len(limit.Tags) > 0 {
+			limitBody.SetAttributeValue("tags", limitAsCty.GetAttr("tags"))
+		}
+
+		if len(limit.DependsOn) > 0 {
+			limitBody.SetAttributeValue("depends_on", limitAsCty.GetAttr("depends_on"))
+		}
+
+		prov.annotate(rootBody, MetadataParallelismLimit, limit.Name)
+		rootBody.AppendBlock(limitBlock)
+	}
+
+	// Handle apply windows
+	for _, window := range cfg.ApplyWindows {
+		windowBlock := hclwrite.NewBlock(MetadataApplyWindow, []string{window.Name})
+		windowBody := windowBlock.Body()
+		windowAsCty := cfgAsCty.GetAttr(MetadataApplyWindow).GetAttr(window.Name)
+
+		windowBody.SetAttributeValue("schedule", windowAsCty.GetAttr("schedule"))
+		windowBody.SetAttributeValue("duration", windowAsCty.GetAttr("duration"))
+
+		if window.Timezone != nil {
+			windowBody.SetAttributeValue("timezone", windowAsCty.GetAttr("timezone"))
+		}
+
+		if window.Outside != nil {
+			windowBody.SetAttributeValue("outside", windowAsCty.GetAttr("outside"))
+		}
+
+		if len(window.Paths) > 0 {
+			windowBody.SetAttributeValue("paths", windowAsCty.GetAttr("paths"))
+		}
+
+		if len(window.Tags) > 0 {
+			windowBody.SetAttributeValue("tags", windowAsCty.GetAttr("tags"))
+		}
+
+		prov.annotate(rootBody, MetadataApplyWindow, window.Name)
+		rootBody.AppendBlock(windowBlock)
+	}
+
+	// Handle failure_policy block
+	if cfg.FailurePolicy != nil {
+		policyBlock := hclwrite.NewBlock(MetadataFailurePolicy, nil)
+		policyBody := policyBlock.Body()
+		policyAsCty := cfgAsCty.GetAttr(MetadataFailurePolicy)
+
+		if cfg.FailurePolicy.Mode != nil {
+			policyBody.SetAttributeValue("mode", policyAsCty.GetAttr("mode"))
+		}
+
+		if len(cfg.FailurePolicy.OnFailure) > 0 {
+			policyBody.SetAttributeValue("on_failure", policyAsCty.GetAttr("on_failure"))
+		}
+
+		prov.annotate(rootBody, MetadataFailurePolicy)
+		rootBody.AppendBlock(policyBlock)
+	}
+
+	// Handle executor block
+	if cfg.Executor != nil {
+		executorBlock := hclwrite.NewBlock(MetadataExecutor, nil)
+		executorBody := executorBlock.Body()
+		executorAsCty := cfgAsCty.GetAttr(MetadataExecutor)
+
+		if cfg.Executor.Address != nil {
+			executorBody.SetAttributeValue("address", executorAsCty.GetAttr("address"))
+		}
+
+		if cfg.Executor.Insecure != nil {
+			executorBody.SetAttributeValue("insecure", executorAsCty.GetAttr("insecure"))
+		}
+
+		if len(cfg.Executor.ForwardEnv) > 0 {
+			executorBody.SetAttributeValue("forward_env", executorAsCty.GetAttr("forward_env"))
+		}
+
+		if len(cfg.Executor.Meta) > 0 {
+			executorBody.SetAttributeValue("meta", executorAsCty.GetAttr("meta"))
+		}
+
+		prov.annotate(rootBody, MetadataExecutor)
+		rootBody.AppendBlock(executorBlock)
+	}
+
 	// Handle engine block
 	if cfg.Engine != nil {
 		engineBlock := hclwrite.NewBlock("engine", nil)
@@ -474,6 +695,7 @@ func (cfg *TerragruntConfig) WriteTo(w io.Writer) (int64, error) {
 			engineBody.SetAttributeValue("meta", engineAsCty.GetAttr("meta"))
 		}
 
+		prov.annotate(rootBody, MetadataEngine)
 		rootBody.AppendBlock(engineBlock)
 	}
 
@@ -493,6 +715,7 @@ func (cfg *TerragruntConfig) WriteTo(w io.Writer) (int64, error) {
 
 		excludeBody.SetAttributeValue("if", excludeAsCty.GetAttr("if"))
 
+		prov.annotate(rootBody, MetadataExclude)
 		rootBody.AppendBlock(excludeBlock)
 	}
 
@@ -557,6 +780,7 @@ func (cfg *TerragruntConfig) WriteTo(w io.Writer) (int64, error) {
 			}
 		}
 
+		prov.annotate(rootBody, MetadataErrors)
 		rootBody.AppendBlock(errorsBlock)
 	}
 
@@ -574,63 +798,112 @@ func (cfg *TerragruntConfig) WriteTo(w io.Writer) (int64, error) {
 			catalogBody.SetAttributeValue("urls", catalogAsCty.GetAttr("urls"))
 		}
 
+		prov.annotate(rootBody, MetadataCatalog)
 		rootBody.AppendBlock(catalogBlock)
 	}
 
 	// Handle attributes
 	if cfg.TerraformBinary != "" {
+		prov.annotate(rootBody, MetadataTerraformBinary)
 		rootBody.SetAttributeValue("terraform_binary", cfgAsCty.GetAttr("terraform_binary"))
 	}
 
 	if cfg.TerraformVersionConstraint != "" {
+		prov.annotate(rootBody, MetadataTerraformVersionConstraint)
 		rootBody.SetAttributeValue("terraform_version_constraint", cfgAsCty.GetAttr("terraform_version_constraint"))
 	}
 
 	if cfg.TerragruntVersionConstraint != "" {
+		prov.annotate(rootBody, MetadataTerragruntVersionConstraint)
 		rootBody.SetAttributeValue("terragrunt_version_constraint", cfgAsCty.GetAttr("terragrunt_version_constraint"))
 	}
 
 	if cfg.DownloadDir != "" {
+		prov.annotate(rootBody, MetadataDownloadDir)
 		rootBody.SetAttributeValue("download_dir", cfgAsCty.GetAttr("download_dir"))
 	}
 
+	if cfg.Environment != "" {
+		prov.annotate(rootBody, MetadataEnvironment)
+		rootBody.SetAttributeValue("environment", cfgAsCty.GetAttr("environment"))
+	}
+
 	if cfg.PreventDestroy != nil {
+		prov.annotate(rootBody, MetadataPreventDestroy)
 		rootBody.SetAttributeValue("prevent_destroy", cfgAsCty.GetAttr("prevent_destroy"))
 	}
 
 	if cfg.Skip != nil {
+		prov.annotate(rootBody, MetadataSkip)
 		rootBody.SetAttributeValue("skip", cfgAsCty.GetAttr("skip"))
 	}
 
 	if cfg.IamRole != "" {
+		prov.annotate(rootBody, MetadataIamRole)
 		rootBody.SetAttributeValue("iam_role", cfgAsCty.GetAttr("iam_role"))
 	}
 
 	if cfg.IamAssumeRoleDuration != nil {
+		prov.annotate(rootBody, MetadataIamAssumeRoleDuration)
 		rootBody.SetAttributeValue("iam_assume_role_duration", cfgAsCty.GetAttr("iam_assume_role_duration"))
 	}
 
 	if cfg.IamAssumeRoleSessionName != "" {
+		prov.annotate(rootBody, MetadataIamAssumeRoleSessionName)
 		rootBody.SetAttributeValue("iam_assume_role_session_name", cfgAsCty.GetAttr("iam_assume_role_session_name"))
 	}
 
 	if cfg.RetryMaxAttempts != nil {
+		prov.annotate(rootBody, MetadataRetryMaxAttempts)
 		rootBody.SetAttributeValue("retry_max_attempts", cfgAsCty.GetAttr("retry_max_attempts"))
 	}
 
 	if cfg.RetrySleepIntervalSec != nil {
+		prov.annotate(rootBody, MetadataRetrySleepIntervalSec)
 		rootBody.SetAttributeValue("retry_sleep_interval_sec", cfgAsCty.GetAttr("retry_sleep_interval_sec"))
 	}
 
+	if cfg.Weight != nil {
+		prov.annotate(rootBody, MetadataWeight)
+		rootBody.SetAttributeValue("weight", cfgAsCty.GetAttr("weight"))
+	}
+
+	if cfg.RunTimeoutSec != nil {
+		prov.annotate(rootBody, MetadataRunTimeoutSec)
+		rootBody.SetAttributeValue("run_timeout_sec", cfgAsCty.GetAttr("run_timeout_sec"))
+	}
+
+	if cfg.QueuePriority != nil {
+		prov.annotate(rootBody, MetadataQueuePriority)
+		rootBody.SetAttributeValue("queue_priority", cfgAsCty.GetAttr("queue_priority"))
+	}
+
 	if len(cfg.RetryableErrors) > 0 {
+		prov.annotate(rootBody, MetadataRetryableErrors)
 		rootBody.SetAttributeValue("retryable_errors", cfgAsCty.GetAttr("retryable_errors"))
 	}
 
+	if len(cfg.Tags) > 0 {
+		prov.annotate(rootBody, MetadataTags)
+		rootBody.SetAttributeValue("tags", cfgAsCty.GetAttr("tags"))
+	}
+
+	if len(cfg.MutexGroups) > 0 {
+		prov.annotate(rootBody, MetadataMutexGroups)
+		rootBody.SetAttributeValue("mutex_groups", cfgAsCty.GetAttr("mutex_groups"))
+	}
+
 	if len(cfg.Inputs) > 0 {
-		rootBody.SetAttributeValue("inputs", cfgAsCty.GetAttr("inputs"))
+		if prov != nil {
+			rootBody.SetAttributeRaw("inputs", prov.inputs(cfgAsCty.GetAttr("inputs")))
+		} else {
+			rootBody.SetAttributeValue("inputs", cfgAsCty.GetAttr("inputs"))
+		}
 	}
 
-	return f.WriteTo(w)
+	n, err := w.Write(hclwrite.Format(f.Bytes()))
+
+	return int64(n), errors.New(err)
 }
 
 // terragruntConfigFile represents the configuration supported in a Terragrunt configuration file (i.e.
@@ -644,6 +917,13 @@ type terragruntConfigFile struct {
 	TerragruntVersionConstraint *string          `hcl:"terragrunt_version_constraint,attr"`
 	Inputs                      *cty.Value       `hcl:"inputs,attr"`
 
+	// We allow users to override the inputs for specific commands:
+	//
+	// inputs_overrides "plan" {
+	//   refresh = false
+	// }
+	InputsOverrides []terragruntInputsOverrides `hcl:"inputs_overrides,block"`
+
 	// We allow users to configure remote state (backend) via blocks:
 	//
 	// remote_state {
@@ -672,6 +952,9 @@ type terragruntConfigFile struct {
 	FeatureFlags             []*FeatureFlag      `hcl:"feature,block"`
 	Exclude                  *ExcludeConfig      `hcl:"exclude,block"`
 	Errors                   *ErrorsConfig       `hcl:"errors,block"`
+	Assertions               []AssertConfig      `hcl:"assert,block"`
+	ParallelismLimits        ParallelismLimits   `hcl:"parallelism_limit,block"`
+	ApplyWindows             ApplyWindows        `hcl:"apply_window,block"`
 
 	// We allow users to configure code generation via blocks:
 	//
@@ -695,6 +978,26 @@ type terragruntConfigFile struct {
 	RetryMaxAttempts      *int     `hcl:"retry_max_attempts,optional"`
 	RetrySleepIntervalSec *int     `hcl:"retry_sleep_interval_sec,optional"`
 
+	Weight *int `hcl:"weight,optional"`
+
+	RunTimeoutSec *int `hcl:"run_timeout_sec,optional"`
+
+	QueuePriority *int `hcl:"queue_priority,optional"`
+
+	FailurePolicy *FailurePolicyConfig `hcl:"failure_policy,block"`
+
+	Executor *ExecutorConfig `hcl:"executor,block"`
+
+	Tags []string `hcl:"tags,optional"`
+
+	MutexGroups []string `hcl:"mutex_groups,optional"`
+
+	Environment              *string `hcl:"environment,optional"`
+	EnvironmentMergeStrategy *string `hcl:"environment_merge_strategy,optional"`
+
+	// The extended unit is parsed and merged in a separate cycle, see extendUnitConfig.
+	Extends *string `hcl:"extends,optional"`
+
 	// This struct is used for validating and parsing the entire terragrunt config. Since locals and include are
 	// evaluated in a completely separate cycle, it should not be evaluated here. Otherwise, we can't support self
 	// referencing other elements in the same block.
@@ -702,6 +1005,8 @@ type terragruntConfigFile struct {
 	// that have extraneous, unsupported blocks and attributes.
 	Locals  *terragruntLocal          `hcl:"locals,block"`
 	Include []terragruntIncludeIgnore `hcl:"include,block"`
+	Values  []terragruntValueIgnore   `hcl:"value,block"`
+	Imports []terragruntImportIgnore  `hcl:"import,block"`
 }
 
 // We use a struct designed to not parse the block, as locals and includes are parsed and decoded using a special
@@ -723,9 +1028,14 @@ type terragruntGenerateBlock struct {
 	DisableSignature *bool   `hcl:"disable_signature,attr" mapstructure:"disable_signature"`
 	Disable          *bool   `hcl:"disable,attr" mapstructure:"disable"`
 	Name             string  `hcl:",label" mapstructure:",omitempty"`
-	Path             string  `hcl:"path,attr" mapstructure:"path"`
+	Path             string  `hcl:"path,optional" mapstructure:"path"`
 	IfExists         string  `hcl:"if_exists,attr" mapstructure:"if_exists"`
-	Contents         string  `hcl:"contents,attr" mapstructure:"contents"`
+	Contents         *string `hcl:"contents,attr" mapstructure:"contents"`
+	TemplateEngine   *string `hcl:"template_engine,attr" mapstructure:"template_engine"`
+	TemplateFile     *string `hcl:"template_file,attr" mapstructure:"template_file"`
+	// Files maps the paths of the files to generate to their contents, so a single block generates multiple files with
+	// the same settings. It is an alternative to path and contents.
+	Files map[string]string `hcl:"files,optional" mapstructure:"files"`
 }
 
 type IncludeConfigsMap map[string]IncludeConfig
@@ -746,10 +1056,15 @@ type IncludeConfigs []IncludeConfig
 // IncludeConfig represents the configuration settings for a parent Terragrunt configuration file that you can
 // include into a child Terragrunt configuration file. You can have more than one include config.
 type IncludeConfig struct {
+	Enabled       *bool   `hcl:"enabled,attr"`
 	Expose        *bool   `hcl:"expose,attr"`
+	ExposeLocals  *bool   `hcl:"expose_locals,attr"`
 	MergeStrategy *string `hcl:"merge_strategy,attr"`
-	Name          string  `hcl:"name,label"`
-	Path          string  `hcl:"path,attr"`
+	// HooksMergeStrategy controls whether the hooks of the including config replace the included ones with the same
+	// name, or are added to them, see mergeIncludedHooks.
+	HooksMergeStrategy *string `hcl:"hooks_merge_strategy,attr"`
+	Name               string  `hcl:"name,label"`
+	Path               string  `hcl:"path,attr"`
 }
 
 func (include *IncludeConfig) String() string {
@@ -778,6 +1093,24 @@ func (include *IncludeConfig) GetExpose() bool {
 	return *include.Expose
 }
 
+// IsEnabled returns true if the include is not gated by an `enabled` condition, or if the condition is true.
+func (include *IncludeConfig) IsEnabled() bool {
+	if include == nil || include.Enabled == nil {
+		return true
+	}
+
+	return *include.Enabled
+}
+
+// GetExposeLocals returns true if only the locals of the included config are exposed to the child.
+func (include *IncludeConfig) GetExposeLocals() bool {
+	if include == nil || include.ExposeLocals == nil {
+		return false
+	}
+
+	return *include.ExposeLocals
+}
+
 func (include *IncludeConfig) GetMergeStrategy() (MergeStrategyType, error) {
 	if include.MergeStrategy == nil {
 		return ShallowMerge, nil
@@ -798,6 +1131,24 @@ func (include *IncludeConfig) GetMergeStrategy() (MergeStrategyType, error) {
 	}
 }
 
+func (include *IncludeConfig) GetHooksMergeStrategy() (HooksMergeStrategyType, error) {
+	if include.HooksMergeStrategy == nil {
+		return OverrideHooks, nil
+	}
+
+	strategy := *include.HooksMergeStrategy
+	switch strategy {
+	case string(OverrideHooks):
+		return OverrideHooks, nil
+	case string(ParentFirstHooks):
+		return ParentFirstHooks, nil
+	case string(ChildFirstHooks):
+		return ChildFirstHooks, nil
+	default:
+		return OverrideHooks, errors.New(InvalidHooksMergeStrategyTypeError(strategy))
+	}
+}
+
 type MergeStrategyType string
 
 const (
@@ -807,6 +1158,14 @@ const (
 	DeepMergeMapOnly MergeStrategyType = "deep_map_only"
 )
 
+type HooksMergeStrategyType string
+
+const (
+	OverrideHooks    HooksMergeStrategyType = "override"
+	ParentFirstHooks HooksMergeStrategyType = "parent_first"
+	ChildFirstHooks  HooksMergeStrategyType = "child_first"
+)
+
 // ModuleDependencies represents the paths to other Terraform modules that must be applied before the current module
 // can be applied
 type ModuleDependencies struct {
@@ -826,6 +1185,61 @@ func (deps *ModuleDependencies) Merge(source *ModuleDependencies) {
 	}
 }
 
+// ExpandGlobs replaces the paths of the dependencies that are glob patterns, e.g. `../apps/*`, with the paths of the
+// units they match, relative to the dir of the given config path like the pattern. Matches that aren't units, or are
+// the unit of the config itself, are ignored, and a pattern matching no unit is removed.
+func (deps *ModuleDependencies) ExpandGlobs(configPath string) error {
+	if deps == nil {
+		return nil
+	}
+
+	configDir := filepath.Dir(configPath)
+	paths := make([]string, 0, len(deps.Paths))
+
+	for _, dependencyPath := range deps.Paths {
+		if !strings.ContainsAny(dependencyPath, "*?[") {
+			paths = append(paths, dependencyPath)
+			continue
+		}
+
+		pattern := filepath.FromSlash(dependencyPath)
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(configDir, pattern)
+		}
+
+		matches, err := zglob.Glob(pattern)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return errors.New(err)
+		}
+
+		slices.Sort(matches)
+
+		for _, match := range matches {
+			if !util.IsDir(match) || strings.Contains(match, util.TerragruntCacheDir) || util.CleanPath(match) == util.CleanPath(configDir) {
+				continue
+			}
+
+			if !util.FileExists(GetDefaultConfigPath(match)) {
+				continue
+			}
+
+			if !filepath.IsAbs(dependencyPath) {
+				if match, err = util.GetPathRelativeTo(match, configDir); err != nil {
+					return err
+				}
+			}
+
+			if !util.ListContainsElement(paths, match) {
+				paths = append(paths, match)
+			}
+		}
+	}
+
+	deps.Paths = paths
+
+	return nil
+}
+
 func (deps *ModuleDependencies) String() string {
 	return fmt.Sprintf("ModuleDependencies{Paths = %v}", deps.Paths)
 }
@@ -836,6 +1250,7 @@ type Hook struct {
 	RunOnError     *bool    `hcl:"run_on_error,attr" cty:"run_on_error"`
 	SuppressStdout *bool    `hcl:"suppress_stdout,attr" cty:"suppress_stdout"`
 	WorkingDir     *string  `hcl:"working_dir,attr" cty:"working_dir"`
+	Priority       *int     `hcl:"priority,attr" cty:"priority"`
 	Name           string   `hcl:"name,label" cty:"name"`
 	Commands       []string `hcl:"commands,attr" cty:"commands"`
 	Execute        []string `hcl:"execute,attr" cty:"execute"`
@@ -844,12 +1259,41 @@ type Hook struct {
 type ErrorHook struct {
 	SuppressStdout *bool    `hcl:"suppress_stdout,attr" cty:"suppress_stdout"`
 	WorkingDir     *string  `hcl:"working_dir,attr" cty:"working_dir"`
+	Priority       *int     `hcl:"priority,attr" cty:"priority"`
 	Name           string   `hcl:"name,label" cty:"name"`
 	Commands       []string `hcl:"commands,attr" cty:"commands"`
 	Execute        []string `hcl:"execute,attr" cty:"execute"`
 	OnErrors       []string `hcl:"on_errors,attr" cty:"on_errors"`
 }
 
+func (conf Hook) priority() int {
+	if conf.Priority == nil {
+		return 0
+	}
+
+	return *conf.Priority
+}
+
+func (conf ErrorHook) priority() int {
+	if conf.Priority == nil {
+		return 0
+	}
+
+	return *conf.Priority
+}
+
+// sortHooksByPriority returns a copy of the given hooks ordered from the highest to the lowest priority, where the
+// hooks with the same priority keep the order in which they are declared.
+func sortHooksByPriority[T interface{ priority() int }](hooks []T) []T {
+	sorted := slices.Clone(hooks)
+
+	slices.SortStableFunc(sorted, func(a, b T) int {
+		return cmp.Compare(b.priority(), a.priority())
+	})
+
+	return sorted
+}
+
 func (conf *Hook) String() string {
 	return fmt.Sprintf("Hook{Name = %s, Commands = %v}", conf.Name, len(conf.Commands))
 }
@@ -885,7 +1329,7 @@ func (cfg *TerraformConfig) GetBeforeHooks() []Hook {
 		return nil
 	}
 
-	return cfg.BeforeHooks
+	return sortHooksByPriority(cfg.BeforeHooks)
 }
 
 func (cfg *TerraformConfig) GetAfterHooks() []Hook {
@@ -893,7 +1337,7 @@ func (cfg *TerraformConfig) GetAfterHooks() []Hook {
 		return nil
 	}
 
-	return cfg.AfterHooks
+	return sortHooksByPriority(cfg.AfterHooks)
 }
 
 func (cfg *TerraformConfig) GetErrorHooks() []ErrorHook {
@@ -901,7 +1345,7 @@ func (cfg *TerraformConfig) GetErrorHooks() []ErrorHook {
 		return nil
 	}
 
-	return cfg.ErrorHooks
+	return sortHooksByPriority(cfg.ErrorHooks)
 }
 
 func (cfg *TerraformConfig) ValidateHooks() error {
@@ -965,6 +1409,48 @@ func (args *TerraformExtraArguments) GetVarFiles(l log.Logger) []string {
 	return varFiles
 }
 
+// FilterTerraformExtraArgs returns the arguments, and the -var-file arguments of the var files, of the extra_arguments
+// blocks of the given config that apply to the command of the given options.
+func FilterTerraformExtraArgs(l log.Logger, opts *options.TerragruntOptions, terragruntConfig *TerragruntConfig) []string {
+	out := []string{}
+	cmd := opts.TerraformCliArgs.First()
+
+	for _, arg := range terragruntConfig.Terraform.ExtraArgs {
+		for _, argCmd := range arg.Commands {
+			if cmd == argCmd {
+				lastArg := opts.TerraformCliArgs.Last()
+				skipVars := (cmd == tf.CommandNameApply || cmd == tf.CommandNameDestroy) && util.IsFile(lastArg)
+
+				// The following is a fix for GH-493.
+				// If the first argument is "apply" and the second argument is a file (plan),
+				// we don't add any -var-file to the command.
+				if arg.Arguments != nil {
+					if skipVars {
+						// If we have to skip vars, we need to iterate over all elements of array...
+						for _, a := range *arg.Arguments {
+							if !strings.HasPrefix(a, "-var") {
+								out = append(out, a)
+							}
+						}
+					} else {
+						// ... Otherwise, let's add all the arguments
+						out = append(out, *arg.Arguments...)
+					}
+				}
+
+				if !skipVars {
+					varFiles := arg.GetVarFiles(l)
+					for _, file := range varFiles {
+						out = append(out, "-var-file="+file)
+					}
+				}
+			}
+		}
+	}
+
+	return out
+}
+
 // GetTerraformSourceURL returns the source URL for OpenTofu/Terraform configuration.
 //
 // There are two ways a user can tell Terragrunt that it needs to download Terraform configurations from a specific
@@ -1293,6 +1779,14 @@ func ParseConfig(ctx *ParsingContext, l log.Logger, file *hclparse.File, include
 
 	ctx = ctx.WithValues(unitValues)
 
+	// Resolve the typed `value` blocks against the values provided by the parent stack.
+	unitValues, err = evaluateValueBlocks(ctx, l, file)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = ctx.WithValues(unitValues)
+
 	// Decode just the Base blocks. See the function docs for DecodeBaseBlocks for more info on what base blocks are.
 	baseBlocks, err := DecodeBaseBlocks(ctx, l, file, includeFromChild)
 	if err != nil {
@@ -1302,6 +1796,7 @@ func ParseConfig(ctx *ParsingContext, l log.Logger, file *hclparse.File, include
 	if baseBlocks != nil {
 		ctx = ctx.WithTrackInclude(baseBlocks.TrackInclude)
 		ctx = ctx.WithFeatures(baseBlocks.FeatureFlags)
+		ctx = ctx.WithImports(baseBlocks.Imports)
 		ctx = ctx.WithLocals(baseBlocks.Locals)
 	}
 
@@ -1355,7 +1850,33 @@ func ParseConfig(ctx *ParsingContext, l log.Logger, file *hclparse.File, include
 		mergedConfig.Locals = config.Locals
 		mergedConfig.Exclude = config.Exclude
 
-		return mergedConfig, errs.ErrorOrNil()
+		// Keep track of the locals exposed by the includes with `expose_locals`, along with the file they come from.
+		if err := setExposedIncludeLocals(ctx, l, mergedConfig); err != nil {
+			errs = errs.Append(err)
+		}
+
+		config = mergedConfig
+	}
+
+	// Merge the unit config onto the unit it extends, once the includes are merged in.
+	if includeFromChild == nil && errs.ErrorOrNil() == nil {
+		config, err = extendUnitConfig(ctx, l, file, config, false)
+		if err != nil {
+			errs = errs.Append(err)
+		}
+	}
+
+	// Layer the environment overlay onto the unit config, once the includes are merged in.
+	if includeFromChild == nil && errs.ErrorOrNil() == nil {
+		config, err = applyEnvironmentOverlay(ctx, l, file.ConfigPath, config, false)
+		if err != nil {
+			errs = errs.Append(err)
+		}
+	}
+
+	// The default inputs have the lowest precedence, so they are only set once all the other sources are merged in.
+	if includeFromChild == nil && errs.ErrorOrNil() == nil && baseBlocks != nil {
+		baseBlocks.defaults.applyDefaultInputs(config)
 	}
 
 	return config, errs.ErrorOrNil()
@@ -1537,9 +2058,18 @@ func decodeAsTerragruntConfigFile(ctx *ParsingContext, l log.Logger, file *hclpa
 			return nil, err
 		}
 
+		inputs, err = decryptEncryptedValues(ctx, l, inputs)
+		if err != nil {
+			return nil, err
+		}
+
 		terragruntConfig.Inputs = &inputs
 	}
 
+	if err := decryptInputsOverrides(ctx, l, terragruntConfig.InputsOverrides); err != nil {
+		return nil, err
+	}
+
 	return &terragruntConfig, nil
 }
 
@@ -1641,6 +2171,10 @@ func convertToTerragruntConfig(ctx *ParsingContext, configPath string, terragrun
 		terragruntConfig.SetFieldMetadata(MetadataTerraform, defaultMetadata)
 	}
 
+	if err := terragruntConfigFromFile.Dependencies.ExpandGlobs(ctx.TerragruntOptions.TerragruntConfigPath); err != nil {
+		errs = errs.Append(err)
+	}
+
 	if err := validateDependencies(ctx, terragruntConfigFromFile.Dependencies); err != nil {
 		errs = errs.Append(err)
 	}
@@ -1652,6 +2186,10 @@ func convertToTerragruntConfig(ctx *ParsingContext, configPath string, terragrun
 		}
 	}
 
+	if err := validateDependencyTargets(terragruntConfigFromFile.TerragruntDependencies); err != nil {
+		errs = errs.Append(err)
+	}
+
 	terragruntConfig.TerragruntDependencies = terragruntConfigFromFile.TerragruntDependencies
 	for _, dep := range terragruntConfig.TerragruntDependencies {
 		terragruntConfig.SetFieldMetadataWithType(MetadataDependency, dep.Name, defaultMetadata)
@@ -1667,6 +2205,31 @@ func convertToTerragruntConfig(ctx *ParsingContext, configPath string, terragrun
 		terragruntConfig.SetFieldMetadata(MetadataRetryableErrors, defaultMetadata)
 	}
 
+	if terragruntConfigFromFile.Tags != nil {
+		terragruntConfig.Tags = terragruntConfigFromFile.Tags
+		terragruntConfig.SetFieldMetadata(MetadataTags, defaultMetadata)
+	}
+
+	if terragruntConfigFromFile.MutexGroups != nil {
+		terragruntConfig.MutexGroups = terragruntConfigFromFile.MutexGroups
+		terragruntConfig.SetFieldMetadata(MetadataMutexGroups, defaultMetadata)
+	}
+
+	if terragruntConfigFromFile.Weight != nil {
+		terragruntConfig.Weight = terragruntConfigFromFile.Weight
+		terragruntConfig.SetFieldMetadata(MetadataWeight, defaultMetadata)
+	}
+
+	if terragruntConfigFromFile.RunTimeoutSec != nil {
+		terragruntConfig.RunTimeoutSec = terragruntConfigFromFile.RunTimeoutSec
+		terragruntConfig.SetFieldMetadata(MetadataRunTimeoutSec, defaultMetadata)
+	}
+
+	if terragruntConfigFromFile.QueuePriority != nil {
+		terragruntConfig.QueuePriority = terragruntConfigFromFile.QueuePriority
+		terragruntConfig.SetFieldMetadata(MetadataQueuePriority, defaultMetadata)
+	}
+
 	if terragruntConfigFromFile.RetryMaxAttempts != nil {
 		terragruntConfig.RetryMaxAttempts = terragruntConfigFromFile.RetryMaxAttempts
 		terragruntConfig.SetFieldMetadata(MetadataRetryMaxAttempts, defaultMetadata)
@@ -1682,6 +2245,15 @@ func convertToTerragruntConfig(ctx *ParsingContext, configPath string, terragrun
 		terragruntConfig.SetFieldMetadata(MetadataDownloadDir, defaultMetadata)
 	}
 
+	if terragruntConfigFromFile.Environment != nil {
+		terragruntConfig.Environment = *terragruntConfigFromFile.Environment
+		terragruntConfig.SetFieldMetadata(MetadataEnvironment, defaultMetadata)
+	}
+
+	if terragruntConfigFromFile.EnvironmentMergeStrategy != nil {
+		terragruntConfig.environmentMergeStrategy = *terragruntConfigFromFile.EnvironmentMergeStrategy
+	}
+
 	if terragruntConfigFromFile.TerraformVersionConstraint != nil {
 		terragruntConfig.TerraformVersionConstraint = *terragruntConfigFromFile.TerraformVersionConstraint
 		terragruntConfig.SetFieldMetadata(MetadataTerraformVersionConstraint, defaultMetadata)
@@ -1744,6 +2316,53 @@ func convertToTerragruntConfig(ctx *ParsingContext, configPath string, terragrun
 		terragruntConfig.SetFieldMetadata(MetadataErrors, defaultMetadata)
 	}
 
+	if len(terragruntConfigFromFile.Assertions) > 0 {
+		if err := validateAssertions(terragruntConfigFromFile.Assertions); err != nil {
+			errs = errs.Append(err)
+		}
+
+		terragruntConfig.Assertions = terragruntConfigFromFile.Assertions
+		for _, assertion := range terragruntConfig.Assertions {
+			terragruntConfig.SetFieldMetadataWithType(MetadataAssert, assertion.Name, defaultMetadata)
+		}
+	}
+
+	if len(terragruntConfigFromFile.ParallelismLimits) > 0 {
+		if err := validateParallelismLimits(configPath, terragruntConfigFromFile.ParallelismLimits); err != nil {
+			errs = errs.Append(err)
+		}
+
+		terragruntConfig.ParallelismLimits = terragruntConfigFromFile.ParallelismLimits
+		for _, limit := range terragruntConfig.ParallelismLimits {
+			terragruntConfig.SetFieldMetadataWithType(MetadataParallelismLimit, limit.Name, defaultMetadata)
+		}
+	}
+
+	if len(terragruntConfigFromFile.ApplyWindows) > 0 {
+		if err := validateApplyWindows(configPath, terragruntConfigFromFile.ApplyWindows); err != nil {
+			errs = errs.Append(err)
+		}
+
+		terragruntConfig.ApplyWindows = terragruntConfigFromFile.ApplyWindows
+		for _, window := range terragruntConfig.ApplyWindows {
+			terragruntConfig.SetFieldMetadataWithType(MetadataApplyWindow, window.Name, defaultMetadata)
+		}
+	}
+
+	if terragruntConfigFromFile.FailurePolicy != nil {
+		if err := terragruntConfigFromFile.FailurePolicy.Validate(configPath); err != nil {
+			errs = errs.Append(err)
+		}
+
+		terragruntConfig.FailurePolicy = terragruntConfigFromFile.FailurePolicy
+		terragruntConfig.SetFieldMetadata(MetadataFailurePolicy, defaultMetadata)
+	}
+
+	if terragruntConfigFromFile.Executor != nil {
+		terragruntConfig.Executor = terragruntConfigFromFile.Executor
+		terragruntConfig.SetFieldMetadata(MetadataExecutor, defaultMetadata)
+	}
+
 	generateBlocks := []terragruntGenerateBlock{}
 	generateBlocks = append(generateBlocks, terragruntConfigFromFile.GenerateBlocks...)
 
@@ -1764,6 +2383,11 @@ func convertToTerragruntConfig(ctx *ParsingContext, configPath string, terragrun
 		}
 	}
 
+	generateBlocks, err = expandGenerateFilesBlocks(generateBlocks)
+	if err != nil {
+		errs = errs.Append(err)
+	}
+
 	if err := validateGenerateBlocks(&generateBlocks); err != nil {
 		errs = errs.Append(err)
 	}
@@ -1774,6 +2398,12 @@ func convertToTerragruntConfig(ctx *ParsingContext, configPath string, terragrun
 			return nil, err
 		}
 
+		contents, err := generateContents(ctx, configPath, &block, terragruntConfigFromFile.Inputs)
+		if err != nil {
+			errs = errs.Append(err)
+			continue
+		}
+
 		if block.IfDisabled == nil {
 			block.IfDisabled = &DefaultGenerateBlockIfDisabledValueStr
 		}
@@ -1789,7 +2419,7 @@ func convertToTerragruntConfig(ctx *ParsingContext, configPath string, terragrun
 			IfExistsStr:   block.IfExists,
 			IfDisabled:    ifDisabled,
 			IfDisabledStr: *block.IfDisabled,
-			Contents:      block.Contents,
+			Contents:      contents,
 		}
 		if block.CommentPrefix == nil {
 			genConfig.CommentPrefix = codegen.DefaultCommentPrefix
@@ -1813,6 +2443,16 @@ func convertToTerragruntConfig(ctx *ParsingContext, configPath string, terragrun
 		terragruntConfig.SetFieldMetadataWithType(MetadataGenerateConfigs, block.Name, defaultMetadata)
 	}
 
+	if len(terragruntConfigFromFile.InputsOverrides) > 0 {
+		inputsOverrides, err := convertInputsOverrides(terragruntConfigFromFile.InputsOverrides)
+		if err != nil {
+			errs = errs.Append(err)
+		}
+
+		terragruntConfig.InputsOverrides = inputsOverrides
+		terragruntConfig.SetFieldMetadata(MetadataInputsOverrides, defaultMetadata)
+	}
+
 	if terragruntConfigFromFile.Inputs != nil {
 		inputs, err := ctyhelper.ParseCtyValueToMap(*terragruntConfigFromFile.Inputs)
 		if err != nil {
@@ -1862,6 +2502,47 @@ func validateDependencies(ctx *ParsingContext, dependencies *ModuleDependencies)
 	return nil
 }
 
+// expandGenerateFilesBlocks replaces the generate blocks with files by a block per file, named `<block name>/<path>`,
+// with the settings of the original block. The other blocks must set a path. Invalid blocks are left out.
+func expandGenerateFilesBlocks(blocks []terragruntGenerateBlock) ([]terragruntGenerateBlock, error) {
+	var (
+		expanded = make([]terragruntGenerateBlock, 0, len(blocks))
+		errs     = &errors.MultiError{}
+	)
+
+	for _, block := range blocks {
+		if block.Files == nil {
+			if block.Path == "" {
+				errs = errs.Append(errors.New(GenerateFilesConflictError{Name: block.Name}))
+				continue
+			}
+
+			expanded = append(expanded, block)
+
+			continue
+		}
+
+		if block.Path != "" || block.Contents != nil || block.TemplateFile != nil {
+			errs = errs.Append(errors.New(GenerateFilesConflictError{Name: block.Name}))
+			continue
+		}
+
+		for _, path := range slices.Sorted(maps.Keys(block.Files)) {
+			contents := block.Files[path]
+
+			fileBlock := block
+			fileBlock.Name = block.Name + "/" + path
+			fileBlock.Path = path
+			fileBlock.Contents = &contents
+			fileBlock.Files = nil
+
+			expanded = append(expanded, fileBlock)
+		}
+	}
+
+	return expanded, errs.ErrorOrNil()
+}
+
 // Iterate over generate blocks and detect duplicate names, return error with list of duplicated names
 func validateGenerateBlocks(blocks *[]terragruntGenerateBlock) error {
 	var (
@@ -2036,12 +2717,22 @@ func (cfg *TerragruntConfig) ErrorsConfig() (*options.ErrorsConfig, error) {
 			compiledPatterns = append(compiledPatterns, value)
 		}
 
-		result.Retry[retryBlock.Label] = &options.RetryConfig{
-			Name:             retryBlock.Label,
-			RetryableErrors:  compiledPatterns,
-			MaxAttempts:      retryBlock.MaxAttempts,
-			SleepIntervalSec: retryBlock.SleepIntervalSec,
+		retryConfig := &options.RetryConfig{
+			Name:                retryBlock.Label,
+			Action:              options.RetryAction(retryBlock.Action),
+			Backoff:             options.RetryBackoff(retryBlock.Backoff),
+			RetryableErrors:     compiledPatterns,
+			ExitCodes:           retryBlock.ExitCodes,
+			MaxAttempts:         retryBlock.MaxAttempts,
+			SleepIntervalSec:    retryBlock.SleepIntervalSec,
+			MaxSleepIntervalSec: retryBlock.MaxSleepIntervalSec,
+		}
+
+		if err := validateRetryConfig(retryConfig); err != nil {
+			return nil, err
 		}
+
+		result.Retry[retryBlock.Label] = retryConfig
 	}
 
 	for _, ignoreBlock := range cfg.Errors.Ignore {
@@ -2086,6 +2777,40 @@ func (cfg *TerragruntConfig) ErrorsConfig() (*options.ErrorsConfig, error) {
 	return result, nil
 }
 
+// validateRetryConfig sets the defaults of the given retry block and validates it.
+func validateRetryConfig(retryConfig *options.RetryConfig) error {
+	if retryConfig.Action == "" {
+		retryConfig.Action = options.RetryActionRetry
+	}
+
+	if retryConfig.Backoff == "" {
+		retryConfig.Backoff = options.RetryBackoffConstant
+	}
+
+	if !slices.Contains(options.RetryActions, retryConfig.Action) {
+		return errors.New(InvalidRetryBlockError{Name: retryConfig.Name, Reason: fmt.Sprintf("invalid action %q, must be one of %v", retryConfig.Action, options.RetryActions)})
+	}
+
+	if !slices.Contains(options.RetryBackoffs, retryConfig.Backoff) {
+		return errors.New(InvalidRetryBlockError{Name: retryConfig.Name, Reason: fmt.Sprintf("invalid backoff %q, must be one of %v", retryConfig.Backoff, options.RetryBackoffs)})
+	}
+
+	if len(retryConfig.RetryableErrors) == 0 && len(retryConfig.ExitCodes) == 0 {
+		return errors.New(InvalidRetryBlockError{Name: retryConfig.Name, Reason: "retryable_errors or exit_codes must be set"})
+	}
+
+	retries := retryConfig.Action == options.RetryActionRetry || retryConfig.Action == options.RetryActionRetryWithInit
+	if retries && retryConfig.MaxAttempts < 1 {
+		return errors.New(InvalidRetryBlockError{Name: retryConfig.Name, Reason: fmt.Sprintf("max_attempts must be at least 1, but is %d", retryConfig.MaxAttempts)})
+	}
+
+	if retryConfig.SleepIntervalSec < 0 || retryConfig.MaxSleepIntervalSec < 0 {
+		return errors.New(InvalidRetryBlockError{Name: retryConfig.Name, Reason: "sleep intervals can't be negative"})
+	}
+
+	return nil
+}
+
 // Build ErrorsPattern from string
 func errorsPattern(pattern string) (*options.ErrorsPattern, error) {
 	isNegative := false
@@ -24,6 +24,7 @@ func TerragruntConfigAsCty(config *TerragruntConfig) (cty.Value, error) {
 	output[MetadataTerraformVersionConstraint] = gostringToCty(config.TerraformVersionConstraint)
 	output[MetadataTerragruntVersionConstraint] = gostringToCty(config.TerragruntVersionConstraint)
 	output[MetadataDownloadDir] = gostringToCty(config.DownloadDir)
+	output[MetadataEnvironment] = gostringToCty(config.Environment)
 	output[MetadataIamRole] = gostringToCty(config.IamRole)
 	output[MetadataIamAssumeRoleSessionName] = gostringToCty(config.IamAssumeRoleSessionName)
 	output[MetadataIamWebIdentityToken] = gostringToCty(config.IamWebIdentityToken)
@@ -126,6 +127,24 @@ func TerragruntConfigAsCty(config *TerragruntConfig) (cty.Value, error) {
 		output[MetadataRetryableErrors] = retryableCty
 	}
 
+	tagsCty, err := goTypeToCty(config.Tags)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	if tagsCty != cty.NilVal {
+		output[MetadataTags] = tagsCty
+	}
+
+	mutexGroupsCty, err := goTypeToCty(config.MutexGroups)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	if mutexGroupsCty != cty.NilVal {
+		output[MetadataMutexGroups] = mutexGroupsCty
+	}
+
 	iamAssumeRoleDurationCty, err := goTypeToCty(config.IamAssumeRoleDuration)
 	if err != nil {
 		return cty.NilVal, err
@@ -144,6 +163,33 @@ func TerragruntConfigAsCty(config *TerragruntConfig) (cty.Value, error) {
 		output[MetadataRetryMaxAttempts] = retryMaxAttemptsCty
 	}
 
+	weightCty, err := goTypeToCty(config.Weight)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	if weightCty != cty.NilVal {
+		output[MetadataWeight] = weightCty
+	}
+
+	runTimeoutSecCty, err := goTypeToCty(config.RunTimeoutSec)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	if runTimeoutSecCty != cty.NilVal {
+		output[MetadataRunTimeoutSec] = runTimeoutSecCty
+	}
+
+	queuePriorityCty, err := goTypeToCty(config.QueuePriority)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	if queuePriorityCty != cty.NilVal {
+		output[MetadataQueuePriority] = queuePriorityCty
+	}
+
 	retrySleepIntervalSecCty, err := goTypeToCty(config.RetrySleepIntervalSec)
 	if err != nil {
 		return cty.NilVal, err
@@ -162,6 +208,60 @@ func TerragruntConfigAsCty(config *TerragruntConfig) (cty.Value, error) {
 		output[MetadataInputs] = inputsCty
 	}
 
+	inputsOverridesCty, err := convertToCtyWithJSON(config.InputsOverrides)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	if inputsOverridesCty != cty.NilVal {
+		output[MetadataInputsOverrides] = inputsOverridesCty
+	}
+
+	assertionsCty, err := goTypeToCty(config.Assertions)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	if assertionsCty != cty.NilVal {
+		output[MetadataAssert] = assertionsCty
+	}
+
+	parallelismLimitsCty, err := parallelismLimitsAsCty(config.ParallelismLimits)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	if parallelismLimitsCty != cty.NilVal {
+		output[MetadataParallelismLimit] = parallelismLimitsCty
+	}
+
+	applyWindowsCty, err := applyWindowsAsCty(config.ApplyWindows)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	if applyWindowsCty != cty.NilVal {
+		output[MetadataApplyWindow] = applyWindowsCty
+	}
+
+	if config.FailurePolicy != nil {
+		failurePolicyCty, err := goTypeToCty(config.FailurePolicy)
+		if err != nil {
+			return cty.NilVal, err
+		}
+
+		output[MetadataFailurePolicy] = failurePolicyCty
+	}
+
+	if config.Executor != nil {
+		executorCty, err := goTypeToCty(config.Executor)
+		if err != nil {
+			return cty.NilVal, err
+		}
+
+		output[MetadataExecutor] = executorCty
+	}
+
 	localsCty, err := convertToCtyWithJSON(config.Locals)
 	if err != nil {
 		return cty.NilVal, err
@@ -191,6 +291,15 @@ func TerragruntConfigAsCty(config *TerragruntConfig) (cty.Value, error) {
 		output[MetadataFeatureFlag] = featureFlagsCty
 	}
 
+	includeLocalsCty, err := includeLocalsAsCty(config.IncludeLocals)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	if includeLocalsCty != cty.NilVal {
+		output[MetadataInclude] = includeLocalsCty
+	}
+
 	return convertValuesMapToCtyVal(output)
 }
 
@@ -214,6 +323,10 @@ func TerragruntConfigAsCtyWithMetadata(config *TerragruntConfig) (cty.Value, err
 		return cty.NilVal, err
 	}
 
+	if err := wrapWithMetadata(config, config.Environment, MetadataEnvironment, &output); err != nil {
+		return cty.NilVal, err
+	}
+
 	if err := wrapWithMetadata(config, config.IamRole, MetadataIamRole, &output); err != nil {
 		return cty.NilVal, err
 	}
@@ -236,6 +349,14 @@ func TerragruntConfigAsCtyWithMetadata(config *TerragruntConfig) (cty.Value, err
 		return cty.NilVal, err
 	}
 
+	if err := wrapWithMetadata(config, config.Tags, MetadataTags, &output); err != nil {
+		return cty.NilVal, err
+	}
+
+	if err := wrapWithMetadata(config, config.MutexGroups, MetadataMutexGroups, &output); err != nil {
+		return cty.NilVal, err
+	}
+
 	if err := wrapWithMetadata(config, config.IamAssumeRoleDuration, MetadataIamAssumeRoleDuration, &output); err != nil {
 		return cty.NilVal, err
 	}
@@ -244,6 +365,18 @@ func TerragruntConfigAsCtyWithMetadata(config *TerragruntConfig) (cty.Value, err
 		return cty.NilVal, err
 	}
 
+	if err := wrapWithMetadata(config, config.Weight, MetadataWeight, &output); err != nil {
+		return cty.NilVal, err
+	}
+
+	if err := wrapWithMetadata(config, config.RunTimeoutSec, MetadataRunTimeoutSec, &output); err != nil {
+		return cty.NilVal, err
+	}
+
+	if err := wrapWithMetadata(config, config.QueuePriority, MetadataQueuePriority, &output); err != nil {
+		return cty.NilVal, err
+	}
+
 	if err := wrapWithMetadata(config, config.RetrySleepIntervalSec, MetadataRetrySleepIntervalSec, &output); err != nil {
 		return cty.NilVal, err
 	}
@@ -280,10 +413,58 @@ func TerragruntConfigAsCtyWithMetadata(config *TerragruntConfig) (cty.Value, err
 		return cty.NilVal, err
 	}
 
+	if len(config.InputsOverrides) > 0 {
+		inputsOverridesCty, err := convertToCtyWithJSON(config.InputsOverrides)
+		if err != nil {
+			return cty.NilVal, err
+		}
+
+		if err := wrapWithMetadata(config, inputsOverridesCty, MetadataInputsOverrides, &output); err != nil {
+			return cty.NilVal, err
+		}
+	}
+
+	if len(config.Assertions) > 0 {
+		if err := wrapWithMetadata(config, config.Assertions, MetadataAssert, &output); err != nil {
+			return cty.NilVal, err
+		}
+	}
+
+	if len(config.ParallelismLimits) > 0 {
+		if err := wrapWithMetadata(config, config.ParallelismLimits, MetadataParallelismLimit, &output); err != nil {
+			return cty.NilVal, err
+		}
+	}
+
+	if len(config.ApplyWindows) > 0 {
+		if err := wrapWithMetadata(config, config.ApplyWindows, MetadataApplyWindow, &output); err != nil {
+			return cty.NilVal, err
+		}
+	}
+
+	if config.FailurePolicy != nil {
+		if err := wrapWithMetadata(config, config.FailurePolicy, MetadataFailurePolicy, &output); err != nil {
+			return cty.NilVal, err
+		}
+	}
+
+	if config.Executor != nil {
+		if err := wrapWithMetadata(config, config.Executor, MetadataExecutor, &output); err != nil {
+			return cty.NilVal, err
+		}
+	}
+
 	if err := wrapCtyMapWithMetadata(config, &config.Locals, MetadataLocals, &output); err != nil {
 		return cty.NilVal, err
 	}
 
+	if len(config.IncludeLocals) > 0 {
+		includeLocals := includeLocalsAsMap(config.IncludeLocals)
+		if err := wrapCtyMapWithMetadata(config, &includeLocals, MetadataInclude, &output); err != nil {
+			return cty.NilVal, err
+		}
+	}
+
 	// remder dependencies as list of maps with "value" and "metadata"
 	if config.Dependencies != nil {
 		var dependencyWithMetadata = make([]ValueWithMetadata, 0, len(config.Dependencies.Paths))
@@ -309,7 +490,7 @@ func TerragruntConfigAsCtyWithMetadata(config *TerragruntConfig) (cty.Value, err
 	}
 
 	if config.TerragruntDependencies != nil {
-		var dependenciesMap = map[string]cty.Value{}
+		var dependenciesValues = newDependencyValues()
 
 		for _, block := range config.TerragruntDependencies {
 			ctyValue, err := goTypeToCty(block)
@@ -334,7 +515,12 @@ func TerragruntConfigAsCtyWithMetadata(config *TerragruntConfig) (cty.Value, err
 				continue
 			}
 
-			dependenciesMap[block.Name] = value
+			dependenciesValues.set(block, value)
+		}
+
+		dependenciesMap, err := dependenciesValues.asMap()
+		if err != nil {
+			return cty.NilVal, err
 		}
 
 		if len(dependenciesMap) > 0 {
@@ -637,7 +823,7 @@ func RemoteStateAsCty(remote *remotestate.RemoteState) (cty.Value, error) {
 
 // Serialize the list of dependency blocks to a cty Value as a map that maps the block names to the cty representation.
 func dependencyBlocksAsCty(dependencyBlocks Dependencies) (cty.Value, error) {
-	out := map[string]cty.Value{}
+	out := newDependencyValues()
 
 	for _, block := range dependencyBlocks {
 		blockCty, err := goTypeToCty(block)
@@ -645,10 +831,35 @@ func dependencyBlocksAsCty(dependencyBlocks Dependencies) (cty.Value, error) {
 			return cty.NilVal, err
 		}
 
-		out[block.Name] = blockCty
+		out.set(block, blockCty)
 	}
 
-	return convertValuesMapToCtyVal(out)
+	outMap, err := out.asMap()
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	return convertValuesMapToCtyVal(outMap)
+}
+
+// Serialize the locals exposed by includes to a cty Value as a map that maps the include labels to an object with the
+// `locals` attribute, mirroring how they are referenced in the child config.
+func includeLocalsAsCty(includeLocals map[string]any) (cty.Value, error) {
+	if len(includeLocals) == 0 {
+		return cty.NilVal, nil
+	}
+
+	return convertToCtyWithJSON(includeLocalsAsMap(includeLocals))
+}
+
+func includeLocalsAsMap(includeLocals map[string]any) map[string]any {
+	output := make(map[string]any, len(includeLocals))
+
+	for label, locals := range includeLocals {
+		output[label] = map[string]any{MetadataLocals: locals}
+	}
+
+	return output
 }
 
 // Serialize the list of feature flags to a cty Value as a map that maps the feature names to the cty representation.
@@ -667,6 +878,38 @@ func featureFlagsBlocksAsCty(featureFlagBlocks FeatureFlags) (cty.Value, error)
 	return convertValuesMapToCtyVal(out)
 }
 
+// Serialize the list of parallelism limits to a cty Value as a map that maps the limit names to the cty representation.
+func parallelismLimitsAsCty(limits ParallelismLimits) (cty.Value, error) {
+	out := map[string]cty.Value{}
+
+	for _, limit := range limits {
+		limitCty, err := goTypeToCty(limit)
+		if err != nil {
+			return cty.NilVal, err
+		}
+
+		out[limit.Name] = limitCty
+	}
+
+	return convertValuesMapToCtyVal(out)
+}
+
+// Serialize the list of apply windows to a cty Value as a map that maps the window names to the cty representation.
+func applyWindowsAsCty(windows ApplyWindows) (cty.Value, error) {
+	out := map[string]cty.Value{}
+
+	for _, window := range windows {
+		windowCty, err := goTypeToCty(window)
+		if err != nil {
+			return cty.NilVal, err
+		}
+
+		out[window.Name] = windowCty
+	}
+
+	return convertValuesMapToCtyVal(out)
+}
+
 // Serialize errors configuration as cty.Value.
 func errorsConfigAsCty(config *ErrorsConfig) (cty.Value, error) {
 	if config == nil {
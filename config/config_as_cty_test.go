@@ -27,6 +27,7 @@ func TestTerragruntConfigAsCtyDrift(t *testing.T) {
 	mockOutputs := cty.Zero
 	mockOutputsAllowedTerraformCommands := []string{"init"}
 	dependentModulesPath := []*string{&testSource}
+	failurePolicyMode := config.FailurePolicyContinue
 	metaVal := cty.MapVal(map[string]cty.Value{
 		"foo": cty.StringVal("bar"),
 	})
@@ -95,6 +96,9 @@ func TestTerragruntConfigAsCtyDrift(t *testing.T) {
 		Locals: map[string]any{
 			"quote": "the answer is 42",
 		},
+		IncludeLocals: map[string]any{
+			"root": map[string]any{"region": "us-east-1"},
+		},
 		DependentModulesPath: dependentModulesPath,
 		TerragruntDependencies: config.Dependencies{
 			config.Dependency{
@@ -113,6 +117,32 @@ func TestTerragruntConfigAsCtyDrift(t *testing.T) {
 				Default: &cty.Zero,
 			},
 		},
+		ParallelismLimits: config.ParallelismLimits{
+			&config.ParallelismLimit{
+				Name:  "networking",
+				Max:   2,
+				Paths: []string{"networking/**"},
+			},
+		},
+		MutexGroups: []string{"aws-account-123"},
+		ApplyWindows: config.ApplyWindows{
+			&config.ApplyWindow{
+				Name:     "weeknights",
+				Schedule: "0 22 * * 1-5",
+				Duration: "6h",
+				Tags:     []string{"production"},
+			},
+		},
+		FailurePolicy: &config.FailurePolicyConfig{
+			Mode:      &failurePolicyMode,
+			OnFailure: []string{"./rollback.sh"},
+		},
+		Executor: &config.ExecutorConfig{
+			Address:    &testSource,
+			Insecure:   &testTrue,
+			ForwardEnv: []string{"AWS_REGION"},
+			Meta:       map[string]string{"environment": "prod"},
+		},
 		Errors: &config.ErrorsConfig{
 			Retry: []*config.RetryBlock{
 				{
@@ -281,6 +311,8 @@ func terragruntConfigStructFieldToMapKey(t *testing.T, fieldName string) (string
 		return "dependencies", true
 	case "DownloadDir":
 		return "download_dir", true
+	case "Environment":
+		return "environment", true
 	case "PreventDestroy":
 		return "prevent_destroy", true
 	case "Skip":
@@ -295,6 +327,12 @@ func terragruntConfigStructFieldToMapKey(t *testing.T, fieldName string) (string
 		return "iam_web_identity_token", true
 	case "Inputs":
 		return "inputs", true
+	case "InputsOverrides":
+		return "inputs_overrides", true
+	case "Assertions":
+		return "assert", true
+	case "IncludeLocals":
+		return "include", true
 	case "Locals":
 		return "locals", true
 	case "TerragruntDependencies":
@@ -303,16 +341,36 @@ func terragruntConfigStructFieldToMapKey(t *testing.T, fieldName string) (string
 		return "generate", true
 	case "IsPartial":
 		return "", false
+	case "environmentMergeStrategy":
+		return "", false
 	case "ProcessedIncludes":
 		return "", false
 	case "FieldsMetadata":
 		return "", false
 	case "RetryableErrors":
 		return "retryable_errors", true
+	case "Tags":
+		return "tags", true
+	case "MutexGroups":
+		return "mutex_groups", true
+	case "ParallelismLimits":
+		return "parallelism_limit", true
+	case "ApplyWindows":
+		return "apply_window", true
+	case "FailurePolicy":
+		return "failure_policy", true
+	case "Executor":
+		return "executor", true
 	case "RetryMaxAttempts":
 		return "retry_max_attempts", true
 	case "RetrySleepIntervalSec":
 		return "retry_sleep_interval_sec", true
+	case "Weight":
+		return "weight", true
+	case "RunTimeoutSec":
+		return "run_timeout_sec", true
+	case "QueuePriority":
+		return "queue_priority", true
 	case "DependentModulesPath":
 		return "dependent_modules", true
 	case "Engine":
@@ -11,12 +11,12 @@ import (
 	"runtime"
 	"slices"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/getsops/sops/v3/cmd/sops/formats"
 	"github.com/getsops/sops/v3/decrypt"
 	"github.com/hashicorp/go-getter"
-	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/hcl/v2"
 	tflang "github.com/hashicorp/terraform/lang"
 	"github.com/zclconf/go-cty/cty"
@@ -51,6 +51,8 @@ const (
 	FuncNameGetEnv                                  = "get_env"
 	FuncNameRunCmd                                  = "run_cmd"
 	FuncNameReadTerragruntConfig                    = "read_terragrunt_config"
+	FuncNameReadUnitConfig                          = "read_unit_config"
+	FuncNameReadUnitLocals                          = "read_unit_locals"
 	FuncNameGetPlatform                             = "get_platform"
 	FuncNameGetRepoRoot                             = "get_repo_root"
 	FuncNameGetPathFromRepoRoot                     = "get_path_from_repo_root"
@@ -73,12 +75,25 @@ const (
 	FuncNameGetDefaultRetryableErrors               = "get_default_retryable_errors"
 	FuncNameReadTfvarsFile                          = "read_tfvars_file"
 	FuncNameGetWorkingDir                           = "get_working_dir"
+	FuncNameGetUnitName                             = "get_unit_name"
+	FuncNameGetStackRoot                            = "get_stack_root"
+	FuncNameGetStackPath                            = "get_stack_path"
 	FuncNameStartsWith                              = "startswith"
 	FuncNameEndsWith                                = "endswith"
 	FuncNameStrContains                             = "strcontains"
 	FuncNameTimeCmp                                 = "timecmp"
+	FuncNameTimestamp                               = "timestamp"
+	FuncNamePlanTimestamp                           = "plantimestamp"
 	FuncNameMarkAsRead                              = "mark_as_read"
 	FuncNameConstraintCheck                         = "constraint_check"
+	FuncNameSemverSatisfies                         = "semver_satisfies"
+	FuncNameSemverMax                               = "semver_max"
+	FuncNameSemverParse                             = "semver_parse"
+	FuncNameCidrContains                            = "cidrcontains"
+	FuncNameCidrAllocate                            = "cidr_allocate"
+	FuncNameTFEWorkspaceOutput                      = "tfe_workspace_output"
+	FuncNameJMESPath                                = "jmespath"
+	FuncNameSensitive                               = "sensitive"
 
 	sopsCacheName = "sopsCache"
 )
@@ -155,6 +170,8 @@ func createTerragruntEvalContext(ctx *ParsingContext, l log.Logger, configPath s
 		FuncNameGetEnv:                                  wrapStringSliceToStringAsFuncImpl(ctx, l, getEnvironmentVariable),
 		FuncNameRunCmd:                                  wrapStringSliceToStringAsFuncImpl(ctx, l, RunCommand),
 		FuncNameReadTerragruntConfig:                    readTerragruntConfigAsFuncImpl(ctx, l),
+		FuncNameReadUnitConfig:                          readUnitConfigAsFuncImpl(ctx, l),
+		FuncNameReadUnitLocals:                          readUnitLocalsAsFuncImpl(ctx, l),
 		FuncNameGetPlatform:                             wrapVoidToStringAsFuncImpl(ctx, l, getPlatform),
 		FuncNameGetRepoRoot:                             wrapVoidToStringAsFuncImpl(ctx, l, getRepoRoot),
 		FuncNameGetPathFromRepoRoot:                     wrapVoidToStringAsFuncImpl(ctx, l, getPathFromRepoRoot),
@@ -177,8 +194,19 @@ func createTerragruntEvalContext(ctx *ParsingContext, l log.Logger, configPath s
 		FuncNameGetDefaultRetryableErrors:               wrapVoidToStringSliceAsFuncImpl(ctx, l, getDefaultRetryableErrors),
 		FuncNameReadTfvarsFile:                          wrapStringSliceToStringAsFuncImpl(ctx, l, readTFVarsFile),
 		FuncNameGetWorkingDir:                           wrapVoidToStringAsFuncImpl(ctx, l, getWorkingDir),
+		FuncNameGetUnitName:                             wrapVoidToStringAsFuncImpl(ctx, l, GetUnitName),
+		FuncNameGetStackRoot:                            wrapVoidToStringAsFuncImpl(ctx, l, GetStackRoot),
+		FuncNameGetStackPath:                            wrapVoidToStringAsFuncImpl(ctx, l, GetStackPath),
 		FuncNameMarkAsRead:                              wrapStringSliceToStringAsFuncImpl(ctx, l, markAsRead),
 		FuncNameConstraintCheck:                         wrapStringSliceToBoolAsFuncImpl(ctx, ConstraintCheck),
+		FuncNameSemverSatisfies:                         wrapStringSliceToBoolAsFuncImpl(ctx, SemverSatisfies),
+		FuncNameSemverMax:                               semverMaxAsFuncImpl(),
+		FuncNameSemverParse:                             semverParseAsFuncImpl(),
+		FuncNameCidrContains:                            wrapStringSliceToBoolAsFuncImpl(ctx, CidrContains),
+		FuncNameCidrAllocate:                            cidrAllocateAsFuncImpl(),
+		FuncNameTFEWorkspaceOutput:                      tfeWorkspaceOutputAsFuncImpl(ctx, l),
+		FuncNameJMESPath:                                jmespathAsFuncImpl(),
+		FuncNameSensitive:                               sensitiveAsFuncImpl(ctx, l),
 
 		// Map with HCL functions introduced in Terraform after v0.15.3, since upgrade to a later version is not supported
 		// https://github.com/gruntwork-io/terragrunt/blob/master/go.mod#L22
@@ -186,6 +214,11 @@ func createTerragruntEvalContext(ctx *ParsingContext, l log.Logger, configPath s
 		FuncNameEndsWith:    wrapStringSliceToBoolAsFuncImpl(ctx, EndsWith),
 		FuncNameStrContains: wrapStringSliceToBoolAsFuncImpl(ctx, StrContains),
 		FuncNameTimeCmp:     wrapStringSliceToNumberAsFuncImpl(ctx, l, TimeCmp),
+
+		// Unlike the OpenTofu/Terraform `timestamp` function, the timestamp is captured once per run, so it's the same
+		// for all the units and evaluations of the configs.
+		FuncNameTimestamp:     wrapVoidToStringAsFuncImpl(ctx, l, GetTimestamp),
+		FuncNamePlanTimestamp: wrapVoidToStringAsFuncImpl(ctx, l, GetTimestamp),
 	}
 
 	functions := map[string]function.Function{}
@@ -194,6 +227,10 @@ func createTerragruntEvalContext(ctx *ParsingContext, l log.Logger, configPath s
 	maps.Copy(functions, terragruntFunctions)
 	maps.Copy(functions, ctx.PredefinedFunctions)
 
+	if err := addCustomFunctions(ctx, l, functions); err != nil {
+		return nil, err
+	}
+
 	evalCtx := &hcl.EvalContext{
 		Functions: functions,
 	}
@@ -211,6 +248,10 @@ func createTerragruntEvalContext(ctx *ParsingContext, l log.Logger, configPath s
 		evalCtx.Variables[MetadataValues] = *ctx.Values
 	}
 
+	if ctx.Imports != nil {
+		evalCtx.Variables[MetadataImport] = *ctx.Imports
+	}
+
 	if ctx.DecodedDependencies != nil {
 		evalCtx.Variables[MetadataDependency] = *ctx.DecodedDependencies
 	}
@@ -269,6 +310,80 @@ func getPathToRepoRoot(ctx *ParsingContext, l log.Logger) (string, error) {
 	return filepath.ToSlash(strings.TrimSpace(repoRootPathAbs)), nil
 }
 
+// GetUnitName returns the name of the current unit, which is the name of the directory where its Terragrunt
+// configuration file lives.
+func GetUnitName(ctx *ParsingContext, l log.Logger) (string, error) {
+	unitDir, err := GetTerragruntDir(ctx, l)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Base(unitDir), nil
+}
+
+// GetStackRoot returns the absolute path of the root of the stack the current unit belongs to, which is the directory
+// of the outermost config included by the unit. Units that don't include a config from a parent folder are rooted at
+// the directory Terragrunt runs from, or at their own directory when they live outside of it.
+func GetStackRoot(ctx *ParsingContext, l log.Logger) (string, error) {
+	unitDir, err := GetTerragruntDir(ctx, l)
+	if err != nil {
+		return "", err
+	}
+
+	var includes IncludeConfigs
+
+	if ctx.TrackInclude != nil {
+		if ctx.TrackInclude.Original != nil {
+			includes = append(includes, *ctx.TrackInclude.Original)
+		}
+
+		includes = append(includes, ctx.TrackInclude.CurrentList...)
+	}
+
+	stackRoot := ""
+
+	for _, include := range includes {
+		includeDir := filepath.Dir(include.Path)
+		if !filepath.IsAbs(includeDir) {
+			includeDir = util.JoinPath(unitDir, includeDir)
+		}
+
+		includeDir = filepath.Clean(includeDir)
+
+		if util.HasPathPrefix(unitDir, includeDir) && (stackRoot == "" || len(includeDir) < len(stackRoot)) {
+			stackRoot = includeDir
+		}
+	}
+
+	if stackRoot != "" {
+		return filepath.ToSlash(stackRoot), nil
+	}
+
+	if rootDir := ctx.TerragruntOptions.RootWorkingDir; rootDir != "" {
+		if rootDir, err := filepath.Abs(rootDir); err == nil && util.HasPathPrefix(unitDir, rootDir) {
+			return filepath.ToSlash(rootDir), nil
+		}
+	}
+
+	return unitDir, nil
+}
+
+// GetStackPath returns the path of the current unit relative to the root of its stack, e.g. `prod/us-east-1/vpc`. It
+// returns `.` for the unit at the root of the stack.
+func GetStackPath(ctx *ParsingContext, l log.Logger) (string, error) {
+	unitDir, err := GetTerragruntDir(ctx, l)
+	if err != nil {
+		return "", err
+	}
+
+	stackRoot, err := GetStackRoot(ctx, l)
+	if err != nil {
+		return "", err
+	}
+
+	return util.GetPathRelativeTo(unitDir, stackRoot)
+}
+
 // GetTerragruntDir returns the directory where the Terragrunt configuration file lives.
 func GetTerragruntDir(ctx *ParsingContext, l log.Logger) (string, error) {
 	path := ctx.TerragruntOptions.TerragruntConfigPath
@@ -1085,6 +1200,17 @@ func TimeCmp(ctx *ParsingContext, l log.Logger, args []string) (int64, error) {
 	}
 }
 
+// GetTimestamp returns the base timestamp of the run in the RFC 3339 format, which can be set with the
+// `--frozen-timestamp` flag to make the generated values reproducible.
+func GetTimestamp(ctx *ParsingContext, l log.Logger) (string, error) {
+	timestamp := ctx.TerragruntOptions.FrozenTimestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now().UTC()
+	}
+
+	return timestamp.Format(time.RFC3339), nil
+}
+
 // StrContains Implementation of Terraform's StrContains function
 func StrContains(ctx *ParsingContext, args []string) (bool, error) {
 	if len(args) == 0 {
@@ -1270,19 +1396,5 @@ func extractSopsErrors(err error) *errors.MultiError {
 
 // ConstraintCheck Implementation of Terraform's StartsWith function
 func ConstraintCheck(ctx *ParsingContext, args []string) (bool, error) {
-	if len(args) != matchedPats {
-		return false, errors.New(WrongNumberOfParamsError{Func: FuncNameConstraintCheck, Expected: "2", Actual: len(args)})
-	}
-
-	v, err := version.NewSemver(args[0])
-	if err != nil {
-		return false, errors.Errorf("invalid version %s: %w", args[0], err)
-	}
-
-	c, err := version.NewConstraint(args[1])
-	if err != nil {
-		return false, errors.Errorf("invalid constraint %s: %w", args[1], err)
-	}
-
-	return c.Check(v), nil
+	return checkVersionConstraint(FuncNameConstraintCheck, args)
 }
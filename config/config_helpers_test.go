@@ -1,17 +1,20 @@
 package config_test
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gruntwork-io/terragrunt/config"
 	"github.com/gruntwork-io/terragrunt/internal/ctyhelper"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
 	"github.com/gruntwork-io/terragrunt/test/helpers"
 	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
 	"github.com/stretchr/testify/assert"
@@ -655,6 +658,85 @@ func testGetTerragruntDir(t *testing.T, configPath string, expectedPath string)
 	assert.Equal(t, expectedPath, actualPath)
 }
 
+func TestStackFunctions(t *testing.T) {
+	t.Parallel()
+
+	rootDir := filepath.ToSlash(t.TempDir())
+	unitDir := filepath.Join(rootDir, "prod", "us-east-1", "vpc")
+	standaloneDir := filepath.Join(rootDir, "standalone")
+
+	require.NoError(t, os.MkdirAll(unitDir, 0755))
+	require.NoError(t, os.MkdirAll(standaloneDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "root.hcl"), []byte(`
+inputs = {
+  state_key = "${get_stack_path()}/tofu.tfstate"
+}
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(unitDir, config.DefaultTerragruntConfigPath), []byte(`
+include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+
+inputs = {
+  name       = get_unit_name()
+  stack_root = get_stack_root()
+  stack_path = get_stack_path()
+}
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(standaloneDir, config.DefaultTerragruntConfigPath), []byte(`
+inputs = {
+  stack_root = get_stack_root()
+  stack_path = get_stack_path()
+}
+`), 0644))
+
+	testCases := []struct {
+		expected   map[string]any
+		name       string
+		configPath string
+		rootDir    string
+	}{
+		{
+			name:       "included root",
+			configPath: filepath.Join(unitDir, config.DefaultTerragruntConfigPath),
+			expected: map[string]any{
+				"name":       "vpc",
+				"stack_root": rootDir,
+				"stack_path": "prod/us-east-1/vpc",
+				"state_key":  "prod/us-east-1/vpc/tofu.tfstate",
+			},
+		},
+		{
+			name:       "working dir",
+			configPath: filepath.Join(standaloneDir, config.DefaultTerragruntConfigPath),
+			rootDir:    rootDir,
+			expected:   map[string]any{"stack_root": rootDir, "stack_path": "standalone"},
+		},
+		{
+			name:       "outside of working dir",
+			configPath: filepath.Join(standaloneDir, config.DefaultTerragruntConfigPath),
+			rootDir:    unitDir,
+			expected:   map[string]any{"stack_root": standaloneDir, "stack_path": "."},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := terragruntOptionsForTest(t, tc.configPath)
+			opts.RootWorkingDir = tc.rootDir
+
+			l := logger.CreateLogger()
+			ctx := config.NewParsingContext(t.Context(), l, opts)
+
+			cfg, err := config.ParseConfigFile(ctx, l, tc.configPath, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, cfg.Inputs)
+		})
+	}
+}
+
 func terragruntOptionsForTest(t *testing.T, configPath string) *options.TerragruntOptions {
 	t.Helper()
 
@@ -1340,3 +1422,355 @@ func TestConstraintCheck(t *testing.T) {
 		})
 	}
 }
+
+func TestReadUnitConfig(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	writeReadUnitTestConfig(t, rootDir, "vpc", "")
+	writeReadUnitTestConfig(t, rootDir, "network", `
+locals {
+  name_prefix = "acme-${basename(get_terragrunt_dir())}"
+  cidr        = "10.0.0.0/16"
+}
+
+dependency "vpc" {
+  config_path  = "../vpc"
+  mock_outputs = { id = "vpc-mock" }
+}
+
+inputs = {
+  vpc_id = dependency.vpc.outputs.id
+}
+`)
+	configPath := writeReadUnitTestConfig(t, rootDir, "app", `
+locals {
+  network = read_unit_config("../network")
+}
+
+inputs = {
+  cidr        = local.network.locals.cidr
+  vpc_id      = local.network.inputs.vpc_id
+  name_prefix = read_unit_locals("../network").name_prefix
+}
+`)
+
+	l := createLogger()
+	ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, configPath))
+
+	cfg, err := config.ParseConfigFile(ctx, l, configPath, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"cidr":        "10.0.0.0/16",
+		"vpc_id":      "vpc-mock",
+		"name_prefix": "acme-network",
+	}, cfg.Inputs)
+}
+
+func TestReadUnitConfigCycle(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	writeReadUnitTestConfig(t, rootDir, "a", `locals { b = read_unit_locals("../b") }`)
+	configPath := writeReadUnitTestConfig(t, rootDir, "b", `locals { a = read_unit_locals("../a") }`)
+
+	l := createLogger()
+	ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, configPath))
+
+	_, err := config.ParseConfigFile(ctx, l, configPath, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Found a cycle in the units read with read_unit_config or read_unit_locals")
+}
+
+func writeReadUnitTestConfig(t *testing.T, rootDir, name, content string) string {
+	t.Helper()
+
+	unitDir := filepath.Join(rootDir, name)
+	configPath := filepath.Join(unitDir, config.DefaultTerragruntConfigPath)
+
+	require.NoError(t, os.MkdirAll(unitDir, 0755))
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+
+	return configPath
+}
+
+func TestSemverMax(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		err      string
+		value    string
+		versions []string
+	}{
+		{versions: []string{"1.2.3", "v1.10.0", "1.9.9"}, value: "v1.10.0"},
+		{versions: []string{"2.0.0-beta.1", "2.0.0", "1.99.0"}, value: "2.0.0"},
+		{versions: []string{"0.1"}, value: "0.1"},
+		{versions: []string{}, err: "semver_max requires at least one version"},
+		{versions: []string{"1.0.0", "latest"}, err: "invalid version latest"},
+	}
+
+	for id, tc := range testCases {
+		t.Run(fmt.Sprintf("%v %v", id, tc.versions), func(t *testing.T) {
+			t.Parallel()
+
+			actual, err := config.SemverMax(tc.versions)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.value, actual)
+		})
+	}
+}
+
+func TestSemverFunctions(t *testing.T) {
+	t.Parallel()
+
+	configPath := writeReadUnitTestConfig(t, t.TempDir(), "app", `
+locals {
+  provider_version = semver_max(["5.31.0", "4.67.0", "5.9.1"])
+  parsed           = semver_parse("v1.2.3-beta.1+build.5")
+}
+
+inputs = {
+  provider_version = local.provider_version
+  is_v5            = semver_satisfies(local.provider_version, "~> 5.0")
+  is_v4            = semver_satisfies(local.provider_version, "< 5.0")
+  major            = local.parsed.major
+  minor            = local.parsed.minor
+  patch            = local.parsed.patch
+  prerelease       = local.parsed.prerelease
+  metadata         = local.parsed.metadata
+  version          = local.parsed.version
+}
+`)
+
+	l := createLogger()
+	ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, configPath))
+
+	cfg, err := config.ParseConfigFile(ctx, l, configPath, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"provider_version": "5.31.0",
+		"is_v5":            true,
+		"is_v4":            false,
+		"major":            float64(1),
+		"minor":            float64(2),
+		"patch":            float64(3),
+		"prerelease":       "beta.1",
+		"metadata":         "build.5",
+		"version":          "1.2.3-beta.1+build.5",
+	}, cfg.Inputs)
+}
+
+func TestCidrContains(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		err   string
+		args  []string
+		value bool
+	}{
+		{args: []string{"10.0.0.0/16", "10.0.1.0/24"}, value: true},
+		{args: []string{"10.0.0.0/16", "10.0.255.255"}, value: true},
+		{args: []string{"10.0.0.0/16", "10.1.0.0/24"}},
+		{args: []string{"10.0.0.0/16", "10.0.0.0/8"}},
+		{args: []string{"fd00::/8", "fd00:1::/32"}, value: true},
+		{args: []string{"10.0.0.0/16", "fd00::1"}, err: "address family mismatch"},
+		{args: []string{"10.0.0.0", "10.0.0.1"}, err: "invalid CIDR expression"},
+	}
+
+	for id, tc := range testCases {
+		t.Run(fmt.Sprintf("%v %v", id, tc.args), func(t *testing.T) {
+			t.Parallel()
+
+			l := logger.CreateLogger()
+			ctx := config.NewParsingContext(t.Context(), l, terragruntOptionsForTest(t, ""))
+
+			actual, err := config.CidrContains(ctx, tc.args)
+			if tc.err != "" {
+				require.ErrorContains(t, err, tc.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.value, actual)
+		})
+	}
+}
+
+func TestCidrAllocate(t *testing.T) {
+	t.Parallel()
+
+	subnets, err := config.CidrAllocate("10.0.0.0/16", 8, []string{"vpc", "app", "db"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"vpc": "10.0.0.0/24",
+		"app": "10.0.1.0/24",
+		"db":  "10.0.2.0/24",
+	}, subnets)
+
+	_, err = config.CidrAllocate("10.0.0.0/30", 1, []string{"a", "b", "c"})
+	require.ErrorContains(t, err, "can only be split into 2 subnets")
+
+	_, err = config.CidrAllocate("10.0.0.0/30", 4, []string{"a"})
+	require.ErrorContains(t, err, "insufficient address space")
+
+	_, err = config.CidrAllocate("10.0.0.0/16", 8, []string{"a", "a"})
+	require.ErrorContains(t, err, "duplicate name a")
+}
+
+func TestNetworkFunctions(t *testing.T) {
+	t.Parallel()
+
+	configPath := writeReadUnitTestConfig(t, t.TempDir(), "app", `
+locals {
+  supernet = "10.0.0.0/16"
+  subnets  = cidr_allocate(local.supernet, 4, ["network", "app", "db"])
+}
+
+inputs = {
+  app_cidr       = local.subnets.app
+  db_cidr        = local.subnets["db"]
+  gateway        = cidrhost(local.subnets.app, 1)
+  netmask        = cidrnetmask(local.subnets.app)
+  first_subnet   = cidrsubnet(local.supernet, 8, 0)
+  public_subnets = cidrsubnets(local.subnets.network, 4, 4)
+  in_supernet    = cidrcontains(local.supernet, local.subnets.db)
+}
+`)
+
+	l := createLogger()
+	ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, configPath))
+
+	cfg, err := config.ParseConfigFile(ctx, l, configPath, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"app_cidr":       "10.0.16.0/20",
+		"db_cidr":        "10.0.32.0/20",
+		"gateway":        "10.0.16.1",
+		"netmask":        "255.255.240.0",
+		"first_subnet":   "10.0.0.0/24",
+		"public_subnets": []any{"10.0.0.0/24", "10.0.1.0/24"},
+		"in_supernet":    true,
+	}, cfg.Inputs)
+}
+
+func TestJMESPath(t *testing.T) {
+	t.Parallel()
+
+	configPath := writeReadUnitTestConfig(t, t.TempDir(), "app", `
+locals {
+  vpc = {
+    subnets = {
+      a = { id = "subnet-a", public = true, az = "us-east-1a" }
+      b = { id = "subnet-b", public = false, az = "us-east-1b" }
+      c = { id = "subnet-c", public = true, az = "us-east-1c" }
+    }
+    tags = { env = "prod", owner = null }
+  }
+}
+
+inputs = {
+  public_subnet_ids = jmespath("values(subnets)[?public].id | sort(@)", local.vpc)
+  subnet_azs        = jmespath("subnets.*.{id: id, az: az}", local.vpc)
+  env               = jmespath("tags.env", local.vpc)
+  owner             = jmespath("tags.owner", local.vpc)
+  missing           = jmespath("tags.missing", local.vpc)
+  subnet_count      = jmespath("length(keys(subnets))", local.vpc)
+}
+`)
+
+	l := createLogger()
+	ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, configPath))
+
+	cfg, err := config.ParseConfigFile(ctx, l, configPath, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []any{"subnet-a", "subnet-c"}, cfg.Inputs["public_subnet_ids"])
+	assert.ElementsMatch(t, []any{
+		map[string]any{"id": "subnet-a", "az": "us-east-1a"},
+		map[string]any{"id": "subnet-b", "az": "us-east-1b"},
+		map[string]any{"id": "subnet-c", "az": "us-east-1c"},
+	}, cfg.Inputs["subnet_azs"])
+	assert.Equal(t, "prod", cfg.Inputs["env"])
+	assert.Nil(t, cfg.Inputs["owner"])
+	assert.Nil(t, cfg.Inputs["missing"])
+	assert.InEpsilon(t, float64(3), cfg.Inputs["subnet_count"].(float64), 0.0000000001)
+
+	_, err = config.JMESPath("subnets[?", cty.EmptyObjectVal)
+	require.ErrorContains(t, err, "invalid JMESPath expression")
+}
+
+func TestSensitive(t *testing.T) {
+	t.Parallel()
+
+	configPath := writeReadUnitTestConfig(t, t.TempDir(), "app", `
+locals {
+  db_password = sensitive("hunter2")
+  api_keys    = sensitive({ primary = "key-1", secondary = "key-2" })
+  pin         = sensitive({ code = "123" })
+}
+
+generate "provider" {
+  path      = "provider.tf"
+  if_exists = "overwrite"
+  contents  = "password = \"${local.db_password}\""
+}
+
+inputs = {
+  db_password = local.db_password
+  api_key     = local.api_keys.primary
+}
+`)
+
+	opts := mockOptionsForTestWithConfigPath(t, configPath)
+
+	var logs bytes.Buffer
+
+	l := createLogger()
+	l.SetOptions(log.WithOutput(&logs))
+
+	ctx := config.NewParsingContext(t.Context(), l, opts)
+
+	cfg, err := config.ParseConfigFile(ctx, l, configPath, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"db_password": "hunter2", "api_key": "key-1"}, cfg.Inputs)
+	assert.Equal(t, `password = "hunter2"`, cfg.GenerateConfigs["provider"].Contents)
+
+	assert.Equal(t, "db_password=[REDACTED] api_keys=[REDACTED],[REDACTED] pin=123", opts.Redactor.Redact("db_password=hunter2 api_keys=key-1,key-2 pin=123"))
+	assert.Contains(t, logs.String(), "The sensitive value of the value passed to the sensitive function in "+configPath+" at code is shorter than 4 characters")
+}
+
+func TestFrozenTimestamp(t *testing.T) {
+	t.Parallel()
+
+	configPath := writeReadUnitTestConfig(t, t.TempDir(), "app", `
+locals {
+  created_at = timestamp()
+}
+
+inputs = {
+  created_at = local.created_at
+  same       = timestamp() == plantimestamp()
+  expires_at = timeadd(timestamp(), "24h")
+  date       = formatdate("YYYY-MM-DD", timestamp())
+}
+`)
+
+	opts := mockOptionsForTestWithConfigPath(t, configPath)
+	opts.FrozenTimestamp = time.Date(2024, 2, 29, 23, 30, 0, 0, time.UTC)
+
+	l := createLogger()
+	ctx := config.NewParsingContext(t.Context(), l, opts)
+
+	cfg, err := config.ParseConfigFile(ctx, l, configPath, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"created_at": "2024-02-29T23:30:00Z",
+		"same":       true,
+		"expires_at": "2024-03-01T23:30:00Z",
+		"date":       "2024-02-29",
+	}, cfg.Inputs)
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"github.com/gruntwork-io/terragrunt/internal/ctyhelper"
 	"github.com/gruntwork-io/terragrunt/internal/remotestate"
@@ -39,6 +40,14 @@ const (
 	EngineBlock
 	ExcludeBlock
 	ErrorsBlock
+	TagsAttr
+	ParallelismLimitsBlock
+	WeightAttr
+	FailurePolicyBlock
+	RunTimeoutAttr
+	QueuePriorityAttr
+	ApplyWindowsBlock
+	MutexGroupsAttr
 )
 
 // terragruntIncludeMultiple is a struct that can be used to only decode the include block with labels.
@@ -65,6 +74,54 @@ type terragruntErrors struct {
 	Remain hcl.Body      `hcl:",remain"`
 }
 
+// terragruntTags is a struct that can be used to only decode the tags attribute.
+type terragruntTags struct {
+	Remain hcl.Body `hcl:",remain"`
+	Tags   []string `hcl:"tags,optional"`
+}
+
+// terragruntMutexGroups is a struct that can be used to only decode the mutex_groups attribute.
+type terragruntMutexGroups struct {
+	Remain      hcl.Body `hcl:",remain"`
+	MutexGroups []string `hcl:"mutex_groups,optional"`
+}
+
+// terragruntWeight is a struct that can be used to only decode the weight attribute.
+type terragruntWeight struct {
+	Remain hcl.Body `hcl:",remain"`
+	Weight *int     `hcl:"weight,optional"`
+}
+
+// terragruntRunTimeout is a struct that can be used to only decode the run_timeout_sec attribute.
+type terragruntRunTimeout struct {
+	Remain        hcl.Body `hcl:",remain"`
+	RunTimeoutSec *int     `hcl:"run_timeout_sec,optional"`
+}
+
+// terragruntQueuePriority is a struct that can be used to only decode the queue_priority attribute.
+type terragruntQueuePriority struct {
+	Remain        hcl.Body `hcl:",remain"`
+	QueuePriority *int     `hcl:"queue_priority,optional"`
+}
+
+// terragruntFailurePolicy is a struct that can be used to only decode the failure_policy block.
+type terragruntFailurePolicy struct {
+	FailurePolicy *FailurePolicyConfig `hcl:"failure_policy,block"`
+	Remain        hcl.Body             `hcl:",remain"`
+}
+
+// terragruntParallelismLimits is a struct that can be used to only decode the parallelism_limit blocks.
+type terragruntParallelismLimits struct {
+	Remain            hcl.Body          `hcl:",remain"`
+	ParallelismLimits ParallelismLimits `hcl:"parallelism_limit,block"`
+}
+
+// terragruntApplyWindows is a struct that can be used to only decode the apply_window blocks.
+type terragruntApplyWindows struct {
+	Remain       hcl.Body     `hcl:",remain"`
+	ApplyWindows ApplyWindows `hcl:"apply_window,block"`
+}
+
 // terragruntTerraform is a struct that can be used to only decode the terraform block.
 type terragruntTerraform struct {
 	Terraform *TerraformConfig `hcl:"terraform,block"`
@@ -140,20 +197,6 @@ func DecodeBaseBlocks(ctx *ParsingContext, l log.Logger, file *hclparse.File, in
 		return nil, err
 	}
 
-	// Decode just the `include` and `import` blocks, and verify that it's allowed here
-	terragruntIncludeList, err := decodeAsTerragruntInclude(
-		file,
-		evalParsingContext,
-	)
-	if err != nil {
-		errs = errs.Append(err)
-	}
-
-	trackInclude, err := getTrackInclude(ctx, terragruntIncludeList, includeFromChild)
-	if err != nil {
-		errs = errs.Append(err)
-	}
-
 	// set feature flags
 	tgFlags := terragruntFeatureFlags{}
 	// load default feature flags
@@ -174,14 +217,62 @@ func DecodeBaseBlocks(ctx *ParsingContext, l log.Logger, file *hclparse.File, in
 		errs = errs.Append(flagErrs)
 	}
 
-	flagsAsCtyVal, err := flagsAsCty(ctx, tgFlags.FeatureFlags)
+	flagsAsCtyVal, err := flagsAsCty(ctx, l, tgFlags.FeatureFlags)
+	if err != nil {
+		errs = errs.Append(err)
+	}
+
+	// Evaluate the locals referenced by the conditions of the include blocks, which can also reference feature flags.
+	includeCtx := ctx.WithFeatures(&flagsAsCtyVal)
+
+	conditionLocals, err := evaluateIncludeConditionLocals(includeCtx, l, file)
 	if err != nil {
 		errs = errs.Append(err)
 	}
 
+	if conditionLocals != nil {
+		includeCtx = includeCtx.WithLocals(conditionLocals)
+	}
+
+	includeEvalContext, err := createTerragruntEvalContext(includeCtx, l, file.ConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode just the `include` blocks, skip the disabled ones, and verify that it's allowed here
+	terragruntIncludeList, err := decodeAsTerragruntInclude(
+		file,
+		includeEvalContext,
+	)
+	if err != nil {
+		errs = errs.Append(err)
+	}
+
+	trackInclude, err := getTrackInclude(ctx, filterEnabledIncludes(terragruntIncludeList), includeFromChild)
+	if err != nil {
+		errs = errs.Append(err)
+	}
+
+	// Fetch the documents of the import blocks, so that they can be referenced in locals.
+	imports, err := evaluateImports(ctx.WithTrackInclude(trackInclude).WithFeatures(&flagsAsCtyVal), l, file)
+	if err != nil {
+		errs = errs.Append(err)
+	}
+
+	// Read the defaults of the unit, whose locals are added to the locals the unit doesn't declare.
+	defaults, err := readUnitDefaults(ctx, l, file)
+	if err != nil {
+		errs = errs.Append(err)
+	}
+
+	var defaultLocals map[string]cty.Value
+	if defaults != nil {
+		defaultLocals = defaults.Locals
+	}
+
 	// Evaluate all the expressions in the locals block separately and generate the variables list to use in the
 	// evaluation ctx.
-	locals, err := EvaluateLocalsBlock(ctx.WithTrackInclude(trackInclude).WithFeatures(&flagsAsCtyVal), l, file)
+	locals, err := evaluateLocalsBlockWithDefaults(ctx.WithTrackInclude(trackInclude).WithFeatures(&flagsAsCtyVal).WithImports(imports), l, file, defaultLocals)
 	if err != nil {
 		errs = errs.Append(err)
 	}
@@ -195,10 +286,12 @@ func DecodeBaseBlocks(ctx *ParsingContext, l log.Logger, file *hclparse.File, in
 		TrackInclude: trackInclude,
 		Locals:       &localsAsCtyVal,
 		FeatureFlags: &flagsAsCtyVal,
+		Imports:      imports,
+		defaults:     defaults,
 	}, errs.ErrorOrNil()
 }
 
-func flagsAsCty(ctx *ParsingContext, tgFlags FeatureFlags) (cty.Value, error) {
+func flagsAsCty(ctx *ParsingContext, l log.Logger, tgFlags FeatureFlags) (cty.Value, error) {
 	// extract all flags in map by name
 	flagByName := map[string]*FeatureFlag{}
 	for _, flag := range tgFlags {
@@ -212,21 +305,38 @@ func flagsAsCty(ctx *ParsingContext, tgFlags FeatureFlags) (cty.Value, error) {
 
 	errs := &errors.MultiError{}
 
-	for _, flag := range tgFlags {
-		if _, exists := evaluatedFlags[flag.Name]; !exists {
-			if flag.Default == nil {
-				errs = errs.Append(fmt.Errorf("feature flag %s does not have a default value in %s", flag.Name, ctx.TerragruntOptions.TerragruntConfigPath))
-				continue
-			}
+	var sourceFlags map[string]cty.Value
+	if len(tgFlags) > 0 {
+		sourceFlags = featureFlagSourceValues(ctx, l, ctx.TerragruntOptions)
+	}
 
-			contextFlag, err := flagToCtyValue(flag.Name, *flag.Default)
+	for _, flag := range tgFlags {
+		if _, exists := evaluatedFlags[flag.Name]; exists {
+			continue
+		}
 
+		if value, found := sourceFlags[flag.Name]; found {
+			contextFlag, err := sourceFlagToCty(flag, value)
 			if err != nil {
 				return cty.NilVal, err
 			}
 
 			evaluatedFlags[flag.Name] = contextFlag
+
+			continue
+		}
+
+		if flag.Default == nil {
+			errs = errs.Append(fmt.Errorf("feature flag %s does not have a default value in %s", flag.Name, ctx.TerragruntOptions.TerragruntConfigPath))
+			continue
 		}
+
+		contextFlag, err := flagToCtyValue(flag.Name, *flag.Default)
+		if err != nil {
+			return cty.NilVal, err
+		}
+
+		evaluatedFlags[flag.Name] = contextFlag
 	}
 
 	flagsAsCtyVal, err := convertValuesMapToCtyVal(evaluatedFlags)
@@ -308,10 +418,11 @@ func PartialParseConfigFile(ctx *ParsingContext, l log.Logger, configPath string
 }
 
 // TerragruntConfigFromPartialConfig is a wrapper of PartialParseConfigString which checks for cached configs.
-// filename, configString, includeFromChild and decodeList are used for the cache key,
-// by getting the default value (%#v) through fmt.
+// filename, configString, includeFromChild, decodeList and the units extending the config, if any, are used for the
+// cache key, by getting the default value (%#v) through fmt. An extended unit is evaluated in the folder of the unit
+// extending it, so it is cached separately for each extending unit.
 func TerragruntConfigFromPartialConfig(ctx *ParsingContext, l log.Logger, file *hclparse.File, includeFromChild *IncludeConfig) (*TerragruntConfig, error) {
-	var cacheKey = fmt.Sprintf("%#v-%#v-%#v-%#v", file.ConfigPath, file.Content(), includeFromChild, ctx.PartialParseDecodeList)
+	var cacheKey = fmt.Sprintf("%#v-%#v-%#v-%#v-%#v", file.ConfigPath, file.Content(), includeFromChild, ctx.PartialParseDecodeList, ctx.ExtendedUnits)
 
 	terragruntConfigCache := cache.ContextCache[*TerragruntConfig](ctx, TerragruntConfigCacheContextKey)
 	if ctx.TerragruntOptions.UsePartialParseConfigCache {
@@ -352,6 +463,13 @@ func TerragruntConfigFromPartialConfig(ctx *ParsingContext, l log.Logger, file *
 //   - FeatureFlagsBlock: Parses the `feature` block in the config
 //   - EngineBlock: Parses the `engine` block in the config
 //   - ExcludeBlock : Parses the `exclude` block in the config
+//   - ParallelismLimitsBlock: Parses the `parallelism_limit` blocks in the config
+//   - WeightAttr: Parses the `weight` attribute in the config
+//   - FailurePolicyBlock: Parses the `failure_policy` block in the config
+//   - RunTimeoutAttr: Parses the `run_timeout_sec` attribute in the config
+//   - QueuePriorityAttr: Parses the `queue_priority` attribute in the config
+//   - ApplyWindowsBlock: Parses the `apply_window` blocks in the config
+//   - MutexGroupsAttr: Parses the `mutex_groups` attribute in the config
 //
 // Note that the following blocks are always decoded:
 // - locals
@@ -370,6 +488,10 @@ func PartialParseConfigString(ctx *ParsingContext, l log.Logger, configPath, con
 func PartialParseConfig(ctx *ParsingContext, l log.Logger, file *hclparse.File, includeFromChild *IncludeConfig) (*TerragruntConfig, error) {
 	errs := &errors.MultiError{}
 
+	if err := validateConfigSchema(ctx, l, file); err != nil {
+		return nil, err
+	}
+
 	ctx = ctx.WithTrackInclude(nil)
 
 	// read unit files and add to context
@@ -380,6 +502,13 @@ func PartialParseConfig(ctx *ParsingContext, l log.Logger, file *hclparse.File,
 
 	ctx = ctx.WithValues(unitValues)
 
+	unitValues, err = evaluateValueBlocks(ctx, l, file)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = ctx.WithValues(unitValues)
+
 	// Decode just the Base blocks. See the function docs for DecodeBaseBlocks for more info on what base blocks are.
 	// Initialize evaluation ctx extensions from base blocks.
 	baseBlocks, err := DecodeBaseBlocks(ctx, l, file, includeFromChild)
@@ -390,6 +519,7 @@ func PartialParseConfig(ctx *ParsingContext, l log.Logger, file *hclparse.File,
 	if baseBlocks != nil {
 		ctx = ctx.WithTrackInclude(baseBlocks.TrackInclude)
 		ctx = ctx.WithFeatures(baseBlocks.FeatureFlags)
+		ctx = ctx.WithImports(baseBlocks.Imports)
 		ctx = ctx.WithLocals(baseBlocks.Locals)
 	}
 
@@ -418,6 +548,10 @@ func PartialParseConfig(ctx *ParsingContext, l log.Logger, file *hclparse.File,
 				return nil, err
 			}
 
+			if err := decoded.Dependencies.ExpandGlobs(ctx.TerragruntOptions.TerragruntConfigPath); err != nil {
+				return nil, err
+			}
+
 			// If we already decoded some dependencies, merge them in. Otherwise, set as the new list.
 			if output.Dependencies != nil {
 				output.Dependencies.Merge(decoded.Dependencies)
@@ -546,7 +680,12 @@ func PartialParseConfig(ctx *ParsingContext, l log.Logger, file *hclparse.File,
 			}
 
 			if decoded.Inputs != nil {
-				inputs, err := ctyhelper.ParseCtyValueToMap(*decoded.Inputs)
+				decrypted, err := decryptEncryptedValues(ctx, l, *decoded.Inputs)
+				if err != nil {
+					return nil, err
+				}
+
+				inputs, err := ctyhelper.ParseCtyValueToMap(decrypted)
 				if err != nil {
 					return nil, err
 				}
@@ -636,11 +775,107 @@ func PartialParseConfig(ctx *ParsingContext, l log.Logger, file *hclparse.File,
 				output.Errors = decoded.Errors
 			}
 
+		case TagsAttr:
+			decoded := terragruntTags{}
+			if err := file.Decode(&decoded, evalParsingContext); err != nil {
+				return nil, err
+			}
+
+			if decoded.Tags != nil {
+				output.Tags = util.MergeStringSlices(output.Tags, decoded.Tags)
+			}
+
+		case ParallelismLimitsBlock:
+			decoded := terragruntParallelismLimits{}
+			if err := file.Decode(&decoded, evalParsingContext); err != nil {
+				return nil, err
+			}
+
+			if err := validateParallelismLimits(file.ConfigPath, decoded.ParallelismLimits); err != nil {
+				return nil, err
+			}
+
+			output.ParallelismLimits = output.ParallelismLimits.Merge(decoded.ParallelismLimits)
+
+		case WeightAttr:
+			decoded := terragruntWeight{}
+			if err := file.Decode(&decoded, evalParsingContext); err != nil {
+				return nil, err
+			}
+
+			if decoded.Weight != nil {
+				output.Weight = decoded.Weight
+			}
+
+		case RunTimeoutAttr:
+			decoded := terragruntRunTimeout{}
+			if err := file.Decode(&decoded, evalParsingContext); err != nil {
+				return nil, err
+			}
+
+			if decoded.RunTimeoutSec != nil {
+				output.RunTimeoutSec = decoded.RunTimeoutSec
+			}
+
+		case QueuePriorityAttr:
+			decoded := terragruntQueuePriority{}
+			if err := file.Decode(&decoded, evalParsingContext); err != nil {
+				return nil, err
+			}
+
+			if decoded.QueuePriority != nil {
+				output.QueuePriority = decoded.QueuePriority
+			}
+
+		case FailurePolicyBlock:
+			decoded := terragruntFailurePolicy{}
+			if err := file.Decode(&decoded, evalParsingContext); err != nil {
+				return nil, err
+			}
+
+			if decoded.FailurePolicy != nil {
+				if err := decoded.FailurePolicy.Validate(file.ConfigPath); err != nil {
+					return nil, err
+				}
+
+				output.FailurePolicy = decoded.FailurePolicy
+			}
+
+		case ApplyWindowsBlock:
+			decoded := terragruntApplyWindows{}
+			if err := file.Decode(&decoded, evalParsingContext); err != nil {
+				return nil, err
+			}
+
+			if err := validateApplyWindows(file.ConfigPath, decoded.ApplyWindows); err != nil {
+				return nil, err
+			}
+
+			output.ApplyWindows = output.ApplyWindows.Merge(decoded.ApplyWindows)
+
+		case MutexGroupsAttr:
+			decoded := terragruntMutexGroups{}
+			if err := file.Decode(&decoded, evalParsingContext); err != nil {
+				return nil, err
+			}
+
+			if decoded.MutexGroups != nil {
+				output.MutexGroups = util.MergeStringSlices(output.MutexGroups, decoded.MutexGroups)
+			}
+
 		default:
 			return nil, InvalidPartialBlockName{decode}
 		}
 	}
 
+	// The environment attributes are merged in from the includes like the other sections, the overlay being layered
+	// onto the unit config once the includes are merged in.
+	if needsEnvironmentOverlay(ctx.PartialParseDecodeList) {
+		if err := decodeEnvironment(file, evalParsingContext, output); err != nil {
+			return nil, err
+		}
+	}
+
 	errsContainsIncludeErr := false
 
 	for _, err := range errs.WrappedErrors() {
@@ -662,6 +897,24 @@ func PartialParseConfig(ctx *ParsingContext, l log.Logger, file *hclparse.File,
 		output = config
 	}
 
+	if includeFromChild == nil && errs.ErrorOrNil() == nil {
+		output, err = extendUnitConfig(ctx, l, file, output, true)
+		if err != nil {
+			errs = errs.Append(err)
+		}
+	}
+
+	if includeFromChild == nil && errs.ErrorOrNil() == nil && needsEnvironmentOverlay(ctx.PartialParseDecodeList) {
+		output, err = applyEnvironmentOverlay(ctx, l, file.ConfigPath, output, true)
+		if err != nil {
+			errs = errs.Append(err)
+		}
+	}
+
+	if includeFromChild == nil && errs.ErrorOrNil() == nil && baseBlocks != nil && slices.Contains(ctx.PartialParseDecodeList, TerragruntInputs) {
+		baseBlocks.defaults.applyDefaultInputs(output)
+	}
+
 	if errs.ErrorOrNil() != nil {
 		return output, errs.ErrorOrNil()
 	}
@@ -671,7 +924,7 @@ func PartialParseConfig(ctx *ParsingContext, l log.Logger, file *hclparse.File,
 
 // processExcludes evaluate exclude blocks and merge them into the config.
 func processExcludes(ctx *ParsingContext, l log.Logger, config *TerragruntConfig, file *hclparse.File) (*TerragruntConfig, error) {
-	flagsAsCtyVal, err := flagsAsCty(ctx, config.FeatureFlags)
+	flagsAsCtyVal, err := flagsAsCty(ctx, l, config.FeatureFlags)
 	if err != nil {
 		return nil, err
 	}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/strict/controls"
 	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -388,3 +389,158 @@ dependency "ec2" {
 	require.NoError(t, err)
 	assert.Len(t, terragruntConfig.Dependencies.Paths, 1)
 }
+
+func TestPartialParseStrictConfigSchema(t *testing.T) {
+	t.Parallel()
+
+	cfg := `
+remote_sate {
+  backend = "s3"
+}
+
+dependencies {
+  paths = ["../vpc"]
+}
+`
+
+	l := logger.CreateLogger()
+
+	ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTest(t)).WithDecodeList(config.DependenciesBlock)
+	_, err := config.PartialParseConfigString(ctx, l, config.DefaultTerragruntConfigPath, cfg, nil)
+	require.NoError(t, err)
+
+	opts := mockOptionsForTest(t)
+	require.NoError(t, opts.StrictControls.EnableControl(controls.StrictConfigSchema))
+
+	ctx = config.NewParsingContext(t.Context(), l, opts).WithDecodeList(config.DependenciesBlock)
+	_, err = config.PartialParseConfigString(ctx, l, config.DefaultTerragruntConfigPath, cfg, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `Blocks of type "remote_sate" are not expected here. Did you mean "remote_state"?`)
+}
+
+func TestPartialParseTags(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	writeExtendsTestUnit(t, rootDir, ".", `tags = ["prod", "networking"]`)
+	configPath := writeExtendsTestUnit(t, rootDir, "vpc", `
+include "root" {
+  path = find_in_parent_folders("terragrunt.hcl")
+}
+
+tags = ["team-core", "prod"]
+`)
+
+	l := logger.CreateLogger()
+	ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, configPath))
+
+	terragruntConfig, err := config.ParseConfigFile(ctx, l, configPath, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"prod", "networking", "team-core"}, terragruntConfig.Tags)
+
+	terragruntConfig, err = config.PartialParseConfigFile(ctx.WithDecodeList(config.TagsAttr), l, configPath, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"prod", "networking", "team-core"}, terragruntConfig.Tags)
+}
+
+func TestPartialParseWeight(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	writeExtendsTestUnit(t, rootDir, ".", `weight = 60`)
+	configPath := writeExtendsTestUnit(t, rootDir, "vpc", `
+include "root" {
+  path = find_in_parent_folders("terragrunt.hcl")
+}
+
+weight = 300
+`)
+	otherPath := writeExtendsTestUnit(t, rootDir, "app", `
+include "root" {
+  path = find_in_parent_folders("terragrunt.hcl")
+}
+`)
+
+	l := logger.CreateLogger()
+	ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, configPath)).WithDecodeList(config.WeightAttr)
+
+	terragruntConfig, err := config.PartialParseConfigFile(ctx, l, configPath, nil)
+	require.NoError(t, err)
+	require.NotNil(t, terragruntConfig.Weight)
+	assert.Equal(t, 300, *terragruntConfig.Weight)
+
+	ctx = config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, otherPath)).WithDecodeList(config.WeightAttr)
+
+	terragruntConfig, err = config.PartialParseConfigFile(ctx, l, otherPath, nil)
+	require.NoError(t, err)
+	require.NotNil(t, terragruntConfig.Weight)
+	assert.Equal(t, 60, *terragruntConfig.Weight)
+}
+
+func TestPartialParseRunTimeout(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	writeExtendsTestUnit(t, rootDir, ".", `run_timeout_sec = 1800`)
+	configPath := writeExtendsTestUnit(t, rootDir, "eks", `
+include "root" {
+  path = find_in_parent_folders("terragrunt.hcl")
+}
+
+run_timeout_sec = 3600
+`)
+	otherPath := writeExtendsTestUnit(t, rootDir, "app", `
+include "root" {
+  path = find_in_parent_folders("terragrunt.hcl")
+}
+`)
+
+	l := logger.CreateLogger()
+	ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, configPath)).WithDecodeList(config.RunTimeoutAttr)
+
+	terragruntConfig, err := config.PartialParseConfigFile(ctx, l, configPath, nil)
+	require.NoError(t, err)
+	require.NotNil(t, terragruntConfig.RunTimeoutSec)
+	assert.Equal(t, 3600, *terragruntConfig.RunTimeoutSec)
+
+	ctx = config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, otherPath)).WithDecodeList(config.RunTimeoutAttr)
+
+	terragruntConfig, err = config.PartialParseConfigFile(ctx, l, otherPath, nil)
+	require.NoError(t, err)
+	require.NotNil(t, terragruntConfig.RunTimeoutSec)
+	assert.Equal(t, 1800, *terragruntConfig.RunTimeoutSec)
+}
+
+func TestPartialParseQueuePriority(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	writeExtendsTestUnit(t, rootDir, ".", `queue_priority = 5`)
+	configPath := writeExtendsTestUnit(t, rootDir, "dns", `
+include "root" {
+  path = find_in_parent_folders("terragrunt.hcl")
+}
+
+queue_priority = 10
+`)
+	otherPath := writeExtendsTestUnit(t, rootDir, "app", `
+include "root" {
+  path = find_in_parent_folders("terragrunt.hcl")
+}
+`)
+
+	l := logger.CreateLogger()
+	ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, configPath)).WithDecodeList(config.QueuePriorityAttr)
+
+	terragruntConfig, err := config.PartialParseConfigFile(ctx, l, configPath, nil)
+	require.NoError(t, err)
+	require.NotNil(t, terragruntConfig.QueuePriority)
+	assert.Equal(t, 10, *terragruntConfig.QueuePriority)
+
+	ctx = config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, otherPath)).WithDecodeList(config.QueuePriorityAttr)
+
+	terragruntConfig, err = config.PartialParseConfigFile(ctx, l, otherPath, nil)
+	require.NoError(t, err)
+	require.NotNil(t, terragruntConfig.QueuePriority)
+	assert.Equal(t, 5, *terragruntConfig.QueuePriority)
+}
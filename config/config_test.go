@@ -241,6 +241,23 @@ retryable_errors = [
 	}
 }
 
+func TestParseTerragruntConfigWeight(t *testing.T) {
+	t.Parallel()
+
+	cfg := `
+weight = 1200
+`
+	l := createLogger()
+
+	ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTest(t))
+	terragruntConfig, err := config.ParseConfigString(ctx, l, config.DefaultTerragruntConfigPath, cfg, nil)
+	require.NoError(t, err)
+
+	if assert.NotNil(t, terragruntConfig.Weight) {
+		assert.Equal(t, 1200, *terragruntConfig.Weight)
+	}
+}
+
 func TestParseTerragruntJsonConfigRetryConfiguration(t *testing.T) {
 	t.Parallel()
 
@@ -410,6 +427,44 @@ dependencies {
 	}
 }
 
+func TestParseTerragruntConfigDependenciesGlobPaths(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	configPath := filepath.Join(rootDir, "apps", "gateway", config.DefaultTerragruntConfigPath)
+
+	for _, unit := range []string{"apps/api", "apps/gateway", "apps/web", "vpc"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(rootDir, unit), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(rootDir, unit, config.DefaultTerragruntConfigPath), []byte(""), 0644))
+	}
+
+	// Dirs that aren't units are not matched.
+	require.NoError(t, os.MkdirAll(filepath.Join(rootDir, "apps", "modules"), 0755))
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+dependencies {
+  paths = ["../*", "../../vpc"]
+}
+`), 0644))
+
+	l := createLogger()
+	opts := mockOptionsForTestWithConfigPath(t, configPath)
+	opts.WorkingDir = filepath.Dir(configPath)
+
+	terragruntConfig, err := config.ParseConfigFile(config.NewParsingContext(t.Context(), l, opts), l, configPath, nil)
+	require.NoError(t, err)
+	require.NotNil(t, terragruntConfig.Dependencies)
+
+	// The unit itself is not matched.
+	assert.Equal(t, []string{"../api", "../web", "../../vpc"}, terragruntConfig.Dependencies.Paths)
+
+	ctx := config.NewParsingContext(t.Context(), l, opts).WithDecodeList(config.DependenciesBlock)
+
+	partialConfig, err := config.PartialParseConfigFile(ctx, l, configPath, nil)
+	require.NoError(t, err)
+	require.NotNil(t, partialConfig.Dependencies)
+	assert.Equal(t, []string{"../api", "../web", "../../vpc"}, partialConfig.Dependencies.Paths)
+}
+
 func TestParseTerragruntConfigRemoteStateDynamoDbTerraformConfigAndDependenciesFullConfig(t *testing.T) {
 	t.Parallel()
 
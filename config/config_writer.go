@@ -0,0 +1,112 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// hclProvenance writes the provenance comments of the rendered HCL config, indicating the file each block and
+// attribute came from, based on the fields metadata of the config. A nil provenance writes no comments.
+type hclProvenance struct {
+	cfg *TerragruntConfig
+	// dir is the directory the file paths are relative to.
+	dir string
+}
+
+// header writes a comment listing the files merged into the rendered config.
+func (p *hclProvenance) header(body *hclwrite.Body, configPath string) {
+	if p == nil {
+		return
+	}
+
+	files := []string{p.relPath(configPath)}
+
+	for _, include := range p.cfg.ProcessedIncludes {
+		if file := p.relPath(include.Path); !slices.Contains(files, file) {
+			files = append(files, file)
+		}
+	}
+
+	slices.Sort(files[1:])
+
+	body.AppendUnstructuredTokens(commentTokens("Rendered from " + strings.Join(files, ", ")))
+	body.AppendNewline()
+}
+
+// annotate writes a comment with the files the given fields were found in, if any.
+func (p *hclProvenance) annotate(body *hclwrite.Body, fieldType string, fieldNames ...string) {
+	body.AppendUnstructuredTokens(p.tokens(fieldType, fieldNames...))
+}
+
+// tokens returns the comment tokens with the files the given fields were found in. If no field names are given, the
+// field type is used as the name, the same way as `SetFieldMetadata`.
+func (p *hclProvenance) tokens(fieldType string, fieldNames ...string) hclwrite.Tokens {
+	if p == nil {
+		return nil
+	}
+
+	if len(fieldNames) == 0 {
+		fieldNames = []string{fieldType}
+	}
+
+	var files []string
+
+	for _, name := range fieldNames {
+		metadata, found := p.cfg.GetMapFieldMetadata(fieldType, name)
+		if !found || metadata[FoundInFile] == "" {
+			continue
+		}
+
+		if file := p.relPath(metadata[FoundInFile]); !slices.Contains(files, file) {
+			files = append(files, file)
+		}
+	}
+
+	if len(files) == 0 {
+		return nil
+	}
+
+	return commentTokens("From " + strings.Join(files, ", "))
+}
+
+// inputs returns the tokens of the given inputs, annotating each input with the file it was found in.
+func (p *hclProvenance) inputs(inputs cty.Value) hclwrite.Tokens {
+	valueMap := inputs.AsValueMap()
+	attrs := make([]hclwrite.ObjectAttrTokens, 0, len(valueMap))
+
+	for _, name := range slices.Sorted(maps.Keys(valueMap)) {
+		nameTokens := hclwrite.TokensForValue(cty.StringVal(name))
+		if hclsyntax.ValidIdentifier(name) {
+			nameTokens = hclwrite.TokensForIdentifier(name)
+		}
+
+		attrs = append(attrs, hclwrite.ObjectAttrTokens{
+			Name:  append(p.tokens(MetadataInputs, name), nameTokens...),
+			Value: hclwrite.TokensForValue(valueMap[name]),
+		})
+	}
+
+	return hclwrite.TokensForObject(attrs)
+}
+
+func (p *hclProvenance) relPath(path string) string {
+	if rel, err := filepath.Rel(p.dir, path); err == nil {
+		path = rel
+	}
+
+	return filepath.ToSlash(path)
+}
+
+func commentTokens(comment string) hclwrite.Tokens {
+	return hclwrite.Tokens{{
+		Type:  hclsyntax.TokenComment,
+		Bytes: fmt.Appendf(nil, "# %s\n", comment),
+	}}
+}
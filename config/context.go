@@ -3,6 +3,8 @@ package config
 import (
 	"context"
 
+	"github.com/zclconf/go-cty/cty"
+
 	"github.com/gruntwork-io/terragrunt/config/hclparse"
 	"github.com/gruntwork-io/terragrunt/internal/cache"
 )
@@ -10,15 +12,27 @@ import (
 type configKey byte
 
 const (
-	HclCacheContextKey              configKey = iota
-	TerragruntConfigCacheContextKey configKey = iota
-	RunCmdCacheContextKey           configKey = iota
-	DependencyOutputCacheContextKey configKey = iota
-
-	hclCacheName              = "hclCache"
-	configCacheName           = "configCache"
-	runCmdCacheName           = "runCmdCache"
-	dependencyOutputCacheName = "dependencyOutputCache"
+	HclCacheContextKey                 configKey = iota
+	TerragruntConfigCacheContextKey    configKey = iota
+	RunCmdCacheContextKey              configKey = iota
+	DependencyOutputCacheContextKey    configKey = iota
+	FeatureFlagSourceCacheContextKey   configKey = iota
+	ImportCacheContextKey              configKey = iota
+	DecryptedValueCacheContextKey      configKey = iota
+	FunctionPluginCacheContextKey      configKey = iota
+	TFEWorkspaceOutputsCacheContextKey configKey = iota
+	ConfigSchemaCacheContextKey        configKey = iota
+
+	hclCacheName                 = "hclCache"
+	configCacheName              = "configCache"
+	runCmdCacheName              = "runCmdCache"
+	dependencyOutputCacheName    = "dependencyOutputCache"
+	featureFlagSourceCacheName   = "featureFlagSourceCache"
+	importCacheName              = "importCache"
+	decryptedValueCacheName      = "decryptedValueCache"
+	functionPluginCacheName      = "functionPluginCache"
+	tfeWorkspaceOutputsCacheName = "tfeWorkspaceOutputsCache"
+	configSchemaCacheName        = "configSchemaCache"
 )
 
 // WithConfigValues add to context default values for configuration.
@@ -27,6 +41,12 @@ func WithConfigValues(ctx context.Context) context.Context {
 	ctx = context.WithValue(ctx, TerragruntConfigCacheContextKey, cache.NewCache[*TerragruntConfig](configCacheName))
 	ctx = context.WithValue(ctx, RunCmdCacheContextKey, cache.NewCache[string](runCmdCacheName))
 	ctx = context.WithValue(ctx, DependencyOutputCacheContextKey, cache.NewCache[*dependencyOutputCache](dependencyOutputCacheName))
+	ctx = context.WithValue(ctx, FeatureFlagSourceCacheContextKey, cache.NewCache[map[string]cty.Value](featureFlagSourceCacheName))
+	ctx = context.WithValue(ctx, ImportCacheContextKey, cache.NewCache[[]byte](importCacheName))
+	ctx = context.WithValue(ctx, DecryptedValueCacheContextKey, cache.NewCache[string](decryptedValueCacheName))
+	ctx = context.WithValue(ctx, FunctionPluginCacheContextKey, cache.NewCache[string](functionPluginCacheName))
+	ctx = context.WithValue(ctx, TFEWorkspaceOutputsCacheContextKey, cache.NewCache[map[string]*tfeStateVersionOutput](tfeWorkspaceOutputsCacheName))
+	ctx = context.WithValue(ctx, ConfigSchemaCacheContextKey, cache.NewCache[bool](configSchemaCacheName))
 
 	return ctx
 }
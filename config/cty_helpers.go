@@ -289,9 +289,13 @@ func includeMapAsCtyVal(ctx *ParsingContext, l log.Logger) (cty.Value, error) {
 	return convertValuesMapToCtyVal(exposedIncludeMap)
 }
 
-// includeConfigAsCtyVal returns the parsed include block as a cty.Value object if expose is true. Otherwise, return
-// the nil representation of cty.Value.
+// includeConfigAsCtyVal returns the parsed include block as a cty.Value object if expose is true, or only its locals if
+// expose_locals is true. Otherwise, return the nil representation of cty.Value.
 func includeConfigAsCtyVal(ctx *ParsingContext, l log.Logger, includeConfig IncludeConfig) (cty.Value, error) {
+	if !includeConfig.GetExpose() && includeConfig.GetExposeLocals() {
+		return includeLocalsAsCtyVal(ctx, l, includeConfig)
+	}
+
 	ctx = ctx.WithTrackInclude(nil)
 
 	if includeConfig.GetExpose() {
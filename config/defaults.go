@@ -0,0 +1,137 @@
+package config
+
+import (
+	"path/filepath"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/gruntwork-io/terragrunt/config/hclparse"
+	"github.com/gruntwork-io/terragrunt/internal/ctyhelper"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// DefaultsFile is the name of the defaults file, searched in the unit folder and its parents, whose locals and
+// inputs are the lowest precedence defaults of the unit.
+const DefaultsFile = "terragrunt.defaults.hcl"
+
+// terragruntDefaultsFile is the structure of the defaults file, which can only declare locals and inputs:
+//
+//	locals {
+//	  region = "us-east-1"
+//	}
+//
+//	inputs = {
+//	  region = local.region
+//	}
+type terragruntDefaultsFile struct {
+	Inputs *cty.Value `hcl:"inputs,optional"`
+	// The locals block is evaluated separately, it is only declared here so that it passes the decoding.
+	Locals *struct {
+		Remain any `hcl:",remain"`
+	} `hcl:"locals,block"`
+}
+
+// unitDefaults are the evaluated locals and inputs of the defaults file that applies to a unit.
+type unitDefaults struct {
+	Locals map[string]cty.Value
+	Inputs map[string]any
+	Path   string
+}
+
+// readUnitDefaults reads the closest defaults file of the unit declared in the given file, or returns nil if there is
+// none, or if the file is not the config of the unit being parsed, e.g. an included config or a config read with
+// `read_terragrunt_config`.
+func readUnitDefaults(ctx *ParsingContext, l log.Logger, file *hclparse.File) (*unitDefaults, error) {
+	if filepath.Clean(file.ConfigPath) != filepath.Clean(ctx.TerragruntOptions.TerragruntConfigPath) {
+		return nil, nil
+	}
+
+	path := findDefaultsFile(ctx, filepath.Dir(file.ConfigPath))
+	if path == "" {
+		return nil, nil
+	}
+
+	l.Debugf("Reading the defaults of %s from %s", file.ConfigPath, path)
+
+	ctx.TerragruntOptions.AppendReadFile(path, ctx.TerragruntOptions.WorkingDir)
+
+	defaultsFile, err := hclparse.NewParser(ctx.ParserOptions...).ParseFromFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// The defaults are evaluated on their own, so they can't reference the locals, includes or dependencies of the unit.
+	defaultsCtx := ctx.WithTrackInclude(nil).WithLocals(nil).WithFeatures(nil).WithImports(nil)
+	defaultsCtx.DecodedDependencies = nil
+
+	locals, err := EvaluateLocalsBlock(defaultsCtx, l, defaultsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	localsAsCtyVal, err := convertValuesMapToCtyVal(locals)
+	if err != nil {
+		return nil, err
+	}
+
+	evalCtx, err := createTerragruntEvalContext(defaultsCtx.WithLocals(&localsAsCtyVal), l, path)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded := terragruntDefaultsFile{}
+	if err := defaultsFile.Decode(&decoded, evalCtx); err != nil {
+		return nil, err
+	}
+
+	defaults := &unitDefaults{Path: path, Locals: locals}
+
+	if decoded.Inputs != nil {
+		if defaults.Inputs, err = ctyhelper.ParseCtyValueToMap(*decoded.Inputs); err != nil {
+			return nil, err
+		}
+	}
+
+	return defaults, nil
+}
+
+// findDefaultsFile returns the path of the closest defaults file in the given folder and its parents, or an empty
+// string if there is none.
+func findDefaultsFile(ctx *ParsingContext, dir string) string {
+	for range ctx.TerragruntOptions.MaxFoldersToCheck {
+		path := filepath.Join(dir, DefaultsFile)
+		if util.FileExists(path) {
+			return path
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+
+		dir = parent
+	}
+
+	return ""
+}
+
+// applyDefaultInputs sets the default inputs the given config doesn't set, from any of its sources.
+func (defaults *unitDefaults) applyDefaultInputs(config *TerragruntConfig) {
+	if defaults == nil || len(defaults.Inputs) == 0 {
+		return
+	}
+
+	if config.Inputs == nil {
+		config.Inputs = map[string]any{}
+	}
+
+	for name, value := range defaults.Inputs {
+		if _, ok := config.Inputs[name]; ok {
+			continue
+		}
+
+		config.Inputs[name] = value
+		config.SetFieldMetadataWithType(MetadataInputs, name, map[string]any{FoundInFile: defaults.Path})
+	}
+}
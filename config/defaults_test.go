@@ -0,0 +1,135 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+)
+
+const defaultsTestDefaults = `
+locals {
+  region = "us-east-1"
+  owner  = "platform"
+}
+
+inputs = {
+  region = local.region
+  owner  = local.owner
+  tags   = { managed_by = "terragrunt" }
+}
+`
+
+func TestUnitDefaults(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		expectedInputs map[string]any
+		expectedLocals map[string]any
+		name           string
+		unitConfig     string
+		rootConfig     string
+	}{
+		{
+			name:       "defaults only",
+			unitConfig: `inputs = {}`,
+			expectedInputs: map[string]any{
+				"region": "us-east-1",
+				"owner":  "platform",
+				"tags":   map[string]any{"managed_by": "terragrunt"},
+			},
+			expectedLocals: map[string]any{"region": "us-east-1", "owner": "platform"},
+		},
+		{
+			name: "unit takes precedence",
+			unitConfig: `
+locals {
+  region = "eu-west-1"
+  name   = "${local.owner}-app"
+}
+
+inputs = {
+  region = local.region
+  tags   = { team = local.owner }
+}
+`,
+			expectedInputs: map[string]any{
+				"region": "eu-west-1",
+				"owner":  "platform",
+				"tags":   map[string]any{"team": "platform"},
+			},
+			expectedLocals: map[string]any{"region": "eu-west-1", "owner": "platform", "name": "platform-app"},
+		},
+		{
+			name: "includes take precedence",
+			rootConfig: `
+inputs = {
+  owner = "security"
+}
+`,
+			unitConfig: `
+include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+`,
+			expectedInputs: map[string]any{
+				"region": "us-east-1",
+				"owner":  "security",
+				"tags":   map[string]any{"managed_by": "terragrunt"},
+			},
+			expectedLocals: map[string]any{"region": "us-east-1", "owner": "platform"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rootDir := t.TempDir()
+			unitDir := filepath.Join(rootDir, "live", "app")
+			configPath := filepath.Join(unitDir, config.DefaultTerragruntConfigPath)
+
+			require.NoError(t, os.MkdirAll(unitDir, 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(rootDir, config.DefaultsFile), []byte(defaultsTestDefaults), 0644))
+			require.NoError(t, os.WriteFile(configPath, []byte(tc.unitConfig), 0644))
+
+			if tc.rootConfig != "" {
+				require.NoError(t, os.WriteFile(filepath.Join(rootDir, config.RecommendedParentConfigName), []byte(tc.rootConfig), 0644))
+			}
+
+			opts := mockOptionsForTestWithConfigPath(t, configPath)
+			l := createLogger()
+			ctx := config.NewParsingContext(t.Context(), l, opts)
+
+			cfg, err := config.ParseConfigFile(ctx, l, configPath, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedInputs, cfg.Inputs)
+			assert.Equal(t, tc.expectedLocals, cfg.Locals)
+
+			partialCfg, err := config.PartialParseConfigFile(ctx.WithDecodeList(config.TerragruntInputs), l, configPath, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedInputs, partialCfg.Inputs)
+		})
+	}
+}
+
+func TestUnitDefaultsInvalidBlock(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	configPath := filepath.Join(rootDir, config.DefaultTerragruntConfigPath)
+
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, config.DefaultsFile), []byte(`terraform {}`), 0644))
+	require.NoError(t, os.WriteFile(configPath, []byte(`inputs = {}`), 0644))
+
+	opts := mockOptionsForTestWithConfigPath(t, configPath)
+	l := createLogger()
+	ctx := config.NewParsingContext(t.Context(), l, opts)
+
+	_, err := config.ParseConfigFile(ctx, l, configPath, nil)
+	require.Error(t, err)
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"path/filepath"
 	"strings"
@@ -13,18 +14,14 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws/awserr"
 
-	"github.com/gruntwork-io/terragrunt/awshelper"
 	"github.com/gruntwork-io/terragrunt/internal/cache"
 	"github.com/gruntwork-io/terragrunt/internal/experiment"
 	"github.com/gruntwork-io/terragrunt/internal/remotestate"
 	"github.com/gruntwork-io/terragrunt/internal/report"
 	"github.com/gruntwork-io/terragrunt/pkg/log"
 
-	s3backend "github.com/gruntwork-io/terragrunt/internal/remotestate/backend/s3"
-
-	"github.com/aws/aws-sdk-go/aws"
-	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/hcl/v2"
 	"github.com/zclconf/go-cty/cty"
 	"github.com/zclconf/go-cty/cty/gocty"
 	ctyjson "github.com/zclconf/go-cty/cty/json"
@@ -55,12 +52,33 @@ type dependencyOutputCache struct {
 }
 
 type Dependency struct {
-	ConfigPath                          cty.Value  `hcl:"config_path,attr" cty:"config_path"`
+	ConfigPath                          cty.Value  `hcl:"config_path,optional" cty:"config_path"`
+	TFEWorkspace                        *string    `hcl:"tfe_workspace,attr" cty:"tfe_workspace"`
+	CloudFormationStack                 *string    `hcl:"cloudformation_stack,attr" cty:"cloudformation_stack"`
+	Region                              *string    `hcl:"region,attr" cty:"region"`
+	IAMRole                             *string    `hcl:"iam_role,attr" cty:"iam_role"`
+	Profile                             *string    `hcl:"profile,attr" cty:"profile"`
 	Enabled                             *bool      `hcl:"enabled,attr" cty:"enabled"`
 	SkipOutputs                         *bool      `hcl:"skip_outputs,attr" cty:"skip"`
 	MockOutputs                         *cty.Value `hcl:"mock_outputs,attr" cty:"mock_outputs"`
+	MockOutputsFile                     *string    `hcl:"mock_outputs_file,attr" cty:"mock_outputs_file"`
 	MockOutputsAllowedTerraformCommands *[]string  `hcl:"mock_outputs_allowed_terraform_commands,attr" cty:"mock_outputs_allowed_terraform_commands"`
 
+	// ExpectedOutputs declares the types of the outputs the dependency is expected to have, by output name. It is kept
+	// as an attribute since it holds type constraints, such as `list(string)`, rather than values.
+	ExpectedOutputs *hcl.Attribute `hcl:"expected_outputs,attr"`
+
+	// Outputs selects the outputs of the dependency to fetch, so the others are discarded right after being fetched. It
+	// has no cty tag, as `outputs` holds the rendered outputs of the dependency.
+	Outputs *[]string `hcl:"outputs,attr"`
+
+	// WaitForOutputs configures how long to wait for the outputs of the dependency if they are empty, as it isn't
+	// applied yet, before falling back to the mock outputs.
+	WaitForOutputs *WaitForOutputs `hcl:"wait_for_outputs,block"`
+
+	// FetchTimeoutSec is the timeout on fetching the outputs of the dependency, overriding `--dependency-fetch-timeout`.
+	FetchTimeoutSec *int `hcl:"fetch_timeout_sec,attr" cty:"fetch_timeout_sec"`
+
 	// MockOutputsMergeWithState is deprecated. Use MockOutputsMergeStrategyWithState
 	MockOutputsMergeWithState *bool `hcl:"mock_outputs_merge_with_state,attr" cty:"mock_outputs_merge_with_state"`
 
@@ -70,7 +88,13 @@ type Dependency struct {
 	RenderedOutputs *cty.Value `cty:"outputs"`
 
 	Inputs *cty.Value `cty:"inputs"`
-	Name   string     `hcl:",label" cty:"name"`
+
+	// Each holds the `each.key` and `each.value` of the instance of a dependency block declared with `for_each`. Blocks
+	// declared with `for_each` are expanded into one instance per element when decoded, so the `for_each` attribute of
+	// an instance is replaced with its `each` object.
+	Each *cty.Value `hcl:"for_each,attr"`
+
+	Name string `hcl:",label" cty:"name"`
 }
 
 // DeepMerge will deep merge two Dependency configs, updating the target. Deep merge for Dependency configs is defined
@@ -82,10 +106,30 @@ type Dependency struct {
 //
 // Note that RenderedOutputs is ignored in the deep merge operation.
 func (dep *Dependency) DeepMerge(sourceDepConfig Dependency) error {
-	if sourceDepConfig.ConfigPath.AsString() != "" {
+	if !sourceDepConfig.ConfigPath.IsNull() && sourceDepConfig.ConfigPath.AsString() != "" {
 		dep.ConfigPath = sourceDepConfig.ConfigPath
 	}
 
+	if sourceDepConfig.TFEWorkspace != nil {
+		dep.TFEWorkspace = sourceDepConfig.TFEWorkspace
+	}
+
+	if sourceDepConfig.CloudFormationStack != nil {
+		dep.CloudFormationStack = sourceDepConfig.CloudFormationStack
+	}
+
+	if sourceDepConfig.Region != nil {
+		dep.Region = sourceDepConfig.Region
+	}
+
+	if sourceDepConfig.IAMRole != nil {
+		dep.IAMRole = sourceDepConfig.IAMRole
+	}
+
+	if sourceDepConfig.Profile != nil {
+		dep.Profile = sourceDepConfig.Profile
+	}
+
 	if sourceDepConfig.Enabled != nil {
 		dep.Enabled = sourceDepConfig.Enabled
 	}
@@ -107,6 +151,26 @@ func (dep *Dependency) DeepMerge(sourceDepConfig Dependency) error {
 		}
 	}
 
+	if sourceDepConfig.MockOutputsFile != nil {
+		dep.MockOutputsFile = sourceDepConfig.MockOutputsFile
+	}
+
+	if sourceDepConfig.ExpectedOutputs != nil {
+		dep.ExpectedOutputs = sourceDepConfig.ExpectedOutputs
+	}
+
+	if sourceDepConfig.Outputs != nil {
+		dep.Outputs = sourceDepConfig.Outputs
+	}
+
+	if sourceDepConfig.WaitForOutputs != nil {
+		dep.WaitForOutputs = sourceDepConfig.WaitForOutputs
+	}
+
+	if sourceDepConfig.FetchTimeoutSec != nil {
+		dep.FetchTimeoutSec = sourceDepConfig.FetchTimeoutSec
+	}
+
 	if sourceDepConfig.MockOutputsAllowedTerraformCommands != nil {
 		if dep.MockOutputsAllowedTerraformCommands == nil {
 			dep.MockOutputsAllowedTerraformCommands = sourceDepConfig.MockOutputsAllowedTerraformCommands
@@ -138,11 +202,11 @@ func (dep Dependency) getMockOutputsMergeStrategy() MergeStrategyType {
 
 // Given a dependency config, we should only attempt to get the outputs if SkipOutputs is nil or false
 func (dep Dependency) shouldGetOutputs(ctx *ParsingContext) bool {
-	return !ctx.TerragruntOptions.SkipOutput && dep.isEnabled() && (dep.SkipOutputs == nil || !*dep.SkipOutputs)
+	return !ctx.TerragruntOptions.SkipOutput && dep.IsEnabled() && (dep.SkipOutputs == nil || !*dep.SkipOutputs)
 }
 
-// isEnabled returns true if the dependency is enabled
-func (dep Dependency) isEnabled() bool {
+// IsEnabled returns true if the dependency is enabled
+func (dep Dependency) IsEnabled() bool {
 	if dep.Enabled == nil {
 		return true
 	}
@@ -150,9 +214,143 @@ func (dep Dependency) isEnabled() bool {
 	return *dep.Enabled
 }
 
-// isDisabled returns true if the dependency is disabled
-func (dep Dependency) isDisabled() bool {
-	return !dep.isEnabled()
+// IsDisabled returns true if the dependency is disabled
+func (dep Dependency) IsDisabled() bool {
+	return !dep.IsEnabled()
+}
+
+// ForEachKey returns the key of the instance of a dependency block declared with `for_each`, and whether the block is
+// declared with `for_each`.
+func (dep Dependency) ForEachKey() (string, bool) {
+	if dep.Each == nil || !dep.Each.Type().IsObjectType() || !dep.Each.Type().HasAttribute("key") {
+		return "", false
+	}
+
+	key := dep.Each.GetAttr("key")
+	if key.IsNull() || !key.IsKnown() || !key.Type().Equals(cty.String) {
+		return "", false
+	}
+
+	return key.AsString(), true
+}
+
+// instanceName returns the name of the dependency block, followed by the instance key for blocks declared with
+// `for_each`, e.g. `spokes["us-east-1"]`.
+func (dep Dependency) instanceName() string {
+	if key, ok := dep.ForEachKey(); ok {
+		return fmt.Sprintf("%s[%q]", dep.Name, key)
+	}
+
+	return dep.Name
+}
+
+// IsExternal returns true if the dependency targets infrastructure managed outside of Terragrunt, such as a Terraform
+// Cloud workspace or a CloudFormation stack, rather than a unit. External dependencies are never run by Terragrunt, so
+// they aren't part of the dependency graph of the unit.
+func (dep Dependency) IsExternal() bool {
+	return dep.IsTFEWorkspace() || dep.IsCloudFormationStack()
+}
+
+// target returns the config path of the unit, the workspace or the stack targeted by the dependency.
+func (dep Dependency) target() string {
+	switch {
+	case dep.IsTFEWorkspace():
+		return *dep.TFEWorkspace
+	case dep.IsCloudFormationStack():
+		return *dep.CloudFormationStack
+	default:
+		return dep.ConfigPath.AsString()
+	}
+}
+
+// validateDependencyTargets returns an error for each dependency block that doesn't set exactly one of `config_path`,
+// `tfe_workspace` or `cloudformation_stack`, or that targets a CloudFormation stack without setting its region.
+func validateDependencyTargets(deps Dependencies) error {
+	errs := &errors.MultiError{}
+
+	for i, dep := range deps {
+		targets := 0
+
+		for _, isSet := range []bool{!dep.ConfigPath.IsNull(), dep.IsTFEWorkspace(), dep.IsCloudFormationStack()} {
+			if isSet {
+				targets++
+			}
+		}
+
+		if targets != 1 {
+			errs = errs.Append(errors.New(DependencyTargetError{Name: dep.instanceName()}))
+			continue
+		}
+
+		if dep.IsCloudFormationStack() && dep.Region == nil {
+			errs = errs.Append(errors.New(CloudFormationStackRegionError{Name: dep.instanceName()}))
+			continue
+		}
+
+		if dep.IsExternal() {
+			deps[i].ConfigPath = cty.NullVal(cty.String)
+		}
+	}
+
+	return errs.ErrorOrNil()
+}
+
+// externalDependencyMockOutputs returns the mock outputs of the external dependency when the outputs of its target are
+// not available.
+func externalDependencyMockOutputs(ctx *ParsingContext, l log.Logger, dep Dependency) (*cty.Value, error) {
+	if dep.shouldReturnMockOutputs(ctx) {
+		l.Warnf("%s is a dependency of %s whose outputs are not available, returning the mock outputs of dependency %s.",
+			dep.target(),
+			ctx.TerragruntOptions.TerragruntConfigPath,
+			dep.Name,
+		)
+
+		return dep.MockOutputs, nil
+	}
+
+	return nil, errors.New(ExternalDependencyNoOutputsError{Name: dep.Name, Target: dep.target()})
+}
+
+// dependencyValues builds the map of dependency block names to their value. The values of the instances of the blocks
+// declared with `for_each` are grouped under the name of the block in a map of their instance keys, so that they can be
+// referenced as `dependency.<name>["<key>"]`.
+type dependencyValues struct {
+	values    map[string]cty.Value
+	instances map[string]map[string]cty.Value
+}
+
+func newDependencyValues() *dependencyValues {
+	return &dependencyValues{
+		values:    map[string]cty.Value{},
+		instances: map[string]map[string]cty.Value{},
+	}
+}
+
+func (deps *dependencyValues) set(dep Dependency, value cty.Value) {
+	key, ok := dep.ForEachKey()
+	if !ok {
+		deps.values[dep.Name] = value
+		return
+	}
+
+	if deps.instances[dep.Name] == nil {
+		deps.instances[dep.Name] = map[string]cty.Value{}
+	}
+
+	deps.instances[dep.Name][key] = value
+}
+
+func (deps *dependencyValues) asMap() (map[string]cty.Value, error) {
+	for name, instances := range deps.instances {
+		instancesCty, err := convertValuesMapToCtyVal(instances)
+		if err != nil {
+			return nil, err
+		}
+
+		deps.values[name] = instancesCty
+	}
+
+	return deps.values, nil
 }
 
 // Given a dependency config, we should only attempt to merge mocks outputs with the outputs if MockOutputsMergeWithState is not nil or true
@@ -176,6 +374,10 @@ func (dep *Dependency) setRenderedOutputs(ctx *ParsingContext, l log.Logger) err
 			return err
 		}
 
+		if outputVal, err = dep.validateExpectedOutputs(dep.selectOutputs(outputVal)); err != nil {
+			return err
+		}
+
 		dep.RenderedOutputs = outputVal
 	}
 
@@ -231,7 +433,14 @@ func decodeAndRetrieveOutputs(ctx *ParsingContext, l log.Logger, file *hclparse.
 		decodedDependency = *mergedDecodedDependency
 	}
 
-	return dependencyBlocksToCtyValue(ctx, l, decodedDependency.Dependencies)
+	// Only the outputs of the dependencies referenced in the config are fetched, unless they are being recorded to
+	// mock outputs files.
+	referencedOutputs, ok := referencedDependencyOutputs(ctx, file)
+	if !ok || ctx.TerragruntOptions.RecordDependencyMockOutputs {
+		referencedOutputs = nil
+	}
+
+	return dependencyBlocksToCtyValue(ctx, l, decodedDependency.Dependencies, referencedOutputs)
 }
 
 // decodeDependencies decode dependencies and fetch inputs
@@ -240,8 +449,13 @@ func decodeDependencies(ctx *ParsingContext, l log.Logger, decodedDependency Ter
 	depCache := cache.ContextCache[*dependencyOutputCache](ctx, DependencyOutputCacheContextKey)
 
 	for _, dep := range decodedDependency.Dependencies {
+		if dep.IsExternal() {
+			updatedDependencies.Dependencies = append(updatedDependencies.Dependencies, dep)
+			continue
+		}
+
 		depPath := getCleanedTargetConfigPath(dep.ConfigPath.AsString(), ctx.TerragruntOptions.TerragruntConfigPath)
-		if dep.isEnabled() && util.FileExists(depPath) {
+		if dep.IsEnabled() && util.FileExists(depPath) {
 			cacheKey := ctx.TerragruntOptions.WorkingDir + depPath
 
 			cachedDependency, found := depCache.Get(ctx, cacheKey)
@@ -296,8 +510,8 @@ func dependencyBlocksToModuleDependencies(decodedDependencyBlocks []Dependency)
 	paths := []string{}
 
 	for _, decodedDependencyBlock := range decodedDependencyBlocks {
-		// skip dependency if is not enabled
-		if !decodedDependencyBlock.isEnabled() {
+		// skip dependency if is not enabled, or if it is external, which isn't part of the run
+		if !decodedDependencyBlock.IsEnabled() || decodedDependencyBlock.IsExternal() {
 			continue
 		}
 
@@ -314,7 +528,7 @@ func checkForDependencyBlockCycles(ctx *ParsingContext, l log.Logger, configPath
 	currentTraversalPaths := []string{configPath}
 
 	for _, dependency := range decodedDependency.Dependencies {
-		if dependency.isDisabled() {
+		if dependency.IsDisabled() || dependency.IsExternal() {
 			continue
 		}
 
@@ -350,7 +564,8 @@ func checkForDependencyBlockCyclesUsingDFS(
 	}
 
 	if util.ListContainsElement(*currentTraversalPaths, dependencyPath) {
-		return errors.New(DependencyCycleError(append(*currentTraversalPaths, dependencyPath)))
+		cycle := DependencyCycleError(append(*currentTraversalPaths, dependencyPath))
+		return errors.New(NewDependencyCycleDiagnosticsError(cycle, dependencyBlockCycleEdges(ctx, l, cycle)))
 	}
 
 	*currentTraversalPaths = append(*currentTraversalPaths, dependencyPath)
@@ -404,29 +619,45 @@ func getDependencyBlockConfigPathsByFilepath(ctx *ParsingContext, l log.Logger,
 //     dependency.
 //
 // This routine will go through the process of obtaining the outputs using `terragrunt output` from the target config.
-func dependencyBlocksToCtyValue(ctx *ParsingContext, l log.Logger, dependencyConfigs []Dependency) (*cty.Value, error) {
+// When referencedOutputs is not nil, the outputs are only obtained for the dependencies it contains, and for those
+// declaring `expected_outputs`, so the outputs of the dependencies that are declared but not used aren't fetched.
+func dependencyBlocksToCtyValue(ctx *ParsingContext, l log.Logger, dependencyConfigs []Dependency, referencedOutputs map[string]bool) (*cty.Value, error) {
 	paths := []string{}
 
 	// dependencyMap is the top level map that maps dependency block names to the encoded version, which includes
 	// various attributes for accessing information about the target config (including the module outputs).
-	dependencyMap := map[string]cty.Value{}
+	dependencyMap := newDependencyValues()
 	lock := sync.Mutex{}
 	dependencyErrGroup, _ := errgroup.WithContext(ctx)
 
+	// The outputs of the dependencies are fetched concurrently. The fetches of the same dependency, e.g. required by
+	// multiple units of a run --all, are deduplicated by getOutputJSONWithCaching.
+	if parallelism := ctx.TerragruntOptions.DependencyFetchParallelism; parallelism > 0 {
+		dependencyErrGroup.SetLimit(parallelism)
+	}
+
 	for _, dependencyConfig := range dependencyConfigs {
 		dependencyErrGroup.Go(func() error {
 			// Loose struct to hold the attributes of the dependency. This includes:
 			// - outputs: The module outputs of the target config
 			dependencyEncodingMap := map[string]cty.Value{}
 
-			// Encode the outputs and nest under `outputs` attribute if we should get the outputs or the `mock_outputs`
-			if err := dependencyConfig.setRenderedOutputs(ctx, l); err != nil {
+			if err := dependencyConfig.loadMockOutputsFile(ctx); err != nil {
 				return err
 			}
 
+			// Encode the outputs and nest under `outputs` attribute if we should get the outputs or the `mock_outputs`
+			if referencedOutputs == nil || referencedOutputs[dependencyConfig.Name] || dependencyConfig.ExpectedOutputs != nil {
+				if err := dependencyConfig.setRenderedOutputs(ctx, l); err != nil {
+					return err
+				}
+			} else {
+				l.Debugf("Skipping outputs reading for dependency %s, as its outputs are not referenced", dependencyConfig.Name)
+			}
+
 			if dependencyConfig.RenderedOutputs != nil {
 				lock.Lock()
-				paths = append(paths, dependencyConfig.ConfigPath.AsString())
+				paths = append(paths, dependencyConfig.target())
 				lock.Unlock()
 
 				dependencyEncodingMap["outputs"] = *dependencyConfig.RenderedOutputs
@@ -449,7 +680,7 @@ func dependencyBlocksToCtyValue(ctx *ParsingContext, l log.Logger, dependencyCon
 			defer lock.Unlock()
 
 			// Finally, feed the encoded dependency into the higher order map under the block name
-			dependencyMap[dependencyConfig.Name] = dependencyEncodingMapEncoded
+			dependencyMap.set(dependencyConfig, dependencyEncodingMapEncoded)
 
 			return nil
 		})
@@ -459,8 +690,13 @@ func dependencyBlocksToCtyValue(ctx *ParsingContext, l log.Logger, dependencyCon
 		return nil, err
 	}
 
+	dependencyValuesMap, err := dependencyMap.asMap()
+	if err != nil {
+		return nil, err
+	}
+
 	// We need to convert the value map to a single cty.Value at the end so that it can be used in the execution ctx
-	convertedOutput, err := gocty.ToCtyValue(dependencyMap, generateTypeFromValuesMap(dependencyMap))
+	convertedOutput, err := gocty.ToCtyValue(dependencyValuesMap, generateTypeFromValuesMap(dependencyValuesMap))
 	if err != nil {
 		err = TerragruntOutputListEncodingError{Paths: paths, Err: err}
 	}
@@ -474,15 +710,47 @@ func dependencyBlocksToCtyValue(ctx *ParsingContext, l log.Logger, dependencyCon
 //     If the dependency block indicates a mock_outputs_merge_strategy_with_state attribute, mock_outputs and state outputs will be merged following the merge strategy
 //   - If the dependency block does NOT indicate a mock_outputs attribute, this will return an error.
 func getTerragruntOutputIfAppliedElseConfiguredDefault(ctx *ParsingContext, l log.Logger, dependencyConfig Dependency) (*cty.Value, error) {
-	if dependencyConfig.isDisabled() {
+	if dependencyConfig.IsDisabled() {
 		l.Debugf("Skipping outputs reading for disabled dependency %s", dependencyConfig.Name)
 		return dependencyConfig.MockOutputs, nil
 	}
 
+	if ctx.TerragruntOptions.SpeculativeOutputs != nil && dependencyConfig.shouldGetOutputs(ctx) &&
+		!dependencyConfig.IsTFEWorkspace() && !dependencyConfig.IsCloudFormationStack() {
+		if outputs, found, err := dependencyConfig.getSpeculativeOutputs(ctx, l); err != nil || found {
+			return outputs, err
+		}
+	}
+
+	if ctx.TerragruntOptions.DependencyOffline && dependencyConfig.shouldGetOutputs(ctx) {
+		return dependencyConfig.getOfflineOutputs(ctx, l)
+	}
+
 	if dependencyConfig.shouldGetOutputs(ctx) {
-		outputVal, isEmpty, err := getTerragruntOutput(ctx, l, dependencyConfig)
+		getOutput := getTerragruntOutput
+
+		switch {
+		case dependencyConfig.IsTFEWorkspace():
+			getOutput = getTFEWorkspaceDependencyOutput
+		case dependencyConfig.IsCloudFormationStack():
+			getOutput = getCloudFormationStackDependencyOutput
+		}
+
+		getOutput = dependencyConfig.withFetchTimeout(ctx, getOutput)
+
+		outputVal, isEmpty, err := getOutput(ctx, l, dependencyConfig)
+		if err == nil && isEmpty && dependencyConfig.WaitForOutputs != nil {
+			outputVal, isEmpty, err = dependencyConfig.waitForOutputs(ctx, l, getOutput)
+		}
+
 		if err != nil {
-			return nil, err
+			return nil, dependencyConfig.outputError(err)
+		}
+
+		if !isEmpty && dependencyConfig.MockOutputsFile != nil && ctx.TerragruntOptions.RecordDependencyMockOutputs {
+			if err := dependencyConfig.recordMockOutputsFile(ctx, l, *outputVal); err != nil {
+				return nil, err
+			}
 		}
 
 		if !isEmpty && dependencyConfig.shouldMergeMockOutputsWithState(ctx) && dependencyConfig.MockOutputs != nil {
@@ -504,6 +772,10 @@ func getTerragruntOutputIfAppliedElseConfiguredDefault(ctx *ParsingContext, l lo
 		}
 	}
 
+	if dependencyConfig.IsExternal() {
+		return externalDependencyMockOutputs(ctx, l, dependencyConfig)
+	}
+
 	// When we get no output, it can be an indication that either the module has no outputs or the module is not
 	// applied. In either case, check if there are default output values to return. If yes, return that. Else,
 	// return error.
@@ -528,13 +800,13 @@ func getTerragruntOutputIfAppliedElseConfiguredDefault(ctx *ParsingContext, l lo
 		currentConfig: ctx.TerragruntOptions.TerragruntConfigPath,
 	}
 
-	return nil, err
+	return nil, dependencyConfig.outputError(err)
 }
 
 // We should only return default outputs if the mock_outputs attribute is set, and if we are running one of the
 // allowed commands when `mock_outputs_allowed_terraform_commands` is set as well.
 func (dep Dependency) shouldReturnMockOutputs(ctx *ParsingContext) bool {
-	if dep.isDisabled() {
+	if dep.IsDisabled() {
 		return true
 	}
 
@@ -558,7 +830,7 @@ func getTerragruntOutput(ctx *ParsingContext, l log.Logger, dependencyConfig Dep
 		return nil, true, errors.New(DependencyConfigNotFound{Path: targetConfigPath})
 	}
 
-	jsonBytes, err := getOutputJSONWithCaching(ctx, l, targetConfigPath)
+	jsonBytes, err := getOutputJSONWithCaching(dependencyConfig.withOutputAWSOptions(ctx), l, targetConfigPath)
 	if err != nil {
 		if !isRenderJSONCommand(ctx) && !isRenderCommand(ctx) && !isAwsS3NoSuchKey(err) {
 			return nil, true, err
@@ -570,11 +842,17 @@ func getTerragruntOutput(ctx *ParsingContext, l log.Logger, dependencyConfig Dep
 		if err != nil {
 			return nil, true, err
 		}
+	} else if string(jsonBytes) != "{}" {
+		// The outputs of a dependency that isn't applied yet are empty, so there is nothing to select, and its mock
+		// outputs are used instead.
+		if jsonBytes, err = dependencyConfig.selectOutputsJSON(targetConfigPath, jsonBytes); err != nil {
+			return nil, false, err
+		}
 	}
 
 	isEmpty := string(jsonBytes) == "{}"
 
-	outputMap, err := TerraformOutputJSONToCtyValueMap(targetConfigPath, jsonBytes)
+	outputMap, err := dependencyOutputJSONToCtyValueMap(ctx, l, targetConfigPath, jsonBytes)
 	if err != nil {
 		return nil, isEmpty, err
 	}
@@ -628,6 +906,18 @@ func getOutputJSONWithCaching(ctx *ParsingContext, l log.Logger, targetConfig st
 		return rawJSONBytes.([]byte), nil
 	}
 
+	if useDependencyOutputCache(ctx.TerragruntOptions) {
+		cachedJSONBytes, found, err := readDependencyOutputCache(ctx, l, targetConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		if found {
+			jsonOutputCache.Store(targetConfig, cachedJSONBytes)
+			return cachedJSONBytes, nil
+		}
+	}
+
 	// Cache miss, so look up the output and store in cache
 	newJSONBytes, err := getTerragruntOutputJSON(ctx, l, targetConfig)
 	if err != nil {
@@ -643,6 +933,12 @@ func getOutputJSONWithCaching(ctx *ParsingContext, l log.Logger, targetConfig st
 
 	jsonOutputCache.Store(targetConfig, newJSONBytes)
 
+	if useDependencyOutputCache(ctx.TerragruntOptions) {
+		if err := writeDependencyOutputCache(ctx, l, targetConfig, newJSONBytes); err != nil {
+			l.Warnf("Failed to cache outputs of dependency %s: %v", targetConfig, err)
+		}
+	}
+
 	return newJSONBytes, nil
 }
 
@@ -675,6 +971,44 @@ func cloneTerragruntOptionsForDependency(ctx *ParsingContext, l log.Logger, targ
 	return l, targetOptions, nil
 }
 
+// withOutputAWSOptions returns the parsing context to fetch the outputs of the dependency with, using the AWS IAM role,
+// region and profile set on the dependency block, if any. The IAM role is used as if it was passed on the command line,
+// so it takes precedence over the `iam_role` of the config of the dependency, and the profile replaces the AWS
+// credentials of the environment.
+func (dep Dependency) withOutputAWSOptions(ctx *ParsingContext) *ParsingContext {
+	if dep.IAMRole == nil && dep.Region == nil && dep.Profile == nil {
+		return ctx
+	}
+
+	opts := ctx.TerragruntOptions.Clone()
+
+	if dep.IAMRole != nil {
+		opts.OriginalIAMRoleOptions = options.MergeIAMRoleOptions(opts.OriginalIAMRoleOptions, options.IAMRoleOptions{RoleARN: *dep.IAMRole})
+		opts.IAMRoleOptions = opts.OriginalIAMRoleOptions
+	}
+
+	env := maps.Clone(opts.Env)
+	if env == nil {
+		env = make(map[string]string)
+	}
+
+	if dep.Region != nil {
+		env["AWS_REGION"] = *dep.Region
+	}
+
+	if dep.Profile != nil {
+		env["AWS_PROFILE"] = *dep.Profile
+
+		for _, name := range []string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_SESSION_TOKEN"} {
+			delete(env, name)
+		}
+	}
+
+	opts.Env = env
+
+	return ctx.WithTerragruntOptions(opts)
+}
+
 // Clone terragrunt options and update ctx for dependency block so that the outputs can be read correctly
 func cloneTerragruntOptionsForDependencyOutput(ctx *ParsingContext, l log.Logger, targetConfig string) (log.Logger, *options.TerragruntOptions, error) {
 	l, targetOptions, err := cloneTerragruntOptionsForDependency(ctx, l, targetConfig)
@@ -943,23 +1277,23 @@ func getTerragruntOutputJSONFromRemoteState(
 
 	// To speed up dependencies processing it is possible to retrieve its output directly from the backend without init dependencies
 	if ctx.TerragruntOptions.FetchDependencyOutputFromState {
-		switch backend := remoteState.BackendName; backend {
-		case s3backend.BackendName:
-			jsonBytes, err := getTerragruntOutputJSONFromRemoteStateS3(
-				l,
-				targetTGOptions,
-				remoteState,
-			)
+		if remoteState.CanReadState() {
+			stateBody, err := remoteState.ReadState(ctx, l, targetTGOptions)
 			if err != nil {
 				return nil, err
 			}
 
-			l.Debugf("Retrieved output from %s as json: %s using s3 bucket", targetTGOptions.TerragruntConfigPath, jsonBytes)
+			jsonBytes, err := stateOutputsToJSON(stateBody)
+			if err != nil {
+				return nil, err
+			}
+
+			l.Debugf("Retrieved output from %s as json: %s using the %s backend", targetTGOptions.TerragruntConfigPath, jsonBytes, remoteState.BackendName)
 
 			return jsonBytes, nil
-		default:
-			l.Errorf("FetchDependencyOutputFromState is not supported for backend %s, falling back to normal method", backend)
 		}
+
+		l.Errorf("FetchDependencyOutputFromState is not supported for backend %s, falling back to normal method", remoteState.BackendName)
 	}
 
 	// Generate the backend configuration in the working dir. If no generate config is set on the remote state block,
@@ -977,6 +1311,10 @@ func getTerragruntOutputJSONFromRemoteState(
 
 	l.Debugf("Generated remote state configuration in working dir %s", tempWorkDir)
 
+	if err := remoteState.InjectCredentials(ctx, l, targetTGOptions); err != nil {
+		return nil, err
+	}
+
 	// Check for a provider lock file and copy it to the working dir if it exists.
 	terragruntDir := filepath.Dir(ctx.TerragruntOptions.TerragruntConfigPath)
 	if err := CopyLockFile(l, ctx.TerragruntOptions, terragruntDir, tempWorkDir); err != nil {
@@ -1003,47 +1341,11 @@ func getTerragruntOutputJSONFromRemoteState(
 	return jsonBytes, nil
 }
 
-// getTerragruntOutputJSONFromRemoteStateS3 pulls the output directly from an S3 bucket without calling Terraform
-func getTerragruntOutputJSONFromRemoteStateS3(l log.Logger, opts *options.TerragruntOptions, remoteState *remotestate.RemoteState) ([]byte, error) {
-	l.Debugf("Fetching outputs directly from s3://%s/%s", remoteState.BackendConfig["bucket"], remoteState.BackendConfig["key"])
-
-	s3ConfigExtended, err := s3backend.Config(remoteState.BackendConfig).ParseExtendedS3Config()
-	if err != nil {
-		return nil, err
-	}
-
-	sessionConfig := s3ConfigExtended.GetAwsSessionConfig()
-
-	s3Client, err := awshelper.CreateS3Client(l, sessionConfig, opts)
-	if err != nil {
-		return nil, err
-	}
-
-	result, err := s3Client.GetObject(&s3.GetObjectInput{
-		Bucket: aws.String(fmt.Sprintf("%s", remoteState.BackendConfig["bucket"])),
-		Key:    aws.String(fmt.Sprintf("%s", remoteState.BackendConfig["key"])),
-	})
-
-	if err != nil {
-		return nil, err
-	}
-
-	defer func(Body io.ReadCloser) {
-		err := Body.Close()
-		if err != nil {
-			l.Warnf("Failed to close remote state response %v", err)
-		}
-	}(result.Body)
-
-	steateBody, err := io.ReadAll(result.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	jsonState := string(steateBody)
+// stateOutputsToJSON returns the outputs of the given state file as json.
+func stateOutputsToJSON(stateBody []byte) ([]byte, error) {
 	jsonMap := make(map[string]any)
 
-	err = json.Unmarshal([]byte(jsonState), &jsonMap)
+	err := json.Unmarshal(stateBody, &jsonMap)
 	if err != nil {
 		return nil, err
 	}
@@ -1071,6 +1373,8 @@ func setupTerragruntOptionsForBareTerraform(ctx *ParsingContext, l log.Logger, w
 	targetTGOptions.WorkingDir = workingDir
 	targetTGOptions.Writer = io.Discard
 	targetTGOptions.Engine = ctx.TerragruntOptions.Engine
+	// The temp dir isn't shared with the runner agents, so the outputs are always read locally.
+	targetTGOptions.Executor = nil
 
 	// If the target config has an IAM role directive and it was not set on the command line, set it to
 	// the one we retrieved from the config.
@@ -1122,6 +1426,14 @@ func runTerragruntOutputJSON(ctx *ParsingContext, l log.Logger, targetConfig str
 // TerraformOutputJSONToCtyValueMap takes the terraform output json and converts to a mapping between output keys to the
 // parsed cty.Value encoding of the json objects.
 func TerraformOutputJSONToCtyValueMap(targetConfigPath string, jsonBytes []byte) (map[string]cty.Value, error) {
+	outputs, _, err := terraformOutputJSONToCtyValueMap(targetConfigPath, jsonBytes)
+
+	return outputs, err
+}
+
+// terraformOutputJSONToCtyValueMap converts the terraform output json as TerraformOutputJSONToCtyValueMap, also
+// returning the names of the sensitive outputs.
+func terraformOutputJSONToCtyValueMap(targetConfigPath string, jsonBytes []byte) (map[string]cty.Value, []string, error) {
 	// When getting all outputs, terraform returns a json with the data containing metadata about the types, so we
 	// can't quite return the data directly. Instead, we will need further processing to get the output we want.
 	// To do so, we first Unmarshal the json into a simple go map to a OutputMeta struct.
@@ -1135,26 +1447,33 @@ func TerraformOutputJSONToCtyValueMap(targetConfigPath string, jsonBytes []byte)
 
 	err := json.Unmarshal(jsonBytes, &outputs)
 	if err != nil {
-		return nil, errors.New(TerragruntOutputParsingError{Path: targetConfigPath, Err: err})
+		return nil, nil, errors.New(TerragruntOutputParsingError{Path: targetConfigPath, Err: err})
 	}
 
-	flattenedOutput := map[string]cty.Value{}
+	var (
+		flattenedOutput = map[string]cty.Value{}
+		sensitive       []string
+	)
 
 	for k, v := range outputs {
 		outputType, err := ctyjson.UnmarshalType(v.Type)
 		if err != nil {
-			return nil, errors.New(TerragruntOutputParsingError{Path: targetConfigPath, Err: err})
+			return nil, nil, errors.New(TerragruntOutputParsingError{Path: targetConfigPath, Err: err})
 		}
 
 		outputVal, err := ctyjson.Unmarshal(v.Value, outputType)
 		if err != nil {
-			return nil, errors.New(TerragruntOutputParsingError{Path: targetConfigPath, Err: err})
+			return nil, nil, errors.New(TerragruntOutputParsingError{Path: targetConfigPath, Err: err})
 		}
 
 		flattenedOutput[k] = outputVal
+
+		if v.Sensitive {
+			sensitive = append(sensitive, k)
+		}
 	}
 
-	return flattenedOutput, nil
+	return flattenedOutput, sensitive, nil
 }
 
 // ClearOutputCache clears the output cache. Useful during testing.
@@ -1191,6 +1510,14 @@ func (deps Dependencies) FilteredWithoutConfigPath() Dependencies {
 	var filteredDeps Dependencies
 
 	for _, dep := range deps {
+		if dep.IsExternal() {
+			// The config path of external dependencies is null, rather than unset.
+			dep.ConfigPath = cty.NullVal(cty.String)
+			filteredDeps = append(filteredDeps, dep)
+
+			continue
+		}
+
 		if !dep.ConfigPath.IsNull() {
 			filteredDeps = append(filteredDeps, dep)
 		}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/cloudformation"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/gruntwork-io/terragrunt/awshelper"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+// IsCloudFormationStack returns true if the dependency targets a CloudFormation stack, set with the
+// `cloudformation_stack` attribute, rather than a unit. The outputs of the stack are read with the AWS API.
+func (dep Dependency) IsCloudFormationStack() bool {
+	return dep.CloudFormationStack != nil
+}
+
+// getCloudFormationStackDependencyOutput returns the outputs of the CloudFormation stack targeted by the dependency,
+// read in the region of the dependency, with its profile and IAM role if set. The values exported by the stack are
+// outputs of the stack, so they are returned too, by output key. The returned bool is true if the stack doesn't exist
+// or has no outputs.
+func getCloudFormationStackDependencyOutput(ctx *ParsingContext, l log.Logger, dep Dependency) (*cty.Value, bool, error) {
+	if dep.Region == nil {
+		return nil, true, errors.New(CloudFormationStackRegionError{Name: dep.Name})
+	}
+
+	sessionConfig := &awshelper.AwsSessionConfig{Region: *dep.Region}
+	if dep.IAMRole != nil {
+		sessionConfig.RoleArn = *dep.IAMRole
+	}
+
+	if dep.Profile != nil {
+		sessionConfig.Profile = *dep.Profile
+	}
+
+	sess, err := awshelper.CreateAwsSession(l, sessionConfig, ctx.TerragruntOptions)
+	if err != nil {
+		return nil, true, err
+	}
+
+	l.Debugf("Fetching outputs of CloudFormation stack %s in %s for dependency %s", *dep.CloudFormationStack, *dep.Region, dep.Name)
+
+	resp, err := cloudformation.New(sess).DescribeStacksWithContext(ctx, &cloudformation.DescribeStacksInput{
+		StackName: aws.String(*dep.CloudFormationStack),
+	})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && strings.Contains(awsErr.Message(), "does not exist") {
+			l.Debugf("CloudFormation stack %s of dependency %s does not exist", *dep.CloudFormationStack, dep.Name)
+			return nil, true, nil
+		}
+
+		return nil, true, errors.New(err)
+	}
+
+	if len(resp.Stacks) == 0 || len(resp.Stacks[0].Outputs) == 0 {
+		return nil, true, nil
+	}
+
+	values := make(map[string]cty.Value, len(resp.Stacks[0].Outputs))
+
+	for _, output := range resp.Stacks[0].Outputs {
+		values[aws.StringValue(output.OutputKey)] = cty.StringVal(aws.StringValue(output.OutputValue))
+	}
+
+	outputVal := cty.ObjectVal(values)
+
+	return &outputVal, false, nil
+}
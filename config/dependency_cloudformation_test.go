@@ -0,0 +1,87 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+)
+
+func TestCloudFormationStackDependency(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		cfg         string
+		expectedErr string
+	}{
+		{
+			name: "mock outputs",
+			cfg: `
+dependency "network" {
+  cloudformation_stack = "legacy-network"
+  region               = "us-east-1"
+  iam_role             = "arn:aws:iam::123456789012:role/read-outputs"
+  skip_outputs         = true
+
+  mock_outputs = {
+    VpcId = "vpc-mock"
+  }
+}
+
+inputs = {
+  vpc_id = dependency.network.outputs.VpcId
+}
+`,
+		},
+		{
+			name: "missing region",
+			cfg: `
+dependency "network" {
+  cloudformation_stack = "legacy-network"
+  skip_outputs         = true
+}
+`,
+			expectedErr: "dependency block network must set the region of its cloudformation_stack",
+		},
+		{
+			name: "multiple targets",
+			cfg: `
+dependency "network" {
+  config_path          = "../network"
+  cloudformation_stack = "legacy-network"
+  region               = "us-east-1"
+}
+`,
+			expectedErr: "dependency block network must set exactly one of config_path, tfe_workspace or cloudformation_stack",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			configPath := filepath.Join(t.TempDir(), config.DefaultTerragruntConfigPath)
+			require.NoError(t, os.WriteFile(configPath, []byte(tc.cfg), 0644))
+
+			l := createLogger()
+			ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, configPath))
+
+			cfg, err := config.ParseConfigFile(ctx, l, configPath, nil)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "vpc-mock", cfg.Inputs["vpc_id"])
+			assert.Nil(t, cfg.Dependencies, "stacks must not be part of the dependency graph")
+			require.Len(t, cfg.TerragruntDependencies, 1)
+			assert.Equal(t, "us-east-1", *cfg.TerragruntDependencies[0].Region)
+		})
+	}
+}
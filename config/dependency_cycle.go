@@ -0,0 +1,220 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// DependencyDeclaration is the declaration, in a `dependency` or `dependencies` block, of the dependency of a unit on
+// another unit.
+type DependencyDeclaration struct {
+	// Range is the range of the `config_path` attribute of the `dependency` block, or of the `paths` attribute of the
+	// `dependencies` block. Only the filename is set if the block could not be found in the HCL files of the unit.
+	Range hcl.Range
+	// Name is the name of the `dependency` block, empty for a `dependencies` block.
+	Name string
+	// SkipOutputs is true if the outputs of the dependency are not read, with `skip_outputs`, or because it is
+	// declared in a `dependencies` block.
+	SkipOutputs bool
+}
+
+// String returns the block of the declaration and its location, e.g. `dependency "vpc" at app/terragrunt.hcl:3`.
+func (decl DependencyDeclaration) String() string {
+	block := "dependencies"
+	if decl.Name != "" {
+		block = fmt.Sprintf("dependency %q", decl.Name)
+	}
+
+	if decl.Range.Start.Line == 0 {
+		return fmt.Sprintf("%s in %s", block, decl.Range.Filename)
+	}
+
+	return fmt.Sprintf("%s at %s:%d", block, decl.Range.Filename, decl.Range.Start.Line)
+}
+
+// TargetDir returns the dir of the unit targeted by the `config_path` of the dependency, resolved from the given dir of
+// the unit declaring it. The returned bool is false if the dependency doesn't target a unit.
+func (dep Dependency) TargetDir(unitDir string) (string, bool) {
+	if dep.IsDisabled() || dep.IsExternal() || !dep.ConfigPath.IsKnown() || dep.ConfigPath.IsNull() || dep.ConfigPath.Type() != cty.String {
+		return "", false
+	}
+
+	return dependencyTargetDir(dep.ConfigPath.AsString(), unitDir), true
+}
+
+// dependencyTargetDir returns the dir of the unit at the given path, which can be the dir or the config file of the
+// unit, relative to the given dir.
+func dependencyTargetDir(path, unitDir string) string {
+	if !filepath.IsAbs(path) {
+		path = util.JoinPath(unitDir, path)
+	}
+
+	path = util.CleanPath(path)
+	if util.FileExists(path) && !util.IsDir(path) {
+		path = filepath.Dir(path)
+	}
+
+	return path
+}
+
+// FindDependencyDeclaration returns the declaration of the dependency of the unit with the given config, parsed from
+// the given config path, on the unit in the given dir. The `dependency` and `dependencies` blocks are looked up in the
+// config and in the configs it includes. It returns nil if the unit doesn't depend on the target unit.
+func (cfg *TerragruntConfig) FindDependencyDeclaration(configPath, targetDir string) *DependencyDeclaration {
+	unitDir := filepath.Dir(configPath)
+	targetDir = util.CleanPath(targetDir)
+
+	files := []string{configPath}
+	for _, include := range cfg.ProcessedIncludes {
+		if !slices.Contains(files, include.Path) {
+			files = append(files, include.Path)
+		}
+	}
+
+	for _, dep := range cfg.TerragruntDependencies {
+		if dir, ok := dep.TargetDir(unitDir); !ok || dir != targetDir {
+			continue
+		}
+
+		decl := &DependencyDeclaration{
+			Range:       hcl.Range{Filename: configPath},
+			Name:        dep.Name,
+			SkipOutputs: dep.SkipOutputs != nil && *dep.SkipOutputs,
+		}
+
+		if rng, ok := findBlockAttributeRange(files, "dependency", dep.Name, "config_path"); ok {
+			decl.Range = rng
+		}
+
+		return decl
+	}
+
+	if cfg.Dependencies == nil {
+		return nil
+	}
+
+	for _, path := range cfg.Dependencies.Paths {
+		if dependencyTargetDir(path, unitDir) != targetDir {
+			continue
+		}
+
+		decl := &DependencyDeclaration{Range: hcl.Range{Filename: configPath}, SkipOutputs: true}
+
+		if rng, ok := findBlockAttributeRange(files, "dependencies", "", "paths"); ok {
+			decl.Range = rng
+		}
+
+		return decl
+	}
+
+	return nil
+}
+
+// findBlockAttributeRange returns the range of the given attribute of the first block of the given type and label
+// found in the given HCL files, or the range of the block itself if it doesn't set the attribute. Files that can't be
+// read or parsed as HCL native syntax are skipped.
+func findBlockAttributeRange(files []string, blockType, label, attrName string) (hcl.Range, bool) {
+	for _, file := range files {
+		src, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+
+		hclFile, diags := hclsyntax.ParseConfig(src, file, hcl.InitialPos)
+		if diags.HasErrors() {
+			continue
+		}
+
+		body, ok := hclFile.Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+
+		for _, block := range body.Blocks {
+			if block.Type != blockType || (label != "" && (len(block.Labels) == 0 || block.Labels[0] != label)) {
+				continue
+			}
+
+			if attr, ok := block.Body.Attributes[attrName]; ok {
+				return attr.SrcRange, true
+			}
+
+			return block.DefRange(), true
+		}
+	}
+
+	return hcl.Range{}, false
+}
+
+// DependencyCycleEdge is an edge of a dependency cycle, from a unit to one of its dependencies.
+type DependencyCycleEdge struct {
+	// Declaration is the declaration creating the edge, nil if it could not be found.
+	Declaration *DependencyDeclaration
+	From        string
+	To          string
+	// Dependents is the number of units depending on the unit the edge is from.
+	Dependents int
+}
+
+// NewDependencyCycleDiagnosticsError returns an error listing the given edges of the dependency cycle reported by the
+// given error, and suggesting the edge most likely to be the mistake:
+//   - an edge whose outputs are not read, since it only orders the units, over one reading the outputs of the
+//     dependency, which is more likely to be intended;
+//   - then the edge from the unit depended on by the most units, since foundational units rarely depend on others.
+//
+// When several edges are as likely, the one closing the cycle is suggested.
+func NewDependencyCycleDiagnosticsError(err error, edges []DependencyCycleEdge) error {
+	suggested := -1
+
+	for i, edge := range edges {
+		if suggested == -1 || !dependencyCycleEdgeLess(edge, edges[suggested]) {
+			suggested = i
+		}
+	}
+
+	return DependencyCycleDiagnosticsError{Err: err, Edges: edges, SuggestedEdge: suggested}
+}
+
+// dependencyCycleEdgeLess returns true if the edge a is less likely to be the mistake than the edge b.
+func dependencyCycleEdgeLess(a, b DependencyCycleEdge) bool {
+	aSkipsOutputs := a.Declaration != nil && a.Declaration.SkipOutputs
+	bSkipsOutputs := b.Declaration != nil && b.Declaration.SkipOutputs
+
+	if aSkipsOutputs != bSkipsOutputs {
+		return bSkipsOutputs
+	}
+
+	return a.Dependents < b.Dependents
+}
+
+// dependencyBlockCycleEdges returns the edges of the given cycle between the configs of units, created by `dependency`
+// blocks. The configs are parsed again to find the block creating each edge, which is only done once a cycle is found.
+func dependencyBlockCycleEdges(ctx *ParsingContext, l log.Logger, cycle DependencyCycleError) []DependencyCycleEdge {
+	// The cycle starts at the first occurrence of the path it ends with.
+	start := slices.Index(cycle, cycle[len(cycle)-1])
+	edges := make([]DependencyCycleEdge, 0, len(cycle)-start-1)
+
+	for i := start; i < len(cycle)-1; i++ {
+		edge := DependencyCycleEdge{From: cycle[i], To: cycle[i+1]}
+
+		if l, opts, err := cloneTerragruntOptionsForDependency(ctx, l, cycle[i]); err == nil {
+			cfg, err := PartialParseConfigFile(ctx.WithTerragruntOptions(opts).WithDecodeList(DependencyBlock), l, cycle[i], nil)
+			if err == nil {
+				edge.Declaration = cfg.FindDependencyDeclaration(cycle[i], filepath.Dir(cycle[i+1]))
+			}
+		}
+
+		edges = append(edges, edge)
+	}
+
+	return edges
+}
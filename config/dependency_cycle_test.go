@@ -0,0 +1,98 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+)
+
+func TestDependencyCycleDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	vpcConfigPath := filepath.Join(rootDir, "vpc", config.DefaultTerragruntConfigPath)
+	appConfigPath := filepath.Join(rootDir, "app", config.DefaultTerragruntConfigPath)
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(vpcConfigPath), 0755))
+	require.NoError(t, os.MkdirAll(filepath.Dir(appConfigPath), 0755))
+	require.NoError(t, os.WriteFile(appConfigPath, []byte(`
+dependency "vpc" {
+  config_path = "../vpc"
+}
+`), 0644))
+	require.NoError(t, os.WriteFile(vpcConfigPath, []byte(`
+locals {
+  env = "dev"
+}
+
+dependency "app" {
+  config_path  = "../app"
+  skip_outputs = true
+}
+`), 0644))
+
+	l := createLogger()
+	ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, appConfigPath))
+
+	_, err := config.ParseConfigFile(ctx, l, appConfigPath, nil)
+	require.Error(t, err)
+
+	var cycleErr config.DependencyCycleError
+
+	require.ErrorAs(t, err, &cycleErr)
+	assert.Equal(t, config.DependencyCycleError{appConfigPath, vpcConfigPath, appConfigPath}, cycleErr)
+
+	var diagnosticsErr config.DependencyCycleDiagnosticsError
+
+	require.ErrorAs(t, err, &diagnosticsErr)
+	require.Len(t, diagnosticsErr.Edges, 2)
+	assert.Equal(t, `dependency "vpc" at `+appConfigPath+`:3`, diagnosticsErr.Edges[0].Declaration.String())
+	assert.Equal(t, `dependency "app" at `+vpcConfigPath+`:7`, diagnosticsErr.Edges[1].Declaration.String())
+
+	// The dependency whose outputs are skipped is suggested as the mistake.
+	assert.Equal(t, 1, diagnosticsErr.SuggestedEdge)
+	assert.Contains(t, err.Error(), "The dependency "+vpcConfigPath+" -> "+appConfigPath+" looks like the most likely mistake")
+}
+
+func TestFindDependencyDeclaration(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	configPath := filepath.Join(rootDir, "app", config.DefaultTerragruntConfigPath)
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(configPath), 0755))
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+dependency "vpc" {
+  config_path  = "../vpc"
+  skip_outputs = true
+}
+
+dependencies {
+  paths = ["../db"]
+}
+`), 0644))
+
+	l := createLogger()
+	ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, configPath))
+
+	cfg, err := config.PartialParseConfigFile(ctx.WithDecodeList(config.DependencyBlock, config.DependenciesBlock), l, configPath, nil)
+	require.NoError(t, err)
+
+	decl := cfg.FindDependencyDeclaration(configPath, filepath.Join(rootDir, "vpc"))
+	require.NotNil(t, decl)
+	assert.Equal(t, "vpc", decl.Name)
+	assert.Equal(t, 3, decl.Range.Start.Line)
+	assert.True(t, decl.SkipOutputs)
+
+	decl = cfg.FindDependencyDeclaration(configPath, filepath.Join(rootDir, "db"))
+	require.NotNil(t, decl)
+	assert.Empty(t, decl.Name)
+	assert.Equal(t, `dependencies at `+configPath+`:8`, decl.String())
+
+	assert.Nil(t, cfg.FindDependencyDeclaration(configPath, filepath.Join(rootDir, "cache")))
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"context"
+	"time"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+// fetchTimeout returns the timeout on fetching the outputs of the dependency, set by its `fetch_timeout_sec`
+// attribute, or else by `--dependency-fetch-timeout`. A zero timeout means the outputs are fetched without timeout.
+func (dep Dependency) fetchTimeout(ctx *ParsingContext) time.Duration {
+	if dep.FetchTimeoutSec != nil {
+		return time.Duration(*dep.FetchTimeoutSec) * time.Second
+	}
+
+	return time.Duration(ctx.TerragruntOptions.DependencyFetchTimeout) * time.Second
+}
+
+// withFetchTimeout returns the given function fetching the outputs of a dependency, failing each call that takes
+// longer than the fetch timeout of the dependency. Each attempt of `wait_for_outputs` gets the full timeout.
+func (dep Dependency) withFetchTimeout(
+	ctx *ParsingContext,
+	getOutput func(*ParsingContext, log.Logger, Dependency) (*cty.Value, bool, error),
+) func(*ParsingContext, log.Logger, Dependency) (*cty.Value, bool, error) {
+	timeout := dep.fetchTimeout(ctx)
+	if timeout <= 0 {
+		return getOutput
+	}
+
+	return func(ctx *ParsingContext, l log.Logger, dep Dependency) (*cty.Value, bool, error) {
+		timeoutCtx := *ctx
+
+		var cancel context.CancelFunc
+
+		timeoutCtx.Context, cancel = context.WithTimeout(ctx.Context, timeout)
+		defer cancel()
+
+		outputVal, isEmpty, err := getOutput(&timeoutCtx, l, dep)
+
+		// A command killed at the deadline fails with its own error, so the deadline of the context is checked rather
+		// than the error.
+		if err != nil && errors.Is(timeoutCtx.Err(), context.DeadlineExceeded) {
+			return nil, true, errors.New(DependencyOutputTimeoutError{Err: err, Dependency: dep.instanceName(), Timeout: timeout})
+		}
+
+		return outputVal, isEmpty, err
+	}
+}
@@ -0,0 +1,159 @@
+package config
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+	"gopkg.in/yaml.v3"
+
+	"github.com/gruntwork-io/terragrunt/config/hclparse"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+const mockOutputsFilePerm = 0644
+
+// mockOutputsFileLock ensures only one unit of a run --all records the outputs of a dependency to a mock outputs file
+// at a time.
+var mockOutputsFileLock sync.Mutex
+
+// mockOutputsFilePath returns the absolute path of the mock outputs file of the dependency. Relative paths are
+// resolved from the directory of the unit, like `config_path`.
+func (dep Dependency) mockOutputsFilePath(ctx *ParsingContext) string {
+	path := *dep.MockOutputsFile
+	if !filepath.IsAbs(path) {
+		path = util.JoinPath(filepath.Dir(ctx.TerragruntOptions.TerragruntConfigPath), path)
+	}
+
+	return util.CleanPath(path)
+}
+
+// loadMockOutputsFile sets the mock outputs of the dependency to the outputs declared in its mock outputs file, merged
+// with the `mock_outputs` of the block, which take precedence.
+func (dep *Dependency) loadMockOutputsFile(ctx *ParsingContext) error {
+	if dep.MockOutputsFile == nil {
+		return nil
+	}
+
+	path := dep.mockOutputsFilePath(ctx)
+
+	// The file is created by the first run recording the outputs of the dependency.
+	if ctx.TerragruntOptions.RecordDependencyMockOutputs && !util.FileExists(path) {
+		return nil
+	}
+
+	fileMockOutputs, err := readMockOutputsFile(path)
+	if err != nil {
+		return errors.New(MockOutputsFileError{Dependency: dep.Name, Path: path, Err: err})
+	}
+
+	if dep.MockOutputs == nil {
+		dep.MockOutputs = &fileMockOutputs
+		return nil
+	}
+
+	mockOutputs, err := deepMergeCtyMaps(fileMockOutputs, *dep.MockOutputs)
+	if err != nil {
+		return err
+	}
+
+	dep.MockOutputs = mockOutputs
+
+	return nil
+}
+
+// readMockOutputsFile returns the outputs declared in the given HCL, JSON or YAML file as an object.
+func readMockOutputsFile(path string) (cty.Value, error) {
+	file, err := hclparse.NewParser().ParseFromFile(path)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	attrs, err := file.JustAttributes()
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	outputs := make(map[string]cty.Value, len(attrs))
+
+	for _, attr := range attrs {
+		value, err := attr.Value(nil)
+		if err != nil {
+			return cty.NilVal, err
+		}
+
+		outputs[attr.Name] = value
+	}
+
+	return cty.ObjectVal(outputs), nil
+}
+
+// recordMockOutputsFile writes the given outputs of the dependency to its mock outputs file, in the format given by
+// the extension of the file.
+func (dep Dependency) recordMockOutputsFile(ctx *ParsingContext, l log.Logger, outputs cty.Value) error {
+	path := dep.mockOutputsFilePath(ctx)
+
+	outputs, _ = outputs.UnmarkDeep()
+
+	content, err := mockOutputsFileContent(filepath.Ext(path), outputs)
+	if err != nil {
+		return err
+	}
+
+	mockOutputsFileLock.Lock()
+	defer mockOutputsFileLock.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return errors.New(err)
+	}
+
+	if err := os.WriteFile(path, content, mockOutputsFilePerm); err != nil {
+		return errors.New(err)
+	}
+
+	l.Infof("Recorded outputs of dependency %s to %s", dep.Name, path)
+
+	return nil
+}
+
+// mockOutputsFileContent returns the content of a mock outputs file with the given extension declaring the outputs.
+func mockOutputsFileContent(ext string, outputs cty.Value) ([]byte, error) {
+	if ext != ".json" && ext != ".yaml" && ext != ".yml" {
+		file := hclwrite.NewEmptyFile()
+		valueMap := outputs.AsValueMap()
+
+		for _, name := range slices.Sorted(maps.Keys(valueMap)) {
+			file.Body().SetAttributeValue(name, valueMap[name])
+		}
+
+		return hclwrite.Format(file.Bytes()), nil
+	}
+
+	jsonBytes, err := ctyjson.Marshal(outputs, outputs.Type())
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	if ext == ".json" {
+		return append(jsonBytes, '\n'), nil
+	}
+
+	var data any
+	if err := yaml.Unmarshal(jsonBytes, &data); err != nil {
+		return nil, errors.New(err)
+	}
+
+	content, err := yaml.Marshal(data)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return content, nil
+}
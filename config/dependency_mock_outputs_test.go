@@ -0,0 +1,145 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+func TestDependencyMockOutputsFile(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		fileName string
+		content  string
+	}{
+		{
+			name:     "json",
+			fileName: "vpc.json",
+			content:  `{"vpc_id": "vpc-mock", "subnet_ids": ["subnet-a", "subnet-b"], "cidr": "10.0.0.0/16"}`,
+		},
+		{
+			name:     "yaml",
+			fileName: "vpc.yaml",
+			content:  "vpc_id: vpc-mock\nsubnet_ids:\n  - subnet-a\n  - subnet-b\ncidr: 10.0.0.0/16\n",
+		},
+		{
+			name:     "hcl",
+			fileName: "vpc.hcl",
+			content:  "vpc_id     = \"vpc-mock\"\nsubnet_ids = [\"subnet-a\", \"subnet-b\"]\ncidr       = \"10.0.0.0/16\"\n",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rootDir := t.TempDir()
+			appDir := filepath.Join(rootDir, "app")
+			configPath := filepath.Join(appDir, config.DefaultTerragruntConfigPath)
+
+			require.NoError(t, os.MkdirAll(filepath.Join(rootDir, "vpc"), 0755))
+			require.NoError(t, os.MkdirAll(filepath.Join(appDir, "mocks"), 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(rootDir, "vpc", config.DefaultTerragruntConfigPath), []byte(""), 0644))
+			require.NoError(t, os.WriteFile(filepath.Join(appDir, "mocks", tc.fileName), []byte(tc.content), 0644))
+			require.NoError(t, os.WriteFile(configPath, []byte(`
+dependency "vpc" {
+  config_path       = "../vpc"
+  skip_outputs      = true
+  mock_outputs_file = "mocks/`+tc.fileName+`"
+  mock_outputs = {
+    cidr = "10.1.0.0/16"
+  }
+}
+
+inputs = {
+  vpc_id     = dependency.vpc.outputs.vpc_id
+  subnet_ids = dependency.vpc.outputs.subnet_ids
+  cidr       = dependency.vpc.outputs.cidr
+}
+`), 0644))
+
+			l := createLogger()
+			ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, configPath))
+
+			cfg, err := config.ParseConfigFile(ctx, l, configPath, nil)
+			require.NoError(t, err)
+			assert.Equal(t, "vpc-mock", cfg.Inputs["vpc_id"])
+			assert.Equal(t, []any{"subnet-a", "subnet-b"}, cfg.Inputs["subnet_ids"])
+			assert.Equal(t, "10.1.0.0/16", cfg.Inputs["cidr"])
+		})
+	}
+}
+
+func TestDependencyMockOutputsFileNotFound(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	appDir := filepath.Join(rootDir, "app")
+	configPath := filepath.Join(appDir, config.DefaultTerragruntConfigPath)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(rootDir, "vpc"), 0755))
+	require.NoError(t, os.MkdirAll(appDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "vpc", config.DefaultTerragruntConfigPath), []byte(""), 0644))
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+dependency "vpc" {
+  config_path       = "../vpc"
+  skip_outputs      = true
+  mock_outputs_file = "mocks/vpc.json"
+}
+`), 0644))
+
+	l := createLogger()
+	ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, configPath))
+
+	_, err := config.ParseConfigFile(ctx, l, configPath, nil)
+
+	var mockOutputsFileErr config.MockOutputsFileError
+
+	require.ErrorAs(t, err, &mockOutputsFileErr)
+	assert.Equal(t, "vpc", mockOutputsFileErr.Dependency)
+	assert.Equal(t, filepath.Join(appDir, "mocks", "vpc.json"), mockOutputsFileErr.Path)
+}
+
+func TestRecordDependencyMockOutputs(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	vpcDir := filepath.Join(rootDir, "vpc")
+	appDir := filepath.Join(rootDir, "app")
+	configPath := filepath.Join(appDir, config.DefaultTerragruntConfigPath)
+	mockOutputsPath := filepath.Join(appDir, "mocks", "vpc.json")
+
+	// The outputs of the dependency are read from the dependency output cache, so no OpenTofu/Terraform binary is run.
+	require.NoError(t, os.MkdirAll(filepath.Join(vpcDir, util.TerragruntCacheDir), 0755))
+	require.NoError(t, os.MkdirAll(appDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(vpcDir, config.DefaultTerragruntConfigPath), []byte(""), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(vpcDir, util.TerragruntCacheDir, config.DependencyOutputCacheFile), []byte(`{
+  "outputs": {"vpc_id": {"sensitive": false, "type": "string", "value": "vpc-123"}}
+}`), 0644))
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+dependency "vpc" {
+  config_path       = "../vpc"
+  mock_outputs_file = "mocks/vpc.json"
+}
+`), 0644))
+
+	l := createLogger()
+	opts := mockOptionsForTestWithConfigPath(t, configPath)
+	opts.DependencyOutputCache = true
+	opts.RecordDependencyMockOutputs = true
+
+	_, err := config.ParseConfigFile(config.NewParsingContext(t.Context(), l, opts), l, configPath, nil)
+	require.NoError(t, err)
+
+	content, err := os.ReadFile(mockOutputsPath)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"vpc_id": "vpc-123"}`, string(content))
+}
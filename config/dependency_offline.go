@@ -0,0 +1,124 @@
+package config
+
+import (
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/gocty"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+// MockedDependencyOutputs are the mock outputs of a dependency used in place of its actual outputs, because the
+// outputs of dependencies were resolved offline and weren't cached.
+type MockedDependencyOutputs struct {
+	// ConfigPath is the path of the config of the unit the dependency belongs to.
+	ConfigPath string
+	// Dependency is the name of the dependency block.
+	Dependency string
+	// Outputs are the names of the mocked outputs.
+	Outputs []string
+}
+
+var (
+	offlineMockedOutputs   []MockedDependencyOutputs
+	offlineMockedOutputsMu sync.Mutex
+)
+
+// OfflineMockedDependencyOutputs returns the dependency outputs that were mocked while resolving dependencies offline,
+// sorted by config path and dependency name.
+func OfflineMockedDependencyOutputs() []MockedDependencyOutputs {
+	offlineMockedOutputsMu.Lock()
+	defer offlineMockedOutputsMu.Unlock()
+
+	mocked := slices.Clone(offlineMockedOutputs)
+
+	slices.SortFunc(mocked, func(a, b MockedDependencyOutputs) int {
+		if c := strings.Compare(a.ConfigPath, b.ConfigPath); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.Dependency, b.Dependency)
+	})
+
+	return mocked
+}
+
+// recordOfflineMockedOutputs records that the mock outputs of the given dependency of the given config were used.
+// Configs are parsed several times during a run, so each dependency is only recorded once.
+func recordOfflineMockedOutputs(configPath string, dep Dependency) {
+	mocked := MockedDependencyOutputs{ConfigPath: configPath, Dependency: dep.instanceName()}
+
+	if dep.MockOutputs != nil && !dep.MockOutputs.IsNull() && dep.MockOutputs.CanIterateElements() {
+		for name := range dep.MockOutputs.AsValueMap() {
+			mocked.Outputs = append(mocked.Outputs, name)
+		}
+
+		slices.Sort(mocked.Outputs)
+	}
+
+	offlineMockedOutputsMu.Lock()
+	defer offlineMockedOutputsMu.Unlock()
+
+	if slices.ContainsFunc(offlineMockedOutputs, func(m MockedDependencyOutputs) bool {
+		return m.ConfigPath == mocked.ConfigPath && m.Dependency == mocked.Dependency
+	}) {
+		return
+	}
+
+	offlineMockedOutputs = append(offlineMockedOutputs, mocked)
+}
+
+// getOfflineOutputs returns the outputs of the dependency without accessing its backend, or any other network
+// resource: the outputs already fetched during the run, or cached on disk by a previous run, if any, else the mock
+// outputs of the dependency. The `mock_outputs_allowed_terraform_commands` attribute is ignored, as there are no other
+// outputs to use.
+func (dep Dependency) getOfflineOutputs(ctx *ParsingContext, l log.Logger) (*cty.Value, error) {
+	if !dep.IsExternal() {
+		targetConfig := getCleanedTargetConfigPath(dep.ConfigPath.AsString(), ctx.TerragruntOptions.TerragruntConfigPath)
+
+		if jsonBytes, found := offlineOutputJSON(ctx, l, targetConfig); found {
+			outputMap, err := dependencyOutputJSONToCtyValueMap(ctx, l, targetConfig, jsonBytes)
+			if err != nil {
+				return nil, err
+			}
+
+			outputs, err := gocty.ToCtyValue(outputMap, generateTypeFromValuesMap(outputMap))
+			if err != nil {
+				return nil, errors.New(TerragruntOutputEncodingError{Path: targetConfig, Err: err})
+			}
+
+			return &outputs, nil
+		}
+	}
+
+	if dep.MockOutputs == nil {
+		return nil, errors.New(DependencyOfflineNoOutputsError{Dependency: dep.instanceName(), Target: dep.target(), Config: ctx.TerragruntOptions.TerragruntConfigPath})
+	}
+
+	l.Warnf("Using the mock outputs of dependency %s (%s) in %s, as dependencies are resolved offline and its outputs aren't cached.", dep.instanceName(), dep.target(), ctx.TerragruntOptions.TerragruntConfigPath)
+
+	recordOfflineMockedOutputs(ctx.TerragruntOptions.TerragruntConfigPath, dep)
+
+	return dep.MockOutputs, nil
+}
+
+// offlineOutputJSON returns the outputs of the unit with the given config already fetched during the run, or cached
+// on disk, if any.
+func offlineOutputJSON(ctx *ParsingContext, l log.Logger, targetConfig string) ([]byte, bool) {
+	if jsonBytes, found := jsonOutputCache.Load(targetConfig); found {
+		return jsonBytes.([]byte), true
+	}
+
+	if ctx.TerragruntOptions.NoDependencyOutputCache {
+		return nil, false
+	}
+
+	// The state of the dependency isn't checked offline, so the cached outputs are never stale.
+	jsonBytes, found, _ := readDependencyOutputCache(ctx, l, targetConfig)
+
+	return jsonBytes, found
+}
@@ -0,0 +1,171 @@
+package config_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/redact"
+	"github.com/gruntwork-io/terragrunt/internal/report"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+func TestParseDependencyOffline(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		cached        string
+		mockOutputs   string
+		expectedErr   bool
+		expectedVPCID string
+		expectMocked  bool
+	}{
+		{
+			name:          "cached outputs",
+			cached:        `{"fetched_at": "2025-01-01T00:00:00Z", "outputs": {"vpc_id": {"sensitive": false, "type": "string", "value": "vpc-cached"}, "db_password": {"sensitive": true, "type": "string", "value": "hunter2"}}}`,
+			mockOutputs:   `mock_outputs = { vpc_id = "vpc-mock" }`,
+			expectedVPCID: "vpc-cached",
+		},
+		{
+			name: "mock outputs",
+			// The commands mocks are allowed for are ignored offline.
+			mockOutputs: `mock_outputs = { vpc_id = "vpc-mock" }
+  mock_outputs_allowed_terraform_commands = ["validate"]`,
+			expectedVPCID: "vpc-mock",
+			expectMocked:  true,
+		},
+		{
+			name:        "no outputs",
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rootDir := t.TempDir()
+			vpcDir := filepath.Join(rootDir, "vpc")
+			appConfigPath := filepath.Join(rootDir, "app", config.DefaultTerragruntConfigPath)
+
+			require.NoError(t, os.MkdirAll(filepath.Join(vpcDir, util.TerragruntCacheDir), 0755))
+			require.NoError(t, os.MkdirAll(filepath.Dir(appConfigPath), 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(vpcDir, config.DefaultTerragruntConfigPath), []byte(``), 0644))
+			require.NoError(t, os.WriteFile(appConfigPath, []byte(`
+dependency "vpc" {
+  config_path = "../vpc"
+  `+tc.mockOutputs+`
+}
+
+inputs = {
+  vpc_id = dependency.vpc.outputs.vpc_id
+}
+`), 0644))
+
+			if tc.cached != "" {
+				require.NoError(t, os.WriteFile(filepath.Join(vpcDir, util.TerragruntCacheDir, config.DependencyOutputCacheFile), []byte(tc.cached), 0600))
+			}
+
+			l := createLogger()
+			opts := mockOptionsForTestWithConfigPath(t, appConfigPath)
+			opts.TerraformCommand = "plan"
+			opts.OriginalTerraformCommand = "plan"
+			opts.DependencyOffline = true
+			opts.RunTerragrunt = func(_ context.Context, _ log.Logger, _ *options.TerragruntOptions, _ *report.Report) error {
+				return errors.New("the outputs of dependencies must not be fetched offline")
+			}
+
+			cfg, err := config.ParseConfigFile(config.NewParsingContext(t.Context(), l, opts), l, appConfigPath, nil)
+			if tc.expectedErr {
+				var offlineErr config.DependencyOfflineNoOutputsError
+
+				require.ErrorAs(t, err, &offlineErr)
+				assert.Equal(t, "vpc", offlineErr.Dependency)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedVPCID, cfg.Inputs["vpc_id"])
+
+			// The values of the sensitive outputs of the dependency are redacted.
+			if tc.cached != "" {
+				assert.Equal(t, "vpc_id=vpc-cached db_password="+redact.Placeholder, opts.Redactor.Redact("vpc_id=vpc-cached db_password=hunter2"))
+			}
+
+			var mocked []config.MockedDependencyOutputs
+
+			for _, m := range config.OfflineMockedDependencyOutputs() {
+				if m.ConfigPath == appConfigPath {
+					mocked = append(mocked, m)
+				}
+			}
+
+			if !tc.expectMocked {
+				assert.Empty(t, mocked)
+				return
+			}
+
+			assert.Equal(t, []config.MockedDependencyOutputs{{ConfigPath: appConfigPath, Dependency: "vpc", Outputs: []string{"vpc_id"}}}, mocked)
+		})
+	}
+}
+
+func TestParseDependencyOfflineExternal(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		target string
+	}{
+		{
+			name:   "tfe workspace",
+			target: `tfe_workspace = "acme/networking"`,
+		},
+		{
+			name: "cloudformation stack",
+			target: `cloudformation_stack = "legacy-network"
+  region               = "us-east-1"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			appConfigPath := filepath.Join(t.TempDir(), config.DefaultTerragruntConfigPath)
+			require.NoError(t, os.WriteFile(appConfigPath, []byte(`
+dependency "networking" {
+  `+tc.target+`
+
+  mock_outputs = {
+    vpc_id = "vpc-mock"
+  }
+}
+
+inputs = {
+  vpc_id = dependency.networking.outputs.vpc_id
+}
+`), 0644))
+
+			l := createLogger()
+			opts := mockOptionsForTestWithConfigPath(t, appConfigPath)
+			opts.TerraformCommand = "plan"
+			opts.OriginalTerraformCommand = "plan"
+			opts.DependencyOffline = true
+
+			cfg, err := config.ParseConfigFile(config.NewParsingContext(t.Context(), l, opts), l, appConfigPath, nil)
+			require.NoError(t, err)
+			assert.Equal(t, "vpc-mock", cfg.Inputs["vpc_id"])
+		})
+	}
+}
@@ -0,0 +1,367 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	"github.com/gruntwork-io/terragrunt/awshelper"
+	"github.com/gruntwork-io/terragrunt/config/hclparse"
+	"github.com/gruntwork-io/terragrunt/internal/encryption"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/remotestate"
+	gcsbackend "github.com/gruntwork-io/terragrunt/internal/remotestate/backend/gcs"
+	s3backend "github.com/gruntwork-io/terragrunt/internal/remotestate/backend/s3"
+	"github.com/gruntwork-io/terragrunt/internal/strict/controls"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+const (
+	// DependencyOutputCacheFile is the name of the file, in the Terragrunt cache dir of a unit, where the outputs of
+	// the unit are cached for its dependents when the dependency output cache is enabled.
+	DependencyOutputCacheFile = "dependency-outputs.json"
+
+	// The outputs of dependencies regularly contain secrets, so the cache files are only readable by their owner.
+	dependencyOutputCacheFilePerm = 0600
+
+	localBackendName = "local"
+)
+
+// dependencyOutputCacheEntry is the content of the dependency output cache file of a unit.
+type dependencyOutputCacheEntry struct {
+	FetchedAt time.Time       `json:"fetched_at"`
+	Outputs   json.RawMessage `json:"outputs,omitempty"`
+	// EncryptedOutputs is the envelope of the outputs encrypted with age or AWS KMS, set instead of Outputs when the
+	// cache is encrypted.
+	EncryptedOutputs string `json:"encrypted_outputs,omitempty"`
+	// StateVersion identifies the version of the state of the unit the outputs were fetched from, if known, see
+	// stateVersion.
+	StateVersion string `json:"state_version,omitempty"`
+}
+
+// useDependencyOutputCache returns true if the outputs of dependencies are cached on disk.
+func useDependencyOutputCache(opts *options.TerragruntOptions) bool {
+	return opts.DependencyOutputCache && !opts.NoDependencyOutputCache
+}
+
+// encryptsDependencyOutputCache returns true if the outputs of dependencies cached on disk are encrypted.
+func encryptsDependencyOutputCache(opts *options.TerragruntOptions) bool {
+	return opts.DependencyOutputCacheKMSKeyID != "" || len(opts.DependencyOutputCacheAgeRecipients) > 0
+}
+
+// dependencyOutputCachePath returns the path of the dependency output cache file of the unit with the given config.
+func dependencyOutputCachePath(targetConfig string) string {
+	return filepath.Join(filepath.Dir(targetConfig), util.TerragruntCacheDir, DependencyOutputCacheFile)
+}
+
+// readDependencyOutputCache returns the outputs of the unit with the given config cached on disk by a previous run, if
+// any. A cache file that can't be read or decrypted is treated as a cache miss, and so is a cache file that isn't
+// encrypted when the cache must be, so it is replaced by an encrypted one.
+//
+// Unless dependencies are resolved offline, the cached outputs are also treated as a cache miss if the version of the
+// state of the unit changed since they were fetched, e.g. by an apply from another machine, or an error is returned if
+// the `stale-dependency-outputs` strict control is enabled.
+func readDependencyOutputCache(ctx *ParsingContext, l log.Logger, targetConfig string) ([]byte, bool, error) {
+	cachePath := dependencyOutputCachePath(targetConfig)
+
+	data, err := os.ReadFile(cachePath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			l.Debugf("Failed to read dependency output cache %s: %v", cachePath, err)
+		}
+
+		return nil, false, nil
+	}
+
+	var entry dependencyOutputCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || (len(entry.Outputs) == 0 && entry.EncryptedOutputs == "") {
+		l.Debugf("Ignoring invalid dependency output cache %s", cachePath)
+		return nil, false, nil
+	}
+
+	if !ctx.TerragruntOptions.DependencyOffline {
+		if stale, err := isDependencyOutputCacheStale(ctx, l, targetConfig, &entry); err != nil || stale {
+			return nil, false, err
+		}
+	}
+
+	outputs := []byte(entry.Outputs)
+
+	switch {
+	case entry.EncryptedOutputs != "":
+		plaintext, err := encryption.Decrypt(ctx, l, ctx.TerragruntOptions, entry.EncryptedOutputs)
+		if err != nil {
+			l.Debugf("Ignoring dependency output cache %s that can't be decrypted: %v", cachePath, err)
+			return nil, false, nil
+		}
+
+		outputs = []byte(plaintext)
+	case encryptsDependencyOutputCache(ctx.TerragruntOptions):
+		l.Debugf("Ignoring unencrypted dependency output cache %s", cachePath)
+		return nil, false, nil
+	}
+
+	l.Debugf("Using outputs of %s cached on disk at %s", targetConfig, entry.FetchedAt.Format(time.RFC3339))
+
+	return outputs, true, nil
+}
+
+// isDependencyOutputCacheStale returns true if the state of the unit with the given config changed after its outputs
+// were cached in the given entry. The cache is keyed by the version of the state reported by the backend of the unit,
+// when the entry records it, and otherwise by the time the state was last modified. If the `stale-dependency-outputs`
+// strict control is enabled, an error is returned instead.
+func isDependencyOutputCacheStale(ctx *ParsingContext, l log.Logger, targetConfig string, entry *dependencyOutputCacheEntry) (bool, error) {
+	version, ok := dependencyStateVersion(ctx, l, targetConfig)
+	if !ok {
+		return false, nil
+	}
+
+	if entry.StateVersion != "" && version.ID != "" {
+		if entry.StateVersion == version.ID {
+			return false, nil
+		}
+	} else if !version.ModifiedAt.After(entry.FetchedAt) {
+		return false, nil
+	}
+
+	control := ctx.TerragruntOptions.StrictControls.Find(controls.StaleDependencyOutputs)
+	if control == nil {
+		return false, errors.New("failed to find control " + controls.StaleDependencyOutputs)
+	}
+
+	if err := control.Evaluate(log.ContextWithLogger(ctx, l)); err != nil {
+		return false, errors.New(DependencyOutputCacheStaleError{Target: targetConfig, FetchedAt: entry.FetchedAt, ModifiedAt: version.ModifiedAt})
+	}
+
+	l.Warnf("The outputs of %s cached at %s are stale, as its state was modified at %s. Fetching them again.", targetConfig, entry.FetchedAt.Format(time.RFC3339), version.ModifiedAt.Format(time.RFC3339))
+
+	return true, nil
+}
+
+// stateVersion identifies a version of the state of a unit.
+type stateVersion struct {
+	ModifiedAt time.Time
+	// ID changes whenever the state is written: the version ID or ETag of an s3 object, the generation of a gcs object,
+	// or the lineage and serial of a local state file. It is empty if the backend doesn't report it.
+	ID string
+}
+
+// dependencyStateVersion returns the version of the state of the unit with the given config, as reported by its
+// `remote_state` backend, without downloading the state. The returned bool is false if the version can't be
+// determined, e.g. because the unit has no `remote_state` block or its backend isn't s3, gcs or local.
+func dependencyStateVersion(ctx *ParsingContext, l log.Logger, targetConfig string) (*stateVersion, bool) {
+	l, targetOpts, err := cloneTerragruntOptionsForDependency(ctx, l, targetConfig)
+	if err != nil {
+		return nil, false
+	}
+
+	targetCtx := ctx.WithTerragruntOptions(targetOpts)
+
+	cfg, err := PartialParseConfigFile(
+		targetCtx.WithParseOption(append(targetCtx.ParserOptions, hclparse.WithDiagnosticsWriter(io.Discard, true))).WithDecodeList(RemoteStateBlock),
+		l,
+		targetConfig,
+		nil,
+	)
+	if err != nil || cfg.RemoteState == nil {
+		return nil, false
+	}
+
+	remoteState := cfg.RemoteState
+
+	var version *stateVersion
+
+	switch remoteState.BackendName {
+	case s3backend.BackendName:
+		version, err = s3StateVersion(l, targetOpts, remoteState)
+	case gcsbackend.BackendName:
+		version, err = gcsStateVersion(ctx, remoteState)
+	case localBackendName:
+		statePath, ok := remoteState.BackendConfig["path"].(string)
+		if !ok {
+			return nil, false
+		}
+
+		if !filepath.IsAbs(statePath) {
+			statePath = filepath.Join(filepath.Dir(targetConfig), statePath)
+		}
+
+		version, err = localStateVersion(statePath)
+	default:
+		return nil, false
+	}
+
+	if err != nil {
+		l.Debugf("Failed to get the version of the state of %s: %v", targetConfig, err)
+		return nil, false
+	}
+
+	return version, true
+}
+
+// localStateVersion returns the version of the state file at the given path, identified by its lineage and serial.
+func localStateVersion(statePath string) (*stateVersion, error) {
+	info, err := os.Stat(statePath)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	version := &stateVersion{ModifiedAt: info.ModTime()}
+
+	data, err := os.ReadFile(statePath)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	var state struct {
+		Lineage string `json:"lineage"`
+		Serial  int64  `json:"serial"`
+	}
+
+	if err := json.Unmarshal(data, &state); err == nil && state.Lineage != "" {
+		version.ID = fmt.Sprintf("%s/%d", state.Lineage, state.Serial)
+	}
+
+	return version, nil
+}
+
+// s3StateVersion returns the version of the state object in the s3 bucket of the given remote state, identified by its
+// version ID if the bucket is versioned, and otherwise by its ETag.
+func s3StateVersion(l log.Logger, opts *options.TerragruntOptions, remoteState *remotestate.RemoteState) (*stateVersion, error) {
+	s3ConfigExtended, err := s3backend.Config(remoteState.BackendConfig).ParseExtendedS3Config()
+	if err != nil {
+		return nil, err
+	}
+
+	sessionConfig := s3ConfigExtended.GetAwsSessionConfig()
+	if sessionConfig.Profile == "" {
+		sessionConfig.Profile = opts.Env["AWS_PROFILE"]
+	}
+
+	s3Client, err := awshelper.CreateS3Client(l, sessionConfig, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s3Client.HeadObject(&s3.HeadObjectInput{
+		Bucket: aws.String(fmt.Sprintf("%s", remoteState.BackendConfig["bucket"])),
+		Key:    aws.String(fmt.Sprintf("%s", remoteState.BackendConfig["key"])),
+	})
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	id := aws.StringValue(result.VersionId)
+	if id == "" || id == "null" {
+		id = aws.StringValue(result.ETag)
+	}
+
+	return &stateVersion{ModifiedAt: aws.TimeValue(result.LastModified), ID: id}, nil
+}
+
+// gcsStateVersion returns the version of the state object in the gcs bucket of the given remote state, identified by
+// its generation.
+func gcsStateVersion(ctx *ParsingContext, remoteState *remotestate.RemoteState) (*stateVersion, error) {
+	extGCSCfg, err := gcsbackend.Config(remoteState.BackendConfig).ParseExtendedGCSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	gcsClient, err := gcsbackend.NewClient(ctx, extGCSCfg)
+	if err != nil {
+		return nil, err
+	}
+	defer gcsClient.Close() //nolint:errcheck
+
+	attrs, err := gcsClient.GetGCSObjectAttrs(ctx, extGCSCfg.RemoteStateConfigGCS.Bucket, extGCSCfg.StateKey())
+	if err != nil {
+		return nil, err
+	}
+
+	return &stateVersion{ModifiedAt: attrs.Updated, ID: strconv.FormatInt(attrs.Generation, 10)}, nil
+}
+
+// writeDependencyOutputCache caches the given outputs of the unit with the given config on disk, so they can be reused
+// by the following runs until the unit is applied again. The outputs are encrypted with the age recipients or the AWS
+// KMS key of the options, if any.
+func writeDependencyOutputCache(ctx *ParsingContext, l log.Logger, targetConfig string, jsonBytes []byte) error {
+	if !json.Valid(jsonBytes) {
+		return nil
+	}
+
+	entry := dependencyOutputCacheEntry{FetchedAt: time.Now().UTC()}
+
+	opts := ctx.TerragruntOptions
+
+	if !opts.DependencyOffline {
+		if version, ok := dependencyStateVersion(ctx, l, targetConfig); ok {
+			entry.StateVersion = version.ID
+		}
+	}
+
+	switch {
+	case opts.DependencyOutputCacheKMSKeyID != "":
+		envelope, err := encryption.EncryptKMS(ctx, l, opts, opts.DependencyOutputCacheKMSKeyID, jsonBytes)
+		if err != nil {
+			return err
+		}
+
+		entry.EncryptedOutputs = envelope.String()
+	case len(opts.DependencyOutputCacheAgeRecipients) > 0:
+		envelope, err := encryption.EncryptAge(jsonBytes, opts.DependencyOutputCacheAgeRecipients)
+		if err != nil {
+			return err
+		}
+
+		entry.EncryptedOutputs = envelope.String()
+	default:
+		entry.Outputs = jsonBytes
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	cachePath := dependencyOutputCachePath(targetConfig)
+
+	if err := os.MkdirAll(filepath.Dir(cachePath), os.ModePerm); err != nil {
+		return errors.New(err)
+	}
+
+	if err := os.WriteFile(cachePath, data, dependencyOutputCacheFilePerm); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// InvalidateDependencyOutputCache discards the outputs of the unit with the given config cached in memory and on disk,
+// so the dependents of the unit fetch its outputs again. It is called once a command that can change the state of the
+// unit, such as `apply`, has been run.
+func InvalidateDependencyOutputCache(l log.Logger, configPath string) {
+	targetConfig := util.CleanPath(configPath)
+
+	rawActualLock, _ := outputLocks.LoadOrStore(targetConfig, &sync.Mutex{})
+	actualLock := rawActualLock.(*sync.Mutex)
+	defer actualLock.Unlock()
+	actualLock.Lock()
+
+	jsonOutputCache.Delete(targetConfig)
+
+	cachePath := dependencyOutputCachePath(targetConfig)
+
+	if err := os.Remove(cachePath); err != nil && !os.IsNotExist(err) {
+		l.Warnf("Failed to remove dependency output cache %s: %v", cachePath, err)
+	}
+}
@@ -0,0 +1,320 @@
+package config_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/encryption"
+	"github.com/gruntwork-io/terragrunt/internal/report"
+	"github.com/gruntwork-io/terragrunt/internal/strict/controls"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+func TestDependencyOutputCache(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	vpcDir := filepath.Join(rootDir, "vpc")
+	appDir := filepath.Join(rootDir, "app")
+	vpcConfigPath := filepath.Join(vpcDir, config.DefaultTerragruntConfigPath)
+	appConfigPath := filepath.Join(appDir, config.DefaultTerragruntConfigPath)
+	cachePath := filepath.Join(vpcDir, util.TerragruntCacheDir, config.DependencyOutputCacheFile)
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(cachePath), 0755))
+	require.NoError(t, os.MkdirAll(appDir, 0755))
+	require.NoError(t, os.WriteFile(vpcConfigPath, []byte(``), 0644))
+	require.NoError(t, os.WriteFile(appConfigPath, []byte(`
+dependency "vpc" {
+  config_path = "../vpc"
+}
+
+inputs = {
+  vpc_id = dependency.vpc.outputs.vpc_id
+}
+`), 0644))
+	require.NoError(t, os.WriteFile(cachePath, []byte(`{
+  "fetched_at": "2025-01-01T00:00:00Z",
+  "outputs": {"vpc_id": {"sensitive": false, "type": "string", "value": "vpc-123"}}
+}`), 0644))
+
+	l := createLogger()
+	opts := mockOptionsForTestWithConfigPath(t, appConfigPath)
+	opts.DependencyOutputCache = true
+
+	cfg, err := config.ParseConfigFile(config.NewParsingContext(t.Context(), l, opts), l, appConfigPath, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "vpc-123", cfg.Inputs["vpc_id"])
+
+	config.InvalidateDependencyOutputCache(l, vpcConfigPath)
+	assert.NoFileExists(t, cachePath)
+}
+
+func TestDependencyOutputCacheEncrypted(t *testing.T) {
+	t.Parallel()
+
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	identityFile := filepath.Join(t.TempDir(), "keys.txt")
+	require.NoError(t, os.WriteFile(identityFile, []byte(identity.String()+"\n"), 0600))
+
+	testCases := []struct {
+		name             string
+		cached           func(t *testing.T) string
+		expectRun        bool
+		expectEncrypted  bool
+		noCache          bool
+		noCacheFileAfter bool
+	}{
+		{
+			name: "encrypted cache is decrypted",
+			cached: func(t *testing.T) string {
+				t.Helper()
+
+				envelope, err := encryption.EncryptAge([]byte(`{"vpc_id": {"sensitive": false, "type": "string", "value": "vpc-123"}}`), []string{identity.Recipient().String()})
+				require.NoError(t, err)
+
+				return fmt.Sprintf(`{"fetched_at": "2025-01-01T00:00:00Z", "encrypted_outputs": %q}`, envelope.String())
+			},
+			expectEncrypted: true,
+		},
+		{
+			name: "unencrypted cache is replaced",
+			cached: func(t *testing.T) string {
+				t.Helper()
+
+				return `{"fetched_at": "2025-01-01T00:00:00Z", "outputs": {"vpc_id": {"sensitive": false, "type": "string", "value": "vpc-stale"}}}`
+			},
+			expectRun:       true,
+			expectEncrypted: true,
+		},
+		{
+			name:            "missing cache is written encrypted",
+			expectRun:       true,
+			expectEncrypted: true,
+		},
+		{
+			name:             "cache disabled",
+			noCache:          true,
+			expectRun:        true,
+			noCacheFileAfter: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rootDir := t.TempDir()
+			vpcDir := filepath.Join(rootDir, "vpc")
+			appConfigPath := filepath.Join(rootDir, "app", config.DefaultTerragruntConfigPath)
+			cachePath := filepath.Join(vpcDir, util.TerragruntCacheDir, config.DependencyOutputCacheFile)
+
+			require.NoError(t, os.MkdirAll(filepath.Dir(cachePath), 0755))
+			require.NoError(t, os.MkdirAll(filepath.Dir(appConfigPath), 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(vpcDir, config.DefaultTerragruntConfigPath), []byte(``), 0644))
+			require.NoError(t, os.WriteFile(appConfigPath, []byte(`
+dependency "vpc" {
+  config_path = "../vpc"
+}
+
+inputs = {
+  vpc_id = dependency.vpc.outputs.vpc_id
+}
+`), 0644))
+
+			if tc.cached != nil {
+				require.NoError(t, os.WriteFile(cachePath, []byte(tc.cached(t)), 0600))
+			}
+
+			l := createLogger()
+			opts := mockOptionsForTestWithConfigPath(t, appConfigPath)
+			opts.DependencyOutputCache = true
+			opts.NoDependencyOutputCache = tc.noCache
+			opts.DependencyOutputCacheAgeRecipients = []string{identity.Recipient().String()}
+			opts.AgeIdentityFile = identityFile
+
+			ran := false
+			opts.RunTerragrunt = func(_ context.Context, _ log.Logger, opts *options.TerragruntOptions, _ *report.Report) error {
+				ran = true
+				_, err := fmt.Fprint(opts.Writer, `{"vpc_id": {"sensitive": false, "type": "string", "value": "vpc-123"}}`)
+
+				return err
+			}
+
+			cfg, err := config.ParseConfigFile(config.NewParsingContext(t.Context(), l, opts), l, appConfigPath, nil)
+			require.NoError(t, err)
+			assert.Equal(t, "vpc-123", cfg.Inputs["vpc_id"])
+			assert.Equal(t, tc.expectRun, ran)
+
+			if tc.noCacheFileAfter {
+				assert.NoFileExists(t, cachePath)
+				return
+			}
+
+			data, err := os.ReadFile(cachePath)
+			require.NoError(t, err)
+			assert.NotContains(t, string(data), "vpc-")
+
+			var entry map[string]any
+
+			require.NoError(t, json.Unmarshal(data, &entry))
+			assert.Equal(t, tc.expectEncrypted, entry["encrypted_outputs"] != nil)
+		})
+	}
+}
+
+func TestDependencyOutputCacheStale(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name                 string
+		stateModified        time.Time
+		state                string
+		cachedVersion        string
+		strict               bool
+		expectedVPCID        string
+		expectedStateVersion string
+		expectedErr          bool
+	}{
+		{
+			name:          "state older than the cache",
+			stateModified: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedVPCID: "vpc-cached",
+		},
+		{
+			name:          "state newer than the cache",
+			stateModified: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+			expectedVPCID: "vpc-123",
+		},
+		{
+			name:          "state newer than the cache in strict mode",
+			stateModified: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+			strict:        true,
+			expectedErr:   true,
+		},
+		{
+			name:          "state newer than the cache with the same serial and lineage",
+			stateModified: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+			state:         `{"serial": 3, "lineage": "vpc-lineage"}`,
+			cachedVersion: `"state_version": "vpc-lineage/3",`,
+			expectedVPCID: "vpc-cached",
+		},
+		{
+			name:                 "state older than the cache with a newer serial",
+			stateModified:        time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			state:                `{"serial": 4, "lineage": "vpc-lineage"}`,
+			cachedVersion:        `"state_version": "vpc-lineage/3",`,
+			expectedVPCID:        "vpc-123",
+			expectedStateVersion: "vpc-lineage/4",
+		},
+		{
+			name:                 "state older than the cache with another lineage",
+			stateModified:        time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			state:                `{"serial": 3, "lineage": "other-lineage"}`,
+			cachedVersion:        `"state_version": "vpc-lineage/3",`,
+			expectedVPCID:        "vpc-123",
+			expectedStateVersion: "other-lineage/3",
+		},
+		{
+			name:          "state with a newer serial in strict mode",
+			stateModified: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			state:         `{"serial": 4, "lineage": "vpc-lineage"}`,
+			cachedVersion: `"state_version": "vpc-lineage/3",`,
+			strict:        true,
+			expectedErr:   true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rootDir := t.TempDir()
+			vpcDir := filepath.Join(rootDir, "vpc")
+			statePath := filepath.Join(vpcDir, "terraform.tfstate")
+			appConfigPath := filepath.Join(rootDir, "app", config.DefaultTerragruntConfigPath)
+			cachePath := filepath.Join(vpcDir, util.TerragruntCacheDir, config.DependencyOutputCacheFile)
+
+			require.NoError(t, os.MkdirAll(filepath.Dir(cachePath), 0755))
+			require.NoError(t, os.MkdirAll(filepath.Dir(appConfigPath), 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(vpcDir, config.DefaultTerragruntConfigPath), []byte(`
+remote_state {
+  backend                         = "local"
+  disable_dependency_optimization = true
+  config = {
+    path = "terraform.tfstate"
+  }
+}
+`), 0644))
+
+			state := tc.state
+			if state == "" {
+				state = `{}`
+			}
+
+			require.NoError(t, os.WriteFile(statePath, []byte(state), 0644))
+			require.NoError(t, os.Chtimes(statePath, tc.stateModified, tc.stateModified))
+			require.NoError(t, os.WriteFile(cachePath, []byte(`{"fetched_at": "2025-01-01T00:00:00Z", `+tc.cachedVersion+` "outputs": {"vpc_id": {"sensitive": false, "type": "string", "value": "vpc-cached"}}}`), 0600))
+			require.NoError(t, os.WriteFile(appConfigPath, []byte(`
+dependency "vpc" {
+  config_path = "../vpc"
+}
+
+inputs = {
+  vpc_id = dependency.vpc.outputs.vpc_id
+}
+`), 0644))
+
+			l := createLogger()
+			opts := mockOptionsForTestWithConfigPath(t, appConfigPath)
+			opts.DependencyOutputCache = true
+
+			if tc.strict {
+				require.NoError(t, opts.StrictControls.EnableControl(controls.StaleDependencyOutputs))
+			}
+
+			opts.RunTerragrunt = func(_ context.Context, _ log.Logger, opts *options.TerragruntOptions, _ *report.Report) error {
+				_, err := fmt.Fprint(opts.Writer, `{"vpc_id": {"sensitive": false, "type": "string", "value": "vpc-123"}}`)
+
+				return err
+			}
+
+			cfg, err := config.ParseConfigFile(config.NewParsingContext(t.Context(), l, opts), l, appConfigPath, nil)
+			if tc.expectedErr {
+				var staleErr config.DependencyOutputCacheStaleError
+
+				require.ErrorAs(t, err, &staleErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedVPCID, cfg.Inputs["vpc_id"])
+
+			if tc.state == "" || tc.expectedVPCID == "vpc-cached" {
+				return
+			}
+
+			data, err := os.ReadFile(cachePath)
+			require.NoError(t, err)
+
+			var entry map[string]any
+
+			require.NoError(t, json.Unmarshal(data, &entry))
+			assert.Equal(t, tc.expectedStateVersion, entry["state_version"])
+		})
+	}
+}
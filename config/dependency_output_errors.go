@@ -0,0 +1,164 @@
+package config
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+
+	"cloud.google.com/go/storage"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"google.golang.org/api/googleapi"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend/azurerm"
+	httpbackend "github.com/gruntwork-io/terragrunt/internal/remotestate/backend/http"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// DependencyOutputErrorClass is the class of a failure to fetch the outputs of a dependency, so automation can tell a
+// dependency that isn't applied yet from broken credentials.
+type DependencyOutputErrorClass string
+
+const (
+	// DependencyOutputErrorNotApplied is a dependency without outputs, as it isn't applied yet, and without mock outputs.
+	DependencyOutputErrorNotApplied DependencyOutputErrorClass = "not applied"
+	// DependencyOutputErrorMissingState is a dependency whose state isn't found in its backend.
+	DependencyOutputErrorMissingState DependencyOutputErrorClass = "missing state"
+	// DependencyOutputErrorAuth is a dependency whose backend rejected the credentials, or for which none were found.
+	DependencyOutputErrorAuth DependencyOutputErrorClass = "auth"
+	// DependencyOutputErrorNetwork is a dependency whose backend can't be reached.
+	DependencyOutputErrorNetwork DependencyOutputErrorClass = "network"
+	// DependencyOutputErrorTimeout is a dependency whose outputs weren't fetched within its fetch timeout.
+	DependencyOutputErrorTimeout DependencyOutputErrorClass = "timeout"
+	// DependencyOutputErrorTerraform is a dependency for which OpenTofu/Terraform failed for another reason.
+	DependencyOutputErrorTerraform DependencyOutputErrorClass = "terraform error"
+	// DependencyOutputErrorUnknown is any other failure, e.g. an invalid config of the dependency.
+	DependencyOutputErrorUnknown DependencyOutputErrorClass = "unknown"
+)
+
+// awsAuthErrorCodes are the codes of the AWS errors caused by missing, expired or unauthorized credentials.
+var awsAuthErrorCodes = []string{
+	"AccessDenied",
+	"AccessDeniedException",
+	"ExpiredToken",
+	"ExpiredTokenException",
+	"InvalidAccessKeyId",
+	"InvalidClientTokenId",
+	"NoCredentialProviders",
+	"SignatureDoesNotMatch",
+	"UnrecognizedClientException",
+}
+
+// The messages OpenTofu/Terraform fail with when the credentials of a backend are missing or rejected, or when a
+// backend can't be reached, as only the output of the process is available to classify its failures.
+var (
+	tfAuthErrorMessages = []string{
+		"AccessDenied",
+		"AuthorizationFailed",
+		"ExpiredToken",
+		"InvalidClientTokenId",
+		"NoCredentialProviders",
+		"No valid credential sources found",
+		"could not find default credentials",
+		"oauth2: cannot fetch token",
+		"401 Unauthorized",
+		"403 Forbidden",
+	}
+
+	tfNetworkErrorMessages = []string{
+		"no such host",
+		"connection refused",
+		"connection reset by peer",
+		"i/o timeout",
+		"TLS handshake timeout",
+	}
+)
+
+// outputError returns the given error fetching the outputs of the dependency, wrapped with its class.
+func (dep Dependency) outputError(err error) error {
+	var outputErr DependencyOutputError
+	if errors.As(err, &outputErr) {
+		return err
+	}
+
+	return errors.New(DependencyOutputError{
+		Err:        err,
+		Dependency: dep.instanceName(),
+		Target:     dep.target(),
+		Class:      classifyDependencyOutputError(err),
+	})
+}
+
+// classifyDependencyOutputError returns the class of the given error fetching the outputs of a dependency.
+func classifyDependencyOutputError(err error) DependencyOutputErrorClass {
+	var (
+		outputErr    DependencyOutputError
+		timeoutErr   DependencyOutputTimeoutError
+		noOutputsErr TerragruntOutputTargetNoOutputs
+		awsErr       awserr.Error
+		netErr       net.Error
+		processErr   util.ProcessExecutionError
+	)
+
+	statusCode := backendErrorStatusCode(err)
+
+	switch {
+	case errors.As(err, &outputErr):
+		return outputErr.Class
+	case errors.As(err, &timeoutErr) || errors.Is(err, context.DeadlineExceeded):
+		return DependencyOutputErrorTimeout
+	case errors.As(err, &noOutputsErr):
+		return DependencyOutputErrorNotApplied
+	case isAwsS3NoSuchKey(err) || errors.Is(err, storage.ErrObjectNotExist) || statusCode == http.StatusNotFound:
+		return DependencyOutputErrorMissingState
+	case errors.As(err, &awsErr) && slices.Contains(awsAuthErrorCodes, awsErr.Code()),
+		statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		return DependencyOutputErrorAuth
+	case errors.As(err, &awsErr) && awsErr.Code() == "RequestError", errors.As(err, &netErr):
+		return DependencyOutputErrorNetwork
+	case errors.As(err, &processErr):
+		stderr := processErr.Output.Stderr.String()
+
+		switch {
+		case containsAny(stderr, tfAuthErrorMessages):
+			return DependencyOutputErrorAuth
+		case containsAny(stderr, tfNetworkErrorMessages):
+			return DependencyOutputErrorNetwork
+		}
+
+		return DependencyOutputErrorTerraform
+	}
+
+	return DependencyOutputErrorUnknown
+}
+
+// backendErrorStatusCode returns the HTTP status code of the given error of a backend, or 0 if it has none.
+func backendErrorStatusCode(err error) int {
+	var (
+		awsErr    awserr.RequestFailure
+		googleErr *googleapi.Error
+		stateErr  httpbackend.StateRequestError
+		blobErr   azurerm.BlobRequestError
+	)
+
+	switch {
+	case errors.As(err, &awsErr):
+		return awsErr.StatusCode()
+	case errors.As(err, &googleErr):
+		return googleErr.Code
+	case errors.As(err, &stateErr):
+		return stateErr.StatusCode
+	case errors.As(err, &blobErr):
+		return blobErr.StatusCode
+	}
+
+	return 0
+}
+
+func containsAny(s string, substrs []string) bool {
+	return slices.ContainsFunc(substrs, func(substr string) bool {
+		return strings.Contains(s, substr)
+	})
+}
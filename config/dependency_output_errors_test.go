@@ -0,0 +1,132 @@
+package config_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	httpbackend "github.com/gruntwork-io/terragrunt/internal/remotestate/backend/http"
+	"github.com/gruntwork-io/terragrunt/internal/report"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+func TestParseDependencyOutputErrorClass(t *testing.T) {
+	t.Parallel()
+
+	processErr := func(stderr string) error {
+		err := util.ProcessExecutionError{Command: "tofu", Args: []string{"output", "-json"}, Err: errors.New("exit status 1")}
+		err.Output.Stderr.WriteString(stderr)
+
+		return err
+	}
+
+	testCases := []struct {
+		runTerragrunt func(ctx context.Context, opts *options.TerragruntOptions) error
+		name          string
+		dependency    string
+		expectedClass config.DependencyOutputErrorClass
+	}{
+		{
+			name: "not applied",
+			runTerragrunt: func(_ context.Context, opts *options.TerragruntOptions) error {
+				_, err := fmt.Fprint(opts.Writer, `{}`)
+				return err
+			},
+			expectedClass: config.DependencyOutputErrorNotApplied,
+		},
+		{
+			name: "missing state",
+			runTerragrunt: func(_ context.Context, _ *options.TerragruntOptions) error {
+				return httpbackend.StateRequestError{Address: "https://state.example.com/vpc", StatusCode: 404}
+			},
+			expectedClass: config.DependencyOutputErrorMissingState,
+		},
+		{
+			name: "rejected credentials",
+			runTerragrunt: func(_ context.Context, _ *options.TerragruntOptions) error {
+				return httpbackend.StateRequestError{Address: "https://state.example.com/vpc", StatusCode: 403}
+			},
+			expectedClass: config.DependencyOutputErrorAuth,
+		},
+		{
+			name: "missing credentials",
+			runTerragrunt: func(_ context.Context, _ *options.TerragruntOptions) error {
+				return processErr("Error: No valid credential sources found")
+			},
+			expectedClass: config.DependencyOutputErrorAuth,
+		},
+		{
+			name: "unreachable backend",
+			runTerragrunt: func(_ context.Context, _ *options.TerragruntOptions) error {
+				return &net.DNSError{Err: "no such host", Name: "state.example.com", IsNotFound: true}
+			},
+			expectedClass: config.DependencyOutputErrorNetwork,
+		},
+		{
+			name: "terraform error",
+			runTerragrunt: func(_ context.Context, _ *options.TerragruntOptions) error {
+				return processErr("Error: Unsupported OpenTofu Core version")
+			},
+			expectedClass: config.DependencyOutputErrorTerraform,
+		},
+		{
+			name:       "timeout",
+			dependency: `fetch_timeout_sec = 1`,
+			runTerragrunt: func(ctx context.Context, _ *options.TerragruntOptions) error {
+				<-ctx.Done()
+				return errors.New("signal: killed")
+			},
+			expectedClass: config.DependencyOutputErrorTimeout,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rootDir := t.TempDir()
+			vpcDir := filepath.Join(rootDir, "vpc")
+			appDir := filepath.Join(rootDir, "app")
+			configPath := filepath.Join(appDir, config.DefaultTerragruntConfigPath)
+
+			require.NoError(t, os.MkdirAll(vpcDir, 0755))
+			require.NoError(t, os.MkdirAll(appDir, 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(vpcDir, config.DefaultTerragruntConfigPath), []byte(""), 0644))
+			require.NoError(t, os.WriteFile(configPath, []byte(`
+dependency "vpc" {
+  config_path = "../vpc"
+  `+tc.dependency+`
+}
+
+inputs = {
+  vpc_id = dependency.vpc.outputs.vpc_id
+}
+`), 0644))
+
+			l := logger.CreateLogger()
+			opts := mockOptionsForTestWithConfigPath(t, configPath)
+			opts.RunTerragrunt = func(ctx context.Context, _ log.Logger, opts *options.TerragruntOptions, _ *report.Report) error {
+				return tc.runTerragrunt(ctx, opts)
+			}
+
+			_, err := config.ParseConfigFile(config.NewParsingContext(t.Context(), l, opts), l, configPath, nil)
+			require.Error(t, err)
+
+			var outputErr config.DependencyOutputError
+			require.ErrorAs(t, err, &outputErr)
+			assert.Equal(t, tc.expectedClass, outputErr.Class)
+			assert.Equal(t, "vpc", outputErr.Dependency)
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"slices"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// expectedOutputs returns the types of the outputs declared in the `expected_outputs` attribute of the dependency, by
+// output name, e.g.:
+//
+//	expected_outputs = {
+//	  vpc_id     = string
+//	  subnet_ids = list(string)
+//	}
+//
+// It returns nil if the attribute isn't set.
+func (dep Dependency) expectedOutputs() (map[string]cty.Type, error) {
+	if dep.ExpectedOutputs == nil {
+		return nil, nil
+	}
+
+	pairs, diags := hcl.ExprMap(dep.ExpectedOutputs.Expr)
+	if diags.HasErrors() {
+		return nil, errors.New(diags)
+	}
+
+	types := make(map[string]cty.Type, len(pairs))
+
+	for _, pair := range pairs {
+		name := hcl.ExprAsKeyword(pair.Key)
+		if name == "" {
+			return nil, errors.New(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid expected output name",
+				Detail:   "The keys of expected_outputs must be output names.",
+				Subject:  pair.Key.Range().Ptr(),
+			})
+		}
+
+		ty, diags := typeexpr.TypeConstraint(pair.Value)
+		if diags.HasErrors() {
+			return nil, errors.New(diags)
+		}
+
+		types[name] = ty
+	}
+
+	return types, nil
+}
+
+// validateExpectedOutputs checks the given outputs of the dependency, fetched or mocked, against its
+// `expected_outputs`, and returns the outputs with the expected ones converted to their declared type. An error is
+// returned for each expected output that is missing or can't be converted to its declared type.
+func (dep Dependency) validateExpectedOutputs(outputs *cty.Value) (*cty.Value, error) {
+	types, err := dep.expectedOutputs()
+	if err != nil || types == nil {
+		return outputs, err
+	}
+
+	values := map[string]cty.Value{}
+	if outputs != nil && !outputs.IsNull() && outputs.CanIterateElements() {
+		values = outputs.AsValueMap()
+	}
+
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	errs := &errors.MultiError{}
+
+	for _, name := range names {
+		expected := types[name]
+
+		val, ok := values[name]
+		if !ok {
+			errs = errs.Append(DependencyOutputSchemaError{Dependency: dep.instanceName(), Target: dep.target(), Output: name, Expected: expected})
+			continue
+		}
+
+		converted, err := convert.Convert(val, expected)
+		if err != nil {
+			errs = errs.Append(DependencyOutputSchemaError{Dependency: dep.instanceName(), Target: dep.target(), Output: name, Expected: expected, Actual: val.Type(), Err: err})
+			continue
+		}
+
+		values[name] = converted
+	}
+
+	if err := errs.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	convertedOutputs := cty.ObjectVal(values)
+
+	return &convertedOutputs, nil
+}
@@ -0,0 +1,97 @@
+package config_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/report"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+)
+
+func TestParseDependencyExpectedOutputs(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		outputs       string
+		expectedErr   string
+		expectedInput any
+	}{
+		{
+			name:          "matching outputs",
+			outputs:       `{"vpc_id": {"type": "string", "value": "vpc-123"}, "subnet_ids": {"type": ["tuple", ["string"]], "value": ["subnet-1"]}}`,
+			expectedInput: []any{"subnet-1"},
+		},
+		{
+			name:        "missing output",
+			outputs:     `{"subnet_ids": {"type": ["tuple", ["string"]], "value": ["subnet-1"]}}`,
+			expectedErr: "is expected to have the output vpc_id of type string, but it has no such output",
+		},
+		{
+			name:        "mismatched type",
+			outputs:     `{"vpc_id": {"type": "string", "value": "vpc-123"}, "subnet_ids": {"type": "string", "value": "subnet-1"}}`,
+			expectedErr: "is expected to have the output subnet_ids of type list(string), but its value of type string can't be converted",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rootDir := t.TempDir()
+			vpcDir := filepath.Join(rootDir, "vpc")
+			appDir := filepath.Join(rootDir, "app")
+			configPath := filepath.Join(appDir, config.DefaultTerragruntConfigPath)
+
+			require.NoError(t, os.MkdirAll(vpcDir, 0755))
+			require.NoError(t, os.MkdirAll(appDir, 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(vpcDir, config.DefaultTerragruntConfigPath), []byte(""), 0644))
+			require.NoError(t, os.WriteFile(configPath, []byte(`
+dependency "vpc" {
+  config_path = "../vpc"
+
+  expected_outputs = {
+    vpc_id     = string
+    subnet_ids = list(string)
+  }
+}
+
+inputs = {
+  subnet_ids = dependency.vpc.outputs.subnet_ids
+}
+`), 0644))
+
+			l := logger.CreateLogger()
+			opts := mockOptionsForTestWithConfigPath(t, configPath)
+			opts.RunTerragrunt = func(_ context.Context, _ log.Logger, opts *options.TerragruntOptions, _ *report.Report) error {
+				_, err := fmt.Fprint(opts.Writer, tc.outputs)
+				return err
+			}
+
+			cfg, err := config.ParseConfigFile(config.NewParsingContext(t.Context(), l, opts), l, configPath, nil)
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+
+				var schemaErr config.DependencyOutputSchemaError
+
+				require.ErrorAs(t, err, &schemaErr)
+				assert.Equal(t, "vpc", schemaErr.Dependency)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedInput, cfg.Inputs["subnet_ids"])
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package config
+
+import (
+	"path"
+	"slices"
+
+	"github.com/bmatcuk/doublestar"
+
+	"github.com/gruntwork-io/terragrunt/config/hclparse"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// DependencyPolicy is a stack-level policy forbidding some dependencies between units, e.g.:
+//
+//	rule "prod-isolation" {
+//	  description = "Production units must not depend on development units."
+//	  from        = ["prod/**"]
+//	  deny        = ["dev/**"]
+//	}
+//
+//	rule "app-layering" {
+//	  from_tags  = ["app"]
+//	  allow_tags = ["platform"]
+//	}
+type DependencyPolicy struct {
+	Rules []DependencyPolicyRule `hcl:"rule,block"`
+}
+
+// DependencyPolicyRule is a `rule` block of a dependency policy. The rule applies to the dependencies of the units
+// matching `from` or `from_tags`, or of all the units if both are empty. A dependency on a unit matching `deny` or
+// `deny_tags` violates the rule, and so does a dependency on a unit matching neither `allow` nor `allow_tags`, if
+// one of them is set. Paths are globs matched against the paths of the units relative to the working dir.
+type DependencyPolicyRule struct {
+	Description *string  `hcl:"description,attr"`
+	From        []string `hcl:"from,optional"`
+	FromTags    []string `hcl:"from_tags,optional"`
+	Allow       []string `hcl:"allow,optional"`
+	AllowTags   []string `hcl:"allow_tags,optional"`
+	Deny        []string `hcl:"deny,optional"`
+	DenyTags    []string `hcl:"deny_tags,optional"`
+	Name        string   `hcl:",label"`
+}
+
+// DependencyPolicyUnit is a unit, as matched by the rules of a dependency policy.
+type DependencyPolicyUnit struct {
+	// Path is the path of the unit relative to the working dir.
+	Path string
+	Tags []string
+}
+
+// DependencyPolicyViolation is a dependency violating a rule of a dependency policy.
+type DependencyPolicyViolation struct {
+	Rule        string
+	Description string
+	From        string
+	To          string
+}
+
+// ParseDependencyPolicyFile parses the dependency policy at the given path, and validates its rules.
+func ParseDependencyPolicyFile(policyPath string) (*DependencyPolicy, error) {
+	file, err := hclparse.NewParser().ParseFromFile(policyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &DependencyPolicy{}
+	if err := file.Decode(policy, nil); err != nil {
+		return nil, err
+	}
+
+	for _, rule := range policy.Rules {
+		if len(rule.Allow) == 0 && len(rule.AllowTags) == 0 && len(rule.Deny) == 0 && len(rule.DenyTags) == 0 {
+			return nil, errors.New(InvalidDependencyPolicyError{Path: policyPath, Rule: rule.Name, Reason: "one of allow, allow_tags, deny or deny_tags must be set"})
+		}
+
+		// doublestar only reports malformed patterns when matching reaches them, while path.Match checks the whole
+		// pattern, with the same syntax for the classes and escapes.
+		for _, pattern := range slices.Concat(rule.From, rule.Allow, rule.Deny) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, errors.New(InvalidDependencyPolicyError{Path: policyPath, Rule: rule.Name, Reason: "invalid glob " + pattern + ": " + err.Error()})
+			}
+		}
+	}
+
+	return policy, nil
+}
+
+// Check returns the violations of the policy by the dependency of the unit from on the unit to.
+func (policy *DependencyPolicy) Check(from, to DependencyPolicyUnit) []DependencyPolicyViolation {
+	var violations []DependencyPolicyViolation
+
+	for _, rule := range policy.Rules {
+		if rule.forbids(from, to) {
+			violation := DependencyPolicyViolation{Rule: rule.Name, From: from.Path, To: to.Path}
+			if rule.Description != nil {
+				violation.Description = *rule.Description
+			}
+
+			violations = append(violations, violation)
+		}
+	}
+
+	return violations
+}
+
+// forbids returns true if the dependency of the unit from on the unit to violates the rule.
+func (rule DependencyPolicyRule) forbids(from, to DependencyPolicyUnit) bool {
+	if (len(rule.From) > 0 || len(rule.FromTags) > 0) && !from.matches(rule.From, rule.FromTags) {
+		return false
+	}
+
+	if to.matches(rule.Deny, rule.DenyTags) {
+		return true
+	}
+
+	return (len(rule.Allow) > 0 || len(rule.AllowTags) > 0) && !to.matches(rule.Allow, rule.AllowTags)
+}
+
+// matches returns true if the path of the unit matches one of the given globs, or the unit has one of the given tags.
+func (unit DependencyPolicyUnit) matches(patterns, tags []string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := doublestar.Match(pattern, unit.Path); matched {
+			return true
+		}
+	}
+
+	for _, tag := range tags {
+		if slices.Contains(unit.Tags, tag) {
+			return true
+		}
+	}
+
+	return false
+}
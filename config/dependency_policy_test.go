@@ -0,0 +1,141 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+)
+
+func TestParseDependencyPolicyFile(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		policy      string
+		expectedErr string
+	}{
+		{
+			name: "valid policy",
+			policy: `
+rule "prod-isolation" {
+  from = ["prod/**"]
+  deny = ["dev/**"]
+}
+`,
+		},
+		{
+			name: "rule without allow or deny",
+			policy: `
+rule "empty" {
+  from = ["prod/**"]
+}
+`,
+			expectedErr: "one of allow, allow_tags, deny or deny_tags must be set",
+		},
+		{
+			name: "invalid glob",
+			policy: `
+rule "invalid" {
+  deny = ["dev/[a-"]
+}
+`,
+			expectedErr: "invalid glob dev/[a-",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			path := filepath.Join(t.TempDir(), "policy.hcl")
+			require.NoError(t, os.WriteFile(path, []byte(tc.policy), 0644))
+
+			_, err := config.ParseDependencyPolicyFile(path)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDependencyPolicyCheck(t *testing.T) {
+	t.Parallel()
+
+	description := "Production units must not depend on development units."
+
+	policy := &config.DependencyPolicy{
+		Rules: []config.DependencyPolicyRule{
+			{
+				Name:        "prod-isolation",
+				Description: &description,
+				From:        []string{"prod/**"},
+				Deny:        []string{"dev/**"},
+			},
+			{
+				Name:      "app-layering",
+				FromTags:  []string{"app"},
+				AllowTags: []string{"platform"},
+			},
+		},
+	}
+
+	testCases := []struct {
+		name     string
+		from     config.DependencyPolicyUnit
+		to       config.DependencyPolicyUnit
+		expected []config.DependencyPolicyViolation
+	}{
+		{
+			name: "denied path",
+			from: config.DependencyPolicyUnit{Path: "prod/us-east-1/app"},
+			to:   config.DependencyPolicyUnit{Path: "dev/vpc"},
+			expected: []config.DependencyPolicyViolation{
+				{Rule: "prod-isolation", Description: description, From: "prod/us-east-1/app", To: "dev/vpc"},
+			},
+		},
+		{
+			name: "allowed path",
+			from: config.DependencyPolicyUnit{Path: "prod/app"},
+			to:   config.DependencyPolicyUnit{Path: "prod/vpc"},
+		},
+		{
+			name: "unallowed tag",
+			from: config.DependencyPolicyUnit{Path: "prod/app", Tags: []string{"app"}},
+			to:   config.DependencyPolicyUnit{Path: "prod/api", Tags: []string{"app"}},
+			expected: []config.DependencyPolicyViolation{
+				{Rule: "app-layering", From: "prod/app", To: "prod/api"},
+			},
+		},
+		{
+			name: "allowed tag",
+			from: config.DependencyPolicyUnit{Path: "prod/app", Tags: []string{"app"}},
+			to:   config.DependencyPolicyUnit{Path: "prod/vpc", Tags: []string{"platform"}},
+		},
+		{
+			name: "several rules",
+			from: config.DependencyPolicyUnit{Path: "prod/app", Tags: []string{"app"}},
+			to:   config.DependencyPolicyUnit{Path: "dev/api"},
+			expected: []config.DependencyPolicyViolation{
+				{Rule: "prod-isolation", Description: description, From: "prod/app", To: "dev/api"},
+				{Rule: "app-layering", From: "prod/app", To: "dev/api"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, policy.Check(tc.from, tc.to))
+		})
+	}
+}
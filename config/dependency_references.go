@@ -0,0 +1,122 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+
+	"github.com/gruntwork-io/terragrunt/config/hclparse"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// referencedDependencyOutputs returns the names of the dependency blocks whose outputs are referenced in the given file
+// or in the configs it includes, as `dependency.<name>.outputs` or as the whole `dependency.<name>` object. References
+// to `dependency.<name>.inputs` only don't need the outputs of the dependency.
+//
+// The returned bool is false if the references can't be determined, e.g. because a file uses the JSON syntax or the
+// `dependency` variable is referenced as a whole, in which case the outputs of all the dependencies must be fetched.
+func referencedDependencyOutputs(ctx *ParsingContext, file *hclparse.File) (map[string]bool, bool) {
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil, false
+	}
+
+	bodies := []*hclsyntax.Body{body}
+
+	if ctx.TrackInclude == nil {
+		// The included configs are evaluated with the dependencies of the unit, so they must be known.
+		for _, block := range body.Blocks {
+			if block.Type == MetadataInclude {
+				return nil, false
+			}
+		}
+	} else {
+		for _, include := range ctx.TrackInclude.CurrentList {
+			includePath := include.Path
+			if !filepath.IsAbs(includePath) {
+				includePath = util.JoinPath(filepath.Dir(file.ConfigPath), includePath)
+			}
+
+			src, err := os.ReadFile(includePath)
+			if err != nil {
+				return nil, false
+			}
+
+			includeFile, diags := hclsyntax.ParseConfig(src, includePath, hcl.InitialPos)
+			if diags.HasErrors() {
+				return nil, false
+			}
+
+			includeBody, ok := includeFile.Body.(*hclsyntax.Body)
+			if !ok {
+				return nil, false
+			}
+
+			bodies = append(bodies, includeBody)
+		}
+	}
+
+	names := map[string]bool{}
+
+	for _, body := range bodies {
+		if !collectDependencyOutputReferences(body, names) {
+			return nil, false
+		}
+	}
+
+	return names, true
+}
+
+// collectDependencyOutputReferences adds the names of the dependency blocks whose outputs are referenced in the
+// attributes of the given body, and of its nested blocks, to the given names. It returns false if the `dependency`
+// variable is referenced as a whole, or with a dynamic index.
+func collectDependencyOutputReferences(body *hclsyntax.Body, names map[string]bool) bool {
+	const (
+		dependencyNameStep = 1
+		dependencyAttrStep = 2
+	)
+
+	for _, attr := range body.Attributes {
+		for _, traversal := range attr.Expr.Variables() {
+			root, ok := traversal[0].(hcl.TraverseRoot)
+			if !ok || root.Name != MetadataDependency {
+				continue
+			}
+
+			if len(traversal) <= dependencyNameStep {
+				return false
+			}
+
+			name, ok := traversal[dependencyNameStep].(hcl.TraverseAttr)
+			if !ok {
+				return false
+			}
+
+			// Skip the instance key of a dependency block declared with `for_each`.
+			attrStep := dependencyAttrStep
+			if len(traversal) > attrStep {
+				if _, ok := traversal[attrStep].(hcl.TraverseIndex); ok {
+					attrStep++
+				}
+			}
+
+			if len(traversal) > attrStep {
+				if step, ok := traversal[attrStep].(hcl.TraverseAttr); ok && step.Name == MetadataInputs {
+					continue
+				}
+			}
+
+			names[name.Name] = true
+		}
+	}
+
+	for _, block := range body.Blocks {
+		if !collectDependencyOutputReferences(block.Body, names) {
+			return false
+		}
+	}
+
+	return true
+}
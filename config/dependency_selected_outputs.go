@@ -0,0 +1,69 @@
+package config
+
+import (
+	"encoding/json"
+	"slices"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// selectOutputsJSON returns the given `output -json` of the dependency with only the outputs selected with its
+// `outputs` attribute, so the values of the other outputs are neither parsed nor kept. The values are left as raw JSON,
+// so it is cheap even for large outputs. An error is returned if a selected output is missing.
+func (dep Dependency) selectOutputsJSON(targetConfig string, jsonBytes []byte) ([]byte, error) {
+	if dep.Outputs == nil {
+		return jsonBytes, nil
+	}
+
+	var outputs map[string]json.RawMessage
+	if err := json.Unmarshal(jsonBytes, &outputs); err != nil {
+		return nil, errors.New(TerragruntOutputParsingError{Path: targetConfig, Err: err})
+	}
+
+	selected := make(map[string]json.RawMessage, len(*dep.Outputs))
+
+	var missing []string
+
+	for _, name := range *dep.Outputs {
+		output, ok := outputs[name]
+		if !ok {
+			missing = append(missing, name)
+			continue
+		}
+
+		selected[name] = output
+	}
+
+	if len(missing) > 0 {
+		return nil, errors.New(DependencySelectedOutputsNotFoundError{Dependency: dep.instanceName(), Target: dep.target(), Outputs: missing})
+	}
+
+	selectedBytes, err := json.Marshal(selected)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return selectedBytes, nil
+}
+
+// selectOutputs returns the given outputs of the dependency, fetched or mocked, with only the outputs selected with its
+// `outputs` attribute, so referencing another output fails whether the outputs are mocked or not.
+func (dep Dependency) selectOutputs(outputs *cty.Value) *cty.Value {
+	if dep.Outputs == nil || outputs == nil || outputs.IsNull() || !outputs.IsKnown() || !outputs.CanIterateElements() {
+		return outputs
+	}
+
+	values := map[string]cty.Value{}
+
+	for name, val := range outputs.AsValueMap() {
+		if slices.Contains(*dep.Outputs, name) {
+			values[name] = val
+		}
+	}
+
+	selectedOutputs := cty.ObjectVal(values)
+
+	return &selectedOutputs
+}
@@ -0,0 +1,103 @@
+package config_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/report"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+)
+
+func TestParseDependencySelectedOutputs(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name           string
+		outputs        string
+		inputs         string
+		expectedErr    string
+		expectedInputs map[string]any
+	}{
+		{
+			name:           "selected outputs",
+			outputs:        `{"vpc_id": {"type": "string", "value": "vpc-123"}, "subnets": {"type": ["tuple", ["string"]], "value": ["subnet-1"]}}`,
+			inputs:         `vpc_id = dependency.vpc.outputs.vpc_id`,
+			expectedInputs: map[string]any{"vpc_id": "vpc-123"},
+		},
+		{
+			name:        "unselected output",
+			outputs:     `{"vpc_id": {"type": "string", "value": "vpc-123"}, "subnets": {"type": ["tuple", ["string"]], "value": ["subnet-1"]}}`,
+			inputs:      `subnets = dependency.vpc.outputs.subnets`,
+			expectedErr: `This object does not have an attribute named "subnets"`,
+		},
+		{
+			name:        "missing selected output",
+			outputs:     `{"subnets": {"type": ["tuple", ["string"]], "value": ["subnet-1"]}}`,
+			inputs:      `vpc_id = dependency.vpc.outputs.vpc_id`,
+			expectedErr: "has no outputs vpc_id selected with its outputs attribute",
+		},
+		{
+			name:           "mocked outputs",
+			outputs:        `{}`,
+			inputs:         `outputs = dependency.vpc.outputs`,
+			expectedInputs: map[string]any{"outputs": map[string]any{"vpc_id": "mock-vpc"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rootDir := t.TempDir()
+			vpcDir := filepath.Join(rootDir, "vpc")
+			appDir := filepath.Join(rootDir, "app")
+			configPath := filepath.Join(appDir, config.DefaultTerragruntConfigPath)
+
+			require.NoError(t, os.MkdirAll(vpcDir, 0755))
+			require.NoError(t, os.MkdirAll(appDir, 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(vpcDir, config.DefaultTerragruntConfigPath), []byte(""), 0644))
+			require.NoError(t, os.WriteFile(configPath, []byte(`
+dependency "vpc" {
+  config_path = "../vpc"
+  outputs     = ["vpc_id"]
+
+  mock_outputs = {
+    vpc_id  = "mock-vpc"
+    subnets = ["mock-subnet"]
+  }
+}
+
+inputs = {
+  `+tc.inputs+`
+}
+`), 0644))
+
+			l := logger.CreateLogger()
+			opts := mockOptionsForTestWithConfigPath(t, configPath)
+			opts.RunTerragrunt = func(_ context.Context, _ log.Logger, opts *options.TerragruntOptions, _ *report.Report) error {
+				_, err := fmt.Fprint(opts.Writer, tc.outputs)
+				return err
+			}
+
+			cfg, err := config.ParseConfigFile(config.NewParsingContext(t.Context(), l, opts), l, configPath, nil)
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedInputs, cfg.Inputs)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/gocty"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+// getSpeculativeOutputs returns the outputs the target of the dependency plans to have, recorded by the speculative
+// plan of its unit with run --all plan --speculative-plan, and false if its unit wasn't planned. The outputs that are
+// unknown until the unit is applied are taken from the mock outputs of the dependency, if any.
+func (dep Dependency) getSpeculativeOutputs(ctx *ParsingContext, l log.Logger) (*cty.Value, bool, error) {
+	targetConfig := getCleanedTargetConfigPath(dep.ConfigPath.AsString(), ctx.TerragruntOptions.TerragruntConfigPath)
+
+	jsonBytes, found := ctx.TerragruntOptions.SpeculativeOutputs.Load(targetConfig)
+	if !found {
+		return nil, false, nil
+	}
+
+	outputMap, err := dependencyOutputJSONToCtyValueMap(ctx, l, targetConfig, jsonBytes)
+	if err != nil {
+		return nil, true, err
+	}
+
+	outputs, err := gocty.ToCtyValue(outputMap, generateTypeFromValuesMap(outputMap))
+	if err != nil {
+		return nil, true, errors.New(TerragruntOutputEncodingError{Path: targetConfig, Err: err})
+	}
+
+	l.Debugf("Using the planned outputs of dependency %s (%s) in %s", dep.instanceName(), dep.target(), ctx.TerragruntOptions.TerragruntConfigPath)
+
+	if dep.MockOutputs == nil {
+		return &outputs, true, nil
+	}
+
+	merged, err := shallowMergeCtyMaps(outputs, *dep.MockOutputs)
+	if err != nil {
+		return nil, true, err
+	}
+
+	return merged, true, nil
+}
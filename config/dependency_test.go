@@ -1,11 +1,17 @@
 package config_test
 
 import (
+	"context"
+	"fmt"
 	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/report"
 	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
 	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
 
 	"github.com/gruntwork-io/go-commons/env"
@@ -150,3 +156,282 @@ dependency "vpc" {
 	require.NoError(t, file.Decode(&decoded, &hcl.EvalContext{}))
 	assert.Len(t, decoded.Dependencies, 2)
 }
+
+func TestDecodeDependencyForEach(t *testing.T) {
+	t.Parallel()
+
+	cfg := `
+dependency "spokes" {
+  for_each    = local.regions
+  config_path = "../spokes/${each.key}"
+  mock_outputs = {
+    region = each.value
+  }
+}
+
+dependency "vpc" {
+  config_path = "../vpc"
+}
+`
+	filename := config.DefaultTerragruntConfigPath
+	file, err := hclparse.NewParser(hclparse.WithForEachBlocks(config.MetadataDependency)).ParseFromString(cfg, filename)
+	require.NoError(t, err)
+
+	evalCtx := &hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"local": cty.ObjectVal(map[string]cty.Value{
+				"regions": cty.MapVal(map[string]cty.Value{
+					"us-east-1": cty.StringVal("use1"),
+					"eu-west-1": cty.StringVal("euw1"),
+				}),
+			}),
+		},
+	}
+
+	decoded := config.TerragruntDependency{}
+	require.NoError(t, file.Decode(&decoded, evalCtx))
+	require.Len(t, decoded.Dependencies, 3)
+
+	expected := []struct {
+		key        string
+		configPath string
+		region     string
+	}{
+		{key: "eu-west-1", configPath: "../spokes/eu-west-1", region: "euw1"},
+		{key: "us-east-1", configPath: "../spokes/us-east-1", region: "use1"},
+	}
+
+	for i, want := range expected {
+		dep := decoded.Dependencies[i]
+		assert.Equal(t, "spokes", dep.Name)
+		assert.Equal(t, cty.StringVal(want.configPath), dep.ConfigPath)
+		assert.Equal(t, cty.StringVal(want.region), dep.MockOutputs.GetAttr("region"))
+
+		key, ok := dep.ForEachKey()
+		assert.True(t, ok)
+		assert.Equal(t, want.key, key)
+	}
+
+	_, ok := decoded.Dependencies[2].ForEachKey()
+	assert.False(t, ok)
+	assert.Equal(t, "vpc", decoded.Dependencies[2].Name)
+}
+
+func TestDecodeDependencyForEachInvalid(t *testing.T) {
+	t.Parallel()
+
+	cfg := `
+dependency "spokes" {
+  for_each    = 3
+  config_path = "../spokes/${each.key}"
+}
+`
+	filename := config.DefaultTerragruntConfigPath
+	file, err := hclparse.NewParser(hclparse.WithForEachBlocks(config.MetadataDependency)).ParseFromString(cfg, filename)
+	require.NoError(t, err)
+
+	decoded := config.TerragruntDependency{}
+	require.Error(t, file.Decode(&decoded, &hcl.EvalContext{}))
+}
+
+func TestParseDependencyForEach(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	appDir := filepath.Join(rootDir, "app")
+	configPath := filepath.Join(appDir, config.DefaultTerragruntConfigPath)
+
+	for _, region := range []string{"us-east-1", "eu-west-1"} {
+		spokeDir := filepath.Join(rootDir, "spokes", region)
+		require.NoError(t, os.MkdirAll(spokeDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(spokeDir, config.DefaultTerragruntConfigPath), []byte(""), 0644))
+	}
+
+	require.NoError(t, os.MkdirAll(appDir, 0755))
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+locals {
+  regions = ["us-east-1", "eu-west-1"]
+}
+
+dependency "spokes" {
+  for_each     = toset(local.regions)
+  config_path  = "../spokes/${each.value}"
+  skip_outputs = true
+  mock_outputs = {
+    vpc_id = "vpc-${each.key}"
+  }
+}
+
+inputs = {
+  vpc_id  = dependency.spokes["us-east-1"].outputs.vpc_id
+  vpc_ids = [for region in local.regions : dependency.spokes[region].outputs.vpc_id]
+}
+`), 0644))
+
+	l := logger.CreateLogger()
+	ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, configPath))
+
+	cfg, err := config.ParseConfigFile(ctx, l, configPath, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "vpc-us-east-1", cfg.Inputs["vpc_id"])
+	assert.Equal(t, []any{"vpc-us-east-1", "vpc-eu-west-1"}, cfg.Inputs["vpc_ids"])
+
+	cfgAsCty, err := config.TerragruntConfigAsCty(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, cty.StringVal("../spokes/eu-west-1"), cfgAsCty.GetAttr("dependency").GetAttr("spokes").GetAttr("eu-west-1").GetAttr("config_path"))
+
+	// Each instance is a dependency of the unit in the run --all graph.
+	partialCfg, err := config.PartialParseConfigFile(ctx.WithDecodeList(config.DependencyBlock), l, configPath, nil)
+	require.NoError(t, err)
+	require.NotNil(t, partialCfg.Dependencies)
+	assert.Equal(t, []string{"../spokes/eu-west-1", "../spokes/us-east-1"}, partialCfg.Dependencies.Paths)
+}
+
+func TestParseDependencyBlocksWithFetchParallelism(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	appDir := filepath.Join(rootDir, "app")
+	configPath := filepath.Join(appDir, config.DefaultTerragruntConfigPath)
+
+	for _, name := range []string{"vpc", "db", "cache"} {
+		depDir := filepath.Join(rootDir, name)
+		require.NoError(t, os.MkdirAll(depDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(depDir, config.DefaultTerragruntConfigPath), []byte(""), 0644))
+	}
+
+	require.NoError(t, os.MkdirAll(appDir, 0755))
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+dependency "deps" {
+  for_each     = toset(["vpc", "db", "cache"])
+  config_path  = "../${each.value}"
+  skip_outputs = true
+  mock_outputs = {
+    id = "${each.key}-id"
+  }
+}
+
+inputs = {
+  ids = [for name in ["vpc", "db", "cache"] : dependency.deps[name].outputs.id]
+}
+`), 0644))
+
+	l := logger.CreateLogger()
+	opts := mockOptionsForTestWithConfigPath(t, configPath)
+	opts.DependencyFetchParallelism = 1
+
+	cfg, err := config.ParseConfigFile(config.NewParsingContext(t.Context(), l, opts), l, configPath, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []any{"vpc-id", "db-id", "cache-id"}, cfg.Inputs["ids"])
+}
+
+func TestParseDependencyWithAWSOptions(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	vpcDir := filepath.Join(rootDir, "vpc")
+	appDir := filepath.Join(rootDir, "app")
+	configPath := filepath.Join(appDir, config.DefaultTerragruntConfigPath)
+
+	require.NoError(t, os.MkdirAll(vpcDir, 0755))
+	require.NoError(t, os.MkdirAll(appDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(vpcDir, config.DefaultTerragruntConfigPath), []byte(`
+iam_role = "arn:aws:iam::111111111111:role/vpc"
+`), 0644))
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+dependency "vpc" {
+  config_path = "../vpc"
+  iam_role    = "arn:aws:iam::222222222222:role/read-outputs"
+  region      = "eu-west-1"
+  profile     = "network"
+}
+
+inputs = {
+  vpc_id = dependency.vpc.outputs.vpc_id
+}
+`), 0644))
+
+	l := logger.CreateLogger()
+	opts := mockOptionsForTestWithConfigPath(t, configPath)
+	opts.Env = map[string]string{"AWS_ACCESS_KEY_ID": "caller", "AWS_SECRET_ACCESS_KEY": "caller"}
+
+	var outputOpts *options.TerragruntOptions
+
+	// Capture the options the outputs of the dependency are read with, instead of running OpenTofu/Terraform.
+	opts.RunTerragrunt = func(_ context.Context, _ log.Logger, opts *options.TerragruntOptions, _ *report.Report) error {
+		outputOpts = opts
+		_, err := fmt.Fprint(opts.Writer, `{"vpc_id": {"sensitive": false, "type": "string", "value": "vpc-123"}}`)
+
+		return err
+	}
+
+	cfg, err := config.ParseConfigFile(config.NewParsingContext(t.Context(), l, opts), l, configPath, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "vpc-123", cfg.Inputs["vpc_id"])
+
+	require.NotNil(t, outputOpts)
+	assert.Equal(t, "arn:aws:iam::222222222222:role/read-outputs", outputOpts.OriginalIAMRoleOptions.RoleARN)
+	assert.Equal(t, map[string]string{"AWS_REGION": "eu-west-1", "AWS_PROFILE": "network"}, outputOpts.Env)
+
+	// The AWS options of the dependency are only used to read its outputs.
+	assert.Empty(t, opts.OriginalIAMRoleOptions.RoleARN)
+	assert.Equal(t, "caller", opts.Env["AWS_ACCESS_KEY_ID"])
+}
+
+func TestParseDependencyOnlyFetchesReferencedOutputs(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	configPath := filepath.Join(rootDir, "app", config.DefaultTerragruntConfigPath)
+
+	for _, name := range []string{"vpc", "db", "cache", "app"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(rootDir, name), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(rootDir, name, config.DefaultTerragruntConfigPath), []byte(`inputs = { name = "main" }`), 0644))
+	}
+
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+dependency "vpc" {
+  config_path = "../vpc"
+}
+
+dependency "db" {
+  config_path = "../db"
+}
+
+dependency "cache" {
+  config_path = "../cache"
+}
+
+inputs = {
+  vpc_id   = dependency.vpc.outputs.vpc_id
+  db_name  = dependency.db.inputs.name
+}
+`), 0644))
+
+	l := logger.CreateLogger()
+	opts := mockOptionsForTestWithConfigPath(t, configPath)
+
+	var (
+		mu      sync.Mutex
+		fetched []string
+	)
+
+	opts.RunTerragrunt = func(_ context.Context, _ log.Logger, opts *options.TerragruntOptions, _ *report.Report) error {
+		mu.Lock()
+		fetched = append(fetched, filepath.Base(opts.WorkingDir))
+		mu.Unlock()
+
+		_, err := fmt.Fprint(opts.Writer, `{"vpc_id": {"sensitive": false, "type": "string", "value": "vpc-123"}}`)
+
+		return err
+	}
+
+	cfg, err := config.ParseConfigFile(config.NewParsingContext(t.Context(), l, opts), l, configPath, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "vpc-123", cfg.Inputs["vpc_id"])
+	assert.Equal(t, "main", cfg.Inputs["db_name"])
+
+	// Neither db, whose inputs only are referenced, nor cache, which isn't referenced, are fetched.
+	assert.Equal(t, []string{"vpc"}, fetched)
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+// IsTFEWorkspace returns true if the dependency targets a Terraform Cloud/Enterprise workspace, set with the
+// `tfe_workspace` attribute, rather than a unit. The outputs of the workspace are read with the API.
+func (dep Dependency) IsTFEWorkspace() bool {
+	return dep.TFEWorkspace != nil
+}
+
+// getTFEWorkspaceDependencyOutput returns the outputs of the current state of the workspace targeted by the dependency.
+// The returned bool is true if the workspace has no outputs, or if no API token is configured and the dependency has
+// mock outputs to use instead.
+func getTFEWorkspaceDependencyOutput(ctx *ParsingContext, l log.Logger, dep Dependency) (*cty.Value, bool, error) {
+	workspace, err := parseTFEWorkspace(*dep.TFEWorkspace, ctx.TerragruntOptions.Env)
+	if err != nil {
+		return nil, true, err
+	}
+
+	if dep.MockOutputs != nil && !hasTFEToken(workspace.Hostname, ctx.TerragruntOptions.Env) {
+		l.Debugf("No API token configured for %s, skipping reading the outputs of the workspace %s of dependency %s", workspace.Hostname, workspace, dep.Name)
+		return nil, true, nil
+	}
+
+	outputs, err := tfeWorkspaceOutputs(ctx, l, workspace)
+	if err != nil {
+		return nil, true, err
+	}
+
+	if len(outputs) == 0 {
+		return nil, true, nil
+	}
+
+	values := make(map[string]cty.Value, len(outputs))
+
+	for name, output := range outputs {
+		value, err := tfeOutputValue(ctx, l, workspace, output)
+		if err != nil {
+			return nil, false, err
+		}
+
+		values[name] = value
+	}
+
+	outputVal := cty.ObjectVal(values)
+
+	return &outputVal, false, nil
+}
@@ -0,0 +1,97 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+// defaultWaitForOutputsSleepIntervalSec is the time to wait between attempts to fetch the outputs of a dependency when
+// `sleep_interval_sec` isn't set on its `wait_for_outputs` block.
+const defaultWaitForOutputsSleepIntervalSec = 10
+
+// WaitForOutputs is the `wait_for_outputs` block of a dependency, configuring how long to wait for the outputs of a
+// dependency that hasn't been applied yet, e.g. by another pipeline, before falling back to its mock outputs or failing.
+type WaitForOutputs struct {
+	Backoff             string `hcl:"backoff,optional"`
+	MaxWaitSec          int    `hcl:"max_wait_sec"`
+	SleepIntervalSec    int    `hcl:"sleep_interval_sec,optional"`
+	MaxSleepIntervalSec int    `hcl:"max_sleep_interval_sec,optional"`
+}
+
+// validate returns an error if the block of the given dependency is invalid.
+func (wait *WaitForOutputs) validate(dep Dependency) error {
+	if wait.Backoff != "" && !slices.Contains(options.RetryBackoffs, options.RetryBackoff(wait.Backoff)) {
+		return errors.New(InvalidWaitForOutputsError{Dependency: dep.instanceName(), Reason: fmt.Sprintf("invalid backoff %q, must be one of %v", wait.Backoff, options.RetryBackoffs)})
+	}
+
+	if wait.MaxWaitSec < 0 || wait.SleepIntervalSec < 0 || wait.MaxSleepIntervalSec < 0 {
+		return errors.New(InvalidWaitForOutputsError{Dependency: dep.instanceName(), Reason: "max_wait_sec and sleep intervals can't be negative"})
+	}
+
+	return nil
+}
+
+// sleepInterval returns the time to wait before the given attempt to fetch the outputs again, starting from 1.
+func (wait *WaitForOutputs) sleepInterval(attempt int) time.Duration {
+	retryConfig := options.RetryConfig{
+		Backoff:             options.RetryBackoff(wait.Backoff),
+		SleepIntervalSec:    wait.SleepIntervalSec,
+		MaxSleepIntervalSec: wait.MaxSleepIntervalSec,
+	}
+
+	if retryConfig.SleepIntervalSec == 0 {
+		retryConfig.SleepIntervalSec = defaultWaitForOutputsSleepIntervalSec
+	}
+
+	return time.Duration(retryConfig.SleepIntervalForAttempt(attempt)) * time.Second
+}
+
+// waitForOutputs fetches the outputs of the dependency with the given function again, until they aren't empty or the
+// max wait of its `wait_for_outputs` block is reached. The outputs of the dependency were empty on the first attempt.
+func (dep Dependency) waitForOutputs(
+	ctx *ParsingContext,
+	l log.Logger,
+	getOutput func(*ParsingContext, log.Logger, Dependency) (*cty.Value, bool, error),
+) (*cty.Value, bool, error) {
+	wait := dep.WaitForOutputs
+
+	if err := wait.validate(dep); err != nil {
+		return nil, true, err
+	}
+
+	deadline := time.Now().Add(time.Duration(wait.MaxWaitSec) * time.Second)
+
+	for attempt := 1; ; attempt++ {
+		sleep := min(wait.sleepInterval(attempt), time.Until(deadline))
+		if sleep <= 0 {
+			l.Warnf("Dependency %s (%s) still has no outputs after waiting %ds for it to be applied", dep.instanceName(), dep.target(), wait.MaxWaitSec)
+
+			return nil, true, nil
+		}
+
+		l.Infof("Dependency %s (%s) has no outputs yet, as it may not be applied. Fetching them again in %s.", dep.instanceName(), dep.target(), sleep)
+
+		select {
+		case <-ctx.Done():
+			return nil, true, errors.New(ctx.Err())
+		case <-time.After(sleep):
+		}
+
+		// The empty outputs fetched on the previous attempt are cached, so they must be discarded to fetch them again.
+		if !dep.IsExternal() {
+			InvalidateDependencyOutputCache(l, getCleanedTargetConfigPath(dep.ConfigPath.AsString(), ctx.TerragruntOptions.TerragruntConfigPath))
+		}
+
+		outputVal, isEmpty, err := getOutput(ctx, l, dep)
+		if err != nil || !isEmpty {
+			return outputVal, isEmpty, err
+		}
+	}
+}
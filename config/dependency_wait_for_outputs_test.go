@@ -0,0 +1,116 @@
+package config_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/report"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+)
+
+func TestParseDependencyWaitForOutputs(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name             string
+		waitForOutputs   string
+		expectedErr      string
+		expectedVPCID    string
+		expectedAttempts int32
+	}{
+		{
+			name: "outputs after retry",
+			waitForOutputs: `
+    max_wait_sec       = 5
+    sleep_interval_sec = 1
+`,
+			expectedVPCID:    "vpc-123",
+			expectedAttempts: 3,
+		},
+		{
+			name: "mock outputs after max wait",
+			waitForOutputs: `
+    max_wait_sec       = 1
+    sleep_interval_sec = 1
+`,
+			expectedVPCID:    "mock-vpc",
+			expectedAttempts: 2,
+		},
+		{
+			name: "invalid backoff",
+			waitForOutputs: `
+    max_wait_sec = 1
+    backoff      = "linear"
+`,
+			expectedErr: `invalid wait_for_outputs block of dependency vpc: invalid backoff "linear"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rootDir := t.TempDir()
+			vpcDir := filepath.Join(rootDir, "vpc")
+			appDir := filepath.Join(rootDir, "app")
+			configPath := filepath.Join(appDir, config.DefaultTerragruntConfigPath)
+
+			require.NoError(t, os.MkdirAll(vpcDir, 0755))
+			require.NoError(t, os.MkdirAll(appDir, 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(vpcDir, config.DefaultTerragruntConfigPath), []byte(""), 0644))
+			require.NoError(t, os.WriteFile(configPath, []byte(`
+dependency "vpc" {
+  config_path = "../vpc"
+
+  mock_outputs = {
+    vpc_id = "mock-vpc"
+  }
+
+  wait_for_outputs {`+tc.waitForOutputs+`  }
+}
+
+inputs = {
+  vpc_id = dependency.vpc.outputs.vpc_id
+}
+`), 0644))
+
+			var attempts atomic.Int32
+
+			l := logger.CreateLogger()
+			opts := mockOptionsForTestWithConfigPath(t, configPath)
+			opts.RunTerragrunt = func(_ context.Context, _ log.Logger, opts *options.TerragruntOptions, _ *report.Report) error {
+				// The dependency is applied after the second attempt.
+				if attempts.Add(1) < 3 {
+					_, err := fmt.Fprint(opts.Writer, `{}`)
+					return err
+				}
+
+				_, err := fmt.Fprint(opts.Writer, `{"vpc_id": {"type": "string", "value": "vpc-123"}}`)
+
+				return err
+			}
+
+			cfg, err := config.ParseConfigFile(config.NewParsingContext(t.Context(), l, opts), l, configPath, nil)
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedVPCID, cfg.Inputs["vpc_id"])
+			assert.Equal(t, tc.expectedAttempts, attempts.Load())
+		})
+	}
+}
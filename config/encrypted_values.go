@@ -0,0 +1,44 @@
+package config
+
+import (
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/gruntwork-io/terragrunt/internal/cache"
+	"github.com/gruntwork-io/terragrunt/internal/encryption"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+// decryptEncryptedValues replaces the encrypted values found in the strings of the given value, e.g.
+// `ENC[age,YWdlLWVuY3J5cHRpb24...]`, with their plaintext. Plaintexts are cached for the run, so that a value shared by
+// many units is only decrypted once, and registered as sensitive values, so they are redacted from the logs.
+func decryptEncryptedValues(ctx *ParsingContext, l log.Logger, val cty.Value) (cty.Value, error) {
+	decryptedCache := cache.ContextCache[string](ctx, DecryptedValueCacheContextKey)
+
+	return cty.Transform(val, func(path cty.Path, v cty.Value) (cty.Value, error) {
+		if !v.IsKnown() || v.IsNull() || !v.Type().Equals(cty.String) {
+			return v, nil
+		}
+
+		unmarked, marks := v.Unmark()
+
+		envelope := unmarked.AsString()
+		if !encryption.IsEnvelope(envelope) {
+			return v, nil
+		}
+
+		plaintext, found := decryptedCache.Get(ctx, envelope)
+		if !found {
+			decrypted, err := encryption.Decrypt(ctx, l, ctx.TerragruntOptions, envelope)
+			if err != nil {
+				return v, path.NewError(err)
+			}
+
+			plaintext = decrypted
+			decryptedCache.Put(ctx, envelope, plaintext)
+		}
+
+		registerSensitiveValue(ctx, l, "the encrypted value "+formatCtyPath(path)+" in "+ctx.TerragruntOptions.TerragruntConfigPath, cty.StringVal(plaintext))
+
+		return cty.StringVal(plaintext).WithMarks(marks), nil
+	})
+}
@@ -0,0 +1,95 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/encryption"
+)
+
+func TestEncryptedValues(t *testing.T) {
+	t.Parallel()
+
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	password, err := encryption.EncryptAge([]byte("hunter2"), []string{identity.Recipient().String()})
+	require.NoError(t, err)
+
+	token, err := encryption.EncryptAge([]byte("s3cr3t"), []string{identity.Recipient().String()})
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	identityFile := filepath.Join(dir, "keys.txt")
+	configPath := filepath.Join(dir, config.DefaultTerragruntConfigPath)
+
+	require.NoError(t, os.WriteFile(identityFile, []byte(identity.String()+"\n"), 0600))
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+locals {
+  password = "`+password.String()+`"
+}
+
+inputs = {
+  db_password = local.password
+  api = {
+    tokens = ["`+token.String()+`"]
+  }
+  region = "us-east-1"
+}
+`), 0644))
+
+	l := createLogger()
+
+	opts := mockOptionsForTestWithConfigPath(t, configPath)
+	opts.Env = map[string]string{}
+
+	_, err = config.ParseConfigFile(config.NewParsingContext(t.Context(), l, opts), l, configPath, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no age identity configured")
+
+	opts = mockOptionsForTestWithConfigPath(t, configPath)
+	opts.AgeIdentityFile = identityFile
+
+	cfg, err := config.ParseConfigFile(config.NewParsingContext(t.Context(), l, opts), l, configPath, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", cfg.Locals["password"])
+	assert.Equal(t, map[string]any{
+		"db_password": "hunter2",
+		"api":         map[string]any{"tokens": []any{"s3cr3t"}},
+		"region":      "us-east-1",
+	}, cfg.Inputs)
+}
+
+func TestEncryptedValuesIgnoresSopsCiphertexts(t *testing.T) {
+	t.Parallel()
+
+	const sopsValue = "ENC[AES256_GCM,data:c2VjcmV0,iv:aXY=,tag:dGFn,type:str]"
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, config.DefaultTerragruntConfigPath)
+
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+locals {
+  secret = "`+sopsValue+`"
+}
+
+inputs = {
+  secret = local.secret
+}
+`), 0644))
+
+	l := createLogger()
+
+	opts := mockOptionsForTestWithConfigPath(t, configPath)
+
+	cfg, err := config.ParseConfigFile(config.NewParsingContext(t.Context(), l, opts), l, configPath, nil)
+	require.NoError(t, err)
+	assert.Equal(t, sopsValue, cfg.Locals["secret"])
+	assert.Equal(t, map[string]any{"secret": sopsValue}, cfg.Inputs)
+}
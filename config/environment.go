@@ -0,0 +1,204 @@
+package config
+
+import (
+	"path/filepath"
+	"slices"
+
+	"github.com/hashicorp/hcl/v2"
+
+	"github.com/gruntwork-io/terragrunt/config/hclparse"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/experiment"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+const (
+	// DefaultEnvironmentOverlaysDir is the name of the directory, searched in the unit folder and its parents, holding
+	// the environment overlays. The overlay of the `prod` environment is expected at `env/prod.hcl`.
+	DefaultEnvironmentOverlaysDir = "env"
+
+	environmentOverlayExt = ".hcl"
+)
+
+// terragruntEnvironment is a struct that can be used to only decode the environment attributes of a unit:
+//
+//	environment                = "prod"
+//	environment_merge_strategy = "shallow"
+type terragruntEnvironment struct {
+	Environment   *string  `hcl:"environment,optional"`
+	MergeStrategy *string  `hcl:"environment_merge_strategy,optional"`
+	Remain        hcl.Body `hcl:",remain"`
+}
+
+// environmentOverlayDecodeList are the sections of the partial parses the environment overlays are layered onto,
+// the other partial parses, e.g. of the feature flags or the tags of the units, being left as declared by the unit.
+var environmentOverlayDecodeList = []PartialDecodeSectionType{
+	DependenciesBlock,
+	DependencyBlock,
+	TerraformBlock,
+	TerraformSource,
+	TerragruntInputs,
+	RemoteStateBlock,
+}
+
+// environmentOverlay is the resolved overlay that applies to a unit.
+type environmentOverlay struct {
+	Name          string
+	Path          string
+	MergeStrategy MergeStrategyType
+}
+
+// applyEnvironmentOverlay layers the environment specific overlay onto the given config. The environment is the one
+// declared with the `environment` attribute or, when the `environment-inference` experiment is enabled, the closest
+// parent folder name of the unit that has an overlay. The overlay config overrides the unit config using the declared
+// `environment_merge_strategy`, which defaults to a deep merge. Locals are never merged, as with includes.
+func applyEnvironmentOverlay(ctx *ParsingContext, l log.Logger, configPath string, config *TerragruntConfig, isPartial bool) (*TerragruntConfig, error) {
+	overlay, err := resolveEnvironmentOverlay(ctx, l, configPath, config)
+	if err != nil || overlay == nil {
+		return config, err
+	}
+
+	ctx.TerragruntOptions.AppendReadFile(overlay.Path, ctx.TerragruntOptions.WorkingDir)
+
+	// Parse the overlay as if it was included by the unit, so that nested includes and overlays are not supported and
+	// the functions relative to the include resolve against the unit.
+	overlayInclude := &IncludeConfig{Name: MetadataEnvironment, Path: overlay.Path}
+
+	var overlayConfig *TerragruntConfig
+
+	if isPartial {
+		overlayConfig, err = PartialParseConfigFile(ctx, l, overlay.Path, overlayInclude)
+	} else {
+		overlayConfig, err = ParseConfigFile(ctx, l, overlay.Path, overlayInclude)
+	}
+
+	if err != nil {
+		return config, err
+	}
+
+	switch overlay.MergeStrategy { //nolint:exhaustive
+	case NoMerge:
+		l.Debugf("Environment overlay %s has strategy no merge: not merging config in.", overlay.Path)
+	case ShallowMerge:
+		l.Debugf("Merging environment overlay %s (shallow).", overlay.Path)
+
+		if err := config.Merge(l, overlayConfig, ctx.TerragruntOptions); err != nil {
+			return config, err
+		}
+	case DeepMerge:
+		l.Debugf("Merging environment overlay %s (deep).", overlay.Path)
+
+		if err := config.DeepMerge(l, overlayConfig, ctx.TerragruntOptions); err != nil {
+			return config, err
+		}
+	default:
+		return config, errors.New(InvalidEnvironmentMergeStrategyError(overlay.MergeStrategy))
+	}
+
+	config.Environment = overlay.Name
+
+	return config, nil
+}
+
+// needsEnvironmentOverlay returns true if the environment overlay is layered onto the partial parse of the given
+// decode list, see environmentOverlayDecodeList.
+func needsEnvironmentOverlay(decodeList []PartialDecodeSectionType) bool {
+	return slices.ContainsFunc(decodeList, func(decode PartialDecodeSectionType) bool {
+		return slices.Contains(environmentOverlayDecodeList, decode)
+	})
+}
+
+// decodeEnvironment decodes the environment attributes of the given file into the given partial config, so that they
+// are merged in from the includes as in the full parse.
+func decodeEnvironment(file *hclparse.File, evalCtx *hcl.EvalContext, config *TerragruntConfig) error {
+	decoded := terragruntEnvironment{}
+	if err := file.Decode(&decoded, evalCtx); err != nil {
+		return err
+	}
+
+	if decoded.Environment != nil {
+		config.Environment = *decoded.Environment
+	}
+
+	if decoded.MergeStrategy != nil {
+		config.environmentMergeStrategy = *decoded.MergeStrategy
+	}
+
+	return nil
+}
+
+// resolveEnvironmentOverlay returns the overlay that applies to the unit of the given config path, or nil if none. The
+// environment attributes are read from the given config, with its includes merged in.
+func resolveEnvironmentOverlay(ctx *ParsingContext, l log.Logger, configPath string, config *TerragruntConfig) (*environmentOverlay, error) {
+	mergeStrategy := DeepMerge
+
+	if config.environmentMergeStrategy != "" {
+		var err error
+
+		mergeStrategy, err = (&IncludeConfig{MergeStrategy: &config.environmentMergeStrategy}).GetMergeStrategy()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	unitDir := filepath.Dir(configPath)
+	overlaysDirs := findEnvironmentOverlaysDirs(unitDir)
+
+	if name := config.Environment; name != "" {
+		path := findEnvironmentOverlay(overlaysDirs, name)
+		if path == "" {
+			return nil, errors.New(EnvironmentOverlayNotFoundError{Environment: name, ConfigPath: configPath})
+		}
+
+		return &environmentOverlay{Name: name, Path: path, MergeStrategy: mergeStrategy}, nil
+	}
+
+	if len(overlaysDirs) == 0 || !ctx.TerragruntOptions.Experiments.Evaluate(experiment.EnvironmentInference) {
+		return nil, nil
+	}
+
+	// Infer the environment from the closest parent folder that has an overlay.
+	for dir := unitDir; filepath.Dir(dir) != dir; dir = filepath.Dir(dir) {
+		name := filepath.Base(dir)
+
+		if path := findEnvironmentOverlay(overlaysDirs, name); path != "" {
+			l.Debugf("Inferred environment %q for %s from its path", name, configPath)
+
+			return &environmentOverlay{Name: name, Path: path, MergeStrategy: mergeStrategy}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// findEnvironmentOverlaysDirs returns the overlay directories found in the given folder and its parents, closest first.
+func findEnvironmentOverlaysDirs(dir string) []string {
+	var dirs []string
+
+	for {
+		overlaysDir := filepath.Join(dir, DefaultEnvironmentOverlaysDir)
+		if util.IsDir(overlaysDir) {
+			dirs = append(dirs, overlaysDir)
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return dirs
+		}
+
+		dir = parent
+	}
+}
+
+// findEnvironmentOverlay returns the path of the closest overlay for the given environment, or an empty string.
+func findEnvironmentOverlay(overlaysDirs []string, environment string) string {
+	for _, dir := range overlaysDirs {
+		path := filepath.Join(dir, environment+environmentOverlayExt)
+		if util.FileExists(path) {
+			return path
+		}
+	}
+
+	return ""
+}
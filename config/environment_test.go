@@ -0,0 +1,177 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/experiment"
+)
+
+const environmentOverlayTestOverlay = `
+locals {
+  instance_type = "m5.large"
+}
+
+inputs = {
+  instance_type = local.instance_type
+  tags          = { env = "prod" }
+}
+`
+
+func TestEnvironmentOverlay(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		expectedInputs map[string]any
+		expectedErr    error
+		name           string
+		unitConfig     string
+		experiments    []string
+	}{
+		{
+			name: "deep merge by default",
+			unitConfig: `
+environment = "prod"
+
+inputs = {
+  instance_type = "t3.micro"
+  tags          = { team = "platform" }
+}
+`,
+			expectedInputs: map[string]any{
+				"instance_type": "m5.large",
+				"tags":          map[string]any{"team": "platform", "env": "prod"},
+			},
+		},
+		{
+			name: "shallow merge",
+			unitConfig: `
+environment                = "prod"
+environment_merge_strategy = "shallow"
+
+inputs = {
+  instance_type = "t3.micro"
+  tags          = { team = "platform" }
+}
+`,
+			expectedInputs: map[string]any{
+				"instance_type": "m5.large",
+				"tags":          map[string]any{"env": "prod"},
+			},
+		},
+		{
+			name:        "missing overlay",
+			unitConfig:  `environment = "stage"`,
+			expectedErr: config.EnvironmentOverlayNotFoundError{},
+		},
+		{
+			name:           "not inferred without experiment",
+			unitConfig:     `inputs = { instance_type = "t3.micro" }`,
+			expectedInputs: map[string]any{"instance_type": "t3.micro"},
+		},
+		{
+			name:        "inferred from path",
+			unitConfig:  `inputs = { instance_type = "t3.micro" }`,
+			experiments: []string{experiment.EnvironmentInference},
+			expectedInputs: map[string]any{
+				"instance_type": "m5.large",
+				"tags":          map[string]any{"env": "prod"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rootDir := t.TempDir()
+			unitDir := filepath.Join(rootDir, "live", "prod", "app")
+			configPath := filepath.Join(unitDir, config.DefaultTerragruntConfigPath)
+
+			require.NoError(t, os.MkdirAll(unitDir, 0755))
+			require.NoError(t, os.MkdirAll(filepath.Join(rootDir, config.DefaultEnvironmentOverlaysDir), 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(rootDir, config.DefaultEnvironmentOverlaysDir, "prod.hcl"), []byte(environmentOverlayTestOverlay), 0644))
+			require.NoError(t, os.WriteFile(configPath, []byte(tc.unitConfig), 0644))
+
+			opts := mockOptionsForTestWithConfigPath(t, configPath)
+			for _, name := range tc.experiments {
+				require.NoError(t, opts.Experiments.EnableExperiment(name))
+			}
+
+			l := createLogger()
+			ctx := config.NewParsingContext(t.Context(), l, opts)
+
+			cfg, err := config.ParseConfigFile(ctx, l, configPath, nil)
+			if tc.expectedErr != nil {
+				require.Error(t, err)
+
+				target := config.EnvironmentOverlayNotFoundError{}
+				assert.True(t, errors.As(err, &target), "unexpected error: %v", err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedInputs, cfg.Inputs)
+			assert.Nil(t, cfg.Locals["instance_type"])
+		})
+	}
+}
+
+func TestEnvironmentOverlayFromInclude(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	unitDir := filepath.Join(rootDir, "live", "app")
+	configPath := filepath.Join(unitDir, config.DefaultTerragruntConfigPath)
+
+	require.NoError(t, os.MkdirAll(unitDir, 0755))
+	require.NoError(t, os.MkdirAll(filepath.Join(rootDir, config.DefaultEnvironmentOverlaysDir), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, config.DefaultEnvironmentOverlaysDir, "prod.hcl"), []byte(environmentOverlayTestOverlay), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "root.hcl"), []byte(`
+environment                = "prod"
+environment_merge_strategy = "shallow"
+`), 0644))
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+
+inputs = {
+  instance_type = "t3.micro"
+  tags          = { team = "platform" }
+}
+`), 0644))
+
+	expectedInputs := map[string]any{
+		"instance_type": "m5.large",
+		"tags":          map[string]any{"env": "prod"},
+	}
+
+	l := createLogger()
+	opts := mockOptionsForTestWithConfigPath(t, configPath)
+
+	cfg, err := config.ParseConfigFile(config.NewParsingContext(t.Context(), l, opts), l, configPath, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "prod", cfg.Environment)
+	assert.Equal(t, expectedInputs, cfg.Inputs)
+
+	ctx := config.NewParsingContext(t.Context(), l, opts).WithDecodeList(config.TerragruntInputs)
+
+	cfg, err = config.PartialParseConfigFile(ctx, l, configPath, nil)
+	require.NoError(t, err)
+	assert.Equal(t, expectedInputs, cfg.Inputs)
+
+	// The partial parses of the other sections aren't layered with the overlay.
+	ctx = config.NewParsingContext(t.Context(), l, opts).WithDecodeList(config.TagsAttr)
+
+	cfg, err = config.PartialParseConfigFile(ctx, l, configPath, nil)
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Environment)
+}
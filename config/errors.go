@@ -3,6 +3,10 @@ package config
 import (
 	"fmt"
 	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/zclconf/go-cty/cty"
 )
 
 // Custom error types
@@ -188,6 +192,217 @@ func (err InvalidIncludeKeyError) Error() string {
 	return fmt.Sprintf("There is no include block in the current config with the label '%s'", err.name)
 }
 
+type MissingRequiredValueError struct {
+	Name       string
+	ConfigPath string
+}
+
+func (err MissingRequiredValueError) Error() string {
+	return fmt.Sprintf("No value provided for value %q declared in %s. Set it in the %s file of the unit or add a default to the value block.", err.Name, err.ConfigPath, valuesFile)
+}
+
+type InvalidValueError struct {
+	Name       string
+	ConfigPath string
+	Message    string
+}
+
+func (err InvalidValueError) Error() string {
+	return fmt.Sprintf("Invalid value for %q declared in %s: %s", err.Name, err.ConfigPath, err.Message)
+}
+
+type EnvironmentOverlayNotFoundError struct {
+	Environment string
+	ConfigPath  string
+}
+
+func (err EnvironmentOverlayNotFoundError) Error() string {
+	return fmt.Sprintf("Could not find the overlay %s/%s.hcl of environment %q declared in %s, or in any of its parent folders.", DefaultEnvironmentOverlaysDir, err.Environment, err.Environment, err.ConfigPath)
+}
+
+type InvalidEnvironmentMergeStrategyError MergeStrategyType
+
+func (err InvalidEnvironmentMergeStrategyError) Error() string {
+	return fmt.Sprintf("Environment merge strategy %s is not supported. Valid strategies are: %s, %s, %s", string(err), NoMerge, ShallowMerge, DeepMerge)
+}
+
+type ExtendedUnitNotFoundError struct {
+	Path       string
+	ConfigPath string
+}
+
+func (err ExtendedUnitNotFoundError) Error() string {
+	return fmt.Sprintf("Could not find the unit %s extended in %s.", err.Path, err.ConfigPath)
+}
+
+type ExtendsCycleError []string
+
+func (err ExtendsCycleError) Error() string {
+	return "Found a cycle in the units extended with the extends attribute: " + strings.Join(err, " -> ")
+}
+
+type ReadUnitCycleError []string
+
+func (err ReadUnitCycleError) Error() string {
+	return "Found a cycle in the units read with read_unit_config or read_unit_locals: " + strings.Join(err, " -> ")
+}
+
+type InvalidHooksMergeStrategyTypeError string
+
+func (err InvalidHooksMergeStrategyTypeError) Error() string {
+	return fmt.Sprintf(
+		"Include hooks merge strategy %s is unknown. Valid strategies are: %s, %s, %s",
+		string(err),
+		OverrideHooks,
+		ParentFirstHooks,
+		ChildFirstHooks,
+	)
+}
+
+type InvalidFunctionNameError struct {
+	Name string
+}
+
+func (err InvalidFunctionNameError) Error() string {
+	return fmt.Sprintf("Invalid custom function name %q: function names must be valid HCL identifiers.", err.Name)
+}
+
+type DuplicateFunctionError struct {
+	Name string
+}
+
+func (err DuplicateFunctionError) Error() string {
+	return fmt.Sprintf("Custom function %s is already registered.", err.Name)
+}
+
+type FunctionNameConflictError struct {
+	Name string
+}
+
+func (err FunctionNameConflictError) Error() string {
+	return fmt.Sprintf("Custom function %s conflicts with the built-in function of the same name.", err.Name)
+}
+
+type FunctionPluginError struct {
+	Err  error
+	Name string
+	Path string
+}
+
+func (err FunctionPluginError) Error() string {
+	return fmt.Sprintf("Function plugin %s (%s) failed: %v", err.Name, err.Path, err.Err)
+}
+
+func (err FunctionPluginError) Unwrap() error {
+	return err.Err
+}
+
+type GenerateTemplateConflictError struct {
+	Name string
+}
+
+func (err GenerateTemplateConflictError) Error() string {
+	return fmt.Sprintf("generate block %s must set exactly one of contents or template_file, and template_file requires the %s template engine.", err.Name, GenerateTemplateEngineGo)
+}
+
+type GenerateFilesConflictError struct {
+	Name string
+}
+
+func (err GenerateFilesConflictError) Error() string {
+	return fmt.Sprintf("generate block %s must set exactly one of path or files, and files can't be combined with contents or template_file.", err.Name)
+}
+
+type InvalidGenerateTemplateEngineError struct {
+	Name   string
+	Engine string
+}
+
+func (err InvalidGenerateTemplateEngineError) Error() string {
+	return fmt.Sprintf("generate block %s has unknown template_engine %s. Valid engines are: %s, %s", err.Name, err.Engine, GenerateTemplateEngineNone, GenerateTemplateEngineGo)
+}
+
+type GenerateTemplateError struct {
+	Err  error
+	Name string
+}
+
+func (err GenerateTemplateError) Error() string {
+	return fmt.Sprintf("failed to render the template of generate block %s: %v", err.Name, err.Err)
+}
+
+func (err GenerateTemplateError) Unwrap() error {
+	return err.Err
+}
+
+type IncludeCycleError []string
+
+func (err IncludeCycleError) Error() string {
+	return "Found a cycle between included configs: " + strings.Join(err, " -> ")
+}
+
+type DuplicatedImportBlockError string
+
+func (err DuplicatedImportBlockError) Error() string {
+	return fmt.Sprintf("Detected multiple import blocks with the name %q. Import block names must be unique.", string(err))
+}
+
+type InvalidImportFormatError struct {
+	Name   string
+	Format string
+}
+
+func (err InvalidImportFormatError) Error() string {
+	return fmt.Sprintf("Invalid format %q in import block %q. Valid formats are %q and %q.", err.Format, err.Name, ImportFormatJSON, ImportFormatHCL)
+}
+
+type InvalidImportChecksumError struct {
+	Name     string
+	Checksum string
+}
+
+func (err InvalidImportChecksumError) Error() string {
+	return fmt.Sprintf("Invalid checksum %q in import block %q. The checksum must be a sha256 hex digest, e.g. sha256:<digest>.", err.Checksum, err.Name)
+}
+
+type ImportChecksumMismatchError struct {
+	Name     string
+	Source   string
+	Expected string
+	Actual   string
+}
+
+func (err ImportChecksumMismatchError) Error() string {
+	return fmt.Sprintf("Checksum mismatch for import block %q from %s: expected sha256:%s, got sha256:%s", err.Name, err.Source, err.Expected, err.Actual)
+}
+
+type ImportFetchError struct {
+	Err    error
+	Name   string
+	Source string
+}
+
+func (err ImportFetchError) Error() string {
+	return fmt.Sprintf("Failed to fetch the document of import block %q from %s: %v", err.Name, err.Source, err.Err)
+}
+
+func (err ImportFetchError) Unwrap() error {
+	return err.Err
+}
+
+type InvalidImportDocumentError struct {
+	Err  error
+	Name string
+}
+
+func (err InvalidImportDocumentError) Error() string {
+	return fmt.Sprintf("Failed to decode the document of import block %q: %v", err.Name, err.Err)
+}
+
+func (err InvalidImportDocumentError) Unwrap() error {
+	return err.Err
+}
+
 // Dependency Custom error types
 
 type DependencyConfigNotFound struct {
@@ -272,3 +487,294 @@ type DependencyCycleError []string
 func (err DependencyCycleError) Error() string {
 	return "Found a dependency cycle between modules: " + strings.Join([]string(err), " -> ")
 }
+
+type TFEWorkspaceOutputError struct {
+	Err       error
+	Workspace string
+}
+
+func (err TFEWorkspaceOutputError) Error() string {
+	return fmt.Sprintf("Failed to read the outputs of the workspace %s: %v", err.Workspace, err.Err)
+}
+
+func (err TFEWorkspaceOutputError) Unwrap() error {
+	return err.Err
+}
+
+type TFEWorkspaceOutputNotFoundError struct {
+	Workspace string
+	Output    string
+}
+
+func (err TFEWorkspaceOutputNotFoundError) Error() string {
+	return fmt.Sprintf("The current state of the workspace %s has no output %q", err.Workspace, err.Output)
+}
+
+type InvalidRetryBlockError struct {
+	Name   string
+	Reason string
+}
+
+func (err InvalidRetryBlockError) Error() string {
+	return fmt.Sprintf("Invalid retry block %q: %s", err.Name, err.Reason)
+}
+
+type AssertionFailedError struct {
+	Name         string
+	ErrorMessage string
+	ConfigPath   string
+}
+
+func (err AssertionFailedError) Error() string {
+	if err.ConfigPath != "" {
+		return fmt.Sprintf("Assertion %s of %s failed: %s", err.Name, err.ConfigPath, err.ErrorMessage)
+	}
+
+	return fmt.Sprintf("Assertion %s failed: %s", err.Name, err.ErrorMessage)
+}
+
+type DuplicatedAssertBlocksError struct {
+	Names []string
+}
+
+func (err DuplicatedAssertBlocksError) Error() string {
+	return fmt.Sprintf("Detected multiple assert blocks with the same name: %v", err.Names)
+}
+
+type MockOutputsFileError struct {
+	Err        error
+	Dependency string
+	Path       string
+}
+
+func (err MockOutputsFileError) Error() string {
+	return fmt.Sprintf("Failed to read mock outputs file %s of dependency %s: %v", err.Path, err.Dependency, err.Err)
+}
+
+func (err MockOutputsFileError) Unwrap() error {
+	return err.Err
+}
+
+type DependencyTargetError struct {
+	Name string
+}
+
+func (err DependencyTargetError) Error() string {
+	return fmt.Sprintf("dependency block %s must set exactly one of config_path, tfe_workspace or cloudformation_stack", err.Name)
+}
+
+type ExternalDependencyNoOutputsError struct {
+	Name   string
+	Target string
+}
+
+func (err ExternalDependencyNoOutputsError) Error() string {
+	return fmt.Sprintf("%s, the target of dependency %s, has no outputs, or they could not be read. Set mock_outputs on the dependency to use when the outputs are not available.", err.Target, err.Name)
+}
+
+type CloudFormationStackRegionError struct {
+	Name string
+}
+
+func (err CloudFormationStackRegionError) Error() string {
+	return fmt.Sprintf("dependency block %s must set the region of its cloudformation_stack", err.Name)
+}
+
+type DependencyCycleDiagnosticsError struct {
+	Err           error
+	Edges         []DependencyCycleEdge
+	SuggestedEdge int
+}
+
+func (err DependencyCycleDiagnosticsError) Error() string {
+	var sb strings.Builder
+
+	sb.WriteString(err.Err.Error())
+	sb.WriteString("\nThe cycle is created by:")
+
+	for _, edge := range err.Edges {
+		declaration := "unknown declaration"
+		if edge.Declaration != nil {
+			declaration = edge.Declaration.String()
+		}
+
+		fmt.Fprintf(&sb, "\n  %s -> %s: %s", edge.From, edge.To, declaration)
+	}
+
+	if err.SuggestedEdge >= 0 && err.SuggestedEdge < len(err.Edges) {
+		edge := err.Edges[err.SuggestedEdge]
+		reason := "it closes the cycle"
+
+		switch {
+		case edge.Declaration != nil && edge.Declaration.SkipOutputs:
+			reason = "it only orders the units, without reading the outputs of the dependency"
+		case edge.Dependents > 0:
+			reason = fmt.Sprintf("%s is depended on by %d units, so it is more likely to be depended on than to depend on others", edge.From, edge.Dependents)
+		}
+
+		fmt.Fprintf(&sb, "\nThe dependency %s -> %s looks like the most likely mistake: %s.", edge.From, edge.To, reason)
+	}
+
+	return sb.String()
+}
+
+func (err DependencyCycleDiagnosticsError) Unwrap() error {
+	return err.Err
+}
+
+type DependencyOutputSchemaError struct {
+	Err        error
+	Expected   cty.Type
+	Actual     cty.Type
+	Dependency string
+	Target     string
+	Output     string
+}
+
+func (err DependencyOutputSchemaError) Error() string {
+	if err.Actual == cty.NilType {
+		return fmt.Sprintf("dependency %s (%s) is expected to have the output %s of type %s, but it has no such output. The interface of the dependency may have changed.", err.Dependency, err.Target, err.Output, typeexpr.TypeString(err.Expected))
+	}
+
+	return fmt.Sprintf("dependency %s (%s) is expected to have the output %s of type %s, but its value of type %s can't be converted: %v. The interface of the dependency may have changed.", err.Dependency, err.Target, err.Output, typeexpr.TypeString(err.Expected), typeexpr.TypeString(err.Actual), err.Err)
+}
+
+func (err DependencyOutputSchemaError) Unwrap() error {
+	return err.Err
+}
+
+type DependencyOfflineNoOutputsError struct {
+	Dependency string
+	Target     string
+	Config     string
+}
+
+func (err DependencyOfflineNoOutputsError) Error() string {
+	return fmt.Sprintf("dependency %s (%s) of %s has no cached outputs and no mock_outputs, and dependencies are resolved offline. Set mock_outputs on the dependency, or fetch its outputs with --dependency-output-cache before running offline.", err.Dependency, err.Target, err.Config)
+}
+
+type DependencyOutputCacheStaleError struct {
+	FetchedAt  time.Time
+	ModifiedAt time.Time
+	Target     string
+}
+
+func (err DependencyOutputCacheStaleError) Error() string {
+	return fmt.Sprintf("the outputs of %s cached at %s are stale, as its state was modified at %s. Delete the dependency output cache, or disable the stale-dependency-outputs strict control to fetch them again.", err.Target, err.FetchedAt.Format(time.RFC3339), err.ModifiedAt.Format(time.RFC3339))
+}
+
+type DependencySelectedOutputsNotFoundError struct {
+	Dependency string
+	Target     string
+	Outputs    []string
+}
+
+func (err DependencySelectedOutputsNotFoundError) Error() string {
+	return fmt.Sprintf("dependency %s (%s) has no outputs %s selected with its outputs attribute.", err.Dependency, err.Target, strings.Join(err.Outputs, ", "))
+}
+
+type InvalidWaitForOutputsError struct {
+	Dependency string
+	Reason     string
+}
+
+func (err InvalidWaitForOutputsError) Error() string {
+	return fmt.Sprintf("invalid wait_for_outputs block of dependency %s: %s", err.Dependency, err.Reason)
+}
+
+type InvalidDependencyPolicyError struct {
+	Path   string
+	Rule   string
+	Reason string
+}
+
+func (err InvalidDependencyPolicyError) Error() string {
+	return fmt.Sprintf("invalid rule %s of dependency policy %s: %s", err.Rule, err.Path, err.Reason)
+}
+
+type DependencyPolicyViolationsError struct {
+	Path       string
+	Violations []DependencyPolicyViolation
+}
+
+func (err DependencyPolicyViolationsError) Error() string {
+	lines := make([]string, 0, len(err.Violations))
+
+	for _, violation := range err.Violations {
+		line := fmt.Sprintf("  %s -> %s: violates rule %s", violation.From, violation.To, violation.Rule)
+		if violation.Description != "" {
+			line += ": " + violation.Description
+		}
+
+		lines = append(lines, line)
+	}
+
+	return fmt.Sprintf("%d dependencies violate the dependency policy %s:\n%s", len(err.Violations), err.Path, strings.Join(lines, "\n"))
+}
+
+type DependencyOutputError struct {
+	Err        error
+	Dependency string
+	Target     string
+	Class      DependencyOutputErrorClass
+}
+
+func (err DependencyOutputError) Error() string {
+	return fmt.Sprintf("failed to fetch the outputs of dependency %s (%s), %s: %v", err.Dependency, err.Target, err.Class, err.Err)
+}
+
+func (err DependencyOutputError) Unwrap() error {
+	return err.Err
+}
+
+type DependencyOutputTimeoutError struct {
+	Err        error
+	Dependency string
+	Timeout    time.Duration
+}
+
+func (err DependencyOutputTimeoutError) Error() string {
+	return fmt.Sprintf("timed out after %s fetching the outputs of dependency %s: %v", err.Timeout, err.Dependency, err.Err)
+}
+
+func (err DependencyOutputTimeoutError) Unwrap() error {
+	return err.Err
+}
+
+type InvalidParallelismLimitError struct {
+	Path   string
+	Name   string
+	Reason string
+}
+
+func (err InvalidParallelismLimitError) Error() string {
+	return fmt.Sprintf("invalid parallelism_limit %s in %s: %s", err.Name, err.Path, err.Reason)
+}
+
+type InvalidApplyWindowError struct {
+	Path   string
+	Name   string
+	Reason string
+}
+
+func (err InvalidApplyWindowError) Error() string {
+	return fmt.Sprintf("invalid apply_window %s in %s: %s", err.Name, err.Path, err.Reason)
+}
+
+type ApplyWindowNeverOpensError struct {
+	Name     string
+	Schedule string
+}
+
+func (err ApplyWindowNeverOpensError) Error() string {
+	return fmt.Sprintf("apply_window %s never opens, as no time in the next years matches its schedule %q", err.Name, err.Schedule)
+}
+
+type InvalidFailurePolicyError struct {
+	Path   string
+	Reason string
+}
+
+func (err InvalidFailurePolicyError) Error() string {
+	return fmt.Sprintf("invalid failure_policy in %s: %s", err.Path, err.Reason)
+}
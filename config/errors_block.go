@@ -2,6 +2,7 @@ package config
 
 import (
 	"maps"
+	"slices"
 
 	"github.com/gruntwork-io/terragrunt/util"
 	"github.com/zclconf/go-cty/cty"
@@ -15,10 +16,14 @@ type ErrorsConfig struct {
 
 // RetryBlock represents a labeled retry block
 type RetryBlock struct {
-	Label            string   `cty:"name" hcl:"name,label"`
-	RetryableErrors  []string `cty:"retryable_errors" hcl:"retryable_errors"`
-	MaxAttempts      int      `cty:"max_attempts" hcl:"max_attempts"`
-	SleepIntervalSec int      `cty:"sleep_interval_sec" hcl:"sleep_interval_sec"`
+	Label               string   `cty:"name" hcl:"name,label"`
+	Action              string   `cty:"action" hcl:"action,optional"`
+	Backoff             string   `cty:"backoff" hcl:"backoff,optional"`
+	RetryableErrors     []string `cty:"retryable_errors" hcl:"retryable_errors,optional"`
+	ExitCodes           []int    `cty:"exit_codes" hcl:"exit_codes,optional"`
+	MaxAttempts         int      `cty:"max_attempts" hcl:"max_attempts,optional"`
+	SleepIntervalSec    int      `cty:"sleep_interval_sec" hcl:"sleep_interval_sec,optional"`
+	MaxSleepIntervalSec int      `cty:"max_sleep_interval_sec" hcl:"max_sleep_interval_sec,optional"`
 }
 
 // IgnoreBlock represents a labeled ignore block
@@ -58,10 +63,14 @@ func (r *RetryBlock) Clone() *RetryBlock {
 	}
 
 	return &RetryBlock{
-		Label:            r.Label,
-		RetryableErrors:  cloneStringSlice(r.RetryableErrors),
-		MaxAttempts:      r.MaxAttempts,
-		SleepIntervalSec: r.SleepIntervalSec,
+		Label:               r.Label,
+		Action:              r.Action,
+		Backoff:             r.Backoff,
+		RetryableErrors:     cloneStringSlice(r.RetryableErrors),
+		ExitCodes:           slices.Clone(r.ExitCodes),
+		MaxAttempts:         r.MaxAttempts,
+		SleepIntervalSec:    r.SleepIntervalSec,
+		MaxSleepIntervalSec: r.MaxSleepIntervalSec,
 	}
 }
 
@@ -145,6 +154,20 @@ func mergeRetryBlocks(existing, other []*RetryBlock) []*RetryBlock {
 		if existingBlock, found := retryMap[otherBlock.Label]; found {
 			existingBlock.RetryableErrors = util.MergeStringSlices(existingBlock.RetryableErrors, otherBlock.RetryableErrors)
 
+			for _, exitCode := range otherBlock.ExitCodes {
+				if !slices.Contains(existingBlock.ExitCodes, exitCode) {
+					existingBlock.ExitCodes = append(existingBlock.ExitCodes, exitCode)
+				}
+			}
+
+			if otherBlock.Action != "" {
+				existingBlock.Action = otherBlock.Action
+			}
+
+			if otherBlock.Backoff != "" {
+				existingBlock.Backoff = otherBlock.Backoff
+			}
+
 			if otherBlock.MaxAttempts > 0 {
 				existingBlock.MaxAttempts = otherBlock.MaxAttempts
 			}
@@ -153,6 +176,10 @@ func mergeRetryBlocks(existing, other []*RetryBlock) []*RetryBlock {
 				existingBlock.SleepIntervalSec = otherBlock.SleepIntervalSec
 			}
 
+			if otherBlock.MaxSleepIntervalSec > 0 {
+				existingBlock.MaxSleepIntervalSec = otherBlock.MaxSleepIntervalSec
+			}
+
 			continue
 		}
 
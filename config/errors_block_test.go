@@ -0,0 +1,154 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/cli"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+func TestRetryBlockRules(t *testing.T) {
+	t.Parallel()
+
+	l := createLogger()
+	ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTest(t))
+
+	cfg, err := config.ParseConfigString(ctx, l, config.DefaultTerragruntConfigPath, `
+errors {
+  retry "transient" {
+    retryable_errors       = [".*connection reset.*", ".*timeout.*"]
+    max_attempts           = 5
+    sleep_interval_sec     = 2
+    backoff                = "exponential"
+    max_sleep_interval_sec = 5
+  }
+
+  retry "stale_providers" {
+    retryable_errors = [".*Inconsistent dependency lock file.*"]
+    action           = "retry-with-init"
+    max_attempts     = 2
+  }
+
+  retry "quota" {
+    retryable_errors = [".*timeout waiting for quota.*"]
+    action           = "fail"
+  }
+
+  retry "drift" {
+    exit_codes = [2]
+    action     = "warn-and-continue"
+  }
+}
+`, nil)
+	require.NoError(t, err)
+
+	errorsConfig, err := cfg.ErrorsConfig()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		err      error
+		expected *options.ErrorAction
+		name     string
+		attempt  int
+	}{
+		{
+			name:     "exponential backoff",
+			err:      errors.New("read: connection reset by peer"),
+			attempt:  2,
+			expected: &options.ErrorAction{RetryBlockName: "transient", RetryMessage: "transient", RetryAttempts: 5, RetrySleepSecs: 4, ShouldRetry: true},
+		},
+		{
+			name:     "capped backoff",
+			err:      errors.New("read: connection reset by peer"),
+			attempt:  4,
+			expected: &options.ErrorAction{RetryBlockName: "transient", RetryMessage: "transient", RetryAttempts: 5, RetrySleepSecs: 5, ShouldRetry: true},
+		},
+		{
+			name:     "retry with init",
+			err:      errors.New("Error: Inconsistent dependency lock file"),
+			attempt:  1,
+			expected: &options.ErrorAction{RetryBlockName: "stale_providers", RetryMessage: "stale_providers", RetryAttempts: 2, ShouldRetry: true, RetryWithInit: true},
+		},
+		{
+			name:     "exit code",
+			err:      cli.NewExitError(errors.New("exit status 2"), 2),
+			attempt:  1,
+			expected: &options.ErrorAction{RetryBlockName: "drift", ShouldContinue: true},
+		},
+		{
+			name:    "fail takes precedence",
+			err:     errors.New("timeout waiting for quota"),
+			attempt: 1,
+		},
+		{
+			name:    "not matching",
+			err:     errors.New("Error: invalid reference"),
+			attempt: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			action, err := errorsConfig.ProcessError(l, tc.err, tc.attempt)
+			if tc.expected == nil {
+				require.ErrorIs(t, err, tc.err)
+				assert.Nil(t, action)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, action)
+		})
+	}
+}
+
+func TestRetryBlockValidation(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		block    string
+		expected string
+	}{
+		{
+			name: "invalid action",
+			block: `retryable_errors = [".*"]
+max_attempts = 3
+action = "skip"`,
+			expected: `Invalid retry block "test": invalid action "skip"`,
+		},
+		{
+			name:     "no matcher",
+			block:    `max_attempts = 3`,
+			expected: `Invalid retry block "test": retryable_errors or exit_codes must be set`,
+		},
+		{
+			name:     "missing max attempts",
+			block:    `exit_codes = [1]`,
+			expected: `Invalid retry block "test": max_attempts must be at least 1, but is 0`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := createLogger()
+			ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTest(t))
+
+			cfg, err := config.ParseConfigString(ctx, l, config.DefaultTerragruntConfigPath, "errors {\n  retry \"test\" {\n"+tc.block+"\n  }\n}\n", nil)
+			require.NoError(t, err)
+
+			_, err = cfg.ErrorsConfig()
+			require.ErrorContains(t, err, tc.expected)
+		})
+	}
+}
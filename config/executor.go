@@ -0,0 +1,79 @@
+package config
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+// ExecutorConfig is the `executor` block, dispatching the OpenTofu/Terraform commands of the unit to a remote runner
+// agent instead of running them locally, e.g.:
+//
+//	executor {
+//	  address     = "runner.eu-west-1.example.com:7000"
+//	  forward_env = ["AWS_REGION"]
+//	  meta        = { environment = "prod" }
+//	}
+//
+// The agent serves the gRPC protocol of the IaC engines, streaming the output of the commands back. Only the `TF_*` env
+// vars of the unit, and the ones listed in `forward_env`, are sent to the agent, which holds the credentials of the
+// environment it runs in. An empty `address` runs the unit locally, e.g. to override an included block.
+type ExecutorConfig struct {
+	Address    *string           `hcl:"address,optional" cty:"address"`
+	Insecure   *bool             `hcl:"insecure,optional" cty:"insecure"`
+	ForwardEnv []string          `hcl:"forward_env,optional" cty:"forward_env"`
+	Meta       map[string]string `hcl:"meta,optional" cty:"meta"`
+}
+
+// Clone returns a new instance of ExecutorConfig with the same values as the original.
+func (executor *ExecutorConfig) Clone() *ExecutorConfig {
+	return &ExecutorConfig{
+		Address:    executor.Address,
+		Insecure:   executor.Insecure,
+		ForwardEnv: slices.Clone(executor.ForwardEnv),
+		Meta:       maps.Clone(executor.Meta),
+	}
+}
+
+// Merge merges the values set in the given executor into the executor.
+func (executor *ExecutorConfig) Merge(source *ExecutorConfig) {
+	if source.Address != nil {
+		executor.Address = source.Address
+	}
+
+	if source.Insecure != nil {
+		executor.Insecure = source.Insecure
+	}
+
+	if source.ForwardEnv != nil {
+		executor.ForwardEnv = slices.Clone(source.ForwardEnv)
+	}
+
+	if source.Meta != nil {
+		executor.Meta = maps.Clone(source.Meta)
+	}
+}
+
+// ExecutorOptions returns the options of the remote executor of the unit, or nil if the unit runs locally.
+func (cfg *TerragruntConfig) ExecutorOptions() *options.ExecutorOptions {
+	if cfg.Executor == nil || cfg.Executor.Address == nil || *cfg.Executor.Address == "" {
+		return nil
+	}
+
+	executor := &options.ExecutorOptions{
+		Address:    *cfg.Executor.Address,
+		ForwardEnv: slices.Clone(cfg.Executor.ForwardEnv),
+		Meta:       map[string]any{},
+	}
+
+	if cfg.Executor.Insecure != nil {
+		executor.Insecure = *cfg.Executor.Insecure
+	}
+
+	for key, value := range cfg.Executor.Meta {
+		executor.Meta[key] = value
+	}
+
+	return executor
+}
@@ -0,0 +1,73 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+func TestParseExecutor(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	prodDir := filepath.Join(rootDir, "prod", "vpc")
+	devDir := filepath.Join(rootDir, "dev", "vpc")
+
+	require.NoError(t, os.MkdirAll(prodDir, 0755))
+	require.NoError(t, os.MkdirAll(devDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "root.hcl"), []byte(`
+executor {
+  address     = "runner.eu-west-1.example.com:7000"
+  forward_env = ["AWS_REGION"]
+}
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(prodDir, config.DefaultTerragruntConfigPath), []byte(`
+include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+
+executor {
+  meta = {
+    environment = "prod"
+  }
+}
+`), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(devDir, config.DefaultTerragruntConfigPath), []byte(`
+include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+
+executor {
+  address = ""
+}
+`), 0644))
+
+	l := createLogger()
+
+	configPath := filepath.Join(prodDir, config.DefaultTerragruntConfigPath)
+	opts := mockOptionsForTestWithConfigPath(t, configPath)
+
+	cfg, err := config.ParseConfigFile(config.NewParsingContext(t.Context(), l, opts), l, configPath, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, &options.ExecutorOptions{
+		Address:    "runner.eu-west-1.example.com:7000",
+		ForwardEnv: []string{"AWS_REGION"},
+		Meta:       map[string]any{"environment": "prod"},
+	}, cfg.ExecutorOptions())
+
+	// An empty address runs the unit locally.
+	configPath = filepath.Join(devDir, config.DefaultTerragruntConfigPath)
+	opts = mockOptionsForTestWithConfigPath(t, configPath)
+
+	cfg, err = config.ParseConfigFile(config.NewParsingContext(t.Context(), l, opts), l, configPath, nil)
+	require.NoError(t, err)
+
+	assert.Nil(t, cfg.ExecutorOptions())
+}
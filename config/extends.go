@@ -0,0 +1,105 @@
+package config
+
+import (
+	"path/filepath"
+	"slices"
+
+	"github.com/hashicorp/hcl/v2"
+
+	"github.com/gruntwork-io/terragrunt/config/hclparse"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// terragruntExtends is a struct that can be used to only decode the extends attribute of a unit:
+//
+//	extends = "../base-unit"
+type terragruntExtends struct {
+	Extends *string  `hcl:"extends,optional"`
+	Remain  hcl.Body `hcl:",remain"`
+}
+
+// extendUnitConfig merges the given config onto the fully resolved config of the unit it extends with the `extends`
+// attribute, if any. The extended unit is parsed, along with its own includes and extended units, as if it was declared
+// in the folder of the extending unit, so that functions such as `get_terragrunt_dir` and `path_relative_to_include`
+// and the dependency paths resolve against the extending unit. The extending unit config overrides the extended one
+// using a deep merge, except for the locals, which remain local in scope.
+func extendUnitConfig(ctx *ParsingContext, l log.Logger, file *hclparse.File, config *TerragruntConfig, isPartial bool) (*TerragruntConfig, error) {
+	basePath, err := resolveExtendedUnit(ctx, l, file)
+	if err != nil || basePath == "" {
+		return config, err
+	}
+
+	extended := ctx.ExtendedUnits
+	if len(extended) == 0 {
+		extended = []string{filepath.Clean(file.ConfigPath)}
+	}
+
+	if slices.Contains(extended, basePath) {
+		return config, errors.New(ExtendsCycleError(append(slices.Clone(extended), basePath)))
+	}
+
+	ctx.TerragruntOptions.AppendReadFile(basePath, ctx.TerragruntOptions.WorkingDir)
+
+	baseCtx := ctx.WithTrackInclude(nil)
+	baseCtx.ExtendedUnits = extended
+	baseCtx = baseCtx.WithExtendedUnit(basePath)
+	// The dependency blocks of the extended unit are decoded with its own config.
+	baseCtx.DecodedDependencies = nil
+
+	var baseConfig *TerragruntConfig
+
+	if isPartial {
+		baseConfig, err = PartialParseConfigFile(baseCtx, l, basePath, nil)
+	} else {
+		baseConfig, err = ParseConfigFile(baseCtx, l, basePath, nil)
+	}
+
+	if err != nil {
+		return config, err
+	}
+
+	l.Debugf("Merging %s onto the extended unit %s (deep).", file.ConfigPath, basePath)
+
+	if err := baseConfig.DeepMerge(l, config, ctx.TerragruntOptions); err != nil {
+		return config, err
+	}
+
+	baseConfig.Locals = config.Locals
+	baseConfig.IncludeLocals = config.IncludeLocals
+	baseConfig.ProcessedIncludes = config.ProcessedIncludes
+
+	return baseConfig, nil
+}
+
+// resolveExtendedUnit returns the config path of the unit extended by the unit declared in the given file, or an empty
+// string if none. The `extends` attribute is resolved relative to the folder of the unit and may point to either the
+// folder of the extended unit or its config file.
+func resolveExtendedUnit(ctx *ParsingContext, l log.Logger, file *hclparse.File) (string, error) {
+	evalCtx, err := createTerragruntEvalContext(ctx, l, file.ConfigPath)
+	if err != nil {
+		return "", err
+	}
+
+	decoded := terragruntExtends{}
+	if err := file.Decode(&decoded, evalCtx); err != nil {
+		return "", err
+	}
+
+	if decoded.Extends == nil || *decoded.Extends == "" {
+		return "", nil
+	}
+
+	path := *decoded.Extends
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(file.ConfigPath), path)
+	}
+
+	path = filepath.Clean(GetDefaultConfigPath(path))
+	if !util.FileExists(path) {
+		return "", errors.New(ExtendedUnitNotFoundError{Path: *decoded.Extends, ConfigPath: file.ConfigPath})
+	}
+
+	return path, nil
+}
@@ -0,0 +1,164 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+const extendsTestBaseUnit = `
+terraform {
+  source = "../modules/app"
+
+  before_hook "validate" {
+    commands = ["plan"]
+    execute  = ["tflint"]
+  }
+}
+
+generate "provider" {
+  path      = "provider.tf"
+  if_exists = "overwrite"
+  contents  = "provider \"aws\" {}"
+}
+
+dependencies {
+  paths = ["../vpc"]
+}
+
+inputs = {
+  unit_name     = basename(get_terragrunt_dir())
+  instance_type = "t3.micro"
+  tags          = { team = "platform" }
+}
+`
+
+func TestExtends(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	writeExtendsTestUnit(t, rootDir, "base", extendsTestBaseUnit)
+	writeExtendsTestUnit(t, rootDir, "vpc", "")
+
+	configPath := writeExtendsTestUnit(t, rootDir, "us-east-1", `
+extends = "../base"
+
+generate "provider" {
+  path      = "provider.tf"
+  if_exists = "overwrite"
+  contents  = "provider \"aws\" { region = \"us-east-1\" }"
+}
+
+inputs = {
+  instance_type = "m5.large"
+  tags          = { region = "us-east-1" }
+}
+`)
+
+	l := createLogger()
+	ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, configPath))
+
+	cfg, err := config.ParseConfigFile(ctx, l, configPath, nil)
+	require.NoError(t, err)
+
+	require.NotNil(t, cfg.Terraform)
+	assert.Equal(t, "../modules/app", *cfg.Terraform.Source)
+	require.Len(t, cfg.Terraform.BeforeHooks, 1)
+	assert.Equal(t, "validate", cfg.Terraform.BeforeHooks[0].Name)
+
+	require.Contains(t, cfg.GenerateConfigs, "provider")
+	assert.Equal(t, `provider "aws" { region = "us-east-1" }`, cfg.GenerateConfigs["provider"].Contents)
+
+	assert.Equal(t, map[string]any{
+		"unit_name":     "us-east-1",
+		"instance_type": "m5.large",
+		"tags":          map[string]any{"team": "platform", "region": "us-east-1"},
+	}, cfg.Inputs)
+
+	partial, err := config.PartialParseConfigFile(ctx.WithDecodeList(config.DependenciesBlock), l, configPath, nil)
+	require.NoError(t, err)
+	require.NotNil(t, partial.Dependencies)
+	assert.Equal(t, []string{"../vpc"}, partial.Dependencies.Paths)
+}
+
+func TestExtendsErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing unit", func(t *testing.T) {
+		t.Parallel()
+
+		configPath := writeExtendsTestUnit(t, t.TempDir(), "app", `extends = "../base"`)
+
+		l := createLogger()
+		ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, configPath))
+
+		_, err := config.ParseConfigFile(ctx, l, configPath, nil)
+		require.Error(t, err)
+
+		target := config.ExtendedUnitNotFoundError{}
+		assert.True(t, errors.As(err, &target), "unexpected error: %v", err)
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		t.Parallel()
+
+		rootDir := t.TempDir()
+		writeExtendsTestUnit(t, rootDir, "a", `extends = "../b"`)
+		configPath := writeExtendsTestUnit(t, rootDir, "b", `extends = "../a"`)
+
+		l := createLogger()
+		ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, configPath))
+
+		_, err := config.ParseConfigFile(ctx, l, configPath, nil)
+		require.Error(t, err)
+
+		target := config.ExtendsCycleError{}
+		assert.True(t, errors.As(err, &target), "unexpected error: %v", err)
+	})
+}
+
+func TestExtendsPartialParseConfigCache(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	writeExtendsTestUnit(t, rootDir, "base", `
+terraform {
+  source = "${get_terragrunt_dir()}/../modules/app"
+}
+`)
+
+	l := createLogger()
+	ctx := config.WithConfigValues(t.Context())
+
+	// The extended unit is evaluated in the folder of each extending unit, even when partially parsed configs are
+	// cached.
+	for _, region := range []string{"us-east-1", "eu-west-1"} {
+		configPath := writeExtendsTestUnit(t, rootDir, region, `extends = "../base"`)
+
+		opts := mockOptionsForTestWithConfigPath(t, configPath)
+		opts.UsePartialParseConfigCache = true
+
+		cfg, err := config.PartialParseConfigFile(config.NewParsingContext(ctx, l, opts).WithDecodeList(config.TerraformSource), l, configPath, nil)
+		require.NoError(t, err)
+		require.NotNil(t, cfg.Terraform)
+		assert.Equal(t, filepath.Join(rootDir, region)+"/../modules/app", *cfg.Terraform.Source)
+	}
+}
+
+func writeExtendsTestUnit(t *testing.T, rootDir, name, content string) string {
+	t.Helper()
+
+	unitDir := filepath.Join(rootDir, name)
+	configPath := filepath.Join(unitDir, config.DefaultTerragruntConfigPath)
+
+	require.NoError(t, os.MkdirAll(unitDir, 0755))
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+
+	return configPath
+}
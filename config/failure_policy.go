@@ -0,0 +1,67 @@
+package config
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+const (
+	// FailurePolicyContinue lets the units depending on a failed unit run anyway.
+	FailurePolicyContinue = "continue"
+	// FailurePolicyHaltDependents makes the units depending on a failed unit exit early. This is the default.
+	FailurePolicyHaltDependents = "halt-dependents"
+	// FailurePolicyHaltRun makes all the units that haven't started yet exit early once a unit failed.
+	FailurePolicyHaltRun = "halt-run"
+)
+
+// FailurePolicyModes are the supported modes of the failure policies.
+var FailurePolicyModes = []string{FailurePolicyContinue, FailurePolicyHaltDependents, FailurePolicyHaltRun}
+
+// FailurePolicyConfig is the `failure_policy` block, configuring what happens in run --all when the unit fails, e.g.:
+//
+//	failure_policy {
+//	  mode       = "continue"
+//	  on_failure = ["./scripts/rollback.sh", "vpc"]
+//	}
+//
+// `mode` is one of FailurePolicyModes, and, when unset, defaults to the mode of --failure-policy. `on_failure` is a
+// command run in the dir of the unit once it failed, e.g. to revert the changes it partially applied.
+type FailurePolicyConfig struct {
+	Mode      *string  `hcl:"mode,optional" cty:"mode"`
+	OnFailure []string `hcl:"on_failure,optional" cty:"on_failure"`
+}
+
+// Validate returns an error if the mode of the policy, defined in the config at the given path, isn't supported, or if
+// its on_failure command is empty.
+func (policy *FailurePolicyConfig) Validate(configPath string) error {
+	if policy.Mode != nil && !slices.Contains(FailurePolicyModes, *policy.Mode) {
+		return errors.New(InvalidFailurePolicyError{Path: configPath, Reason: "mode must be one of " + strings.Join(FailurePolicyModes, ", ")})
+	}
+
+	if policy.OnFailure != nil && (len(policy.OnFailure) == 0 || policy.OnFailure[0] == "") {
+		return errors.New(InvalidFailurePolicyError{Path: configPath, Reason: "on_failure must start with the command to run"})
+	}
+
+	return nil
+}
+
+// Clone returns a new instance of FailurePolicyConfig with the same values as the original.
+func (policy *FailurePolicyConfig) Clone() *FailurePolicyConfig {
+	return &FailurePolicyConfig{
+		Mode:      policy.Mode,
+		OnFailure: slices.Clone(policy.OnFailure),
+	}
+}
+
+// Merge merges the values set in the given policy into the policy.
+func (policy *FailurePolicyConfig) Merge(source *FailurePolicyConfig) {
+	if source.Mode != nil {
+		policy.Mode = source.Mode
+	}
+
+	if source.OnFailure != nil {
+		policy.OnFailure = slices.Clone(source.OnFailure)
+	}
+}
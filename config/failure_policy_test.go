@@ -0,0 +1,98 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+)
+
+func TestParseFailurePolicy(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	unitDir := filepath.Join(rootDir, "networking", "vpc")
+	configPath := filepath.Join(unitDir, config.DefaultTerragruntConfigPath)
+
+	require.NoError(t, os.MkdirAll(unitDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "root.hcl"), []byte(`
+failure_policy {
+  mode       = "halt-run"
+  on_failure = ["./rollback.sh"]
+}
+`), 0644))
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+
+failure_policy {
+  mode = "continue"
+}
+`), 0644))
+
+	l := createLogger()
+	opts := mockOptionsForTestWithConfigPath(t, configPath)
+
+	cfg, err := config.ParseConfigFile(config.NewParsingContext(t.Context(), l, opts), l, configPath, nil)
+	require.NoError(t, err)
+
+	require.NotNil(t, cfg.FailurePolicy)
+	assert.Equal(t, config.FailurePolicyContinue, *cfg.FailurePolicy.Mode)
+	assert.Equal(t, []string{"./rollback.sh"}, cfg.FailurePolicy.OnFailure)
+
+	ctx := config.NewParsingContext(t.Context(), l, opts).WithDecodeList(config.FailurePolicyBlock)
+
+	partialCfg, err := config.PartialParseConfigFile(ctx, l, configPath, nil)
+	require.NoError(t, err)
+
+	require.NotNil(t, partialCfg.FailurePolicy)
+	assert.Equal(t, config.FailurePolicyContinue, *partialCfg.FailurePolicy.Mode)
+	assert.Equal(t, []string{"./rollback.sh"}, partialCfg.FailurePolicy.OnFailure)
+}
+
+func TestParseFailurePolicyInvalid(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		cfg    string
+		reason string
+	}{
+		{
+			name: "mode",
+			cfg: `
+failure_policy {
+  mode = "retry"
+}
+`,
+			reason: "mode must be one of continue, halt-dependents, halt-run",
+		},
+		{
+			name: "on_failure",
+			cfg: `
+failure_policy {
+  on_failure = []
+}
+`,
+			reason: "on_failure must start with the command to run",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := createLogger()
+			ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTest(t))
+
+			_, err := config.ParseConfigString(ctx, l, config.DefaultTerragruntConfigPath, tc.cfg, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.reason)
+		})
+	}
+}
@@ -1,11 +1,18 @@
 package config
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 
 	"github.com/pkg/errors"
 	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+
+	"github.com/gruntwork-io/terragrunt/internal/cache"
+	"github.com/gruntwork-io/terragrunt/internal/featureflags"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
 )
 
 // FeatureFlags represents a list of feature flags.
@@ -134,3 +141,66 @@ func flagToTypedCtyValue(name string, ctyType cty.Type, value any) (cty.Value, e
 
 	return goTypeToCty(ctyFlag)
 }
+
+// FeatureFlagServedBySource returns true if the external feature flag source serves a value for the given flag. The
+// value isn't part of the CLI overrides of opts.FeatureFlags: it is resolved when parsing the config, overriding the
+// default of the flag but not the CLI overrides.
+func FeatureFlagServedBySource(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, name string) bool {
+	_, found := featureFlagSourceValues(ctx, l, opts)[name]
+
+	return found
+}
+
+// sourceFlagToCty converts the value served by the external source to the type of the flag default.
+func sourceFlagToCty(flag *FeatureFlag, value cty.Value) (cty.Value, error) {
+	if flag.Default != nil && !flag.Default.IsNull() {
+		converted, err := convert.Convert(value, flag.Default.Type())
+		if err != nil {
+			return cty.NilVal, errors.Errorf("feature flag %s from external source: %v", flag.Name, err)
+		}
+
+		value = converted
+	}
+
+	return flagToCtyValue(flag.Name, value)
+}
+
+// featureFlagSourceValues returns the flags served by the external feature flag source configured with
+// `--feature-source`. Flags are fetched once per run. If the source can't be reached, a warning is logged and
+// an empty set is cached, so that flags fall back to their default values for the rest of the run.
+func featureFlagSourceValues(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) map[string]cty.Value {
+	if opts.FeatureFlagSource == "" {
+		return nil
+	}
+
+	sourceCache := cache.ContextCache[map[string]cty.Value](ctx, FeatureFlagSourceCacheContextKey)
+
+	if values, found := sourceCache.Get(ctx, opts.FeatureFlagSource); found {
+		return values
+	}
+
+	values, err := fetchFeatureFlagSource(ctx, l, opts)
+	if err != nil {
+		l.Warnf("Failed to fetch feature flags from %s, falling back to default values: %v", opts.FeatureFlagSource, err)
+
+		values = map[string]cty.Value{}
+	}
+
+	sourceCache.Put(ctx, opts.FeatureFlagSource, values)
+
+	return values
+}
+
+func fetchFeatureFlagSource(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) (map[string]cty.Value, error) {
+	source, err := featureflags.NewSource(l, opts, opts.FeatureFlagSource)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, featureflags.FetchTimeout)
+	defer cancel()
+
+	l.Debugf("Fetching feature flags from %s", opts.FeatureFlagSource)
+
+	return source.Fetch(ctx)
+}
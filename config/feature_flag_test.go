@@ -0,0 +1,94 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+)
+
+const featureSourceTestConfig = `
+feature "from_source" {
+  default = false
+}
+
+feature "from_cli" {
+  default = "default"
+}
+
+feature "from_default" {
+  default = 1
+}
+
+inputs = {
+  from_source  = feature.from_source.value
+  from_cli     = feature.from_cli.value
+  from_default = feature.from_default.value
+}
+`
+
+func TestFeatureFlagSource(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		expectedInputs map[string]any
+		name           string
+		source         string
+	}{
+		{
+			name:   "source overrides defaults but not CLI flags",
+			source: `{"from_source": true, "from_cli": "source", "unknown": 5}`,
+			expectedInputs: map[string]any{
+				"from_source":  true,
+				"from_cli":     "cli",
+				"from_default": float64(1),
+			},
+		},
+		{
+			name: "unreachable source falls back to defaults",
+			expectedInputs: map[string]any{
+				"from_source":  false,
+				"from_cli":     "cli",
+				"from_default": float64(1),
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			configPath := filepath.Join(dir, config.DefaultTerragruntConfigPath)
+			sourcePath := filepath.Join(dir, "flags.json")
+
+			require.NoError(t, os.WriteFile(configPath, []byte(featureSourceTestConfig), 0644))
+
+			if tc.source != "" {
+				require.NoError(t, os.WriteFile(sourcePath, []byte(tc.source), 0644))
+			}
+
+			opts := mockOptionsForTestWithConfigPath(t, configPath)
+			opts.FeatureFlagSource = sourcePath
+			opts.FeatureFlags.Store("from_cli", "cli")
+
+			l := createLogger()
+			ctx := config.NewParsingContext(config.WithConfigValues(t.Context()), l, opts)
+
+			cfg, err := config.ParseConfigFile(ctx, l, configPath, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedInputs, cfg.Inputs)
+
+			// The values of the source are kept out of the CLI overrides.
+			assert.Equal(t, tc.source != "", config.FeatureFlagServedBySource(ctx, l, opts, "from_source"))
+			assert.False(t, config.FeatureFlagServedBySource(ctx, l, opts, "from_default"))
+
+			_, found := opts.FeatureFlags.Load("from_source")
+			assert.False(t, found)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/codegen"
+	"github.com/gruntwork-io/terragrunt/config"
+)
+
+func TestGenerateFiles(t *testing.T) {
+	t.Parallel()
+
+	cfg := `
+locals {
+  region = "us-east-1"
+}
+
+generate "providers" {
+  if_exists         = "overwrite_terragrunt"
+  disable_signature = true
+  files = {
+    "provider.tf" = "provider \"aws\" { region = \"${local.region}\" }"
+    "versions.tf" = "terraform { required_version = \">= 1.6\" }"
+  }
+}
+
+generate = {
+  backend = {
+    if_exists = "skip"
+    files = {
+      "backend_override.tf" = "terraform { backend \"local\" {} }"
+    }
+  }
+}
+`
+
+	l := createLogger()
+	ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTest(t))
+
+	terragruntConfig, err := config.ParseConfigString(ctx, l, config.DefaultTerragruntConfigPath, cfg, nil)
+	require.NoError(t, err)
+	require.Len(t, terragruntConfig.GenerateConfigs, 3)
+
+	provider := terragruntConfig.GenerateConfigs["providers/provider.tf"]
+	assert.Equal(t, "provider.tf", provider.Path)
+	assert.Equal(t, `provider "aws" { region = "us-east-1" }`, provider.Contents)
+	assert.Equal(t, codegen.ExistsOverwriteTerragrunt, provider.IfExists)
+	assert.True(t, provider.DisableSignature)
+
+	versions := terragruntConfig.GenerateConfigs["providers/versions.tf"]
+	assert.Equal(t, "versions.tf", versions.Path)
+	assert.Equal(t, `terraform { required_version = ">= 1.6" }`, versions.Contents)
+	assert.Equal(t, codegen.ExistsOverwriteTerragrunt, versions.IfExists)
+	assert.True(t, versions.DisableSignature)
+
+	backend := terragruntConfig.GenerateConfigs["backend/backend_override.tf"]
+	assert.Equal(t, "backend_override.tf", backend.Path)
+	assert.Equal(t, codegen.ExistsSkip, backend.IfExists)
+}
+
+func TestGenerateFilesConflict(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name string
+		cfg  string
+	}{
+		{
+			name: "files and path",
+			cfg: `
+generate "providers" {
+  path      = "provider.tf"
+  if_exists = "overwrite"
+  files     = { "versions.tf" = "" }
+}
+`,
+		},
+		{
+			name: "files and contents",
+			cfg: `
+generate "providers" {
+  if_exists = "overwrite"
+  contents  = ""
+  files     = { "versions.tf" = "" }
+}
+`,
+		},
+		{
+			name: "neither path nor files",
+			cfg: `
+generate "providers" {
+  if_exists = "overwrite"
+  contents  = ""
+}
+`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := createLogger()
+			ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTest(t))
+
+			_, err := config.ParseConfigString(ctx, l, config.DefaultTerragruntConfigPath, tc.cfg, nil)
+			require.ErrorContains(t, err, "generate block providers must set exactly one of path or files")
+		})
+	}
+}
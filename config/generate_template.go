@@ -0,0 +1,126 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/gruntwork-io/terragrunt/internal/ctyhelper"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+const (
+	// GenerateTemplateEngineNone writes the generate block contents as is.
+	GenerateTemplateEngineNone = "none"
+	// GenerateTemplateEngineGo renders the generate block contents as a Go template, with the sprig functions.
+	GenerateTemplateEngineGo = "go"
+)
+
+// generateContents returns the contents to generate for the given block, rendering the template if the block uses the
+// Go template engine. A block uses the Go template engine if it sets `template_engine = "go"` or loads its contents
+// from a `template_file`.
+//
+// Templates have access to the following data:
+//   - .locals: the locals of the config.
+//   - .inputs: the inputs of the config.
+//   - .dependency: the dependencies of the config, e.g. `{{ .dependency.vpc.outputs.vpc_id }}`.
+//   - .values: the values of the unit.
+//   - .feature: the feature flags of the config, e.g. `{{ .feature.new_provider.value }}`.
+func generateContents(ctx *ParsingContext, configPath string, block *terragruntGenerateBlock, inputs *cty.Value) (string, error) {
+	engine := GenerateTemplateEngineNone
+	if block.TemplateFile != nil {
+		engine = GenerateTemplateEngineGo
+	}
+
+	if block.TemplateEngine != nil {
+		engine = *block.TemplateEngine
+	}
+
+	if block.TemplateFile != nil && block.Contents != nil {
+		return "", errors.New(GenerateTemplateConflictError{Name: block.Name})
+	}
+
+	contents := ""
+	if block.Contents != nil {
+		contents = *block.Contents
+	}
+
+	switch engine {
+	case GenerateTemplateEngineNone:
+		if block.TemplateFile != nil {
+			return "", errors.New(GenerateTemplateConflictError{Name: block.Name})
+		}
+
+		return contents, nil
+	case GenerateTemplateEngineGo:
+	default:
+		return "", errors.New(InvalidGenerateTemplateEngineError{Name: block.Name, Engine: engine})
+	}
+
+	if block.TemplateFile != nil {
+		templatePath := *block.TemplateFile
+		if !filepath.IsAbs(templatePath) {
+			templatePath = filepath.Join(filepath.Dir(configPath), templatePath)
+		}
+
+		data, err := os.ReadFile(templatePath)
+		if err != nil {
+			return "", errors.New(err)
+		}
+
+		ctx.TerragruntOptions.AppendReadFile(templatePath, ctx.TerragruntOptions.WorkingDir)
+
+		contents = string(data)
+	}
+
+	data, err := generateTemplateData(ctx, inputs)
+	if err != nil {
+		return "", err
+	}
+
+	tmpl, err := template.New(block.Name).Funcs(sprig.TxtFuncMap()).Option("missingkey=error").Parse(contents)
+	if err != nil {
+		return "", errors.New(GenerateTemplateError{Name: block.Name, Err: err})
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", errors.New(GenerateTemplateError{Name: block.Name, Err: err})
+	}
+
+	return out.String(), nil
+}
+
+// generateTemplateData converts the evaluation context of the config to the data passed to the templates.
+func generateTemplateData(ctx *ParsingContext, inputs *cty.Value) (map[string]any, error) {
+	data := map[string]any{}
+
+	vars := map[string]*cty.Value{
+		MetadataInputs:      inputs,
+		MetadataLocals:      ctx.Locals,
+		MetadataDependency:  ctx.DecodedDependencies,
+		MetadataValues:      ctx.Values,
+		MetadataFeatureFlag: ctx.Features,
+	}
+
+	for name, val := range vars {
+		data[name] = map[string]any{}
+
+		if val == nil || val.IsNull() || !val.IsWhollyKnown() {
+			continue
+		}
+
+		parsed, err := ctyhelper.ParseCtyValueToMap(*val)
+		if err != nil {
+			return nil, err
+		}
+
+		data[name] = parsed
+	}
+
+	return data, nil
+}
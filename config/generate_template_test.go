@@ -0,0 +1,142 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+)
+
+func TestGenerateTemplate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name             string
+		cfg              string
+		template         string
+		expectedContents string
+		expectErr        bool
+	}{
+		{
+			name: "inline go template",
+			cfg: `
+locals {
+  regions = ["us-east-1", "eu-west-1"]
+}
+
+inputs = {
+  project = "demo"
+}
+
+generate "provider" {
+  path            = "provider.tf"
+  if_exists       = "overwrite"
+  template_engine = "go"
+  contents        = <<EOF
+{{- range .locals.regions }}
+provider "aws" {
+  alias  = "{{ . | replace "-" "_" }}"
+  region = "{{ . }}"
+}
+{{- end }}
+# {{ .inputs.project | upper }}
+EOF
+}
+`,
+			expectedContents: `
+provider "aws" {
+  alias  = "us_east_1"
+  region = "us-east-1"
+}
+provider "aws" {
+  alias  = "eu_west_1"
+  region = "eu-west-1"
+}
+# DEMO
+`,
+		},
+		{
+			name: "template file",
+			cfg: `
+locals {
+  bucket = "state"
+}
+
+generate "backend" {
+  path          = "backend.tf"
+  if_exists     = "overwrite"
+  template_file = "backend.tf.tmpl"
+}
+`,
+			template:         `bucket = "{{ .locals.bucket }}"`,
+			expectedContents: `bucket = "state"`,
+		},
+		{
+			name: "contents are not rendered by default",
+			cfg: `
+generate "raw" {
+  path      = "raw.tf"
+  if_exists = "overwrite"
+  contents  = "{{ .locals.bucket }}"
+}
+`,
+			expectedContents: `{{ .locals.bucket }}`,
+		},
+		{
+			name: "missing key",
+			cfg: `
+generate "missing" {
+  path            = "missing.tf"
+  if_exists       = "overwrite"
+  template_engine = "go"
+  contents        = "{{ .locals.unknown }}"
+}
+`,
+			expectErr: true,
+		},
+		{
+			name: "both contents and template file",
+			cfg: `
+generate "conflict" {
+  path          = "conflict.tf"
+  if_exists     = "overwrite"
+  contents      = "foo"
+  template_file = "backend.tf.tmpl"
+}
+`,
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			configPath := filepath.Join(dir, config.DefaultTerragruntConfigPath)
+
+			require.NoError(t, os.WriteFile(configPath, []byte(tc.cfg), 0644))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "backend.tf.tmpl"), []byte(tc.template), 0644))
+
+			l := createLogger()
+			ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, configPath))
+
+			cfg, err := config.ParseConfigFile(ctx, l, configPath, nil)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, cfg.GenerateConfigs, 1)
+
+			for _, generateConfig := range cfg.GenerateConfigs {
+				assert.Equal(t, tc.expectedContents, generateConfig.Contents)
+			}
+		})
+	}
+}
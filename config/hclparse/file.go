@@ -58,6 +58,8 @@ func (file *File) Update(content []byte) error {
 // blocks with labels, requiring the exact number of expected labels in the parsing step.  To handle this restriction,
 // we first see if there are any include blocks without any labels, and if there is, we modify it in the file object to
 // inject the label as "".
+//
+// Blocks enabled with `WithForEachBlocks` that declare a `for_each` attribute are decoded once per element.
 func (file *File) Decode(out any, evalContext *hcl.EvalContext) (err error) {
 	if file.fileUpdateHandlerFunc != nil {
 		if err := file.Parser.fileUpdateHandlerFunc(file); err != nil {
@@ -65,7 +67,12 @@ func (file *File) Decode(out any, evalContext *hcl.EvalContext) (err error) {
 		}
 	}
 
-	diags := gohcl.DecodeBody(file.Body, evalContext, out)
+	body := file.Body
+	if len(file.forEachBlockTypes) > 0 {
+		body = &forEachBody{Body: body, evalCtx: evalContext, blockTypes: file.forEachBlockTypes}
+	}
+
+	diags := gohcl.DecodeBody(body, evalContext, out)
 	if err := file.HandleDiagnostics(diags); err != nil {
 		return errors.New(err)
 	}
@@ -0,0 +1,256 @@
+package hclparse
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	// ForEachAttr is the name of the attribute used to declare multiple instances of a block.
+	ForEachAttr = "for_each"
+
+	// eachVar is the name of the variable holding the key and value of the instance of a block declared with for_each.
+	eachVar = "each"
+)
+
+// forEachBody wraps a body to expand the blocks of the given types that declare a `for_each` attribute into one block
+// per element of the collection. The expressions of an expanded block are evaluated with the `each.key` and
+// `each.value` variables of their element, and its `for_each` attribute is replaced with the `each` object, so that the
+// decoded block knows which instance it is.
+type forEachBody struct {
+	hcl.Body
+	evalCtx    *hcl.EvalContext
+	blockTypes []string
+}
+
+func (body *forEachBody) Content(schema *hcl.BodySchema) (*hcl.BodyContent, hcl.Diagnostics) {
+	content, diags := body.Body.Content(schema)
+	if content == nil {
+		return content, diags
+	}
+
+	blocks, moreDiags := body.expandBlocks(content.Blocks)
+	content.Blocks = blocks
+
+	return content, append(diags, moreDiags...)
+}
+
+func (body *forEachBody) PartialContent(schema *hcl.BodySchema) (*hcl.BodyContent, hcl.Body, hcl.Diagnostics) {
+	content, remain, diags := body.Body.PartialContent(schema)
+	if content == nil {
+		return content, remain, diags
+	}
+
+	blocks, moreDiags := body.expandBlocks(content.Blocks)
+	content.Blocks = blocks
+
+	if remain != nil {
+		remain = &forEachBody{Body: remain, evalCtx: body.evalCtx, blockTypes: body.blockTypes}
+	}
+
+	return content, remain, append(diags, moreDiags...)
+}
+
+func (body *forEachBody) expandBlocks(blocks hcl.Blocks) (hcl.Blocks, hcl.Diagnostics) {
+	var (
+		expanded hcl.Blocks
+		diags    hcl.Diagnostics
+	)
+
+	for _, block := range blocks {
+		if !slices.Contains(body.blockTypes, block.Type) {
+			expanded = append(expanded, block)
+			continue
+		}
+
+		content, _, moreDiags := block.Body.PartialContent(&hcl.BodySchema{
+			Attributes: []hcl.AttributeSchema{{Name: ForEachAttr}},
+		})
+		if moreDiags.HasErrors() {
+			diags = append(diags, moreDiags...)
+			continue
+		}
+
+		attr, ok := content.Attributes[ForEachAttr]
+		if !ok {
+			expanded = append(expanded, block)
+			continue
+		}
+
+		instances, moreDiags := forEachInstances(attr, body.evalCtx)
+		if moreDiags.HasErrors() {
+			diags = append(diags, moreDiags...)
+			continue
+		}
+
+		for _, each := range instances {
+			instance := *block
+			instance.Body = &eachBody{Body: block.Body, each: each}
+
+			expanded = append(expanded, &instance)
+		}
+	}
+
+	return expanded, diags
+}
+
+// forEachInstances evaluates the `for_each` attribute and returns the `each` object of every instance, sorted by key.
+// The collection can be a map, whose keys are the instance keys, or a set or list of strings, whose values are both the
+// keys and values of the instances.
+func forEachInstances(attr *hcl.Attribute, evalCtx *hcl.EvalContext) ([]cty.Value, hcl.Diagnostics) {
+	val, diags := attr.Expr.Value(evalCtx)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	invalid := func(detail string) hcl.Diagnostics {
+		return hcl.Diagnostics{{
+			Severity:    hcl.DiagError,
+			Summary:     "Invalid for_each argument",
+			Detail:      detail,
+			Subject:     attr.Expr.Range().Ptr(),
+			Expression:  attr.Expr,
+			EvalContext: evalCtx,
+		}}
+	}
+
+	if val.IsNull() {
+		return nil, nil
+	}
+
+	if !val.IsWhollyKnown() {
+		return nil, invalid("The for_each value depends on values that cannot be determined until apply.")
+	}
+
+	ty := val.Type()
+	isMap := ty.IsMapType() || ty.IsObjectType()
+
+	if !isMap && !ty.IsSetType() && !ty.IsListType() && !ty.IsTupleType() {
+		return nil, invalid(fmt.Sprintf("The for_each argument must be a map, or a set or list of strings, and you have provided a value of type %s.", ty.FriendlyName()))
+	}
+
+	instances := make([]cty.Value, 0, val.LengthInt())
+	keys := map[string]bool{}
+
+	for it := val.ElementIterator(); it.Next(); {
+		key, value := it.Element()
+
+		if !isMap {
+			if !value.Type().Equals(cty.String) || value.IsNull() {
+				return nil, invalid("The for_each set or list can only contain strings.")
+			}
+
+			key = value
+		}
+
+		if keys[key.AsString()] {
+			return nil, invalid(fmt.Sprintf("The for_each key %q is declared more than once.", key.AsString()))
+		}
+
+		keys[key.AsString()] = true
+
+		instances = append(instances, cty.ObjectVal(map[string]cty.Value{
+			"key":   key,
+			"value": value,
+		}))
+	}
+
+	slices.SortFunc(instances, func(a, b cty.Value) int {
+		aKey, bKey := a.GetAttr("key").AsString(), b.GetAttr("key").AsString()
+
+		switch {
+		case aKey < bKey:
+			return -1
+		case aKey > bKey:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	return instances, nil
+}
+
+// eachBody is the body of an instance of a block declared with for_each.
+type eachBody struct {
+	hcl.Body
+	each cty.Value
+}
+
+func (body *eachBody) Content(schema *hcl.BodySchema) (*hcl.BodyContent, hcl.Diagnostics) {
+	content, diags := body.Body.Content(schema)
+
+	return body.wrapContent(content), diags
+}
+
+func (body *eachBody) PartialContent(schema *hcl.BodySchema) (*hcl.BodyContent, hcl.Body, hcl.Diagnostics) {
+	content, remain, diags := body.Body.PartialContent(schema)
+	if remain != nil {
+		remain = &eachBody{Body: remain, each: body.each}
+	}
+
+	return body.wrapContent(content), remain, diags
+}
+
+func (body *eachBody) JustAttributes() (hcl.Attributes, hcl.Diagnostics) {
+	attrs, diags := body.Body.JustAttributes()
+
+	return body.wrapAttributes(attrs), diags
+}
+
+func (body *eachBody) wrapContent(content *hcl.BodyContent) *hcl.BodyContent {
+	if content == nil {
+		return nil
+	}
+
+	content.Attributes = body.wrapAttributes(content.Attributes)
+
+	for i, block := range content.Blocks {
+		nested := *block
+		nested.Body = &eachBody{Body: block.Body, each: body.each}
+		content.Blocks[i] = &nested
+	}
+
+	return content
+}
+
+func (body *eachBody) wrapAttributes(attrs hcl.Attributes) hcl.Attributes {
+	wrapped := make(hcl.Attributes, len(attrs))
+
+	for name, attr := range attrs {
+		attr := *attr
+
+		if name == ForEachAttr {
+			attr.Expr = hcl.StaticExpr(body.each, attr.Expr.Range())
+		} else {
+			attr.Expr = &eachExpression{Expression: attr.Expr, each: body.each}
+		}
+
+		wrapped[name] = &attr
+	}
+
+	return wrapped
+}
+
+// eachExpression evaluates the wrapped expression with the `each` variable of the instance of the block.
+type eachExpression struct {
+	hcl.Expression
+	each cty.Value
+}
+
+func (expr *eachExpression) Value(evalCtx *hcl.EvalContext) (cty.Value, hcl.Diagnostics) {
+	var child *hcl.EvalContext
+
+	if evalCtx != nil {
+		child = evalCtx.NewChild()
+	} else {
+		child = &hcl.EvalContext{}
+	}
+
+	child.Variables = map[string]cty.Value{eachVar: expr.each}
+
+	return expr.Expression.Value(child)
+}
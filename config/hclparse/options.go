@@ -46,6 +46,15 @@ func WithFileUpdate(fn func(*File) error) Option {
 	}
 }
 
+// WithForEachBlocks enables the `for_each` attribute on the blocks of the given types, which are expanded into one
+// block per element of the collection when the file is decoded.
+func WithForEachBlocks(blockTypes ...string) Option {
+	return func(parser *Parser) *Parser {
+		parser.forEachBlockTypes = append(parser.forEachBlockTypes, blockTypes...)
+		return parser
+	}
+}
+
 // WithHaltOnErrorOnlyForBlocks configures a diagnostic error handler that runs when diagnostic errors occur.
 // If errors occur in the given `blockNames` blocks, parser returns the error to its caller, otherwise it skips the error.
 func WithHaltOnErrorOnlyForBlocks(blockNames []string) Option {
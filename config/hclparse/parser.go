@@ -22,6 +22,7 @@ type Parser struct {
 	handleDiagnosticsFunc func(*File, hcl.Diagnostics) (hcl.Diagnostics, error)
 	fileUpdateHandlerFunc func(*File) error
 	logger                log.Logger
+	forEachBlockTypes     []string
 }
 
 func NewParser(opts ...Option) *Parser {
@@ -72,6 +73,8 @@ func (parser *Parser) ParseFromBytes(content []byte, configPath string) (file *F
 	switch filepath.Ext(configPath) {
 	case ".json":
 		hclFile, diags = parser.ParseJSON(content, configPath)
+	case ".yaml", ".yml":
+		hclFile, diags = parser.ParseYAML(content, configPath)
 	default:
 		hclFile, diags = parser.ParseHCL(content, configPath)
 	}
@@ -0,0 +1,122 @@
+package hclparse
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// ParseYAML parses the given YAML config. YAML configs map onto the same structure as the HCL JSON syntax, see
+// https://github.com/hashicorp/hcl/blob/main/json/spec.md, so they are converted to JSON and parsed as such. Strings
+// support the `${...}` interpolation sequences, while the `%{...}` template directives are rejected to keep YAML
+// configs declarative.
+func (parser *Parser) ParseYAML(content []byte, configPath string) (*hcl.File, hcl.Diagnostics) {
+	var doc yaml.Node
+
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, hcl.Diagnostics{{
+			Severity: hcl.DiagError,
+			Summary:  "Invalid YAML",
+			Detail:   err.Error(),
+			Subject:  &hcl.Range{Filename: configPath},
+		}}
+	}
+
+	var (
+		buf   bytes.Buffer
+		diags hcl.Diagnostics
+	)
+
+	if len(doc.Content) == 0 {
+		buf.WriteString("{}")
+	} else {
+		diags = writeYAMLNodeAsJSON(&buf, doc.Content[0], configPath)
+		if diags.HasErrors() {
+			return nil, diags
+		}
+	}
+
+	return parser.ParseJSON(buf.Bytes(), configPath)
+}
+
+// writeYAMLNodeAsJSON writes the given YAML node as JSON, preserving the order of the mapping keys, as the order of the
+// blocks, e.g. includes, matters.
+func writeYAMLNodeAsJSON(buf *bytes.Buffer, node *yaml.Node, configPath string) hcl.Diagnostics {
+	switch node.Kind {
+	case yaml.AliasNode:
+		return writeYAMLNodeAsJSON(buf, node.Alias, configPath)
+	case yaml.MappingNode:
+		var diags hcl.Diagnostics
+
+		buf.WriteByte('{')
+
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			key, _ := json.Marshal(node.Content[i].Value)
+			buf.Write(key)
+			buf.WriteByte(':')
+
+			diags = append(diags, writeYAMLNodeAsJSON(buf, node.Content[i+1], configPath)...)
+		}
+
+		buf.WriteByte('}')
+
+		return diags
+	case yaml.SequenceNode:
+		var diags hcl.Diagnostics
+
+		buf.WriteByte('[')
+
+		for i, item := range node.Content {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			diags = append(diags, writeYAMLNodeAsJSON(buf, item, configPath)...)
+		}
+
+		buf.WriteByte(']')
+
+		return diags
+	case yaml.ScalarNode:
+		var val any
+
+		if err := node.Decode(&val); err != nil {
+			return hcl.Diagnostics{yamlDiagnostic(node, configPath, "Invalid YAML value", err.Error())}
+		}
+
+		if str, ok := val.(string); ok && strings.Contains(strings.ReplaceAll(str, "%%{", ""), "%{") {
+			return hcl.Diagnostics{yamlDiagnostic(node, configPath, "Unsupported template directive",
+				"Template directives, such as %{if} and %{for}, are not supported in YAML configs. Use ${...} interpolations, or escape the sequence as %%{.")}
+		}
+
+		encoded, err := json.Marshal(val)
+		if err != nil {
+			return hcl.Diagnostics{yamlDiagnostic(node, configPath, "Invalid YAML value", err.Error())}
+		}
+
+		buf.Write(encoded)
+
+		return nil
+	default:
+		return hcl.Diagnostics{yamlDiagnostic(node, configPath, "Invalid YAML value", fmt.Sprintf("Unsupported YAML node of kind %d.", node.Kind))}
+	}
+}
+
+func yamlDiagnostic(node *yaml.Node, configPath, summary, detail string) *hcl.Diagnostic {
+	pos := hcl.Pos{Line: node.Line, Column: node.Column}
+
+	return &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  summary,
+		Detail:   detail,
+		Subject:  &hcl.Range{Filename: configPath, Start: pos, End: pos},
+	}
+}
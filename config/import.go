@@ -0,0 +1,288 @@
+package config
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/go-getter"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	"github.com/gruntwork-io/terragrunt/config/hclparse"
+	"github.com/gruntwork-io/terragrunt/internal/cache"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+const (
+	// ImportFormatJSON decodes the imported document as a JSON object.
+	ImportFormatJSON = "json"
+	// ImportFormatHCL decodes the imported document as HCL attributes.
+	ImportFormatHCL = "hcl"
+
+	importChecksumAlgorithm = "sha256"
+	importCacheDirName      = "imports"
+	importCacheFilePerm     = 0644
+)
+
+// ImportConfig represents an `import` block, which pulls a values document from a remote location at parse time and
+// exposes it under the `import` variable, e.g. `import.network.vpc_cidrs`:
+//
+//	import "network" {
+//	  source   = "s3::https://s3.amazonaws.com/acme-platform/network.json"
+//	  checksum = "sha256:6b86b273ff34fce19d6b804eff5a3f5747ada4eaa22f1d49c01e52ddb7875b4b"
+//	}
+//
+// The source can be any address supported by go-getter, e.g. a local path, an HTTP(S) URL, an S3 object
+// (`s3::https://...`) or a GCS object (`gcs::https://...`). Documents are fetched once per run and, when a checksum is
+// pinned, cached on disk by checksum so that they are not downloaded again.
+type ImportConfig struct {
+	Checksum *string `hcl:"checksum,attr"`
+	Format   *string `hcl:"format,attr"`
+	Name     string  `hcl:",label"`
+	Source   string  `hcl:"source,attr"`
+}
+
+// terragruntImports is a struct that can be used to only decode the import blocks.
+type terragruntImports struct {
+	Remain  hcl.Body       `hcl:",remain"`
+	Imports []ImportConfig `hcl:"import,block"`
+}
+
+// terragruntImportIgnore is used to skip import blocks when decoding the full config, as they are evaluated with the
+// base blocks.
+type terragruntImportIgnore struct {
+	Remain hcl.Body `hcl:",remain"`
+	Name   string   `hcl:"name,label"`
+}
+
+// evaluateImports decodes the `import` blocks of the given file, fetches their documents and returns them as an object
+// mapping the import names to the decoded documents, to be used as the `import` variable.
+func evaluateImports(ctx *ParsingContext, l log.Logger, file *hclparse.File) (*cty.Value, error) {
+	evalCtx, err := createTerragruntEvalContext(ctx, l, file.ConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded := terragruntImports{}
+	if err := file.Decode(&decoded, evalCtx); err != nil {
+		return nil, err
+	}
+
+	if len(decoded.Imports) == 0 {
+		return nil, nil
+	}
+
+	imports := map[string]cty.Value{}
+
+	for _, imp := range decoded.Imports {
+		if _, found := imports[imp.Name]; found {
+			return nil, errors.New(DuplicatedImportBlockError(imp.Name))
+		}
+
+		val, err := importDocument(ctx, l, filepath.Dir(file.ConfigPath), imp)
+		if err != nil {
+			return nil, err
+		}
+
+		imports[imp.Name] = val
+	}
+
+	importsAsCty, err := convertValuesMapToCtyVal(imports)
+	if err != nil {
+		return nil, err
+	}
+
+	return &importsAsCty, nil
+}
+
+// importDocument fetches and decodes the document of the given import block.
+func importDocument(ctx context.Context, l log.Logger, configDir string, imp ImportConfig) (cty.Value, error) {
+	format, err := importFormat(imp)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	checksum := ""
+	if imp.Checksum != nil {
+		checksum, err = parseImportChecksum(imp)
+		if err != nil {
+			return cty.NilVal, err
+		}
+	}
+
+	source, err := getter.Detect(imp.Source, configDir, getter.Detectors)
+	if err != nil {
+		return cty.NilVal, errors.New(err)
+	}
+
+	importCache := cache.ContextCache[[]byte](ctx, ImportCacheContextKey)
+	cacheKey := source + "#" + checksum
+
+	content, found := importCache.Get(ctx, cacheKey)
+	if !found {
+		content, err = fetchImportDocument(ctx, l, configDir, imp, checksum)
+		if err != nil {
+			return cty.NilVal, err
+		}
+
+		importCache.Put(ctx, cacheKey, content)
+	}
+
+	return decodeImportDocument(imp, format, content)
+}
+
+// fetchImportDocument downloads the document of the import block. Documents pinned with a checksum are verified, and
+// cached on disk under their checksum.
+func fetchImportDocument(ctx context.Context, l log.Logger, configDir string, imp ImportConfig, checksum string) ([]byte, error) {
+	var cachePath string
+
+	if checksum != "" {
+		if cacheDir, err := util.GetCacheDir(); err == nil {
+			cachePath = filepath.Join(cacheDir, importCacheDirName, checksum)
+		}
+	}
+
+	if cachePath != "" && util.FileExists(cachePath) {
+		if content, err := os.ReadFile(cachePath); err == nil && importChecksum(content) == checksum {
+			l.Debugf("Using cached document of import %s from %s", imp.Name, cachePath)
+
+			return content, nil
+		}
+	}
+
+	l.Debugf("Fetching document of import %s from %s", imp.Name, imp.Source)
+
+	tmpDir, err := os.MkdirTemp("", "terragrunt-import-*")
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	defer os.RemoveAll(tmpDir) //nolint:errcheck
+
+	dst := filepath.Join(tmpDir, imp.Name)
+
+	client := &getter.Client{
+		Ctx:           ctx,
+		Src:           imp.Source,
+		Dst:           dst,
+		Pwd:           configDir,
+		Mode:          getter.ClientModeFile,
+		Decompressors: map[string]getter.Decompressor{},
+	}
+
+	if err := client.Get(); err != nil {
+		return nil, errors.New(ImportFetchError{Name: imp.Name, Source: imp.Source, Err: err})
+	}
+
+	content, err := os.ReadFile(dst)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	if checksum == "" {
+		return content, nil
+	}
+
+	if actual := importChecksum(content); actual != checksum {
+		return nil, errors.New(ImportChecksumMismatchError{Name: imp.Name, Source: imp.Source, Expected: checksum, Actual: actual})
+	}
+
+	if cachePath != "" {
+		if err := os.MkdirAll(filepath.Dir(cachePath), os.ModePerm); err == nil {
+			if err := os.WriteFile(cachePath, content, importCacheFilePerm); err != nil {
+				l.Debugf("Failed to cache document of import %s: %v", imp.Name, err)
+			}
+		}
+	}
+
+	return content, nil
+}
+
+// decodeImportDocument decodes the content of the imported document in the given format.
+func decodeImportDocument(imp ImportConfig, format string, content []byte) (cty.Value, error) {
+	if format == ImportFormatHCL {
+		file, diags := hclsyntax.ParseConfig(content, imp.Name+".hcl", hcl.InitialPos)
+		if diags.HasErrors() {
+			return cty.NilVal, errors.New(InvalidImportDocumentError{Name: imp.Name, Err: diags})
+		}
+
+		attrs, diags := file.Body.JustAttributes()
+		if diags.HasErrors() {
+			return cty.NilVal, errors.New(InvalidImportDocumentError{Name: imp.Name, Err: diags})
+		}
+
+		values := map[string]cty.Value{}
+
+		for name, attr := range attrs {
+			val, diags := attr.Expr.Value(nil)
+			if diags.HasErrors() {
+				return cty.NilVal, errors.New(InvalidImportDocumentError{Name: imp.Name, Err: diags})
+			}
+
+			values[name] = val
+		}
+
+		return cty.ObjectVal(values), nil
+	}
+
+	ty, err := ctyjson.ImpliedType(content)
+	if err != nil {
+		return cty.NilVal, errors.New(InvalidImportDocumentError{Name: imp.Name, Err: err})
+	}
+
+	if !ty.IsObjectType() {
+		return cty.NilVal, errors.New(InvalidImportDocumentError{Name: imp.Name, Err: errors.New("the document must be a JSON object")})
+	}
+
+	val, err := ctyjson.Unmarshal(content, ty)
+	if err != nil {
+		return cty.NilVal, errors.New(InvalidImportDocumentError{Name: imp.Name, Err: err})
+	}
+
+	return val, nil
+}
+
+// importFormat returns the format of the imported document, which defaults to HCL for `.hcl` sources and JSON
+// otherwise.
+func importFormat(imp ImportConfig) (string, error) {
+	if imp.Format == nil {
+		if path, _, _ := strings.Cut(imp.Source, "?"); strings.HasSuffix(path, ".hcl") {
+			return ImportFormatHCL, nil
+		}
+
+		return ImportFormatJSON, nil
+	}
+
+	switch format := *imp.Format; format {
+	case ImportFormatJSON, ImportFormatHCL:
+		return format, nil
+	default:
+		return "", errors.New(InvalidImportFormatError{Name: imp.Name, Format: format})
+	}
+}
+
+// parseImportChecksum returns the hex encoded sha256 checksum pinned in the import block, which can be declared with or
+// without the `sha256:` prefix.
+func parseImportChecksum(imp ImportConfig) (string, error) {
+	checksum := strings.ToLower(strings.TrimPrefix(*imp.Checksum, importChecksumAlgorithm+":"))
+
+	if decoded, err := hex.DecodeString(checksum); err != nil || len(decoded) != sha256.Size {
+		return "", errors.New(InvalidImportChecksumError{Name: imp.Name, Checksum: *imp.Checksum})
+	}
+
+	return checksum, nil
+}
+
+func importChecksum(content []byte) string {
+	sum := sha256.Sum256(content)
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,176 @@
+package config_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+)
+
+func TestImportBlock(t *testing.T) {
+	t.Parallel()
+
+	const document = `{"vpc_cidrs": {"prod": "10.0.0.0/16", "dev": "10.1.0.0/16"}, "account_id": "111111111111"}`
+
+	sum := sha256.Sum256([]byte(document))
+	checksum := hex.EncodeToString(sum[:])
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(document))
+	}))
+	t.Cleanup(server.Close)
+
+	testCases := []struct {
+		name      string
+		cfg       string
+		hcl       string
+		expected  map[string]any
+		expectErr bool
+	}{
+		{
+			name: "json over http",
+			cfg: `
+import "network" {
+  source = "` + server.URL + `/network.json"
+}
+
+locals {
+  cidr = import.network.vpc_cidrs["prod"]
+}
+
+inputs = {
+  cidr       = local.cidr
+  account_id = import.network.account_id
+}
+`,
+			expected: map[string]any{"cidr": "10.0.0.0/16", "account_id": "111111111111"},
+		},
+		{
+			name: "pinned checksum",
+			cfg: `
+import "network" {
+  source   = "` + server.URL + `/network.json"
+  checksum = "sha256:` + checksum + `"
+}
+
+inputs = {
+  cidr = import.network.vpc_cidrs.dev
+}
+`,
+			expected: map[string]any{"cidr": "10.1.0.0/16"},
+		},
+		{
+			name: "checksum mismatch",
+			cfg: `
+import "network" {
+  source   = "` + server.URL + `/network.json"
+  checksum = "sha256:` + hex.EncodeToString(make([]byte, sha256.Size)) + `"
+}
+`,
+			expectErr: true,
+		},
+		{
+			name: "local hcl document",
+			cfg: `
+import "accounts" {
+  source = "./accounts.hcl"
+}
+
+inputs = {
+  prod = import.accounts.accounts.prod
+}
+`,
+			hcl: `
+accounts = {
+  prod = "222222222222"
+}
+`,
+			expected: map[string]any{"prod": "222222222222"},
+		},
+		{
+			name: "invalid format",
+			cfg: `
+import "network" {
+  source = "` + server.URL + `/network.json"
+  format = "yaml"
+}
+`,
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			configPath := filepath.Join(dir, config.DefaultTerragruntConfigPath)
+
+			require.NoError(t, os.WriteFile(configPath, []byte(tc.cfg), 0644))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "accounts.hcl"), []byte(tc.hcl), 0644))
+
+			l := createLogger()
+			ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, configPath))
+
+			cfg, err := config.ParseConfigFile(ctx, l, configPath, nil)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, cfg.Inputs)
+		})
+	}
+}
+
+func TestImportBlockFetchedOncePerRun(t *testing.T) {
+	t.Parallel()
+
+	var hits atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			hits.Add(1)
+		}
+
+		_, _ = w.Write([]byte(`{"region": "us-east-1"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	l := createLogger()
+	runCtx := config.WithConfigValues(t.Context())
+
+	for _, unit := range []string{"app", "db"} {
+		dir := filepath.Join(t.TempDir(), unit)
+		configPath := filepath.Join(dir, config.DefaultTerragruntConfigPath)
+
+		require.NoError(t, os.MkdirAll(dir, 0755))
+		require.NoError(t, os.WriteFile(configPath, []byte(`
+import "common" {
+  source = "`+server.URL+`/common.json"
+}
+
+inputs = {
+  region = import.common.region
+}
+`), 0644))
+
+		ctx := config.NewParsingContext(runCtx, l, mockOptionsForTestWithConfigPath(t, configPath))
+
+		cfg, err := config.ParseConfigFile(ctx, l, configPath, nil)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"region": "us-east-1"}, cfg.Inputs)
+	}
+
+	assert.Equal(t, int32(1), hits.Load())
+}
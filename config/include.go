@@ -1,9 +1,11 @@
 package config
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/gruntwork-io/terragrunt/codegen"
@@ -25,6 +27,42 @@ const bareIncludeKey = ""
 
 var fieldsCopyLocks = util.NewKeyLocks()
 
+// includeChainContextKey is the context key of the includes being parsed, stored in the context rather than in the
+// parsing context so that it is kept by functions such as read_terragrunt_config that create a new parsing context.
+type includeChainContextKey struct{}
+
+// includeEdge is a config including another config.
+type includeEdge struct {
+	configPath  string
+	includePath string
+}
+
+// trackIncludeEdge records that the config of the given parsing context is including the config at includePath. An
+// include that is already being parsed higher up the chain, for example because the included config reads the child
+// with read_terragrunt_config, would recurse indefinitely and is returned as an IncludeCycleError.
+func trackIncludeEdge(ctx *ParsingContext, includePath string) (*ParsingContext, error) {
+	edge := includeEdge{
+		configPath:  filepath.Clean(ctx.TerragruntOptions.TerragruntConfigPath),
+		includePath: filepath.Clean(includePath),
+	}
+
+	chain, _ := ctx.Value(includeChainContextKey{}).([]includeEdge)
+
+	if idx := slices.Index(chain, edge); idx >= 0 {
+		paths := make([]string, 0, len(chain)-idx+1)
+		for _, e := range chain[idx:] {
+			paths = append(paths, e.configPath)
+		}
+
+		return nil, errors.New(IncludeCycleError(append(paths, edge.includePath)))
+	}
+
+	newCtx := *ctx
+	newCtx.Context = context.WithValue(ctx.Context, includeChainContextKey{}, append(slices.Clone(chain), edge))
+
+	return &newCtx, nil
+}
+
 // Parse the config of the given include, if one is specified
 func parseIncludedConfig(ctx *ParsingContext, l log.Logger, includedConfig *IncludeConfig) (*TerragruntConfig, error) {
 	if includedConfig.Path == "" {
@@ -37,6 +75,11 @@ func parseIncludedConfig(ctx *ParsingContext, l log.Logger, includedConfig *Incl
 		includePath = util.JoinPath(filepath.Dir(ctx.TerragruntOptions.TerragruntConfigPath), includePath)
 	}
 
+	ctx, err := trackIncludeEdge(ctx, includePath)
+	if err != nil {
+		return nil, err
+	}
+
 	// These condition are here to specifically handle the `run --all` command. During any `run --all` call, terragrunt
 	// needs to first build up the dependency graph to know what order to process the modules in. We want to limit users
 	// from creating a dependency between the dependency path for graph generation, and a module output. This is because
@@ -118,6 +161,11 @@ func handleInclude(ctx *ParsingContext, l log.Logger, config *TerragruntConfig,
 			return config, err
 		}
 
+		hooksMergeStrategy, err := includeConfig.GetHooksMergeStrategy()
+		if err != nil {
+			return config, err
+		}
+
 		var (
 			parsedIncludeConfig *TerragruntConfig
 			logPrefix           string
@@ -134,6 +182,8 @@ func handleInclude(ctx *ParsingContext, l log.Logger, config *TerragruntConfig,
 			return baseConfig, err
 		}
 
+		parentHooks, childHooks := getTerraformHooks(parsedIncludeConfig), getTerraformHooks(baseConfig)
+
 		// TODO: Remove lint suppression
 		switch mergeStrategy { //nolint:exhaustive
 		case NoMerge:
@@ -145,6 +195,8 @@ func handleInclude(ctx *ParsingContext, l log.Logger, config *TerragruntConfig,
 				return nil, err
 			}
 
+			mergeIncludedHooks(l, parsedIncludeConfig, parentHooks, childHooks, hooksMergeStrategy)
+
 			baseConfig = parsedIncludeConfig
 		case DeepMerge:
 			l.Debugf("%sIncluded config %s has strategy deep merge: merging config in (deep).", logPrefix, includeConfig.Path)
@@ -153,6 +205,8 @@ func handleInclude(ctx *ParsingContext, l log.Logger, config *TerragruntConfig,
 				return nil, err
 			}
 
+			mergeIncludedHooks(l, parsedIncludeConfig, parentHooks, childHooks, hooksMergeStrategy)
+
 			baseConfig = parsedIncludeConfig
 		default:
 			return nil, fmt.Errorf("you reached an impossible condition. This is most likely a bug in terragrunt. Please open an issue at github.com/gruntwork-io/terragrunt with this error message. Code: UNKNOWN_MERGE_STRATEGY_%s", mergeStrategy)
@@ -230,6 +284,14 @@ func (cfg *TerragruntConfig) Merge(l log.Logger, sourceConfig *TerragruntConfig,
 		cfg.DownloadDir = sourceConfig.DownloadDir
 	}
 
+	if sourceConfig.Environment != "" {
+		cfg.Environment = sourceConfig.Environment
+	}
+
+	if sourceConfig.environmentMergeStrategy != "" {
+		cfg.environmentMergeStrategy = sourceConfig.environmentMergeStrategy
+	}
+
 	if sourceConfig.IamRole != "" {
 		cfg.IamRole = sourceConfig.IamRole
 	}
@@ -254,6 +316,18 @@ func (cfg *TerragruntConfig) Merge(l log.Logger, sourceConfig *TerragruntConfig,
 		cfg.RetryMaxAttempts = sourceConfig.RetryMaxAttempts
 	}
 
+	if sourceConfig.Weight != nil {
+		cfg.Weight = sourceConfig.Weight
+	}
+
+	if sourceConfig.RunTimeoutSec != nil {
+		cfg.RunTimeoutSec = sourceConfig.RunTimeoutSec
+	}
+
+	if sourceConfig.QueuePriority != nil {
+		cfg.QueuePriority = sourceConfig.QueuePriority
+	}
+
 	if sourceConfig.RetrySleepIntervalSec != nil {
 		cfg.RetrySleepIntervalSec = sourceConfig.RetrySleepIntervalSec
 	}
@@ -321,6 +395,16 @@ func (cfg *TerragruntConfig) Merge(l log.Logger, sourceConfig *TerragruntConfig,
 		cfg.RetryableErrors = sourceConfig.RetryableErrors
 	}
 
+	// Tags are labels rather than settings, so the child tags are added to the included ones instead of replacing them.
+	if sourceConfig.Tags != nil {
+		cfg.Tags = util.MergeStringSlices(cfg.Tags, sourceConfig.Tags)
+	}
+
+	// A unit is a member of the mutex groups of both the child and the included configs, as tags.
+	if sourceConfig.MutexGroups != nil {
+		cfg.MutexGroups = util.MergeStringSlices(cfg.MutexGroups, sourceConfig.MutexGroups)
+	}
+
 	// Merge the generate configs. This is a shallow merge. Meaning, if the child has the same name generate block, then the
 	// child's generate block will override the parent's block.
 
@@ -335,6 +419,45 @@ func (cfg *TerragruntConfig) Merge(l log.Logger, sourceConfig *TerragruntConfig,
 		cfg.Inputs = mergeInputs(sourceConfig.Inputs, cfg.Inputs)
 	}
 
+	if sourceConfig.InputsOverrides != nil {
+		inputsOverrides, err := mergeInputsOverrides(sourceConfig.InputsOverrides, cfg.InputsOverrides, func(childInputs, parentInputs map[string]any) (map[string]any, error) {
+			return mergeInputs(childInputs, parentInputs), nil
+		})
+		if err != nil {
+			return err
+		}
+
+		cfg.InputsOverrides = inputsOverrides
+	}
+
+	if sourceConfig.Assertions != nil {
+		cfg.Assertions = mergeAssertions(sourceConfig.Assertions, cfg.Assertions)
+	}
+
+	if sourceConfig.ParallelismLimits != nil {
+		cfg.ParallelismLimits = cfg.ParallelismLimits.Merge(sourceConfig.ParallelismLimits)
+	}
+
+	if sourceConfig.ApplyWindows != nil {
+		cfg.ApplyWindows = cfg.ApplyWindows.Merge(sourceConfig.ApplyWindows)
+	}
+
+	if sourceConfig.FailurePolicy != nil {
+		if cfg.FailurePolicy == nil {
+			cfg.FailurePolicy = &FailurePolicyConfig{}
+		}
+
+		cfg.FailurePolicy.Merge(sourceConfig.FailurePolicy)
+	}
+
+	if sourceConfig.Executor != nil {
+		if cfg.Executor == nil {
+			cfg.Executor = &ExecutorConfig{}
+		}
+
+		cfg.Executor.Merge(sourceConfig.Executor)
+	}
+
 	CopyFieldsMetadata(sourceConfig, cfg)
 
 	return nil
@@ -360,6 +483,14 @@ func (cfg *TerragruntConfig) DeepMerge(l log.Logger, sourceConfig *TerragruntCon
 		cfg.DownloadDir = sourceConfig.DownloadDir
 	}
 
+	if sourceConfig.Environment != "" {
+		cfg.Environment = sourceConfig.Environment
+	}
+
+	if sourceConfig.environmentMergeStrategy != "" {
+		cfg.environmentMergeStrategy = sourceConfig.environmentMergeStrategy
+	}
+
 	if sourceConfig.IamRole != "" {
 		cfg.IamRole = sourceConfig.IamRole
 	}
@@ -384,6 +515,18 @@ func (cfg *TerragruntConfig) DeepMerge(l log.Logger, sourceConfig *TerragruntCon
 		cfg.RetryMaxAttempts = sourceConfig.RetryMaxAttempts
 	}
 
+	if sourceConfig.Weight != nil {
+		cfg.Weight = sourceConfig.Weight
+	}
+
+	if sourceConfig.RunTimeoutSec != nil {
+		cfg.RunTimeoutSec = sourceConfig.RunTimeoutSec
+	}
+
+	if sourceConfig.QueuePriority != nil {
+		cfg.QueuePriority = sourceConfig.QueuePriority
+	}
+
 	if sourceConfig.RetrySleepIntervalSec != nil {
 		cfg.RetrySleepIntervalSec = sourceConfig.RetrySleepIntervalSec
 	}
@@ -479,6 +622,14 @@ func (cfg *TerragruntConfig) DeepMerge(l log.Logger, sourceConfig *TerragruntCon
 		cfg.RetryableErrors = append(cfg.RetryableErrors, sourceConfig.RetryableErrors...)
 	}
 
+	if sourceConfig.Tags != nil {
+		cfg.Tags = util.MergeStringSlices(cfg.Tags, sourceConfig.Tags)
+	}
+
+	if sourceConfig.MutexGroups != nil {
+		cfg.MutexGroups = util.MergeStringSlices(cfg.MutexGroups, sourceConfig.MutexGroups)
+	}
+
 	// Handle complex structs by recursively merging the structs together
 	if sourceConfig.Terraform != nil {
 		if cfg.Terraform == nil {
@@ -533,6 +684,43 @@ func (cfg *TerragruntConfig) DeepMerge(l log.Logger, sourceConfig *TerragruntCon
 		cfg.Inputs = mergedInputs
 	}
 
+	if sourceConfig.InputsOverrides != nil {
+		inputsOverrides, err := mergeInputsOverrides(sourceConfig.InputsOverrides, cfg.InputsOverrides, deepMergeInputs)
+		if err != nil {
+			return err
+		}
+
+		cfg.InputsOverrides = inputsOverrides
+	}
+
+	if sourceConfig.Assertions != nil {
+		cfg.Assertions = mergeAssertions(sourceConfig.Assertions, cfg.Assertions)
+	}
+
+	if sourceConfig.ParallelismLimits != nil {
+		cfg.ParallelismLimits = cfg.ParallelismLimits.Merge(sourceConfig.ParallelismLimits)
+	}
+
+	if sourceConfig.ApplyWindows != nil {
+		cfg.ApplyWindows = cfg.ApplyWindows.Merge(sourceConfig.ApplyWindows)
+	}
+
+	if sourceConfig.FailurePolicy != nil {
+		if cfg.FailurePolicy == nil {
+			cfg.FailurePolicy = &FailurePolicyConfig{}
+		}
+
+		cfg.FailurePolicy.Merge(sourceConfig.FailurePolicy)
+	}
+
+	if sourceConfig.Executor != nil {
+		if cfg.Executor == nil {
+			cfg.Executor = &ExecutorConfig{}
+		}
+
+		cfg.Executor.Merge(sourceConfig.Executor)
+	}
+
 	// MAINTAINER'S NOTE: The following structs cannot be deep merged due to an implementation detail (they do not
 	// support nil attributes, so we can't determine if an attribute was intentionally set, or was defaulted from
 	// unspecified - this is especially problematic for bool attributes).
@@ -555,7 +743,11 @@ func fetchDependencyPaths(config *TerragruntConfig) map[string]string {
 	}
 
 	for _, dependency := range config.TerragruntDependencies {
-		m[dependency.Name] = dependency.ConfigPath.AsString()
+		if dependency.IsExternal() {
+			continue
+		}
+
+		m[dependency.instanceName()] = dependency.ConfigPath.AsString()
 	}
 
 	return m
@@ -603,17 +795,17 @@ func mergeDependencyBlocks(targetDependencies []Dependency, sourceDependencies [
 
 	dependencyBlocks := make(map[string]Dependency)
 	for _, dep := range targetDependencies {
-		dependencyBlocks[dep.Name] = dep
-		keys = append(keys, dep.Name)
+		dependencyBlocks[dep.instanceName()] = dep
+		keys = append(keys, dep.instanceName())
 	}
 
 	for _, dep := range sourceDependencies {
-		_, hasSameKey := dependencyBlocks[dep.Name]
+		_, hasSameKey := dependencyBlocks[dep.instanceName()]
 		if !hasSameKey {
-			keys = append(keys, dep.Name)
+			keys = append(keys, dep.instanceName())
 		}
 		// Regardless of what is in dependencyBlocks, we will always override the key with source
-		dependencyBlocks[dep.Name] = dep
+		dependencyBlocks[dep.instanceName()] = dep
 	}
 	// Now convert the map to list and set target
 	combinedDeps := []Dependency{}
@@ -634,22 +826,22 @@ func deepMergeDependencyBlocks(targetDependencies []Dependency, sourceDependenci
 
 	dependencyBlocks := make(map[string]Dependency)
 	for _, dep := range targetDependencies {
-		dependencyBlocks[dep.Name] = dep
-		keys = append(keys, dep.Name)
+		dependencyBlocks[dep.instanceName()] = dep
+		keys = append(keys, dep.instanceName())
 	}
 
 	for _, dep := range sourceDependencies {
-		sameKeyDep, hasSameKey := dependencyBlocks[dep.Name]
+		sameKeyDep, hasSameKey := dependencyBlocks[dep.instanceName()]
 		if hasSameKey {
 			sameKeyDepPtr := &sameKeyDep
 			if err := sameKeyDepPtr.DeepMerge(dep); err != nil {
 				return nil, err
 			}
 
-			dependencyBlocks[dep.Name] = *sameKeyDepPtr
+			dependencyBlocks[dep.instanceName()] = *sameKeyDepPtr
 		} else {
-			dependencyBlocks[dep.Name] = dep
-			keys = append(keys, dep.Name)
+			dependencyBlocks[dep.instanceName()] = dep
+			keys = append(keys, dep.instanceName())
 		}
 	}
 
@@ -759,6 +951,49 @@ func mergeErrorHooks(l log.Logger, childHooks []ErrorHook, parentHooks *[]ErrorH
 	*parentHooks = result
 }
 
+// terraformHooks are the hooks of the terraform block of a config.
+type terraformHooks struct {
+	beforeHooks []Hook
+	afterHooks  []Hook
+	errorHooks  []ErrorHook
+}
+
+// getTerraformHooks returns a copy of the hooks of the given config, taken before merging it, as the merge modifies the
+// hooks in place.
+func getTerraformHooks(cfg *TerragruntConfig) terraformHooks {
+	if cfg == nil || cfg.Terraform == nil {
+		return terraformHooks{}
+	}
+
+	return terraformHooks{
+		beforeHooks: slices.Clone(cfg.Terraform.BeforeHooks),
+		afterHooks:  slices.Clone(cfg.Terraform.AfterHooks),
+		errorHooks:  slices.Clone(cfg.Terraform.ErrorHooks),
+	}
+}
+
+// mergeIncludedHooks replaces the hooks of the merged config according to the hooks merge strategy of the include block.
+// With the default override strategy, the merged hooks are kept as is, meaning a child hook replaces the parent hook
+// with the same name. With the additive strategies, all the hooks of both the parent and the child are kept, even if
+// they have the same name, the parent ones running first with parent_first and last with child_first. In both cases,
+// the hooks are then run in the order of their priority.
+func mergeIncludedHooks(l log.Logger, cfg *TerragruntConfig, parentHooks, childHooks terraformHooks, strategy HooksMergeStrategyType) {
+	if strategy == OverrideHooks || cfg.Terraform == nil {
+		return
+	}
+
+	l.Debugf("Merging hooks of the included config with strategy %s.", strategy)
+
+	first, last := parentHooks, childHooks
+	if strategy == ChildFirstHooks {
+		first, last = childHooks, parentHooks
+	}
+
+	cfg.Terraform.BeforeHooks = append(slices.Clone(first.beforeHooks), last.beforeHooks...)
+	cfg.Terraform.AfterHooks = append(slices.Clone(first.afterHooks), last.afterHooks...)
+	cfg.Terraform.ErrorHooks = append(slices.Clone(first.errorHooks), last.errorHooks...)
+}
+
 // getTrackInclude converts the terragrunt include blocks into TrackInclude structs that differentiate between an
 // included config in the current parsing ctx, and an included config that was passed through from a previous
 // parsing ctx.
@@ -0,0 +1,172 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/gruntwork-io/terragrunt/config/hclparse"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+// includeEnabledAttr is the attribute of include blocks gating the include on a condition.
+const includeEnabledAttr = "enabled"
+
+// includeConditionUnavailableVars are the variables that are only defined after the include blocks are decoded, and so
+// can't be referenced in their conditions.
+var includeConditionUnavailableVars = []string{MetadataInclude, MetadataImport, MetadataDependency}
+
+// filterEnabledIncludes returns the include blocks whose `enabled` condition is true or not set.
+func filterEnabledIncludes(includes IncludeConfigs) IncludeConfigs {
+	enabled := make(IncludeConfigs, 0, len(includes))
+
+	for _, include := range includes {
+		if include.IsEnabled() {
+			enabled = append(enabled, include)
+		}
+	}
+
+	return enabled
+}
+
+// evaluateIncludeConditionLocals evaluates the locals referenced by the `enabled` conditions of the include blocks of
+// the file, along with the locals they depend on, so that includes can be gated on locals. As these locals are
+// evaluated before the include blocks, they can't reference the `include`, `import` and `dependency` variables. It
+// returns nil when no condition references locals.
+func evaluateIncludeConditionLocals(ctx *ParsingContext, l log.Logger, file *hclparse.File) (*cty.Value, error) {
+	content, _, diags := file.Body.PartialContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: MetadataInclude, LabelNames: []string{"name"}}},
+	})
+	if err := file.HandleDiagnostics(diags); err != nil {
+		return nil, errors.New(err)
+	}
+
+	var referenced []string
+
+	for _, block := range content.Blocks {
+		content, _, moreDiags := block.Body.PartialContent(&hcl.BodySchema{
+			Attributes: []hcl.AttributeSchema{{Name: includeEnabledAttr}},
+		})
+		if moreDiags.HasErrors() {
+			diags = append(diags, moreDiags...)
+			continue
+		}
+
+		attr, ok := content.Attributes[includeEnabledAttr]
+		if !ok {
+			continue
+		}
+
+		for _, traversal := range attr.Expr.Variables() {
+			rootName := traversal.RootName()
+
+			if slices.Contains(includeConditionUnavailableVars, rootName) {
+				diags = diags.Append(includeConditionDiagnostic(attr.Expr, fmt.Sprintf(
+					"The enabled condition of include blocks is evaluated before the include, import and dependency blocks, so it can't reference %q.",
+					rootName,
+				)))
+
+				continue
+			}
+
+			if name := getLocalName(traversal); name != "" && !slices.Contains(referenced, name) {
+				referenced = append(referenced, name)
+			}
+		}
+	}
+
+	if err := file.HandleDiagnostics(diags); err != nil {
+		return nil, errors.New(err)
+	}
+
+	if len(referenced) == 0 {
+		return nil, nil
+	}
+
+	localsBlocks, err := file.Blocks(MetadataLocals, false)
+	if err != nil || len(localsBlocks) == 0 {
+		return nil, err
+	}
+
+	localAttrs, err := localsBlocks[0].JustAttributes()
+	if err != nil {
+		return nil, err
+	}
+
+	// Select the referenced locals and, transitively, the locals they reference.
+	var attrs hclparse.Attributes
+
+	for i := 0; i < len(referenced); i++ {
+		idx := slices.IndexFunc(localAttrs, func(attr *hclparse.Attribute) bool { return attr.Name == referenced[i] })
+		if idx < 0 {
+			// Undefined locals are reported when the condition is evaluated.
+			continue
+		}
+
+		attr := localAttrs[idx]
+		attrs = append(attrs, attr)
+
+		for _, traversal := range attr.Expr.Variables() {
+			rootName := traversal.RootName()
+
+			if slices.Contains(includeConditionUnavailableVars, rootName) {
+				diags = diags.Append(includeConditionDiagnostic(attr.Expr, fmt.Sprintf(
+					"The local %q is referenced by the enabled condition of an include block, which is evaluated before the include, import and dependency blocks, so it can't reference %q.",
+					attr.Name, rootName,
+				)))
+
+				continue
+			}
+
+			if name := getLocalName(traversal); name != "" && !slices.Contains(referenced, name) {
+				referenced = append(referenced, name)
+			}
+		}
+	}
+
+	if err := file.HandleDiagnostics(diags); err != nil {
+		return nil, errors.New(err)
+	}
+
+	ctx = ctx.WithTrackInclude(nil)
+	evaluatedLocals := map[string]cty.Value{}
+	evaluated := true
+
+	for iterations := 0; len(attrs) > 0 && evaluated; iterations++ {
+		if iterations > MaxIter {
+			return nil, errors.New(MaxIterError{})
+		}
+
+		attrs, evaluatedLocals, evaluated, err = attemptEvaluateLocals(ctx, l, file, attrs, evaluatedLocals)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, attr := range attrs {
+		diags = append(diags, canEvaluateLocals(attr.Expr, evaluatedLocals)...)
+	}
+
+	if err := file.HandleDiagnostics(diags); err != nil {
+		return nil, errors.New(CouldNotEvaluateAllLocalsError{Err: err})
+	}
+
+	localsAsCtyVal, err := convertValuesMapToCtyVal(evaluatedLocals)
+	if err != nil {
+		return nil, err
+	}
+
+	return &localsAsCtyVal, nil
+}
+
+func includeConditionDiagnostic(expr hcl.Expression, detail string) *hcl.Diagnostic {
+	return &hcl.Diagnostic{
+		Severity: hcl.DiagError,
+		Summary:  "Invalid include condition",
+		Detail:   detail,
+		Subject:  expr.Range().Ptr(),
+	}
+}
@@ -0,0 +1,160 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+)
+
+func TestConditionalInclude(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		expected         map[string]any
+		expectedIncludes map[string]string
+		name             string
+		cfg              string
+		expectedErr      string
+	}{
+		{
+			name: "enabled by local",
+			cfg: `
+locals {
+  provider = "legacy"
+  legacy   = local.provider == "legacy"
+}
+
+include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+
+include "legacy" {
+  path    = find_in_parent_folders("legacy-providers.hcl")
+  enabled = local.legacy
+}
+`,
+			expected:         map[string]any{"region": "us-east-1", "legacy": true},
+			expectedIncludes: map[string]string{"root": "root.hcl", "legacy": "legacy-providers.hcl"},
+		},
+		{
+			name: "disabled by local",
+			cfg: `
+locals {
+  legacy = false
+}
+
+include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+
+include "legacy" {
+  path    = find_in_parent_folders("legacy-providers.hcl")
+  enabled = local.legacy
+}
+`,
+			expected:         map[string]any{"region": "us-east-1"},
+			expectedIncludes: map[string]string{"root": "root.hcl"},
+		},
+		{
+			name: "disabled by feature flag",
+			cfg: `
+feature "legacy" {
+  default = false
+}
+
+include "legacy" {
+  path    = find_in_parent_folders("legacy-providers.hcl")
+  enabled = feature.legacy.value
+}
+`,
+			expected:         nil,
+			expectedIncludes: map[string]string{},
+		},
+		{
+			name: "condition referencing an include",
+			cfg: `
+include "root" {
+  path   = find_in_parent_folders("root.hcl")
+  expose = true
+}
+
+include "legacy" {
+  path    = find_in_parent_folders("legacy-providers.hcl")
+  enabled = include.root.inputs.region == "us-east-1"
+}
+`,
+			expectedErr: `can't reference "include"`,
+		},
+		{
+			name: "condition local referencing an include",
+			cfg: `
+locals {
+  legacy = include.root.inputs.region == "us-east-1"
+}
+
+include "root" {
+  path   = find_in_parent_folders("root.hcl")
+  expose = true
+}
+
+include "legacy" {
+  path    = find_in_parent_folders("legacy-providers.hcl")
+  enabled = local.legacy
+}
+`,
+			expectedErr: `The local "legacy" is referenced by the enabled condition of an include block`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rootDir := t.TempDir()
+			unitDir := filepath.Join(rootDir, "app")
+			configPath := filepath.Join(unitDir, config.DefaultTerragruntConfigPath)
+
+			require.NoError(t, os.MkdirAll(unitDir, 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(rootDir, "root.hcl"), []byte(`
+inputs = {
+  region = "us-east-1"
+}
+`), 0644))
+			require.NoError(t, os.WriteFile(filepath.Join(rootDir, "legacy-providers.hcl"), []byte(`
+inputs = {
+  legacy = true
+}
+`), 0644))
+			require.NoError(t, os.WriteFile(configPath, []byte(tc.cfg), 0644))
+
+			l := createLogger()
+			ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, configPath))
+
+			cfg, err := config.ParseConfigFile(ctx, l, configPath, nil)
+			if tc.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.expectedErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, cfg.Inputs)
+
+			partial, err := config.PartialParseConfigFile(ctx.WithDecodeList(config.DependencyBlock), l, configPath, nil)
+			require.NoError(t, err)
+
+			includes := map[string]string{}
+			for name, include := range partial.ProcessedIncludes {
+				includes[name] = filepath.Base(include.Path)
+			}
+
+			assert.Equal(t, tc.expectedIncludes, includes)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"path/filepath"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// includeLocalsAsCtyVal evaluates only the locals of the included config, for include blocks that set
+// `expose_locals = true`, and returns them as an object with a single `locals` attribute so that they can be referenced
+// as `include.<label>.locals` in the child config. Unlike `expose`, the rest of the included config is not parsed, so
+// neither dependencies nor inputs of the included config are evaluated.
+func includeLocalsAsCtyVal(ctx *ParsingContext, l log.Logger, includeConfig IncludeConfig) (cty.Value, error) {
+	locals, err := evaluateIncludeLocals(ctx, l, includeConfig)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	localsCty, err := convertToCtyWithJSON(locals)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	return cty.ObjectVal(map[string]cty.Value{MetadataLocals: localsCty}), nil
+}
+
+// evaluateIncludeLocals partially parses the included config to evaluate its locals.
+func evaluateIncludeLocals(ctx *ParsingContext, l log.Logger, includeConfig IncludeConfig) (map[string]any, error) {
+	if includeConfig.Path == "" {
+		return nil, errors.New(IncludedConfigMissingPathError(ctx.TerragruntOptions.TerragruntConfigPath))
+	}
+
+	includePath := includeConfig.Path
+	if !filepath.IsAbs(includePath) {
+		includePath = util.JoinPath(filepath.Dir(ctx.TerragruntOptions.TerragruntConfigPath), includePath)
+	}
+
+	ctx, err := trackIncludeEdge(ctx.WithTrackInclude(nil).WithDecodeList(), includePath)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := PartialParseConfigFile(ctx, l, includePath, &includeConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if parsed.Locals == nil {
+		return map[string]any{}, nil
+	}
+
+	return parsed.Locals, nil
+}
+
+// setExposedIncludeLocals sets the locals exposed by the include blocks of the config being parsed with
+// `expose_locals`, keyed by include label, on the given config along with the file they come from. The config is left
+// unchanged if none of its include blocks sets `expose_locals`, so that it isn't rendered with an `include` key.
+func setExposedIncludeLocals(ctx *ParsingContext, l log.Logger, config *TerragruntConfig) error {
+	config.IncludeLocals = nil
+
+	if ctx.TrackInclude == nil {
+		return nil
+	}
+
+	for label, includeConfig := range ctx.TrackInclude.CurrentMap {
+		if includeConfig.GetExpose() || !includeConfig.GetExposeLocals() {
+			continue
+		}
+
+		locals, err := evaluateIncludeLocals(ctx, l, includeConfig)
+		if err != nil {
+			return err
+		}
+
+		if config.IncludeLocals == nil {
+			config.IncludeLocals = map[string]any{}
+		}
+
+		config.IncludeLocals[label] = locals
+		config.SetFieldMetadataWithType(MetadataInclude, label, map[string]any{FoundInFile: includeConfig.Path})
+	}
+
+	return nil
+}
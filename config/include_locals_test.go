@@ -0,0 +1,140 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/ctyhelper"
+)
+
+func TestIncludeExposeLocals(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	unitDir := filepath.Join(rootDir, "app")
+	rootPath := filepath.Join(rootDir, "root.hcl")
+	configPath := filepath.Join(unitDir, config.DefaultTerragruntConfigPath)
+
+	require.NoError(t, os.MkdirAll(unitDir, 0755))
+	require.NoError(t, os.WriteFile(rootPath, []byte(`
+locals {
+  region = "us-east-1"
+  name   = "app-${local.region}"
+}
+`), 0644))
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+include "root" {
+  path          = find_in_parent_folders("root.hcl")
+  expose_locals = true
+}
+
+inputs = {
+  name = include.root.locals.name
+}
+`), 0644))
+
+	l := createLogger()
+	ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, configPath))
+
+	cfg, err := config.ParseConfigFile(ctx, l, configPath, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"name": "app-us-east-1"}, cfg.Inputs)
+	assert.Equal(t, map[string]any{"root": map[string]any{"region": "us-east-1", "name": "app-us-east-1"}}, cfg.IncludeLocals)
+
+	ctyVal, err := config.TerragruntConfigAsCtyWithMetadata(cfg)
+	require.NoError(t, err)
+
+	rendered, err := ctyhelper.ParseCtyValueToMap(ctyVal)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"root": map[string]any{
+			"metadata": map[string]any{config.FoundInFile: rootPath},
+			"value":    map[string]any{"locals": map[string]any{"region": "us-east-1", "name": "app-us-east-1"}},
+		},
+	}, rendered["include"])
+}
+
+func TestIncludeExposeLocalsCycle(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	unitDir := filepath.Join(rootDir, "app")
+	configPath := filepath.Join(unitDir, config.DefaultTerragruntConfigPath)
+
+	require.NoError(t, os.MkdirAll(unitDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "root.hcl"), []byte(`
+locals {
+  child = read_terragrunt_config("${get_terragrunt_dir()}/terragrunt.hcl")
+}
+`), 0644))
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+include "root" {
+  path          = find_in_parent_folders("root.hcl")
+  expose_locals = true
+}
+`), 0644))
+
+	l := createLogger()
+	ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, configPath))
+
+	_, err := config.ParseConfigFile(ctx, l, configPath, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Found a cycle between included configs")
+}
+
+func TestIncludeWithoutExposeLocals(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	unitDir := filepath.Join(rootDir, "app")
+	rootPath := filepath.Join(rootDir, "root.hcl")
+	configPath := filepath.Join(unitDir, config.DefaultTerragruntConfigPath)
+
+	require.NoError(t, os.MkdirAll(unitDir, 0755))
+	require.NoError(t, os.WriteFile(rootPath, []byte(`
+locals {
+  region = "us-east-1"
+}
+
+inputs = {
+  region = local.region
+}
+`), 0644))
+
+	for _, expose := range []string{"false", "true"} {
+		require.NoError(t, os.WriteFile(configPath, []byte(`
+include "root" {
+  path   = find_in_parent_folders("root.hcl")
+  expose = `+expose+`
+}
+`), 0644))
+
+		l := createLogger()
+		ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, configPath))
+
+		cfg, err := config.ParseConfigFile(ctx, l, configPath, nil)
+		require.NoError(t, err)
+		assert.Nil(t, cfg.IncludeLocals)
+
+		// Without expose_locals, the rendered config has no include key.
+		ctyVal, err := config.TerragruntConfigAsCty(cfg)
+		require.NoError(t, err)
+
+		rendered, err := ctyhelper.ParseCtyValueToMap(ctyVal)
+		require.NoError(t, err)
+		assert.NotContains(t, rendered, "include", "expose = %s", expose)
+		assert.Equal(t, map[string]any{"region": "us-east-1"}, rendered["inputs"])
+
+		ctyVal, err = config.TerragruntConfigAsCtyWithMetadata(cfg)
+		require.NoError(t, err)
+
+		rendered, err = ctyhelper.ParseCtyValueToMap(ctyVal)
+		require.NoError(t, err)
+		assert.NotContains(t, rendered, "include", "expose = %s", expose)
+	}
+}
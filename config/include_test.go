@@ -386,3 +386,134 @@ func TestConcurrentCopyFieldsMetadata(t *testing.T) {
 		t.Errorf("Expected %d fields, got %d", expectedFields, len(targetConfig.FieldsMetadata))
 	}
 }
+
+func TestHooksMergeStrategy(t *testing.T) {
+	t.Parallel()
+
+	const rootConfig = `
+terraform {
+  before_hook "lint" {
+    commands = ["plan"]
+    execute  = ["tflint"]
+  }
+
+  before_hook "audit" {
+    commands = ["plan"]
+    execute  = ["audit"]
+  }
+
+  error_hook "notify" {
+    commands  = ["apply"]
+    execute   = ["notify"]
+    on_errors = [".*"]
+  }
+}
+`
+
+	testCases := []struct {
+		name           string
+		strategy       string
+		childHooks     string
+		expectedBefore []string
+		expectedError  []string
+	}{
+		{
+			name:           "override",
+			strategy:       "",
+			expectedBefore: []string{"lint:child-lint", "audit:audit", "init:init"},
+			expectedError:  []string{"notify:notify"},
+		},
+		{
+			name:           "parent first",
+			strategy:       `hooks_merge_strategy = "parent_first"`,
+			expectedBefore: []string{"lint:tflint", "audit:audit", "lint:child-lint", "init:init"},
+			expectedError:  []string{"notify:notify"},
+		},
+		{
+			name:           "child first",
+			strategy:       `hooks_merge_strategy = "child_first"`,
+			expectedBefore: []string{"lint:child-lint", "init:init", "lint:tflint", "audit:audit"},
+			expectedError:  []string{"notify:notify"},
+		},
+		{
+			name:     "child first with priority",
+			strategy: `hooks_merge_strategy = "child_first"`,
+			childHooks: `
+  before_hook "first" {
+    commands = ["plan"]
+    execute  = ["first"]
+    priority = 10
+  }
+`,
+			expectedBefore: []string{"first:first", "lint:child-lint", "init:init", "lint:tflint", "audit:audit"},
+			expectedError:  []string{"notify:notify"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			rootDir := t.TempDir()
+			writeExtendsTestUnit(t, rootDir, ".", rootConfig)
+			configPath := writeExtendsTestUnit(t, rootDir, "app", `
+include "root" {
+  path = find_in_parent_folders("terragrunt.hcl")
+  `+tc.strategy+`
+}
+
+terraform {
+  before_hook "lint" {
+    commands = ["plan"]
+    execute  = ["child-lint"]
+  }
+
+  before_hook "init" {
+    commands = ["plan"]
+    execute  = ["init"]
+  }
+`+tc.childHooks+`
+}
+`)
+
+			l := logger.CreateLogger()
+			ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, configPath))
+
+			cfg, err := config.ParseConfigFile(ctx, l, configPath, nil)
+			require.NoError(t, err)
+
+			before := []string{}
+			for _, hook := range cfg.Terraform.GetBeforeHooks() {
+				before = append(before, hook.Name+":"+hook.Execute[0])
+			}
+
+			errorHooks := []string{}
+			for _, hook := range cfg.Terraform.GetErrorHooks() {
+				errorHooks = append(errorHooks, hook.Name+":"+hook.Execute[0])
+			}
+
+			assert.Equal(t, tc.expectedBefore, before)
+			assert.Equal(t, tc.expectedError, errorHooks)
+		})
+	}
+}
+
+func TestHooksMergeStrategyInvalid(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	writeExtendsTestUnit(t, rootDir, ".", "")
+	configPath := writeExtendsTestUnit(t, rootDir, "app", `
+include "root" {
+  path                 = find_in_parent_folders("terragrunt.hcl")
+  hooks_merge_strategy = "append"
+}
+`)
+
+	l := logger.CreateLogger()
+	ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, configPath))
+
+	_, err := config.ParseConfigFile(ctx, l, configPath, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Include hooks merge strategy append is unknown")
+}
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/gruntwork-io/terragrunt/internal/ctyhelper"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/tf"
+)
+
+// terragruntInputsOverrides is an `inputs_overrides` block, whose attributes override the inputs of the unit when it
+// runs the command of the label.
+type terragruntInputsOverrides struct {
+	Inputs  map[string]cty.Value `hcl:",remain"`
+	Command string               `hcl:"command,label"`
+}
+
+// decryptInputsOverrides decrypts the encrypted values of the given inputs_overrides blocks, the same as the inputs.
+func decryptInputsOverrides(ctx *ParsingContext, l log.Logger, blocks []terragruntInputsOverrides) error {
+	for i, block := range blocks {
+		if len(block.Inputs) == 0 {
+			continue
+		}
+
+		inputs, err := ctyhelper.UpdateUnknownCtyValValues(cty.ObjectVal(block.Inputs))
+		if err != nil {
+			return err
+		}
+
+		if inputs, err = decryptEncryptedValues(ctx, l, inputs); err != nil {
+			return err
+		}
+
+		blocks[i].Inputs = inputs.AsValueMap()
+	}
+
+	return nil
+}
+
+// convertInputsOverrides converts the given inputs_overrides blocks to the inputs overriding the inputs of the unit by
+// command. Blocks with the same label are merged, the latest taking precedence.
+func convertInputsOverrides(blocks []terragruntInputsOverrides) (map[string]map[string]any, error) {
+	overrides := make(map[string]map[string]any, len(blocks))
+
+	for _, block := range blocks {
+		if block.Command == "" {
+			return nil, errors.Errorf("the label of an %s block can't be empty", MetadataInputsOverrides)
+		}
+
+		inputs := map[string]any{}
+
+		if len(block.Inputs) > 0 {
+			var err error
+			if inputs, err = ctyhelper.ParseCtyValueToMap(cty.ObjectVal(block.Inputs)); err != nil {
+				return nil, err
+			}
+		}
+
+		if overrides[block.Command] == nil {
+			overrides[block.Command] = inputs
+			continue
+		}
+
+		maps.Copy(overrides[block.Command], inputs)
+	}
+
+	return overrides, nil
+}
+
+// mergeInputsOverrides merges the given child inputs overrides onto the given parent ones, command by command, with the
+// given function used to merge the inputs of a command.
+func mergeInputsOverrides(
+	childOverrides, parentOverrides map[string]map[string]any,
+	mergeFn func(childInputs, parentInputs map[string]any) (map[string]any, error),
+) (map[string]map[string]any, error) {
+	out := make(map[string]map[string]any, len(childOverrides)+len(parentOverrides))
+	maps.Copy(out, parentOverrides)
+
+	for command, inputs := range childOverrides {
+		merged, err := mergeFn(inputs, out[command])
+		if err != nil {
+			return nil, err
+		}
+
+		out[command] = merged
+	}
+
+	return out, nil
+}
+
+// ApplyInputsOverrides merges the inputs of the inputs_overrides blocks of the command run with the given options onto
+// the inputs of the config. The `destroy` overrides also apply to the `plan` and `apply` commands run with the
+// `-destroy` flag.
+func (cfg *TerragruntConfig) ApplyInputsOverrides(opts *options.TerragruntOptions) {
+	if len(cfg.InputsOverrides) == 0 {
+		return
+	}
+
+	commands := []string{opts.TerraformCommand}
+	if opts.TerraformCommand != tf.CommandNameDestroy && slices.Contains(opts.TerraformCliArgs, tf.FlagNameDestroy) {
+		commands = append(commands, tf.CommandNameDestroy)
+	}
+
+	for _, command := range commands {
+		inputs, ok := cfg.InputsOverrides[command]
+		if !ok {
+			continue
+		}
+
+		if cfg.Inputs == nil {
+			cfg.Inputs = make(map[string]any, len(inputs))
+		}
+
+		maps.Copy(cfg.Inputs, inputs)
+	}
+}
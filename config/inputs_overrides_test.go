@@ -0,0 +1,118 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+)
+
+func TestInputsOverrides(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	unitDir := filepath.Join(rootDir, "app")
+	configPath := filepath.Join(unitDir, config.DefaultTerragruntConfigPath)
+
+	require.NoError(t, os.MkdirAll(unitDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, config.RecommendedParentConfigName), []byte(`
+inputs_overrides "destroy" {
+  force_destroy = true
+  tags          = { reason = "teardown" }
+}
+`), 0644))
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+
+locals {
+  refresh = false
+}
+
+inputs = {
+  refresh       = true
+  force_destroy = false
+  tags          = { team = "platform" }
+}
+
+inputs_overrides "plan" {
+  refresh = local.refresh
+}
+
+inputs_overrides "destroy" {
+  tags = { env = "dev" }
+}
+`), 0644))
+
+	testCases := []struct {
+		expected map[string]any
+		name     string
+		command  string
+		args     []string
+	}{
+		{
+			name:    "apply",
+			command: "apply",
+			args:    []string{"apply"},
+			expected: map[string]any{
+				"refresh":       true,
+				"force_destroy": false,
+				"tags":          map[string]any{"team": "platform"},
+			},
+		},
+		{
+			name:    "plan",
+			command: "plan",
+			args:    []string{"plan"},
+			expected: map[string]any{
+				"refresh":       false,
+				"force_destroy": false,
+				"tags":          map[string]any{"team": "platform"},
+			},
+		},
+		{
+			name:    "plan destroy",
+			command: "plan",
+			args:    []string{"plan", "-destroy"},
+			expected: map[string]any{
+				"refresh":       false,
+				"force_destroy": true,
+				"tags":          map[string]any{"env": "dev"},
+			},
+		},
+		{
+			name:    "destroy",
+			command: "destroy",
+			args:    []string{"destroy"},
+			expected: map[string]any{
+				"refresh":       true,
+				"force_destroy": true,
+				"tags":          map[string]any{"env": "dev"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := mockOptionsForTestWithConfigPath(t, configPath)
+			opts.TerraformCommand = tc.command
+			opts.TerraformCliArgs = tc.args
+
+			l := createLogger()
+			ctx := config.NewParsingContext(t.Context(), l, opts)
+
+			cfg, err := config.ParseConfigFile(ctx, l, configPath, nil)
+			require.NoError(t, err)
+
+			cfg.ApplyInputsOverrides(opts)
+			assert.Equal(t, tc.expected, cfg.Inputs)
+		})
+	}
+}
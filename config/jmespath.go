@@ -0,0 +1,106 @@
+package config
+
+import (
+	"encoding/json"
+
+	"github.com/jmespath/go-jmespath"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// JMESPath applies the given JMESPath expression to the given value and returns the result, e.g.
+// `jmespath("subnets[?public].id", dependency.vpc.outputs)`. Lists and maps of the value become arrays and objects, so
+// the result is made of tuples and objects, the same as a value decoded with `jsondecode`.
+func JMESPath(expr string, value cty.Value) (cty.Value, error) {
+	query, err := jmespath.Compile(expr)
+	if err != nil {
+		return cty.NilVal, errors.Errorf("invalid JMESPath expression %q passed to the %s function: %w", expr, FuncNameJMESPath, err)
+	}
+
+	// The result can't be known until the whole value is, as the expression can read any part of it.
+	if !value.IsWhollyKnown() {
+		return cty.DynamicVal, nil
+	}
+
+	value, marks := value.UnmarkDeep()
+
+	result, err := query.Search(ctyValueToJMESPathData(value))
+	if err != nil {
+		return cty.NilVal, errors.Errorf("failed to evaluate JMESPath expression %q: %w", expr, err)
+	}
+
+	if result == nil {
+		return cty.NullVal(cty.DynamicPseudoType).WithMarks(marks), nil
+	}
+
+	resultJSON, err := json.Marshal(result)
+	if err != nil {
+		return cty.NilVal, errors.New(err)
+	}
+
+	ty, err := ctyjson.ImpliedType(resultJSON)
+	if err != nil {
+		return cty.NilVal, errors.New(err)
+	}
+
+	val, err := ctyjson.Unmarshal(resultJSON, ty)
+	if err != nil {
+		return cty.NilVal, errors.New(err)
+	}
+
+	return val.WithMarks(marks), nil
+}
+
+// ctyValueToJMESPathData converts the given known value to the JSON-like Go value that JMESPath expressions are
+// applied to.
+func ctyValueToJMESPathData(value cty.Value) any {
+	if value.IsNull() {
+		return nil
+	}
+
+	ty := value.Type()
+
+	switch {
+	case ty == cty.String:
+		return value.AsString()
+	case ty == cty.Number:
+		number, _ := value.AsBigFloat().Float64()
+		return number
+	case ty == cty.Bool:
+		return value.True()
+	case ty.IsListType(), ty.IsSetType(), ty.IsTupleType():
+		data := make([]any, 0, value.LengthInt())
+		for _, elem := range value.AsValueSlice() {
+			data = append(data, ctyValueToJMESPathData(elem))
+		}
+
+		return data
+	case ty.IsMapType(), ty.IsObjectType():
+		data := make(map[string]any, value.LengthInt())
+		for key, elem := range value.AsValueMap() {
+			data[key] = ctyValueToJMESPathData(elem)
+		}
+
+		return data
+	}
+
+	return nil
+}
+
+// jmespathAsFuncImpl creates a cty Function for calling jmespath, which filters and reshapes the given value with a
+// JMESPath expression.
+func jmespathAsFuncImpl() function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{Name: "expr", Type: cty.String},
+			{Name: "value", Type: cty.DynamicPseudoType, AllowNull: true, AllowUnknown: true, AllowMarked: true},
+		},
+		Type: function.StaticReturnType(cty.DynamicPseudoType),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			return JMESPath(args[0].AsString(), args[1])
+		},
+	})
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/hashicorp/hcl/v2"
@@ -26,6 +27,12 @@ const MaxIter = 1000
 // This returns a map of the local names to the evaluated expressions (represented as `cty.Value` objects). This will
 // error if there are remaining unevaluated locals after all references that can be evaluated has been evaluated.
 func EvaluateLocalsBlock(ctx *ParsingContext, l log.Logger, file *hclparse.File) (map[string]cty.Value, error) {
+	return evaluateLocalsBlockWithDefaults(ctx, l, file, nil)
+}
+
+// evaluateLocalsBlockWithDefaults evaluates the locals block like EvaluateLocalsBlock, with the given default locals
+// added to the evaluated locals unless the block declares locals with the same names, which take precedence.
+func evaluateLocalsBlockWithDefaults(ctx *ParsingContext, l log.Logger, file *hclparse.File, defaultLocals map[string]cty.Value) (map[string]cty.Value, error) {
 	localsBlock, err := file.Blocks(MetadataLocals, false)
 	if err != nil {
 		return nil, err
@@ -34,7 +41,7 @@ func EvaluateLocalsBlock(ctx *ParsingContext, l log.Logger, file *hclparse.File)
 	if len(localsBlock) == 0 {
 		// No locals block referenced in the file
 		l.Debugf("Did not find any locals block: skipping evaluation.")
-		return nil, nil
+		return maps.Clone(defaultLocals), nil
 	}
 
 	l.Debugf("Found locals block: evaluating the expressions.")
@@ -50,6 +57,13 @@ func EvaluateLocalsBlock(ctx *ParsingContext, l log.Logger, file *hclparse.File)
 	evaluatedLocals := map[string]cty.Value{}
 	evaluated := true
 
+	for name, value := range defaultLocals {
+		declared := slices.ContainsFunc(attrs, func(attr *hclparse.Attribute) bool { return attr.Name == name })
+		if !declared {
+			evaluatedLocals[name] = value
+		}
+	}
+
 	for iterations := 0; len(attrs) > 0 && evaluated; iterations++ {
 		if iterations > MaxIter {
 			// Reached maximum supported iterations, which is most likely an infinite loop bug so cut the iteration
@@ -139,6 +153,12 @@ func attemptEvaluateLocals(
 				continue
 			}
 
+			evaluatedVal, err = decryptEncryptedValues(ctx, l, evaluatedVal)
+			if err != nil {
+				errs = errs.Append(err)
+				continue
+			}
+
 			newEvaluatedLocals[attr.Name] = evaluatedVal
 
 			newlyEvaluatedLocalNames = append(newlyEvaluatedLocalNames, attr.Name)
@@ -191,6 +211,9 @@ func canEvaluateLocals(expression hcl.Expression, evaluatedLocals map[string]cty
 		case rootName == MetadataValues:
 			// If the variable is `values`
 
+		case rootName == MetadataImport:
+			// If the variable is `import`
+
 		case rootName != "local":
 			// We can't evaluate any variable other than `local`
 			detail = fmt.Sprintf("You can only reference to other local variables here, but it looks like you're referencing something else (%q is not defined)", rootName)
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"path"
+	"slices"
+
+	"github.com/bmatcuk/doublestar"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// ParallelismLimits represents a list of parallelism limits.
+type ParallelismLimits []*ParallelismLimit
+
+// ParallelismLimit is a `parallelism_limit` block, limiting the number of the units it matches that run concurrently in
+// run --all, on top of --parallelism, e.g.:
+//
+//	parallelism_limit "networking" {
+//	  max        = 2
+//	  paths      = ["networking/**"]
+//	  tags       = ["networking"]
+//	  depends_on = ["accounts/networking"]
+//	}
+//
+// A unit matches the limit if its path matches one of `paths`, it has one of `tags`, or the path of one of its
+// dependencies matches one of `depends_on`. Paths are globs matched against the paths of the units relative to the
+// working dir.
+type ParallelismLimit struct {
+	Paths     []string `hcl:"paths,optional" cty:"paths"`
+	Tags      []string `hcl:"tags,optional" cty:"tags"`
+	DependsOn []string `hcl:"depends_on,optional" cty:"depends_on"`
+	Name      string   `hcl:",label" cty:"name"`
+	Max       int      `hcl:"max,attr" cty:"max"`
+}
+
+// Validate returns an error if the limit, defined in the config at the given path, doesn't select any unit, its max
+// is lower than 1, or one of its globs is malformed.
+func (limit *ParallelismLimit) Validate(configPath string) error {
+	if limit.Max < 1 {
+		return errors.New(InvalidParallelismLimitError{Path: configPath, Name: limit.Name, Reason: "max must be at least 1"})
+	}
+
+	if len(limit.Paths) == 0 && len(limit.Tags) == 0 && len(limit.DependsOn) == 0 {
+		return errors.New(InvalidParallelismLimitError{Path: configPath, Name: limit.Name, Reason: "one of paths, tags or depends_on must be set"})
+	}
+
+	// doublestar only reports malformed patterns when matching reaches them, see ParseDependencyPolicyFile.
+	for _, pattern := range slices.Concat(limit.Paths, limit.DependsOn) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return errors.New(InvalidParallelismLimitError{Path: configPath, Name: limit.Name, Reason: "invalid glob " + pattern + ": " + err.Error()})
+		}
+	}
+
+	return nil
+}
+
+// Matches returns true if the unit at the given path, relative to the working dir, with the given tags and
+// dependencies, also relative to the working dir, is limited by the limit.
+func (limit *ParallelismLimit) Matches(unitPath string, tags, dependencyPaths []string) bool {
+	if matchesAnyGlob(limit.Paths, unitPath) {
+		return true
+	}
+
+	if slices.ContainsFunc(limit.Tags, func(tag string) bool { return slices.Contains(tags, tag) }) {
+		return true
+	}
+
+	return slices.ContainsFunc(dependencyPaths, func(dependencyPath string) bool {
+		return matchesAnyGlob(limit.DependsOn, dependencyPath)
+	})
+}
+
+// validateParallelismLimits returns an error if one of the given limits, defined in the config at the given path, is
+// invalid, or if multiple limits have the same name.
+func validateParallelismLimits(configPath string, limits ParallelismLimits) error {
+	names := map[string]bool{}
+
+	for _, limit := range limits {
+		if names[limit.Name] {
+			return errors.New(InvalidParallelismLimitError{Path: configPath, Name: limit.Name, Reason: "multiple parallelism_limit blocks have this name"})
+		}
+
+		names[limit.Name] = true
+
+		if err := limit.Validate(configPath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Merge merges the given limits into the limits by name, the given limits replacing the ones with the same name.
+func (limits ParallelismLimits) Merge(sourceLimits ParallelismLimits) ParallelismLimits {
+	if limits == nil && sourceLimits == nil {
+		return nil
+	}
+
+	merged := slices.Clone(limits)
+
+	for _, source := range sourceLimits {
+		if i := slices.IndexFunc(merged, func(limit *ParallelismLimit) bool { return limit.Name == source.Name }); i >= 0 {
+			merged[i] = source
+			continue
+		}
+
+		merged = append(merged, source)
+	}
+
+	return merged
+}
+
+func matchesAnyGlob(patterns []string, unitPath string) bool {
+	return slices.ContainsFunc(patterns, func(pattern string) bool {
+		matched, _ := doublestar.Match(pattern, unitPath)
+		return matched
+	})
+}
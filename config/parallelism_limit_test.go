@@ -0,0 +1,122 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+)
+
+func TestParseParallelismLimits(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	unitDir := filepath.Join(rootDir, "networking", "vpc")
+	configPath := filepath.Join(unitDir, config.DefaultTerragruntConfigPath)
+
+	require.NoError(t, os.MkdirAll(unitDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "root.hcl"), []byte(`
+parallelism_limit "networking" {
+  max   = 2
+  paths = ["networking/**"]
+}
+
+parallelism_limit "eks" {
+  max  = 3
+  tags = ["eks"]
+}
+`), 0644))
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+
+parallelism_limit "networking" {
+  max        = 1
+  depends_on = ["accounts/*"]
+}
+`), 0644))
+
+	l := createLogger()
+	opts := mockOptionsForTestWithConfigPath(t, configPath)
+
+	cfg, err := config.ParseConfigFile(config.NewParsingContext(t.Context(), l, opts), l, configPath, nil)
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, config.ParallelismLimits{
+		{Name: "networking", Max: 1, DependsOn: []string{"accounts/*"}},
+		{Name: "eks", Max: 3, Tags: []string{"eks"}},
+	}, cfg.ParallelismLimits)
+}
+
+func TestParseParallelismLimitsInvalid(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		cfg    string
+		reason string
+	}{
+		{
+			name: "max",
+			cfg: `
+parallelism_limit "networking" {
+  max   = 0
+  paths = ["networking/**"]
+}
+`,
+			reason: "max must be at least 1",
+		},
+		{
+			name: "selectors",
+			cfg: `
+parallelism_limit "networking" {
+  max = 1
+}
+`,
+			reason: "one of paths, tags or depends_on must be set",
+		},
+		{
+			name: "glob",
+			cfg: `
+parallelism_limit "networking" {
+  max   = 1
+  paths = ["networking/["]
+}
+`,
+			reason: "invalid glob networking/[",
+		},
+		{
+			name: "duplicate",
+			cfg: `
+parallelism_limit "networking" {
+  max   = 1
+  paths = ["networking/**"]
+}
+
+parallelism_limit "networking" {
+  max  = 2
+  tags = ["networking"]
+}
+`,
+			reason: "multiple parallelism_limit blocks have this name",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			l := createLogger()
+			ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTest(t))
+
+			_, err := config.ParseConfigString(ctx, l, config.DefaultTerragruntConfigPath, tc.cfg, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.reason)
+		})
+	}
+}
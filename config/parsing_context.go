@@ -2,6 +2,7 @@ package config
 
 import (
 	"context"
+	"slices"
 
 	"github.com/zclconf/go-cty/cty"
 	"github.com/zclconf/go-cty/cty/function"
@@ -31,6 +32,9 @@ type ParsingContext struct {
 	// Values of the unit.
 	Values *cty.Value
 
+	// Imports are the documents pulled by the `import` blocks of the config.
+	Imports *cty.Value
+
 	// DecodedDependencies are references of other terragrunt config. This contains the following attributes that map to
 	// various fields related to that config:
 	// - outputs: The map of outputs from the terraform state obtained by running `terragrunt output` on that target config.
@@ -50,6 +54,14 @@ type ParsingContext struct {
 
 	// `ParserOptions` is used to configure hcl Parser.
 	ParserOptions []hclparse.Option
+
+	// ExtendedUnits are the config paths of the units extended so far with the `extends` attribute, starting with the
+	// unit being parsed. It is used to detect cycles.
+	ExtendedUnits []string
+
+	// ReadUnits are the config paths of the units being read with `read_unit_config` and `read_unit_locals`, starting
+	// with the unit being parsed. It is used to detect cycles.
+	ReadUnits []string
 }
 
 func NewParsingContext(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) *ParsingContext {
@@ -81,6 +93,13 @@ func (ctx ParsingContext) WithValues(values *cty.Value) *ParsingContext {
 	return &ctx
 }
 
+// WithImports sets the imported documents to be used in evaluation context.
+func (ctx ParsingContext) WithImports(imports *cty.Value) *ParsingContext {
+	ctx.Imports = imports
+
+	return &ctx
+}
+
 // WithFeatures sets the feature flags to be used in evaluation context.
 func (ctx ParsingContext) WithFeatures(features *cty.Value) *ParsingContext {
 	ctx.Features = features
@@ -93,6 +112,13 @@ func (ctx ParsingContext) WithTrackInclude(trackInclude *TrackInclude) *ParsingC
 	return &ctx
 }
 
+// WithExtendedUnit records that the unit at the given config path is being extended.
+func (ctx ParsingContext) WithExtendedUnit(configPath string) *ParsingContext {
+	ctx.ExtendedUnits = append(slices.Clone(ctx.ExtendedUnits), configPath)
+
+	return &ctx
+}
+
 func (ctx ParsingContext) WithParseOption(parserOptions []hclparse.Option) *ParsingContext {
 	ctx.ParserOptions = parserOptions
 	return &ctx
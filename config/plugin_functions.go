@@ -0,0 +1,233 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	"github.com/gruntwork-io/terragrunt/internal/cache"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/os/exec"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+// FunctionPluginProtocolVersion is the version of the protocol used to call function plugins, sent with each request.
+const FunctionPluginProtocolVersion = 1
+
+// FunctionFactory creates a custom function for the given parsing context, which gives the function access to the
+// options of the unit being evaluated, e.g. its directory or environment variables.
+type FunctionFactory func(ctx *ParsingContext, l log.Logger) function.Function
+
+var (
+	customFunctionsMu sync.RWMutex
+	customFunctions   = map[string]FunctionFactory{}
+)
+
+// RegisterFunction registers a custom function available during the evaluation of Terragrunt configs under the given
+// name. It is meant to be called from an `init` function of a custom build of Terragrunt, to add company-specific
+// helpers without forking the config package. Function names must be valid HCL identifiers, and must not conflict with
+// other custom functions or, once the config is evaluated, with the built-in functions.
+func RegisterFunction(name string, factory FunctionFactory) error {
+	if !hclsyntax.ValidIdentifier(name) {
+		return errors.New(InvalidFunctionNameError{Name: name})
+	}
+
+	customFunctionsMu.Lock()
+	defer customFunctionsMu.Unlock()
+
+	if _, ok := customFunctions[name]; ok {
+		return errors.New(DuplicateFunctionError{Name: name})
+	}
+
+	customFunctions[name] = factory
+
+	return nil
+}
+
+// UnregisterFunction removes the custom function registered under the given name, if any.
+func UnregisterFunction(name string) {
+	customFunctionsMu.Lock()
+	defer customFunctionsMu.Unlock()
+
+	delete(customFunctions, name)
+}
+
+// addCustomFunctions adds the functions registered with RegisterFunction, along with the function plugins set with the
+// `--function-plugin` flag, to the given functions, returning an error if any of them conflicts with a built-in function.
+func addCustomFunctions(ctx *ParsingContext, l log.Logger, functions map[string]function.Function) error {
+	custom := map[string]function.Function{}
+
+	customFunctionsMu.RLock()
+	for name, factory := range customFunctions {
+		custom[name] = factory(ctx, l)
+	}
+	customFunctionsMu.RUnlock()
+
+	for name, path := range ctx.TerragruntOptions.FunctionPlugins {
+		if !hclsyntax.ValidIdentifier(name) {
+			return errors.New(InvalidFunctionNameError{Name: name})
+		}
+
+		if _, ok := custom[name]; ok {
+			return errors.New(DuplicateFunctionError{Name: name})
+		}
+
+		custom[name] = functionPluginAsFuncImpl(ctx, l, name, path)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(custom)) {
+		if _, ok := functions[name]; ok {
+			return errors.New(FunctionNameConflictError{Name: name})
+		}
+	}
+
+	maps.Copy(functions, custom)
+
+	return nil
+}
+
+// functionPluginRequest is the request written to the stdin of a function plugin.
+type functionPluginRequest struct {
+	Function      string            `json:"function"`
+	TerragruntDir string            `json:"terragrunt_dir"`
+	Args          []json.RawMessage `json:"args"`
+	Version       int               `json:"version"`
+}
+
+// functionPluginResponse is the response read from the stdout of a function plugin.
+type functionPluginResponse struct {
+	Error  string          `json:"error"`
+	Result json.RawMessage `json:"result"`
+}
+
+// functionPluginAsFuncImpl creates a cty Function calling the function plugin executable at the given path. The plugin
+// receives the function name and arguments as JSON on its stdin, and must write a JSON object with either the `result`
+// or the `error` of the call to its stdout.
+func functionPluginAsFuncImpl(ctx *ParsingContext, l log.Logger, name, path string) function.Function {
+	return function.New(&function.Spec{
+		VarParam: &function.Parameter{Name: "args", Type: cty.DynamicPseudoType},
+		// We don't know the return type until we call the plugin, so we use a dynamic type
+		Type: function.StaticReturnType(cty.DynamicPseudoType),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			return callFunctionPlugin(ctx, l, name, path, args)
+		},
+	})
+}
+
+func callFunctionPlugin(ctx *ParsingContext, l log.Logger, name, path string, args []cty.Value) (cty.Value, error) {
+	// functionPluginCache - cache of the function plugin results, so the plugins are called once for the same
+	// arguments, even though the configs are evaluated several times.
+	functionPluginCache := cache.ContextCache[string](ctx, FunctionPluginCacheContextKey)
+
+	terragruntDir := filepath.Dir(ctx.TerragruntOptions.TerragruntConfigPath)
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(ctx.TerragruntOptions.RootWorkingDir, path)
+	}
+
+	req := functionPluginRequest{
+		Version:       FunctionPluginProtocolVersion,
+		Function:      name,
+		TerragruntDir: filepath.ToSlash(terragruntDir),
+		Args:          make([]json.RawMessage, 0, len(args)),
+	}
+
+	for _, arg := range args {
+		encoded, err := ctyjson.SimpleJSONValue{Value: arg}.MarshalJSON()
+		if err != nil {
+			return cty.NilVal, errors.New(FunctionPluginError{Name: name, Path: path, Err: err})
+		}
+
+		req.Args = append(req.Args, encoded)
+	}
+
+	input, err := json.Marshal(req)
+	if err != nil {
+		return cty.NilVal, errors.New(FunctionPluginError{Name: name, Path: path, Err: err})
+	}
+
+	cacheKey := fmt.Sprintf("%v-%v", path, string(input))
+
+	result, foundInCache := functionPluginCache.Get(ctx, cacheKey)
+	if !foundInCache {
+		if result, err = runFunctionPlugin(ctx, l, name, path, terragruntDir, input); err != nil {
+			return cty.NilVal, err
+		}
+
+		functionPluginCache.Put(ctx, cacheKey, result)
+	}
+
+	if result == "" {
+		return cty.NullVal(cty.DynamicPseudoType), nil
+	}
+
+	ty, err := ctyjson.ImpliedType([]byte(result))
+	if err != nil {
+		return cty.NilVal, errors.New(FunctionPluginError{Name: name, Path: path, Err: err})
+	}
+
+	val, err := ctyjson.Unmarshal([]byte(result), ty)
+	if err != nil {
+		return cty.NilVal, errors.New(FunctionPluginError{Name: name, Path: path, Err: err})
+	}
+
+	return val, nil
+}
+
+// runFunctionPlugin runs the function plugin in the directory of the unit, returning the JSON encoded result.
+func runFunctionPlugin(ctx *ParsingContext, l log.Logger, name, path, dir string, input []byte) (string, error) {
+	l.Debugf("Calling function plugin %s: %s", name, path)
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.Command(path)
+	cmd.Dir = dir
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Configure(
+		exec.WithLogger(l),
+		exec.WithEnv(ctx.TerragruntOptions.Env),
+	)
+
+	if err := cmd.Start(); err != nil { //nolint:contextcheck
+		return "", errors.New(FunctionPluginError{Name: name, Path: path, Err: err})
+	}
+
+	cancelShutdown := cmd.RegisterGracefullyShutdown(ctx)
+	defer cancelShutdown()
+
+	if err := cmd.Wait(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			err = fmt.Errorf("%w: %s", err, msg)
+		}
+
+		return "", errors.New(FunctionPluginError{Name: name, Path: path, Err: err})
+	}
+
+	var resp functionPluginResponse
+
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return "", errors.New(FunctionPluginError{Name: name, Path: path, Err: fmt.Errorf("invalid response: %w", err)})
+	}
+
+	if resp.Error != "" {
+		return "", errors.New(FunctionPluginError{Name: name, Path: path, Err: errors.New(resp.Error)})
+	}
+
+	if string(resp.Result) == "null" {
+		return "", nil
+	}
+
+	return string(resp.Result), nil
+}
@@ -0,0 +1,123 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+func TestRegisterFunction(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, config.RegisterFunction("test_resource_name", func(ctx *config.ParsingContext, l log.Logger) function.Function {
+		return function.New(&function.Spec{
+			Params: []function.Parameter{{Name: "name", Type: cty.String}},
+			Type:   function.StaticReturnType(cty.String),
+			Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+				unit := filepath.Base(filepath.Dir(ctx.TerragruntOptions.TerragruntConfigPath))
+
+				return cty.StringVal("acme-" + unit + "-" + args[0].AsString()), nil
+			},
+		})
+	}))
+	t.Cleanup(func() { config.UnregisterFunction("test_resource_name") })
+
+	err := config.RegisterFunction("test_resource_name", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is already registered")
+
+	configPath := writeReadUnitTestConfig(t, t.TempDir(), "app", `
+inputs = {
+  bucket = test_resource_name("logs")
+}
+`)
+
+	l := createLogger()
+	ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, configPath))
+
+	cfg, err := config.ParseConfigFile(ctx, l, configPath, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"bucket": "acme-app-logs"}, cfg.Inputs)
+}
+
+func TestRegisterFunctionErrors(t *testing.T) {
+	t.Parallel()
+
+	err := config.RegisterFunction("1st.function", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be valid HCL identifiers")
+
+	configPath := writeReadUnitTestConfig(t, t.TempDir(), "app", `inputs = {}`)
+
+	opts := mockOptionsForTestWithConfigPath(t, configPath)
+	opts.FunctionPlugins = map[string]string{config.FuncNameGetEnv: "/bin/true"}
+
+	l := createLogger()
+	ctx := config.NewParsingContext(t.Context(), l, opts)
+
+	_, err = config.ParseConfigFile(ctx, l, configPath, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "conflicts with the built-in function")
+}
+
+func TestFunctionPlugin(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("function plugin test scripts require a POSIX shell")
+	}
+
+	rootDir := t.TempDir()
+
+	echoPlugin := filepath.Join(rootDir, "echo-plugin")
+	require.NoError(t, os.WriteFile(echoPlugin, []byte("#!/bin/sh\nprintf '{\"result\": %s}' \"$(cat)\"\n"), 0755))
+
+	failingPlugin := filepath.Join(rootDir, "failing-plugin")
+	require.NoError(t, os.WriteFile(failingPlugin, []byte("#!/bin/sh\necho '{\"error\": \"no free subnets\"}'\n"), 0755))
+
+	configPath := writeReadUnitTestConfig(t, rootDir, "app", `
+locals {
+  request = echo("logs", 3, { env = "prod" })
+}
+
+inputs = {
+  function = local.request.function
+  args     = local.request.args
+  dir      = basename(local.request.terragrunt_dir)
+  version  = local.request.version
+}
+`)
+
+	opts := mockOptionsForTestWithConfigPath(t, configPath)
+	opts.FunctionPlugins = map[string]string{"echo": echoPlugin, "next_subnet": failingPlugin}
+
+	l := createLogger()
+	ctx := config.NewParsingContext(t.Context(), l, opts)
+
+	cfg, err := config.ParseConfigFile(ctx, l, configPath, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"function": "echo",
+		"args":     []any{"logs", float64(3), map[string]any{"env": "prod"}},
+		"dir":      "app",
+		"version":  float64(config.FunctionPluginProtocolVersion),
+	}, cfg.Inputs)
+
+	failingConfigPath := writeReadUnitTestConfig(t, rootDir, "network", `inputs = { subnet = next_subnet("10.0.0.0/16") }`)
+
+	ctx = config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, failingConfigPath))
+	ctx.TerragruntOptions.FunctionPlugins = opts.FunctionPlugins
+
+	_, err = config.ParseConfigFile(ctx, l, failingConfigPath, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no free subnets")
+}
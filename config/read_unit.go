@@ -0,0 +1,96 @@
+package config
+
+import (
+	"slices"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// readUnitConfigAsFuncImpl creates a cty Function for calling read_unit_config, which returns the evaluated `locals`
+// and `inputs` of another unit.
+func readUnitConfigAsFuncImpl(ctx *ParsingContext, l log.Logger) function.Function {
+	return readUnitAsFuncImpl(ctx, l, func(cfg *TerragruntConfig) (cty.Value, error) {
+		locals, err := convertToCtyWithJSON(cfg.Locals)
+		if err != nil {
+			return cty.NilVal, err
+		}
+
+		inputs, err := convertToCtyWithJSON(cfg.Inputs)
+		if err != nil {
+			return cty.NilVal, err
+		}
+
+		return cty.ObjectVal(map[string]cty.Value{
+			MetadataLocals: locals,
+			MetadataInputs: inputs,
+		}), nil
+	})
+}
+
+// readUnitLocalsAsFuncImpl creates a cty Function for calling read_unit_locals, which returns the evaluated `locals` of
+// another unit.
+func readUnitLocalsAsFuncImpl(ctx *ParsingContext, l log.Logger) function.Function {
+	return readUnitAsFuncImpl(ctx, l, func(cfg *TerragruntConfig) (cty.Value, error) {
+		return convertToCtyWithJSON(cfg.Locals)
+	})
+}
+
+func readUnitAsFuncImpl(ctx *ParsingContext, l log.Logger, toCty func(cfg *TerragruntConfig) (cty.Value, error)) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{{Type: cty.String}},
+		// We don't know the return type until we parse the unit config, so we use a dynamic type
+		Type: function.StaticReturnType(cty.DynamicPseudoType),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			strArgs, err := ctySliceToStringSlice(args)
+			if err != nil {
+				return cty.NilVal, err
+			}
+
+			cfg, err := readUnitConfig(ctx, l, strArgs[0])
+			if err != nil {
+				return cty.NilVal, err
+			}
+
+			return toCty(cfg)
+		},
+	})
+}
+
+// readUnitConfig evaluates the locals and inputs of the unit at the given path, relative to the current unit. Unlike
+// read_terragrunt_config, the outputs of the dependencies of the read unit are never fetched from their state, only
+// their mock outputs are available, so reading a unit doesn't require it or its dependencies to be applied.
+func readUnitConfig(ctx *ParsingContext, l log.Logger, configPath string) (*TerragruntConfig, error) {
+	targetConfig := getCleanedTargetConfigPath(configPath, ctx.TerragruntOptions.TerragruntConfigPath)
+	if !util.FileExists(targetConfig) {
+		return nil, errors.New(TerragruntConfigNotFoundError{Path: targetConfig})
+	}
+
+	readUnits := ctx.ReadUnits
+	if len(readUnits) == 0 {
+		readUnits = []string{util.CleanPath(ctx.TerragruntOptions.TerragruntConfigPath)}
+	}
+
+	if slices.Contains(readUnits, targetConfig) {
+		return nil, errors.New(ReadUnitCycleError(append(slices.Clone(readUnits), targetConfig)))
+	}
+
+	ctx.TerragruntOptions.AppendReadFile(targetConfig, ctx.TerragruntOptions.WorkingDir)
+
+	l, opts, err := ctx.TerragruntOptions.CloneWithConfigPath(l, targetConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.SkipOutput = true
+
+	unitCtx := ctx.WithTerragruntOptions(opts).WithTrackInclude(nil).WithDecodeList(TerragruntInputs)
+	unitCtx.ReadUnits = append(slices.Clone(readUnits), targetConfig)
+	unitCtx.DecodedDependencies = nil
+
+	return PartialParseConfigFile(unitCtx, l, targetConfig, nil)
+}
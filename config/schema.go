@@ -0,0 +1,73 @@
+package config
+
+import (
+	"slices"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+
+	"github.com/gruntwork-io/terragrunt/config/hclparse"
+	"github.com/gruntwork-io/terragrunt/internal/cache"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/strict/controls"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+// unknownConfigDiagSummaries are the summaries of the diagnostics HCL reports for the blocks and attributes that are
+// not part of a schema, along with a did-you-mean suggestion when a known name is close enough.
+var unknownConfigDiagSummaries = []string{
+	"Unsupported argument",
+	"Unsupported block type",
+	"Extraneous JSON object property",
+}
+
+// terragruntConfigFileSchema returns the schema of the top level blocks and attributes of a Terragrunt config, where
+// none of the attributes are required, as only the unknown ones are checked.
+func terragruntConfigFileSchema() *hcl.BodySchema {
+	schema, _ := gohcl.ImpliedBodySchema(&terragruntConfigFile{})
+
+	for i := range schema.Attributes {
+		schema.Attributes[i].Required = false
+	}
+
+	return schema
+}
+
+// validateConfigSchema checks the given file for unknown top level blocks and attributes, e.g. `remote_sate`, which are
+// ignored when partially parsing configs, e.g. to build the dependency graph. When the `strict-config-schema` strict
+// control is enabled, they are reported as errors suggesting the closest known name, otherwise only a warning is logged.
+// As the configs are partially parsed many times, each file is only validated once.
+func validateConfigSchema(ctx *ParsingContext, l log.Logger, file *hclparse.File) error {
+	schemaCache := cache.ContextCache[bool](ctx, ConfigSchemaCacheContextKey)
+	if _, found := schemaCache.Get(ctx, file.ConfigPath); found {
+		return nil
+	}
+
+	_, diags := file.Body.Content(terragruntConfigFileSchema())
+
+	var unknown hcl.Diagnostics
+
+	for _, diag := range diags {
+		if slices.Contains(unknownConfigDiagSummaries, diag.Summary) {
+			unknown = append(unknown, diag)
+		}
+	}
+
+	if len(unknown) == 0 {
+		schemaCache.Put(ctx, file.ConfigPath, true)
+		return nil
+	}
+
+	control := ctx.TerragruntOptions.StrictControls.Find(controls.StrictConfigSchema)
+	if control == nil {
+		return errors.New("failed to find control " + controls.StrictConfigSchema)
+	}
+
+	if err := control.Evaluate(log.ContextWithLogger(ctx, l)); err != nil {
+		return file.HandleDiagnostics(unknown)
+	}
+
+	schemaCache.Put(ctx, file.ConfigPath, true)
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"github.com/hashicorp/go-version"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// semverObjectType is the type of the object returned by semver_parse.
+var semverObjectType = cty.Object(map[string]cty.Type{
+	"version":    cty.String,
+	"major":      cty.Number,
+	"minor":      cty.Number,
+	"patch":      cty.Number,
+	"prerelease": cty.String,
+	"metadata":   cty.String,
+})
+
+// SemverSatisfies returns true if the given version satisfies the given constraint, e.g.
+// `semver_satisfies("5.31.0", "~> 5.0")`.
+func SemverSatisfies(ctx *ParsingContext, args []string) (bool, error) {
+	return checkVersionConstraint(FuncNameSemverSatisfies, args)
+}
+
+// SemverMax returns the highest of the given versions, as it was written.
+func SemverMax(versions []string) (string, error) {
+	if len(versions) == 0 {
+		return "", errors.New(FuncNameSemverMax + " requires at least one version")
+	}
+
+	var latest *version.Version
+
+	for _, str := range versions {
+		v, err := version.NewSemver(str)
+		if err != nil {
+			return "", errors.Errorf("invalid version %s: %w", str, err)
+		}
+
+		if latest == nil || v.GreaterThan(latest) {
+			latest = v
+		}
+	}
+
+	return latest.Original(), nil
+}
+
+// semverMaxAsFuncImpl creates a cty Function for calling semver_max, which takes a list of versions.
+func semverMaxAsFuncImpl() function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{{Name: "versions", Type: cty.List(cty.String)}},
+		Type:   function.StaticReturnType(cty.String),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			versions, err := ctySliceToStringSlice(args[0].AsValueSlice())
+			if err != nil {
+				return cty.NilVal, err
+			}
+
+			latest, err := SemverMax(versions)
+			if err != nil {
+				return cty.NilVal, err
+			}
+
+			return cty.StringVal(latest), nil
+		},
+	})
+}
+
+// semverParseAsFuncImpl creates a cty Function for calling semver_parse, which returns the components of a version,
+// e.g. `semver_parse("v1.2.3-beta.1").minor`.
+func semverParseAsFuncImpl() function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{{Name: "version", Type: cty.String}},
+		Type:   function.StaticReturnType(semverObjectType),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			str := args[0].AsString()
+
+			v, err := version.NewSemver(str)
+			if err != nil {
+				return cty.NilVal, errors.Errorf("invalid version %s: %w", str, err)
+			}
+
+			segments := v.Segments64()
+
+			return cty.ObjectVal(map[string]cty.Value{
+				"version":    cty.StringVal(v.String()),
+				"major":      cty.NumberIntVal(segments[0]),
+				"minor":      cty.NumberIntVal(segments[1]),
+				"patch":      cty.NumberIntVal(segments[2]),
+				"prerelease": cty.StringVal(v.Prerelease()),
+				"metadata":   cty.StringVal(v.Metadata()),
+			}), nil
+		},
+	})
+}
+
+// checkVersionConstraint checks the version and constraint passed to the given function.
+func checkVersionConstraint(funcName string, args []string) (bool, error) {
+	if len(args) != matchedPats {
+		return false, errors.New(WrongNumberOfParamsError{Func: funcName, Expected: "2", Actual: len(args)})
+	}
+
+	v, err := version.NewSemver(args[0])
+	if err != nil {
+		return false, errors.Errorf("invalid version %s: %w", args[0], err)
+	}
+
+	c, err := version.NewConstraint(args[1])
+	if err != nil {
+		return false, errors.Errorf("invalid constraint %s: %w", args[1], err)
+	}
+
+	return c.Check(v), nil
+}
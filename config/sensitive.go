@@ -0,0 +1,113 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+
+	"github.com/gruntwork-io/terragrunt/internal/redact"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+// Sensitive registers the strings of the given value as sensitive values of the run, so they are redacted from the logs,
+// the rendered configs, the error messages and the output of OpenTofu/Terraform, and returns the value, e.g.
+// `sensitive(get_env("DB_PASSWORD"))`.
+//
+// Unlike the OpenTofu/Terraform `sensitive` function, the value isn't marked, so it can be used anywhere in the
+// configuration, e.g. in the contents of a generate block.
+func Sensitive(ctx *ParsingContext, l log.Logger, value cty.Value) cty.Value {
+	value, _ = value.UnmarkDeep()
+
+	registerSensitiveValue(ctx, l, "the value passed to the sensitive function in "+ctx.TerragruntOptions.TerragruntConfigPath, value)
+
+	return value
+}
+
+// registerSensitiveValue registers the known strings of the given value, described by the given key, with the redactor
+// of the run. The strings shorter than redact.MinLength are ignored by the redactor, so a warning naming them is logged
+// instead, as they will appear in the logs.
+func registerSensitiveValue(ctx *ParsingContext, l log.Logger, key string, value cty.Value) {
+	_ = cty.Walk(value, func(path cty.Path, val cty.Value) (bool, error) {
+		if !val.IsKnown() || val.IsNull() || val.Type() != cty.String {
+			return true, nil
+		}
+
+		if str := val.AsString(); len(str) >= redact.MinLength {
+			ctx.TerragruntOptions.Redactor.Add(str)
+		} else if str != "" {
+			name := key
+			if len(path) > 0 {
+				name += " at " + formatCtyPath(path)
+			}
+
+			l.Warnf("The sensitive value of %s is shorter than %d characters, so it isn't redacted and will appear in the logs.", name, redact.MinLength)
+		}
+
+		return true, nil
+	})
+}
+
+// formatCtyPath returns the given path within a value in HCL syntax, e.g. `tags["env"]`.
+func formatCtyPath(path cty.Path) string {
+	var sb strings.Builder
+
+	for _, step := range path {
+		switch step := step.(type) {
+		case cty.GetAttrStep:
+			if sb.Len() > 0 {
+				sb.WriteString(".")
+			}
+
+			sb.WriteString(step.Name)
+		case cty.IndexStep:
+			switch step.Key.Type() {
+			case cty.String:
+				fmt.Fprintf(&sb, "[%q]", step.Key.AsString())
+			case cty.Number:
+				sb.WriteString("[" + step.Key.AsBigFloat().String() + "]")
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+// dependencyOutputJSONToCtyValueMap converts the terraform output json of a dependency as
+// TerraformOutputJSONToCtyValueMap, registering the values of its sensitive outputs with the redactor of the run.
+func dependencyOutputJSONToCtyValueMap(ctx *ParsingContext, l log.Logger, targetConfigPath string, jsonBytes []byte) (map[string]cty.Value, error) {
+	outputs, sensitive, err := terraformOutputJSONToCtyValueMap(targetConfigPath, jsonBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range sensitive {
+		registerSensitiveValue(ctx, l, fmt.Sprintf("output %s of dependency %s", name, targetConfigPath), outputs[name])
+	}
+
+	return outputs, nil
+}
+
+// sensitiveAsFuncImpl creates a cty Function for calling sensitive, which registers the given value as sensitive and
+// returns it.
+func sensitiveAsFuncImpl(ctx *ParsingContext, l log.Logger) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{
+				Name:             "value",
+				Type:             cty.DynamicPseudoType,
+				AllowUnknown:     true,
+				AllowNull:        true,
+				AllowMarked:      true,
+				AllowDynamicType: true,
+			},
+		},
+		Type: func(args []cty.Value) (cty.Type, error) {
+			return args[0].Type(), nil
+		},
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			return Sensitive(ctx, l, args[0]), nil
+		},
+	})
+}
@@ -0,0 +1,322 @@
+package config
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	svchost "github.com/hashicorp/terraform-svchost"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/function"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	"github.com/gruntwork-io/terragrunt/internal/cache"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/tf/cliconfig"
+)
+
+const (
+	// DefaultTFEHostname is the hostname of Terraform Cloud, used when the workspace doesn't specify one.
+	DefaultTFEHostname = "app.terraform.io"
+
+	// tfeAddressEnvName is the environment variable overriding the address of the default Terraform Cloud/Enterprise
+	// instance, e.g. `https://tfe.example.com`, the same as the go-tfe client.
+	tfeAddressEnvName = "TFE_ADDRESS"
+	// tfeTokenEnvName is the environment variable with the API token used when no credentials are configured for the
+	// host in the CLI config.
+	tfeTokenEnvName = "TFE_TOKEN"
+
+	tfeMediaType       = "application/vnd.api+json"
+	tfeOutputsPageSize = "100"
+	tfeRequestTimeout  = 30 * time.Second
+)
+
+// tfeHTTPClient is the client of the Terraform Cloud/Enterprise API, with a timeout so that an unreachable instance
+// doesn't hang the parsing of the configuration.
+var tfeHTTPClient = &http.Client{Timeout: tfeRequestTimeout}
+
+// tfeWorkspace is a Terraform Cloud/Enterprise workspace referenced by `[hostname/]organization/workspace`.
+type tfeWorkspace struct {
+	Address      string
+	Hostname     string
+	Organization string
+	Name         string
+}
+
+// parseTFEWorkspace parses the given `[hostname/]organization/workspace` workspace reference, the address of the
+// default instance being overridden by the `TFE_ADDRESS` variable of the given env.
+func parseTFEWorkspace(ref string, env map[string]string) (*tfeWorkspace, error) {
+	parts := strings.Split(ref, "/")
+
+	workspace := &tfeWorkspace{}
+
+	switch len(parts) {
+	case 2: //nolint:mnd
+		workspace.Address = "https://" + DefaultTFEHostname
+		if address := env[tfeAddressEnvName]; address != "" {
+			workspace.Address = strings.TrimSuffix(address, "/")
+		}
+
+		workspace.Organization, workspace.Name = parts[0], parts[1]
+	case 3: //nolint:mnd
+		workspace.Address = "https://" + parts[0]
+		workspace.Organization, workspace.Name = parts[1], parts[2]
+	default:
+		return nil, errors.Errorf("invalid workspace %q, expected [hostname/]organization/workspace", ref)
+	}
+
+	if workspace.Organization == "" || workspace.Name == "" {
+		return nil, errors.Errorf("invalid workspace %q, expected [hostname/]organization/workspace", ref)
+	}
+
+	addressURL, err := url.Parse(workspace.Address)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	workspace.Hostname = addressURL.Host
+
+	return workspace, nil
+}
+
+func (workspace *tfeWorkspace) String() string {
+	return workspace.Hostname + "/" + workspace.Organization + "/" + workspace.Name
+}
+
+// tfeStateVersionOutput is an output of the current state version of a workspace, as returned by the API.
+type tfeStateVersionOutput struct {
+	ID         string `json:"id"`
+	Attributes struct {
+		Name         string          `json:"name"`
+		Value        json.RawMessage `json:"value"`
+		DetailedType json.RawMessage `json:"detailed-type"`
+		Sensitive    bool            `json:"sensitive"`
+	} `json:"attributes"`
+}
+
+// TFEWorkspaceOutput returns the value of the given output of the current state of the given Terraform
+// Cloud/Enterprise workspace, e.g. `tfe_workspace_output("acme/networking-prod", "vpc_id")`. The API token is read from
+// the credentials of the host in the CLI config, the same as OpenTofu/Terraform, or the `TFE_TOKEN` environment variable.
+func TFEWorkspaceOutput(ctx *ParsingContext, l log.Logger, workspaceRef, outputName string) (cty.Value, error) {
+	workspace, err := parseTFEWorkspace(workspaceRef, ctx.TerragruntOptions.Env)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	outputs, err := tfeWorkspaceOutputs(ctx, l, workspace)
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	output, ok := outputs[outputName]
+	if !ok {
+		return cty.NilVal, errors.New(TFEWorkspaceOutputNotFoundError{Workspace: workspace.String(), Output: outputName})
+	}
+
+	return tfeOutputValue(ctx, l, workspace, output)
+}
+
+// tfeWorkspaceOutputs returns the outputs of the current state of the given workspace by name. The outputs are cached
+// for the run, so the API is only called once per workspace.
+func tfeWorkspaceOutputs(ctx *ParsingContext, l log.Logger, workspace *tfeWorkspace) (map[string]*tfeStateVersionOutput, error) {
+	outputsCache := cache.ContextCache[map[string]*tfeStateVersionOutput](ctx, TFEWorkspaceOutputsCacheContextKey)
+
+	cacheKey := workspace.Address + "/" + workspace.Organization + "/" + workspace.Name
+	if outputs, found := outputsCache.Get(ctx, cacheKey); found {
+		return outputs, nil
+	}
+
+	l.Debugf("Reading the outputs of the workspace %s", workspace)
+
+	workspaceResp := struct {
+		Data struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}{}
+
+	if err := tfeGet(ctx, workspace, "/api/v2/organizations/"+url.PathEscape(workspace.Organization)+"/workspaces/"+url.PathEscape(workspace.Name), &workspaceResp); err != nil {
+		return nil, err
+	}
+
+	outputs := map[string]*tfeStateVersionOutput{}
+
+	path := "/api/v2/workspaces/" + url.PathEscape(workspaceResp.Data.ID) + "/current-state-version-outputs?page%5Bsize%5D=" + tfeOutputsPageSize
+
+	for path != "" {
+		outputsResp := struct {
+			Links struct {
+				Next string `json:"next"`
+			} `json:"links"`
+			Data []*tfeStateVersionOutput `json:"data"`
+		}{}
+
+		if err := tfeGet(ctx, workspace, path, &outputsResp); err != nil {
+			return nil, err
+		}
+
+		for _, output := range outputsResp.Data {
+			outputs[output.Attributes.Name] = output
+		}
+
+		path = strings.TrimPrefix(outputsResp.Links.Next, workspace.Address)
+	}
+
+	outputsCache.Put(ctx, cacheKey, outputs)
+
+	return outputs, nil
+}
+
+// tfeGet sends a GET request for the given API path to the instance of the given workspace, and decodes the response
+// into the given value.
+func tfeGet(ctx *ParsingContext, workspace *tfeWorkspace, path string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, workspace.Address+path, nil)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	req.Header.Set("Accept", tfeMediaType)
+
+	if err := applyTFEToken(req, workspace.Hostname, ctx.TerragruntOptions.Env); err != nil {
+		return err
+	}
+
+	resp, err := tfeHTTPClient.Do(req)
+	if err != nil {
+		return errors.New(TFEWorkspaceOutputError{Workspace: workspace.String(), Err: err})
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return errors.New(TFEWorkspaceOutputError{Workspace: workspace.String(), Err: errors.Errorf("GET %s: %s", path, resp.Status)})
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return errors.New(TFEWorkspaceOutputError{Workspace: workspace.String(), Err: err})
+	}
+
+	return nil
+}
+
+// applyTFEToken authenticates the given request with the credentials of the given host from the CLI config, falling
+// back to the `TFE_TOKEN` variable of the given env.
+func applyTFEToken(req *http.Request, hostname string, env map[string]string) error {
+	cliCfg, err := cliconfig.LoadUserConfig()
+	if err != nil {
+		return err
+	}
+
+	if host, err := svchost.ForComparison(hostname); err == nil {
+		if creds := cliCfg.CredentialsSource().ForHost(host); creds != nil {
+			creds.PrepareRequest(req)
+			return nil
+		}
+	}
+
+	if token := env[tfeTokenEnvName]; token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	return nil
+}
+
+// hasTFEToken returns true if an API token is configured for the given host, in the CLI config or the `TFE_TOKEN`
+// variable of the given env.
+func hasTFEToken(hostname string, env map[string]string) bool {
+	req, err := http.NewRequest(http.MethodGet, "https://"+hostname, nil)
+	if err != nil {
+		return false
+	}
+
+	if err := applyTFEToken(req, hostname, env); err != nil {
+		return false
+	}
+
+	return req.Header.Get("Authorization") != ""
+}
+
+// tfeOutputValue converts the value of the given output of the given workspace to a cty value, using the type of the
+// output if the API returns it. The values of the sensitive outputs are registered with the redactor of the run.
+func tfeOutputValue(ctx *ParsingContext, l log.Logger, workspace *tfeWorkspace, output *tfeStateVersionOutput) (cty.Value, error) {
+	// The values of the sensitive outputs are omitted from the outputs of the current state, so they are read one by one.
+	if output.Attributes.Sensitive && isJSONNull(output.Attributes.Value) {
+		sensitiveOutput := struct {
+			Data *tfeStateVersionOutput `json:"data"`
+		}{}
+
+		if err := tfeGet(ctx, workspace, "/api/v2/state-version-outputs/"+url.PathEscape(output.ID), &sensitiveOutput); err != nil {
+			return cty.NilVal, err
+		}
+
+		if sensitiveOutput.Data == nil {
+			return cty.NilVal, errors.New(TFEWorkspaceOutputError{
+				Workspace: workspace.String(),
+				Err:       errors.Errorf("no value returned for the sensitive output %q", output.Attributes.Name),
+			})
+		}
+
+		output = sensitiveOutput.Data
+	}
+
+	value := output.Attributes.Value
+	ty := cty.DynamicPseudoType
+
+	if len(output.Attributes.DetailedType) > 0 && !isJSONNull(output.Attributes.DetailedType) {
+		var err error
+		if ty, err = ctyjson.UnmarshalType(output.Attributes.DetailedType); err != nil {
+			return cty.NilVal, errors.New(err)
+		}
+	}
+
+	if len(value) == 0 || isJSONNull(value) {
+		return cty.NullVal(ty), nil
+	}
+
+	if ty == cty.DynamicPseudoType {
+		impliedType, err := ctyjson.ImpliedType(value)
+		if err != nil {
+			return cty.NilVal, errors.New(err)
+		}
+
+		ty = impliedType
+	}
+
+	val, err := ctyjson.Unmarshal(value, ty)
+	if err != nil {
+		return cty.NilVal, errors.New(err)
+	}
+
+	if output.Attributes.Sensitive {
+		registerSensitiveValue(ctx, l, "output "+output.Attributes.Name+" of workspace "+workspace.String(), val)
+	}
+
+	return val, nil
+}
+
+func isJSONNull(raw json.RawMessage) bool {
+	return strings.TrimSpace(string(raw)) == "null"
+}
+
+// tfeWorkspaceOutputAsFuncImpl creates a cty Function for calling tfe_workspace_output, which returns the value of an
+// output of a Terraform Cloud/Enterprise workspace.
+func tfeWorkspaceOutputAsFuncImpl(ctx *ParsingContext, l log.Logger) function.Function {
+	return function.New(&function.Spec{
+		Params: []function.Parameter{
+			{Name: "workspace", Type: cty.String},
+			{Name: "output", Type: cty.String},
+		},
+		Type: function.StaticReturnType(cty.DynamicPseudoType),
+		Impl: func(args []cty.Value, retType cty.Type) (cty.Value, error) {
+			return TFEWorkspaceOutput(ctx, l, args[0].AsString(), args[1].AsString())
+		},
+	})
+}
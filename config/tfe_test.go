@@ -0,0 +1,174 @@
+package config_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/redact"
+)
+
+func TestTFEWorkspaceOutput(t *testing.T) {
+	t.Parallel()
+
+	var server *httptest.Server
+
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/api/v2/organizations/acme/workspaces/networking":
+			_, _ = w.Write([]byte(`{"data": {"id": "ws-123"}}`))
+		case "/api/v2/workspaces/ws-123/current-state-version-outputs":
+			if r.URL.Query().Get("page[number]") == "2" {
+				_, _ = w.Write([]byte(`{"data": [
+					{"id": "wsout-3", "attributes": {"name": "db_password", "sensitive": true, "value": null, "detailed-type": "string"}},
+				{"id": "wsout-4", "attributes": {"name": "api_key", "sensitive": true, "value": null, "detailed-type": "string"}}
+				]}`))
+
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"links": {"next": "` + server.URL + `/api/v2/workspaces/ws-123/current-state-version-outputs?page%5Bnumber%5D=2"}, "data": [
+				{"id": "wsout-1", "attributes": {"name": "vpc_id", "sensitive": false, "value": "vpc-123", "detailed-type": "string"}},
+				{"id": "wsout-2", "attributes": {"name": "subnet_ids", "sensitive": false, "value": ["subnet-1", "subnet-2"], "detailed-type": ["list", "string"]}}
+			]}`))
+		case "/api/v2/state-version-outputs/wsout-3":
+			_, _ = w.Write([]byte(`{"data": {"id": "wsout-3", "attributes": {"name": "db_password", "sensitive": true, "value": "hunter2", "detailed-type": "string"}}}`))
+		case "/api/v2/state-version-outputs/wsout-4":
+			_, _ = w.Write([]byte(`{"data": null}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	configPath := filepath.Join(t.TempDir(), config.DefaultTerragruntConfigPath)
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+inputs = {
+  vpc_id      = tfe_workspace_output("acme/networking", "vpc_id")
+  subnet_ids  = tfe_workspace_output("acme/networking", "subnet_ids")
+  db_password = tfe_workspace_output("acme/networking", "db_password")
+}
+`), 0644))
+
+	l := createLogger()
+	opts := mockOptionsForTestWithConfigPath(t, configPath)
+	opts.Env = map[string]string{
+		"TFE_ADDRESS": server.URL,
+		"TFE_TOKEN":   "test-token",
+	}
+	ctx := config.NewParsingContext(config.WithConfigValues(t.Context()), l, opts)
+
+	cfg, err := config.ParseConfigFile(ctx, l, configPath, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"vpc_id":      "vpc-123",
+		"subnet_ids":  []any{"subnet-1", "subnet-2"},
+		"db_password": "hunter2",
+	}, cfg.Inputs)
+
+	// Only the values of the sensitive outputs are redacted.
+	assert.Equal(t, "vpc_id=vpc-123 db_password="+redact.Placeholder, opts.Redactor.Redact("vpc_id=vpc-123 db_password=hunter2"))
+
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+inputs = {
+  vpc_id = tfe_workspace_output("acme/networking", "missing")
+}
+`), 0644))
+
+	_, err = config.ParseConfigFile(ctx, l, configPath, nil)
+	require.ErrorContains(t, err, `has no output "missing"`)
+
+	// The API not returning the value of a sensitive output is an error.
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+inputs = {
+  api_key = tfe_workspace_output("acme/networking", "api_key")
+}
+`), 0644))
+
+	_, err = config.ParseConfigFile(ctx, l, configPath, nil)
+	require.ErrorContains(t, err, `no value returned for the sensitive output "api_key"`)
+}
+
+func TestTFEWorkspaceDependency(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/api/v2/organizations/acme/workspaces/networking":
+			_, _ = w.Write([]byte(`{"data": {"id": "ws-123"}}`))
+		case "/api/v2/workspaces/ws-123/current-state-version-outputs":
+			_, _ = w.Write([]byte(`{"data": [
+				{"id": "wsout-1", "attributes": {"name": "vpc_id", "sensitive": false, "value": "vpc-123", "detailed-type": "string"}}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	configPath := filepath.Join(t.TempDir(), config.DefaultTerragruntConfigPath)
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+dependency "networking" {
+  tfe_workspace = "acme/networking"
+
+  mock_outputs = {
+    vpc_id = "vpc-mock"
+  }
+}
+
+inputs = {
+  vpc_id = dependency.networking.outputs.vpc_id
+}
+`), 0644))
+
+	l := createLogger()
+
+	opts := mockOptionsForTestWithConfigPath(t, configPath)
+	opts.Env = map[string]string{
+		"TFE_ADDRESS": server.URL,
+		"TFE_TOKEN":   "test-token",
+	}
+
+	ctx := config.NewParsingContext(config.WithConfigValues(t.Context()), l, opts)
+
+	cfg, err := config.ParseConfigFile(ctx, l, configPath, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "vpc-123", cfg.Inputs["vpc_id"])
+	assert.Nil(t, cfg.Dependencies, "workspaces must not be part of the dependency graph")
+
+	// Without a token, the mock outputs are used.
+	opts = mockOptionsForTestWithConfigPath(t, configPath)
+	opts.Env = map[string]string{"TFE_ADDRESS": server.URL}
+
+	ctx = config.NewParsingContext(config.WithConfigValues(t.Context()), l, opts)
+
+	cfg, err = config.ParseConfigFile(ctx, l, configPath, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "vpc-mock", cfg.Inputs["vpc_id"])
+
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+dependency "networking" {
+  config_path   = "../networking"
+  tfe_workspace = "acme/networking"
+}
+`), 0644))
+
+	_, err = config.ParseConfigFile(ctx, l, configPath, nil)
+	require.ErrorContains(t, err, "dependency block networking must set exactly one of config_path, tfe_workspace or cloudformation_stack")
+}
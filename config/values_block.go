@@ -0,0 +1,206 @@
+package config
+
+import (
+	"maps"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+
+	"github.com/gruntwork-io/terragrunt/config/hclparse"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+// ValueBlock represents a `value` block declared in a unit configuration. Value blocks turn the free-form `values`
+// passed down by a parent stack (through the terragrunt.values.hcl file) into a typed contract:
+//
+//	value "environment" {
+//	  type        = string
+//	  default     = "dev"
+//	  description = "Name of the environment the unit is deployed to."
+//
+//	  validation {
+//	    condition     = contains(["dev", "stage", "prod"], values.environment)
+//	    error_message = "environment must be one of dev, stage or prod."
+//	  }
+//	}
+type ValueBlock struct {
+	Type        hcl.Expression     `hcl:"type,optional"`
+	Default     *cty.Value         `hcl:"default,optional"`
+	Description *string            `hcl:"description,optional"`
+	Name        string             `hcl:",label"`
+	Validations []*ValueValidation `hcl:"validation,block"`
+}
+
+// ValueValidation represents a `validation` block nested in a `value` block.
+type ValueValidation struct {
+	Condition    hcl.Expression `hcl:"condition,attr"`
+	ErrorMessage hcl.Expression `hcl:"error_message,attr"`
+}
+
+// terragruntValueBlocks is a struct that can be used to only decode the value blocks.
+type terragruntValueBlocks struct {
+	Remain hcl.Body      `hcl:",remain"`
+	Values []*ValueBlock `hcl:"value,block"`
+}
+
+// terragruntValueIgnore is used to skip value blocks when decoding the full config, as they are evaluated in a
+// separate cycle before the base blocks.
+type terragruntValueIgnore struct {
+	Remain hcl.Body `hcl:",remain"`
+	Name   string   `hcl:"name,label"`
+}
+
+// TypeConstraint returns the type declared in the value block, along with optional attribute defaults. If no type is
+// declared, any value is accepted.
+func (value *ValueBlock) TypeConstraint() (cty.Type, *typeexpr.Defaults, error) {
+	if !hasExpression(value.Type) {
+		return cty.DynamicPseudoType, nil, nil
+	}
+
+	ty, defaults, diags := typeexpr.TypeConstraintWithDefaults(value.Type)
+	if diags.HasErrors() {
+		return cty.NilType, nil, errors.New(diags)
+	}
+
+	return ty, defaults, nil
+}
+
+// evaluateValueBlocks decodes the `value` blocks of the given file and resolves each of them against the values
+// provided by the parent stack. The provided values are converted to the declared types, defaults are applied for
+// absent values and validation rules are checked. The returned object contains all the provided values, with the
+// declared ones replaced by their typed version, so it can be used as the `values` variable for the rest of the parse.
+func evaluateValueBlocks(ctx *ParsingContext, l log.Logger, file *hclparse.File) (*cty.Value, error) {
+	evalCtx, err := createTerragruntEvalContext(ctx, l, file.ConfigPath)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded := terragruntValueBlocks{}
+	if err := file.Decode(&decoded, evalCtx); err != nil {
+		return nil, err
+	}
+
+	if len(decoded.Values) == 0 {
+		return ctx.Values, nil
+	}
+
+	provided := map[string]cty.Value{}
+	if ctx.Values != nil && !ctx.Values.IsNull() && ctx.Values.CanIterateElements() {
+		maps.Copy(provided, ctx.Values.AsValueMap())
+	}
+
+	resolved := make(map[string]cty.Value, len(provided))
+	maps.Copy(resolved, provided)
+
+	errs := &errors.MultiError{}
+
+	for _, value := range decoded.Values {
+		typed, err := value.resolve(file.ConfigPath, provided)
+		if err != nil {
+			errs = errs.Append(err)
+			continue
+		}
+
+		resolved[value.Name] = typed
+	}
+
+	if err := errs.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	resolvedVal := cty.ObjectVal(resolved)
+	evalCtx.Variables[MetadataValues] = resolvedVal
+
+	for _, value := range decoded.Values {
+		if err := value.validate(evalCtx, file.ConfigPath); err != nil {
+			errs = errs.Append(err)
+		}
+	}
+
+	if err := errs.ErrorOrNil(); err != nil {
+		return nil, err
+	}
+
+	l.Debugf("Resolved %d value block(s) in %s", len(decoded.Values), file.ConfigPath)
+
+	return &resolvedVal, nil
+}
+
+// resolve returns the provided value converted to the declared type, falling back to the default.
+func (value *ValueBlock) resolve(configPath string, provided map[string]cty.Value) (cty.Value, error) {
+	ty, defaults, err := value.TypeConstraint()
+	if err != nil {
+		return cty.NilVal, err
+	}
+
+	raw, found := provided[value.Name]
+	if !found || raw.IsNull() {
+		if value.Default == nil {
+			return cty.NilVal, errors.New(MissingRequiredValueError{Name: value.Name, ConfigPath: configPath})
+		}
+
+		raw = *value.Default
+	}
+
+	if defaults != nil {
+		raw = defaults.Apply(raw)
+	}
+
+	typed, err := convert.Convert(raw, ty)
+	if err != nil {
+		return cty.NilVal, errors.New(InvalidValueError{Name: value.Name, ConfigPath: configPath, Message: err.Error()})
+	}
+
+	return typed, nil
+}
+
+// validate evaluates the validation rules of the value block. The eval context must already expose the resolved values.
+func (value *ValueBlock) validate(evalCtx *hcl.EvalContext, configPath string) error {
+	for _, validation := range value.Validations {
+		result, diags := validation.Condition.Value(evalCtx)
+		if diags.HasErrors() {
+			return errors.New(diags)
+		}
+
+		result, err := convert.Convert(result, cty.Bool)
+		if err != nil || result.IsNull() || !result.IsKnown() {
+			return errors.New(InvalidValueError{Name: value.Name, ConfigPath: configPath, Message: "validation condition must evaluate to a boolean"})
+		}
+
+		if result.True() {
+			continue
+		}
+
+		message, diags := validation.ErrorMessage.Value(evalCtx)
+		if diags.HasErrors() {
+			return errors.New(diags)
+		}
+
+		messageStr := "validation failed"
+		if !message.IsNull() && message.Type() == cty.String {
+			messageStr = message.AsString()
+		}
+
+		return errors.New(InvalidValueError{Name: value.Name, ConfigPath: configPath, Message: messageStr})
+	}
+
+	return nil
+}
+
+// hasExpression returns false for the synthetic null expression that gohcl assigns to omitted optional attributes.
+func hasExpression(expr hcl.Expression) bool {
+	if expr == nil {
+		return false
+	}
+
+	if len(expr.Variables()) > 0 {
+		return true
+	}
+
+	val, diags := expr.Value(nil)
+
+	return diags.HasErrors() || !val.IsNull()
+}
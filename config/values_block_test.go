@@ -0,0 +1,105 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+const valueBlocksTestConfig = `
+value "environment" {
+  type = string
+
+  validation {
+    condition     = contains(["dev", "prod"], values.environment)
+    error_message = "environment must be dev or prod, got ${values.environment}."
+  }
+}
+
+value "replicas" {
+  type    = number
+  default = 1
+}
+
+value "tags" {
+  type    = object({ team = string, cost_center = optional(string, "shared") })
+  default = { team = "platform" }
+}
+
+inputs = {
+  environment = values.environment
+  replicas    = values.replicas
+  tags        = values.tags
+}
+`
+
+func TestValueBlocks(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		expectedInputs map[string]any
+		expectedErr    any
+		name           string
+		values         string
+	}{
+		{
+			name:   "provided values are converted to declared types",
+			values: `environment = "prod"` + "\n" + `replicas = "3"`,
+			expectedInputs: map[string]any{
+				"environment": "prod",
+				"replicas":    float64(3),
+				"tags":        map[string]any{"team": "platform", "cost_center": "shared"},
+			},
+		},
+		{
+			name:        "missing required value",
+			values:      `replicas = 2`,
+			expectedErr: config.MissingRequiredValueError{},
+		},
+		{
+			name:        "failed validation",
+			values:      `environment = "stage"`,
+			expectedErr: config.InvalidValueError{},
+		},
+		{
+			name:        "wrong type",
+			values:      `environment = "dev"` + "\n" + `replicas = "many"`,
+			expectedErr: config.InvalidValueError{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			configPath := filepath.Join(dir, config.DefaultTerragruntConfigPath)
+
+			require.NoError(t, os.WriteFile(configPath, []byte(valueBlocksTestConfig), 0644))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "terragrunt.values.hcl"), []byte(tc.values), 0644))
+
+			l := createLogger()
+			ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, configPath))
+
+			cfg, err := config.ParseConfigFile(ctx, l, configPath, nil)
+
+			switch expected := tc.expectedErr.(type) {
+			case config.MissingRequiredValueError:
+				require.Error(t, err)
+				assert.True(t, errors.As(err, &expected), "unexpected error: %v", err)
+			case config.InvalidValueError:
+				require.Error(t, err)
+				assert.True(t, errors.As(err, &expected), "unexpected error: %v", err)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tc.expectedInputs, cfg.Inputs)
+			}
+		})
+	}
+}
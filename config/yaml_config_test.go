@@ -0,0 +1,84 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+)
+
+func TestParseYAMLConfig(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	unitDir := filepath.Join(rootDir, "app")
+	configPath := filepath.Join(unitDir, config.DefaultTerragruntYAMLConfigPath)
+
+	require.NoError(t, os.MkdirAll(unitDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(rootDir, "root.hcl"), []byte(`
+inputs = {
+  team = "platform"
+}
+`), 0644))
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+include:
+  root:
+    path: ${find_in_parent_folders("root.hcl")}
+locals:
+  region: us-east-1
+terraform:
+  source: ../modules/app
+generate:
+  provider:
+    path: provider.tf
+    if_exists: overwrite
+    contents: |
+      provider "aws" {
+        region = "${local.region}"
+      }
+inputs:
+  name: ${basename(get_terragrunt_dir())}
+  replicas: 3
+  zones:
+    - ${local.region}a
+    - ${local.region}b
+`), 0644))
+
+	assert.Equal(t, configPath, config.GetDefaultConfigPath(unitDir))
+
+	l := createLogger()
+	ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, configPath))
+
+	cfg, err := config.ParseConfigFile(ctx, l, configPath, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "../modules/app", *cfg.Terraform.Source)
+	assert.Equal(t, "provider \"aws\" {\n  region = \"us-east-1\"\n}\n", cfg.GenerateConfigs["provider"].Contents)
+	assert.Equal(t, map[string]any{
+		"team":     "platform",
+		"name":     "app",
+		"replicas": float64(3),
+		"zones":    []any{"us-east-1a", "us-east-1b"},
+	}, cfg.Inputs)
+}
+
+func TestParseYAMLConfigTemplateDirective(t *testing.T) {
+	t.Parallel()
+
+	configPath := filepath.Join(t.TempDir(), config.DefaultTerragruntYAMLConfigPath)
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+inputs:
+  name: "%{ if true }app%{ endif }"
+`), 0644))
+
+	l := createLogger()
+	ctx := config.NewParsingContext(t.Context(), l, mockOptionsForTestWithConfigPath(t, configPath))
+
+	_, err := config.ParseConfigFile(ctx, l, configPath, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Unsupported template directive")
+}
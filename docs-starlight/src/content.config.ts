@@ -24,6 +24,7 @@ const commands = defineCollection({
 		category: z.enum([
 			"main",
 			"backend",
+			"runs",
 			"stack",
 			"catalog",
 			"discovery",
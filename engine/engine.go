@@ -13,6 +13,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
 
@@ -52,6 +53,7 @@ const (
 	terraformCommandContextKey engineClientsKey = iota
 	locksContextKey            engineLocksKey   = iota
 	latestVersionsContextKey   engineLocksKey   = iota
+	remoteExecutorsContextKey  engineClientsKey = iota
 )
 
 type engineClientsKey byte
@@ -68,6 +70,41 @@ type ExecutionOptions struct {
 	AllocatePseudoTty bool
 }
 
+// meta returns the metadata sent along with the command, of the remote executor running it, if any, or of the engine.
+func (runOptions *ExecutionOptions) meta() map[string]any {
+	opts := runOptions.TerragruntOptions
+
+	if opts.Executor != nil {
+		return opts.Executor.Meta
+	}
+
+	if opts.Engine != nil {
+		return opts.Engine.Meta
+	}
+
+	return nil
+}
+
+// envVars returns the env vars sent along with the command. A remote executor only gets the `TF_*` env vars and the
+// ones it forwards, the credentials of the environment being held by the runner agent.
+func (runOptions *ExecutionOptions) envVars() map[string]string {
+	opts := runOptions.TerragruntOptions
+
+	if opts.Executor == nil {
+		return opts.Env
+	}
+
+	envVars := make(map[string]string)
+
+	for key, value := range opts.Env {
+		if strings.HasPrefix(key, remoteExecutorEnvPrefix) || slices.Contains(opts.Executor.ForwardEnv, key) {
+			envVars[key] = value
+		}
+	}
+
+	return envVars
+}
+
 type engineInstance struct {
 	terragruntEngine *proto.EngineClient
 	client           *plugin.Client
@@ -132,6 +169,7 @@ func WithEngineValues(ctx context.Context) context.Context {
 	ctx = context.WithValue(ctx, terraformCommandContextKey, &sync.Map{})
 	ctx = context.WithValue(ctx, locksContextKey, util.NewKeyLocks())
 	ctx = context.WithValue(ctx, latestVersionsContextKey, cache.NewCache[string]("engineVersions"))
+	ctx = context.WithValue(ctx, remoteExecutorsContextKey, newRemoteExecutors())
 
 	return ctx
 }
@@ -453,8 +491,12 @@ func engineVersionsCacheFromContext(ctx context.Context) (*cache.Cache[string],
 	return result, nil
 }
 
-// Shutdown shuts down the experimental engine.
+// Shutdown shuts down the experimental engine, and the remote executors.
 func Shutdown(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) error {
+	if err := shutdownRemoteExecutors(ctx, l); err != nil {
+		return err
+	}
+
 	if !opts.EngineEnabled {
 		return nil
 	}
@@ -562,7 +604,7 @@ func createEngine(l log.Logger, terragruntOptions *options.TerragruntOptions) (*
 func invoke(ctx context.Context, l log.Logger, runOptions *ExecutionOptions, client *proto.EngineClient) (*util.CmdOutput, error) {
 	opts := runOptions.TerragruntOptions
 
-	meta, err := ConvertMetaToProtobuf(runOptions.TerragruntOptions.Engine.Meta)
+	meta, err := ConvertMetaToProtobuf(runOptions.meta())
 	if err != nil {
 		return nil, errors.New(err)
 	}
@@ -573,7 +615,7 @@ func invoke(ctx context.Context, l log.Logger, runOptions *ExecutionOptions, cli
 		AllocatePseudoTty: runOptions.AllocatePseudoTty,
 		WorkingDir:        runOptions.WorkingDir,
 		Meta:              meta,
-		EnvVars:           runOptions.TerragruntOptions.Env,
+		EnvVars:           runOptions.envVars(),
 	})
 	if err != nil {
 		return nil, errors.New(err)
@@ -666,7 +708,7 @@ var ErrEngineInitFailed = errors.New("engine init failed")
 
 // initialize engine for working directory
 func initialize(ctx context.Context, l log.Logger, runOptions *ExecutionOptions, client *proto.EngineClient) error {
-	meta, err := ConvertMetaToProtobuf(runOptions.TerragruntOptions.Engine.Meta)
+	meta, err := ConvertMetaToProtobuf(runOptions.meta())
 	if err != nil {
 		return errors.New(err)
 	}
@@ -674,7 +716,7 @@ func initialize(ctx context.Context, l log.Logger, runOptions *ExecutionOptions,
 	l.Debugf("Running init for engine in %s", runOptions.WorkingDir)
 
 	request, err := (*client).Init(ctx, &proto.InitRequest{
-		EnvVars:    runOptions.TerragruntOptions.Env,
+		EnvVars:    runOptions.envVars(),
 		WorkingDir: runOptions.WorkingDir,
 		Meta:       meta,
 	})
@@ -711,7 +753,7 @@ var ErrEngineShutdownFailed = errors.New("engine shutdown failed")
 
 // shutdown engine for working directory
 func shutdown(ctx context.Context, l log.Logger, runOptions *ExecutionOptions, terragruntEngine *proto.EngineClient) error {
-	meta, err := ConvertMetaToProtobuf(runOptions.TerragruntOptions.Engine.Meta)
+	meta, err := ConvertMetaToProtobuf(runOptions.meta())
 	if err != nil {
 		return errors.New(err)
 	}
@@ -719,7 +761,7 @@ func shutdown(ctx context.Context, l log.Logger, runOptions *ExecutionOptions, t
 	request, err := (*terragruntEngine).Shutdown(ctx, &proto.ShutdownRequest{
 		WorkingDir: runOptions.WorkingDir,
 		Meta:       meta,
-		EnvVars:    runOptions.TerragruntOptions.Env,
+		EnvVars:    runOptions.envVars(),
 	})
 
 	if err != nil {
@@ -0,0 +1,235 @@
+package engine
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/gruntwork-io/terragrunt-engine-go/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// remoteExecutorEnvPrefix is the prefix of the env vars always sent to the remote executors, e.g. the inputs of the
+// unit, passed as `TF_VAR_*` env vars.
+const remoteExecutorEnvPrefix = "TF_"
+
+// remoteExecutorProbeDirPattern is the pattern of the dir created in the working dir of the unit to check that it is
+// shared with the runner agent.
+const remoteExecutorProbeDirPattern = ".terragrunt-executor-probe-*"
+
+// ErrRemoteExecutorWorkingDirNotShared is returned when the runner agent of the remote executor can't see the working
+// dir of the unit.
+var ErrRemoteExecutorWorkingDirNotShared = errors.New("the working dir isn't shared with the runner agent")
+
+// remoteExecutors are the connections to the runner agents of the remote executors, by address, and the working dirs
+// initialized on them. The initialization of each working dir is serialized with initLocks, so that it is initialized
+// once, before any command of the unit is dispatched to it.
+type remoteExecutors struct {
+	conns     map[string]*grpc.ClientConn
+	instances map[string]*remoteExecutorInstance
+	initLocks *util.KeyLocks
+	mu        sync.Mutex
+}
+
+type remoteExecutorInstance struct {
+	terragruntEngine proto.EngineClient
+	executionOptions *ExecutionOptions
+}
+
+func newRemoteExecutors() *remoteExecutors {
+	return &remoteExecutors{
+		conns:     make(map[string]*grpc.ClientConn),
+		instances: make(map[string]*remoteExecutorInstance),
+		initLocks: util.NewKeyLocks(),
+	}
+}
+
+// RunRemote executes the given command on the runner agent of the remote executor of the unit, which serves the gRPC
+// protocol of the engines, the output of the command being streamed back. The working dir is initialized on the agent
+// the first time a command of the unit is dispatched to it.
+func RunRemote(
+	ctx context.Context,
+	l log.Logger,
+	runOptions *ExecutionOptions,
+) (*util.CmdOutput, error) {
+	executors, err := remoteExecutorsFromContext(ctx)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	instance, err := executors.instance(ctx, l, runOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := invoke(ctx, l, runOptions, &instance.terragruntEngine)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return output, nil
+}
+
+// instance returns the instance of the working dir of the given options, initializing it on the runner agent of its
+// executor if it isn't yet. The instance is only cached once initialized, so a failed initialization is retried by the
+// next command of the unit.
+func (executors *remoteExecutors) instance(ctx context.Context, l log.Logger, runOptions *ExecutionOptions) (*remoteExecutorInstance, error) {
+	workingDir := runOptions.TerragruntOptions.WorkingDir
+
+	executors.initLocks.Lock(workingDir)
+	defer executors.initLocks.Unlock(workingDir)
+
+	executors.mu.Lock()
+	instance, found := executors.instances[workingDir]
+	executors.mu.Unlock()
+
+	if found {
+		return instance, nil
+	}
+
+	executors.mu.Lock()
+	conn, err := executors.dial(l, runOptions.TerragruntOptions.Executor)
+	executors.mu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	instance = &remoteExecutorInstance{
+		terragruntEngine: proto.NewEngineClient(conn),
+		executionOptions: runOptions,
+	}
+
+	if err := initialize(ctx, l, runOptions, &instance.terragruntEngine); err != nil {
+		return nil, errors.New(err)
+	}
+
+	if err := checkSharedWorkingDir(ctx, l, runOptions, &instance.terragruntEngine); err != nil {
+		return nil, err
+	}
+
+	executors.mu.Lock()
+	executors.instances[workingDir] = instance
+	executors.mu.Unlock()
+
+	return instance, nil
+}
+
+// checkSharedWorkingDir checks that the runner agent sees the working dir of the unit, as it runs the commands in it at
+// the same path: a probe dir is created in the working dir, and the agent is asked to change into it before printing
+// the version of the binary, which fails if the working dir isn't shared with it.
+func checkSharedWorkingDir(ctx context.Context, l log.Logger, runOptions *ExecutionOptions, client *proto.EngineClient) error {
+	opts := runOptions.TerragruntOptions
+
+	probeDir, err := os.MkdirTemp(runOptions.WorkingDir, remoteExecutorProbeDirPattern)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	defer func() {
+		if err := os.RemoveAll(probeDir); err != nil {
+			l.Warnf("Failed to remove the probe dir %s: %v", probeDir, err)
+		}
+	}()
+
+	l.Debugf("Checking that the working dir %s is shared with the remote executor %s", runOptions.WorkingDir, opts.Executor.Address)
+
+	probeOptions := *runOptions
+	probeOptions.CmdStdout = io.Discard
+	probeOptions.CmdStderr = io.Discard
+	probeOptions.Args = []string{"-chdir=" + filepath.Base(probeDir), "version"}
+
+	if _, err := invoke(ctx, l, &probeOptions, client); err != nil {
+		l.Debugf("The probe of the working dir %s failed: %v", runOptions.WorkingDir, err)
+
+		return errors.Errorf(
+			"%w: the runner agent of the remote executor %s can't see the working dir %s, which must be shared with it at the same path, e.g. with a volume mounted on both",
+			ErrRemoteExecutorWorkingDirNotShared, opts.Executor.Address, runOptions.WorkingDir,
+		)
+	}
+
+	return nil
+}
+
+// dial returns the connection to the runner agent of the given executor, shared by all the units dispatched to it.
+// The connection is secured with TLS, unless the executor is insecure.
+func (executors *remoteExecutors) dial(l log.Logger, executor *options.ExecutorOptions) (*grpc.ClientConn, error) {
+	if conn, ok := executors.conns[executor.Address]; ok {
+		return conn, nil
+	}
+
+	creds := credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	if executor.Insecure {
+		l.Warnf("Connecting to the remote executor %s without TLS", executor.Address)
+
+		creds = insecure.NewCredentials()
+	}
+
+	l.Debugf("Connecting to the remote executor %s", executor.Address)
+
+	conn, err := grpc.NewClient(executor.Address, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, errors.Errorf("failed to connect to the remote executor %s: %w", executor.Address, err)
+	}
+
+	executors.conns[executor.Address] = conn
+
+	return conn, nil
+}
+
+// shutdownRemoteExecutors shuts down the working dirs initialized on the runner agents, and closes the connections to
+// them.
+func shutdownRemoteExecutors(ctx context.Context, l log.Logger) error {
+	executors, err := remoteExecutorsFromContext(ctx)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	executors.mu.Lock()
+	defer executors.mu.Unlock()
+
+	for workingDir, instance := range executors.instances {
+		l.Debugf("Shutting down remote executor for %s", instance.executionOptions.WorkingDir)
+
+		if err := shutdown(ctx, l, instance.executionOptions, &instance.terragruntEngine); err != nil {
+			l.Errorf("Error shutting down remote executor: %v", err)
+		}
+
+		delete(executors.instances, workingDir)
+	}
+
+	for address, conn := range executors.conns {
+		if err := conn.Close(); err != nil {
+			l.Warnf("Failed to close the connection to the remote executor %s: %v", address, err)
+		}
+
+		delete(executors.conns, address)
+	}
+
+	return nil
+}
+
+// remoteExecutorsFromContext returns the remote executors from the context.
+func remoteExecutorsFromContext(ctx context.Context) (*remoteExecutors, error) {
+	val := ctx.Value(remoteExecutorsContextKey)
+	if val == nil {
+		return nil, errors.New("failed to fetch remote executors from context")
+	}
+
+	result, ok := val.(*remoteExecutors)
+	if !ok {
+		return nil, errors.New("failed to cast remote executors from context")
+	}
+
+	return result, nil
+}
@@ -0,0 +1,277 @@
+package engine_test
+
+import (
+	"bytes"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt-engine-go/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/gruntwork-io/terragrunt/engine"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+)
+
+// fakeRunnerAgent is a runner agent recording the requests it gets, and streaming the output of the commands back.
+type fakeRunnerAgent struct {
+	proto.UnimplementedEngineServer
+
+	inits     []string
+	runs      []*proto.RunRequest
+	probes    []string
+	shutdowns []string
+	// failedInits is the number of the next inits to fail.
+	failedInits int
+	// isolated makes the agent run on its own filesystem, without the working dirs of the units.
+	isolated bool
+	mu       sync.Mutex
+}
+
+func (agent *fakeRunnerAgent) Init(req *proto.InitRequest, stream grpc.ServerStreamingServer[proto.InitResponse]) error {
+	agent.mu.Lock()
+	agent.inits = append(agent.inits, req.GetWorkingDir())
+
+	if agent.failedInits > 0 {
+		agent.failedInits--
+		agent.mu.Unlock()
+
+		return stream.Send(&proto.InitResponse{Stderr: "init failed\n", ResultCode: 1})
+	}
+
+	agent.mu.Unlock()
+
+	return stream.Send(&proto.InitResponse{Stdout: "initialized\n"})
+}
+
+func (agent *fakeRunnerAgent) Run(req *proto.RunRequest, stream grpc.ServerStreamingServer[proto.RunResponse]) error {
+	if dir, ok := strings.CutPrefix(req.GetArgs()[0], "-chdir="); ok {
+		dir = filepath.Join(req.GetWorkingDir(), dir)
+
+		agent.mu.Lock()
+		agent.probes = append(agent.probes, dir)
+		agent.mu.Unlock()
+
+		if _, err := os.Stat(dir); err != nil || agent.isolated {
+			return stream.Send(&proto.RunResponse{Stderr: "Error handling -chdir option: no such file or directory\n", ResultCode: 1})
+		}
+
+		return stream.Send(&proto.RunResponse{Stdout: "OpenTofu v1.9.0\n"})
+	}
+
+	agent.mu.Lock()
+	agent.runs = append(agent.runs, req)
+	agent.mu.Unlock()
+
+	if err := stream.Send(&proto.RunResponse{Stdout: "No changes. "}); err != nil {
+		return err
+	}
+
+	return stream.Send(&proto.RunResponse{Stdout: "Your infrastructure matches the configuration.\n"})
+}
+
+func (agent *fakeRunnerAgent) Shutdown(req *proto.ShutdownRequest, stream grpc.ServerStreamingServer[proto.ShutdownResponse]) error {
+	agent.mu.Lock()
+	agent.shutdowns = append(agent.shutdowns, req.GetWorkingDir())
+	agent.mu.Unlock()
+
+	return stream.Send(&proto.ShutdownResponse{})
+}
+
+// serveRunnerAgent serves the given runner agent for the duration of the test, and returns its address.
+func serveRunnerAgent(t *testing.T, agent *fakeRunnerAgent) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	proto.RegisterEngineServer(server, agent)
+
+	go server.Serve(listener) //nolint:errcheck
+
+	t.Cleanup(server.Stop)
+
+	return listener.Addr().String()
+}
+
+func TestRunRemote(t *testing.T) {
+	t.Parallel()
+
+	agent := &fakeRunnerAgent{}
+	address := serveRunnerAgent(t, agent)
+
+	ctx := engine.WithEngineValues(t.Context())
+	l := logger.CreateLogger()
+
+	workingDir := t.TempDir()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(workingDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	opts.WorkingDir = workingDir
+	opts.Env = map[string]string{
+		"TF_VAR_cidr":           "10.0.0.0/16",
+		"AWS_REGION":            "eu-west-1",
+		"AWS_SECRET_ACCESS_KEY": "secret",
+	}
+	opts.Executor = &options.ExecutorOptions{
+		Address:    address,
+		Insecure:   true,
+		ForwardEnv: []string{"AWS_REGION"},
+		Meta:       map[string]any{"environment": "prod"},
+	}
+
+	for range 2 {
+		var stdout, stderr bytes.Buffer
+
+		output, err := engine.RunRemote(ctx, l, &engine.ExecutionOptions{
+			TerragruntOptions: opts,
+			CmdStdout:         &stdout,
+			CmdStderr:         &stderr,
+			WorkingDir:        opts.WorkingDir,
+			Command:           "tofu",
+			Args:              []string{"plan"},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "No changes. Your infrastructure matches the configuration.\n", stdout.String())
+		assert.Equal(t, stdout.String(), output.Stdout.String())
+	}
+
+	require.NoError(t, engine.Shutdown(ctx, l, opts))
+
+	agent.mu.Lock()
+	defer agent.mu.Unlock()
+
+	// The working dir is initialized and checked once, and shut down at the end of the run.
+	assert.Equal(t, []string{workingDir}, agent.inits)
+	assert.Equal(t, []string{workingDir}, agent.shutdowns)
+	require.Len(t, agent.probes, 1)
+	assert.NoDirExists(t, agent.probes[0])
+
+	require.Len(t, agent.runs, 2)
+	assert.Equal(t, []string{"plan"}, agent.runs[0].GetArgs())
+	assert.Contains(t, agent.runs[0].GetMeta(), "environment")
+
+	// The credentials of the environment stay on the agent.
+	assert.Equal(t, map[string]string{
+		"TF_VAR_cidr": "10.0.0.0/16",
+		"AWS_REGION":  "eu-west-1",
+	}, agent.runs[0].GetEnvVars())
+}
+
+func TestRunRemoteInitializesOnce(t *testing.T) {
+	t.Parallel()
+
+	agent := &fakeRunnerAgent{failedInits: 1}
+	address := serveRunnerAgent(t, agent)
+
+	ctx := engine.WithEngineValues(t.Context())
+	l := logger.CreateLogger()
+
+	workingDir := t.TempDir()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(workingDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	opts.WorkingDir = workingDir
+	opts.Executor = &options.ExecutorOptions{Address: address, Insecure: true}
+
+	runRemote := func() error {
+		var stdout, stderr bytes.Buffer
+
+		_, err := engine.RunRemote(ctx, l, &engine.ExecutionOptions{
+			TerragruntOptions: opts,
+			CmdStdout:         &stdout,
+			CmdStderr:         &stderr,
+			WorkingDir:        opts.WorkingDir,
+			Command:           "tofu",
+			Args:              []string{"plan"},
+		})
+
+		return err
+	}
+
+	// The failed initialization isn't cached, so the next command initializes the working dir again.
+	require.Error(t, runRemote())
+
+	// The concurrent commands of the unit wait for the working dir to be initialized once.
+	var wg sync.WaitGroup
+
+	errs := make([]error, 4)
+
+	for i := range errs {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			errs[i] = runRemote()
+		}()
+	}
+
+	wg.Wait()
+
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, engine.Shutdown(ctx, l, opts))
+
+	agent.mu.Lock()
+	defer agent.mu.Unlock()
+
+	assert.Equal(t, []string{workingDir, workingDir}, agent.inits)
+	assert.Len(t, agent.runs, len(errs))
+}
+
+func TestRunRemoteWorkingDirNotShared(t *testing.T) {
+	t.Parallel()
+
+	agent := &fakeRunnerAgent{isolated: true}
+	address := serveRunnerAgent(t, agent)
+
+	ctx := engine.WithEngineValues(t.Context())
+	l := logger.CreateLogger()
+
+	workingDir := t.TempDir()
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(workingDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	opts.WorkingDir = workingDir
+	opts.Executor = &options.ExecutorOptions{Address: address, Insecure: true}
+
+	var stdout, stderr bytes.Buffer
+
+	_, err = engine.RunRemote(ctx, l, &engine.ExecutionOptions{
+		TerragruntOptions: opts,
+		CmdStdout:         &stdout,
+		CmdStderr:         &stderr,
+		WorkingDir:        opts.WorkingDir,
+		Command:           "tofu",
+		Args:              []string{"plan"},
+	})
+	require.ErrorIs(t, err, engine.ErrRemoteExecutorWorkingDirNotShared)
+	assert.Contains(t, err.Error(), workingDir)
+
+	require.NoError(t, engine.Shutdown(ctx, l, opts))
+
+	agent.mu.Lock()
+	defer agent.mu.Unlock()
+
+	// No command of the unit is dispatched to the agent, and the probe output isn't streamed to the user.
+	assert.Empty(t, agent.runs)
+	assert.NotContains(t, stderr.String(), "-chdir")
+
+	entries, err := os.ReadDir(workingDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
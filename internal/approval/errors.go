@@ -0,0 +1,57 @@
+package approval
+
+import (
+	"fmt"
+	"time"
+)
+
+// UnsupportedGateError is returned when the approval gate address has an unknown scheme.
+type UnsupportedGateError struct {
+	Address string
+}
+
+func (err UnsupportedGateError) Error() string {
+	return fmt.Sprintf("unsupported approval gate %q, expected an http(s):// or a github://<owner>/<repo>/pull/<number> address", err.Address)
+}
+
+// RejectedError is returned when the run is rejected by the approval gate.
+type RejectedError struct {
+	Reason string
+}
+
+func (err RejectedError) Error() string {
+	if err.Reason == "" {
+		return "the run was rejected by the approval gate"
+	}
+
+	return "the run was rejected by the approval gate: " + err.Reason
+}
+
+// TimeoutError is returned when the approval gate doesn't decide on the run within the timeout.
+type TimeoutError struct {
+	Timeout time.Duration
+}
+
+func (err TimeoutError) Error() string {
+	return fmt.Sprintf("the run was not approved by the approval gate within %s", err.Timeout)
+}
+
+// InvalidResponseError is returned when the approval gate responds with an unexpected content.
+type InvalidResponseError struct {
+	URL     string
+	Message string
+}
+
+func (err InvalidResponseError) Error() string {
+	return fmt.Sprintf("invalid response of approval gate %s: %s", err.URL, err.Message)
+}
+
+// UnexpectedStatusError is returned when the approval gate responds with a non 2xx status.
+type UnexpectedStatusError struct {
+	URL        string
+	StatusCode int
+}
+
+func (err UnexpectedStatusError) Error() string {
+	return fmt.Sprintf("approval gate %s responded with status %d", err.URL, err.StatusCode)
+}
@@ -0,0 +1,184 @@
+// Package approval provides external approval gates for `run --all apply`.
+//
+// A gate is addressed by a single string, its scheme selecting the backend:
+//
+//   - `http://...` and `https://...` post the summary of the run to a webhook, and poll it until it decides.
+//   - `github://<owner>/<repo>/pull/<number>` comments the summary of the run on the pull request, and waits for a
+//     `/terragrunt approve` or `/terragrunt reject` comment of a maintainer of the repository.
+//
+// An approved run is identified by the approval token the gate returns, e.g. the ID of the change request approving it.
+package approval
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+const (
+	// DefaultPollInterval is the interval at which gates poll for the decision on the run.
+	DefaultPollInterval = 10 * time.Second
+
+	schemeHTTP   = "http"
+	schemeHTTPS  = "https"
+	schemeGitHub = "github"
+)
+
+// Summary is the summary of the run submitted for approval.
+type Summary struct {
+	Command    string `json:"command"`
+	WorkingDir string `json:"working_dir"`
+	// Groups are the paths of the units, relative to the working dir, in the groups they run in.
+	Groups [][]string `json:"groups"`
+}
+
+// Markdown returns the summary formatted in Markdown, the units of each group in a list.
+func (summary *Summary) Markdown() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "Terragrunt requests the approval of `run --all %s` in `%s`, running the following units:\n", summary.Command, summary.WorkingDir)
+
+	for i, group := range summary.Groups {
+		fmt.Fprintf(&sb, "\n**Group %d**\n", i+1)
+
+		for _, unit := range group {
+			fmt.Fprintf(&sb, "- `%s`\n", unit)
+		}
+	}
+
+	return sb.String()
+}
+
+// Gate requests the approval of a run from an external system.
+type Gate interface {
+	// Await submits the summary of the run for approval, and blocks until the run is approved, returning the approval
+	// token, or rejected, or the context is done.
+	Await(ctx context.Context, summary *Summary) (string, error)
+}
+
+// NewGate returns the gate matching the scheme of the given address, authenticated with the given token. The GitHub
+// gate falls back to the GITHUB_TOKEN variable of the given env, and its API URL is overridden by GITHUB_API_URL.
+func NewGate(address, token string, env map[string]string) (Gate, error) {
+	parsed, err := url.Parse(address)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	switch parsed.Scheme {
+	case schemeHTTP, schemeHTTPS:
+		return &WebhookGate{URL: address, Token: token}, nil
+	case schemeGitHub:
+		parts := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+		if parsed.Host == "" || len(parts) != 3 || parts[1] != "pull" {
+			break
+		}
+
+		number, err := strconv.Atoi(parts[2])
+		if err != nil {
+			break
+		}
+
+		if token == "" {
+			token = env["GITHUB_TOKEN"]
+		}
+
+		return &GitHubGate{Owner: parsed.Host, Repo: parts[0], Number: number, Token: token, BaseURL: env["GITHUB_API_URL"]}, nil
+	}
+
+	return nil, errors.New(UnsupportedGateError{Address: address})
+}
+
+// Await submits the summary of the run to the given gate, and waits for its approval at most the given timeout,
+// returning the approval token.
+func Await(ctx context.Context, gate Gate, summary *Summary, timeout time.Duration) (string, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	token, err := gate.Await(ctx, summary)
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return "", errors.New(TimeoutError{Timeout: timeout})
+	}
+
+	return token, err
+}
+
+// wait waits for the given interval, or returns the error of the context if it is done first.
+func wait(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+
+	timer := time.NewTimer(interval)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return errors.New(ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
+
+// doJSON sends a request with the given JSON body, if any, and decodes the JSON response into the given value.
+func doJSON(ctx context.Context, method, url string, header http.Header, body, out any) error {
+	var reader io.Reader
+
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return errors.New(err)
+		}
+
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	for name, values := range header {
+		req.Header[name] = values
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return errors.New(UnexpectedStatusError{URL: url, StatusCode: resp.StatusCode})
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if err := json.Unmarshal(data, out); err != nil {
+		return errors.New(InvalidResponseError{URL: url, Message: err.Error()})
+	}
+
+	return nil
+}
@@ -0,0 +1,238 @@
+package approval_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/internal/approval"
+)
+
+var testSummary = &approval.Summary{
+	Command:    "apply",
+	WorkingDir: "/live/prod",
+	Groups:     [][]string{{"vpc"}, {"app", "db"}},
+}
+
+func TestNewGate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		expected approval.Gate
+		name     string
+		address  string
+	}{
+		{name: "webhook", address: "https://approvals.example.com/runs", expected: &approval.WebhookGate{URL: "https://approvals.example.com/runs", Token: "secret"}},
+		{name: "github", address: "github://acme/infra/pull/42", expected: &approval.GitHubGate{Owner: "acme", Repo: "infra", Number: 42, Token: "secret"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			gate, err := approval.NewGate(tc.address, "secret", nil)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, gate)
+		})
+	}
+
+	for _, address := range []string{"ftp://approvals", "github://acme/infra/issues/42", "github://acme/infra/pull/latest"} {
+		_, err := approval.NewGate(address, "secret", nil)
+		require.Error(t, err, address)
+	}
+}
+
+func TestWebhookGate(t *testing.T) {
+	t.Parallel()
+
+	var polls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodPost:
+			var summary approval.Summary
+			if err := json.NewDecoder(r.Body).Decode(&summary); err != nil || summary.Command != "apply" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"status": "pending", "poll_url": "` + "http://" + r.Host + `/runs/42"}`))
+		case http.MethodGet:
+			if r.URL.Path != "/runs/42" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			if polls.Add(1) < 2 {
+				_, _ = w.Write([]byte(`{"status": "pending"}`))
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"status": "approved", "token": "CHG0042"}`))
+		}
+	}))
+	defer server.Close()
+
+	gate := &approval.WebhookGate{URL: server.URL, Token: "secret", PollInterval: time.Millisecond}
+
+	token, err := approval.Await(t.Context(), gate, testSummary, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "CHG0042", token)
+	assert.Equal(t, int32(2), polls.Load())
+}
+
+func TestNewGateGitHubEnv(t *testing.T) {
+	t.Parallel()
+
+	gate, err := approval.NewGate("github://acme/infra/pull/42", "", map[string]string{
+		"GITHUB_TOKEN":   "env-secret",
+		"GITHUB_API_URL": "https://github.example.com/api/v3",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &approval.GitHubGate{
+		Owner:   "acme",
+		Repo:    "infra",
+		Number:  42,
+		Token:   "env-secret",
+		BaseURL: "https://github.example.com/api/v3",
+	}, gate)
+}
+
+func TestWebhookGatePollURLOtherOrigin(t *testing.T) {
+	t.Parallel()
+
+	var pollAuthorization atomic.Value
+
+	pollServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pollAuthorization.Store(r.Header.Get("Authorization"))
+
+		_, _ = w.Write([]byte(`{"status": "approved", "token": "CHG0042"}`))
+	}))
+	defer pollServer.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"status": "pending", "poll_url": "` + pollServer.URL + `/runs/42"}`))
+	}))
+	defer server.Close()
+
+	gate := &approval.WebhookGate{URL: server.URL, Token: "secret", PollInterval: time.Millisecond}
+
+	token, err := approval.Await(t.Context(), gate, testSummary, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, "CHG0042", token)
+
+	// The token isn't sent to a poll URL of another origin.
+	assert.Empty(t, pollAuthorization.Load())
+}
+
+func TestWebhookGateRejected(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"status": "rejected", "reason": "change freeze"}`))
+	}))
+	defer server.Close()
+
+	_, err := approval.Await(t.Context(), &approval.WebhookGate{URL: server.URL}, testSummary, time.Minute)
+
+	var rejectedErr approval.RejectedError
+	require.ErrorAs(t, err, &rejectedErr)
+	assert.Equal(t, "change freeze", rejectedErr.Reason)
+}
+
+func TestWebhookGateTimeout(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"status": "pending"}`))
+	}))
+	defer server.Close()
+
+	gate := &approval.WebhookGate{URL: server.URL, PollInterval: time.Millisecond}
+
+	_, err := approval.Await(t.Context(), gate, testSummary, 50*time.Millisecond)
+
+	var timeoutErr approval.TimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	assert.Equal(t, 50*time.Millisecond, timeoutErr.Timeout)
+}
+
+func TestGitHubGate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		comments string
+		token    string
+		reason   string
+	}{
+		{
+			name: "approved",
+			comments: `[
+  {"id": 1, "body": "/terragrunt approve", "author_association": "MEMBER", "html_url": "https://github.com/acme/infra/pull/42#issuecomment-1"},
+  {"id": 100, "body": "Terragrunt requests the approval", "author_association": "NONE"},
+  {"id": 101, "body": "/terragrunt approve", "author_association": "NONE", "html_url": "https://github.com/acme/infra/pull/42#issuecomment-101"},
+  {"id": 102, "body": " /terragrunt approve\n", "author_association": "COLLABORATOR", "html_url": "https://github.com/acme/infra/pull/42#issuecomment-102"}
+]`,
+			token: "https://github.com/acme/infra/pull/42#issuecomment-102",
+		},
+		{
+			name: "rejected",
+			comments: `[
+  {"id": 101, "body": "/terragrunt reject\nThe database is being migrated.", "author_association": "OWNER", "user": {"login": "octocat"}}
+]`,
+			reason: "rejected by octocat: The database is being migrated.",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/repos/acme/infra/issues/42/comments" || r.Header.Get("Authorization") != "Bearer secret" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+
+				if r.Method == http.MethodPost {
+					_, _ = w.Write([]byte(`{"id": 100, "created_at": "2026-10-14T10:00:00Z"}`))
+					return
+				}
+
+				if r.URL.Query().Get("since") != "2026-10-14T10:00:00Z" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+
+				_, _ = w.Write([]byte(tc.comments))
+			}))
+			defer server.Close()
+
+			gate := &approval.GitHubGate{Owner: "acme", Repo: "infra", Number: 42, Token: "secret", BaseURL: server.URL, PollInterval: time.Millisecond}
+
+			token, err := approval.Await(t.Context(), gate, testSummary, time.Minute)
+			if tc.reason == "" {
+				require.NoError(t, err)
+				assert.Equal(t, tc.token, token)
+
+				return
+			}
+
+			var rejectedErr approval.RejectedError
+			require.ErrorAs(t, err, &rejectedErr)
+			assert.Equal(t, tc.reason, rejectedErr.Reason)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package approval
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+const (
+	// GitHubAPIURL is the base URL of the GitHub REST API, overridden by the GITHUB_API_URL env var, e.g. for GitHub
+	// Enterprise Server.
+	GitHubAPIURL = "https://api.github.com"
+
+	// ApproveCommand is the comment approving a run on a pull request.
+	ApproveCommand = "/terragrunt approve"
+	// RejectCommand is the comment rejecting a run on a pull request.
+	RejectCommand = "/terragrunt reject"
+)
+
+// approverAssociations are the associations with the repository of the authors whose comments decide on a run.
+var approverAssociations = []string{"OWNER", "MEMBER", "COLLABORATOR"}
+
+// GitHubGate comments the summary of the run on a pull request, and waits for an ApproveCommand or a RejectCommand
+// comment posted after it by an owner, a member or a collaborator of the repository. The approval token is the URL of
+// the approving comment.
+type GitHubGate struct {
+	Owner  string
+	Repo   string
+	Token  string
+	Number int
+	// BaseURL overrides GitHubAPIURL, e.g. for GitHub Enterprise Server.
+	BaseURL string
+	// PollInterval overrides DefaultPollInterval, mostly used for testing.
+	PollInterval time.Duration
+}
+
+type gitHubComment struct {
+	CreatedAt time.Time `json:"created_at"`
+	User      struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	Body              string `json:"body"`
+	HTMLURL           string `json:"html_url"`
+	AuthorAssociation string `json:"author_association"`
+	ID                int64  `json:"id"`
+}
+
+// Await implements Gate.
+func (gate *GitHubGate) Await(ctx context.Context, summary *Summary) (string, error) {
+	baseURL := gate.BaseURL
+	if baseURL == "" {
+		baseURL = GitHubAPIURL
+	}
+
+	commentsURL := fmt.Sprintf("%s/repos/%s/%s/issues/%d/comments", strings.TrimSuffix(baseURL, "/"), gate.Owner, gate.Repo, gate.Number)
+
+	header := http.Header{}
+
+	if gate.Token != "" {
+		header.Set("Authorization", "Bearer "+gate.Token)
+	}
+
+	body := map[string]string{
+		"body": fmt.Sprintf("%s\nComment `%s` to approve the run, or `%s` to reject it.", summary.Markdown(), ApproveCommand, RejectCommand),
+	}
+
+	var request gitHubComment
+
+	if err := doJSON(ctx, http.MethodPost, commentsURL, header, body, &request); err != nil {
+		return "", err
+	}
+
+	query := url.Values{}
+	query.Set("since", request.CreatedAt.Format(time.RFC3339))
+	query.Set("per_page", "100")
+
+	pollURL := commentsURL + "?" + query.Encode()
+
+	for {
+		if err := wait(ctx, gate.PollInterval); err != nil {
+			return "", err
+		}
+
+		var comments []gitHubComment
+
+		if err := doJSON(ctx, http.MethodGet, pollURL, header, nil, &comments); err != nil {
+			return "", err
+		}
+
+		for _, comment := range comments {
+			if comment.ID <= request.ID || !slices.Contains(approverAssociations, comment.AuthorAssociation) {
+				continue
+			}
+
+			// The first line of the comment is the command, a rejection may be followed by its reason.
+			command, _, _ := strings.Cut(strings.TrimSpace(comment.Body), "\n")
+			command = strings.TrimSpace(command)
+
+			switch {
+			case command == ApproveCommand:
+				return comment.HTMLURL, nil
+			case command == RejectCommand || strings.HasPrefix(command, RejectCommand+" "):
+				reason := "rejected by " + comment.User.Login
+				if details := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(comment.Body), RejectCommand)); details != "" {
+					reason += ": " + details
+				}
+
+				return "", errors.New(RejectedError{Reason: reason})
+			}
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package approval
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+const (
+	// StatusApproved is the status of a run approved by a webhook.
+	StatusApproved = "approved"
+	// StatusRejected is the status of a run rejected by a webhook.
+	StatusRejected = "rejected"
+	// StatusPending is the status of a run a webhook hasn't decided on yet.
+	StatusPending = "pending"
+)
+
+// WebhookGate posts the summary of the run as JSON to a webhook. If Token is set, it is sent as a bearer token.
+//
+// The webhook responds with its decision, e.g.:
+//
+//	{"status": "approved", "token": "CHG0042"}
+//	{"status": "rejected", "reason": "change freeze"}
+//	{"status": "pending", "poll_url": "https://approvals.example.com/runs/42"}
+//
+// A pending run is polled with GET requests to `poll_url`, or else to the webhook, until the webhook decides. The token
+// is only sent to a `poll_url` with the same scheme and host as the webhook.
+type WebhookGate struct {
+	URL   string
+	Token string
+	// PollInterval overrides DefaultPollInterval, mostly used for testing.
+	PollInterval time.Duration
+}
+
+type webhookDecision struct {
+	Status  string `json:"status"`
+	Token   string `json:"token"`
+	Reason  string `json:"reason"`
+	PollURL string `json:"poll_url"`
+}
+
+// Await implements Gate.
+func (gate *WebhookGate) Await(ctx context.Context, summary *Summary) (string, error) {
+	header := http.Header{}
+
+	if gate.Token != "" {
+		header.Set("Authorization", "Bearer "+gate.Token)
+	}
+
+	var decision webhookDecision
+
+	if err := doJSON(ctx, http.MethodPost, gate.URL, header, summary, &decision); err != nil {
+		return "", err
+	}
+
+	pollURL := gate.URL
+	pollHeader := header
+
+	for {
+		switch decision.Status {
+		case StatusApproved:
+			if decision.Token == "" {
+				return "", errors.New(InvalidResponseError{URL: pollURL, Message: "approved run without an approval token"})
+			}
+
+			return decision.Token, nil
+		case StatusRejected:
+			return "", errors.New(RejectedError{Reason: decision.Reason})
+		case StatusPending:
+		default:
+			return "", errors.New(InvalidResponseError{URL: pollURL, Message: "unknown status " + decision.Status})
+		}
+
+		if decision.PollURL != "" && decision.PollURL != pollURL {
+			pollURL = decision.PollURL
+
+			pollHeader = header
+			if !sameOrigin(gate.URL, pollURL) {
+				pollHeader = http.Header{}
+			}
+		}
+
+		if err := wait(ctx, gate.PollInterval); err != nil {
+			return "", err
+		}
+
+		decision = webhookDecision{}
+
+		if err := doJSON(ctx, http.MethodGet, pollURL, pollHeader, nil, &decision); err != nil {
+			return "", err
+		}
+	}
+}
+
+// sameOrigin returns true if the given URLs have the same scheme and host.
+func sameOrigin(a, b string) bool {
+	aURL, err := url.Parse(a)
+	if err != nil {
+		return false
+	}
+
+	bURL, err := url.Parse(b)
+	if err != nil {
+		return false
+	}
+
+	return aURL.Scheme == bURL.Scheme && aURL.Host == bURL.Host
+}
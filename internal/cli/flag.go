@@ -164,6 +164,7 @@ type flagValue struct {
 	hasBeenSet       bool
 	envHasBeenSet    bool
 	negative         bool
+	optionalValue    bool
 }
 
 func (flag *flagValue) MultipleSet() bool {
@@ -173,7 +174,7 @@ func (flag *flagValue) MultipleSet() bool {
 // IsBoolFlag implements `cli.FlagValue` interface.
 func (flag *flagValue) IsBoolFlag() bool {
 	_, ok := flag.value.Get().(bool)
-	return ok
+	return ok || flag.optionalValue
 }
 
 // IsNegativeBoolFlag implements `cli.FlagValue` interface.
@@ -28,6 +28,10 @@ type GenericFlag[T GenericType] struct {
 	// Destination is a pointer to which the value of the flag or env var is assigned.
 	Destination *T
 
+	// NoValue, if set, allows the flag to be specified without a value, like a bool flag, e.g. `--flag` instead of
+	// `--flag=value`, assigning NoValue. Note that the value must then be passed with `=`.
+	NoValue *T
+
 	// Name is the name of the flag.
 	Name string
 
@@ -57,12 +61,17 @@ func (flag *GenericFlag[T]) Apply(set *libflag.FlagSet) error {
 		flag.Destination = new(T)
 	}
 
-	valueType := &genericVar[T]{dest: flag.Destination}
+	var valueType FlagVariable[T] = &genericVar[T]{dest: flag.Destination}
+	if flag.NoValue != nil {
+		valueType = &noValueVar[T]{genericVar: &genericVar[T]{dest: flag.Destination}, noValue: *flag.NoValue}
+	}
+
 	value := newGenericValue(valueType, flag.Setter)
 
 	flag.FlagValue = &flagValue{
 		value:            value,
 		initialTextValue: value.String(),
+		optionalValue:    flag.NoValue != nil,
 	}
 
 	return ApplyFlag(flag, set)
@@ -241,3 +250,31 @@ func (val *genericVar[T]) String() string {
 
 	return fmt.Sprintf(format, *val.dest)
 }
+
+var _ = FlagVariable[string](new(noValueVar[string]))
+
+// -- generic Type of flags that can be specified without a value
+type noValueVar[T comparable] struct {
+	*genericVar[T]
+	noValue T
+}
+
+func (val *noValueVar[T]) Clone(dest *T) FlagVariable[T] {
+	return &noValueVar[T]{genericVar: val.genericVar.Clone(dest).(*genericVar[T]), noValue: val.noValue}
+}
+
+// Set assigns the NoValue of the flag when it is specified without a value, in which case the flag set passes "true",
+// as with bool flags.
+func (val *noValueVar[T]) Set(str string) error {
+	if str != "true" {
+		return val.genericVar.Set(str)
+	}
+
+	if val.dest == nil {
+		val.dest = new(T)
+	}
+
+	*val.dest = val.noValue
+
+	return nil
+}
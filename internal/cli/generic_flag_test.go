@@ -62,6 +62,63 @@ func TestGenericFlagStringApply(t *testing.T) {
 	}
 }
 
+func TestGenericFlagNoValueApply(t *testing.T) {
+	t.Parallel()
+
+	noValue := "no-value"
+
+	testCases := []struct {
+		envs          map[string]string
+		expectedValue string
+		args          []string
+	}{
+		{
+			args:          []string{"--foo"},
+			expectedValue: "no-value",
+		},
+		{
+			args:          []string{"--foo=arg-value"},
+			expectedValue: "arg-value",
+		},
+		{
+			envs:          map[string]string{"FOO": "true"},
+			expectedValue: "no-value",
+		},
+		{
+			envs:          map[string]string{"FOO": "env-value"},
+			expectedValue: "env-value",
+		},
+		{
+			args:          []string{"--foo", "arg"},
+			expectedValue: "no-value",
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("testCase-%d", i), func(t *testing.T) {
+			t.Parallel()
+
+			flag := &cli.GenericFlag[string]{Name: "foo", EnvVars: []string{"FOO"}, NoValue: &noValue}
+			flag.LookupEnvFunc = func(key string) []string {
+				if val, ok := tc.envs[key]; ok {
+					return []string{val}
+				}
+
+				return nil
+			}
+
+			flagSet := libflag.NewFlagSet("test-cmd", libflag.ContinueOnError)
+			flagSet.SetOutput(io.Discard)
+
+			require.NoError(t, flag.Apply(flagSet))
+			require.NoError(t, flagSet.Parse(tc.args))
+
+			assert.Equal(t, tc.expectedValue, flag.Value().Get())
+			assert.True(t, flag.Value().IsBoolFlag(), "IsBoolFlag()")
+		})
+	}
+}
+
 func TestGenericFlagIntApply(t *testing.T) {
 	t.Parallel()
 
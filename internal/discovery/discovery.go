@@ -96,6 +96,9 @@ type Discovery struct {
 	// parseInclude determines whether to parse include configurations.
 	parseInclude bool
 
+	// parseTags determines whether to parse the tags of the units.
+	parseTags bool
+
 	// discoverExternalDependencies determines whether to discover external dependencies.
 	discoverExternalDependencies bool
 
@@ -107,7 +110,12 @@ type Discovery struct {
 type DiscoveryOption func(*Discovery)
 
 // DefaultConfigFilenames are the default Terragrunt config filenames used in discovery.
-var DefaultConfigFilenames = []string{config.DefaultTerragruntConfigPath, config.DefaultStackFile}
+var DefaultConfigFilenames = []string{
+	config.DefaultTerragruntConfigPath,
+	config.DefaultTerragruntYAMLConfigPath,
+	config.DefaultTerragruntYMLConfigPath,
+	config.DefaultStackFile,
+}
 
 // NewDiscovery creates a new Discovery.
 func NewDiscovery(dir string, opts ...DiscoveryOption) *Discovery {
@@ -168,6 +176,15 @@ func (d *Discovery) WithParseInclude() *Discovery {
 	return d
 }
 
+// WithParseTags sets the ParseTags flag to true.
+func (d *Discovery) WithParseTags() *Discovery {
+	d.parseTags = true
+
+	d.requiresParse = true
+
+	return d
+}
+
 // WithMaxDependencyDepth sets the MaxDependencyDepth flag to the given depth.
 func (d *Discovery) WithMaxDependencyDepth(depth int) *Discovery {
 	d.maxDependencyDepth = depth
@@ -233,19 +250,25 @@ func (c *DiscoveredConfig) Parse(ctx context.Context, l log.Logger, opts *option
 	parseOpts.ErrWriter = io.Discard
 	parseOpts.SkipOutput = true
 
-	filename := config.DefaultTerragruntConfigPath
+	parseOpts.TerragruntConfigPath = config.GetDefaultConfigPath(parseOpts.WorkingDir)
 
 	if c.Type == ConfigTypeStack {
-		filename = config.DefaultStackFile
+		parseOpts.TerragruntConfigPath = filepath.Join(parseOpts.WorkingDir, config.DefaultStackFile)
 	}
 
-	parseOpts.TerragruntConfigPath = filepath.Join(parseOpts.WorkingDir, filename)
-
 	parsingCtx := config.NewParsingContext(ctx, l, parseOpts).WithDecodeList(
 		config.DependenciesBlock,
 		config.DependencyBlock,
 		config.FeatureFlagsBlock,
 		config.ExcludeBlock,
+		config.TagsAttr,
+		config.ParallelismLimitsBlock,
+		config.WeightAttr,
+		config.FailurePolicyBlock,
+		config.RunTimeoutAttr,
+		config.QueuePriorityAttr,
+		config.ApplyWindowsBlock,
+		config.MutexGroupsAttr,
 	)
 
 	//nolint: contextcheck
@@ -511,6 +534,11 @@ func (d *DependencyDiscovery) DiscoverDependencies(ctx context.Context, l log.Lo
 	errs := []error{}
 
 	for _, dependency := range dependencyBlocks {
+		// Disabled and external dependencies are not part of the graph.
+		if dependency.IsDisabled() || dependency.IsExternal() {
+			continue
+		}
+
 		if dependency.ConfigPath.Type() != cty.String {
 			errs = append(errs, errors.New("dependency config path is not a string"))
 
@@ -24,6 +24,7 @@ func TestDiscovery(t *testing.T) {
 	stack1Dir := filepath.Join(tmpDir, "stack1")
 	hiddenUnitDir := filepath.Join(tmpDir, ".hidden", "hidden-unit")
 	nestedUnit4Dir := filepath.Join(tmpDir, "nested", "unit4")
+	yamlUnit5Dir := filepath.Join(tmpDir, "unit5")
 
 	testDirs := []string{
 		unit1Dir,
@@ -31,6 +32,7 @@ func TestDiscovery(t *testing.T) {
 		stack1Dir,
 		hiddenUnitDir,
 		nestedUnit4Dir,
+		yamlUnit5Dir,
 	}
 
 	for _, dir := range testDirs {
@@ -45,6 +47,7 @@ func TestDiscovery(t *testing.T) {
 		filepath.Join(stack1Dir, "terragrunt.stack.hcl"): "",
 		filepath.Join(hiddenUnitDir, "terragrunt.hcl"):   "",
 		filepath.Join(nestedUnit4Dir, "terragrunt.hcl"):  "",
+		filepath.Join(yamlUnit5Dir, "terragrunt.yml"):    "",
 	}
 
 	for path, content := range testFiles {
@@ -62,13 +65,13 @@ func TestDiscovery(t *testing.T) {
 		{
 			name:       "basic discovery without hidden",
 			discovery:  discovery.NewDiscovery(tmpDir),
-			wantUnits:  []string{unit1Dir, unit2Dir, nestedUnit4Dir},
+			wantUnits:  []string{unit1Dir, unit2Dir, nestedUnit4Dir, yamlUnit5Dir},
 			wantStacks: []string{stack1Dir},
 		},
 		{
 			name:       "discovery with hidden",
 			discovery:  discovery.NewDiscovery(tmpDir).WithHidden(),
-			wantUnits:  []string{unit1Dir, unit2Dir, hiddenUnitDir, nestedUnit4Dir},
+			wantUnits:  []string{unit1Dir, unit2Dir, hiddenUnitDir, nestedUnit4Dir, yamlUnit5Dir},
 			wantStacks: []string{stack1Dir},
 		},
 	}
@@ -293,6 +296,64 @@ func TestDiscoveryWithDependencies(t *testing.T) {
 	}
 }
 
+func TestDiscoveryWithDisabledDependency(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+
+	appDir := filepath.Join(tmpDir, "app")
+	vpcDir := filepath.Join(tmpDir, "vpc")
+	sharedVpcDir := filepath.Join(tmpDir, "shared-vpc")
+
+	for _, dir := range []string{appDir, vpcDir, sharedVpcDir} {
+		require.NoError(t, os.MkdirAll(dir, 0755))
+	}
+
+	testFiles := map[string]string{
+		filepath.Join(appDir, "terragrunt.hcl"): `
+		locals {
+		  use_shared_vpc = true
+		}
+
+		dependency "vpc" {
+			config_path = "../vpc"
+			enabled     = !local.use_shared_vpc
+		}
+
+		dependency "shared_vpc" {
+			config_path = "../shared-vpc"
+			enabled     = local.use_shared_vpc
+		}
+		`,
+		filepath.Join(vpcDir, "terragrunt.hcl"):       ``,
+		filepath.Join(sharedVpcDir, "terragrunt.hcl"): ``,
+	}
+
+	for path, content := range testFiles {
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	opts := options.NewTerragruntOptions()
+	opts.WorkingDir = tmpDir
+	opts.RootWorkingDir = tmpDir
+
+	configs, err := discovery.NewDiscovery(tmpDir).WithDiscoverDependencies().Discover(t.Context(), logger.CreateLogger(), opts)
+	require.NoError(t, err)
+
+	for _, cfg := range configs {
+		if cfg.Path != appDir {
+			continue
+		}
+
+		require.Len(t, cfg.Dependencies, 1)
+		assert.Equal(t, sharedVpcDir, cfg.Dependencies[0].Path)
+
+		return
+	}
+
+	t.Fatalf("app unit was not discovered")
+}
+
 func TestDiscoveredConfigsCycleCheck(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,97 @@
+package encryption
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+
+	"filippo.io/age"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+const (
+	// SopsAgeKeyFileEnvName is the environment variable SOPS reads the path of the age identities file from. It is
+	// used as a fallback when no identity file is configured, so that existing SOPS setups work out of the box.
+	SopsAgeKeyFileEnvName = "SOPS_AGE_KEY_FILE"
+	// SopsAgeKeyEnvName is the environment variable SOPS reads inline age identities from.
+	SopsAgeKeyEnvName = "SOPS_AGE_KEY"
+)
+
+// EncryptAge encrypts the plaintext with age to the given recipients, e.g. `age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p`.
+func EncryptAge(plaintext []byte, recipients []string) (*Envelope, error) {
+	parsed, err := age.ParseRecipients(strings.NewReader(strings.Join(recipients, "\n")))
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	var buf bytes.Buffer
+
+	writer, err := age.Encrypt(&buf, parsed...)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	if _, err := writer.Write(plaintext); err != nil {
+		return nil, errors.New(err)
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, errors.New(err)
+	}
+
+	return &Envelope{Type: TypeAge, Ciphertext: buf.Bytes()}, nil
+}
+
+func decryptAge(opts *options.TerragruntOptions, envelope *Envelope) ([]byte, error) {
+	identities, err := ageIdentities(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := age.Decrypt(bytes.NewReader(envelope.Ciphertext), identities...)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	plaintext, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return plaintext, nil
+}
+
+// ageIdentities returns the age identities configured with `--age-identity-file`, falling back to the SOPS
+// environment variables.
+func ageIdentities(opts *options.TerragruntOptions) ([]age.Identity, error) {
+	var content []byte
+
+	path := opts.AgeIdentityFile
+	if path == "" {
+		path = opts.Env[SopsAgeKeyFileEnvName]
+	}
+
+	switch {
+	case path != "":
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.New(err)
+		}
+
+		content = data
+	case opts.Env[SopsAgeKeyEnvName] != "":
+		content = []byte(opts.Env[SopsAgeKeyEnvName])
+	default:
+		return nil, errors.New(MissingAgeIdentityError{})
+	}
+
+	identities, err := age.ParseIdentities(bytes.NewReader(content))
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return identities, nil
+}
@@ -0,0 +1,127 @@
+// Package encryption implements the encrypted values that can be declared inline in Terragrunt configurations, so
+// that sensitive inputs and locals can be committed to version control.
+//
+// An encrypted value is a string envelope holding the ciphertext and the information needed to decrypt it:
+//
+//   - `ENC[age,<base64 ciphertext>]` is encrypted with age to one or more recipients, and decrypted with the age
+//     identities configured with `--age-identity-file`, `SOPS_AGE_KEY_FILE` or `SOPS_AGE_KEY`.
+//   - `ENC[kms,<key arn>,<base64 ciphertext>]` is encrypted with an AWS KMS key, and decrypted with the AWS
+//     credentials of the run.
+//
+// Envelopes are produced with the `terragrunt encrypt` command.
+package encryption
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+const (
+	// TypeAge is the type of the envelopes encrypted with age.
+	TypeAge = "age"
+	// TypeKMS is the type of the envelopes encrypted with AWS KMS.
+	TypeKMS = "kms"
+
+	envelopePrefix    = "ENC["
+	envelopeSuffix    = "]"
+	envelopeSeparator = ","
+)
+
+// Envelope is a parsed encrypted value.
+type Envelope struct {
+	// Type is the mechanism the value is encrypted with, either TypeAge or TypeKMS.
+	Type string
+	// KeyID is the ARN of the KMS key the value is encrypted with. It is empty for age envelopes.
+	KeyID string
+	// Ciphertext is the encrypted value.
+	Ciphertext []byte
+}
+
+// IsEnvelope returns true if the given string looks like an encrypted value envelope of a supported type. Other
+// strings of the `ENC[...]` shape, e.g. SOPS ciphertexts such as `ENC[AES256_GCM,data:...]`, are not envelopes.
+func IsEnvelope(str string) bool {
+	if !strings.HasSuffix(str, envelopeSuffix) {
+		return false
+	}
+
+	for _, envelopeType := range []string{TypeAge, TypeKMS} {
+		if strings.HasPrefix(str, envelopePrefix+envelopeType+envelopeSeparator) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseEnvelope parses an encrypted value envelope.
+func ParseEnvelope(str string) (*Envelope, error) {
+	if !strings.HasPrefix(str, envelopePrefix) || !strings.HasSuffix(str, envelopeSuffix) {
+		return nil, errors.New(InvalidEnvelopeError{Reason: "expected the ENC[<type>,...] format"})
+	}
+
+	parts := strings.Split(strings.TrimSuffix(strings.TrimPrefix(str, envelopePrefix), envelopeSuffix), envelopeSeparator)
+	envelope := &Envelope{Type: parts[0]}
+
+	var data string
+
+	switch {
+	case envelope.Type == TypeAge && len(parts) == 2: //nolint:mnd
+		data = parts[1]
+	case envelope.Type == TypeKMS && len(parts) == 3: //nolint:mnd
+		envelope.KeyID, data = parts[1], parts[2]
+	case envelope.Type == TypeAge || envelope.Type == TypeKMS:
+		return nil, errors.New(InvalidEnvelopeError{Reason: "unexpected number of fields for type " + envelope.Type})
+	default:
+		return nil, errors.New(UnsupportedEnvelopeTypeError{Type: envelope.Type})
+	}
+
+	ciphertext, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, errors.New(InvalidEnvelopeError{Reason: "the ciphertext is not valid base64"})
+	}
+
+	envelope.Ciphertext = ciphertext
+
+	return envelope, nil
+}
+
+// String formats the envelope to be declared in a Terragrunt configuration.
+func (envelope *Envelope) String() string {
+	fields := []string{envelope.Type}
+
+	if envelope.KeyID != "" {
+		fields = append(fields, envelope.KeyID)
+	}
+
+	fields = append(fields, base64.StdEncoding.EncodeToString(envelope.Ciphertext))
+
+	return envelopePrefix + strings.Join(fields, envelopeSeparator) + envelopeSuffix
+}
+
+// Decrypt parses the given envelope and returns its plaintext, using the keys configured in the options.
+func Decrypt(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, str string) (string, error) {
+	envelope, err := ParseEnvelope(str)
+	if err != nil {
+		return "", err
+	}
+
+	var plaintext []byte
+
+	switch envelope.Type {
+	case TypeAge:
+		plaintext, err = decryptAge(opts, envelope)
+	case TypeKMS:
+		plaintext, err = decryptKMS(ctx, l, opts, envelope)
+	}
+
+	if err != nil {
+		return "", errors.New(DecryptError{Type: envelope.Type, Err: err})
+	}
+
+	return string(plaintext), nil
+}
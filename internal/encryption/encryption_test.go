@@ -0,0 +1,130 @@
+package encryption_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/internal/encryption"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+func TestParseEnvelope(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		value     string
+		expected  *encryption.Envelope
+		expectErr bool
+	}{
+		{
+			name:     "age",
+			value:    "ENC[age,c2VjcmV0]",
+			expected: &encryption.Envelope{Type: encryption.TypeAge, Ciphertext: []byte("secret")},
+		},
+		{
+			name:  "kms",
+			value: "ENC[kms,arn:aws:kms:us-east-1:111111111111:key/1234,c2VjcmV0]",
+			expected: &encryption.Envelope{
+				Type:       encryption.TypeKMS,
+				KeyID:      "arn:aws:kms:us-east-1:111111111111:key/1234",
+				Ciphertext: []byte("secret"),
+			},
+		},
+		{
+			name:      "unsupported type",
+			value:     "ENC[gpg,c2VjcmV0]",
+			expectErr: true,
+		},
+		{
+			name:      "missing key",
+			value:     "ENC[kms,c2VjcmV0]",
+			expectErr: true,
+		},
+		{
+			name:      "invalid base64",
+			value:     "ENC[age,not base64]",
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			envelope, err := encryption.ParseEnvelope(tc.value)
+			if tc.expectErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, envelope)
+			assert.Equal(t, tc.value, envelope.String())
+		})
+	}
+}
+
+func TestIsEnvelope(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		value    string
+		expected bool
+	}{
+		{value: "ENC[age,c2VjcmV0]", expected: true},
+		{value: "ENC[kms,arn:aws:kms:us-east-1:111111111111:key/1234,c2VjcmV0]", expected: true},
+		{value: "ENC[AES256_GCM,data:c2VjcmV0,iv:aXY=,tag:dGFn,type:str]", expected: false},
+		{value: "ENC[gpg,c2VjcmV0]", expected: false},
+		{value: "ENC[]", expected: false},
+		{value: "us-east-1", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, encryption.IsEnvelope(tc.value))
+		})
+	}
+}
+
+func TestAgeRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	identityFile := filepath.Join(t.TempDir(), "keys.txt")
+	require.NoError(t, os.WriteFile(identityFile, []byte(identity.String()+"\n"), 0600))
+
+	envelope, err := encryption.EncryptAge([]byte("hunter2"), []string{identity.Recipient().String()})
+	require.NoError(t, err)
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	opts.Env = map[string]string{}
+
+	_, err = encryption.Decrypt(t.Context(), log.New(), opts, envelope.String())
+	require.ErrorAs(t, err, &encryption.MissingAgeIdentityError{})
+
+	opts.AgeIdentityFile = identityFile
+
+	plaintext, err := encryption.Decrypt(t.Context(), log.New(), opts, envelope.String())
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", plaintext)
+
+	opts.AgeIdentityFile = ""
+	opts.Env = map[string]string{encryption.SopsAgeKeyEnvName: identity.String()}
+
+	plaintext, err = encryption.Decrypt(t.Context(), log.New(), opts, envelope.String())
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", plaintext)
+}
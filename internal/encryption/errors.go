@@ -0,0 +1,44 @@
+package encryption
+
+import (
+	"fmt"
+)
+
+// InvalidEnvelopeError is returned when an encrypted value is malformed.
+type InvalidEnvelopeError struct {
+	Reason string
+}
+
+func (err InvalidEnvelopeError) Error() string {
+	return "invalid encrypted value: " + err.Reason
+}
+
+// UnsupportedEnvelopeTypeError is returned when an encrypted value declares an unknown encryption type.
+type UnsupportedEnvelopeTypeError struct {
+	Type string
+}
+
+func (err UnsupportedEnvelopeTypeError) Error() string {
+	return fmt.Sprintf("unsupported encrypted value type %q, expected %q or %q", err.Type, TypeAge, TypeKMS)
+}
+
+// MissingAgeIdentityError is returned when an age encrypted value is decrypted without any age identity configured.
+type MissingAgeIdentityError struct{}
+
+func (err MissingAgeIdentityError) Error() string {
+	return fmt.Sprintf("no age identity configured, set --age-identity-file, %s or %s", SopsAgeKeyFileEnvName, SopsAgeKeyEnvName)
+}
+
+// DecryptError is returned when an encrypted value can not be decrypted.
+type DecryptError struct {
+	Err  error
+	Type string
+}
+
+func (err DecryptError) Error() string {
+	return fmt.Sprintf("failed to decrypt %s encrypted value: %v", err.Type, err.Err)
+}
+
+func (err DecryptError) Unwrap() error {
+	return err.Err
+}
@@ -0,0 +1,61 @@
+package encryption
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/kms"
+
+	"github.com/gruntwork-io/terragrunt/awshelper"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+// EncryptKMS encrypts the plaintext with the given AWS KMS key, which can be a key ID, a key ARN or an alias. The
+// envelope records the ARN of the key, so that it can be decrypted in the region of the key.
+func EncryptKMS(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, keyID string, plaintext []byte) (*Envelope, error) {
+	var sessionConfig *awshelper.AwsSessionConfig
+
+	if parsed, err := arn.Parse(keyID); err == nil {
+		sessionConfig = &awshelper.AwsSessionConfig{Region: parsed.Region}
+	}
+
+	sess, err := awshelper.CreateAwsSession(l, sessionConfig, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := kms.New(sess).EncryptWithContext(ctx, &kms.EncryptInput{
+		KeyId:     aws.String(keyID),
+		Plaintext: plaintext,
+	})
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return &Envelope{Type: TypeKMS, KeyID: aws.StringValue(output.KeyId), Ciphertext: output.CiphertextBlob}, nil
+}
+
+func decryptKMS(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, envelope *Envelope) ([]byte, error) {
+	keyArn, err := arn.Parse(envelope.KeyID)
+	if err != nil {
+		return nil, errors.New(InvalidEnvelopeError{Reason: "the KMS key must be an ARN"})
+	}
+
+	sess, err := awshelper.CreateAwsSession(l, &awshelper.AwsSessionConfig{Region: keyArn.Region}, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := kms.New(sess).DecryptWithContext(ctx, &kms.DecryptInput{
+		KeyId:          aws.String(envelope.KeyID),
+		CiphertextBlob: envelope.Ciphertext,
+	})
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return output.Plaintext, nil
+}
@@ -32,6 +32,9 @@ const (
 	//
 	// Only works with OpenTofu version >= 1.10.
 	AutoProviderCacheDir = "auto-provider-cache-dir"
+	// EnvironmentInference is the experiment that infers the environment of a unit from its path, to layer the
+	// matching environment overlay onto its config.
+	EnvironmentInference = "environment-inference"
 )
 
 const (
@@ -71,6 +74,9 @@ func NewExperiments() Experiments {
 		{
 			Name: AutoProviderCacheDir,
 		},
+		{
+			Name: EnvironmentInference,
+		},
 	}
 }
 
@@ -0,0 +1,182 @@
+package featureflags
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/gruntwork-io/terragrunt/awshelper"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+const (
+	// LaunchDarklyBaseURL is the base URL of the LaunchDarkly client-side evaluation API.
+	LaunchDarklyBaseURL = "https://clientsdk.launchdarkly.com"
+
+	defaultLaunchDarklyContextKey = "terragrunt"
+)
+
+// FileSource reads flags from a local JSON file.
+type FileSource struct {
+	Path string
+}
+
+// Fetch implements Source.
+func (src *FileSource) Fetch(_ context.Context) (map[string]cty.Value, error) {
+	data, err := os.ReadFile(src.Path)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return decodeFlags(data)
+}
+
+// HTTPSource reads flags from an HTTP endpoint. If Token is set, it is sent as a bearer token.
+type HTTPSource struct {
+	URL   string
+	Token string
+}
+
+// Fetch implements Source.
+func (src *HTTPSource) Fetch(ctx context.Context) (map[string]cty.Value, error) {
+	data, err := httpGet(ctx, src.URL, src.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeFlags(data)
+}
+
+// S3Source reads flags from a JSON object stored in an S3 bucket.
+type S3Source struct {
+	logger log.Logger
+	opts   *options.TerragruntOptions
+	Bucket string
+	Key    string
+	Region string
+}
+
+// Fetch implements Source.
+func (src *S3Source) Fetch(ctx context.Context) (map[string]cty.Value, error) {
+	var sessionConfig *awshelper.AwsSessionConfig
+	if src.Region != "" {
+		sessionConfig = &awshelper.AwsSessionConfig{Region: src.Region}
+	}
+
+	client, err := awshelper.CreateS3Client(src.logger, sessionConfig, src.opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(src.Bucket),
+		Key:    aws.String(src.Key),
+	})
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	defer result.Body.Close() //nolint:errcheck
+
+	data, err := io.ReadAll(result.Body)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return decodeFlags(data)
+}
+
+// LaunchDarklySource evaluates flags with the LaunchDarkly client-side API, for the given context key.
+type LaunchDarklySource struct {
+	ClientSideID string
+	ContextKey   string
+	// BaseURL overrides LaunchDarklyBaseURL, mostly used for testing.
+	BaseURL string
+}
+
+// Fetch implements Source.
+func (src *LaunchDarklySource) Fetch(ctx context.Context) (map[string]cty.Value, error) {
+	baseURL := src.BaseURL
+	if baseURL == "" {
+		baseURL = LaunchDarklyBaseURL
+	}
+
+	contextKey := src.ContextKey
+	if contextKey == "" {
+		contextKey = defaultLaunchDarklyContextKey
+	}
+
+	evalContext, err := json.Marshal(map[string]string{"kind": "user", "key": contextKey})
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	url := fmt.Sprintf("%s/sdk/evalx/%s/contexts/%s", baseURL, src.ClientSideID, base64.RawURLEncoding.EncodeToString(evalContext))
+
+	data, err := httpGet(ctx, url, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// The API responds with an object of evaluation details, keep only the evaluated values.
+	var details map[string]struct {
+		Value json.RawMessage `json:"value"`
+	}
+
+	if err := json.Unmarshal(data, &details); err != nil {
+		return nil, errors.New(InvalidSourceContentError{Message: err.Error()})
+	}
+
+	values := make(map[string]json.RawMessage, len(details))
+	for name, detail := range details {
+		values[name] = detail.Value
+	}
+
+	data, err = json.Marshal(values)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return decodeFlags(data)
+}
+
+func httpGet(ctx context.Context, url, token string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, errors.New(UnexpectedStatusError{URL: url, StatusCode: resp.StatusCode})
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return data, nil
+}
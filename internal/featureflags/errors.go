@@ -0,0 +1,31 @@
+package featureflags
+
+import "fmt"
+
+// UnsupportedSourceError is returned when the feature flag source address has an unknown scheme.
+type UnsupportedSourceError struct {
+	Address string
+}
+
+func (err UnsupportedSourceError) Error() string {
+	return fmt.Sprintf("unsupported feature flag source %q, expected a file path or a file://, http(s)://, s3:// or launchdarkly:// address", err.Address)
+}
+
+// InvalidSourceContentError is returned when the source does not serve a JSON object of flags.
+type InvalidSourceContentError struct {
+	Message string
+}
+
+func (err InvalidSourceContentError) Error() string {
+	return "invalid feature flag source content: " + err.Message
+}
+
+// UnexpectedStatusError is returned when an HTTP based source responds with a non 2xx status.
+type UnexpectedStatusError struct {
+	URL        string
+	StatusCode int
+}
+
+func (err UnexpectedStatusError) Error() string {
+	return fmt.Sprintf("feature flag source %s responded with status %d", err.URL, err.StatusCode)
+}
@@ -0,0 +1,95 @@
+// Package featureflags provides external sources for feature flag values.
+//
+// A source is addressed by a single string, its scheme selecting the backend:
+//
+//   - `/path/to/flags.json` or `file:///path/to/flags.json` reads a local JSON file.
+//   - `http://...` and `https://...` issue a GET request to the endpoint.
+//   - `s3://bucket/key?region=us-east-1` reads a JSON object stored in S3.
+//   - `launchdarkly://<client-side-id>?context=<key>` evaluates the flags with the LaunchDarkly client-side API.
+//
+// File, HTTP and S3 sources must serve a flat JSON object mapping flag names to values.
+package featureflags
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+const (
+	// FetchTimeout is the maximum time spent fetching flags from a remote source.
+	FetchTimeout = 10 * time.Second
+
+	schemeFile         = "file"
+	schemeHTTP         = "http"
+	schemeHTTPS        = "https"
+	schemeS3           = "s3"
+	schemeLaunchDarkly = "launchdarkly"
+)
+
+// Source fetches feature flag values from an external system.
+type Source interface {
+	// Fetch returns the flag values keyed by flag name.
+	Fetch(ctx context.Context) (map[string]cty.Value, error)
+}
+
+// NewSource returns the source matching the scheme of the given address.
+func NewSource(l log.Logger, opts *options.TerragruntOptions, address string) (Source, error) {
+	if !strings.Contains(address, "://") {
+		return &FileSource{Path: address}, nil
+	}
+
+	parsed, err := url.Parse(address)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	switch parsed.Scheme {
+	case schemeFile:
+		return &FileSource{Path: parsed.Path}, nil
+	case schemeHTTP, schemeHTTPS:
+		return &HTTPSource{URL: address, Token: opts.FeatureFlagSourceToken}, nil
+	case schemeS3:
+		return &S3Source{
+			Bucket: parsed.Host,
+			Key:    strings.TrimPrefix(parsed.Path, "/"),
+			Region: parsed.Query().Get("region"),
+			logger: l,
+			opts:   opts,
+		}, nil
+	case schemeLaunchDarkly:
+		return &LaunchDarklySource{
+			ClientSideID: parsed.Host,
+			ContextKey:   parsed.Query().Get("context"),
+		}, nil
+	}
+
+	return nil, errors.New(UnsupportedSourceError{Address: address})
+}
+
+// decodeFlags parses a flat JSON object into cty values, preserving the JSON types.
+func decodeFlags(data []byte) (map[string]cty.Value, error) {
+	ty, err := ctyjson.ImpliedType(data)
+	if err != nil {
+		return nil, errors.New(InvalidSourceContentError{Message: err.Error()})
+	}
+
+	if !ty.IsObjectType() {
+		return nil, errors.New(InvalidSourceContentError{Message: "expected a JSON object, got " + ty.FriendlyName()})
+	}
+
+	val, err := ctyjson.Unmarshal(data, ty)
+	if err != nil {
+		return nil, errors.New(InvalidSourceContentError{Message: err.Error()})
+	}
+
+	return val.AsValueMap(), nil
+}
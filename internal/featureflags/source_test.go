@@ -0,0 +1,107 @@
+package featureflags_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+
+	"github.com/gruntwork-io/terragrunt/internal/featureflags"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+func TestNewSource(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		expected any
+		name     string
+		address  string
+	}{
+		{name: "plain path", address: "flags.json", expected: &featureflags.FileSource{Path: "flags.json"}},
+		{name: "file scheme", address: "file:///tmp/flags.json", expected: &featureflags.FileSource{Path: "/tmp/flags.json"}},
+		{name: "https", address: "https://flags.example.com/tg", expected: &featureflags.HTTPSource{URL: "https://flags.example.com/tg", Token: "secret"}},
+		{name: "launchdarkly", address: "launchdarkly://abc123?context=ci", expected: &featureflags.LaunchDarklySource{ClientSideID: "abc123", ContextKey: "ci"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := options.NewTerragruntOptions()
+			opts.FeatureFlagSourceToken = "secret"
+
+			source, err := featureflags.NewSource(log.New(), opts, tc.address)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, source)
+		})
+	}
+
+	_, err := featureflags.NewSource(log.New(), options.NewTerragruntOptions(), "ftp://flags")
+	require.Error(t, err)
+}
+
+func TestFileSource(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "flags.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"enabled": true, "replicas": 3, "name": "blue"}`), 0644))
+
+	flags, err := (&featureflags.FileSource{Path: path}).Fetch(t.Context())
+	require.NoError(t, err)
+	require.Len(t, flags, 3)
+	assert.True(t, flags["enabled"].True())
+	assert.True(t, flags["replicas"].Equals(cty.NumberIntVal(3)).True())
+	assert.Equal(t, "blue", flags["name"].AsString())
+
+	require.NoError(t, os.WriteFile(path, []byte(`["not", "an", "object"]`), 0644))
+
+	_, err = (&featureflags.FileSource{Path: path}).Fetch(t.Context())
+	require.Error(t, err)
+}
+
+func TestHTTPSource(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"enabled": false}`))
+	}))
+	defer server.Close()
+
+	flags, err := (&featureflags.HTTPSource{URL: server.URL, Token: "secret"}).Fetch(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]cty.Value{"enabled": cty.False}, flags)
+
+	_, err = (&featureflags.HTTPSource{URL: server.URL}).Fetch(t.Context())
+	require.Error(t, err)
+}
+
+func TestLaunchDarklySource(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/sdk/evalx/abc123/contexts/") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"new-vpc": {"value": true, "variation": 0, "version": 4}}`))
+	}))
+	defer server.Close()
+
+	flags, err := (&featureflags.LaunchDarklySource{ClientSideID: "abc123", BaseURL: server.URL}).Fetch(t.Context())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]cty.Value{"new-vpc": cty.True}, flags)
+}
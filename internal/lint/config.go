@@ -0,0 +1,240 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// DefaultConfigFileName is the name of the lint config file read from the working directory, if present.
+const DefaultConfigFileName = ".terragrunt-lint.hcl"
+
+// Config is the lint config, overriding the built-in rules and defining the custom rules of an organization.
+//
+//	rule "missing-remote-state" {
+//	  severity = "error"
+//	}
+//
+//	custom_rule "require-catalog-source" {
+//	  description    = "Units must use modules of the catalog."
+//	  files          = ["terragrunt.hcl"]
+//	  source_pattern = "^git::https://github.com/acme/catalog"
+//	}
+type Config struct {
+	Rules       []RuleConfig  `hcl:"rule,block"`
+	CustomRules []*CustomRule `hcl:"custom_rule,block"`
+}
+
+// RuleConfig overrides the settings of a built-in or registered rule.
+type RuleConfig struct {
+	Enabled  *bool   `hcl:"enabled,optional"`
+	Severity *string `hcl:"severity,optional"`
+	Name     string  `hcl:",label"`
+}
+
+// ReadConfig reads the lint config at the given path.
+func ReadConfig(path string) (*Config, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	file, diags := hclsyntax.ParseConfig(src, path, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, errors.New(diags)
+	}
+
+	cfg := &Config{}
+
+	if diags := gohcl.DecodeBody(file.Body, nil, cfg); diags.HasErrors() {
+		return nil, errors.New(diags)
+	}
+
+	return cfg, nil
+}
+
+// apply creates a linter with the given rules, overridden by the rule configs, followed by the custom rules.
+func (cfg *Config) apply(rules []Rule) (*Linter, error) {
+	linter := &Linter{severities: map[string]Severity{}}
+
+	for _, rule := range rules {
+		linter.severities[rule.Name()] = rule.Severity()
+	}
+
+	disabled := map[string]bool{}
+
+	for _, ruleCfg := range cfg.Rules {
+		if _, ok := linter.severities[ruleCfg.Name]; !ok {
+			return nil, errors.Errorf("unknown lint rule %q", ruleCfg.Name)
+		}
+
+		if ruleCfg.Enabled != nil {
+			disabled[ruleCfg.Name] = !*ruleCfg.Enabled
+		}
+
+		if ruleCfg.Severity != nil {
+			severity, err := ParseSeverity(*ruleCfg.Severity)
+			if err != nil {
+				return nil, errors.Errorf("rule %q: %w", ruleCfg.Name, err)
+			}
+
+			linter.severities[ruleCfg.Name] = severity
+		}
+	}
+
+	for _, rule := range cfg.CustomRules {
+		if _, ok := linter.severities[rule.RuleName]; ok {
+			return nil, errors.Errorf("custom rule %q conflicts with an existing rule", rule.RuleName)
+		}
+
+		if err := rule.init(); err != nil {
+			return nil, errors.Errorf("custom rule %q: %w", rule.RuleName, err)
+		}
+
+		linter.severities[rule.RuleName] = rule.Severity()
+		rules = append(rules, rule)
+	}
+
+	for _, rule := range rules {
+		if !disabled[rule.Name()] {
+			linter.rules = append(linter.rules, rule)
+		}
+	}
+
+	return linter, nil
+}
+
+// CustomRule is a rule defined in the lint config, checking the top-level attributes and blocks, the function calls and
+// the terraform source of the files.
+type CustomRule struct {
+	sourceRegexp      *regexp.Regexp
+	severity          Severity
+	SeverityStr       *string  `hcl:"severity,optional"`
+	SourcePattern     *string  `hcl:"source_pattern,optional"`
+	RuleName          string   `hcl:",label"`
+	RuleDescription   string   `hcl:"description,optional"`
+	Files             []string `hcl:"files,optional"`
+	RequireAttributes []string `hcl:"require_attributes,optional"`
+	RequireBlocks     []string `hcl:"require_blocks,optional"`
+	ForbidAttributes  []string `hcl:"forbid_attributes,optional"`
+	ForbidBlocks      []string `hcl:"forbid_blocks,optional"`
+	ForbidFunctions   []string `hcl:"forbid_functions,optional"`
+}
+
+func (rule *CustomRule) init() error {
+	if !hclsyntax.ValidIdentifier(rule.RuleName) {
+		return errors.Errorf("invalid rule name")
+	}
+
+	rule.severity = SeverityWarning
+
+	if rule.SeverityStr != nil {
+		severity, err := ParseSeverity(*rule.SeverityStr)
+		if err != nil {
+			return err
+		}
+
+		rule.severity = severity
+	}
+
+	for _, pattern := range rule.Files {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return errors.Errorf("invalid files pattern %q: %w", pattern, err)
+		}
+	}
+
+	if rule.SourcePattern != nil {
+		re, err := regexp.Compile(*rule.SourcePattern)
+		if err != nil {
+			return errors.Errorf("invalid source_pattern: %w", err)
+		}
+
+		rule.sourceRegexp = re
+	}
+
+	return nil
+}
+
+func (rule *CustomRule) Name() string { return rule.RuleName }
+
+func (rule *CustomRule) Description() string { return rule.RuleDescription }
+
+func (rule *CustomRule) Severity() Severity { return rule.severity }
+
+func (rule *CustomRule) Check(file *File) []Finding {
+	if !rule.matchesFile(file) {
+		return nil
+	}
+
+	var findings []Finding
+
+	fileStart := hcl.Range{Filename: file.Path, Start: hcl.InitialPos, End: hcl.InitialPos}
+
+	for _, name := range rule.RequireAttributes {
+		if _, ok := file.Body.Attributes[name]; !ok {
+			findings = append(findings, rule.finding(fmt.Sprintf("The %s attribute is required.", name), fileStart))
+		}
+	}
+
+	for _, name := range rule.RequireBlocks {
+		if !slices.ContainsFunc(file.Body.Blocks, func(block *hclsyntax.Block) bool { return block.Type == name }) {
+			findings = append(findings, rule.finding(fmt.Sprintf("A %s block is required.", name), fileStart))
+		}
+	}
+
+	for _, name := range rule.ForbidAttributes {
+		if attr, ok := file.Body.Attributes[name]; ok {
+			findings = append(findings, rule.finding(fmt.Sprintf("The %s attribute is not allowed.", name), attr.Range()))
+		}
+	}
+
+	for _, block := range file.Body.Blocks {
+		if slices.Contains(rule.ForbidBlocks, block.Type) {
+			findings = append(findings, rule.finding(fmt.Sprintf("The %s block is not allowed.", block.Type), block.DefRange()))
+		}
+	}
+
+	visitFunctionCalls(file.Body, func(call *hclsyntax.FunctionCallExpr) {
+		if slices.Contains(rule.ForbidFunctions, call.Name) {
+			findings = append(findings, rule.finding(fmt.Sprintf("The %s function is not allowed.", call.Name), call.Range()))
+		}
+	})
+
+	if attr := terraformSourceAttribute(file.Body); attr != nil && rule.sourceRegexp != nil {
+		if source := strings.Trim(file.SourceText(attr.Expr.Range()), `"`); !rule.sourceRegexp.MatchString(source) {
+			findings = append(findings, rule.finding(fmt.Sprintf("The source does not match %q.", rule.sourceRegexp), attr.Expr.Range()))
+		}
+	}
+
+	return findings
+}
+
+// matchesFile returns true if the file name matches one of the files patterns of the rule, or if there are none.
+func (rule *CustomRule) matchesFile(file *File) bool {
+	if len(rule.Files) == 0 {
+		return true
+	}
+
+	return slices.ContainsFunc(rule.Files, func(pattern string) bool {
+		matched, _ := filepath.Match(pattern, filepath.Base(file.Path))
+		return matched
+	})
+}
+
+// finding returns a finding with the given message, prefixed with the description of the rule, if any.
+func (rule *CustomRule) finding(msg string, rng hcl.Range) Finding {
+	if rule.RuleDescription != "" {
+		msg = rule.RuleDescription + " " + msg
+	}
+
+	return Finding{Message: msg, Range: rng}
+}
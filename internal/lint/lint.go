@@ -0,0 +1,219 @@
+// Package lint provides a rules engine for linting Terragrunt configurations. The configurations are checked
+// statically, without being evaluated, against the built-in rules, the rules registered with RegisterRule and the
+// custom rules defined in the lint config file.
+package lint
+
+import (
+	"maps"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+
+	"github.com/gruntwork-io/terragrunt/config/hclparse"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// UnitConfigFileName is the name of the config file of a unit.
+const UnitConfigFileName = "terragrunt.hcl"
+
+// SyntaxRuleName is the name of the rule reported for files that can't be parsed.
+const SyntaxRuleName = "hcl-syntax"
+
+// Severity is the severity of a finding.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// ParseSeverity parses the given severity, returning an error if it is not a valid one.
+func ParseSeverity(str string) (Severity, error) {
+	switch severity := Severity(str); severity {
+	case SeverityError, SeverityWarning:
+		return severity, nil
+	}
+
+	return "", errors.Errorf("invalid severity %q, must be %q or %q", str, SeverityError, SeverityWarning)
+}
+
+// File is a parsed HCL file checked by the rules.
+type File struct {
+	Body *hclsyntax.Body
+	// Path is the path to the file.
+	Path string
+	// Bytes is the content of the file.
+	Bytes []byte
+}
+
+// IsUnitConfig returns true if the file is the config of a unit, as opposed to a file included by units.
+func (file *File) IsUnitConfig() bool {
+	return filepath.Base(file.Path) == UnitConfigFileName
+}
+
+// SourceText returns the source text of the given range of the file.
+func (file *File) SourceText(rng hcl.Range) string {
+	return string(rng.SliceBytes(file.Bytes))
+}
+
+// Finding is a violation of a rule.
+type Finding struct {
+	Rule     string
+	Severity Severity
+	Message  string
+	Range    hcl.Range
+}
+
+// Rule checks the files for violations of a standard.
+type Rule interface {
+	// Name returns the name of the rule, used to reference it in the lint config and in the findings.
+	Name() string
+	// Description returns a short description of the standard checked by the rule.
+	Description() string
+	// Severity returns the default severity of the findings of the rule.
+	Severity() Severity
+	// Check returns the violations of the rule in the given file. The rule and severity of the returned findings are
+	// set by the linter.
+	Check(file *File) []Finding
+}
+
+var (
+	registeredRulesMu sync.RWMutex
+	registeredRules   = map[string]Rule{}
+)
+
+// RegisterRule registers a rule run by all the linters, in addition to the built-in rules. It is meant to be called
+// from an `init` function of a custom build of Terragrunt, to codify company-specific standards that can't be expressed
+// with the custom rules of the lint config.
+func RegisterRule(rule Rule) error {
+	name := rule.Name()
+	if !hclsyntax.ValidIdentifier(name) {
+		return errors.Errorf("invalid rule name %q", name)
+	}
+
+	registeredRulesMu.Lock()
+	defer registeredRulesMu.Unlock()
+
+	if _, ok := registeredRules[name]; ok || slices.ContainsFunc(builtinRules, func(r Rule) bool { return r.Name() == name }) {
+		return errors.Errorf("rule %q is already registered", name)
+	}
+
+	registeredRules[name] = rule
+
+	return nil
+}
+
+// UnregisterRule removes the rule registered under the given name, if any.
+func UnregisterRule(name string) {
+	registeredRulesMu.Lock()
+	defer registeredRulesMu.Unlock()
+
+	delete(registeredRules, name)
+}
+
+// Linter checks files against a set of rules.
+type Linter struct {
+	severities map[string]Severity
+	rules      []Rule
+}
+
+// NewLinter creates a linter running the built-in and registered rules, configured with the given lint config, which
+// may be nil.
+func NewLinter(cfg *Config) (*Linter, error) {
+	rules := slices.Clone(builtinRules)
+
+	registeredRulesMu.RLock()
+	for _, name := range slices.Sorted(maps.Keys(registeredRules)) {
+		rules = append(rules, registeredRules[name])
+	}
+	registeredRulesMu.RUnlock()
+
+	if cfg == nil {
+		cfg = &Config{}
+	}
+
+	return cfg.apply(rules)
+}
+
+// Rules returns the enabled rules of the linter.
+func (linter *Linter) Rules() []Rule {
+	return linter.rules
+}
+
+// Lint parses the given file and checks it against the rules of the linter, returning the findings sorted by position.
+// A file that can't be parsed is reported with the hcl-syntax rule. The JSON and YAML files are only checked for syntax
+// errors, as the rules match the native HCL syntax.
+func (linter *Linter) Lint(path string, src []byte) []Finding {
+	switch filepath.Ext(path) {
+	case ".json":
+		_, diags := hclparse.NewParser().ParseJSON(src, path)
+		return syntaxFindings(diags)
+	case ".yaml", ".yml":
+		_, diags := hclparse.NewParser().ParseYAML(src, path)
+		return syntaxFindings(diags)
+	}
+
+	hclFile, diags := hclsyntax.ParseConfig(src, path, hcl.InitialPos)
+	if diags.HasErrors() {
+		return syntaxFindings(diags)
+	}
+
+	file := &File{
+		Path:  path,
+		Bytes: src,
+		Body:  hclFile.Body.(*hclsyntax.Body),
+	}
+
+	var findings []Finding
+
+	for _, rule := range linter.rules {
+		for _, finding := range rule.Check(file) {
+			finding.Rule = rule.Name()
+			finding.Severity = linter.severities[rule.Name()]
+			findings = append(findings, finding)
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Range.Start.Byte < findings[j].Range.Start.Byte
+	})
+
+	return findings
+}
+
+// syntaxFindings returns the hcl-syntax findings of the errors of the given parsing diagnostics.
+func syntaxFindings(diags hcl.Diagnostics) []Finding {
+	var findings []Finding
+
+	for _, diag := range diags {
+		if diag.Severity != hcl.DiagError {
+			continue
+		}
+
+		finding := Finding{Rule: SyntaxRuleName, Severity: SeverityError, Message: diag.Summary + "; " + diag.Detail}
+		if diag.Subject != nil {
+			finding.Range = *diag.Subject
+		}
+
+		findings = append(findings, finding)
+	}
+
+	return findings
+}
+
+// ErrorCount returns the number of the given findings with the error severity.
+func ErrorCount(findings []Finding) int {
+	count := 0
+
+	for _, finding := range findings {
+		if finding.Severity == SeverityError {
+			count++
+		}
+	}
+
+	return count
+}
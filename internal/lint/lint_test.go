@@ -0,0 +1,336 @@
+package lint_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/internal/lint"
+)
+
+func TestBuiltinRules(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		path     string
+		content  string
+		expected []string
+	}{
+		{
+			name: "unpinned git source",
+			path: "unit/terragrunt.hcl",
+			content: `
+include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+
+terraform {
+  source = "git::https://github.com/acme/modules.git//vpc"
+}
+`,
+			expected: []string{lint.UnpinnedSourceRuleName},
+		},
+		{
+			name: "pinned sources",
+			path: "unit/terragrunt.hcl",
+			content: `
+include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+
+terraform {
+  source = "git::https://github.com/acme/modules.git//vpc?ref=${local.version}"
+}
+`,
+		},
+		{
+			name: "unpinned registry source",
+			path: "unit/terragrunt.hcl",
+			content: `
+remote_state {
+  backend = "s3"
+}
+
+terraform {
+  source = "tfr:///terraform-aws-modules/vpc/aws"
+}
+`,
+			expected: []string{lint.UnpinnedSourceRuleName},
+		},
+		{
+			name: "local source",
+			path: "unit/terragrunt.hcl",
+			content: `
+include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+
+terraform {
+  source = "../modules/vpc"
+}
+`,
+		},
+		{
+			name:     "missing remote state",
+			path:     "unit/terragrunt.hcl",
+			content:  `inputs = {}`,
+			expected: []string{lint.MissingRemoteStateRuleName},
+		},
+		{
+			name:    "missing remote state in included config",
+			path:    "root.hcl",
+			content: `inputs = {}`,
+		},
+		{
+			name: "deprecated function",
+			path: "unit/terragrunt.hcl",
+			content: `
+include "root" {
+  path = find_in_parent_folders()
+}
+`,
+			expected: []string{lint.DeprecatedFunctionRuleName},
+		},
+		{
+			name: "broad skip",
+			path: "unit/terragrunt.hcl",
+			content: `
+include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+
+skip = true
+`,
+			expected: []string{lint.BroadSkipRuleName},
+		},
+		{
+			name: "conditional skip",
+			path: "unit/terragrunt.hcl",
+			content: `
+include "root" {
+  path = find_in_parent_folders("root.hcl")
+}
+
+skip = get_env("SKIP", "false") == "true"
+`,
+		},
+		{
+			name:     "invalid syntax",
+			path:     "unit/terragrunt.hcl",
+			content:  `inputs = {`,
+			expected: []string{lint.SyntaxRuleName},
+		},
+		{
+			name:    "json config",
+			path:    "unit/terragrunt.hcl.json",
+			content: `{"inputs": {}}`,
+		},
+		{
+			name:     "invalid json syntax",
+			path:     "unit/terragrunt.hcl.json",
+			content:  `{"inputs": nope}`,
+			expected: []string{lint.SyntaxRuleName},
+		},
+		{
+			name:    "yaml config",
+			path:    "unit/terragrunt.yaml",
+			content: "inputs:\n  name: vpc\n",
+		},
+		{
+			name:     "invalid yaml syntax",
+			path:     "unit/terragrunt.yml",
+			content:  "inputs: [",
+			expected: []string{lint.SyntaxRuleName},
+		},
+	}
+
+	linter, err := lint.NewLinter(nil)
+	require.NoError(t, err)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var rules []string
+
+			for _, finding := range linter.Lint(tc.path, []byte(tc.content)) {
+				rules = append(rules, finding.Rule)
+			}
+
+			assert.Equal(t, tc.expected, rules)
+		})
+	}
+}
+
+func TestLintConfig(t *testing.T) {
+	t.Parallel()
+
+	configPath := filepath.Join(t.TempDir(), lint.DefaultConfigFileName)
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+rule "missing-remote-state" {
+  enabled = false
+}
+
+rule "broad-skip" {
+  severity = "error"
+}
+
+custom_rule "acme-standards" {
+  severity           = "error"
+  files              = ["terragrunt.hcl"]
+  require_attributes = ["inputs"]
+  forbid_functions   = ["run_cmd"]
+  source_pattern     = "^git::https://github.com/acme/"
+}
+`), 0644))
+
+	cfg, err := lint.ReadConfig(configPath)
+	require.NoError(t, err)
+
+	linter, err := lint.NewLinter(cfg)
+	require.NoError(t, err)
+
+	findings := linter.Lint("unit/terragrunt.hcl", []byte(`
+terraform {
+  source = "git::https://github.com/other/modules.git//vpc?ref=v1.0.0"
+}
+
+skip = true
+
+locals {
+  account = run_cmd("whoami")
+}
+`))
+
+	expected := []lint.Finding{
+		{Rule: "acme-standards", Severity: lint.SeverityError, Message: "The inputs attribute is required."},
+		{Rule: "acme-standards", Severity: lint.SeverityError, Message: `The source does not match "^git::https://github.com/acme/".`},
+		{Rule: lint.BroadSkipRuleName, Severity: lint.SeverityError, Message: "The unit is skipped unconditionally, use an exclude block with a condition and the excluded actions instead."},
+		{Rule: "acme-standards", Severity: lint.SeverityError, Message: "The run_cmd function is not allowed."},
+	}
+
+	for i := range findings {
+		findings[i].Range = expected[i].Range
+	}
+
+	assert.Equal(t, expected, findings)
+
+	assert.Empty(t, linter.Lint("root.hcl", []byte(`locals {}`)))
+
+	_, err = lint.NewLinter(&lint.Config{Rules: []lint.RuleConfig{{Name: "unknown"}}})
+	require.EqualError(t, err, `unknown lint rule "unknown"`)
+}
+
+type requireLocalsRule struct{}
+
+func (rule *requireLocalsRule) Name() string { return "require-locals" }
+
+func (rule *requireLocalsRule) Description() string { return "Configs must define locals." }
+
+func (rule *requireLocalsRule) Severity() lint.Severity { return lint.SeverityWarning }
+
+func (rule *requireLocalsRule) Check(file *lint.File) []lint.Finding {
+	for _, block := range file.Body.Blocks {
+		if block.Type == "locals" {
+			return nil
+		}
+	}
+
+	return []lint.Finding{{Message: "No locals."}}
+}
+
+// TestRegisterRule is not run in parallel, as the registered rules are run by all the linters.
+//
+//nolint:paralleltest
+func TestRegisterRule(t *testing.T) {
+	rule := &requireLocalsRule{}
+
+	require.NoError(t, lint.RegisterRule(rule))
+	t.Cleanup(func() { lint.UnregisterRule(rule.Name()) })
+
+	require.EqualError(t, lint.RegisterRule(rule), `rule "require-locals" is already registered`)
+
+	linter, err := lint.NewLinter(nil)
+	require.NoError(t, err)
+
+	findings := linter.Lint("root.hcl", []byte(`inputs = {}`))
+	require.Len(t, findings, 1)
+	assert.Equal(t, "require-locals", findings[0].Rule)
+}
+
+func TestWriter(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	linter, err := lint.NewLinter(nil)
+	require.NoError(t, err)
+
+	findings := linter.Lint(filepath.Join(dir, "unit", "terragrunt.hcl"), []byte("remote_state {}\nskip = true\n"))
+
+	var buf bytes.Buffer
+
+	require.NoError(t, lint.NewWriter(&buf, lint.FormatText, dir, "terragrunt").Write(linter.Rules(), findings))
+	assert.Equal(t, "unit/terragrunt.hcl:2:1: warning [broad-skip] The unit is skipped unconditionally, use an exclude block with a condition and the excluded actions instead.\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, lint.NewWriter(&buf, lint.FormatJSON, dir, "terragrunt").Write(linter.Rules(), findings))
+	assert.JSONEq(t, `[{
+		"rule": "broad-skip",
+		"severity": "warning",
+		"message": "The unit is skipped unconditionally, use an exclude block with a condition and the excluded actions instead.",
+		"file": "unit/terragrunt.hcl",
+		"line": 2,
+		"column": 1
+	}]`, buf.String())
+
+	buf.Reset()
+	require.NoError(t, lint.NewWriter(&buf, lint.FormatSARIF, dir, "terragrunt").Write(linter.Rules(), findings))
+
+	var sarif struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name  string `json:"name"`
+					Rules []struct {
+						ID string `json:"id"`
+					} `json:"rules"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				Level     string `json:"level"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+						Region struct {
+							StartLine int `json:"startLine"`
+						} `json:"region"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &sarif))
+	assert.Equal(t, "2.1.0", sarif.Version)
+	require.Len(t, sarif.Runs, 1)
+
+	run := sarif.Runs[0]
+	assert.Equal(t, "terragrunt", run.Tool.Driver.Name)
+	assert.Len(t, run.Tool.Driver.Rules, len(linter.Rules()))
+	require.Len(t, run.Results, 1)
+	assert.Equal(t, "broad-skip", run.Results[0].RuleID)
+	assert.Equal(t, "warning", run.Results[0].Level)
+	assert.Equal(t, "unit/terragrunt.hcl", run.Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, 2, run.Results[0].Locations[0].PhysicalLocation.Region.StartLine)
+}
@@ -0,0 +1,247 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// Format is the output format of the findings.
+type Format string
+
+const (
+	FormatText  Format = "text"
+	FormatJSON  Format = "json"
+	FormatSARIF Format = "sarif"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	// sarifSrcRoot is the URI base ID the artifact locations are relative to.
+	sarifSrcRoot = "%SRCROOT%"
+)
+
+// ParseFormat parses the given output format, returning an error if it is not a valid one.
+func ParseFormat(str string) (Format, error) {
+	switch format := Format(str); format {
+	case FormatText, FormatJSON, FormatSARIF:
+		return format, nil
+	}
+
+	return "", errors.Errorf("invalid lint output format %q, must be one of %q, %q or %q", str, FormatText, FormatJSON, FormatSARIF)
+}
+
+// Writer writes the findings in the given format, with the file paths relative to the given directory.
+type Writer struct {
+	w      io.Writer
+	format Format
+	dir    string
+	name   string
+}
+
+// NewWriter returns a new writer of the findings. The name is the tool name written to the SARIF log.
+func NewWriter(w io.Writer, format Format, dir, name string) *Writer {
+	return &Writer{w: w, format: format, dir: dir, name: name}
+}
+
+// Write writes the given findings of the given rules.
+func (writer *Writer) Write(rules []Rule, findings []Finding) error {
+	switch writer.format {
+	case FormatJSON:
+		return writer.writeJSON(findings)
+	case FormatSARIF:
+		return writer.writeSARIF(rules, findings)
+	default:
+		return writer.writeText(findings)
+	}
+}
+
+func (writer *Writer) writeText(findings []Finding) error {
+	for _, finding := range findings {
+		start := finding.Range.Start
+		if _, err := fmt.Fprintf(writer.w, "%s:%d:%d: %s [%s] %s\n", writer.relPath(finding.Range.Filename), start.Line, start.Column, finding.Severity, finding.Rule, finding.Message); err != nil {
+			return errors.New(err)
+		}
+	}
+
+	return nil
+}
+
+type jsonFinding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+	File     string   `json:"file"`
+	Line     int      `json:"line"`
+	Column   int      `json:"column"`
+}
+
+func (writer *Writer) writeJSON(findings []Finding) error {
+	out := make([]jsonFinding, 0, len(findings))
+
+	for _, finding := range findings {
+		out = append(out, jsonFinding{
+			Rule:     finding.Rule,
+			Severity: finding.Severity,
+			Message:  finding.Message,
+			File:     writer.relPath(finding.Range.Filename),
+			Line:     finding.Range.Start.Line,
+			Column:   finding.Range.Start.Column,
+		})
+	}
+
+	return writer.encode(out)
+}
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	EndLine     int `json:"endLine"`
+	EndColumn   int `json:"endColumn"`
+}
+
+func (writer *Writer) writeSARIF(rules []Rule, findings []Finding) error {
+	driver := sarifDriver{Name: writer.name, Rules: []sarifRule{}}
+
+	for _, rule := range rules {
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:                   rule.Name(),
+			ShortDescription:     sarifMessage{Text: rule.Description()},
+			DefaultConfiguration: sarifConfiguration{Level: sarifLevel(rule.Severity())},
+		})
+	}
+
+	results := make([]sarifResult, 0, len(findings))
+
+	for _, finding := range findings {
+		// Files that can't be parsed are reported before the rules run, so the syntax rule is only listed when needed.
+		if finding.Rule == SyntaxRuleName && !hasSARIFRule(driver.Rules, SyntaxRuleName) {
+			driver.Rules = append(driver.Rules, sarifRule{
+				ID:                   SyntaxRuleName,
+				ShortDescription:     sarifMessage{Text: "Files must be valid HCL."},
+				DefaultConfiguration: sarifConfiguration{Level: sarifLevel(SeverityError)},
+			})
+		}
+
+		rng := finding.Range
+
+		results = append(results, sarifResult{
+			RuleID:  finding.Rule,
+			Level:   sarifLevel(finding.Severity),
+			Message: sarifMessage{Text: finding.Message},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: writer.relPath(rng.Filename), URIBaseID: sarifSrcRoot},
+					Region: sarifRegion{
+						StartLine:   rng.Start.Line,
+						StartColumn: rng.Start.Column,
+						EndLine:     rng.End.Line,
+						EndColumn:   rng.End.Column,
+					},
+				},
+			}},
+		})
+	}
+
+	return writer.encode(sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	})
+}
+
+func (writer *Writer) encode(v any) error {
+	encoder := json.NewEncoder(writer.w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(v); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+func (writer *Writer) relPath(path string) string {
+	if rel, err := filepath.Rel(writer.dir, path); err == nil {
+		path = rel
+	}
+
+	return filepath.ToSlash(path)
+}
+
+func hasSARIFRule(rules []sarifRule, id string) bool {
+	for _, rule := range rules {
+		if rule.ID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sarifLevel(severity Severity) string {
+	if severity == SeverityError {
+		return "error"
+	}
+
+	return "warning"
+}
@@ -0,0 +1,200 @@
+package lint
+
+import (
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	UnpinnedSourceRuleName     = "unpinned-source"
+	MissingRemoteStateRuleName = "missing-remote-state"
+	DeprecatedFunctionRuleName = "deprecated-function"
+	BroadSkipRuleName          = "broad-skip"
+)
+
+// builtinRules are the rules run by all the linters, unless disabled in the lint config.
+var builtinRules = []Rule{
+	&unpinnedSourceRule{},
+	&missingRemoteStateRule{},
+	&deprecatedFunctionRule{},
+	&broadSkipRule{},
+}
+
+// remoteSourcePrefixes are the prefixes of the sources fetched from a version control system.
+var remoteSourcePrefixes = []string{"git::", "git@", "github.com/", "bitbucket.org/", "hg::"}
+
+// unpinnedSourceRule reports the terraform sources fetched from a version control system without a ref, and the
+// sources fetched from a registry without a version, which change whenever the upstream module changes.
+type unpinnedSourceRule struct{}
+
+func (rule *unpinnedSourceRule) Name() string { return UnpinnedSourceRuleName }
+
+func (rule *unpinnedSourceRule) Description() string {
+	return "Remote terraform sources must be pinned to a ref or a version."
+}
+
+func (rule *unpinnedSourceRule) Severity() Severity { return SeverityWarning }
+
+func (rule *unpinnedSourceRule) Check(file *File) []Finding {
+	attr := terraformSourceAttribute(file.Body)
+	if attr == nil {
+		return nil
+	}
+
+	// The source text is checked rather than the value, so the sources built with interpolations are checked too.
+	source := strings.Trim(file.SourceText(attr.Expr.Range()), `"`)
+
+	switch {
+	case strings.HasPrefix(source, "tfr:"):
+		if !strings.Contains(source, "version=") {
+			return []Finding{{
+				Message: "The registry source is not pinned to a version, add a `version` query parameter.",
+				Range:   attr.Expr.Range(),
+			}}
+		}
+	case isVCSSource(source):
+		if !strings.Contains(source, "ref=") {
+			return []Finding{{
+				Message: "The source is not pinned to a ref, add a `ref` query parameter with a tag or a commit.",
+				Range:   attr.Expr.Range(),
+			}}
+		}
+	}
+
+	return nil
+}
+
+func isVCSSource(source string) bool {
+	for _, prefix := range remoteSourcePrefixes {
+		if strings.HasPrefix(source, prefix) {
+			return true
+		}
+	}
+
+	source, _, _ = strings.Cut(source, "?")
+
+	return strings.HasSuffix(source, ".git") || strings.Contains(source, ".git//")
+}
+
+// missingRemoteStateRule reports the units that neither configure nor include a remote state, and so store their
+// state locally.
+type missingRemoteStateRule struct{}
+
+func (rule *missingRemoteStateRule) Name() string { return MissingRemoteStateRuleName }
+
+func (rule *missingRemoteStateRule) Description() string {
+	return "Units must configure a remote state, directly or through an include."
+}
+
+func (rule *missingRemoteStateRule) Severity() Severity { return SeverityWarning }
+
+func (rule *missingRemoteStateRule) Check(file *File) []Finding {
+	if !file.IsUnitConfig() {
+		return nil
+	}
+
+	if _, ok := file.Body.Attributes["remote_state"]; ok {
+		return nil
+	}
+
+	for _, block := range file.Body.Blocks {
+		switch block.Type {
+		case "remote_state", "include":
+			return nil
+		case "generate":
+			// A backend may also be generated directly.
+			if len(block.Labels) > 0 && strings.Contains(block.Labels[0], "backend") {
+				return nil
+			}
+		}
+	}
+
+	return []Finding{{
+		Message: "The unit does not configure or include a remote_state, so its state is stored locally.",
+		Range:   hcl.Range{Filename: file.Path, Start: hcl.InitialPos, End: hcl.InitialPos},
+	}}
+}
+
+// deprecatedFunctionRule reports the calls of deprecated functions.
+type deprecatedFunctionRule struct{}
+
+func (rule *deprecatedFunctionRule) Name() string { return DeprecatedFunctionRuleName }
+
+func (rule *deprecatedFunctionRule) Description() string {
+	return "Deprecated functions must not be used."
+}
+
+func (rule *deprecatedFunctionRule) Severity() Severity { return SeverityWarning }
+
+func (rule *deprecatedFunctionRule) Check(file *File) []Finding {
+	var findings []Finding
+
+	visitFunctionCalls(file.Body, func(call *hclsyntax.FunctionCallExpr) {
+		if call.Name != "find_in_parent_folders" {
+			return
+		}
+
+		// Calling find_in_parent_folders without arguments, or with the name of the unit config, is deprecated by
+		// the root-terragrunt-hcl strict control.
+		if len(call.Args) == 0 || strings.Trim(file.SourceText(call.Args[0].Range()), `"`) == UnitConfigFileName {
+			findings = append(findings, Finding{
+				Message: "Using find_in_parent_folders to find a root terragrunt.hcl is deprecated, rename the root config, e.g. to root.hcl, and pass its name to the function.",
+				Range:   call.Range(),
+			})
+		}
+	})
+
+	return findings
+}
+
+// broadSkipRule reports the configs skipping the unit unconditionally, which also skips all the units including them.
+type broadSkipRule struct{}
+
+func (rule *broadSkipRule) Name() string { return BroadSkipRuleName }
+
+func (rule *broadSkipRule) Description() string {
+	return "Units must not be skipped unconditionally."
+}
+
+func (rule *broadSkipRule) Severity() Severity { return SeverityWarning }
+
+func (rule *broadSkipRule) Check(file *File) []Finding {
+	attr, ok := file.Body.Attributes["skip"]
+	if !ok {
+		return nil
+	}
+
+	if val, diags := attr.Expr.Value(nil); diags.HasErrors() || !val.Type().Equals(cty.Bool) || !val.IsKnown() || val.IsNull() || !val.True() {
+		return nil
+	}
+
+	return []Finding{{
+		Message: "The unit is skipped unconditionally, use an exclude block with a condition and the excluded actions instead.",
+		Range:   attr.Range(),
+	}}
+}
+
+// terraformSourceAttribute returns the source attribute of the terraform block of the given body, if any.
+func terraformSourceAttribute(body *hclsyntax.Body) *hclsyntax.Attribute {
+	for _, block := range body.Blocks {
+		if block.Type == "terraform" {
+			return block.Body.Attributes["source"]
+		}
+	}
+
+	return nil
+}
+
+// visitFunctionCalls calls the given function for all the function calls of the given body.
+func visitFunctionCalls(body *hclsyntax.Body, fn func(call *hclsyntax.FunctionCallExpr)) {
+	_ = hclsyntax.VisitAll(body, func(node hclsyntax.Node) hcl.Diagnostics {
+		if call, ok := node.(*hclsyntax.FunctionCallExpr); ok {
+			fn(call)
+		}
+
+		return nil
+	})
+}
@@ -23,6 +23,7 @@ type Cmd struct {
 	*exec.Cmd
 	filename           string
 	forwardSignalDelay time.Duration
+	killDelay          time.Duration
 	usePTY             bool
 }
 
@@ -72,6 +73,7 @@ func (cmd *Cmd) Start() error {
 //     Thus we will send the signal to the executed command with a delay or immediately if Terragrunt receives this same signal again.
 //  2. If the context does not contain any causes, this means that there was some failure and we need to terminate all executed commands,
 //     in this situation we are sure that commands did not receive any signal, so we send them an interrupt signal immediately.
+//     If the context timed out, the command is killed if it is still running cmd.killDelay after the interrupt signal.
 func (cmd *Cmd) RegisterGracefullyShutdown(ctx context.Context) func() {
 	ctxShutdown, cancelShutdown := context.WithCancel(context.Background())
 
@@ -86,6 +88,10 @@ func (cmd *Cmd) RegisterGracefullyShutdown(ctx context.Context) func() {
 			}
 
 			cmd.SendSignal(cmd.interruptSignal)
+
+			if cmd.killDelay > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				cmd.killAfterDelay(ctxShutdown)
+			}
 		}
 	}()
 
@@ -120,6 +126,22 @@ func (cmd *Cmd) ForwardSignal(ctx context.Context, sig os.Signal) {
 	cmd.SendSignal(sig)
 }
 
+// killAfterDelay kills the executed command if it is still running cmd.killDelay after being interrupted, i.e. if the
+// given context, canceled once the command exits, isn't done by then.
+func (cmd *Cmd) killAfterDelay(ctx context.Context) {
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(cmd.killDelay):
+	}
+
+	cmd.logger.Warnf("%s did not exit within %s of being interrupted, killing it", cmd.filename, cmd.killDelay)
+
+	if err := cmd.Process.Kill(); err != nil {
+		cmd.logger.Errorf("Failed to kill %s: %v", cmd.filename, err)
+	}
+}
+
 // SendSignal sends the given `sig` to the executed command.
 func (cmd *Cmd) SendSignal(sig os.Signal) {
 	cmd.logger.Debugf("%s signal is forwarded to %s", cases.Title(language.English).String(sig.String()), cmd.filename)
@@ -39,3 +39,10 @@ func WithForwardSignalDelay(delay time.Duration) Option {
 		cmd.forwardSignalDelay = delay
 	}
 }
+
+// WithKillDelay sets the delay after which the Cmd, interrupted as its context timed out, is killed if still running.
+func WithKillDelay(delay time.Duration) Option {
+	return func(cmd *Cmd) {
+		cmd.killDelay = delay
+	}
+}
@@ -4,16 +4,17 @@
 //
 // The algorithm for populating the queue is as follows:
 //  1. Given a list of discovered configurations, start with an empty queue.
-//  2. Sort configurations alphabetically to ensure deterministic ordering of independent items.
+//  2. Sort configurations by priority, the highest first, then by weight, the heaviest first, then alphabetically to
+//     ensure deterministic ordering of independent items.
 //  3. For each discovered configuration:
 //     a. If the configuration has no dependencies, append it to the queue.
 //     b. Otherwise, find the position after its last dependency.
-//     c. Among items that depend on the same dependency, maintain alphabetical order.
+//     c. Among items that depend on the same dependency, maintain priority, then weight, then alphabetical, order.
 //
 // The resulting queue will have:
 // - Configurations with no dependencies at the front
 // - Configurations with dependents are ordered after their dependencies
-// - Alphabetical ordering only between items that share the same dependencies
+// - Priority, then weight, then alphabetical, ordering only between items that share the same dependencies
 //
 // During operations like applies, entries will be dequeued from the front of the queue and run.
 // During operations like destroys, entries will be dequeued from the back of the queue and run.
@@ -26,7 +27,9 @@ import (
 	"errors"
 	"slices"
 	"sort"
+	"time"
 
+	"github.com/gruntwork-io/terragrunt/config"
 	"github.com/gruntwork-io/terragrunt/internal/discovery"
 )
 
@@ -54,6 +57,7 @@ const (
 	StatusSucceeded
 	StatusFailed
 	StatusEarlyExit // Terminal status set on Entries in case of fail fast mode
+	StatusSkipped   // Terminal status set on Entries skipped outside their apply windows, and on the Entries waiting for them
 )
 
 // UpdateBlocked updates the status of the entry to blocked, if it is blocked.
@@ -141,9 +145,42 @@ func (e *Entry) IsUp() bool {
 type Queue struct {
 	Entries  Entries
 	FailFast bool
+	// FailurePolicies are the modes of the failure policies of the configurations, by path, see
+	// config.FailurePolicyConfig. Configurations without a mode halt their dependents when they fail.
+	FailurePolicies map[string]string
+	// MaxFailures is the number of failed entries after which all the entries that aren't running or done are marked as
+	// early exit, whatever the failure policies. 0 never does.
+	MaxFailures int
 	// IgnoreDependencyOrder, if set to true, causes the queue to ignore dependencies when fetching ready entries.
 	// When enabled, GetReadyWithDependencies will return all entries with StatusReady, regardless of dependency status.
 	IgnoreDependencyOrder bool
+	// weights are the expected durations of the runs of the configurations, by path, so the longest running
+	// configurations of a level are run first, to minimize the total duration of the runs.
+	weights map[string]time.Duration
+	// priorities are the priorities of the configurations, by path, so the configurations of a level with the highest
+	// priority are run first, whatever their weights.
+	priorities map[string]int
+	// failures is the number of failed entries.
+	failures int
+}
+
+// Option is a function that modifies a Queue while it is created.
+type Option func(*Queue)
+
+// WithWeights sets the expected durations of the runs of the configurations, by path. Configurations without a weight
+// are expected to be the quickest.
+func WithWeights(weights map[string]time.Duration) Option {
+	return func(q *Queue) {
+		q.weights = weights
+	}
+}
+
+// WithPriorities sets the priorities of the configurations, by path. Configurations without a priority have the
+// priority 0.
+func WithPriorities(priorities map[string]int) Option {
+	return func(q *Queue) {
+		q.priorities = priorities
+	}
 }
 
 type Entries []*Entry
@@ -191,16 +228,28 @@ func (q *Queue) EntryByPath(path string) *Entry {
 //     - Otherwise, it is considered a "down" command, and will be inserted at the back of the queue,
 //     after its dependents.
 //
-//  2. The name of the configuration. Configurations of the same "level" are sorted alphabetically.
+//  2. The priority of the configuration, see WithPriorities. Configurations of the same "level" are sorted by
+//     priority, the highest first.
+//
+//  3. The weight of the configuration, see WithWeights. Configurations of the same "level" and priority are sorted by
+//     weight, the heaviest first.
+//
+//  4. The name of the configuration. Configurations of the same "level", priority and weight are sorted alphabetically.
 //
 // Passing configurations that haven't been checked for cycles in their dependency graph is unsafe.
 // If any cycles are present, the queue construction will halt after N
 // iterations, where N is the number of discovered configs, and throw an error.
-func NewQueue(discovered discovery.DiscoveredConfigs) (*Queue, error) {
+func NewQueue(discovered discovery.DiscoveredConfigs, opts ...Option) (*Queue, error) {
+	q := &Queue{
+		Entries: Entries{},
+	}
+
+	for _, opt := range opts {
+		opt(q)
+	}
+
 	if len(discovered) == 0 {
-		return &Queue{
-			Entries: Entries{},
-		}, nil
+		return q, nil
 	}
 
 	// First, we need to take all the discovered configs
@@ -215,9 +264,7 @@ func NewQueue(discovered discovery.DiscoveredConfigs) (*Queue, error) {
 		entries = append(entries, entry)
 	}
 
-	q := &Queue{
-		Entries: entries,
-	}
+	q.Entries = entries
 
 	// readyPending returns the index of the first pending entry if there is one,
 	// or -1 if there are no pending entries.
@@ -235,6 +282,14 @@ func NewQueue(discovered discovery.DiscoveredConfigs) (*Queue, error) {
 			}
 
 			if entries[i].Status == StatusUnsorted && entries[j].Status == StatusUnsorted {
+				if priorityI, priorityJ := q.priorities[entries[i].Config.Path], q.priorities[entries[j].Config.Path]; priorityI != priorityJ {
+					return priorityI > priorityJ
+				}
+
+				if weightI, weightJ := q.weights[entries[i].Config.Path], q.weights[entries[j].Config.Path]; weightI != weightJ {
+					return weightI > weightJ
+				}
+
 				return entries[i].Config.Path < entries[j].Config.Path
 			}
 
@@ -303,7 +358,7 @@ func (q *Queue) GetReadyWithDependencies() []*Entry {
 
 			for _, dep := range e.Config.Dependencies {
 				depEntry := q.EntryByPath(dep.Path)
-				if depEntry == nil || depEntry.Status != StatusSucceeded {
+				if depEntry == nil || !q.isDone(depEntry) {
 					allDepsReady = false
 					break
 				}
@@ -315,7 +370,7 @@ func (q *Queue) GetReadyWithDependencies() []*Entry {
 
 			continue
 		}
-		// Down logic: all dependents must be succeeded
+		// Down logic: all dependents must be done
 		allDependentsReady := true
 
 		for _, other := range q.Entries {
@@ -325,7 +380,7 @@ func (q *Queue) GetReadyWithDependencies() []*Entry {
 
 			for _, dep := range other.Config.Dependencies {
 				if dep.Path == e.Config.Path {
-					if other.Status != StatusSucceeded {
+					if !q.isDone(other) {
 						allDependentsReady = false
 						break
 					}
@@ -345,15 +400,25 @@ func (q *Queue) GetReadyWithDependencies() []*Entry {
 	return out
 }
 
-// FailEntry marks the entry as failed and updates related entries if needed.
+// FailEntry marks the entry as failed and updates related entries if needed, depending on its failure policy.
 // For up commands, this marks entries that come after this one as early exit.
 // For destroy/down commands, this marks entries that come before this one as early exit.
+// With the continue failure policy, no entry is marked as early exit, and with the halt-run failure policy, in fail
+// fast mode, or once MaxFailures entries failed, all the entries that aren't running or done are.
 // Use only for failure transitions. For other status changes, set Status directly.
 func (q *Queue) FailEntry(e *Entry) {
 	e.Status = StatusFailed
+	q.failures++
+
+	policy := q.FailurePolicies[e.Config.Path]
+	maxFailuresReached := q.MaxFailures > 0 && q.failures >= q.MaxFailures
+
+	if policy == config.FailurePolicyContinue && !q.FailFast && !maxFailuresReached {
+		return
+	}
 
 	// If this entry failed and has dependents/dependencies, we need to propagate the failure.
-	if q.FailFast {
+	if q.FailFast || policy == config.FailurePolicyHaltRun || maxFailuresReached {
 		for _, n := range q.Entries {
 			if isTerminalOrRunning(n.Status) {
 				continue
@@ -366,15 +431,30 @@ func (q *Queue) FailEntry(e *Entry) {
 	}
 
 	if e.IsUp() {
-		q.earlyExitDependents(e)
+		q.markDependents(e, StatusEarlyExit)
 		return
 	}
 
-	q.earlyExitDependencies(e)
+	q.markDependencies(e, StatusEarlyExit)
+}
+
+// SkipEntry marks the entry as skipped, e.g. outside its apply window, along with the entries waiting for it, as they
+// would run against an entry that didn't run: for up commands the entries that come after this one, and for
+// destroy/down commands the entries that come before this one. It returns the entries waiting for it.
+func (q *Queue) SkipEntry(e *Entry) []*Entry {
+	e.Status = StatusSkipped
+
+	if e.IsUp() {
+		return q.markDependents(e, StatusSkipped)
+	}
+
+	return q.markDependencies(e, StatusSkipped)
 }
 
-// earlyExitDependents - Recursively mark all entries that are dependent on this one as early exit.
-func (q *Queue) earlyExitDependents(e *Entry) {
+// markDependents - Recursively mark all entries that are dependent on this one with the given status, and return them.
+func (q *Queue) markDependents(e *Entry, status Status) []*Entry {
+	var marked []*Entry
+
 	for _, entry := range q.Entries {
 		if entry.Config.Dependencies == nil {
 			continue
@@ -386,22 +466,28 @@ func (q *Queue) earlyExitDependents(e *Entry) {
 					continue
 				}
 
-				entry.Status = StatusEarlyExit
+				entry.Status = status
+				marked = append(marked, entry)
 
-				q.earlyExitDependents(entry)
+				marked = append(marked, q.markDependents(entry, status)...)
 
 				break
 			}
 		}
 	}
+
+	return marked
 }
 
-// earlyExitDependencies - Recursively mark all entries that are dependencies on this one as early exit.
-func (q *Queue) earlyExitDependencies(e *Entry) {
+// markDependencies - Recursively mark all entries that are dependencies on this one with the given status, and return
+// them.
+func (q *Queue) markDependencies(e *Entry, status Status) []*Entry {
 	if e.Config.Dependencies == nil {
-		return
+		return nil
 	}
 
+	var marked []*Entry
+
 	for _, dep := range e.Config.Dependencies {
 		depEntry := q.EntryByPath(dep.Path)
 		if depEntry == nil {
@@ -412,9 +498,12 @@ func (q *Queue) earlyExitDependencies(e *Entry) {
 			continue
 		}
 
-		depEntry.Status = StatusEarlyExit
-		q.earlyExitDependencies(depEntry)
+		depEntry.Status = status
+		marked = append(marked, depEntry)
+		marked = append(marked, q.markDependencies(depEntry, status)...)
 	}
+
+	return marked
 }
 
 // Finished checks if all entries in the queue are in a terminal state (i.e., not pending, blocked, ready, or running).
@@ -428,6 +517,12 @@ func (q *Queue) Finished() bool {
 	return true
 }
 
+// isDone returns true if the entry doesn't hold back the entries waiting for it: it succeeded, or failed with the
+// continue failure policy.
+func (q *Queue) isDone(e *Entry) bool {
+	return e.Status == StatusSucceeded || (e.Status == StatusFailed && q.FailurePolicies[e.Config.Path] == config.FailurePolicyContinue)
+}
+
 // RemainingDeps Helper to calculate remaining dependencies for an entry.
 func (q *Queue) RemainingDeps(e *Entry) int {
 	if e.Config == nil || e.Config.Dependencies == nil {
@@ -438,7 +533,7 @@ func (q *Queue) RemainingDeps(e *Entry) int {
 
 	for _, dep := range e.Config.Dependencies {
 		depEntry := q.EntryByPath(dep.Path)
-		if depEntry == nil || depEntry.Status != StatusSucceeded {
+		if depEntry == nil || !q.isDone(depEntry) {
 			count++
 		}
 	}
@@ -451,7 +546,7 @@ func isTerminal(status Status) bool {
 	switch status {
 	case StatusPending, StatusBlocked, StatusUnsorted, StatusReady, StatusRunning:
 		return false
-	case StatusSucceeded, StatusFailed, StatusEarlyExit:
+	case StatusSucceeded, StatusFailed, StatusEarlyExit, StatusSkipped:
 		return true
 	}
 
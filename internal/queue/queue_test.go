@@ -2,7 +2,9 @@ package queue_test
 
 import (
 	"testing"
+	"time"
 
+	"github.com/gruntwork-io/terragrunt/config"
 	"github.com/gruntwork-io/terragrunt/internal/discovery"
 	"github.com/gruntwork-io/terragrunt/internal/queue"
 	"github.com/stretchr/testify/assert"
@@ -580,6 +582,84 @@ func TestQueue_AdvancedDependency_BFails_NoFailFast(t *testing.T) {
 	assert.Empty(t, readyEntries, "After C is done, no entries should be ready")
 }
 
+func TestQueue_AdvancedDependency_BFails_ContinuePolicy(t *testing.T) {
+	t.Parallel()
+	configs := buildMultiLevelDependencyTree()
+
+	q, err := queue.NewQueue(configs)
+	require.NoError(t, err)
+	q.FailurePolicies = map[string]string{"B": config.FailurePolicyContinue}
+
+	q.EntryByPath("A").Status = queue.StatusSucceeded
+
+	entryB := q.EntryByPath("B")
+	entryB.Status = queue.StatusRunning
+	q.FailEntry(entryB)
+
+	// D and E depend on B, which fails with the continue policy, so they run anyway
+	assert.Equal(t, queue.StatusFailed, entryB.Status)
+	assert.Equal(t, queue.StatusReady, q.EntryByPath("D").Status)
+	assert.Equal(t, queue.StatusReady, q.EntryByPath("E").Status)
+	assert.Equal(t, 0, q.RemainingDeps(q.EntryByPath("D")))
+
+	paths := []string{}
+	for _, entry := range q.GetReadyWithDependencies() {
+		paths = append(paths, entry.Config.Path)
+	}
+
+	assert.ElementsMatch(t, []string{"C", "D", "E"}, paths)
+}
+
+func TestQueue_AdvancedDependency_BFails_HaltRunPolicy(t *testing.T) {
+	t.Parallel()
+	configs := buildMultiLevelDependencyTree()
+
+	q, err := queue.NewQueue(configs)
+	require.NoError(t, err)
+	q.FailurePolicies = map[string]string{"B": config.FailurePolicyHaltRun}
+
+	q.EntryByPath("A").Status = queue.StatusSucceeded
+
+	entryB := q.EntryByPath("B")
+	entryB.Status = queue.StatusRunning
+	q.FailEntry(entryB)
+
+	// B fails with the halt-run policy, so C exits early too, although it doesn't depend on B
+	assert.Equal(t, queue.StatusFailed, entryB.Status)
+	assert.Equal(t, queue.StatusEarlyExit, q.EntryByPath("C").Status)
+	assert.Equal(t, queue.StatusEarlyExit, q.EntryByPath("D").Status)
+	assert.Equal(t, queue.StatusEarlyExit, q.EntryByPath("E").Status)
+	assert.True(t, q.Finished())
+}
+
+func TestQueue_AdvancedDependency_MaxFailures(t *testing.T) {
+	t.Parallel()
+	configs := buildMultiLevelDependencyTree()
+
+	q, err := queue.NewQueue(configs)
+	require.NoError(t, err)
+	q.FailurePolicies = map[string]string{"B": config.FailurePolicyContinue, "C": config.FailurePolicyContinue}
+	q.MaxFailures = 2
+
+	q.EntryByPath("A").Status = queue.StatusSucceeded
+
+	entryB := q.EntryByPath("B")
+	entryB.Status = queue.StatusRunning
+	entryC := q.EntryByPath("C")
+	entryC.Status = queue.StatusRunning
+
+	// B fails with the continue policy, so its dependents are still ready
+	q.FailEntry(entryB)
+	assert.Equal(t, queue.StatusReady, q.EntryByPath("D").Status)
+
+	// C fails too, reaching the max failures, so no more entries run, whatever the failure policies
+	q.FailEntry(entryC)
+	assert.Equal(t, queue.StatusFailed, entryC.Status)
+	assert.Equal(t, queue.StatusEarlyExit, q.EntryByPath("D").Status)
+	assert.Equal(t, queue.StatusEarlyExit, q.EntryByPath("E").Status)
+	assert.True(t, q.Finished())
+}
+
 func TestQueue_FailFast_SequentialOrder(t *testing.T) {
 	t.Parallel()
 	// A -> B -> C, where A fails and fail-fast is enabled
@@ -667,6 +747,39 @@ func TestFailEntry_DirectAndRecursive(t *testing.T) {
 	assert.Equal(t, queue.StatusEarlyExit, q.EntryByPath("D").Status)
 }
 
+func TestSkipEntry(t *testing.T) {
+	t.Parallel()
+	// Build a graph: A -> B -> C, A -> D, E
+	cfgA := &discovery.DiscoveredConfig{Path: "A"}
+	cfgB := &discovery.DiscoveredConfig{Path: "B", Dependencies: []*discovery.DiscoveredConfig{cfgA}}
+	cfgC := &discovery.DiscoveredConfig{Path: "C", Dependencies: []*discovery.DiscoveredConfig{cfgB}}
+	cfgD := &discovery.DiscoveredConfig{Path: "D", Dependencies: []*discovery.DiscoveredConfig{cfgA}}
+	cfgE := &discovery.DiscoveredConfig{Path: "E"}
+	configs := []*discovery.DiscoveredConfig{cfgA, cfgB, cfgC, cfgD, cfgE}
+
+	q, err := queue.NewQueue(configs)
+	require.NoError(t, err)
+
+	// Skipping B skips C, which waits for it, without failing the run.
+	entryA := q.EntryByPath("A")
+	entryA.Status = queue.StatusSucceeded
+
+	skipped := q.SkipEntry(q.EntryByPath("B"))
+	require.Len(t, skipped, 1)
+	assert.Equal(t, "C", skipped[0].Config.Path)
+	assert.Equal(t, queue.StatusSkipped, q.EntryByPath("B").Status)
+	assert.Equal(t, queue.StatusSkipped, q.EntryByPath("C").Status)
+
+	readyEntries := q.GetReadyWithDependencies()
+	paths := []string{}
+
+	for _, entry := range readyEntries {
+		paths = append(paths, entry.Config.Path)
+	}
+
+	assert.ElementsMatch(t, []string{"D", "E"}, paths)
+}
+
 func TestQueue_DestroyFail_PropagatesToDependencies_NonFailFast(t *testing.T) {
 	t.Parallel()
 	// Build a graph: A -> B -> C, A -> D
@@ -774,3 +887,60 @@ func TestDestroyCommandQueueOrder_MultiLevelDependencyTree(t *testing.T) {
 	expected := []string{"C", "D", "E", "B", "A"}
 	assert.Equal(t, expected, processed)
 }
+
+func TestWeightsOrderEntriesWithinDependencyLevel(t *testing.T) {
+	t.Parallel()
+
+	// 'a', 'b' and 'c' have no deps, 'd' and 'e' depend on 'a'
+	aCfg := &discovery.DiscoveredConfig{Path: "a", Dependencies: []*discovery.DiscoveredConfig{}}
+	bCfg := &discovery.DiscoveredConfig{Path: "b", Dependencies: []*discovery.DiscoveredConfig{}}
+	cCfg := &discovery.DiscoveredConfig{Path: "c", Dependencies: []*discovery.DiscoveredConfig{}}
+	dCfg := &discovery.DiscoveredConfig{Path: "d", Dependencies: []*discovery.DiscoveredConfig{aCfg}}
+	eCfg := &discovery.DiscoveredConfig{Path: "e", Dependencies: []*discovery.DiscoveredConfig{aCfg}}
+
+	configs := []*discovery.DiscoveredConfig{aCfg, bCfg, cCfg, dCfg, eCfg}
+
+	q, err := queue.NewQueue(configs, queue.WithWeights(map[string]time.Duration{
+		"b": time.Minute,
+		"c": time.Hour,
+		"e": time.Second,
+	}))
+	require.NoError(t, err)
+
+	paths := make([]string, 0, len(q.Entries))
+	for _, entry := range q.Entries {
+		paths = append(paths, entry.Config.Path)
+	}
+
+	// The heaviest entries of each level come first, a weight never moves an entry before its dependencies
+	assert.Equal(t, []string{"c", "b", "a", "e", "d"}, paths)
+}
+
+func TestPrioritiesOrderEntriesWithinDependencyLevel(t *testing.T) {
+	t.Parallel()
+
+	// 'a', 'b' and 'c' have no deps, 'd' and 'e' depend on 'a'
+	aCfg := &discovery.DiscoveredConfig{Path: "a", Dependencies: []*discovery.DiscoveredConfig{}}
+	bCfg := &discovery.DiscoveredConfig{Path: "b", Dependencies: []*discovery.DiscoveredConfig{}}
+	cCfg := &discovery.DiscoveredConfig{Path: "c", Dependencies: []*discovery.DiscoveredConfig{}}
+	dCfg := &discovery.DiscoveredConfig{Path: "d", Dependencies: []*discovery.DiscoveredConfig{aCfg}}
+	eCfg := &discovery.DiscoveredConfig{Path: "e", Dependencies: []*discovery.DiscoveredConfig{aCfg}}
+
+	configs := []*discovery.DiscoveredConfig{aCfg, bCfg, cCfg, dCfg, eCfg}
+
+	q, err := queue.NewQueue(configs, queue.WithPriorities(map[string]int{
+		"b": 10,
+		"e": 5,
+	}), queue.WithWeights(map[string]time.Duration{
+		"c": time.Hour,
+	}))
+	require.NoError(t, err)
+
+	paths := make([]string, 0, len(q.Entries))
+	for _, entry := range q.Entries {
+		paths = append(paths, entry.Config.Path)
+	}
+
+	// The highest priority entries of each level come first, whatever the weights, then the heaviest ones
+	assert.Equal(t, []string{"b", "c", "a", "e", "d"}, paths)
+}
@@ -0,0 +1,22 @@
+package ratelimit
+
+import "fmt"
+
+// InvalidLimitError is returned when a limit isn't of the form `<rate>` or `<rate>:<burst>`.
+type InvalidLimitError struct {
+	Value  string
+	Reason string
+}
+
+func (err InvalidLimitError) Error() string {
+	return fmt.Sprintf("invalid API rate limit %q: %s", err.Value, err.Reason)
+}
+
+// InvalidKeyError is returned when the key of a limit is neither a provider nor a provider and one of its services.
+type InvalidKeyError struct {
+	Key string
+}
+
+func (err InvalidKeyError) Error() string {
+	return fmt.Sprintf("invalid API rate limit key %q, expected a provider, e.g. aws, or a provider and one of its services, e.g. aws/sts", err.Key)
+}
@@ -0,0 +1,140 @@
+// Package ratelimit provides token buckets shared by all the units of a run, throttling their aggregate rate of calls
+// to cloud APIs, e.g. to stay below the API rate limits of an AWS account running many units in parallel.
+//
+// The buckets are keyed by provider, e.g. `aws`, throttling all the calls to the APIs of the provider, or by provider
+// and service, e.g. `aws/sts`, throttling the calls to a single service of the provider. A call to a service waits for
+// a token of both the bucket of the service and the bucket of its provider, if any.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"golang.org/x/time/rate"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+const (
+	// ProviderAWS is the provider of the calls Terragrunt makes to the AWS APIs, e.g. to bootstrap an S3 backend.
+	ProviderAWS = "aws"
+
+	limitSeparator = ":"
+	keySeparator   = "/"
+)
+
+var keyRegexp = regexp.MustCompile(`^[a-z0-9_-]+(/[a-z0-9_-]+)?$`)
+
+// Limit is the rate of a bucket, in calls per second, and its burst, the number of calls that can be made at once.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// ParseLimit parses a limit of the form `<rate>` or `<rate>:<burst>`, the burst defaulting to the rate rounded up.
+func ParseLimit(value string) (Limit, error) {
+	rateStr, burstStr, hasBurst := strings.Cut(value, limitSeparator)
+
+	callRate, err := strconv.ParseFloat(strings.TrimSpace(rateStr), 64)
+	if err != nil || callRate <= 0 || math.IsInf(callRate, 0) {
+		return Limit{}, errors.New(InvalidLimitError{Value: value, Reason: "the rate must be a positive number of calls per second"})
+	}
+
+	limit := Limit{Rate: callRate, Burst: int(math.Ceil(callRate))}
+
+	if hasBurst {
+		limit.Burst, err = strconv.Atoi(strings.TrimSpace(burstStr))
+		if err != nil || limit.Burst <= 0 {
+			return Limit{}, errors.New(InvalidLimitError{Value: value, Reason: "the burst must be a positive number of calls"})
+		}
+	}
+
+	return limit, nil
+}
+
+// Limiter is a set of token buckets keyed by provider or by provider and service. The zero value, as a nil limiter,
+// doesn't throttle anything.
+type Limiter struct {
+	buckets map[string]*rate.Limiter
+	mu      sync.RWMutex
+}
+
+// New returns a limiter without buckets.
+func New() *Limiter {
+	return &Limiter{buckets: map[string]*rate.Limiter{}}
+}
+
+// SetLimit sets the limit of the bucket of the given key, a provider, e.g. `aws`, or a provider and one of its
+// services, e.g. `aws/sts`.
+func (limiter *Limiter) SetLimit(key string, limit Limit) error {
+	if !keyRegexp.MatchString(key) {
+		return errors.New(InvalidKeyError{Key: key})
+	}
+
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	if limiter.buckets == nil {
+		limiter.buckets = map[string]*rate.Limiter{}
+	}
+
+	limiter.buckets[key] = rate.NewLimiter(rate.Limit(limit.Rate), limit.Burst)
+
+	return nil
+}
+
+// Wait blocks until a call to the given service of the given provider is allowed by the buckets of the service and of
+// the provider, or the context is done.
+func (limiter *Limiter) Wait(ctx context.Context, provider, service string) error {
+	if limiter == nil {
+		return nil
+	}
+
+	limiter.mu.RLock()
+	buckets := []*rate.Limiter{limiter.buckets[provider+keySeparator+service], limiter.buckets[provider]}
+	limiter.mu.RUnlock()
+
+	for _, bucket := range buckets {
+		if bucket == nil {
+			continue
+		}
+
+		if err := bucket.Wait(ctx); err != nil {
+			return errors.New(err)
+		}
+	}
+
+	return nil
+}
+
+// WaitProviders blocks until a call is allowed by the buckets of all the providers, or the context is done. It is
+// used for the commands making calls to the APIs of providers Terragrunt doesn't know of, such as OpenTofu/Terraform.
+func (limiter *Limiter) WaitProviders(ctx context.Context) error {
+	if limiter == nil {
+		return nil
+	}
+
+	var buckets []*rate.Limiter
+
+	limiter.mu.RLock()
+
+	for key, bucket := range limiter.buckets {
+		if !strings.Contains(key, keySeparator) {
+			buckets = append(buckets, bucket)
+		}
+	}
+
+	limiter.mu.RUnlock()
+
+	for _, bucket := range buckets {
+		if err := bucket.Wait(ctx); err != nil {
+			return errors.New(err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/internal/ratelimit"
+)
+
+func TestParseLimit(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		value    string
+		expected ratelimit.Limit
+		valid    bool
+	}{
+		{value: "20", expected: ratelimit.Limit{Rate: 20, Burst: 20}, valid: true},
+		{value: "0.5", expected: ratelimit.Limit{Rate: 0.5, Burst: 1}, valid: true},
+		{value: "5:10", expected: ratelimit.Limit{Rate: 5, Burst: 10}, valid: true},
+		{value: ""},
+		{value: "0"},
+		{value: "-1"},
+		{value: "fast"},
+		{value: "5:0"},
+		{value: "5:many"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			t.Parallel()
+
+			limit, err := ratelimit.ParseLimit(tc.value)
+			if !tc.valid {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, limit)
+		})
+	}
+}
+
+func TestLimiterSetLimit(t *testing.T) {
+	t.Parallel()
+
+	limiter := ratelimit.New()
+
+	for _, key := range []string{"aws", "aws/sts", "google/compute"} {
+		require.NoError(t, limiter.SetLimit(key, ratelimit.Limit{Rate: 1, Burst: 1}), key)
+	}
+
+	for _, key := range []string{"", "AWS", "aws/", "aws/sts/get-caller-identity"} {
+		require.Error(t, limiter.SetLimit(key, ratelimit.Limit{Rate: 1, Burst: 1}), key)
+	}
+}
+
+func TestLimiterWait(t *testing.T) {
+	t.Parallel()
+
+	limiter := ratelimit.New()
+	require.NoError(t, limiter.SetLimit("aws/sts", ratelimit.Limit{Rate: 1, Burst: 1}))
+
+	// The other services aren't throttled.
+	for range 5 {
+		require.NoError(t, limiter.Wait(t.Context(), "aws", "s3"))
+	}
+
+	require.NoError(t, limiter.Wait(t.Context(), "aws", "sts"))
+
+	// The bucket is empty, so the next call waits longer than the context lasts.
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	require.Error(t, limiter.Wait(ctx, "aws", "sts"))
+
+	// The provider buckets throttle all the services of the provider.
+	require.NoError(t, limiter.SetLimit("aws", ratelimit.Limit{Rate: 1, Burst: 1}))
+	require.NoError(t, limiter.Wait(t.Context(), "aws", "s3"))
+
+	ctx, cancel = context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+
+	require.Error(t, limiter.Wait(ctx, "aws", "dynamodb"))
+	require.Error(t, limiter.WaitProviders(ctx))
+}
+
+func TestLimiterWaitProviders(t *testing.T) {
+	t.Parallel()
+
+	limiter := ratelimit.New()
+	require.NoError(t, limiter.SetLimit("aws/sts", ratelimit.Limit{Rate: 1, Burst: 1}))
+	require.NoError(t, limiter.SetLimit("google", ratelimit.Limit{Rate: 100, Burst: 1}))
+
+	start := time.Now()
+
+	// The service buckets aren't waited for, and the provider buckets are.
+	for range 5 {
+		require.NoError(t, limiter.WaitProviders(t.Context()))
+	}
+
+	assert.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+	assert.Less(t, time.Since(start), time.Second)
+
+	var limiterNil *ratelimit.Limiter
+
+	require.NoError(t, limiterNil.Wait(t.Context(), "aws", "sts"))
+	require.NoError(t, limiterNil.WaitProviders(t.Context()))
+}
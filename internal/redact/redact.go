@@ -0,0 +1,144 @@
+// Package redact implements the redaction of the sensitive values of the configurations, such as the values passed to
+// the `sensitive` function or the decrypted values, from the logs and the output of Terragrunt.
+//
+// The sensitive values are registered with the Redactor of the run as the configurations are parsed, and replaced
+// with Placeholder anywhere they would appear afterwards: in the log entries, through the logrus hook implemented by
+// the Redactor, and in the output of the commands run by Terragrunt, through the writers returned by Writer.
+package redact
+
+import (
+	"cmp"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// Placeholder is the text the sensitive values are replaced with.
+	Placeholder = "[REDACTED]"
+
+	// MinLength is the minimum length of the sensitive values that are redacted. Shorter values, e.g. `on` or an
+	// ID like `1`, would be redacted from unrelated text all over the output.
+	MinLength = 4
+)
+
+// Redactor redacts the registered sensitive values from strings. A nil Redactor doesn't redact anything.
+type Redactor struct {
+	secrets  map[string]struct{}
+	replacer *strings.Replacer
+	mu       sync.RWMutex
+}
+
+// New returns a new Redactor without any sensitive values.
+func New() *Redactor {
+	return &Redactor{
+		secrets: map[string]struct{}{},
+	}
+}
+
+// Add registers the given sensitive values to be redacted. Values shorter than MinLength are ignored.
+func (r *Redactor) Add(secrets ...string) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var added bool
+
+	for _, secret := range secrets {
+		if _, ok := r.secrets[secret]; ok || len(secret) < MinLength {
+			continue
+		}
+
+		r.secrets[secret] = struct{}{}
+		added = true
+	}
+
+	if !added {
+		return
+	}
+
+	// The longest values are replaced first, so a value containing another one is fully redacted.
+	sorted := make([]string, 0, len(r.secrets))
+	for secret := range r.secrets {
+		sorted = append(sorted, secret)
+	}
+
+	slices.SortFunc(sorted, func(a, b string) int {
+		return cmp.Or(cmp.Compare(len(b), len(a)), cmp.Compare(a, b))
+	})
+
+	oldnew := make([]string, 0, len(sorted)*2) //nolint:mnd
+	for _, secret := range sorted {
+		oldnew = append(oldnew, secret, Placeholder)
+	}
+
+	r.replacer = strings.NewReplacer(oldnew...)
+}
+
+// Redact returns the given string with the registered sensitive values replaced with Placeholder.
+func (r *Redactor) Redact(str string) string {
+	if r == nil {
+		return str
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.replacer == nil {
+		return str
+	}
+
+	return r.replacer.Replace(str)
+}
+
+// Writer returns a writer redacting the registered sensitive values from the data written to the given writer. The
+// data is redacted write by write, so a value split across two writes isn't redacted.
+func (r *Redactor) Writer(w io.Writer) io.Writer {
+	if r == nil {
+		return w
+	}
+
+	return &writer{redactor: r, w: w}
+}
+
+// Levels implements logrus.Hook.
+func (r *Redactor) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements logrus.Hook, redacting the message and the fields of the given entry before it is formatted.
+func (r *Redactor) Fire(entry *logrus.Entry) error {
+	entry.Message = r.Redact(entry.Message)
+
+	for key, val := range entry.Data {
+		switch val := val.(type) {
+		case string:
+			entry.Data[key] = r.Redact(val)
+		case error:
+			if redacted := r.Redact(val.Error()); redacted != val.Error() {
+				entry.Data[key] = redacted
+			}
+		}
+	}
+
+	return nil
+}
+
+type writer struct {
+	redactor *Redactor
+	w        io.Writer
+}
+
+func (w *writer) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(w.w, w.redactor.Redact(string(p))); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
@@ -0,0 +1,59 @@
+package redact_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/internal/redact"
+)
+
+func TestRedact(t *testing.T) {
+	t.Parallel()
+
+	r := redact.New()
+	assert.Equal(t, "password=hunter2", r.Redact("password=hunter2"))
+
+	r.Add("hunter2", "hunter2-admin", "", "on")
+	assert.Equal(t, "password=[REDACTED] admin=[REDACTED] logging=on", r.Redact("password=hunter2 admin=hunter2-admin logging=on"))
+
+	var nilRedactor *redact.Redactor
+
+	nilRedactor.Add("hunter2")
+	assert.Equal(t, "hunter2", nilRedactor.Redact("hunter2"))
+}
+
+func TestRedactWriter(t *testing.T) {
+	t.Parallel()
+
+	r := redact.New()
+	r.Add("hunter2")
+
+	var buf bytes.Buffer
+
+	n, err := r.Writer(&buf).Write([]byte("db_password = hunter2\n"))
+	require.NoError(t, err)
+	assert.Equal(t, len("db_password = hunter2\n"), n)
+	assert.Equal(t, "db_password = [REDACTED]\n", buf.String())
+}
+
+func TestRedactHook(t *testing.T) {
+	t.Parallel()
+
+	r := redact.New()
+	r.Add("hunter2")
+
+	var buf bytes.Buffer
+
+	logger := logrus.New()
+	logger.SetOutput(&buf)
+	logger.SetFormatter(&logrus.TextFormatter{DisableTimestamp: true, DisableQuote: true})
+	logger.AddHook(r)
+
+	logger.WithField("password", "hunter2").WithError(errors.New("invalid password hunter2")).Info("Logging in with hunter2")
+	assert.Equal(t, "level=info msg=Logging in with [REDACTED] error=invalid password [REDACTED] password=[REDACTED]\n", buf.String())
+}
@@ -0,0 +1,163 @@
+// Package azurerm represents the Azure Storage backend for bootstrapping the storage account of the remote state, and
+// for reading remote state.
+package azurerm
+
+import (
+	"context"
+
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+const BackendName = "azurerm"
+
+var (
+	_ backend.Backend     = new(Backend)
+	_ backend.StateReader = new(Backend)
+)
+
+// Backend bootstraps the storage account and the container of the state stored in Azure Storage, and reads the state.
+// Migrating and deleting the state aren't supported.
+type Backend struct {
+	*backend.CommonBackend
+}
+
+func NewBackend() *Backend {
+	return &Backend{
+		CommonBackend: backend.NewCommonBackend(BackendName),
+	}
+}
+
+// NeedsBootstrap returns true if the storage account of the state can be bootstrapped, see `CanBootstrap`, and:
+//
+// 1. The storage account or the container of the state does not exist
+// 2. The versioning, soft delete or tags of the storage account differ from the config, unless updates are disabled
+func (backend *Backend) NeedsBootstrap(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) (bool, error) {
+	cfg, err := Config(backendConfig).ParseExtendedRemoteStateConfig()
+	if err != nil {
+		return false, err
+	}
+
+	if !cfg.CanBootstrap() {
+		return false, nil
+	}
+
+	client := NewClient(cfg, opts.Env)
+
+	if exists, err := client.StorageAccountExists(ctx); err != nil || !exists {
+		return true, err
+	}
+
+	if exists, err := client.ContainerExists(ctx); err != nil || !exists {
+		return true, err
+	}
+
+	if !cfg.DisableStorageAccountUpdate {
+		if _, _, updates, err := client.checkIfStorageAccountNeedsUpdate(ctx, l); err != nil || len(updates) > 0 {
+			return true, err
+		}
+	}
+
+	return false, nil
+}
+
+// Bootstrap the storage account of the state specified in the given config. This function will validate the config
+// parameters, create the storage account and the container if they don't already exist, update the versioning, soft
+// delete and tags of the storage account if they differ from the config, and check that versioning is enabled.
+func (backend *Backend) Bootstrap(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) error {
+	cfg, err := Config(backendConfig).ParseExtendedRemoteStateConfig()
+	if err != nil {
+		return err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	if !cfg.CanBootstrap() {
+		return nil
+	}
+
+	client := NewClient(cfg, opts.Env)
+
+	// ensure that only one goroutine can initialize storage account
+	mu := backend.GetBucketMutex(cfg.StorageAccountName)
+	mu.Lock()
+	defer mu.Unlock()
+
+	if backend.IsConfigInited(cfg) {
+		l.Debugf("%s storage account %s has already been confirmed to be initialized, skipping initialization checks", backend.Name(), cfg.StorageAccountName)
+
+		return nil
+	}
+
+	exists, err := client.StorageAccountExists(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		if err := client.CreateStorageAccountIfNecessary(ctx, l, opts); err != nil {
+			return err
+		}
+
+		// The user declined to create the storage account.
+		if exists, err := client.StorageAccountExists(ctx); err != nil || !exists {
+			return err
+		}
+	} else if !cfg.DisableStorageAccountUpdate {
+		if err := client.UpdateStorageAccountIfNecessary(ctx, l, opts); err != nil {
+			return err
+		}
+	}
+
+	if err := client.CreateContainerIfNecessary(ctx, l, opts); err != nil {
+		return err
+	}
+
+	// If skip_blob_versioning is false then warn user if versioning is disabled on storage account
+	if !cfg.SkipBlobVersioning {
+		if _, err := client.CheckIfBlobVersioningEnabled(ctx, l); err != nil {
+			return err
+		}
+	}
+
+	backend.MarkConfigInited(cfg)
+
+	return nil
+}
+
+// IsVersionControlEnabled returns true if versioning is enabled for the blobs of the storage account of the state.
+func (backend *Backend) IsVersionControlEnabled(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) (bool, error) {
+	cfg, err := Config(backendConfig).ParseExtendedRemoteStateConfig()
+	if err != nil {
+		return false, err
+	}
+
+	return NewClient(cfg, opts.Env).CheckIfBlobVersioningEnabled(ctx, l)
+}
+
+// GetTFInitArgs returns the subset of the given config that should be passed to terraform init
+// when initializing the remote state.
+func (backend *Backend) GetTFInitArgs(config backend.Config) map[string]any {
+	return Config(config).FilterOutTerragruntKeys()
+}
+
+// ReadState implements `backend.StateReader` interface.
+func (backend *Backend) ReadState(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) ([]byte, error) {
+	cfg, err := Config(backendConfig).ParseExtendedRemoteStateConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cfg.RemoteStateConfigAzureRM.Validate(); err != nil {
+		return nil, err
+	}
+
+	client := NewClient(cfg, opts.Env)
+
+	l.Debugf("Reading state from %s", client.BlobURL())
+
+	return client.GetBlob(ctx)
+}
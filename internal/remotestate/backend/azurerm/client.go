@@ -0,0 +1,230 @@
+package azurerm
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+const (
+	// storageAPIVersion is the version of the Azure Storage REST API the requests are made with.
+	storageAPIVersion = "2021-08-06"
+
+	// storageScope is the scope of the Azure AD tokens granting access to Azure Storage.
+	storageScope = "https://storage.azure.com/.default"
+
+	maxErrorBodyLength = 1024
+
+	// requestTimeout is the timeout of the requests to the REST APIs, so that an unreachable endpoint doesn't hang the
+	// run. The provisioning of a storage account is polled, so its requests don't take longer.
+	requestTimeout = 30 * time.Second
+)
+
+// Client reads blobs from Azure Storage with its REST API, authenticating with the access key or the SAS token of the
+// config, or of the `ARM_ACCESS_KEY` and `ARM_SAS_TOKEN` env vars, as the azurerm backend does, else with Azure AD.
+// It also manages the storage account of the state with the Azure Resource Manager REST API, authenticating with Azure
+// AD.
+type Client struct {
+	*ExtendedRemoteStateConfigAzureRM
+
+	httpClient *http.Client
+	now        func() time.Time
+	// token returns an Azure AD access token of the given scope, replaced in tests.
+	token func(ctx context.Context, scope string) (string, error)
+	// sleep waits between the checks of the provisioning of a storage account, replaced in tests.
+	sleep func(time.Duration)
+}
+
+// NewClient creates a new client for the storage account of the given config. Unset credentials are read from the
+// given env, from the same env vars as the azurerm backend.
+func NewClient(cfg *ExtendedRemoteStateConfigAzureRM, env map[string]string) *Client {
+	copied := *cfg
+	cfg = &copied
+
+	for _, setting := range []struct {
+		value *string
+		env   string
+	}{
+		{&cfg.AccessKey, "ARM_ACCESS_KEY"},
+		{&cfg.SASToken, "ARM_SAS_TOKEN"},
+		{&cfg.SubscriptionID, "ARM_SUBSCRIPTION_ID"},
+		{&cfg.TenantID, "ARM_TENANT_ID"},
+		{&cfg.ClientID, "ARM_CLIENT_ID"},
+		{&cfg.ClientSecret, "ARM_CLIENT_SECRET"},
+	} {
+		if *setting.value == "" {
+			*setting.value = env[setting.env]
+		}
+	}
+
+	if !cfg.UseMSI {
+		cfg.UseMSI, _ = strconv.ParseBool(env["ARM_USE_MSI"])
+	}
+
+	client := &Client{
+		ExtendedRemoteStateConfigAzureRM: cfg,
+		httpClient:                       &http.Client{Timeout: requestTimeout},
+		now:                              time.Now,
+		sleep:                            time.Sleep,
+	}
+
+	client.token = client.azureADToken
+
+	return client
+}
+
+// BlobURL returns the URL of the state blob.
+func (client *Client) BlobURL() string {
+	return fmt.Sprintf("https://%s.%s/%s/%s", client.StorageAccountName, client.blobEndpointSuffix(), client.ContainerName, client.Key)
+}
+
+// GetBlob returns the content of the state blob.
+func (client *Client) GetBlob(ctx context.Context) ([]byte, error) {
+	blobURL := client.BlobURL()
+
+	if client.AccessKey == "" && client.SASToken != "" {
+		blobURL += "?" + strings.TrimPrefix(client.SASToken, "?")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, blobURL, nil)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	req.Header.Set("x-ms-date", client.now().UTC().Format(http.TimeFormat))
+	req.Header.Set("x-ms-version", storageAPIVersion)
+
+	switch {
+	case client.AccessKey != "":
+		authorization, err := client.sharedKeyAuthorization(req)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Authorization", authorization)
+	case client.SASToken == "":
+		token, err := client.token(ctx, storageScope)
+		if err != nil {
+			return nil, err
+		}
+
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyLength))
+
+		return nil, errors.New(BlobRequestError{URL: client.BlobURL(), StatusCode: resp.StatusCode, Body: string(body)})
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return body, nil
+}
+
+// sharedKeyAuthorization returns the Authorization header of the given GET request signed with the access key of the
+// storage account, see https://learn.microsoft.com/rest/api/storageservices/authorize-with-shared-key.
+func (client *Client) sharedKeyAuthorization(req *http.Request) (string, error) {
+	key, err := base64.StdEncoding.DecodeString(client.AccessKey)
+	if err != nil {
+		return "", errors.Errorf("invalid Azure Storage access key: %w", err)
+	}
+
+	resource := "/" + client.StorageAccountName + req.URL.EscapedPath()
+
+	// A GET request has no content, nor conditional headers, so the standard headers of the string to sign are empty.
+	stringToSign := req.Method + strings.Repeat("\n", 12) +
+		"x-ms-date:" + req.Header.Get("x-ms-date") + "\n" +
+		"x-ms-version:" + req.Header.Get("x-ms-version") + "\n" +
+		canonicalizedResource(resource, req.URL.Query())
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(stringToSign))
+
+	return fmt.Sprintf("SharedKey %s:%s", client.StorageAccountName, base64.StdEncoding.EncodeToString(mac.Sum(nil))), nil
+}
+
+// canonicalizedResource returns the resource with its query parameters, as signed with Shared Key.
+func canonicalizedResource(resource string, query url.Values) string {
+	var sb strings.Builder
+
+	sb.WriteString(resource)
+
+	for _, name := range slices.Sorted(maps.Keys(query)) {
+		fmt.Fprintf(&sb, "\n%s:%s", strings.ToLower(name), strings.Join(query[name], ","))
+	}
+
+	return sb.String()
+}
+
+// azureADToken returns an Azure AD access token of the given scope, see `credential`.
+func (client *Client) azureADToken(ctx context.Context, scope string) (string, error) {
+	cred, err := client.credential()
+	if err != nil {
+		return "", err
+	}
+
+	token, err := cred.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{scope}})
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	return token.Token, nil
+}
+
+// credential returns the Azure AD credential of the config: the managed identity, with the client ID of the config if
+// any, if `use_msi` is set, else the service principal of the client ID and secret of the config, if set, else the
+// credentials of the environment, a managed identity or the Azure CLI.
+func (client *Client) credential() (azcore.TokenCredential, error) {
+	clientOpts := azcore.ClientOptions{Cloud: client.cloud()}
+
+	var (
+		cred azcore.TokenCredential
+		err  error
+	)
+
+	switch {
+	case client.UseMSI:
+		opts := &azidentity.ManagedIdentityCredentialOptions{ClientOptions: clientOpts}
+		if client.ClientID != "" {
+			opts.ID = azidentity.ClientID(client.ClientID)
+		}
+
+		cred, err = azidentity.NewManagedIdentityCredential(opts)
+	case client.ClientID != "" && client.ClientSecret != "" && client.TenantID != "":
+		cred, err = azidentity.NewClientSecretCredential(client.TenantID, client.ClientID, client.ClientSecret, &azidentity.ClientSecretCredentialOptions{ClientOptions: clientOpts})
+	default:
+		cred, err = azidentity.NewDefaultAzureCredential(&azidentity.DefaultAzureCredentialOptions{ClientOptions: clientOpts, TenantID: client.TenantID})
+	}
+
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return cred, nil
+}
@@ -0,0 +1,336 @@
+package azurerm
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// redirectTransport sends all the requests to the given test server.
+type redirectTransport struct {
+	server *httptest.Server
+}
+
+func (transport redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	serverURL, err := url.Parse(transport.server.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.Clone(req.Context())
+	req.URL.Scheme = serverURL.Scheme
+	req.URL.Host = serverURL.Host
+
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestClientGetBlob(t *testing.T) {
+	t.Parallel()
+
+	accessKey := base64.StdEncoding.EncodeToString([]byte("secret"))
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		cfg           RemoteStateConfigAzureRM
+		expectedQuery string
+		expectedAuth  func(req *http.Request) string
+	}{
+		{
+			name: "access key",
+			cfg:  RemoteStateConfigAzureRM{AccessKey: accessKey},
+			expectedAuth: func(req *http.Request) string {
+				stringToSign := "GET" + strings.Repeat("\n", 12) +
+					"x-ms-date:Thu, 02 Jan 2025 03:04:05 GMT\n" +
+					"x-ms-version:" + storageAPIVersion + "\n" +
+					"/account/tfstate/app/terraform.tfstate"
+
+				mac := hmac.New(sha256.New, []byte("secret"))
+				mac.Write([]byte(stringToSign))
+
+				return "SharedKey account:" + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+			},
+		},
+		{
+			name:          "sas token",
+			cfg:           RemoteStateConfigAzureRM{SASToken: "?sv=2021-08-06&sig=abc"},
+			expectedQuery: "sv=2021-08-06&sig=abc",
+			expectedAuth:  func(_ *http.Request) string { return "" },
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				assert.Equal(t, "/tfstate/app/terraform.tfstate", req.URL.Path)
+				assert.Equal(t, tc.expectedQuery, req.URL.RawQuery)
+				assert.Equal(t, tc.expectedAuth(req), req.Header.Get("Authorization"))
+				assert.Equal(t, "Thu, 02 Jan 2025 03:04:05 GMT", req.Header.Get("x-ms-date"))
+
+				_, _ = w.Write([]byte(`{"version": 4}`))
+			}))
+			defer server.Close()
+
+			cfg := tc.cfg
+			cfg.StorageAccountName = "account"
+			cfg.ContainerName = "tfstate"
+			cfg.Key = "app/terraform.tfstate"
+
+			client := NewClient(&ExtendedRemoteStateConfigAzureRM{RemoteStateConfigAzureRM: cfg}, nil)
+			client.httpClient = &http.Client{Transport: redirectTransport{server: server}}
+			client.now = func() time.Time { return now }
+
+			body, err := client.GetBlob(t.Context())
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"version": 4}`, string(body))
+		})
+	}
+}
+
+func TestClientGetBlobNotFound(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte("BlobNotFound"))
+	}))
+	defer server.Close()
+
+	client := NewClient(&ExtendedRemoteStateConfigAzureRM{RemoteStateConfigAzureRM: RemoteStateConfigAzureRM{StorageAccountName: "account", ContainerName: "tfstate", Key: "terraform.tfstate"}}, map[string]string{"ARM_SAS_TOKEN": "sig=abc"})
+	client.httpClient = &http.Client{Transport: redirectTransport{server: server}}
+
+	_, err := client.GetBlob(t.Context())
+
+	var blobErr BlobRequestError
+
+	require.ErrorAs(t, err, &blobErr)
+	assert.Equal(t, http.StatusNotFound, blobErr.StatusCode)
+	assert.Equal(t, "BlobNotFound", blobErr.Body)
+}
+
+// fakeResourceManager serves the storage account, blob service and container resources of a resource group, recording
+// the requests made to them.
+type fakeResourceManager struct {
+	account   *storageAccount
+	service   *blobService
+	requests  []string
+	mu        sync.Mutex
+	container bool
+}
+
+func (fake *fakeResourceManager) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+
+	const accountPath = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/account"
+
+	fake.requests = append(fake.requests, req.Method+" "+strings.TrimPrefix(req.URL.Path, accountPath))
+
+	if req.URL.Query().Get("api-version") != managementAPIVersion || req.Header.Get("Authorization") != "Bearer token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var resource any
+
+	switch req.URL.Path {
+	case accountPath:
+		switch req.Method {
+		case http.MethodPut:
+			fake.account = &storageAccount{}
+			_ = json.NewDecoder(req.Body).Decode(fake.account)
+			fake.account.Properties.ProvisioningState = storageAccountProvisioningSucceeded
+			fake.service = &blobService{}
+
+			w.WriteHeader(http.StatusAccepted)
+
+			return
+		case http.MethodPatch:
+			patch := &storageAccount{}
+			_ = json.NewDecoder(req.Body).Decode(patch)
+			fake.account.Tags = patch.Tags
+		}
+
+		if fake.account != nil {
+			resource = fake.account
+		}
+	case accountPath + "/blobServices/default":
+		if req.Method == http.MethodPut {
+			fake.service = &blobService{}
+			_ = json.NewDecoder(req.Body).Decode(fake.service)
+		}
+
+		if fake.service != nil {
+			resource = fake.service
+		}
+	case accountPath + "/blobServices/default/containers/tfstate":
+		if req.Method == http.MethodPut {
+			fake.container = true
+		}
+
+		if fake.container {
+			resource = &blobContainer{Properties: blobContainerProperties{PublicAccess: "None"}}
+		}
+	}
+
+	if resource == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(resource)
+}
+
+func newBootstrapTestClient(t *testing.T, fake *fakeResourceManager) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	cfg, err := Config{
+		"storage_account_name":            "account",
+		"container_name":                  "tfstate",
+		"key":                             "terraform.tfstate",
+		"resource_group_name":             "rg",
+		"location":                        "westeurope",
+		"storage_account_tags":            map[string]any{"team": "platform"},
+		"blob_soft_delete_retention_days": 7,
+	}.ParseExtendedRemoteStateConfig()
+	require.NoError(t, err)
+
+	client := NewClient(cfg, map[string]string{"ARM_SUBSCRIPTION_ID": "sub"})
+	client.httpClient = &http.Client{Transport: redirectTransport{server: server}}
+	client.token = func(_ context.Context, scope string) (string, error) {
+		assert.Equal(t, "https://management.azure.com/.default", scope)
+
+		return "token", nil
+	}
+	client.sleep = func(time.Duration) {}
+
+	return client
+}
+
+func TestClientCreateStorageAccount(t *testing.T) {
+	t.Parallel()
+
+	l := logger.CreateLogger()
+	opts := options.NewTerragruntOptions()
+	opts.NonInteractive = true
+
+	fake := &fakeResourceManager{}
+	client := newBootstrapTestClient(t, fake)
+
+	require.NoError(t, client.CreateStorageAccountIfNecessary(t.Context(), l, opts))
+	require.NoError(t, client.CreateContainerIfNecessary(t.Context(), l, opts))
+
+	assert.Equal(t, []string{
+		"GET ",
+		"PUT ",
+		"GET ",
+		"PUT /blobServices/default",
+		"GET /blobServices/default/containers/tfstate",
+		"PUT /blobServices/default/containers/tfstate",
+	}, fake.requests)
+
+	assert.Equal(t, "westeurope", fake.account.Location)
+	assert.Equal(t, DefaultStorageAccountSKU, fake.account.SKU.Name)
+	assert.Equal(t, map[string]string{"team": "platform"}, fake.account.Tags)
+	assert.True(t, *fake.service.Properties.IsVersioningEnabled)
+	assert.Equal(t, &deleteRetentionPolicy{Enabled: true, Days: 7}, fake.service.Properties.DeleteRetentionPolicy)
+	assert.Nil(t, fake.service.Properties.ContainerDeleteRetentionPolicy)
+	assert.True(t, fake.container)
+
+	enabled, err := client.CheckIfBlobVersioningEnabled(t.Context(), l)
+	require.NoError(t, err)
+	assert.True(t, enabled)
+}
+
+func TestClientUpdateStorageAccount(t *testing.T) {
+	t.Parallel()
+
+	l := logger.CreateLogger()
+	opts := options.NewTerragruntOptions()
+	opts.NonInteractive = true
+
+	disabled := false
+
+	fake := &fakeResourceManager{
+		account: &storageAccount{Tags: map[string]string{"team": "other", "owner": "ops"}},
+		service: &blobService{Properties: blobServiceProperties{
+			IsVersioningEnabled:            &disabled,
+			ContainerDeleteRetentionPolicy: &deleteRetentionPolicy{Enabled: true, Days: 30},
+		}},
+		container: true,
+	}
+	client := newBootstrapTestClient(t, fake)
+
+	_, _, updates, err := client.checkIfStorageAccountNeedsUpdate(t.Context(), l)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Blob Versioning", "Blob Soft Delete", "Storage Account Tags"}, updates)
+
+	require.NoError(t, client.UpdateStorageAccountIfNecessary(t.Context(), l, opts))
+
+	assert.Equal(t, map[string]string{"team": "platform", "owner": "ops"}, fake.account.Tags)
+	assert.True(t, *fake.service.Properties.IsVersioningEnabled)
+	assert.Equal(t, &deleteRetentionPolicy{Enabled: true, Days: 7}, fake.service.Properties.DeleteRetentionPolicy)
+	assert.Equal(t, &deleteRetentionPolicy{Enabled: true, Days: 30}, fake.service.Properties.ContainerDeleteRetentionPolicy)
+
+	_, _, updates, err = client.checkIfStorageAccountNeedsUpdate(t.Context(), l)
+	require.NoError(t, err)
+	assert.Empty(t, updates)
+}
+
+func TestConfigValidateSoftDeleteRetention(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := Config{
+		"storage_account_name":                 "account",
+		"container_name":                       "tfstate",
+		"key":                                  "terraform.tfstate",
+		"container_soft_delete_retention_days": 400,
+	}.ParseExtendedRemoteStateConfig()
+	require.NoError(t, err)
+
+	var invalidErr InvalidAzureRMRemoteStateConfig
+
+	require.ErrorAs(t, cfg.Validate(), &invalidErr)
+	assert.Equal(t, "container_soft_delete_retention_days", invalidErr.name)
+	assert.False(t, cfg.CanBootstrap())
+}
+
+func TestConfigFilterOutTerragruntKeys(t *testing.T) {
+	t.Parallel()
+
+	cfg := Config{
+		"storage_account_name":          "account",
+		"resource_group_name":           "rg",
+		"location":                      "westeurope",
+		"skip_storage_account_creation": true,
+		"storage_account_tags":          map[string]any{"team": "platform"},
+	}
+
+	assert.Equal(t, Config{"storage_account_name": "account", "resource_group_name": "rg"}, cfg.FilterOutTerragruntKeys())
+}
+
+func TestClientRequestTimeout(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(&ExtendedRemoteStateConfigAzureRM{RemoteStateConfigAzureRM: RemoteStateConfigAzureRM{StorageAccountName: "account", ContainerName: "tfstate", Key: "terraform.tfstate"}}, nil)
+	assert.Equal(t, requestTimeout, client.httpClient.Timeout)
+}
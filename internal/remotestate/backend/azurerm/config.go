@@ -0,0 +1,192 @@
+package azurerm
+
+import (
+	"slices"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+const (
+	// DefaultStorageAccountSKU is the SKU of the storage accounts Terragrunt creates, unless `storage_account_sku` is
+	// set.
+	DefaultStorageAccountSKU = "Standard_LRS"
+
+	// maxSoftDeleteRetentionDays is the maximum retention of the deleted blobs and containers Azure Storage supports.
+	maxSoftDeleteRetentionDays = 365
+)
+
+// terragruntOnlyConfigs are the keys of the config only used by Terragrunt to bootstrap the storage account, which
+// aren't passed to the azurerm backend.
+var terragruntOnlyConfigs = []string{
+	"location",
+	"storage_account_sku",
+	"storage_account_tags",
+	"skip_storage_account_creation",
+	"skip_blob_versioning",
+	"blob_soft_delete_retention_days",
+	"container_soft_delete_retention_days",
+	"disable_storage_account_update",
+}
+
+// Config is the `config` of a `remote_state` block using the azurerm backend.
+type Config map[string]any
+
+// RemoteStateConfigAzureRM is the subset of the settings of the azurerm backend needed to read the state and to
+// bootstrap the storage account.
+type RemoteStateConfigAzureRM struct {
+	StorageAccountName string `mapstructure:"storage_account_name"`
+	ContainerName      string `mapstructure:"container_name"`
+	Key                string `mapstructure:"key"`
+	SASToken           string `mapstructure:"sas_token"`
+	AccessKey          string `mapstructure:"access_key"`
+	// Environment is the Azure cloud, e.g. `usgovernment` or `china`. Defaults to the public cloud.
+	Environment       string `mapstructure:"environment"`
+	SubscriptionID    string `mapstructure:"subscription_id"`
+	ResourceGroupName string `mapstructure:"resource_group_name"`
+	TenantID          string `mapstructure:"tenant_id"`
+	ClientID          string `mapstructure:"client_id"`
+	ClientSecret      string `mapstructure:"client_secret"`
+	UseMSI            bool   `mapstructure:"use_msi"`
+}
+
+// ExtendedRemoteStateConfigAzureRM is the config of the azurerm backend with the settings only used by Terragrunt to
+// create the storage account and the container of the state, and to configure the versioning and the soft delete of
+// their blobs.
+type ExtendedRemoteStateConfigAzureRM struct {
+	StorageAccountTags map[string]string `mapstructure:"storage_account_tags"`
+	Location           string            `mapstructure:"location"`
+	StorageAccountSKU  string            `mapstructure:"storage_account_sku"`
+
+	RemoteStateConfigAzureRM `mapstructure:",squash"`
+
+	BlobSoftDeleteRetentionDays      int  `mapstructure:"blob_soft_delete_retention_days"`
+	ContainerSoftDeleteRetentionDays int  `mapstructure:"container_soft_delete_retention_days"`
+	SkipStorageAccountCreation       bool `mapstructure:"skip_storage_account_creation"`
+	SkipBlobVersioning               bool `mapstructure:"skip_blob_versioning"`
+	DisableStorageAccountUpdate      bool `mapstructure:"disable_storage_account_update"`
+}
+
+// FilterOutTerragruntKeys returns the config without the keys only used by Terragrunt.
+func (cfg Config) FilterOutTerragruntKeys() Config {
+	var filtered = make(Config)
+
+	for key, val := range cfg {
+		if slices.Contains(terragruntOnlyConfigs, key) {
+			continue
+		}
+
+		filtered[key] = val
+	}
+
+	return filtered
+}
+
+// ParseRemoteStateConfig parses the given map into an azurerm config.
+func (cfg Config) ParseRemoteStateConfig() (*RemoteStateConfigAzureRM, error) {
+	var azureCfg RemoteStateConfigAzureRM
+
+	if err := mapstructure.WeakDecode(cfg, &azureCfg); err != nil {
+		return nil, errors.New(err)
+	}
+
+	return &azureCfg, nil
+}
+
+// ParseExtendedRemoteStateConfig parses the given map into an azurerm config, with the settings only used by
+// Terragrunt.
+func (cfg Config) ParseExtendedRemoteStateConfig() (*ExtendedRemoteStateConfigAzureRM, error) {
+	var azureCfg ExtendedRemoteStateConfigAzureRM
+
+	if err := mapstructure.WeakDecode(cfg, &azureCfg); err != nil {
+		return nil, errors.New(err)
+	}
+
+	if azureCfg.StorageAccountSKU == "" {
+		azureCfg.StorageAccountSKU = DefaultStorageAccountSKU
+	}
+
+	return &azureCfg, nil
+}
+
+// Validate checks that the storage account, container and key of the state are set.
+func (cfg *RemoteStateConfigAzureRM) Validate() error {
+	switch {
+	case cfg.StorageAccountName == "":
+		return errors.New(MissingRequiredAzureRMRemoteStateConfig("storage_account_name"))
+	case cfg.ContainerName == "":
+		return errors.New(MissingRequiredAzureRMRemoteStateConfig("container_name"))
+	case cfg.Key == "":
+		return errors.New(MissingRequiredAzureRMRemoteStateConfig("key"))
+	}
+
+	return nil
+}
+
+// Validate checks the config of the state, and the retentions of the deleted blobs and containers.
+func (cfg *ExtendedRemoteStateConfigAzureRM) Validate() error {
+	if err := cfg.RemoteStateConfigAzureRM.Validate(); err != nil {
+		return err
+	}
+
+	for name, days := range map[string]int{
+		"blob_soft_delete_retention_days":      cfg.BlobSoftDeleteRetentionDays,
+		"container_soft_delete_retention_days": cfg.ContainerSoftDeleteRetentionDays,
+	} {
+		if days < 0 || days > maxSoftDeleteRetentionDays {
+			return errors.New(InvalidAzureRMRemoteStateConfig{name: name, reason: "expected a number of days no greater than 365"})
+		}
+	}
+
+	return nil
+}
+
+// CanBootstrap returns true if Terragrunt can create and configure the storage account of the state, which requires
+// its resource group. Without it, e.g. as the state is only accessed with an access key or a SAS token, the storage
+// account is left as it is.
+func (cfg *ExtendedRemoteStateConfigAzureRM) CanBootstrap() bool {
+	return !cfg.SkipStorageAccountCreation && cfg.ResourceGroupName != ""
+}
+
+// CacheKey returns a unique key for the given azurerm config that can be used to cache the initialization.
+func (cfg *RemoteStateConfigAzureRM) CacheKey() string {
+	return cfg.StorageAccountName + "/" + cfg.ContainerName
+}
+
+// blobEndpointSuffix returns the suffix of the blob service endpoints of the Azure cloud of the config.
+func (cfg *RemoteStateConfigAzureRM) blobEndpointSuffix() string {
+	switch cfg.Environment {
+	case "usgovernment":
+		return "blob.core.usgovcloudapi.net"
+	case "china":
+		return "blob.core.chinacloudapi.cn"
+	default:
+		return "blob.core.windows.net"
+	}
+}
+
+// resourceManagerEndpoint returns the Azure Resource Manager endpoint of the Azure cloud of the config.
+func (cfg *RemoteStateConfigAzureRM) resourceManagerEndpoint() string {
+	switch cfg.Environment {
+	case "usgovernment":
+		return "https://management.usgovcloudapi.net"
+	case "china":
+		return "https://management.chinacloudapi.cn"
+	default:
+		return "https://management.azure.com"
+	}
+}
+
+// cloud returns the Azure cloud of the config, whose Azure AD authority the credentials authenticate with.
+func (cfg *RemoteStateConfigAzureRM) cloud() cloud.Configuration {
+	switch cfg.Environment {
+	case "usgovernment":
+		return cloud.AzureGovernment
+	case "china":
+		return cloud.AzureChina
+	default:
+		return cloud.AzurePublic
+	}
+}
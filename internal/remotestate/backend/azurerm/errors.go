@@ -0,0 +1,50 @@
+package azurerm
+
+import "fmt"
+
+type MissingRequiredAzureRMRemoteStateConfig string
+
+func (configName MissingRequiredAzureRMRemoteStateConfig) Error() string {
+	return "Missing required AzureRM remote state configuration " + string(configName)
+}
+
+// BlobRequestError is the error that is returned when Azure Storage doesn't return the state blob.
+type BlobRequestError struct {
+	URL        string
+	Body       string
+	StatusCode int
+}
+
+// Error implements `error` interface.
+func (err BlobRequestError) Error() string {
+	return fmt.Sprintf("failed to read Azure Storage blob %s: status code %d: %s", err.URL, err.StatusCode, err.Body)
+}
+
+type InvalidAzureRMRemoteStateConfig struct {
+	name   string
+	reason string
+}
+
+func (err InvalidAzureRMRemoteStateConfig) Error() string {
+	return fmt.Sprintf("Invalid AzureRM remote state configuration %s: %s", err.name, err.reason)
+}
+
+type MaxRetriesWaitingForStorageAccountExceeded string
+
+func (err MaxRetriesWaitingForStorageAccountExceeded) Error() string {
+	return fmt.Sprintf("Exceeded max retries (%d) waiting for Azure storage account %s to be provisioned", maxRetriesWaitingForStorageAccount, string(err))
+}
+
+// ManagementRequestError is the error that is returned when Azure Resource Manager fails a request managing the
+// storage account of the state.
+type ManagementRequestError struct {
+	Method     string
+	URL        string
+	Body       string
+	StatusCode int
+}
+
+// Error implements `error` interface.
+func (err ManagementRequestError) Error() string {
+	return fmt.Sprintf("failed to %s Azure resource %s: status code %d: %s", err.Method, err.URL, err.StatusCode, err.Body)
+}
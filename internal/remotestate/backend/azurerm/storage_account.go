@@ -0,0 +1,441 @@
+package azurerm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/shell"
+)
+
+const (
+	// managementAPIVersion is the version of the Microsoft.Storage resource provider API the storage accounts are
+	// managed with.
+	managementAPIVersion = "2023-01-01"
+
+	// storageAccountProvisioningSucceeded is the provisioning state of the storage accounts ready to store the state.
+	storageAccountProvisioningSucceeded = "Succeeded"
+
+	maxRetriesWaitingForStorageAccount          = 30
+	sleepBetweenRetriesWaitingForStorageAccount = 5 * time.Second
+)
+
+// storageAccount is the part of a storage account resource of Azure Resource Manager Terragrunt creates, and reads.
+type storageAccount struct {
+	Tags       map[string]string         `json:"tags,omitempty"`
+	SKU        *storageAccountSKU        `json:"sku,omitempty"`
+	Properties *storageAccountProperties `json:"properties,omitempty"`
+	Location   string                    `json:"location,omitempty"`
+	Kind       string                    `json:"kind,omitempty"`
+}
+
+type storageAccountSKU struct {
+	Name string `json:"name"`
+}
+
+type storageAccountProperties struct {
+	AllowBlobPublicAccess    *bool  `json:"allowBlobPublicAccess,omitempty"`
+	SupportsHTTPSTrafficOnly *bool  `json:"supportsHttpsTrafficOnly,omitempty"`
+	ProvisioningState        string `json:"provisioningState,omitempty"`
+	MinimumTLSVersion        string `json:"minimumTlsVersion,omitempty"`
+}
+
+// blobService is the part of the blob service resource of a storage account Terragrunt configures.
+type blobService struct {
+	Properties blobServiceProperties `json:"properties"`
+}
+
+type blobServiceProperties struct {
+	IsVersioningEnabled            *bool                  `json:"isVersioningEnabled,omitempty"`
+	DeleteRetentionPolicy          *deleteRetentionPolicy `json:"deleteRetentionPolicy,omitempty"`
+	ContainerDeleteRetentionPolicy *deleteRetentionPolicy `json:"containerDeleteRetentionPolicy,omitempty"`
+}
+
+type deleteRetentionPolicy struct {
+	Enabled bool `json:"enabled"`
+	Days    int  `json:"days,omitempty"`
+}
+
+// blobContainer is the part of a container resource of a storage account Terragrunt creates.
+type blobContainer struct {
+	Properties blobContainerProperties `json:"properties"`
+}
+
+type blobContainerProperties struct {
+	PublicAccess string `json:"publicAccess"`
+}
+
+// CreateStorageAccountIfNecessary prompts the user to create the storage account of the state if it doesn't exist,
+// and if the user confirms, creates it with the versioning and soft delete of the config.
+func (client *Client) CreateStorageAccountIfNecessary(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) error {
+	account, err := client.getStorageAccount(ctx)
+	if err != nil || account != nil {
+		return err
+	}
+
+	if client.Location == "" {
+		return errors.New(MissingRequiredAzureRMRemoteStateConfig("location"))
+	}
+
+	prompt := fmt.Sprintf("Remote state Azure storage account %s does not exist or you don't have permissions to access it. Would you like Terragrunt to create it?", client.StorageAccountName)
+
+	shouldCreateAccount, err := shell.PromptUserForYesNo(ctx, l, prompt, opts)
+	if err != nil {
+		return err
+	}
+
+	if !shouldCreateAccount {
+		return nil
+	}
+
+	l.Debugf("Creating Azure storage account %s in resource group %s", client.StorageAccountName, client.ResourceGroupName)
+
+	enabled, disabled := true, false
+
+	account = &storageAccount{
+		Location: client.Location,
+		Kind:     "StorageV2",
+		SKU:      &storageAccountSKU{Name: client.StorageAccountSKU},
+		Tags:     client.StorageAccountTags,
+		Properties: &storageAccountProperties{
+			MinimumTLSVersion:        "TLS1_2",
+			AllowBlobPublicAccess:    &disabled,
+			SupportsHTTPSTrafficOnly: &enabled,
+		},
+	}
+
+	if _, err := client.managementRequest(ctx, http.MethodPut, client.storageAccountPath(), account, nil); err != nil {
+		return err
+	}
+
+	if err := client.waitUntilStorageAccountProvisioned(ctx, l); err != nil {
+		return err
+	}
+
+	service, _ := client.blobServiceUpdates(&blobServiceProperties{})
+	if service == nil {
+		return nil
+	}
+
+	l.Debugf("Configuring the blob service of Azure storage account %s", client.StorageAccountName)
+
+	_, err = client.managementRequest(ctx, http.MethodPut, client.storageAccountPath()+"/blobServices/default", service, nil)
+
+	return err
+}
+
+// UpdateStorageAccountIfNecessary prompts the user to update the storage account of the state if its versioning, soft
+// delete or tags differ from the config, and if the user confirms, updates it.
+func (client *Client) UpdateStorageAccountIfNecessary(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) error {
+	service, tags, updates, err := client.checkIfStorageAccountNeedsUpdate(ctx, l)
+	if err != nil {
+		return err
+	}
+
+	if len(updates) == 0 {
+		l.Debug("Azure storage account is already up to date")
+		return nil
+	}
+
+	prompt := fmt.Sprintf("Remote state Azure storage account %s is out of date. Would you like Terragrunt to update it?", client.StorageAccountName)
+
+	shouldUpdateAccount, err := shell.PromptUserForYesNo(ctx, l, prompt, opts)
+	if err != nil {
+		return err
+	}
+
+	if !shouldUpdateAccount {
+		return nil
+	}
+
+	l.Debugf("Updating Azure storage account %s", client.StorageAccountName)
+
+	if service != nil {
+		if _, err := client.managementRequest(ctx, http.MethodPut, client.storageAccountPath()+"/blobServices/default", service, nil); err != nil {
+			return err
+		}
+	}
+
+	if tags != nil {
+		if _, err := client.managementRequest(ctx, http.MethodPatch, client.storageAccountPath(), &storageAccount{Tags: tags}, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkIfStorageAccountNeedsUpdate returns the blob service and the tags the storage account of the state must be
+// updated with to match the config, nil if they are up to date, and the descriptions of the updates.
+func (client *Client) checkIfStorageAccountNeedsUpdate(ctx context.Context, l log.Logger) (*blobService, map[string]string, []string, error) {
+	account, err := client.getStorageAccount(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if account == nil {
+		return nil, nil, nil, errors.Errorf("Azure storage account %s does not exist", client.StorageAccountName)
+	}
+
+	current, err := client.getBlobService(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	service, updates := client.blobServiceUpdates(&current.Properties)
+
+	tags, tagsUpdated := client.tagUpdates(account.Tags)
+	if tagsUpdated {
+		updates = append(updates, "Storage Account Tags")
+	} else {
+		tags = nil
+	}
+
+	if len(updates) > 0 {
+		l.Warnf("The remote state Azure storage account %s needs to be updated:", client.StorageAccountName)
+
+		for _, update := range updates {
+			l.Warnf("  - %s", update)
+		}
+	}
+
+	return service, tags, updates, nil
+}
+
+// blobServiceUpdates returns the blob service properties matching the config, starting from the given ones, or nil if
+// they already match, and the descriptions of the updates. The settings the config doesn't enable are left as they are.
+func (client *Client) blobServiceUpdates(current *blobServiceProperties) (*blobService, []string) {
+	var (
+		props   = *current
+		updates []string
+	)
+
+	if !client.SkipBlobVersioning && (props.IsVersioningEnabled == nil || !*props.IsVersioningEnabled) {
+		enabled := true
+		props.IsVersioningEnabled = &enabled
+
+		updates = append(updates, "Blob Versioning")
+	}
+
+	if days := client.BlobSoftDeleteRetentionDays; days > 0 && !props.DeleteRetentionPolicy.matches(days) {
+		props.DeleteRetentionPolicy = &deleteRetentionPolicy{Enabled: true, Days: days}
+
+		updates = append(updates, "Blob Soft Delete")
+	}
+
+	if days := client.ContainerSoftDeleteRetentionDays; days > 0 && !props.ContainerDeleteRetentionPolicy.matches(days) {
+		props.ContainerDeleteRetentionPolicy = &deleteRetentionPolicy{Enabled: true, Days: days}
+
+		updates = append(updates, "Container Soft Delete")
+	}
+
+	if len(updates) == 0 {
+		return nil, nil
+	}
+
+	return &blobService{Properties: props}, updates
+}
+
+// tagUpdates returns the given tags of the storage account with the tags of the config, and true if they differ. The
+// tags of the storage account that aren't in the config are kept.
+func (client *Client) tagUpdates(current map[string]string) (map[string]string, bool) {
+	tags := maps.Clone(current)
+	if tags == nil {
+		tags = map[string]string{}
+	}
+
+	updated := false
+
+	for key, value := range client.StorageAccountTags {
+		if currentValue, ok := tags[key]; !ok || currentValue != value {
+			tags[key] = value
+			updated = true
+		}
+	}
+
+	return tags, updated
+}
+
+// matches returns true if the policy retains the deleted blobs the given number of days.
+func (policy *deleteRetentionPolicy) matches(days int) bool {
+	return policy != nil && policy.Enabled && policy.Days == days
+}
+
+// CreateContainerIfNecessary prompts the user to create the container of the state if it doesn't exist, and if the
+// user confirms, creates it without public access.
+func (client *Client) CreateContainerIfNecessary(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) error {
+	containerPath := client.storageAccountPath() + "/blobServices/default/containers/" + client.ContainerName
+
+	status, err := client.managementRequest(ctx, http.MethodGet, containerPath, nil, nil)
+	if err != nil || status != http.StatusNotFound {
+		return err
+	}
+
+	prompt := fmt.Sprintf("Remote state Azure storage container %s does not exist in storage account %s. Would you like Terragrunt to create it?", client.ContainerName, client.StorageAccountName)
+
+	shouldCreateContainer, err := shell.PromptUserForYesNo(ctx, l, prompt, opts)
+	if err != nil {
+		return err
+	}
+
+	if !shouldCreateContainer {
+		return nil
+	}
+
+	l.Debugf("Creating Azure storage container %s in storage account %s", client.ContainerName, client.StorageAccountName)
+
+	_, err = client.managementRequest(ctx, http.MethodPut, containerPath, &blobContainer{Properties: blobContainerProperties{PublicAccess: "None"}}, nil)
+
+	return err
+}
+
+// CheckIfBlobVersioningEnabled checks if versioning is enabled for the blobs of the storage account of the state, and
+// warns the user if it isn't.
+func (client *Client) CheckIfBlobVersioningEnabled(ctx context.Context, l log.Logger) (bool, error) {
+	service, err := client.getBlobService(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if service.Properties.IsVersioningEnabled == nil || !*service.Properties.IsVersioningEnabled {
+		l.Warnf("Versioning is not enabled for the blobs of the remote state Azure storage account %s. We recommend enabling versioning so that you can roll back to previous versions of your OpenTofu/Terraform state in case of error.", client.StorageAccountName)
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// StorageAccountExists returns true if the storage account of the state exists.
+func (client *Client) StorageAccountExists(ctx context.Context) (bool, error) {
+	account, err := client.getStorageAccount(ctx)
+
+	return account != nil, err
+}
+
+// ContainerExists returns true if the container of the state exists.
+func (client *Client) ContainerExists(ctx context.Context) (bool, error) {
+	status, err := client.managementRequest(ctx, http.MethodGet, client.storageAccountPath()+"/blobServices/default/containers/"+client.ContainerName, nil, nil)
+
+	return status != http.StatusNotFound, err
+}
+
+// getStorageAccount returns the storage account of the state, or nil if it doesn't exist.
+func (client *Client) getStorageAccount(ctx context.Context) (*storageAccount, error) {
+	account := &storageAccount{}
+
+	status, err := client.managementRequest(ctx, http.MethodGet, client.storageAccountPath(), nil, account)
+	if err != nil || status == http.StatusNotFound {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// getBlobService returns the blob service of the storage account of the state.
+func (client *Client) getBlobService(ctx context.Context) (*blobService, error) {
+	service := &blobService{}
+
+	if _, err := client.managementRequest(ctx, http.MethodGet, client.storageAccountPath()+"/blobServices/default", nil, service); err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
+
+// waitUntilStorageAccountProvisioned waits until the storage account of the state is provisioned, as it is created
+// asynchronously.
+func (client *Client) waitUntilStorageAccountProvisioned(ctx context.Context, l log.Logger) error {
+	for retries := range maxRetriesWaitingForStorageAccount {
+		account, err := client.getStorageAccount(ctx)
+		if err != nil {
+			return err
+		}
+
+		if account != nil && account.Properties != nil && account.Properties.ProvisioningState == storageAccountProvisioningSucceeded {
+			l.Debugf("Azure storage account %s is provisioned.", client.StorageAccountName)
+			return nil
+		}
+
+		if retries < maxRetriesWaitingForStorageAccount-1 {
+			l.Debugf("Waiting for Azure storage account %s to be provisioned. Sleeping for %s.", client.StorageAccountName, sleepBetweenRetriesWaitingForStorageAccount)
+			client.sleep(sleepBetweenRetriesWaitingForStorageAccount)
+		}
+	}
+
+	return errors.New(MaxRetriesWaitingForStorageAccountExceeded(client.StorageAccountName))
+}
+
+// storageAccountPath returns the Azure Resource Manager path of the storage account of the state.
+func (client *Client) storageAccountPath() string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Storage/storageAccounts/%s", client.SubscriptionID, client.ResourceGroupName, client.StorageAccountName)
+}
+
+// managementRequest makes a request to the given path of Azure Resource Manager, with the given body as JSON, and
+// decodes the response into the given result, if any. It returns the status code of the response, without error if the
+// resource doesn't exist.
+func (client *Client) managementRequest(ctx context.Context, method, path string, body, result any) (int, error) {
+	if client.SubscriptionID == "" {
+		return 0, errors.New(MissingRequiredAzureRMRemoteStateConfig("subscription_id"))
+	}
+
+	reqURL := client.resourceManagerEndpoint() + path + "?api-version=" + managementAPIVersion
+
+	var reqBody io.Reader
+
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return 0, errors.New(err)
+		}
+
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, reqBody)
+	if err != nil {
+		return 0, errors.New(err)
+	}
+
+	token, err := client.token(ctx, client.resourceManagerEndpoint()+"/.default")
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return 0, errors.New(err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode == http.StatusNotFound && method == http.MethodGet {
+		return resp.StatusCode, nil
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyLength))
+
+		return resp.StatusCode, errors.New(ManagementRequestError{Method: method, URL: client.resourceManagerEndpoint() + path, StatusCode: resp.StatusCode, Body: string(respBody)})
+	}
+
+	if result != nil {
+		if err := json.NewDecoder(resp.Body).Decode(result); err != nil && !errors.Is(err, io.EOF) {
+			return resp.StatusCode, errors.New(err)
+		}
+	}
+
+	return resp.StatusCode, nil
+}
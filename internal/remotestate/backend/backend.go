@@ -3,7 +3,9 @@ package backend
 
 import (
 	"context"
+	"time"
 
+	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/options"
 	"github.com/gruntwork-io/terragrunt/pkg/log"
 )
@@ -47,3 +49,132 @@ type Backend interface {
 	// Allows the Backends to filter and/or modify the configuration given from the user.
 	GetTFInitArgs(config Config) map[string]any
 }
+
+// StateReader is implemented by the backends that can read the state they store without invoking OpenTofu/Terraform,
+// e.g. to fetch the outputs of dependencies directly from their state.
+type StateReader interface {
+	// ReadState returns the content of the state file stored in the backend with the given config.
+	ReadState(ctx context.Context, l log.Logger, config Config, opts *options.TerragruntOptions) ([]byte, error)
+}
+
+// CredentialsInjector is implemented by the backends whose credentials are passed to OpenTofu/Terraform as env vars at
+// runtime, e.g. the http backend, rather than written to the backend config.
+type CredentialsInjector interface {
+	// InjectCredentials sets the credentials of the given config in the env of the given options.
+	InjectCredentials(ctx context.Context, l log.Logger, config Config, opts *options.TerragruntOptions) error
+}
+
+// ConnectivityChecker is implemented by the backends that can check they are reachable, with the credentials of their
+// config, before `init`, e.g. the http backend, whose server is only known to be valid once OpenTofu/Terraform reads
+// the state.
+type ConnectivityChecker interface {
+	// CheckConnectivity returns an error if the backend with the given config can't be reached.
+	CheckConnectivity(ctx context.Context, l log.Logger, config Config, opts *options.TerragruntOptions) error
+}
+
+// StateLocker is implemented by the backends that can lock the state they store without invoking OpenTofu/Terraform,
+// e.g. to lock the state left at its previous backend once migrated, so that it isn't changed anymore.
+type StateLocker interface {
+	// LockState locks the state stored in the backend with the given config with the given lock, which is never
+	// released.
+	LockState(ctx context.Context, l log.Logger, config Config, lock *StateLock, opts *options.TerragruntOptions) error
+}
+
+// StateReencrypter is implemented by the backends whose states are encrypted with a KMS key of their config, e.g. the
+// S3 backend with `kms_key_id`, to re-encrypt the states once the key of the config changes, instead of leaving them
+// on the retired key.
+type StateReencrypter interface {
+	// ReencryptState re-encrypts the states of the given config with its KMS key, and updates the default encryption
+	// of their bucket to the key.
+	ReencryptState(ctx context.Context, l log.Logger, config Config, opts *options.TerragruntOptions) error
+}
+
+// StateLock is the lock of a state, with the fields of the locks of OpenTofu/Terraform, so that they report it as they
+// report their own locks.
+type StateLock struct {
+	Created   time.Time `json:"Created"`
+	ID        string    `json:"ID"`
+	Operation string    `json:"Operation"`
+	Info      string    `json:"Info"`
+	Who       string    `json:"Who"`
+	Version   string    `json:"Version"`
+	// Path is the path of the locked state, set by the backends.
+	Path string `json:"Path"`
+}
+
+// LockMigrator is implemented by the backends whose state can be locked both with a lock table and natively, e.g. the
+// S3 backend with a DynamoDB table and S3 lockfiles, to switch the state off the lock table.
+type LockMigrator interface {
+	// LockMigrationStatus returns the status of the migration off its lock table of the state of the given config.
+	LockMigrationStatus(ctx context.Context, l log.Logger, config Config, opts *options.TerragruntOptions) (*LockMigrationStatus, error)
+
+	// DeleteLockTable deletes the given lock table, accessed with the credentials of the given config, once it holds no
+	// lock, nor any item of the states other than the given ones, as the table may be shared with other stacks.
+	DeleteLockTable(ctx context.Context, l log.Logger, config Config, tableName string, stateIDs []string, opts *options.TerragruntOptions) error
+}
+
+// LockMigrationStatus is the status of the migration of a state off its lock table.
+type LockMigrationStatus struct {
+	// LockTable is the lock table the state is locked with, empty once the state is switched off it.
+	LockTable string
+	// StateID is the ID of the items of the state in the lock tables, e.g. `<bucket>/<key>`.
+	StateID string
+	// NativeLocking is true if the state is also locked natively.
+	NativeLocking bool
+	// Locked is true if the state is currently locked, e.g. by a run of OpenTofu/Terraform.
+	Locked bool
+}
+
+// ReadyToSwitch returns true if the state can be switched off its lock table: it is also locked natively, so removing
+// the lock table from the config never leaves it unlocked, and it isn't currently locked.
+func (status *LockMigrationStatus) ReadyToSwitch() bool {
+	return status.LockTable != "" && status.NativeLocking && !status.Locked
+}
+
+// BucketDriftChecker is implemented by the backends whose bucket, bootstrapped with the settings of their config, can
+// drift from it once bootstrapped, e.g. the S3 backend, whose bucket encryption or public access block can be changed
+// outside of Terragrunt.
+type BucketDriftChecker interface {
+	// BucketDrift returns the settings of the bucket of the given config that differ from the config, or nil if the
+	// bucket doesn't exist.
+	BucketDrift(ctx context.Context, l log.Logger, config Config, opts *options.TerragruntOptions) (*BucketDrift, error)
+
+	// RemediateBucketDrift updates the settings of the bucket of the given config that differ from the config, without
+	// prompting the user.
+	RemediateBucketDrift(ctx context.Context, l log.Logger, config Config, opts *options.TerragruntOptions) error
+}
+
+// BucketDrift is the drift of a bucket from its config.
+type BucketDrift struct {
+	// Bucket is the name of the bucket.
+	Bucket string
+	// Settings are the descriptions of the settings of the bucket that differ from the config, empty if the bucket
+	// matches its config.
+	Settings []string
+}
+
+// DriftPolicy is the policy applied to the bucket that drifted from its config.
+type DriftPolicy string
+
+const (
+	// DriftPolicyWarn logs the drift of the bucket.
+	DriftPolicyWarn DriftPolicy = "warn"
+	// DriftPolicyFail returns an error if the bucket drifted.
+	DriftPolicyFail DriftPolicy = "fail"
+	// DriftPolicyRemediate updates the bucket to match its config.
+	DriftPolicyRemediate DriftPolicy = "remediate"
+)
+
+// DriftPolicies are the supported drift policies.
+var DriftPolicies = []DriftPolicy{DriftPolicyWarn, DriftPolicyFail, DriftPolicyRemediate}
+
+// ParseDriftPolicy returns the drift policy of the given name.
+func ParseDriftPolicy(str string) (DriftPolicy, error) {
+	for _, policy := range DriftPolicies {
+		if string(policy) == str {
+			return policy, nil
+		}
+	}
+
+	return "", errors.New(InvalidDriftPolicyError(str))
+}
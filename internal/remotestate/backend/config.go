@@ -7,7 +7,8 @@ import (
 )
 
 const (
-	configPathKey = "path"
+	configPathKey              = "path"
+	configBucketDriftPolicyKey = "bucket_drift_policy"
 )
 
 type Config map[string]any
@@ -17,6 +18,11 @@ func (cfg Config) Path() string {
 	return getConfigValueByKey[string](cfg, configPathKey)
 }
 
+// DriftPolicy returns the `bucket_drift_policy` field value.
+func (cfg Config) DriftPolicy() string {
+	return getConfigValueByKey[string](cfg, configBucketDriftPolicyKey)
+}
+
 // IsEqual returns true if the given `targetCfg` config is in any way different than what is configured for the backend.
 func (cfg Config) IsEqual(targetCfg Config, backendName string, logger log.Logger) bool {
 	if len(cfg) == 0 && len(targetCfg) == 0 {
@@ -2,6 +2,7 @@ package backend
 
 import (
 	"fmt"
+	"strings"
 )
 
 type BucketCreationNotAllowed string
@@ -24,3 +25,59 @@ func NewBucketDoesNotExistError(bucketName string) *BucketDoesNotExistError {
 func (err BucketDoesNotExistError) Error() string {
 	return fmt.Sprintf("S3 bucket %s does not exist", err.bucketName)
 }
+
+// StateReaderNotSupportedError is the error that is returned when the state of a backend can't be read without
+// invoking OpenTofu/Terraform.
+type StateReaderNotSupportedError string
+
+// Error implements `error` interface.
+func (backendName StateReaderNotSupportedError) Error() string {
+	return fmt.Sprintf("reading the state of the %s backend is not supported", string(backendName))
+}
+
+// LockMigratorNotSupportedError is the error that is returned when the state of a backend can't be switched off its
+// lock table.
+type LockMigratorNotSupportedError string
+
+// Error implements `error` interface.
+func (backendName LockMigratorNotSupportedError) Error() string {
+	return fmt.Sprintf("migrating the locks of the %s backend is not supported", string(backendName))
+}
+
+// StateLockerNotSupportedError is the error that is returned when the state of a backend can't be locked without
+// invoking OpenTofu/Terraform.
+type StateLockerNotSupportedError string
+
+// Error implements `error` interface.
+func (backendName StateLockerNotSupportedError) Error() string {
+	return fmt.Sprintf("locking the state of the %s backend is not supported", string(backendName))
+}
+
+// StateReencrypterNotSupportedError is the error that is returned when the states of a backend can't be re-encrypted.
+type StateReencrypterNotSupportedError string
+
+// Error implements `error` interface.
+func (backendName StateReencrypterNotSupportedError) Error() string {
+	return fmt.Sprintf("re-encrypting the state of the %s backend is not supported", string(backendName))
+}
+
+// InvalidDriftPolicyError is the error that is returned when the bucket drift policy is not supported.
+type InvalidDriftPolicyError string
+
+// Error implements `error` interface.
+func (policy InvalidDriftPolicyError) Error() string {
+	return fmt.Sprintf("unsupported bucket drift policy %q, expected one of %s", string(policy), DriftPolicies)
+}
+
+// BucketDriftError is the error that is returned when the bucket of a backend drifted from its config with the `fail`
+// drift policy.
+type BucketDriftError struct {
+	BackendName string
+	Bucket      string
+	Settings    []string
+}
+
+// Error implements `error` interface.
+func (err BucketDriftError) Error() string {
+	return fmt.Sprintf("remote state %s bucket %s drifted from its config: %s", err.BackendName, err.Bucket, strings.Join(err.Settings, "; "))
+}
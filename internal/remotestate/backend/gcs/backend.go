@@ -18,7 +18,10 @@ const (
 	defaultTfState = "default.tfstate"
 )
 
-var _ backend.Backend = new(Backend)
+var (
+	_ backend.Backend     = new(Backend)
+	_ backend.StateReader = new(Backend)
+)
 
 type Backend struct {
 	*backend.CommonBackend
@@ -30,13 +33,14 @@ func NewBackend() *Backend {
 	}
 }
 
-// NeedsBootstrap returns true if the GCS bucket specified in the given config does not exist or if the bucket
-// exists but versioning is not enabled.
+// NeedsBootstrap returns true if the GCS bucket specified in the given config needs to be bootstrapped.
 //
 // Returns true if:
 //
 // 1. Any of the existing backend settings are different than the current config
 // 2. The configured GCS bucket does not exist
+// 3. The settings of the bucket, e.g. its versioning or labels, differ from the config, unless bucket updates are disabled
+// 4. The permissions on the bucket are checked with `enable_bucket_access_check`
 func (backend *Backend) NeedsBootstrap(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) (bool, error) {
 	extGCSCfg, err := Config(backendConfig).ExtendedGCSConfig()
 	if err != nil {
@@ -63,11 +67,22 @@ func (backend *Backend) NeedsBootstrap(ctx context.Context, l log.Logger, backen
 		return true, nil
 	}
 
+	if extGCSCfg.EnableBucketAccessCheck {
+		return true, nil
+	}
+
+	if !extGCSCfg.SkipBucketCreation && !extGCSCfg.DisableBucketUpdate {
+		if _, updates, err := client.checkIfGCSBucketNeedsUpdate(ctx, l, bucketName); err != nil || len(updates) > 0 {
+			return true, err
+		}
+	}
+
 	return false, nil
 }
 
 // Bootstrap the remote state GCS bucket specified in the given config. This function will validate the config
-// parameters, create the GCS bucket if it doesn't already exist, and check that versioning is enabled.
+// parameters, create the GCS bucket if it doesn't already exist, update its settings if they differ from the config,
+// check that versioning is enabled, and check the permissions on the bucket with `enable_bucket_access_check`.
 func (backend *Backend) Bootstrap(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) error {
 	extGCSCfg, err := Config(backendConfig).ExtendedGCSConfig()
 	if err != nil {
@@ -106,7 +121,14 @@ func (backend *Backend) Bootstrap(ctx context.Context, l log.Logger, backendConf
 		if err := client.CreateGCSBucketIfNecessary(ctx, l, bucketName, opts); err != nil {
 			return err
 		}
+
+		if !extGCSCfg.DisableBucketUpdate {
+			if err := client.UpdateGCSBucketIfNecessary(ctx, l, bucketName, opts); err != nil {
+				return err
+			}
+		}
 	}
+
 	// If bucket is specified and skip_bucket_versioning is false then warn user if versioning is disabled on bucket
 	if !extGCSCfg.SkipBucketVersioning && bucketName != "" {
 		// TODO: Remove lint suppression
@@ -115,6 +137,12 @@ func (backend *Backend) Bootstrap(ctx context.Context, l log.Logger, backendConf
 		}
 	}
 
+	if extGCSCfg.EnableBucketAccessCheck && bucketName != "" {
+		if err := client.CheckGCSBucketAccess(ctx, l, bucketName); err != nil {
+			return err
+		}
+	}
+
 	backend.MarkConfigInited(gcsCfg)
 
 	return nil
@@ -220,3 +248,31 @@ func (backend *Backend) DeleteBucket(ctx context.Context, l log.Logger, backendC
 func (backend *Backend) GetTFInitArgs(config backend.Config) map[string]any {
 	return Config(config).FilterOutTerragruntKeys()
 }
+
+// ReadState implements `backend.StateReader` interface.
+func (backend *Backend) ReadState(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) ([]byte, error) {
+	extGCSCfg, err := Config(backendConfig).ParseExtendedGCSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := NewClient(ctx, extGCSCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := client.Close(); err != nil {
+			l.Warnf("Error closing GCS client: %v", err)
+		}
+	}()
+
+	var (
+		bucketName = extGCSCfg.RemoteStateConfigGCS.Bucket
+		key        = extGCSCfg.StateKey()
+	)
+
+	l.Debugf("Reading state from gs://%s/%s", bucketName, key)
+
+	return client.GetGCSObject(ctx, bucketName, key)
+}
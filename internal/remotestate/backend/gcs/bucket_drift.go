@@ -0,0 +1,91 @@
+package gcs
+
+import (
+	"context"
+
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+var _ backend.BucketDriftChecker = new(Backend)
+
+// BucketDrift implements `backend.BucketDriftChecker` interface.
+//
+// The versioning, uniform bucket-level access, public access prevention, encryption key, retention policy and labels
+// of the bucket are compared with the config, as they are when bootstrapping the bucket. It returns nil if the bucket
+// doesn't exist yet.
+func (*Backend) BucketDrift(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) (*backend.BucketDrift, error) {
+	extGCSCfg, err := Config(backendConfig).ExtendedGCSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := NewClient(ctx, extGCSCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := client.Close(); err != nil {
+			l.Warnf("Error closing GCS client: %v", err)
+		}
+	}()
+
+	bucketName := extGCSCfg.RemoteStateConfigGCS.Bucket
+
+	if !client.DoesGCSBucketExist(ctx, bucketName) {
+		return nil, nil
+	}
+
+	l.Debugf("Checking the drift of the remote state GCS bucket %s", bucketName)
+
+	_, updates, err := client.GCSBucketDrift(ctx, l, bucketName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &backend.BucketDrift{Bucket: bucketName, Settings: updates}, nil
+}
+
+// RemediateBucketDrift implements `backend.BucketDriftChecker` interface.
+//
+// The settings of the bucket that drifted from the config are updated, without prompting, unless the bucket updates
+// are disabled with `disable_bucket_update`. The labels of the bucket that aren't in the config are kept.
+func (backend *Backend) RemediateBucketDrift(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) error {
+	extGCSCfg, err := Config(backendConfig).ExtendedGCSConfig()
+	if err != nil {
+		return err
+	}
+
+	bucketName := extGCSCfg.RemoteStateConfigGCS.Bucket
+
+	if extGCSCfg.DisableBucketUpdate {
+		l.Warnf("Updates of the remote state GCS bucket %s are disabled using 'disable_bucket_update' config, leaving its drift as it is.", bucketName)
+		return nil
+	}
+
+	// ensure that the units sharing the bucket don't update it concurrently
+	mu := backend.GetBucketMutex(bucketName)
+	mu.Lock()
+	defer mu.Unlock()
+
+	client, err := NewClient(ctx, extGCSCfg)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := client.Close(); err != nil {
+			l.Warnf("Error closing GCS client: %v", err)
+		}
+	}()
+
+	// The bucket may have been remediated by another unit meanwhile.
+	bucketAttrs, updates, err := client.GCSBucketDrift(ctx, l, bucketName)
+	if err != nil || len(updates) == 0 {
+		return err
+	}
+
+	return client.updateGCSBucket(ctx, l, bucketName, bucketAttrs)
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path"
+	"slices"
 	"time"
 
 	"cloud.google.com/go/storage"
@@ -32,6 +34,15 @@ const (
 	tokenURL = "https://oauth2.googleapis.com/token"
 )
 
+// requiredBucketPermissions are the permissions on the remote state bucket OpenTofu/Terraform needs to read, write and
+// lock the state, checked with `enable_bucket_access_check`.
+var requiredBucketPermissions = []string{
+	"storage.objects.create",
+	"storage.objects.delete",
+	"storage.objects.get",
+	"storage.objects.list",
+}
+
 type Client struct {
 	*ExtendedRemoteStateConfigGCS
 	*storage.Client
@@ -250,6 +261,29 @@ func (client *Client) CreateGCSBucket(ctx context.Context, l log.Logger, bucketN
 		bucketAttrs.BucketPolicyOnly = storage.BucketPolicyOnly{Enabled: true}
 	}
 
+	if client.EnableBucketPublicAccessPrevention {
+		l.Debugf("Enforcing public access prevention on GCS bucket %s", bucketName)
+
+		bucketAttrs.PublicAccessPrevention = storage.PublicAccessPreventionEnforced
+	}
+
+	if client.BucketKMSKeyName != "" {
+		l.Debugf("Encrypting GCS bucket %s with KMS key %s", bucketName, client.BucketKMSKeyName)
+
+		bucketAttrs.Encryption = &storage.BucketEncryption{DefaultKMSKeyName: client.BucketKMSKeyName}
+	}
+
+	retentionPeriod, err := client.RetentionPeriod()
+	if err != nil {
+		return err
+	}
+
+	if retentionPeriod > 0 {
+		l.Debugf("Setting a retention policy of %s on GCS bucket %s", retentionPeriod, bucketName)
+
+		bucketAttrs.RetentionPolicy = &storage.RetentionPolicy{RetentionPeriod: retentionPeriod}
+	}
+
 	if err := bucket.Create(ctx, projectID, bucketAttrs); err != nil {
 		return errors.Errorf("error creating GCS bucket %s: %w", bucketName, err)
 	}
@@ -257,6 +291,166 @@ func (client *Client) CreateGCSBucket(ctx context.Context, l log.Logger, bucketN
 	return nil
 }
 
+// UpdateGCSBucketIfNecessary prompts the user to update the given bucket if its settings differ from the config, i.e.
+// its versioning, uniform bucket-level access, public access prevention, encryption key, retention policy or labels,
+// and if the user confirms, updates the bucket.
+func (client *Client) UpdateGCSBucketIfNecessary(ctx context.Context, l log.Logger, bucketName string, opts *options.TerragruntOptions) error {
+	bucketAttrs, updates, err := client.checkIfGCSBucketNeedsUpdate(ctx, l, bucketName)
+	if err != nil {
+		return err
+	}
+
+	if len(updates) == 0 {
+		l.Debug("GCS bucket is already up to date")
+		return nil
+	}
+
+	prompt := fmt.Sprintf("Remote state GCS bucket %s is out of date. Would you like Terragrunt to update it?", bucketName)
+
+	shouldUpdateBucket, err := shell.PromptUserForYesNo(ctx, l, prompt, opts)
+	if err != nil {
+		return err
+	}
+
+	if !shouldUpdateBucket {
+		return nil
+	}
+
+	return client.updateGCSBucket(ctx, l, bucketName, bucketAttrs)
+}
+
+// updateGCSBucket updates the given bucket with the given attributes.
+func (client *Client) updateGCSBucket(ctx context.Context, l log.Logger, bucketName string, bucketAttrs *storage.BucketAttrsToUpdate) error {
+	l.Debugf("Updating GCS bucket %s", bucketName)
+
+	if _, err := client.Bucket(bucketName).Update(ctx, *bucketAttrs); err != nil {
+		return errors.Errorf("error updating GCS bucket %s: %w", bucketName, err)
+	}
+
+	return nil
+}
+
+// checkIfGCSBucketNeedsUpdate returns the updates of the given bucket to match the config, and their descriptions,
+// empty if the bucket is up to date.
+func (client *Client) checkIfGCSBucketNeedsUpdate(ctx context.Context, l log.Logger, bucketName string) (*storage.BucketAttrsToUpdate, []string, error) {
+	bucketAttrs, updates, err := client.GCSBucketDrift(ctx, l, bucketName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(updates) > 0 {
+		l.Warnf("The remote state GCS bucket %s needs to be updated:", bucketName)
+
+		for _, update := range updates {
+			l.Warnf("  - %s", update)
+		}
+	}
+
+	return bucketAttrs, updates, nil
+}
+
+// GCSBucketDrift returns the updates of the given bucket to match the config, and their descriptions, empty if the
+// bucket is up to date.
+func (client *Client) GCSBucketDrift(ctx context.Context, l log.Logger, bucketName string) (*storage.BucketAttrsToUpdate, []string, error) {
+	attrs, err := client.Bucket(bucketName).Attrs(ctx)
+	if err != nil {
+		return nil, nil, errors.New(err)
+	}
+
+	return client.BucketUpdates(l, attrs)
+}
+
+// BucketUpdates returns the updates of a bucket with the given attributes to match the config, and their
+// descriptions, empty if the bucket is up to date. The settings the config doesn't enable are left as they are, and
+// the labels of the bucket that aren't in the config are kept.
+func (cfg *ExtendedRemoteStateConfigGCS) BucketUpdates(l log.Logger, attrs *storage.BucketAttrs) (*storage.BucketAttrsToUpdate, []string, error) {
+	var (
+		bucketAttrs = &storage.BucketAttrsToUpdate{}
+		updates     []string
+	)
+
+	if !cfg.SkipBucketVersioning && !attrs.VersioningEnabled {
+		bucketAttrs.VersioningEnabled = true
+
+		updates = append(updates, "Bucket Versioning")
+	}
+
+	if cfg.EnableBucketPolicyOnly && !attrs.UniformBucketLevelAccess.Enabled {
+		bucketAttrs.UniformBucketLevelAccess = &storage.UniformBucketLevelAccess{Enabled: true}
+
+		updates = append(updates, "Bucket Uniform Bucket-Level Access")
+	}
+
+	if cfg.EnableBucketPublicAccessPrevention && attrs.PublicAccessPrevention != storage.PublicAccessPreventionEnforced {
+		bucketAttrs.PublicAccessPrevention = storage.PublicAccessPreventionEnforced
+
+		updates = append(updates, "Bucket Public Access Prevention")
+	}
+
+	if cfg.BucketKMSKeyName != "" && (attrs.Encryption == nil || attrs.Encryption.DefaultKMSKeyName != cfg.BucketKMSKeyName) {
+		bucketAttrs.Encryption = &storage.BucketEncryption{DefaultKMSKeyName: cfg.BucketKMSKeyName}
+
+		updates = append(updates, "Bucket Encryption Key")
+	}
+
+	retentionPeriod, err := cfg.RetentionPeriod()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if retentionPeriod > 0 && (attrs.RetentionPolicy == nil || attrs.RetentionPolicy.RetentionPeriod != retentionPeriod) {
+		// A locked retention policy can't be changed.
+		if attrs.RetentionPolicy != nil && attrs.RetentionPolicy.IsLocked {
+			l.Warnf("The retention policy of the remote state GCS bucket %s is locked, so it can't be set to %s.", attrs.Name, retentionPeriod)
+		} else {
+			bucketAttrs.RetentionPolicy = &storage.RetentionPolicy{RetentionPeriod: retentionPeriod}
+
+			updates = append(updates, "Bucket Retention Policy")
+		}
+	}
+
+	labelsUpdated := false
+
+	for key, value := range cfg.GCSBucketLabels {
+		if current, ok := attrs.Labels[key]; !ok || current != value {
+			bucketAttrs.SetLabel(key, value)
+
+			labelsUpdated = true
+		}
+	}
+
+	if labelsUpdated {
+		updates = append(updates, "Bucket Labels")
+	}
+
+	return bucketAttrs, updates, nil
+}
+
+// CheckGCSBucketAccess returns an error if the credentials are missing any of the permissions on the given bucket
+// OpenTofu/Terraform needs to read, write and lock the state.
+func (client *Client) CheckGCSBucketAccess(ctx context.Context, l log.Logger, bucketName string) error {
+	l.Debugf("Checking the permissions on GCS bucket %s", bucketName)
+
+	granted, err := client.Bucket(bucketName).IAM().TestPermissions(ctx, requiredBucketPermissions)
+	if err != nil {
+		return errors.Errorf("error checking the permissions on GCS bucket %s: %w", bucketName, err)
+	}
+
+	var missing []string
+
+	for _, permission := range requiredBucketPermissions {
+		if !slices.Contains(granted, permission) {
+			missing = append(missing, permission)
+		}
+	}
+
+	if len(missing) > 0 {
+		return errors.New(MissingGCSBucketPermissions{bucket: bucketName, permissions: missing})
+	}
+
+	return nil
+}
+
 // WaitUntilGCSBucketExists waits for the GCS bucket specified in the given config to be created.
 //
 // GCP is eventually consistent, so after creating a GCS bucket, this method can be used to wait until the information
@@ -437,6 +631,28 @@ func (client *Client) DoesGCSObjectExistWithLogging(ctx context.Context, l log.L
 	return false, nil
 }
 
+// GetGCSObject returns the content of the specified GCS object.
+func (client *Client) GetGCSObject(ctx context.Context, bucketName, key string) ([]byte, error) {
+	reader, err := client.Bucket(bucketName).Object(key).NewReader(ctx)
+	if err != nil {
+		return nil, errors.Errorf("failed to read GCS bucket %s object %s: %w", bucketName, key, err)
+	}
+	defer reader.Close() //nolint:errcheck
+
+	return io.ReadAll(reader)
+}
+
+// GetGCSObjectAttrs returns the attributes of the specified GCS object, such as its generation and the time it was
+// last modified, without reading its content.
+func (client *Client) GetGCSObjectAttrs(ctx context.Context, bucketName, key string) (*storage.ObjectAttrs, error) {
+	attrs, err := client.Bucket(bucketName).Object(key).Attrs(ctx)
+	if err != nil {
+		return nil, errors.Errorf("failed to read GCS bucket %s object %s attributes: %w", bucketName, key, err)
+	}
+
+	return attrs, nil
+}
+
 // MoveGCSObject copies the GCS object at the specified srcKey to dstKey and then removes srcKey.
 func (client *Client) MoveGCSObject(ctx context.Context, l log.Logger, srcBucketName, srcKey, dstBucketName, dstKey string) error {
 	if err := client.CopyGCSBucketObject(ctx, l, srcBucketName, srcKey, dstBucketName, dstKey); err != nil {
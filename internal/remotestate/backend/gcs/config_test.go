@@ -2,10 +2,13 @@ package gcs_test
 
 import (
 	"testing"
+	"time"
 
+	"cloud.google.com/go/storage"
 	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend/gcs"
 	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestConfig_IsEqual(t *testing.T) {
@@ -102,3 +105,105 @@ func TestConfig_IsEqual(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_StateKey(t *testing.T) {
+	t.Parallel()
+
+	extGCSCfg, err := gcs.Config{"bucket": "my-bucket", "prefix": "prod/vpc"}.ParseExtendedGCSConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "prod/vpc/default.tfstate", extGCSCfg.StateKey())
+
+	extGCSCfg, err = gcs.Config{"bucket": "my-bucket"}.ParseExtendedGCSConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "default.tfstate", extGCSCfg.StateKey())
+}
+
+func TestConfig_RetentionPeriod(t *testing.T) {
+	t.Parallel()
+
+	extGCSCfg, err := gcs.Config{"bucket": "my-bucket", "bucket_retention_period": "720h"}.ParseExtendedGCSConfig()
+	require.NoError(t, err)
+
+	period, err := extGCSCfg.RetentionPeriod()
+	require.NoError(t, err)
+	assert.Equal(t, 720*time.Hour, period)
+
+	extGCSCfg, err = gcs.Config{"bucket": "my-bucket", "bucket_retention_period": "30 days"}.ParseExtendedGCSConfig()
+	require.NoError(t, err)
+	require.Error(t, extGCSCfg.Validate())
+}
+
+func TestConfig_BucketUpdates(t *testing.T) {
+	t.Parallel()
+
+	logger := logger.CreateLogger()
+
+	extGCSCfg, err := gcs.Config{
+		"bucket":                                 "my-bucket",
+		"project":                                "my-project",
+		"location":                               "eu",
+		"enable_bucket_policy_only":              true,
+		"enable_bucket_public_access_prevention": true,
+		"bucket_kms_key_name":                    "projects/p/locations/eu/keyRings/r/cryptoKeys/k",
+		"bucket_retention_period":                "720h",
+		"gcs_bucket_labels":                      map[string]any{"team": "platform"},
+	}.ParseExtendedGCSConfig()
+	require.NoError(t, err)
+
+	testCases := []struct { //nolint: govet
+		name     string
+		attrs    *storage.BucketAttrs
+		expected []string
+	}{
+		{
+			"up-to-date",
+			&storage.BucketAttrs{
+				VersioningEnabled:        true,
+				UniformBucketLevelAccess: storage.UniformBucketLevelAccess{Enabled: true},
+				PublicAccessPrevention:   storage.PublicAccessPreventionEnforced,
+				Encryption:               &storage.BucketEncryption{DefaultKMSKeyName: "projects/p/locations/eu/keyRings/r/cryptoKeys/k"},
+				RetentionPolicy:          &storage.RetentionPolicy{RetentionPeriod: 720 * time.Hour},
+				Labels:                   map[string]string{"team": "platform", "other": "kept"},
+			},
+			nil,
+		},
+		{
+			"out-of-date",
+			&storage.BucketAttrs{
+				Encryption:      &storage.BucketEncryption{DefaultKMSKeyName: "projects/p/locations/eu/keyRings/r/cryptoKeys/old"},
+				RetentionPolicy: &storage.RetentionPolicy{RetentionPeriod: time.Hour},
+				Labels:          map[string]string{"team": "other"},
+			},
+			[]string{
+				"Bucket Versioning",
+				"Bucket Uniform Bucket-Level Access",
+				"Bucket Public Access Prevention",
+				"Bucket Encryption Key",
+				"Bucket Retention Policy",
+				"Bucket Labels",
+			},
+		},
+		{
+			"locked-retention-policy",
+			&storage.BucketAttrs{
+				VersioningEnabled:        true,
+				UniformBucketLevelAccess: storage.UniformBucketLevelAccess{Enabled: true},
+				PublicAccessPrevention:   storage.PublicAccessPreventionEnforced,
+				Encryption:               &storage.BucketEncryption{DefaultKMSKeyName: "projects/p/locations/eu/keyRings/r/cryptoKeys/k"},
+				RetentionPolicy:          &storage.RetentionPolicy{RetentionPeriod: time.Hour, IsLocked: true},
+				Labels:                   map[string]string{"team": "platform"},
+			},
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, updates, err := extGCSCfg.BucketUpdates(logger, tc.attrs)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, updates)
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package gcs
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type MissingRequiredGCSRemoteStateConfig string
 
@@ -13,3 +16,21 @@ type MaxRetriesWaitingForGCSBucketExceeded string
 func (err MaxRetriesWaitingForGCSBucketExceeded) Error() string {
 	return fmt.Sprintf("Exceeded max retries (%d) waiting for bucket GCS bucket %s", maxRetriesWaitingForGcsBucket, string(err))
 }
+
+type InvalidGCSRemoteStateConfig struct {
+	name   string
+	reason string
+}
+
+func (err InvalidGCSRemoteStateConfig) Error() string {
+	return fmt.Sprintf("Invalid GCS remote state configuration %s: %s", err.name, err.reason)
+}
+
+type MissingGCSBucketPermissions struct {
+	bucket      string
+	permissions []string
+}
+
+func (err MissingGCSBucketPermissions) Error() string {
+	return fmt.Sprintf("The credentials are missing the permissions %s on the remote state GCS bucket %s", strings.Join(err.permissions, ", "), err.bucket)
+}
@@ -1,6 +1,9 @@
 package gcs
 
 import (
+	"path"
+	"time"
+
 	"github.com/gruntwork-io/terragrunt/internal/errors"
 )
 
@@ -13,22 +16,38 @@ var terragruntOnlyConfigs = []string{
 	"skip_bucket_versioning",
 	"skip_bucket_creation",
 	"enable_bucket_policy_only",
+	"enable_bucket_public_access_prevention",
+	"enable_bucket_access_check",
+	"disable_bucket_update",
+	"bucket_kms_key_name",
+	"bucket_retention_period",
+	"bucket_drift_policy",
 }
 
 /* ExtendedRemoteStateConfigGCS is a struct that contains the GCS specific configuration options.
  *
  * We use this construct to separate the config key 'gcs_bucket_labels' from the others, as they
  * are specific to the gcs backend, but only used by terragrunt to tag the gcs bucket in case it
- * has to create them.
+ * has to create them, and the settings of the bucket terragrunt creates it with, and updates it to.
  */
 type ExtendedRemoteStateConfigGCS struct {
-	GCSBucketLabels        map[string]string    `mapstructure:"gcs_bucket_labels"`
-	Project                string               `mapstructure:"project"`
-	Location               string               `mapstructure:"location"`
-	RemoteStateConfigGCS   RemoteStateConfigGCS `mapstructure:",squash"`
-	SkipBucketVersioning   bool                 `mapstructure:"skip_bucket_versioning"`
-	SkipBucketCreation     bool                 `mapstructure:"skip_bucket_creation"`
-	EnableBucketPolicyOnly bool                 `mapstructure:"enable_bucket_policy_only"`
+	GCSBucketLabels                    map[string]string    `mapstructure:"gcs_bucket_labels"`
+	Project                            string               `mapstructure:"project"`
+	Location                           string               `mapstructure:"location"`
+	BucketKMSKeyName                   string               `mapstructure:"bucket_kms_key_name"`
+	BucketRetentionPeriod              string               `mapstructure:"bucket_retention_period"`
+	RemoteStateConfigGCS               RemoteStateConfigGCS `mapstructure:",squash"`
+	SkipBucketVersioning               bool                 `mapstructure:"skip_bucket_versioning"`
+	SkipBucketCreation                 bool                 `mapstructure:"skip_bucket_creation"`
+	EnableBucketPolicyOnly             bool                 `mapstructure:"enable_bucket_policy_only"`
+	EnableBucketPublicAccessPrevention bool                 `mapstructure:"enable_bucket_public_access_prevention"`
+	EnableBucketAccessCheck            bool                 `mapstructure:"enable_bucket_access_check"`
+	DisableBucketUpdate                bool                 `mapstructure:"disable_bucket_update"`
+}
+
+// StateKey returns the key of the state object of the default workspace in the GCS bucket.
+func (cfg *ExtendedRemoteStateConfigGCS) StateKey() string {
+	return path.Join(cfg.RemoteStateConfigGCS.Prefix, defaultTfState)
 }
 
 // Validate validates the configuration for GCS remote state.
@@ -42,9 +61,28 @@ func (cfg *ExtendedRemoteStateConfigGCS) Validate() error {
 		return errors.New(MissingRequiredGCSRemoteStateConfig("bucket"))
 	}
 
+	if _, err := cfg.RetentionPeriod(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// RetentionPeriod returns the retention period of the objects of the bucket, or zero if the bucket has no retention
+// policy.
+func (cfg *ExtendedRemoteStateConfigGCS) RetentionPeriod() (time.Duration, error) {
+	if cfg.BucketRetentionPeriod == "" {
+		return 0, nil
+	}
+
+	period, err := time.ParseDuration(cfg.BucketRetentionPeriod)
+	if err != nil || period <= 0 {
+		return 0, errors.New(InvalidGCSRemoteStateConfig{name: "bucket_retention_period", reason: "expected a positive duration, e.g. 720h"})
+	}
+
+	return period, nil
+}
+
 // RemoteStateConfigGCS is a representation of the configuration
 // options available for GCS remote state.
 type RemoteStateConfigGCS struct {
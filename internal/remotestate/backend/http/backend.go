@@ -0,0 +1,187 @@
+// Package http represents the HTTP backend for reading remote state.
+package http
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net/http"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+const (
+	BackendName = "http"
+
+	maxErrorBodyLength = 1024
+)
+
+var (
+	_ backend.Backend             = new(Backend)
+	_ backend.StateReader         = new(Backend)
+	_ backend.CredentialsInjector = new(Backend)
+	_ backend.ConnectivityChecker = new(Backend)
+)
+
+// Backend configures the http backend, whose state is stored by the server, so there is nothing to bootstrap, nor to
+// migrate. The credentials of the backend are passed to OpenTofu/Terraform at runtime, and the state is read without
+// invoking OpenTofu/Terraform.
+type Backend struct {
+	*backend.CommonBackend
+}
+
+func NewBackend() *Backend {
+	return &Backend{
+		CommonBackend: backend.NewCommonBackend(BackendName),
+	}
+}
+
+// GetTFInitArgs returns the config that should be passed on to `tofu -backend-config` cmd line param, with the
+// addresses built by Terragrunt, but without the credentials, passed at runtime by `InjectCredentials`.
+func (backend *Backend) GetTFInitArgs(config backend.Config) map[string]any {
+	httpCfg, err := Config(config).ParseRemoteStateConfig(nil)
+	if err != nil {
+		// The invalid config is reported by `InjectCredentials`, run before OpenTofu/Terraform.
+		return Config(config).FilterOutTerragruntKeys()
+	}
+
+	return httpCfg.tfInitArgs(Config(config))
+}
+
+// InjectCredentials implements `backend.CredentialsInjector` interface.
+//
+// The `username` and `password` of the config are set as the `TF_HTTP_USERNAME` and `TF_HTTP_PASSWORD` env vars,
+// read by the http backend. Unset, the env vars are left as they are, e.g. set by the `--auth-provider-cmd`.
+func (backend *Backend) InjectCredentials(_ context.Context, l log.Logger, config backend.Config, opts *options.TerragruntOptions) error {
+	httpCfg, err := Config(config).ParseRemoteStateConfig(opts.Env)
+	if err != nil {
+		return err
+	}
+
+	if opts.Env == nil {
+		opts.Env = make(map[string]string)
+	}
+
+	for name, val := range map[string]string{
+		envUsername: httpCfg.Username,
+		envPassword: httpCfg.Password,
+	} {
+		if val == "" || opts.Env[name] == val {
+			continue
+		}
+
+		l.Debugf("Setting %s for the http backend", name)
+
+		opts.Env[name] = val
+	}
+
+	return nil
+}
+
+// CheckConnectivity implements `backend.ConnectivityChecker` interface.
+//
+// The `address` of the config is requested as the state is read, so that unreachable servers and invalid credentials
+// fail before `init`. The state doesn't exist before the first apply, so the server may not find it. The check is
+// skipped with `skip_connectivity_check`.
+func (backend *Backend) CheckConnectivity(ctx context.Context, l log.Logger, config backend.Config, opts *options.TerragruntOptions) error {
+	cfg, err := Config(config).ParseRemoteStateConfig(opts.Env)
+	if err != nil {
+		return err
+	}
+
+	if cfg.SkipConnectivityCheck {
+		l.Debugf("Skipping the connectivity check of the http backend")
+		return nil
+	}
+
+	l.Debugf("Checking connectivity of the http backend at %s", cfg.Address)
+
+	resp, err := cfg.getState(ctx)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyLength))
+
+	return errors.New(StateRequestError{Address: cfg.Address, StatusCode: resp.StatusCode, Body: string(body)})
+}
+
+// ReadState implements `backend.StateReader` interface.
+//
+// The state is fetched with a GET request to the `address` of the config, authenticated with the `username` and
+// `password` of the config, or of the `TF_HTTP_*` env vars, as the http backend does.
+func (backend *Backend) ReadState(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) ([]byte, error) {
+	cfg, err := Config(backendConfig).ParseRemoteStateConfig(opts.Env)
+	if err != nil {
+		return nil, err
+	}
+
+	l.Debugf("Reading state from %s", cfg.Address)
+
+	resp, err := cfg.getState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyLength))
+
+		return nil, errors.New(StateRequestError{Address: cfg.Address, StatusCode: resp.StatusCode, Body: string(body)})
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return body, nil
+}
+
+// getState requests the state at the address of the config, authenticated with the credentials of the config.
+func (cfg *RemoteStateConfigHTTP) getState(ctx context.Context) (*http.Response, error) {
+	if cfg.Address == "" {
+		return nil, errors.New(MissingRequiredHTTPRemoteStateConfig("address"))
+	}
+
+	return cfg.request(ctx, http.MethodGet, "address", cfg.Address, nil)
+}
+
+// request sends a request with the given method to the given address, the value of the given setting of the config,
+// authenticated with the credentials of the config.
+func (cfg *RemoteStateConfigHTTP) request(ctx context.Context, method, setting, address string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, address, body)
+	if err != nil {
+		return nil, errors.New(InvalidHTTPRemoteStateConfig{Setting: setting, Err: err})
+	}
+
+	if cfg.Username != "" || cfg.Password != "" {
+		req.SetBasicAuth(cfg.Username, cfg.Password)
+	}
+
+	httpClient := http.DefaultClient
+
+	if cfg.SkipCertVerification {
+		httpClient = &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true}, //nolint:gosec
+			},
+		}
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, errors.New(UnreachableBackendError{Address: address, Err: err})
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,336 @@
+package http_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend"
+	httpbackend "github.com/gruntwork-io/terragrunt/internal/remotestate/backend/http"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+)
+
+func TestBackendReadState(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		username, password, ok := req.BasicAuth()
+		if !ok || username != "user" || password != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"version": 4}`))
+	}))
+	t.Cleanup(server.Close)
+
+	testCases := []struct {
+		name        string
+		config      backend.Config
+		env         map[string]string
+		expectedErr bool
+	}{
+		{
+			name:   "config credentials",
+			config: backend.Config{"address": server.URL, "username": "user", "password": "pass"},
+		},
+		{
+			name:   "env credentials",
+			config: backend.Config{"address": server.URL},
+			env:    map[string]string{"TF_HTTP_USERNAME": "user", "TF_HTTP_PASSWORD": "pass"},
+		},
+		{
+			name:        "invalid credentials",
+			config:      backend.Config{"address": server.URL, "username": "user", "password": "wrong"},
+			expectedErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts, err := options.NewTerragruntOptionsForTest("")
+			require.NoError(t, err)
+
+			opts.Env = tc.env
+
+			body, err := httpbackend.NewBackend().ReadState(t.Context(), logger.CreateLogger(), tc.config, opts)
+			if tc.expectedErr {
+				var stateErr httpbackend.StateRequestError
+
+				require.ErrorAs(t, err, &stateErr)
+				assert.Equal(t, http.StatusUnauthorized, stateErr.StatusCode)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.JSONEq(t, `{"version": 4}`, string(body))
+		})
+	}
+}
+
+func TestBackendGetTFInitArgs(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		config   backend.Config
+		expected map[string]any
+	}{
+		{
+			name:     "credentials",
+			config:   backend.Config{"address": "https://state.example.com/app", "username": "user", "password": "pass"},
+			expected: map[string]any{"address": "https://state.example.com/app"},
+		},
+		{
+			name:     "base address",
+			config:   backend.Config{"base_address": "https://state.example.com/states/", "state_name": "prod/my app", "skip_connectivity_check": true},
+			expected: map[string]any{"address": "https://state.example.com/states/prod/my%20app"},
+		},
+		{
+			name: "locking",
+			config: backend.Config{
+				"base_address":   "https://gitlab.example.com/api/v4/projects/1/terraform/state",
+				"state_name":     "prod",
+				"enable_locking": true,
+				"lock_method":    "POST",
+				"unlock_method":  "DELETE",
+			},
+			expected: map[string]any{
+				"address":        "https://gitlab.example.com/api/v4/projects/1/terraform/state/prod",
+				"lock_address":   "https://gitlab.example.com/api/v4/projects/1/terraform/state/prod/lock",
+				"unlock_address": "https://gitlab.example.com/api/v4/projects/1/terraform/state/prod/lock",
+				"lock_method":    "POST",
+				"unlock_method":  "DELETE",
+			},
+		},
+		{
+			name:   "explicit addresses",
+			config: backend.Config{"address": "https://state.example.com/app", "lock_address": "https://state.example.com/locks/app", "enable_locking": true},
+			expected: map[string]any{
+				"address":        "https://state.example.com/app",
+				"lock_address":   "https://state.example.com/locks/app",
+				"unlock_address": "https://state.example.com/app/lock",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, httpbackend.NewBackend().GetTFInitArgs(tc.config))
+		})
+	}
+}
+
+func TestBackendInjectCredentials(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		config   backend.Config
+		env      map[string]string
+		expected map[string]string
+	}{
+		{
+			name:     "config credentials",
+			config:   backend.Config{"address": "https://state.example.com/app", "username": "user", "password": "pass"},
+			env:      map[string]string{"TF_HTTP_USERNAME": "other"},
+			expected: map[string]string{"TF_HTTP_USERNAME": "user", "TF_HTTP_PASSWORD": "pass"},
+		},
+		{
+			name:     "env credentials",
+			config:   backend.Config{"address": "https://state.example.com/app"},
+			env:      map[string]string{"TF_HTTP_USERNAME": "user", "TF_HTTP_PASSWORD": "pass"},
+			expected: map[string]string{"TF_HTTP_USERNAME": "user", "TF_HTTP_PASSWORD": "pass"},
+		},
+		{
+			name:     "no credentials",
+			config:   backend.Config{"address": "https://state.example.com/app"},
+			expected: map[string]string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts, err := options.NewTerragruntOptionsForTest("")
+			require.NoError(t, err)
+
+			opts.Env = tc.env
+
+			err = httpbackend.NewBackend().InjectCredentials(t.Context(), logger.CreateLogger(), tc.config, opts)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, opts.Env)
+		})
+	}
+}
+
+func TestBackendCheckConnectivity(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if username, password, ok := req.BasicAuth(); !ok || username != "user" || password != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		if req.URL.Path != "/states/app" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"version": 4}`))
+	}))
+	t.Cleanup(server.Close)
+
+	unreachable := httptest.NewServer(http.NotFoundHandler())
+	unreachable.Close()
+
+	testCases := []struct {
+		config         backend.Config
+		name           string
+		expectedStatus int
+		unreachable    bool
+	}{
+		{
+			name:   "existing state",
+			config: backend.Config{"base_address": server.URL + "/states", "state_name": "app", "username": "user", "password": "pass"},
+		},
+		{
+			name:   "new state",
+			config: backend.Config{"base_address": server.URL + "/states", "state_name": "new", "username": "user", "password": "pass"},
+		},
+		{
+			name:           "invalid credentials",
+			config:         backend.Config{"address": server.URL + "/states/app", "username": "user", "password": "wrong"},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:        "unreachable server",
+			config:      backend.Config{"address": unreachable.URL + "/states/app"},
+			unreachable: true,
+		},
+		{
+			name:   "skipped check",
+			config: backend.Config{"address": unreachable.URL + "/states/app", "skip_connectivity_check": true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts, err := options.NewTerragruntOptionsForTest("")
+			require.NoError(t, err)
+
+			err = httpbackend.NewBackend().CheckConnectivity(t.Context(), logger.CreateLogger(), tc.config, opts)
+
+			switch {
+			case tc.expectedStatus != 0:
+				var stateErr httpbackend.StateRequestError
+				require.ErrorAs(t, err, &stateErr)
+				assert.Equal(t, tc.expectedStatus, stateErr.StatusCode)
+			case tc.unreachable:
+				var unreachableErr httpbackend.UnreachableBackendError
+				require.ErrorAs(t, err, &unreachableErr)
+			default:
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestBackendLockState(t *testing.T) {
+	t.Parallel()
+
+	var locks = map[string]backend.StateLock{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != "LOCK" && req.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		if _, ok := locks[req.URL.Path]; ok {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		var lock backend.StateLock
+		if err := json.NewDecoder(req.Body).Decode(&lock); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		locks[req.URL.Path] = lock
+	}))
+	t.Cleanup(server.Close)
+
+	testCases := []struct {
+		config         backend.Config
+		name           string
+		expectedPath   string
+		expectedState  string
+		expectedStatus int
+	}{
+		{
+			name:          "lock address",
+			config:        backend.Config{"address": server.URL + "/states/app", "lock_address": server.URL + "/locks/app"},
+			expectedPath:  "/locks/app",
+			expectedState: "/states/app",
+		},
+		{
+			name:          "lock method",
+			config:        backend.Config{"base_address": server.URL + "/states", "state_name": "db", "enable_locking": true, "lock_method": "POST"},
+			expectedPath:  "/states/db/lock",
+			expectedState: "/states/db",
+		},
+		{
+			name:           "already locked",
+			config:         backend.Config{"address": server.URL + "/states/vpc", "lock_address": server.URL + "/locks/vpc"},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:   "no lock address",
+			config: backend.Config{"address": server.URL + "/states/dns"},
+		},
+	}
+
+	locks["/locks/vpc"] = backend.StateLock{ID: "existing"}
+
+	for _, tc := range testCases {
+		// The test cases share the locks of the server.
+		t.Run(tc.name, func(t *testing.T) {
+			opts, err := options.NewTerragruntOptionsForTest("")
+			require.NoError(t, err)
+
+			lock := &backend.StateLock{ID: "migrated", Operation: "migrate"}
+
+			err = httpbackend.NewBackend().LockState(t.Context(), logger.CreateLogger(), tc.config, lock, opts)
+
+			switch {
+			case tc.expectedStatus != 0:
+				var lockErr httpbackend.LockRequestError
+				require.ErrorAs(t, err, &lockErr)
+				assert.Equal(t, tc.expectedStatus, lockErr.StatusCode)
+			case tc.expectedPath == "":
+				var missingErr httpbackend.MissingRequiredHTTPRemoteStateConfig
+				require.ErrorAs(t, err, &missingErr)
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, "migrated", locks[tc.expectedPath].ID)
+				assert.Equal(t, server.URL+tc.expectedState, locks[tc.expectedPath].Path)
+			}
+		})
+	}
+}
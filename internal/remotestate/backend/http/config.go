@@ -0,0 +1,157 @@
+package http
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+const (
+	// lockPath is the path, relative to the address of the state, of its lock address and unlock address when
+	// `enable_locking` is set, as served e.g. by GitLab.
+	lockPath = "lock"
+
+	envAddress  = "TF_HTTP_ADDRESS"
+	envUsername = "TF_HTTP_USERNAME"
+	envPassword = "TF_HTTP_PASSWORD"
+)
+
+var terragruntOnlyConfigs = []string{
+	"base_address",
+	"state_name",
+	"enable_locking",
+	"skip_connectivity_check",
+}
+
+// credentialConfigs are the settings of the http backend passed to OpenTofu/Terraform as the `TF_HTTP_*` env vars at
+// runtime, rather than as backend config, so that they are neither written to the generated backend config nor to the
+// backend config OpenTofu/Terraform saves in the `.terraform` dir.
+var credentialConfigs = []string{
+	"username",
+	"password",
+}
+
+// Config is the `config` of a `remote_state` block using the http backend.
+type Config map[string]any
+
+// RemoteStateConfigHTTP is the subset of the settings of the http backend needed to read the state, and the settings
+// Terragrunt builds the addresses of the state with.
+type RemoteStateConfigHTTP struct {
+	Address               string `mapstructure:"address"`
+	LockAddress           string `mapstructure:"lock_address"`
+	UnlockAddress         string `mapstructure:"unlock_address"`
+	LockMethod            string `mapstructure:"lock_method"`
+	Username              string `mapstructure:"username"`
+	Password              string `mapstructure:"password"`
+	BaseAddress           string `mapstructure:"base_address"`
+	StateName             string `mapstructure:"state_name"`
+	SkipCertVerification  bool   `mapstructure:"skip_cert_verification"`
+	EnableLocking         bool   `mapstructure:"enable_locking"`
+	SkipConnectivityCheck bool   `mapstructure:"skip_connectivity_check"`
+}
+
+// FilterOutTerragruntKeys returns a new map without the keys only used by Terragrunt, nor the credentials.
+func (cfg Config) FilterOutTerragruntKeys() Config {
+	var filtered = make(Config)
+
+	for key, val := range cfg {
+		if slices.Contains(terragruntOnlyConfigs, key) || slices.Contains(credentialConfigs, key) {
+			continue
+		}
+
+		filtered[key] = val
+	}
+
+	return filtered
+}
+
+// ParseRemoteStateConfig parses the given map into an http config, with the addresses built from `base_address` and
+// `state_name`, and the unset settings read from the `TF_HTTP_*` env vars of the given env.
+func (cfg Config) ParseRemoteStateConfig(env map[string]string) (*RemoteStateConfigHTTP, error) {
+	var httpCfg RemoteStateConfigHTTP
+
+	if err := mapstructure.WeakDecode(cfg, &httpCfg); err != nil {
+		return nil, errors.New(err)
+	}
+
+	if err := httpCfg.buildAddresses(); err != nil {
+		return nil, err
+	}
+
+	if httpCfg.Address == "" {
+		httpCfg.Address = env[envAddress]
+	}
+
+	if httpCfg.Username == "" {
+		httpCfg.Username = env[envUsername]
+	}
+
+	if httpCfg.Password == "" {
+		httpCfg.Password = env[envPassword]
+	}
+
+	return &httpCfg, nil
+}
+
+// buildAddresses sets the unset `address` to the address of the state `state_name` under `base_address`, and, with
+// `enable_locking`, the unset `lock_address` and `unlock_address` to the lock path of the address.
+func (cfg *RemoteStateConfigHTTP) buildAddresses() error {
+	if cfg.BaseAddress != "" && cfg.Address == "" {
+		if cfg.StateName == "" {
+			return errors.New(MissingRequiredHTTPRemoteStateConfig("state_name"))
+		}
+
+		if _, err := url.Parse(cfg.BaseAddress); err != nil {
+			return errors.New(InvalidHTTPRemoteStateConfig{Setting: "base_address", Err: err})
+		}
+
+		segments := strings.Split(strings.Trim(cfg.StateName, "/"), "/")
+		for i, segment := range segments {
+			segments[i] = url.PathEscape(segment)
+		}
+
+		cfg.Address = strings.TrimSuffix(cfg.BaseAddress, "/") + "/" + strings.Join(segments, "/")
+	}
+
+	if !cfg.EnableLocking {
+		return nil
+	}
+
+	if cfg.Address == "" {
+		return errors.New(MissingRequiredHTTPRemoteStateConfig("address"))
+	}
+
+	lockAddress := strings.TrimSuffix(cfg.Address, "/") + "/" + lockPath
+
+	if cfg.LockAddress == "" {
+		cfg.LockAddress = lockAddress
+	}
+
+	if cfg.UnlockAddress == "" {
+		cfg.UnlockAddress = lockAddress
+	}
+
+	return nil
+}
+
+// tfInitArgs returns the given config without the keys only used by Terragrunt, nor the credentials, but with the
+// addresses Terragrunt built.
+func (cfg *RemoteStateConfigHTTP) tfInitArgs(config Config) Config {
+	args := config.FilterOutTerragruntKeys()
+
+	for key, val := range map[string]string{
+		"address":        cfg.Address,
+		"lock_address":   cfg.LockAddress,
+		"unlock_address": cfg.UnlockAddress,
+	} {
+		if val != "" {
+			args[key] = val
+		}
+	}
+
+	return args
+}
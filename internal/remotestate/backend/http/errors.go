@@ -0,0 +1,66 @@
+package http
+
+import "fmt"
+
+type MissingRequiredHTTPRemoteStateConfig string
+
+func (configName MissingRequiredHTTPRemoteStateConfig) Error() string {
+	return "Missing required HTTP remote state configuration " + string(configName)
+}
+
+// InvalidHTTPRemoteStateConfig is the error that is returned when a setting of the http backend is invalid.
+type InvalidHTTPRemoteStateConfig struct {
+	Err     error
+	Setting string
+}
+
+// Error implements `error` interface.
+func (err InvalidHTTPRemoteStateConfig) Error() string {
+	return fmt.Sprintf("invalid HTTP remote state configuration %s: %v", err.Setting, err.Err)
+}
+
+// Unwrap returns the wrapped error.
+func (err InvalidHTTPRemoteStateConfig) Unwrap() error {
+	return err.Err
+}
+
+// StateRequestError is the error that is returned when the server of the http backend doesn't return the state.
+type StateRequestError struct {
+	Address    string
+	Body       string
+	StatusCode int
+}
+
+// Error implements `error` interface.
+func (err StateRequestError) Error() string {
+	return fmt.Sprintf("failed to read state from %s: status code %d: %s", err.Address, err.StatusCode, err.Body)
+}
+
+// UnreachableBackendError is the error that is returned when the server of the http backend can't be reached.
+type UnreachableBackendError struct {
+	Err     error
+	Address string
+}
+
+// Error implements `error` interface.
+func (err UnreachableBackendError) Error() string {
+	return fmt.Sprintf("failed to connect to the http backend at %s: %v", err.Address, err.Err)
+}
+
+// Unwrap returns the wrapped error.
+func (err UnreachableBackendError) Unwrap() error {
+	return err.Err
+}
+
+// LockRequestError is the error that is returned when the server of the http backend doesn't lock the state, e.g. as
+// it is already locked.
+type LockRequestError struct {
+	Address    string
+	Body       string
+	StatusCode int
+}
+
+// Error implements `error` interface.
+func (err LockRequestError) Error() string {
+	return fmt.Sprintf("failed to lock state at %s: status code %d: %s", err.Address, err.StatusCode, err.Body)
+}
@@ -0,0 +1,62 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+// defaultLockMethod is the method of the lock requests of the http backend, unless `lock_method` is set.
+const defaultLockMethod = "LOCK"
+
+var _ backend.StateLocker = new(Backend)
+
+// LockState implements `backend.StateLocker` interface.
+//
+// The state is locked as the http backend locks it: the lock is sent to the `lock_address` of the config with the
+// `lock_method` of the config. The server returns a conflict if the state is already locked.
+func (backend *Backend) LockState(ctx context.Context, l log.Logger, backendConfig backend.Config, lock *backend.StateLock, opts *options.TerragruntOptions) error {
+	cfg, err := Config(backendConfig).ParseRemoteStateConfig(opts.Env)
+	if err != nil {
+		return err
+	}
+
+	if cfg.LockAddress == "" {
+		return errors.New(MissingRequiredHTTPRemoteStateConfig("lock_address"))
+	}
+
+	method := cfg.LockMethod
+	if method == "" {
+		method = defaultLockMethod
+	}
+
+	lock.Path = cfg.Address
+
+	info, err := json.Marshal(lock)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	l.Debugf("Locking state at %s", cfg.LockAddress)
+
+	resp, err := cfg.request(ctx, method, "lock_address", cfg.LockAddress, bytes.NewReader(info))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyLength))
+
+	return errors.New(LockRequestError{Address: cfg.LockAddress, StatusCode: resp.StatusCode, Body: string(body)})
+}
@@ -0,0 +1,211 @@
+// Package pg represents the Postgres backend for bootstrapping the schema of the remote state, and the role granted
+// access to it.
+package pg
+
+import (
+	"context"
+
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+const BackendName = "pg"
+
+var (
+	_ backend.Backend             = new(Backend)
+	_ backend.CredentialsInjector = new(Backend)
+	_ backend.ConnectivityChecker = new(Backend)
+)
+
+// Backend bootstraps the schema of the state stored in Postgres, and the role granted access to it, and checks the
+// connection to the database before `init`. Migrating and deleting the state aren't supported.
+type Backend struct {
+	*backend.CommonBackend
+}
+
+func NewBackend() *Backend {
+	return &Backend{
+		CommonBackend: backend.NewCommonBackend(BackendName),
+	}
+}
+
+// NeedsBootstrap returns true if the schema of the state can be bootstrapped, see `CanBootstrap`, and:
+//
+// 1. The schema of the state does not exist
+// 2. With `schema_role`, the role does not exist, or lacks the privileges to store the states
+func (backend *Backend) NeedsBootstrap(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) (bool, error) {
+	cfg, err := Config(backendConfig).ParseExtendedRemoteStateConfig(opts.Env)
+	if err != nil {
+		return false, err
+	}
+
+	if !cfg.CanBootstrap() {
+		return false, nil
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return false, err
+	}
+
+	client, err := NewBootstrapClient(ctx, l, cfg, opts)
+	if err != nil {
+		return false, err
+	}
+
+	defer func() {
+		if err := client.Close(ctx); err != nil {
+			l.Warnf("Error closing Postgres connection: %v", err)
+		}
+	}()
+
+	if exists, err := client.SchemaExists(ctx); err != nil || !exists {
+		return true, err
+	}
+
+	if cfg.SchemaRole == "" {
+		return false, nil
+	}
+
+	if exists, err := client.RoleExists(ctx); err != nil || !exists {
+		return true, err
+	}
+
+	granted, err := client.RoleHasPrivileges(ctx)
+
+	return !granted, err
+}
+
+// Bootstrap the schema of the state specified in the given config. This function will validate the config parameters,
+// create the schema if it doesn't already exist, and, with `schema_role`, create the role if it doesn't already exist,
+// create the table of the states and grant the role the privileges to store the states in it, and nothing else.
+func (backend *Backend) Bootstrap(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) error {
+	cfg, err := Config(backendConfig).ParseExtendedRemoteStateConfig(opts.Env)
+	if err != nil {
+		return err
+	}
+
+	if !cfg.CanBootstrap() {
+		return nil
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	// ensure that only one goroutine can initialize the schema
+	mu := backend.GetBucketMutex(cfg.CacheKey())
+	mu.Lock()
+	defer mu.Unlock()
+
+	if backend.IsConfigInited(cfg) {
+		l.Debugf("%s schema %s has already been confirmed to be initialized, skipping initialization checks", backend.Name(), cfg.SchemaName)
+
+		return nil
+	}
+
+	client, err := NewBootstrapClient(ctx, l, cfg, opts)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err := client.Close(ctx); err != nil {
+			l.Warnf("Error closing Postgres connection: %v", err)
+		}
+	}()
+
+	if err := client.CreateSchemaIfNecessary(ctx, l, opts); err != nil {
+		return err
+	}
+
+	if cfg.SchemaRole != "" {
+		if err := client.CreateRoleIfNecessary(ctx, l, opts); err != nil {
+			return err
+		}
+
+		if err := client.GrantRolePrivileges(ctx, l); err != nil {
+			return err
+		}
+	}
+
+	backend.MarkConfigInited(cfg)
+
+	return nil
+}
+
+// GetTFInitArgs returns the config that should be passed on to `tofu -backend-config` cmd line param, with the TLS
+// parameters of the config in the connection string.
+func (backend *Backend) GetTFInitArgs(config backend.Config) map[string]any {
+	cfg, err := Config(config).ParseExtendedRemoteStateConfig(nil)
+	if err != nil {
+		return Config(config).FilterOutTerragruntKeys()
+	}
+
+	return cfg.tfInitArgs(Config(config))
+}
+
+// InjectCredentials implements `backend.CredentialsInjector` interface.
+//
+// With `iam_auth`, an IAM authentication token of RDS for the user of `conn_str` is set as the `PGPASSWORD` env var,
+// read by the pg backend. The tokens expire after 15 minutes, so a new one is generated for each run.
+func (backend *Backend) InjectCredentials(_ context.Context, l log.Logger, config backend.Config, opts *options.TerragruntOptions) error {
+	cfg, err := Config(config).ParseExtendedRemoteStateConfig(opts.Env)
+	if err != nil {
+		return err
+	}
+
+	if !cfg.IAMAuth {
+		return nil
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	token, err := cfg.connStringIAMAuthToken(l, opts)
+	if err != nil {
+		return err
+	}
+
+	if opts.Env == nil {
+		opts.Env = make(map[string]string)
+	}
+
+	l.Debugf("Setting %s for the pg backend", envPassword)
+
+	opts.Env[envPassword] = token
+
+	return nil
+}
+
+// CheckConnectivity implements `backend.ConnectivityChecker` interface.
+//
+// Terragrunt connects to the database with `conn_str`, so that unreachable servers, TLS misconfigurations and invalid
+// credentials fail before `init`. The check is skipped with `skip_connectivity_check`.
+func (backend *Backend) CheckConnectivity(ctx context.Context, l log.Logger, config backend.Config, opts *options.TerragruntOptions) error {
+	cfg, err := Config(config).ParseExtendedRemoteStateConfig(opts.Env)
+	if err != nil {
+		return err
+	}
+
+	if cfg.SkipConnectivityCheck {
+		l.Debugf("Skipping the connectivity check of the pg backend")
+		return nil
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	conn, err := cfg.connect(ctx, l, cfg.connString(), opts)
+	if err != nil {
+		return err
+	}
+
+	if err := conn.Close(ctx); err != nil {
+		l.Warnf("Error closing Postgres connection: %v", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package pg_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend"
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend/pg"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+)
+
+func TestBackendGetTFInitArgs(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		config   backend.Config
+		expected map[string]any
+	}{
+		{
+			name:     "terragrunt keys",
+			config:   backend.Config{"conn_str": "postgres://db.example.com/state", "bootstrap_conn_str": "postgres://admin@db.example.com/state", "iam_auth": true, "region": "us-east-1"},
+			expected: map[string]any{"conn_str": "postgres://db.example.com/state"},
+		},
+		{
+			name:     "tls url",
+			config:   backend.Config{"conn_str": "postgres://db.example.com/state?sslmode=disable", "sslmode": "verify-full", "sslrootcert": "/etc/ssl/rds.pem"},
+			expected: map[string]any{"conn_str": "postgres://db.example.com/state?sslmode=verify-full&sslrootcert=%2Fetc%2Fssl%2Frds.pem"},
+		},
+		{
+			name:     "tls keywords",
+			config:   backend.Config{"conn_str": "host=db.example.com dbname=state", "sslmode": "verify-full", "sslrootcert": "/etc/ssl/it's.pem"},
+			expected: map[string]any{"conn_str": `host=db.example.com dbname=state sslmode='verify-full' sslrootcert='/etc/ssl/it\'s.pem'`},
+		},
+		{
+			name:   "schema role",
+			config: backend.Config{"conn_str": "postgres://tofu@db.example.com/state", "schema_role": "tofu", "skip_index_creation": false},
+			expected: map[string]any{
+				"conn_str":             "postgres://tofu@db.example.com/state",
+				"skip_schema_creation": true,
+				"skip_table_creation":  true,
+				"skip_index_creation":  false,
+			},
+		},
+		{
+			name:     "schema role without bootstrap",
+			config:   backend.Config{"conn_str": "postgres://tofu@db.example.com/state", "schema_role": "tofu", "skip_schema_bootstrap": true},
+			expected: map[string]any{"conn_str": "postgres://tofu@db.example.com/state"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, pg.NewBackend().GetTFInitArgs(tc.config))
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name        string
+		config      pg.Config
+		env         map[string]string
+		expectedErr error
+	}{
+		{
+			name:   "conn str",
+			config: pg.Config{"conn_str": "postgres://db.example.com/state"},
+		},
+		{
+			name:   "env conn str",
+			config: pg.Config{},
+			env:    map[string]string{"PG_CONN_STR": "postgres://db.example.com/state"},
+		},
+		{
+			name:        "missing conn str",
+			config:      pg.Config{},
+			expectedErr: pg.MissingRequiredPGRemoteStateConfig("conn_str"),
+		},
+		{
+			name:        "invalid sslmode",
+			config:      pg.Config{"conn_str": "postgres://db.example.com/state", "sslmode": "sometimes"},
+			expectedErr: pg.InvalidPGRemoteStateConfig{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg, err := tc.config.ParseExtendedRemoteStateConfig(tc.env)
+			require.NoError(t, err)
+			assert.Equal(t, pg.DefaultSchemaName, cfg.SchemaName)
+
+			err = cfg.Validate()
+
+			switch expectedErr := tc.expectedErr.(type) {
+			case nil:
+				require.NoError(t, err)
+			case pg.InvalidPGRemoteStateConfig:
+				require.ErrorAs(t, err, &expectedErr)
+			default:
+				require.ErrorIs(t, err, expectedErr)
+			}
+		})
+	}
+}
+
+func TestBackendCheckConnectivity(t *testing.T) {
+	t.Parallel()
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	config := backend.Config{"conn_str": "postgres://tofu@127.0.0.1:1/state?connect_timeout=1", "sslmode": "disable"}
+
+	err = pg.NewBackend().CheckConnectivity(t.Context(), logger.CreateLogger(), config, opts)
+
+	var connErr pg.ConnectionError
+	require.ErrorAs(t, err, &connErr)
+	assert.Equal(t, "127.0.0.1", connErr.Host)
+
+	config["skip_connectivity_check"] = true
+
+	require.NoError(t, pg.NewBackend().CheckConnectivity(t.Context(), logger.CreateLogger(), config, opts))
+}
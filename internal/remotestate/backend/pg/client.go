@@ -0,0 +1,285 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/rds/rdsutils"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/gruntwork-io/terragrunt/awshelper"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/shell"
+)
+
+const (
+	// statesTableName, statesIndexName and statesSequenceName are the table the pg backend stores the states in, its
+	// index by name, and the sequence numbering the states of all the schemas.
+	statesTableName    = "states"
+	statesIndexName    = "states_by_name"
+	statesSequenceName = "public.global_states_id_seq"
+
+	// rdsIAMRole is the role granting the IAM authentication of RDS to the roles it is granted to.
+	rdsIAMRole = "rds_iam"
+
+	// rdsHostSuffix is the suffix of the hosts of RDS, e.g. `mydb.123456789012.us-east-1.rds.amazonaws.com`.
+	rdsHostSuffix = ".rds.amazonaws.com"
+)
+
+// Client bootstraps the schema of the state, connected to its database.
+type Client struct {
+	*ExtendedRemoteStateConfigPG
+	conn *pgx.Conn
+}
+
+// NewBootstrapClient returns a client connected with `bootstrap_conn_str`.
+func NewBootstrapClient(ctx context.Context, l log.Logger, cfg *ExtendedRemoteStateConfigPG, opts *options.TerragruntOptions) (*Client, error) {
+	conn, err := cfg.connect(ctx, l, cfg.bootstrapConnString(), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{ExtendedRemoteStateConfigPG: cfg, conn: conn}, nil
+}
+
+// Close closes the connection of the client.
+func (client *Client) Close(ctx context.Context) error {
+	return client.conn.Close(ctx)
+}
+
+// SchemaExists returns true if the schema of the state exists.
+func (client *Client) SchemaExists(ctx context.Context) (bool, error) {
+	var exists bool
+
+	if err := client.conn.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM pg_namespace WHERE nspname = $1)", client.SchemaName).Scan(&exists); err != nil {
+		return false, errors.New(err)
+	}
+
+	return exists, nil
+}
+
+// RoleExists returns true if `schema_role` exists.
+func (client *Client) RoleExists(ctx context.Context) (bool, error) {
+	var exists bool
+
+	if err := client.conn.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM pg_roles WHERE rolname = $1)", client.SchemaRole).Scan(&exists); err != nil {
+		return false, errors.New(err)
+	}
+
+	return exists, nil
+}
+
+// RoleHasPrivileges returns true if the table of the states exists, and `schema_role` has the privileges to store
+// states in it.
+func (client *Client) RoleHasPrivileges(ctx context.Context) (bool, error) {
+	const query = `SELECT CASE
+		WHEN to_regclass($2::text) IS NULL OR to_regclass($3::text) IS NULL THEN false
+		ELSE has_schema_privilege($1::name, $4::text, 'USAGE')
+			AND has_table_privilege($1::name, $2::text, 'SELECT') AND has_table_privilege($1::name, $2::text, 'INSERT')
+			AND has_table_privilege($1::name, $2::text, 'UPDATE') AND has_table_privilege($1::name, $2::text, 'DELETE')
+			AND has_sequence_privilege($1::name, $3::text, 'USAGE')
+		END`
+
+	var granted bool
+
+	if err := client.conn.QueryRow(ctx, query, client.SchemaRole, client.statesTable(), statesSequenceName, client.SchemaName).Scan(&granted); err != nil {
+		return false, errors.New(err)
+	}
+
+	return granted, nil
+}
+
+// CreateSchemaIfNecessary prompts the user to create the schema of the state if it doesn't exist.
+func (client *Client) CreateSchemaIfNecessary(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) error {
+	if exists, err := client.SchemaExists(ctx); err != nil || exists {
+		return err
+	}
+
+	if opts.FailIfBucketCreationRequired {
+		return errors.New(SchemaDoesNotExistError(client.SchemaName))
+	}
+
+	prompt := fmt.Sprintf("Remote state Postgres schema %s does not exist or you don't have permissions to access it. Would you like Terragrunt to create it?", client.SchemaName)
+
+	shouldCreateSchema, err := shell.PromptUserForYesNo(ctx, l, prompt, opts)
+	if err != nil {
+		return err
+	}
+
+	if !shouldCreateSchema {
+		return nil
+	}
+
+	l.Debugf("Creating Postgres schema %s", client.SchemaName)
+
+	return client.exec(ctx, "CREATE SCHEMA IF NOT EXISTS "+pgx.Identifier{client.SchemaName}.Sanitize())
+}
+
+// CreateRoleIfNecessary prompts the user to create `schema_role` if it doesn't exist. The role can log in, without a
+// password: it authenticates with the IAM authentication of RDS if `iam_auth` is set, else with the password or the
+// certificate set by the administrators of the database.
+func (client *Client) CreateRoleIfNecessary(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) error {
+	if exists, err := client.RoleExists(ctx); err != nil || exists {
+		return err
+	}
+
+	prompt := fmt.Sprintf("Postgres role %s of the remote state does not exist. Would you like Terragrunt to create it?", client.SchemaRole)
+
+	shouldCreateRole, err := shell.PromptUserForYesNo(ctx, l, prompt, opts)
+	if err != nil || !shouldCreateRole {
+		return err
+	}
+
+	l.Debugf("Creating Postgres role %s", client.SchemaRole)
+
+	role := pgx.Identifier{client.SchemaRole}.Sanitize()
+
+	if err := client.exec(ctx, "CREATE ROLE "+role+" LOGIN"); err != nil {
+		return err
+	}
+
+	if client.IAMAuth {
+		return client.exec(ctx, "GRANT "+rdsIAMRole+" TO "+role)
+	}
+
+	return nil
+}
+
+// GrantRolePrivileges creates the table of the states, as `schema_role` has no privilege to create it, and grants the
+// role the privileges to store states in it, and nothing else: it can't create nor alter the objects of the schema.
+func (client *Client) GrantRolePrivileges(ctx context.Context, l log.Logger) error {
+	var (
+		schema = pgx.Identifier{client.SchemaName}.Sanitize()
+		table  = client.statesTable()
+		role   = pgx.Identifier{client.SchemaRole}.Sanitize()
+	)
+
+	l.Debugf("Granting Postgres role %s the privileges on the table %s", client.SchemaRole, table)
+
+	// The same table, index and sequence as those the pg backend creates.
+	statements := []string{
+		"CREATE SEQUENCE IF NOT EXISTS " + statesSequenceName + " AS bigint",
+		"CREATE TABLE IF NOT EXISTS " + table + " (id bigint NOT NULL DEFAULT nextval('" + statesSequenceName + "') PRIMARY KEY, name text UNIQUE, data text)",
+		"CREATE UNIQUE INDEX IF NOT EXISTS " + statesIndexName + " ON " + table + " (name)",
+		"GRANT USAGE ON SCHEMA " + schema + " TO " + role,
+		"GRANT SELECT, INSERT, UPDATE, DELETE ON " + table + " TO " + role,
+		"GRANT USAGE, SELECT ON SEQUENCE " + statesSequenceName + " TO " + role,
+	}
+
+	for _, statement := range statements {
+		if err := client.exec(ctx, statement); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Ping checks the connection of the client.
+func (client *Client) Ping(ctx context.Context) error {
+	if err := client.conn.Ping(ctx); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+func (client *Client) exec(ctx context.Context, statement string) error {
+	if _, err := client.conn.Exec(ctx, statement); err != nil {
+		return errors.Errorf("failed to run %q: %w", statement, err)
+	}
+
+	return nil
+}
+
+// statesTable returns the quoted name of the table of the states.
+func (client *Client) statesTable() string {
+	return pgx.Identifier{client.SchemaName, statesTableName}.Sanitize()
+}
+
+// connect connects to the database with the given connection string, authenticated with an IAM authentication token
+// of RDS if `iam_auth` is set.
+func (cfg *ExtendedRemoteStateConfigPG) connect(ctx context.Context, l log.Logger, connStr string, opts *options.TerragruntOptions) (*pgx.Conn, error) {
+	connCfg, err := pgx.ParseConfig(connStr)
+	if err != nil {
+		return nil, errors.New(InvalidPGRemoteStateConfig{name: "conn_str", reason: err.Error()})
+	}
+
+	if cfg.IAMAuth {
+		token, err := cfg.iamAuthToken(l, connCfg.Host, connCfg.Port, connCfg.User, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		connCfg.Password = token
+	}
+
+	l.Debugf("Connecting to Postgres at %s as %s", connCfg.Host, connCfg.User)
+
+	conn, err := pgx.ConnectConfig(ctx, connCfg)
+	if err != nil {
+		return nil, errors.New(ConnectionError{Host: connCfg.Host, User: connCfg.User, Err: err})
+	}
+
+	return conn, nil
+}
+
+// connStringIAMAuthToken returns an IAM authentication token of RDS for the user of `conn_str`.
+func (cfg *ExtendedRemoteStateConfigPG) connStringIAMAuthToken(l log.Logger, opts *options.TerragruntOptions) (string, error) {
+	connCfg, err := pgx.ParseConfig(cfg.connString())
+	if err != nil {
+		return "", errors.New(InvalidPGRemoteStateConfig{name: "conn_str", reason: err.Error()})
+	}
+
+	return cfg.iamAuthToken(l, connCfg.Host, connCfg.Port, connCfg.User, opts)
+}
+
+// iamAuthToken returns an IAM authentication token of RDS for the given user of the database at the given host. The
+// token is valid for 15 minutes.
+func (cfg *ExtendedRemoteStateConfigPG) iamAuthToken(l log.Logger, host string, port uint16, user string, opts *options.TerragruntOptions) (string, error) {
+	region := cfg.iamAuthRegion(host, opts.Env)
+	if region == "" {
+		return "", errors.New(MissingRequiredPGRemoteStateConfig("region"))
+	}
+
+	sess, err := awshelper.CreateAwsSession(l, &awshelper.AwsSessionConfig{
+		Region:  region,
+		Profile: cfg.Profile,
+		RoleArn: cfg.RoleArn,
+	}, opts)
+	if err != nil {
+		return "", err
+	}
+
+	endpoint := net.JoinHostPort(host, strconv.Itoa(int(port)))
+
+	token, err := rdsutils.BuildAuthToken(endpoint, region, user, sess.Config.Credentials)
+	if err != nil {
+		return "", errors.Errorf("failed to generate the RDS IAM authentication token of %s: %w", user, err)
+	}
+
+	return token, nil
+}
+
+// iamAuthRegion returns the region the IAM authentication tokens of RDS are generated for: `region`, else the region of
+// the RDS host, else the region of the `AWS_REGION` or `AWS_DEFAULT_REGION` env vars.
+func (cfg *ExtendedRemoteStateConfigPG) iamAuthRegion(host string, env map[string]string) string {
+	if cfg.Region != "" {
+		return cfg.Region
+	}
+
+	if labels := strings.Split(strings.TrimSuffix(host, rdsHostSuffix), "."); strings.HasSuffix(host, rdsHostSuffix) && len(labels) > 1 {
+		return labels[len(labels)-1]
+	}
+
+	if region := env["AWS_REGION"]; region != "" {
+		return region
+	}
+
+	return env["AWS_DEFAULT_REGION"]
+}
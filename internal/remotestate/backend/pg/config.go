@@ -0,0 +1,246 @@
+package pg
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/mitchellh/mapstructure"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+const (
+	// DefaultSchemaName is the schema the pg backend stores the states in, unless `schema_name` is set.
+	DefaultSchemaName = "terraform_remote_state"
+
+	envConnStr    = "PG_CONN_STR"
+	envSchemaName = "PG_SCHEMA_NAME"
+	envPassword   = "PGPASSWORD"
+)
+
+// terragruntOnlyConfigs are the keys of the config only used by Terragrunt to bootstrap the schema and to connect to the
+// database, which aren't passed to the pg backend.
+var terragruntOnlyConfigs = []string{
+	"bootstrap_conn_str",
+	"schema_role",
+	"skip_schema_bootstrap",
+	"skip_connectivity_check",
+	"sslmode",
+	"sslrootcert",
+	"sslcert",
+	"sslkey",
+	"iam_auth",
+	"region",
+	"profile",
+	"role_arn",
+}
+
+// tlsConfigs are the keys of the config set as the TLS parameters of the connection strings.
+var tlsConfigs = []string{
+	"sslmode",
+	"sslrootcert",
+	"sslcert",
+	"sslkey",
+}
+
+// Config is the `config` of a `remote_state` block using the pg backend.
+type Config map[string]any
+
+// RemoteStateConfigPG is the subset of the settings of the pg backend needed to bootstrap the schema of the state.
+type RemoteStateConfigPG struct {
+	ConnStr            string `mapstructure:"conn_str"`
+	SchemaName         string `mapstructure:"schema_name"`
+	SkipSchemaCreation bool   `mapstructure:"skip_schema_creation"`
+}
+
+// ExtendedRemoteStateConfigPG is the config of the pg backend with the settings only used by Terragrunt to bootstrap
+// the schema of the state and the role granted access to it, and to connect to the database, e.g. over TLS or with the
+// IAM authentication of RDS.
+type ExtendedRemoteStateConfigPG struct {
+	// BootstrapConnStr is the connection string of the role the schema and the role of the state are bootstrapped with,
+	// e.g. an admin. Defaults to `conn_str`.
+	BootstrapConnStr string `mapstructure:"bootstrap_conn_str"`
+	// SchemaRole is the role OpenTofu/Terraform connects as, granted the privileges to store the state in the schema.
+	SchemaRole string `mapstructure:"schema_role"`
+
+	SSLMode     string `mapstructure:"sslmode"`
+	SSLRootCert string `mapstructure:"sslrootcert"`
+	SSLCert     string `mapstructure:"sslcert"`
+	SSLKey      string `mapstructure:"sslkey"`
+
+	// Region, Profile and RoleArn are the AWS settings the IAM authentication tokens of RDS are generated with.
+	Region  string `mapstructure:"region"`
+	Profile string `mapstructure:"profile"`
+	RoleArn string `mapstructure:"role_arn"`
+
+	RemoteStateConfigPG `mapstructure:",squash"`
+
+	SkipSchemaBootstrap   bool `mapstructure:"skip_schema_bootstrap"`
+	SkipConnectivityCheck bool `mapstructure:"skip_connectivity_check"`
+	IAMAuth               bool `mapstructure:"iam_auth"`
+}
+
+// FilterOutTerragruntKeys returns the config without the keys only used by Terragrunt.
+func (cfg Config) FilterOutTerragruntKeys() Config {
+	var filtered = make(Config)
+
+	for key, val := range cfg {
+		if slices.Contains(terragruntOnlyConfigs, key) {
+			continue
+		}
+
+		filtered[key] = val
+	}
+
+	return filtered
+}
+
+// ParseExtendedRemoteStateConfig parses the given map into a pg config, with the settings only used by Terragrunt, and
+// the unset `conn_str` and `schema_name` read from the `PG_CONN_STR` and `PG_SCHEMA_NAME` env vars of the given env, as
+// the pg backend does.
+func (cfg Config) ParseExtendedRemoteStateConfig(env map[string]string) (*ExtendedRemoteStateConfigPG, error) {
+	var pgCfg ExtendedRemoteStateConfigPG
+
+	if err := mapstructure.WeakDecode(cfg, &pgCfg); err != nil {
+		return nil, errors.New(err)
+	}
+
+	if pgCfg.ConnStr == "" {
+		pgCfg.ConnStr = env[envConnStr]
+	}
+
+	if pgCfg.SchemaName == "" {
+		pgCfg.SchemaName = env[envSchemaName]
+	}
+
+	if pgCfg.SchemaName == "" {
+		pgCfg.SchemaName = DefaultSchemaName
+	}
+
+	if pgCfg.BootstrapConnStr == "" {
+		pgCfg.BootstrapConnStr = pgCfg.ConnStr
+	}
+
+	return &pgCfg, nil
+}
+
+// Validate checks that the connection string of the state is set, and that the connection strings can be parsed with
+// the TLS parameters of the config.
+func (cfg *ExtendedRemoteStateConfigPG) Validate() error {
+	if cfg.ConnStr == "" {
+		return errors.New(MissingRequiredPGRemoteStateConfig("conn_str"))
+	}
+
+	for name, connStr := range map[string]string{
+		"conn_str":           cfg.connString(),
+		"bootstrap_conn_str": cfg.bootstrapConnString(),
+	} {
+		if _, err := pgx.ParseConfig(connStr); err != nil {
+			return errors.New(InvalidPGRemoteStateConfig{name: name, reason: err.Error()})
+		}
+	}
+
+	return nil
+}
+
+// CanBootstrap returns true if Terragrunt bootstraps the schema of the state.
+func (cfg *ExtendedRemoteStateConfigPG) CanBootstrap() bool {
+	return !cfg.SkipSchemaBootstrap
+}
+
+// CacheKey returns a unique key for the given pg config that can be used to cache the initialization.
+func (cfg *ExtendedRemoteStateConfigPG) CacheKey() string {
+	return cfg.ConnStr + "/" + cfg.SchemaName + "/" + cfg.SchemaRole
+}
+
+// tfInitArgs returns the given config without the keys only used by Terragrunt, with the TLS parameters of the config
+// in the connection string. With `schema_role`, the role can't create the schema nor the table of the states,
+// bootstrapped by Terragrunt, so the pg backend skips their creation, unless configured otherwise.
+func (cfg *ExtendedRemoteStateConfigPG) tfInitArgs(config Config) Config {
+	args := config.FilterOutTerragruntKeys()
+
+	if _, ok := args["conn_str"]; ok {
+		args["conn_str"] = cfg.connString()
+	}
+
+	if cfg.SchemaRole == "" || !cfg.CanBootstrap() {
+		return args
+	}
+
+	for _, key := range []string{"skip_schema_creation", "skip_table_creation", "skip_index_creation"} {
+		if _, ok := args[key]; !ok {
+			args[key] = true
+		}
+	}
+
+	return args
+}
+
+// connString returns the connection string of the state with the TLS parameters of the config.
+func (cfg *ExtendedRemoteStateConfigPG) connString() string {
+	return withConnParams(cfg.ConnStr, cfg.tlsParams())
+}
+
+// bootstrapConnString returns the connection string the schema is bootstrapped with, with the TLS parameters of the
+// config.
+func (cfg *ExtendedRemoteStateConfigPG) bootstrapConnString() string {
+	return withConnParams(cfg.BootstrapConnStr, cfg.tlsParams())
+}
+
+// tlsParams returns the TLS parameters of the config that are set.
+func (cfg *ExtendedRemoteStateConfigPG) tlsParams() map[string]string {
+	params := make(map[string]string)
+
+	for i, val := range []string{cfg.SSLMode, cfg.SSLRootCert, cfg.SSLCert, cfg.SSLKey} {
+		if val != "" {
+			params[tlsConfigs[i]] = val
+		}
+	}
+
+	return params
+}
+
+// withConnParams returns the given connection string, either a URL or a list of `keyword=value` settings, with the
+// given parameters, overriding those of the connection string.
+func withConnParams(connStr string, params map[string]string) string {
+	if len(params) == 0 {
+		return connStr
+	}
+
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	if strings.HasPrefix(connStr, "postgres://") || strings.HasPrefix(connStr, "postgresql://") {
+		if connURL, err := url.Parse(connStr); err == nil {
+			query := connURL.Query()
+
+			for _, key := range keys {
+				query.Set(key, params[key])
+			}
+
+			connURL.RawQuery = query.Encode()
+
+			return connURL.String()
+		}
+	}
+
+	settings := make([]string, 0, len(keys)+1)
+
+	if connStr = strings.TrimSpace(connStr); connStr != "" {
+		settings = append(settings, connStr)
+	}
+
+	// The last settings of a keyword override the previous ones.
+	for _, key := range keys {
+		val := strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(params[key])
+		settings = append(settings, key+"='"+val+"'")
+	}
+
+	return strings.Join(settings, " ")
+}
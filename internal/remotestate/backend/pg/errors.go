@@ -0,0 +1,44 @@
+package pg
+
+import "fmt"
+
+type MissingRequiredPGRemoteStateConfig string
+
+func (configName MissingRequiredPGRemoteStateConfig) Error() string {
+	return "Missing required Postgres remote state configuration " + string(configName)
+}
+
+type InvalidPGRemoteStateConfig struct {
+	name   string
+	reason string
+}
+
+func (err InvalidPGRemoteStateConfig) Error() string {
+	return fmt.Sprintf("Invalid Postgres remote state configuration %s: %s", err.name, err.reason)
+}
+
+// ConnectionError is the error that is returned when Terragrunt fails to connect to the database of the state.
+type ConnectionError struct {
+	Err  error
+	Host string
+	User string
+}
+
+// Error implements `error` interface.
+func (err ConnectionError) Error() string {
+	return fmt.Sprintf("failed to connect to Postgres at %s as %s: %v", err.Host, err.User, err.Err)
+}
+
+// Unwrap returns the wrapped error.
+func (err ConnectionError) Unwrap() error {
+	return err.Err
+}
+
+// SchemaDoesNotExistError is the error that is returned when the schema of the state doesn't exist, and Terragrunt
+// wasn't allowed to create it.
+type SchemaDoesNotExistError string
+
+// Error implements `error` interface.
+func (schemaName SchemaDoesNotExistError) Error() string {
+	return fmt.Sprintf("Postgres schema %s of the remote state does not exist", string(schemaName))
+}
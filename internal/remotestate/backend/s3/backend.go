@@ -14,7 +14,10 @@ import (
 
 const BackendName = "s3"
 
-var _ backend.Backend = new(Backend)
+var (
+	_ backend.Backend     = new(Backend)
+	_ backend.StateReader = new(Backend)
+)
 
 type Backend struct {
 	*backend.CommonBackend
@@ -271,3 +274,29 @@ func (backend *Backend) DeleteBucket(ctx context.Context, l log.Logger, backendC
 func (backend *Backend) GetTFInitArgs(config backend.Config) map[string]any {
 	return Config(config).GetTFInitArgs()
 }
+
+// ReadState implements `backend.StateReader` interface.
+//
+// The credentials are not validated, unlike when bootstrapping the backend, to read the state with as few requests as
+// possible. The AWS profile of the environment is used if the config sets none.
+func (backend *Backend) ReadState(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) ([]byte, error) {
+	extS3Cfg, err := Config(backendConfig).ParseExtendedS3Config()
+	if err != nil {
+		return nil, err
+	}
+
+	extS3Cfg.SkipCredentialsValidation = true
+
+	if extS3Cfg.RemoteStateConfigS3.Profile == "" {
+		extS3Cfg.RemoteStateConfigS3.Profile = opts.Env["AWS_PROFILE"]
+	}
+
+	client, err := NewClient(l, extS3Cfg, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	l.Debugf("Reading state from s3://%s/%s", extS3Cfg.RemoteStateConfigS3.Bucket, extS3Cfg.RemoteStateConfigS3.Key)
+
+	return client.GetS3Object(ctx, l, extS3Cfg.RemoteStateConfigS3.Bucket, extS3Cfg.RemoteStateConfigS3.Key)
+}
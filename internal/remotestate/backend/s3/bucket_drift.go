@@ -0,0 +1,148 @@
+package s3
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+// errCodeNoSuchTagSet is the code of the error returned by S3 for the buckets without tags.
+const errCodeNoSuchTagSet = "NoSuchTagSet"
+
+var _ backend.BucketDriftChecker = new(Backend)
+
+// BucketDrift implements `backend.BucketDriftChecker` interface.
+//
+// The versioning, encryption, root access and enforced TLS policies, access logging, public access block and tags of
+// the bucket are compared with the config, as they are when bootstrapping the bucket. It returns nil if the bucket
+// doesn't exist yet.
+func (*Backend) BucketDrift(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) (*backend.BucketDrift, error) {
+	extS3Cfg, err := Config(backendConfig).ExtendedS3Config(l)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := NewClient(l, extS3Cfg, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	bucketName := extS3Cfg.RemoteStateConfigS3.Bucket
+
+	if exists, err := client.DoesS3BucketExist(ctx, bucketName); err != nil || !exists {
+		return nil, err
+	}
+
+	l.Debugf("Checking the drift of the remote state S3 bucket %s", bucketName)
+
+	_, updates, err := client.S3BucketDrift(ctx, l, bucketName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &backend.BucketDrift{Bucket: bucketName, Settings: updates}, nil
+}
+
+// RemediateBucketDrift implements `backend.BucketDriftChecker` interface.
+//
+// The settings of the bucket that drifted from the config are updated, without prompting, unless the bucket updates
+// are disabled with `disable_bucket_update`. The tags of the bucket that aren't in the config are kept.
+func (backend *Backend) RemediateBucketDrift(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) error {
+	extS3Cfg, err := Config(backendConfig).ExtendedS3Config(l)
+	if err != nil {
+		return err
+	}
+
+	bucketName := extS3Cfg.RemoteStateConfigS3.Bucket
+
+	if extS3Cfg.DisableBucketUpdate {
+		l.Warnf("Updates of the remote state S3 bucket %s are disabled using 'disable_bucket_update' config, leaving its drift as it is.", bucketName)
+		return nil
+	}
+
+	// ensure that the units sharing the bucket don't update it concurrently
+	mu := backend.GetBucketMutex(bucketName)
+	mu.Lock()
+	defer mu.Unlock()
+
+	client, err := NewClient(l, extS3Cfg, opts)
+	if err != nil {
+		return err
+	}
+
+	// The bucket may have been remediated by another unit meanwhile.
+	toUpdate, updates, err := client.S3BucketDrift(ctx, l, bucketName)
+	if err != nil || len(updates) == 0 {
+		return err
+	}
+
+	return client.updateS3Bucket(ctx, l, bucketName, toUpdate, opts)
+}
+
+// checkIfS3BucketTagged returns true if the given bucket has all the tags of the config, with their values.
+func (client *Client) checkIfS3BucketTagged(ctx context.Context, bucketName string) (bool, error) {
+	tags, err := client.getS3BucketTags(ctx, bucketName)
+	if err != nil {
+		return false, err
+	}
+
+	for key, val := range client.S3BucketTags {
+		if current, ok := tags[key]; !ok || current != val {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// mergeS3BucketTags sets the tags of the config on the given bucket, keeping its other tags.
+func (client *Client) mergeS3BucketTags(ctx context.Context, l log.Logger, bucketName string) error {
+	tags, err := client.getS3BucketTags(ctx, bucketName)
+	if err != nil {
+		return err
+	}
+
+	for key, val := range client.S3BucketTags {
+		tags[key] = val
+	}
+
+	l.Debugf("Tagging S3 bucket %s with %s", bucketName, client.S3BucketTags)
+
+	input := &s3.PutBucketTaggingInput{
+		Bucket:  aws.String(bucketName),
+		Tagging: &s3.Tagging{TagSet: convertTags(tags)},
+	}
+
+	if _, err := client.PutBucketTaggingWithContext(ctx, input); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// getS3BucketTags returns the tags of the given bucket.
+func (client *Client) getS3BucketTags(ctx context.Context, bucketName string) (map[string]string, error) {
+	tags := make(map[string]string)
+
+	res, err := client.GetBucketTaggingWithContext(ctx, &s3.GetBucketTaggingInput{Bucket: aws.String(bucketName)})
+	if err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == errCodeNoSuchTagSet {
+			return tags, nil
+		}
+
+		return nil, errors.New(err)
+	}
+
+	for _, tag := range res.TagSet {
+		tags[aws.StringValue(tag.Key)] = aws.StringValue(tag.Value)
+	}
+
+	return tags, nil
+}
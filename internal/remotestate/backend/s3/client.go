@@ -3,6 +3,7 @@ package s3
 import (
 	"context"
 	"fmt"
+	"io"
 	"path"
 	"reflect"
 	"slices"
@@ -179,6 +180,17 @@ func (client *Client) UpdateS3BucketIfNecessary(ctx context.Context, l log.Logge
 		return nil
 	}
 
+	return client.updateS3Bucket(ctx, l, bucketName, bucketUpdatesRequired, opts)
+}
+
+// updateS3Bucket applies the given updates to the given bucket, except those the config skips.
+func (client *Client) updateS3Bucket(ctx context.Context, l log.Logger, bucketName string, bucketUpdatesRequired S3BucketUpdatesRequired, opts *options.TerragruntOptions) error {
+	if bucketUpdatesRequired.Tags {
+		if err := client.mergeS3BucketTags(ctx, l, bucketName); err != nil {
+			return err
+		}
+	}
+
 	if bucketUpdatesRequired.Versioning {
 		if client.SkipBucketVersioning {
 			l.Debugf("Versioning is disabled for the remote state S3 bucket %s using 'skip_bucket_versioning' config.", bucketName)
@@ -309,9 +321,32 @@ type S3BucketUpdatesRequired struct {
 	EnforcedTLS   bool
 	AccessLogging bool
 	PublicAccess  bool
+	Tags          bool
 }
 
 func (client *Client) checkIfS3BucketNeedsUpdate(ctx context.Context, l log.Logger, bucketName string) (bool, S3BucketUpdatesRequired, error) {
+	toUpdate, updates, err := client.S3BucketDrift(ctx, l, bucketName)
+	if err != nil {
+		return false, toUpdate, err
+	}
+
+	// show update message if any of the above configs are not set
+	if len(updates) > 0 {
+		l.Warnf("The remote state S3 bucket %s needs to be updated:", bucketName)
+
+		for _, update := range updates {
+			l.Warnf("  - %s", update)
+		}
+
+		return true, toUpdate, nil
+	}
+
+	return false, toUpdate, nil
+}
+
+// S3BucketDrift returns the updates of the given bucket required to match the config, and their descriptions, empty if
+// the bucket is up to date. The settings the config skips aren't checked.
+func (client *Client) S3BucketDrift(ctx context.Context, l log.Logger, bucketName string) (S3BucketUpdatesRequired, []string, error) {
 	var (
 		updates  []string
 		toUpdate S3BucketUpdatesRequired
@@ -320,7 +355,7 @@ func (client *Client) checkIfS3BucketNeedsUpdate(ctx context.Context, l log.Logg
 	if !client.SkipBucketVersioning {
 		enabled, err := client.CheckIfVersioningEnabled(ctx, l, bucketName)
 		if err != nil {
-			return false, toUpdate, err
+			return toUpdate, nil, err
 		}
 
 		if !enabled {
@@ -333,7 +368,7 @@ func (client *Client) checkIfS3BucketNeedsUpdate(ctx context.Context, l log.Logg
 	if !client.SkipBucketSSEncryption {
 		matches, err := client.checkIfSSEForS3MatchesConfig(l, bucketName)
 		if err != nil {
-			return false, toUpdate, err
+			return toUpdate, nil, err
 		}
 
 		if !matches {
@@ -346,7 +381,7 @@ func (client *Client) checkIfS3BucketNeedsUpdate(ctx context.Context, l log.Logg
 	if !client.SkipBucketRootAccess {
 		enabled, err := client.checkIfBucketRootAccess(l, bucketName)
 		if err != nil {
-			return false, toUpdate, err
+			return toUpdate, nil, err
 		}
 
 		if !enabled {
@@ -359,7 +394,7 @@ func (client *Client) checkIfS3BucketNeedsUpdate(ctx context.Context, l log.Logg
 	if !client.SkipBucketEnforcedTLS {
 		enabled, err := client.checkIfBucketEnforcedTLS(l, bucketName)
 		if err != nil {
-			return false, toUpdate, err
+			return toUpdate, nil, err
 		}
 
 		if !enabled {
@@ -372,7 +407,7 @@ func (client *Client) checkIfS3BucketNeedsUpdate(ctx context.Context, l log.Logg
 	if !client.SkipBucketAccessLogging && client.AccessLoggingBucketName != "" {
 		enabled, err := client.checkS3AccessLoggingConfiguration(l, bucketName)
 		if err != nil {
-			return false, toUpdate, err
+			return toUpdate, nil, err
 		}
 
 		if !enabled {
@@ -385,7 +420,7 @@ func (client *Client) checkIfS3BucketNeedsUpdate(ctx context.Context, l log.Logg
 	if !client.SkipBucketPublicAccessBlocking {
 		enabled, err := client.checkIfS3PublicAccessBlockingEnabled(l, bucketName)
 		if err != nil {
-			return false, toUpdate, err
+			return toUpdate, nil, err
 		}
 
 		if !enabled {
@@ -395,18 +430,20 @@ func (client *Client) checkIfS3BucketNeedsUpdate(ctx context.Context, l log.Logg
 		}
 	}
 
-	// show update message if any of the above configs are not set
-	if len(updates) > 0 {
-		l.Warnf("The remote state S3 bucket %s needs to be updated:", bucketName)
-
-		for _, update := range updates {
-			l.Warnf("  - %s", update)
+	if len(client.S3BucketTags) > 0 {
+		tagged, err := client.checkIfS3BucketTagged(ctx, bucketName)
+		if err != nil {
+			return toUpdate, nil, err
 		}
 
-		return true, toUpdate, nil
+		if !tagged {
+			toUpdate.Tags = true
+
+			updates = append(updates, "Bucket Tags")
+		}
 	}
 
-	return false, toUpdate, nil
+	return toUpdate, updates, nil
 }
 
 // CheckIfVersioningEnabled checks if versioning is enabled for the S3 bucket specified in the given config and warn the user if it is not
@@ -1018,12 +1055,18 @@ func (client *Client) checkIfSSEForS3MatchesConfig(l log.Logger, bucketName stri
 	}
 
 	for _, rule := range output.ServerSideEncryptionConfiguration.Rules {
-		if rule.ApplyServerSideEncryptionByDefault != nil && rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm != nil {
-			if *rule.ApplyServerSideEncryptionByDefault.SSEAlgorithm == client.FetchEncryptionAlgorithm() {
-				return true, nil
+		if defEnc := rule.ApplyServerSideEncryptionByDefault; defEnc != nil && defEnc.SSEAlgorithm != nil {
+			algorithm := client.FetchEncryptionAlgorithm()
+			if *defEnc.SSEAlgorithm != algorithm {
+				return false, nil
 			}
 
-			return false, nil
+			// The bucket is still encrypted with the retired key once `bucket_sse_kms_key_id` changes.
+			if algorithm == s3.ServerSideEncryptionAwsKms && client.BucketSSEKMSKeyID != "" {
+				return KMSKeyMatches(aws.StringValue(defEnc.KMSMasterKeyID), client.BucketSSEKMSKeyID), nil
+			}
+
+			return true, nil
 		}
 	}
 
@@ -1211,6 +1254,30 @@ func (client *Client) checkBucketAccess(bucket, key string) error {
 	return errors.Errorf("error checking access to S3 bucket %s: %w", bucket, err)
 }
 
+// GetS3Object returns the content of the specified S3 object.
+func (client *Client) GetS3Object(ctx context.Context, l log.Logger, bucketName, key string) ([]byte, error) {
+	result, err := client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, errors.Errorf("failed to read S3 bucket %s object %s: %w", bucketName, key, err)
+	}
+
+	defer func() {
+		if err := result.Body.Close(); err != nil {
+			l.Warnf("Failed to close S3 bucket %s object %s: %v", bucketName, key, err)
+		}
+	}()
+
+	body, err := io.ReadAll(result.Body)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	return body, nil
+}
+
 // DeleteS3BucketIfNecessary deletes the given S3 bucket with all its objects if it exists.
 func (client *Client) DeleteS3BucketIfNecessary(ctx context.Context, l log.Logger, bucketName string) error {
 	if exists, err := client.DoesS3BucketExistWithLogging(ctx, l, bucketName); err != nil || !exists {
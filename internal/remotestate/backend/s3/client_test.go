@@ -179,6 +179,27 @@ func listTagsOfResourceWithRetry(t *testing.T, client *s3backend.Client, resourc
 	return nil
 }
 
+func TestAwsLockTableItems(t *testing.T) {
+	t.Parallel()
+
+	client := CreateS3ClientForTest(t)
+
+	WithLockTable(t, client, func(tableName string, client *s3backend.Client) {
+		for _, lockID := range []string{"bucket/app/terraform.tfstate", "bucket/vpc/terraform.tfstate-md5"} {
+			_, err := client.PutItem(&dynamodb.PutItemInput{
+				TableName: aws.String(tableName),
+				Item:      CreateKeyFromItemID(lockID),
+			})
+			require.NoError(t, err)
+		}
+
+		items, err := client.LockTableItems(t.Context(), tableName)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"bucket/app/terraform.tfstate", "bucket/vpc/terraform.tfstate-md5"}, items)
+		assert.Equal(t, []string{"bucket/app/terraform.tfstate"}, s3backend.LockTableLocks(items))
+	})
+}
+
 func UniqueTableNameForTest() string {
 	return "terragrunt_test_" + util.UniqueID()
 }
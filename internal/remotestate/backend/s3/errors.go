@@ -1,6 +1,9 @@
 package s3
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type MissingRequiredS3RemoteStateConfig string
 
@@ -60,3 +63,59 @@ type TableEncryptedRetriesExceeded struct {
 func (err TableEncryptedRetriesExceeded) Error() string {
 	return fmt.Sprintf("Failed to confirm that DynamoDB table %s has encryption enabled after %d retries.", err.TableName, err.Retries)
 }
+
+type LockTableInUseError struct {
+	TableName string
+	Locks     []string
+}
+
+func (err LockTableInUseError) Error() string {
+	return fmt.Sprintf("DynamoDB table %s still holds the locks %s, refusing to delete it.", err.TableName, strings.Join(err.Locks, ", "))
+}
+
+type LockTableSharedError struct {
+	TableName string
+	Items     []string
+}
+
+func (err LockTableSharedError) Error() string {
+	return fmt.Sprintf("DynamoDB table %s holds the items %s of states outside of the stack, refusing to delete it. If you are sure no other stack uses it, use the --force-delete-table flag.", err.TableName, strings.Join(err.Items, ", "))
+}
+
+// StateNotLockableError is the error that is returned when the state is locked neither with a DynamoDB table nor with
+// an S3 lockfile.
+type StateNotLockableError string
+
+func (path StateNotLockableError) Error() string {
+	return fmt.Sprintf("State %s is locked neither with a DynamoDB table nor with an S3 lockfile, unable to lock it.", string(path))
+}
+
+// StateAlreadyLockedError is the error that is returned when the state is already locked.
+type StateAlreadyLockedError string
+
+func (path StateAlreadyLockedError) Error() string {
+	return fmt.Sprintf("State %s is already locked.", string(path))
+}
+
+// KMSKeyNotEnabledError is the error that is returned when the KMS key the states are re-encrypted with isn't enabled.
+type KMSKeyNotEnabledError struct {
+	KeyID string
+	State string
+}
+
+func (err KMSKeyNotEnabledError) Error() string {
+	return fmt.Sprintf("KMS key %s is %s, unable to re-encrypt the states with it.", err.KeyID, err.State)
+}
+
+// ObjectNotReencryptedError is the error that is returned when the re-encrypted object isn't encrypted with the KMS
+// key it was re-encrypted with.
+type ObjectNotReencryptedError struct {
+	Bucket       string
+	Key          string
+	KeyArn       string
+	ActualKeyArn string
+}
+
+func (err ObjectNotReencryptedError) Error() string {
+	return fmt.Sprintf("S3 bucket %s object %s is encrypted with KMS key %q instead of %s once re-encrypted.", err.Bucket, err.Key, err.ActualKeyArn, err.KeyArn)
+}
@@ -0,0 +1,162 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/shell"
+)
+
+// lockfileSuffix is the suffix of the key of the S3 object locking a state with `use_lockfile`.
+const lockfileSuffix = ".tflock"
+
+var _ backend.LockMigrator = new(Backend)
+
+// LockMigrationStatus implements `backend.LockMigrator` interface. The state is locked if the DynamoDB table holds its
+// lock, or if its S3 lockfile exists.
+func (*Backend) LockMigrationStatus(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) (*backend.LockMigrationStatus, error) {
+	extS3Cfg, err := Config(backendConfig).ExtendedS3Config(l)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		s3Cfg  = &extS3Cfg.RemoteStateConfigS3
+		status = &backend.LockMigrationStatus{
+			LockTable:     s3Cfg.GetLockTableName(),
+			StateID:       path.Join(s3Cfg.Bucket, s3Cfg.Key),
+			NativeLocking: s3Cfg.UseLockfile,
+		}
+	)
+
+	if status.LockTable == "" {
+		return status, nil
+	}
+
+	client, err := NewClient(l, extS3Cfg, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if status.Locked, err = client.DoesTableItemExist(ctx, status.LockTable, status.StateID); err != nil || status.Locked {
+		return status, err
+	}
+
+	if status.NativeLocking {
+		if status.Locked, err = client.DoesS3ObjectExist(ctx, s3Cfg.Bucket, s3Cfg.Key+lockfileSuffix); err != nil {
+			return nil, err
+		}
+	}
+
+	return status, nil
+}
+
+// DeleteLockTable implements `backend.LockMigrator` interface. The table is refused to be deleted while it holds the
+// locks or the digests of states other than the given ones, e.g. of other stacks sharing the table, unless
+// `ForceDeleteLockTable` is set. The user is prompted before the table is deleted.
+func (backend *Backend) DeleteLockTable(ctx context.Context, l log.Logger, backendConfig backend.Config, tableName string, stateIDs []string, opts *options.TerragruntOptions) error {
+	extS3Cfg, err := Config(backendConfig).ExtendedS3Config(l)
+	if err != nil {
+		return err
+	}
+
+	client, err := NewClient(l, extS3Cfg, opts)
+	if err != nil {
+		return err
+	}
+
+	if exists, err := client.DoesLockTableExist(ctx, tableName); err != nil || !exists {
+		if err == nil {
+			l.Infof("DynamoDB table %s does not exist, nothing to delete", tableName)
+		}
+
+		return err
+	}
+
+	items, err := client.LockTableItems(ctx, tableName)
+	if err != nil {
+		return err
+	}
+
+	if locks := LockTableLocks(items); len(locks) > 0 {
+		return errors.New(LockTableInUseError{TableName: tableName, Locks: locks})
+	}
+
+	if foreign := ForeignLockTableItems(items, stateIDs); len(foreign) > 0 {
+		if !opts.ForceDeleteLockTable {
+			return errors.New(LockTableSharedError{TableName: tableName, Items: foreign})
+		}
+
+		l.Warnf("DynamoDB table %s holds the items %s of states outside of the stack, deleting it anyway", tableName, strings.Join(foreign, ", "))
+	}
+
+	prompt := fmt.Sprintf("DynamoDB table %s holds no lock and will be completely deleted. Do you want to continue?", tableName)
+	if yes, err := shell.PromptUserForYesNo(ctx, l, prompt, opts); err != nil || !yes {
+		return err
+	}
+
+	return client.DeleteTable(ctx, l, tableName)
+}
+
+// LockTableLocks returns the IDs of the locks among the given IDs of the items of a DynamoDB table, i.e. the items
+// other than the digests of the states.
+func LockTableLocks(items []string) []string {
+	var locks []string
+
+	for _, item := range items {
+		if !strings.HasSuffix(item, stateIDSuffix) {
+			locks = append(locks, item)
+		}
+	}
+
+	return locks
+}
+
+// ForeignLockTableItems returns the IDs among the given IDs of the items of a DynamoDB table, either locks or digests,
+// that belong to states other than the ones of the given IDs.
+func ForeignLockTableItems(items, stateIDs []string) []string {
+	var foreign []string
+
+	for _, item := range items {
+		if !slices.Contains(stateIDs, strings.TrimSuffix(item, stateIDSuffix)) {
+			foreign = append(foreign, item)
+		}
+	}
+
+	return foreign
+}
+
+// LockTableItems returns the IDs of the items the given DynamoDB table holds, both the locks and the digests of the
+// states.
+func (client *Client) LockTableItems(ctx context.Context, tableName string) ([]string, error) {
+	var items []string
+
+	input := &dynamodb.ScanInput{
+		TableName:            aws.String(tableName),
+		ProjectionExpression: aws.String(AttrLockID),
+	}
+
+	err := client.ScanPagesWithContext(ctx, input, func(page *dynamodb.ScanOutput, _ bool) bool {
+		for _, item := range page.Items {
+			if lockID := item[AttrLockID]; lockID != nil && lockID.S != nil {
+				items = append(items, *lockID.S)
+			}
+		}
+
+		return true
+	})
+	if err != nil {
+		return nil, errors.Errorf("failed to scan the items of table %s: %w", tableName, err)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,43 @@
+package s3_test
+
+import (
+	"testing"
+
+	s3backend "github.com/gruntwork-io/terragrunt/internal/remotestate/backend/s3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForeignLockTableItems(t *testing.T) {
+	t.Parallel()
+
+	stateIDs := []string{"bucket/app/terraform.tfstate", "bucket/vpc/terraform.tfstate"}
+
+	testCases := []struct {
+		name     string
+		items    []string
+		expected []string
+	}{
+		{
+			name:  "only the digests of the stack",
+			items: []string{"bucket/app/terraform.tfstate-md5", "bucket/vpc/terraform.tfstate-md5"},
+		},
+		{
+			name:     "digest of another stack",
+			items:    []string{"bucket/app/terraform.tfstate-md5", "bucket/other/terraform.tfstate-md5"},
+			expected: []string{"bucket/other/terraform.tfstate-md5"},
+		},
+		{
+			name:     "lock of another stack",
+			items:    []string{"other-bucket/app/terraform.tfstate"},
+			expected: []string{"other-bucket/app/terraform.tfstate"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, s3backend.ForeignLockTableItems(tc.items, stateIDs))
+		})
+	}
+}
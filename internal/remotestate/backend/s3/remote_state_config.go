@@ -33,6 +33,7 @@ var terragruntOnlyConfigs = []string{
 	"skip_accesslogging_bucket_ssencryption",
 	"bucket_sse_algorithm",
 	"bucket_sse_kms_key_id",
+	"bucket_drift_policy",
 }
 
 /* ExtendedRemoteStateConfigS3 is a struct that contains the RemoteStateConfigS3 struct and additional
@@ -173,23 +174,25 @@ type RemoteStateConfigS3Endpoints struct {
 // RemoteStateConfigS3 is a representation of the
 // configuration options available for S3 remote state.
 type RemoteStateConfigS3 struct {
-	Endpoints        RemoteStateConfigS3Endpoints  `mapstructure:"endpoints"`
-	RoleArn          string                        `mapstructure:"role_arn"`
-	ExternalID       string                        `mapstructure:"external_id"`
-	Region           string                        `mapstructure:"region"`
-	Endpoint         string                        `mapstructure:"endpoint"`
-	DynamoDBEndpoint string                        `mapstructure:"dynamodb_endpoint"`
-	Bucket           string                        `mapstructure:"bucket"`
-	Key              string                        `mapstructure:"key"`
-	CredsFilename    string                        `mapstructure:"shared_credentials_file"`
-	Profile          string                        `mapstructure:"profile"`
-	SessionName      string                        `mapstructure:"session_name"`
-	LockTable        string                        `mapstructure:"lock_table"`
-	DynamoDBTable    string                        `mapstructure:"dynamodb_table"`
-	AssumeRole       RemoteStateConfigS3AssumeRole `mapstructure:"assume_role"`
-	Encrypt          bool                          `mapstructure:"encrypt"`
-	S3ForcePathStyle bool                          `mapstructure:"force_path_style"`
-	UseLockfile      bool                          `mapstructure:"use_lockfile"`
+	Endpoints          RemoteStateConfigS3Endpoints  `mapstructure:"endpoints"`
+	RoleArn            string                        `mapstructure:"role_arn"`
+	ExternalID         string                        `mapstructure:"external_id"`
+	Region             string                        `mapstructure:"region"`
+	Endpoint           string                        `mapstructure:"endpoint"`
+	DynamoDBEndpoint   string                        `mapstructure:"dynamodb_endpoint"`
+	Bucket             string                        `mapstructure:"bucket"`
+	Key                string                        `mapstructure:"key"`
+	KMSKeyID           string                        `mapstructure:"kms_key_id"`
+	WorkspaceKeyPrefix string                        `mapstructure:"workspace_key_prefix"`
+	CredsFilename      string                        `mapstructure:"shared_credentials_file"`
+	Profile            string                        `mapstructure:"profile"`
+	SessionName        string                        `mapstructure:"session_name"`
+	LockTable          string                        `mapstructure:"lock_table"`
+	DynamoDBTable      string                        `mapstructure:"dynamodb_table"`
+	AssumeRole         RemoteStateConfigS3AssumeRole `mapstructure:"assume_role"`
+	Encrypt            bool                          `mapstructure:"encrypt"`
+	S3ForcePathStyle   bool                          `mapstructure:"force_path_style"`
+	UseLockfile        bool                          `mapstructure:"use_lockfile"`
 }
 
 // CacheKey returns a unique key for the given S3 config that can be used to cache the initialization
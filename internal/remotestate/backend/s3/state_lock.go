@@ -0,0 +1,116 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+// AttrInfo is the name of the attribute of the items of the lock table holding the lock of the state.
+const AttrInfo = "Info"
+
+var _ backend.StateLocker = new(Backend)
+
+// LockState implements `backend.StateLocker` interface. The state is locked as the S3 backend locks it: with an item of
+// its DynamoDB table, and with its S3 lockfile with `use_lockfile`. It returns an error if the state is already locked,
+// or if it is neither locked with a DynamoDB table nor natively.
+func (*Backend) LockState(ctx context.Context, l log.Logger, backendConfig backend.Config, lock *backend.StateLock, opts *options.TerragruntOptions) error {
+	extS3Cfg, err := Config(backendConfig).ExtendedS3Config(l)
+	if err != nil {
+		return err
+	}
+
+	var (
+		s3Cfg     = &extS3Cfg.RemoteStateConfigS3
+		tableName = s3Cfg.GetLockTableName()
+	)
+
+	if tableName == "" && !s3Cfg.UseLockfile {
+		return errors.New(StateNotLockableError(path.Join(s3Cfg.Bucket, s3Cfg.Key)))
+	}
+
+	client, err := NewClient(l, extS3Cfg, opts)
+	if err != nil {
+		return err
+	}
+
+	lock.Path = path.Join(s3Cfg.Bucket, s3Cfg.Key)
+
+	info, err := json.Marshal(lock)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if tableName != "" {
+		if err := client.CreateLockTableItem(ctx, l, tableName, lock.Path, string(info)); err != nil {
+			return err
+		}
+	}
+
+	if s3Cfg.UseLockfile {
+		return client.CreateLockfile(ctx, l, s3Cfg.Bucket, s3Cfg.Key+lockfileSuffix, info)
+	}
+
+	return nil
+}
+
+// CreateLockTableItem creates the item of the given DynamoDB lock table locking the state with the given key with the
+// given lock info, unless the state is already locked.
+func (client *Client) CreateLockTableItem(ctx context.Context, l log.Logger, tableName, key, info string) error {
+	l.Debugf("Locking %s with DynamoDB table %s", key, tableName)
+
+	input := &dynamodb.PutItemInput{
+		TableName: aws.String(tableName),
+		Item: map[string]*dynamodb.AttributeValue{
+			AttrLockID: {S: aws.String(key)},
+			AttrInfo:   {S: aws.String(info)},
+		},
+		ConditionExpression: aws.String("attribute_not_exists(" + AttrLockID + ")"),
+	}
+
+	if _, err := client.PutItemWithContext(ctx, input); err != nil {
+		var awsErr awserr.Error
+		if errors.As(err, &awsErr) && awsErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return errors.New(StateAlreadyLockedError(key))
+		}
+
+		return errors.Errorf("failed to lock %s with table %s: %w", key, tableName, err)
+	}
+
+	return nil
+}
+
+// CreateLockfile creates the given S3 lockfile with the given lock info, unless it already exists. Unlike OpenTofu,
+// the SDK can't create the object conditionally, so the lockfile is checked before it is created.
+func (client *Client) CreateLockfile(ctx context.Context, l log.Logger, bucketName, key string, info []byte) error {
+	if exists, err := client.DoesS3ObjectExist(ctx, bucketName, key); err != nil {
+		return err
+	} else if exists {
+		return errors.New(StateAlreadyLockedError(path.Join(bucketName, key)))
+	}
+
+	l.Debugf("Creating S3 lockfile %s in bucket %s", key, bucketName)
+
+	input := &s3.PutObjectInput{
+		Bucket:      aws.String(bucketName),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(info),
+		ContentType: aws.String("application/json"),
+	}
+
+	if _, err := client.PutObjectWithContext(ctx, input); err != nil {
+		return errors.Errorf("failed to create S3 lockfile %s in bucket %s: %w", key, bucketName, err)
+	}
+
+	return nil
+}
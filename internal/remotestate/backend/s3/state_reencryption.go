@@ -0,0 +1,262 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/kms"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/shell"
+)
+
+// defaultWorkspaceKeyPrefix is the prefix of the keys of the states of the workspaces other than the default one, as
+// set by the S3 backend without `workspace_key_prefix`.
+const defaultWorkspaceKeyPrefix = "env:"
+
+var _ backend.StateReencrypter = new(Backend)
+
+// ReencryptState implements `backend.StateReencrypter` interface.
+//
+// The states of all the workspaces of the config are copied in place with the KMS key of `kms_key_id`, else of
+// `bucket_sse_kms_key_id`, once the key is checked to be enabled, and read back to verify that they are encrypted with
+// it. The default encryption of the bucket is updated to `bucket_sse_kms_key_id` beforehand, so that the states written
+// without `kms_key_id` are also encrypted with the new key. The previous versions of the states are left as they are.
+func (*Backend) ReencryptState(ctx context.Context, l log.Logger, backendConfig backend.Config, opts *options.TerragruntOptions) error {
+	extS3Cfg, err := Config(backendConfig).ExtendedS3Config(l)
+	if err != nil {
+		return err
+	}
+
+	var (
+		s3Cfg      = &extS3Cfg.RemoteStateConfigS3
+		bucketName = s3Cfg.Bucket
+		keyID      = s3Cfg.KMSKeyID
+	)
+
+	if keyID == "" {
+		keyID = extS3Cfg.BucketSSEKMSKeyID
+	}
+
+	if keyID == "" {
+		return errors.New(MissingRequiredS3RemoteStateConfig("kms_key_id or bucket_sse_kms_key_id"))
+	}
+
+	if s3Cfg.KMSKeyID == "" && s3Cfg.Encrypt {
+		l.Warnf("The states of S3 bucket %s written with 'encrypt' and without 'kms_key_id' are encrypted with AES256 rather than KMS key %s, set 'kms_key_id' to keep them on the key.", bucketName, keyID)
+	}
+
+	client, err := NewClient(l, extS3Cfg, opts)
+	if err != nil {
+		return err
+	}
+
+	keyArn, err := client.EnabledKMSKeyArn(ctx, keyID)
+	if err != nil {
+		return err
+	}
+
+	if err := client.updateBucketEncryptionKeyIfNecessary(l, bucketName); err != nil {
+		return err
+	}
+
+	objects, err := client.stateObjectsToReencrypt(ctx, l, keyArn)
+	if err != nil {
+		return err
+	}
+
+	if len(objects) == 0 {
+		l.Infof("States of S3 bucket %s are already encrypted with KMS key %s", bucketName, keyArn)
+		return nil
+	}
+
+	prompt := fmt.Sprintf("Re-encrypt the states %s of S3 bucket %s with KMS key %s?", strings.Join(objects, ", "), bucketName, keyArn)
+
+	shouldReencrypt, err := shell.PromptUserForYesNo(ctx, l, prompt, opts)
+	if err != nil || !shouldReencrypt {
+		return err
+	}
+
+	for _, key := range objects {
+		if err := client.ReencryptS3Object(ctx, l, bucketName, key, keyArn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// EnabledKMSKeyArn returns the ARN of the given KMS key, given by its ID, ARN or alias, once it is checked to be
+// enabled and accessible with the credentials of the client.
+func (client *Client) EnabledKMSKeyArn(ctx context.Context, keyID string) (string, error) {
+	output, err := kms.New(client.session).DescribeKeyWithContext(ctx, &kms.DescribeKeyInput{KeyId: aws.String(keyID)})
+	if err != nil {
+		return "", errors.Errorf("failed to access KMS key %s: %w", keyID, err)
+	}
+
+	if state := aws.StringValue(output.KeyMetadata.KeyState); state != kms.KeyStateEnabled {
+		return "", errors.New(KMSKeyNotEnabledError{KeyID: keyID, State: state})
+	}
+
+	return aws.StringValue(output.KeyMetadata.Arn), nil
+}
+
+// ReencryptS3Object copies the given object in place, encrypted with the given KMS key, unless it changed since it was
+// listed, and reads it back to verify that it is encrypted with the key.
+func (client *Client) ReencryptS3Object(ctx context.Context, l log.Logger, bucketName, key, keyArn string) error {
+	head, err := client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{Bucket: aws.String(bucketName), Key: aws.String(key)})
+	if err != nil {
+		return errors.Errorf("failed to read S3 bucket %s object %s: %w", bucketName, key, err)
+	}
+
+	l.Infof("Re-encrypting S3 bucket %s object %s with KMS key %s", bucketName, key, keyArn)
+
+	input := &s3.CopyObjectInput{
+		Bucket:               aws.String(bucketName),
+		Key:                  aws.String(key),
+		CopySource:           aws.String(CopySource(bucketName, key)),
+		CopySourceIfMatch:    head.ETag,
+		MetadataDirective:    aws.String(s3.MetadataDirectiveCopy),
+		ServerSideEncryption: aws.String(s3.ServerSideEncryptionAwsKms),
+		SSEKMSKeyId:          aws.String(keyArn),
+	}
+
+	if _, err := client.CopyObjectWithContext(ctx, input); err != nil {
+		return errors.Errorf("failed to re-encrypt S3 bucket %s object %s: %w", bucketName, key, err)
+	}
+
+	// The state is read back to check that it can be decrypted with the key, not only that it is encrypted with it.
+	if _, err := client.GetS3Object(ctx, l, bucketName, key); err != nil {
+		return err
+	}
+
+	if head, err = client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{Bucket: aws.String(bucketName), Key: aws.String(key)}); err != nil {
+		return errors.Errorf("failed to read S3 bucket %s object %s: %w", bucketName, key, err)
+	}
+
+	if actual := aws.StringValue(head.SSEKMSKeyId); actual != keyArn {
+		return errors.New(ObjectNotReencryptedError{Bucket: bucketName, Key: key, KeyArn: keyArn, ActualKeyArn: actual})
+	}
+
+	return nil
+}
+
+// updateBucketEncryptionKeyIfNecessary updates the default encryption of the given bucket to `bucket_sse_kms_key_id`
+// if the bucket is still encrypted with another key, unless its encryption is skipped or its updates are disabled.
+func (client *Client) updateBucketEncryptionKeyIfNecessary(l log.Logger, bucketName string) error {
+	if client.SkipBucketSSEncryption || client.BucketSSEKMSKeyID == "" {
+		return nil
+	}
+
+	if matches, err := client.checkIfSSEForS3MatchesConfig(l, bucketName); err != nil || matches {
+		return err
+	}
+
+	if client.DisableBucketUpdate {
+		l.Warnf("Updates of the remote state S3 bucket %s are disabled using 'disable_bucket_update' config, its default encryption is left on its previous key.", bucketName)
+		return nil
+	}
+
+	l.Infof("Updating the default encryption of S3 bucket %s to KMS key %s", bucketName, client.BucketSSEKMSKeyID)
+
+	return client.EnableSSEForS3BucketWide(l, bucketName, client.FetchEncryptionAlgorithm())
+}
+
+// stateObjectsToReencrypt returns the keys of the states of all the workspaces of the config that aren't encrypted
+// with the given KMS key.
+func (client *Client) stateObjectsToReencrypt(ctx context.Context, l log.Logger, keyArn string) ([]string, error) {
+	s3Cfg := &client.RemoteStateConfigS3
+
+	var keys []string
+
+	if exists, err := client.DoesS3ObjectExist(ctx, s3Cfg.Bucket, s3Cfg.Key); err != nil {
+		return nil, err
+	} else if exists {
+		keys = append(keys, s3Cfg.Key)
+	}
+
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(s3Cfg.Bucket),
+		Prefix: aws.String(s3Cfg.GetWorkspaceKeyPrefix() + "/"),
+	}
+
+	err := client.ListObjectsV2PagesWithContext(ctx, input, func(page *s3.ListObjectsV2Output, _ bool) bool {
+		for _, object := range page.Contents {
+			if key := aws.StringValue(object.Key); key != s3Cfg.Key && s3Cfg.IsStateKey(key) {
+				keys = append(keys, key)
+			}
+		}
+
+		return true
+	})
+	if err != nil {
+		return nil, errors.Errorf("failed to list the objects of S3 bucket %s: %w", s3Cfg.Bucket, err)
+	}
+
+	var objects []string
+
+	for _, key := range keys {
+		head, err := client.HeadObjectWithContext(ctx, &s3.HeadObjectInput{Bucket: aws.String(s3Cfg.Bucket), Key: aws.String(key)})
+		if err != nil {
+			return nil, errors.Errorf("failed to read S3 bucket %s object %s: %w", s3Cfg.Bucket, key, err)
+		}
+
+		if aws.StringValue(head.SSEKMSKeyId) == keyArn {
+			l.Debugf("State %s of S3 bucket %s is already encrypted with KMS key %s", key, s3Cfg.Bucket, keyArn)
+			continue
+		}
+
+		objects = append(objects, key)
+	}
+
+	return objects, nil
+}
+
+// GetWorkspaceKeyPrefix returns the prefix of the keys of the states of the workspaces other than the default one.
+func (cfg *RemoteStateConfigS3) GetWorkspaceKeyPrefix() string {
+	if cfg.WorkspaceKeyPrefix != "" {
+		return cfg.WorkspaceKeyPrefix
+	}
+
+	return defaultWorkspaceKeyPrefix
+}
+
+// IsStateKey returns true if the given key is the key of the state of a workspace of the config: `key` for the default
+// workspace, else `<workspace_key_prefix>/<workspace>/<key>`.
+func (cfg *RemoteStateConfigS3) IsStateKey(key string) bool {
+	if key == cfg.Key {
+		return true
+	}
+
+	workspace, ok := strings.CutPrefix(key, cfg.GetWorkspaceKeyPrefix()+"/")
+	if !ok {
+		return false
+	}
+
+	workspace, ok = strings.CutSuffix(workspace, "/"+cfg.Key)
+
+	return ok && workspace != "" && !strings.Contains(workspace, "/")
+}
+
+// CopySource returns the URL-encoded copy source of the given object, each segment of its key being escaped so that
+// keys with spaces or special characters, e.g. `env:/prod/terraform.tfstate`, are copied.
+func CopySource(bucketName, key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return bucketName + "/" + strings.Join(segments, "/")
+}
+
+// KMSKeyMatches returns true if the given KMS key, as returned by S3, is the given configured key, given by its ID, ARN
+// or alias.
+func KMSKeyMatches(actual, configured string) bool {
+	return actual == configured || strings.HasSuffix(actual, ":key/"+configured) || strings.HasSuffix(actual, ":"+configured)
+}
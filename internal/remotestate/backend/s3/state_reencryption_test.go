@@ -0,0 +1,81 @@
+package s3_test
+
+import (
+	"testing"
+
+	s3backend "github.com/gruntwork-io/terragrunt/internal/remotestate/backend/s3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsStateKey(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		cfg      s3backend.RemoteStateConfigS3
+		key      string
+		expected bool
+	}{
+		{
+			name:     "default workspace",
+			cfg:      s3backend.RemoteStateConfigS3{Key: "vpc/terraform.tfstate"},
+			key:      "vpc/terraform.tfstate",
+			expected: true,
+		},
+		{
+			name:     "workspace",
+			cfg:      s3backend.RemoteStateConfigS3{Key: "vpc/terraform.tfstate"},
+			key:      "env:/dev/vpc/terraform.tfstate",
+			expected: true,
+		},
+		{
+			name:     "workspace key prefix",
+			cfg:      s3backend.RemoteStateConfigS3{Key: "vpc/terraform.tfstate", WorkspaceKeyPrefix: "workspaces"},
+			key:      "workspaces/dev/vpc/terraform.tfstate",
+			expected: true,
+		},
+		{
+			name: "other unit",
+			cfg:  s3backend.RemoteStateConfigS3{Key: "vpc/terraform.tfstate"},
+			key:  "env:/dev/app/vpc/terraform.tfstate",
+		},
+		{
+			name: "other workspace key prefix",
+			cfg:  s3backend.RemoteStateConfigS3{Key: "vpc/terraform.tfstate", WorkspaceKeyPrefix: "workspaces"},
+			key:  "env:/dev/vpc/terraform.tfstate",
+		},
+		{
+			name: "lockfile",
+			cfg:  s3backend.RemoteStateConfigS3{Key: "vpc/terraform.tfstate"},
+			key:  "env:/dev/vpc/terraform.tfstate.tflock",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, tc.cfg.IsStateKey(tc.key))
+		})
+	}
+}
+
+func TestKMSKeyMatches(t *testing.T) {
+	t.Parallel()
+
+	const keyArn = "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"
+
+	assert.True(t, s3backend.KMSKeyMatches(keyArn, keyArn))
+	assert.True(t, s3backend.KMSKeyMatches(keyArn, "1234abcd-12ab-34cd-56ef-1234567890ab"))
+	assert.True(t, s3backend.KMSKeyMatches("arn:aws:kms:us-east-1:123456789012:alias/state", "alias/state"))
+	assert.False(t, s3backend.KMSKeyMatches(keyArn, "0987dcba-09fe-87dc-65ba-ab0987654321"))
+	assert.False(t, s3backend.KMSKeyMatches("", "alias/state"))
+}
+
+func TestCopySource(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "state/prod/vpc/terraform.tfstate", s3backend.CopySource("state", "prod/vpc/terraform.tfstate"))
+	assert.Equal(t, "state/env:/prod%20eu/vpc%23app/terraform.tfstate", s3backend.CopySource("state", "env:/prod eu/vpc#app/terraform.tfstate"))
+	assert.Equal(t, "state/prod/caf%C3%A9%3Fv=1/terraform.tfstate", s3backend.CopySource("state", "prod/café?v=1/terraform.tfstate"))
+}
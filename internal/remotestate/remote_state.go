@@ -5,19 +5,35 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 
+	"github.com/gruntwork-io/terragrunt/codegen"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend"
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend/azurerm"
 	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend/gcs"
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend/http"
+	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend/pg"
 	"github.com/gruntwork-io/terragrunt/internal/remotestate/backend/s3"
 	"github.com/gruntwork-io/terragrunt/options"
 	"github.com/gruntwork-io/terragrunt/pkg/log"
 	"github.com/gruntwork-io/terragrunt/tf"
 )
 
+const (
+	// backendWorkingDirFile is the file the backend is generated in, in the working dirs of their own the states are
+	// pulled and pushed in.
+	backendWorkingDirFile = "backend.tf"
+	// pushedStateFile is the file the state is written to before it's pushed.
+	pushedStateFile = "pushed.tfstate"
+)
+
 var backends = backend.Backends{
 	s3.NewBackend(),
 	gcs.NewBackend(),
+	azurerm.NewBackend(),
+	http.NewBackend(),
+	pg.NewBackend(),
 }
 
 // RemoteState is the configuration for Terraform remote state.
@@ -65,6 +81,182 @@ func (remote *RemoteState) DeleteBucket(ctx context.Context, l log.Logger, opts
 	return remote.backend.DeleteBucket(ctx, l, remote.BackendConfig, opts)
 }
 
+// CanReadState returns true if the state stored in the remote state backend can be read without invoking
+// OpenTofu/Terraform.
+func (remote *RemoteState) CanReadState() bool {
+	_, ok := remote.backend.(backend.StateReader)
+
+	return ok
+}
+
+// ReadState returns the content of the state file stored in the remote state backend, read without invoking
+// OpenTofu/Terraform.
+func (remote *RemoteState) ReadState(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) ([]byte, error) {
+	reader, ok := remote.backend.(backend.StateReader)
+	if !ok {
+		return nil, errors.New(backend.StateReaderNotSupportedError(remote.BackendName))
+	}
+
+	l.Debugf("Reading state of the %s backend", remote.BackendName)
+
+	return reader.ReadState(ctx, l, remote.BackendConfig, opts)
+}
+
+// CanMigrateLocks returns true if the state stored in the remote state backend can be switched off its lock table.
+func (remote *RemoteState) CanMigrateLocks() bool {
+	_, ok := remote.backend.(backend.LockMigrator)
+
+	return ok
+}
+
+// LockMigrationStatus returns the status of the migration of the state off its lock table.
+func (remote *RemoteState) LockMigrationStatus(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) (*backend.LockMigrationStatus, error) {
+	migrator, ok := remote.backend.(backend.LockMigrator)
+	if !ok {
+		return nil, errors.New(backend.LockMigratorNotSupportedError(remote.BackendName))
+	}
+
+	l.Debugf("Checking the lock migration of the %s backend", remote.BackendName)
+
+	return migrator.LockMigrationStatus(ctx, l, remote.BackendConfig, opts)
+}
+
+// DeleteLockTable deletes the given lock table, once it holds no lock, nor any item of the states other than the ones
+// of the given IDs.
+func (remote *RemoteState) DeleteLockTable(ctx context.Context, l log.Logger, tableName string, stateIDs []string, opts *options.TerragruntOptions) error {
+	migrator, ok := remote.backend.(backend.LockMigrator)
+	if !ok {
+		return errors.New(backend.LockMigratorNotSupportedError(remote.BackendName))
+	}
+
+	l.Debugf("Deleting the lock table %s of the %s backend", tableName, remote.BackendName)
+
+	return migrator.DeleteLockTable(ctx, l, remote.BackendConfig, tableName, stateIDs, opts)
+}
+
+// CanLockState returns true if the state stored in the remote state backend can be locked without invoking
+// OpenTofu/Terraform.
+func (remote *RemoteState) CanLockState() bool {
+	_, ok := remote.backend.(backend.StateLocker)
+
+	return ok
+}
+
+// LockState locks the state stored in the remote state backend with the given lock, which is never released.
+func (remote *RemoteState) LockState(ctx context.Context, l log.Logger, lock *backend.StateLock, opts *options.TerragruntOptions) error {
+	locker, ok := remote.backend.(backend.StateLocker)
+	if !ok {
+		return errors.New(backend.StateLockerNotSupportedError(remote.BackendName))
+	}
+
+	l.Debugf("Locking state of the %s backend", remote.BackendName)
+
+	return locker.LockState(ctx, l, remote.BackendConfig, lock, opts)
+}
+
+// CanReencryptState returns true if the state stored in the remote state backend can be re-encrypted with the KMS key
+// of the config.
+func (remote *RemoteState) CanReencryptState() bool {
+	_, ok := remote.backend.(backend.StateReencrypter)
+
+	return ok
+}
+
+// ReencryptState re-encrypts the state stored in the remote state backend with the KMS key of the config.
+func (remote *RemoteState) ReencryptState(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) error {
+	reencrypter, ok := remote.backend.(backend.StateReencrypter)
+	if !ok {
+		return errors.New(backend.StateReencrypterNotSupportedError(remote.BackendName))
+	}
+
+	l.Debugf("Re-encrypting state of the %s backend", remote.BackendName)
+
+	return reencrypter.ReencryptState(ctx, l, remote.BackendConfig, opts)
+}
+
+// CanCheckBucketDrift returns true if the remote state backend can check the drift of its bucket from the config.
+func (remote *RemoteState) CanCheckBucketDrift() bool {
+	_, ok := remote.backend.(backend.BucketDriftChecker)
+
+	return ok
+}
+
+// CheckBucketDrift checks the drift of the bucket of the remote state backend from the config, if the backend can
+// check it, and applies the given drift policy, else the `bucket_drift_policy` of the config, to the drifted bucket:
+// `warn` logs the drifted settings, `fail` returns a `BucketDriftError`, and `remediate` updates the bucket, unless
+// bucket updates are disabled. The bucket isn't checked without policy. It returns the drift of the bucket, or nil if
+// it wasn't checked or doesn't exist.
+func (remote *RemoteState) CheckBucketDrift(ctx context.Context, l log.Logger, policyName string, opts *options.TerragruntOptions) (*backend.BucketDrift, error) {
+	checker, ok := remote.backend.(backend.BucketDriftChecker)
+	if !ok || remote.DisableInit {
+		return nil, nil
+	}
+
+	if policyName == "" {
+		policyName = remote.BackendConfig.DriftPolicy()
+	}
+
+	if policyName == "" {
+		return nil, nil
+	}
+
+	policy, err := backend.ParseDriftPolicy(policyName)
+	if err != nil {
+		return nil, err
+	}
+
+	drift, err := checker.BucketDrift(ctx, l, remote.BackendConfig, opts)
+	if err != nil || drift == nil || len(drift.Settings) == 0 {
+		return drift, err
+	}
+
+	if policy == backend.DriftPolicyRemediate && opts.DisableBucketUpdate {
+		l.Warnf("Bucket updates are disabled, the drift of the remote state %s bucket %s is not remediated", remote.BackendName, drift.Bucket)
+
+		policy = backend.DriftPolicyWarn
+	}
+
+	switch policy {
+	case backend.DriftPolicyFail:
+		return drift, errors.New(backend.BucketDriftError{BackendName: remote.BackendName, Bucket: drift.Bucket, Settings: drift.Settings})
+	case backend.DriftPolicyRemediate:
+		l.Infof("Remediating the drift of the remote state %s bucket %s", remote.BackendName, drift.Bucket)
+
+		return drift, checker.RemediateBucketDrift(ctx, l, remote.BackendConfig, opts)
+	case backend.DriftPolicyWarn:
+		l.Warnf("The remote state %s bucket %s drifted from its config:", remote.BackendName, drift.Bucket)
+
+		for _, setting := range drift.Settings {
+			l.Warnf("  - %s", setting)
+		}
+	}
+
+	return drift, nil
+}
+
+// InjectCredentials sets the credentials of the remote state backend in the env of the given options, if the backend
+// passes them to OpenTofu/Terraform at runtime.
+func (remote *RemoteState) InjectCredentials(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) error {
+	injector, ok := remote.backend.(backend.CredentialsInjector)
+	if !ok {
+		return nil
+	}
+
+	return injector.InjectCredentials(ctx, l, remote.BackendConfig, opts)
+}
+
+// CheckConnectivity returns an error if the remote state backend can't be reached, if the backend can check it.
+func (remote *RemoteState) CheckConnectivity(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) error {
+	checker, ok := remote.backend.(backend.ConnectivityChecker)
+	if !ok || remote.DisableInit {
+		return nil
+	}
+
+	l.Debugf("Checking connectivity of the %s backend", remote.BackendName)
+
+	return checker.CheckConnectivity(ctx, l, remote.BackendConfig, opts)
+}
+
 // Bootstrap performs any actions necessary to bootstrap remote state before it's used for storage. For example, if you're
 // using S3 or GCS for remote state storage, this may create the bucket if it doesn't exist already.
 func (remote *RemoteState) Bootstrap(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) error {
@@ -138,6 +330,32 @@ func (remote *RemoteState) GetTFInitArgs() []string {
 	return backendConfigArgs
 }
 
+// DiffersFrom returns true if the remote state backend, or its config, differs from the given backend, e.g. the backend
+// recorded in the data dir of a unit once initialized.
+func (remote *RemoteState) DiffersFrom(tfBackend *TerraformBackend) bool {
+	if tfBackend == nil || tfBackend.Type != remote.BackendName {
+		return true
+	}
+
+	var (
+		config         = remote.backend.GetTFInitArgs(remote.BackendConfig)
+		existingConfig = tfBackend.Settings()
+	)
+
+	if len(config) != len(existingConfig) {
+		return true
+	}
+
+	for key, val := range config {
+		// The recorded values are decoded from JSON, e.g. the numbers as floats, so they are compared as printed.
+		if existingVal, ok := existingConfig[key]; !ok || fmt.Sprint(existingVal) != fmt.Sprint(val) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // GenerateOpenTofuCode generates the OpenTofu/Terraform code for configuring remote state backend.
 func (remote *RemoteState) GenerateOpenTofuCode(l log.Logger, opts *options.TerragruntOptions) error {
 	backendConfig := remote.backend.GetTFInitArgs(remote.BackendConfig)
@@ -145,6 +363,77 @@ func (remote *RemoteState) GenerateOpenTofuCode(l log.Logger, opts *options.Terr
 	return remote.Config.GenerateOpenTofuCode(l, opts, backendConfig)
 }
 
+// PullBackendState returns the content of the state stored in the remote state backend, pulled with `state pull` in a
+// working dir of its own, so that neither the code of the unit nor the backend its working dir is initialized with are
+// involved. It returns an empty content if there is no state yet.
+func (remote *RemoteState) PullBackendState(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) ([]byte, error) {
+	var state []byte
+
+	err := remote.runInBackendWorkingDir(ctx, l, opts, func(opts *options.TerragruntOptions) error {
+		l.Debugf("Pulling state from %s backend", remote.BackendName)
+
+		output, err := tf.RunCommandWithOutput(ctx, l, opts, tf.CommandNameState, tf.CommandNamePull)
+		if err != nil {
+			return err
+		}
+
+		state = output.Stdout.Bytes()
+
+		return nil
+	})
+
+	return state, err
+}
+
+// PushBackendState pushes the given state to the remote state backend with `state push`, in a working dir of its own,
+// see `PullBackendState`.
+func (remote *RemoteState) PushBackendState(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, state []byte) error {
+	return remote.runInBackendWorkingDir(ctx, l, opts, func(opts *options.TerragruntOptions) error {
+		stateFile := filepath.Join(opts.WorkingDir, pushedStateFile)
+
+		if err := os.WriteFile(stateFile, state, os.FileMode(0600)); err != nil {
+			return errors.New(err)
+		}
+
+		l.Debugf("Pushing state to %s backend", remote.BackendName)
+
+		return tf.RunCommand(ctx, l, opts, tf.CommandNameState, tf.CommandNamePush, stateFile)
+	})
+}
+
+// runInBackendWorkingDir runs the given function with the given options cloned with a temporary working dir, where only
+// the remote state backend is generated and initialized.
+func (remote *RemoteState) runInBackendWorkingDir(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, fn func(opts *options.TerragruntOptions) error) error {
+	workingDir, err := os.MkdirTemp("", "terragrunt-backend-*")
+	if err != nil {
+		return errors.New(err)
+	}
+
+	defer func() {
+		os.RemoveAll(workingDir) // nolint: errcheck
+	}()
+
+	backendOpts := opts.Clone()
+	backendOpts.WorkingDir = workingDir
+	backendOpts.TerraformCliArgs = []string{tf.CommandNameInit}
+
+	// The data dir of the unit, if set, would be initialized with the backend instead.
+	delete(backendOpts.Env, "TF_DATA_DIR")
+
+	backendCfg := *remote.Config
+	backendCfg.Generate = &ConfigGenerate{Path: backendWorkingDirFile, IfExists: codegen.ExistsOverwriteTerragruntStr}
+
+	if err := backendCfg.GenerateOpenTofuCode(l, backendOpts, remote.backend.GetTFInitArgs(backendCfg.BackendConfig)); err != nil {
+		return err
+	}
+
+	if err := tf.RunCommand(ctx, l, backendOpts, tf.CommandNameInit, "-input=false", "-reconfigure"); err != nil {
+		return err
+	}
+
+	return fn(backendOpts)
+}
+
 func (remote *RemoteState) pullState(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) (string, error) {
 	l.Debugf("Pulling state from %s backend", remote.BackendName)
 
@@ -5,7 +5,10 @@ import (
 	"testing"
 
 	"github.com/gruntwork-io/terragrunt/internal/remotestate"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 /**
@@ -125,6 +128,120 @@ func TestGetTFInitArgsNoBackendConfigs(t *testing.T) {
 	}
 }
 
+func TestDiffersFrom(t *testing.T) {
+	t.Parallel()
+
+	remote := remotestate.New(&remotestate.Config{
+		BackendName: "s3",
+		BackendConfig: map[string]any{
+			"bucket":         "my-bucket",
+			"key":            "vpc/terraform.tfstate",
+			"region":         "us-east-1",
+			"max_retries":    5,
+			"s3_bucket_tags": map[string]any{"team": "platform"},
+		},
+	})
+
+	testCases := []struct {
+		tfBackend *remotestate.TerraformBackend
+		name      string
+		expected  bool
+	}{
+		{
+			name: "same backend",
+			tfBackend: &remotestate.TerraformBackend{
+				Type:   "s3",
+				Config: map[string]any{"bucket": "my-bucket", "key": "vpc/terraform.tfstate", "region": "us-east-1", "max_retries": float64(5), "profile": nil},
+			},
+		},
+		{
+			name: "different config",
+			tfBackend: &remotestate.TerraformBackend{
+				Type:   "s3",
+				Config: map[string]any{"bucket": "my-bucket", "key": "app/terraform.tfstate", "region": "us-east-1", "max_retries": float64(5)},
+			},
+			expected: true,
+		},
+		{
+			name: "different backend",
+			tfBackend: &remotestate.TerraformBackend{
+				Type:   "gcs",
+				Config: map[string]any{"bucket": "my-bucket", "prefix": "vpc"},
+			},
+			expected: true,
+		},
+		{
+			name:     "no backend",
+			expected: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tc.expected, remote.DiffersFrom(tc.tfBackend))
+		})
+	}
+}
+
+func TestCheckBucketDrift(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name          string
+		backendName   string
+		policy        string
+		backendConfig map[string]any
+		expectedErr   string
+	}{
+		{
+			name:          "no policy",
+			backendName:   "s3",
+			backendConfig: map[string]any{"bucket": "my-bucket", "key": "terraform.tfstate", "region": "us-east-1"},
+		},
+		{
+			name:          "invalid config policy",
+			backendName:   "s3",
+			backendConfig: map[string]any{"bucket": "my-bucket", "key": "terraform.tfstate", "region": "us-east-1", "bucket_drift_policy": "ignore"},
+			expectedErr:   `unsupported bucket drift policy "ignore"`,
+		},
+		{
+			name:          "invalid policy",
+			backendName:   "gcs",
+			policy:        "ignore",
+			backendConfig: map[string]any{"bucket": "my-bucket", "bucket_drift_policy": "fail"},
+			expectedErr:   `unsupported bucket drift policy "ignore"`,
+		},
+		{
+			name:          "unsupported backend",
+			backendName:   "local",
+			policy:        "fail",
+			backendConfig: map[string]any{"path": "terraform.tfstate"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts, err := options.NewTerragruntOptionsForTest("terragrunt.hcl")
+			require.NoError(t, err)
+
+			remote := remotestate.New(&remotestate.Config{BackendName: tc.backendName, BackendConfig: tc.backendConfig})
+
+			drift, err := remote.CheckBucketDrift(t.Context(), logger.CreateLogger(), tc.policy, opts)
+			if tc.expectedErr != "" {
+				require.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Nil(t, drift)
+		})
+	}
+}
+
 func assertTerraformInitArgsEqual(t *testing.T, actualArgs []string, expectedArgs string) {
 	t.Helper()
 
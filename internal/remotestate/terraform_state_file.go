@@ -24,6 +24,7 @@ const DefaultPathToRemoteStateFile = "terraform.tfstate"
 // TerraformState - represents the structure of the Terraform .tfstate file.
 type TerraformState struct {
 	Backend *TerraformBackend      `json:"Backend"`
+	Lineage string                 `json:"Lineage"`
 	Modules []TerraformStateModule `json:"Modules"`
 	Version int                    `json:"Version"`
 	Serial  int                    `json:"Serial"`
@@ -35,6 +36,19 @@ type TerraformBackend struct {
 	Type   string         `json:"Type"`
 }
 
+// Settings returns the config of the backend without the settings recorded as null, as they are unset.
+func (tfBackend *TerraformBackend) Settings() backend.Config {
+	settings := make(backend.Config)
+
+	for key, val := range tfBackend.Config {
+		if val != nil {
+			settings[key] = val
+		}
+	}
+
+	return settings
+}
+
 // TerraformStateModule represents the structure of a "module" section in the Terraform .tfstate file.
 type TerraformStateModule struct {
 	Outputs   map[string]any `json:"Outputs"`
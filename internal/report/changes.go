@@ -0,0 +1,95 @@
+package report
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ResourceChanges are the numbers of resources a run changed, or plans to change.
+type ResourceChanges struct {
+	Add     int `json:"Add"`
+	Change  int `json:"Change"`
+	Destroy int `json:"Destroy"`
+	Import  int `json:"Import,omitempty"`
+}
+
+var (
+	planChangesRegexp    = regexp.MustCompile(`Plan: (?:(\d+) to import, )?(\d+) to add, (\d+) to change, (\d+) to destroy`)
+	applyChangesRegexp   = regexp.MustCompile(`Apply complete! Resources: (?:(\d+) imported, )?(\d+) added, (\d+) changed, (\d+) destroyed`)
+	destroyChangesRegexp = regexp.MustCompile(`Destroy complete! Resources: (\d+) destroyed`)
+	ansiEscapeRegexp     = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+)
+
+// ParseResourceChanges parses the numbers of changed resources from a line of the output of OpenTofu/Terraform, e.g.
+// `Plan: 1 to add, 0 to change, 0 to destroy.`, and returns false if the line doesn't report any.
+func ParseResourceChanges(line string) (ResourceChanges, bool) {
+	line = ansiEscapeRegexp.ReplaceAllString(line, "")
+
+	atoi := func(s string) int {
+		n, _ := strconv.Atoi(s)
+		return n
+	}
+
+	if match := planChangesRegexp.FindStringSubmatch(line); match != nil {
+		return ResourceChanges{Import: atoi(match[1]), Add: atoi(match[2]), Change: atoi(match[3]), Destroy: atoi(match[4])}, true
+	}
+
+	if match := applyChangesRegexp.FindStringSubmatch(line); match != nil {
+		return ResourceChanges{Import: atoi(match[1]), Add: atoi(match[2]), Change: atoi(match[3]), Destroy: atoi(match[4])}, true
+	}
+
+	if match := destroyChangesRegexp.FindStringSubmatch(line); match != nil {
+		return ResourceChanges{Destroy: atoi(match[1])}, true
+	}
+
+	if strings.Contains(line, "No changes.") {
+		return ResourceChanges{}, true
+	}
+
+	return ResourceChanges{}, false
+}
+
+// ChangesWriter forwards the output of a run to another writer, recording the numbers of changed resources it reports
+// in the run. The last numbers reported win, so the result of an apply replaces the plan printed before it.
+type ChangesWriter struct {
+	out  io.Writer
+	run  *Run
+	line []byte
+}
+
+// NewChangesWriter returns a writer forwarding to the given writer, and recording the changes in the given run.
+func NewChangesWriter(out io.Writer, run *Run) *ChangesWriter {
+	return &ChangesWriter{out: out, run: run}
+}
+
+// Write forwards p to the underlying writer, and parses the complete lines written so far.
+func (writer *ChangesWriter) Write(p []byte) (int, error) {
+	writer.line = append(writer.line, p...)
+
+	for {
+		i := bytes.IndexByte(writer.line, '\n')
+		if i < 0 {
+			break
+		}
+
+		writer.record(string(writer.line[:i]))
+		writer.line = writer.line[i+1:]
+	}
+
+	return writer.out.Write(p)
+}
+
+func (writer *ChangesWriter) record(line string) {
+	changes, ok := ParseResourceChanges(line)
+	if !ok {
+		return
+	}
+
+	writer.run.mu.Lock()
+	defer writer.run.mu.Unlock()
+
+	writer.run.Changes = &changes
+}
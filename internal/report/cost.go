@@ -0,0 +1,21 @@
+package report
+
+// CostEstimate is the monthly cost of the resources of a run, estimated by Infracost from its plan.
+type CostEstimate struct {
+	// Currency is the currency of the costs, e.g. USD.
+	Currency string `json:"Currency"`
+	// MonthlyCost is the monthly cost of the resources once the plan is applied.
+	MonthlyCost float64 `json:"MonthlyCost"`
+	// PastMonthlyCost is the monthly cost of the resources before the plan is applied.
+	PastMonthlyCost float64 `json:"PastMonthlyCost"`
+	// DiffMonthlyCost is the change of the monthly cost the plan makes, negative if the plan reduces the cost.
+	DiffMonthlyCost float64 `json:"DiffMonthlyCost"`
+}
+
+// SetCost sets the cost estimate of the run.
+func (run *Run) SetCost(cost *CostEstimate) {
+	run.mu.Lock()
+	defer run.mu.Unlock()
+
+	run.Cost = cost
+}
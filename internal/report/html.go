@@ -0,0 +1,117 @@
+package report
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//go:embed templates/report.html
+var htmlTemplate string
+
+// htmlReport is the data of the HTML report template.
+type htmlReport struct {
+	Duration       string
+	Units          []htmlUnit
+	TotalUnits     int
+	UnitsSucceeded int
+	UnitsFailed    int
+	EarlyExits     int
+	Excluded       int
+}
+
+// htmlUnit is the data of a run in the HTML report template.
+type htmlUnit struct {
+	Changes  *ResourceChanges
+	Name     string
+	Result   string
+	Class    string
+	Duration string
+	Reason   string
+	Cause    string
+	Retries  int
+}
+
+// WriteHTMLToFile writes the report to a file as a self-contained HTML page.
+func (r *Report) WriteHTMLToFile(path string) error {
+	tmpFile, err := os.CreateTemp("", "terragrunt-report-*.html")
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.SortRuns()
+	r.mu.Unlock()
+
+	if err := r.WriteHTML(tmpFile); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("failed to close report file: %w", err)
+	}
+
+	if r.workingDir != "" && !filepath.IsAbs(path) {
+		path = filepath.Join(r.workingDir, path)
+	}
+
+	return os.Rename(tmpFile.Name(), path)
+}
+
+// WriteHTML writes the report to a writer as a self-contained HTML page, summarizing the runs for humans, e.g. as an
+// artifact of a CI pipeline.
+func (r *Report) WriteHTML(w io.Writer) error {
+	tmpl, err := template.New("report").Parse(htmlTemplate)
+	if err != nil {
+		return err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	summary := r.Summarize()
+	colorizer := NewColorizer(false)
+
+	data := htmlReport{
+		Duration:       colorizer.colorDuration(summary.TotalDuration()),
+		TotalUnits:     summary.TotalUnits(),
+		UnitsSucceeded: summary.UnitsSucceeded,
+		UnitsFailed:    summary.UnitsFailed,
+		EarlyExits:     summary.EarlyExits,
+		Excluded:       summary.Excluded,
+		Units:          make([]htmlUnit, 0, len(r.Runs)),
+	}
+
+	for _, run := range r.Runs {
+		run.mu.RLock()
+		defer run.mu.RUnlock()
+
+		unit := htmlUnit{
+			Name:     nameOfPath(run.Path, r.workingDir),
+			Result:   string(run.Result),
+			Class:    strings.ReplaceAll(string(run.Result), " ", "-"),
+			Duration: colorizer.colorDuration(run.Ended.Sub(run.Started)),
+			Retries:  run.Retries,
+			Changes:  run.Changes,
+		}
+
+		if run.Reason != nil {
+			unit.Reason = string(*run.Reason)
+		}
+
+		if run.Cause != nil {
+			unit.Cause = string(*run.Cause)
+			if run.Reason != nil && (*run.Reason == ReasonAncestorError || *run.Reason == ReasonRunHalted) && r.workingDir != "" {
+				unit.Cause = strings.TrimPrefix(unit.Cause, r.workingDir+string(os.PathSeparator))
+			}
+		}
+
+		data.Units = append(data.Units, unit)
+	}
+
+	return tmpl.Execute(w, data)
+}
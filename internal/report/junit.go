@@ -0,0 +1,167 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// junitSuiteName is the name of the test suite of the units of a run in the JUnit report.
+const junitSuiteName = "terragrunt run --all"
+
+// junitTestSuites is the root element of the JUnit report, as read by GitLab, Jenkins and the test summaries of GitHub
+// Actions.
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	Time      string          `xml:"time,attr"`
+	Cases     []junitTestCase `xml:"testcase"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+}
+
+type junitTestCase struct {
+	Failure   *junitResult `xml:"failure,omitempty"`
+	Skipped   *junitResult `xml:"skipped,omitempty"`
+	Name      string       `xml:"name,attr"`
+	ClassName string       `xml:"classname,attr"`
+	Time      string       `xml:"time,attr"`
+	SystemOut string       `xml:"system-out,omitempty"`
+}
+
+type junitResult struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnitToFile writes the report to a file as JUnit XML.
+func (r *Report) WriteJUnitToFile(path string) error {
+	tmpFile, err := os.CreateTemp("", "terragrunt-report-*.xml")
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.SortRuns()
+	r.mu.Unlock()
+
+	if err := r.WriteJUnit(tmpFile); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("failed to close report file: %w", err)
+	}
+
+	if r.workingDir != "" && !filepath.IsAbs(path) {
+		path = filepath.Join(r.workingDir, path)
+	}
+
+	return os.Rename(tmpFile.Name(), path)
+}
+
+// WriteJUnit writes the report to a writer as JUnit XML, each unit being a test case, so that CI systems list the
+// units that failed in their test summaries. Failed units are failures, with their reason and cause as the message,
+// and the units that exited early, or were excluded, are skipped.
+func (r *Report) WriteJUnit(w io.Writer) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	summary := r.Summarize()
+
+	suite := junitTestSuite{
+		Name:  junitSuiteName,
+		Time:  junitSeconds(summary.TotalDuration()),
+		Tests: summary.TotalUnits(),
+		Cases: make([]junitTestCase, 0, len(r.Runs)),
+	}
+
+	if summary.firstRunStart != nil {
+		suite.Timestamp = summary.firstRunStart.UTC().Format(time.RFC3339)
+	}
+
+	for _, run := range r.Runs {
+		run.mu.RLock()
+		defer run.mu.RUnlock()
+
+		testCase := junitTestCase{
+			Name:      nameOfPath(run.Path, r.workingDir),
+			ClassName: "terragrunt",
+			Time:      junitSeconds(run.Ended.Sub(run.Started)),
+		}
+
+		result := &junitResult{Message: string(run.Result)}
+
+		if run.Reason != nil {
+			result.Type = string(*run.Reason)
+			result.Message += ": " + string(*run.Reason)
+		}
+
+		if run.Cause != nil {
+			result.Text = string(*run.Cause)
+			if run.Reason != nil && (*run.Reason == ReasonAncestorError || *run.Reason == ReasonRunHalted) && r.workingDir != "" {
+				result.Text = strings.TrimPrefix(result.Text, r.workingDir+string(os.PathSeparator))
+			}
+		}
+
+		switch run.Result {
+		case ResultFailed:
+			testCase.Failure = result
+			suite.Failures++
+		case ResultEarlyExit, ResultExcluded:
+			testCase.Skipped = result
+			suite.Skipped++
+		}
+
+		if changes := run.Changes; changes != nil {
+			testCase.SystemOut = fmt.Sprintf("%d to add, %d to change, %d to destroy", changes.Add, changes.Change, changes.Destroy)
+		}
+
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	suites := junitTestSuites{
+		Name:     junitSuiteName,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Skipped:  suite.Skipped,
+		Time:     suite.Time,
+		Suites:   []junitTestSuite{suite},
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+
+	return err
+}
+
+// junitSeconds formats the given duration in seconds, as JUnit XML does.
+func junitSeconds(duration time.Duration) string {
+	return fmt.Sprintf("%.3f", duration.Seconds())
+}
@@ -0,0 +1,120 @@
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ReadDurations reads the report previously written to the given path in the given format, and returns how long the
+// units that ran took, by path, the names of the units being relative to the given working dir. Units that didn't run,
+// e.g. excluded units, are left out.
+func ReadDurations(path string, format Format, workingDir string) (map[string]time.Duration, error) {
+	runs, err := readRuns(path, format)
+	if err != nil {
+		return nil, err
+	}
+
+	durations := make(map[string]time.Duration, len(runs))
+
+	for _, run := range runs {
+		if run.Result != string(ResultSucceeded) && run.Result != string(ResultFailed) {
+			continue
+		}
+
+		durations[runPath(run, workingDir)] = run.Ended.Sub(run.Started)
+	}
+
+	return durations, nil
+}
+
+// ReadResults reads the report previously written to the given path in the given format, and returns the results of
+// all the units it reports, by path, the names of the units being relative to the given working dir.
+func ReadResults(path string, format Format, workingDir string) (map[string]Result, error) {
+	runs, err := readRuns(path, format)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[string]Result, len(runs))
+
+	for _, run := range runs {
+		results[runPath(run, workingDir)] = Result(run.Result)
+	}
+
+	return results, nil
+}
+
+// readRuns reads the runs of the report written to the given path in the given format.
+func readRuns(path string, format Format) ([]JSONRun, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close() //nolint:errcheck
+
+	var runs []JSONRun
+
+	switch format {
+	case FormatCSV:
+		runs, err = readCSV(file)
+	case FormatJSON:
+		err = json.NewDecoder(file).Decode(&runs)
+	default:
+		return nil, fmt.Errorf("unsupported format: %s", format)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report %s: %w", path, err)
+	}
+
+	return runs, nil
+}
+
+// runPath returns the path of the unit of the given run, its name being relative to the given working dir.
+func runPath(run JSONRun, workingDir string) string {
+	if filepath.IsAbs(run.Name) {
+		return run.Name
+	}
+
+	return filepath.Join(workingDir, run.Name)
+}
+
+// readCSV reads the runs of a report written in CSV format, see WriteCSV.
+func readCSV(r io.Reader) ([]JSONRun, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	runs := make([]JSONRun, 0, len(records))
+
+	// The first record is the header.
+	for i, record := range records {
+		if i == 0 {
+			continue
+		}
+
+		if len(record) < 4 { //nolint:mnd
+			return nil, fmt.Errorf("invalid record on line %d", i+1)
+		}
+
+		started, err := time.Parse(time.RFC3339, record[1])
+		if err != nil {
+			return nil, err
+		}
+
+		ended, err := time.Parse(time.RFC3339, record[2])
+		if err != nil {
+			return nil, err
+		}
+
+		runs = append(runs, JSONRun{Name: record[0], Started: started, Ended: ended, Result: record[3]})
+	}
+
+	return runs, nil
+}
@@ -15,6 +15,7 @@ type Report struct {
 	workingDir           string
 	format               Format
 	Runs                 []*Run
+	mockedOutputs        []MockedOutputs
 	mu                   sync.RWMutex
 	shouldColor          bool
 	showUnitLevelSummary bool
@@ -26,11 +27,28 @@ type Run struct {
 	Ended   time.Time
 	Reason  *Reason
 	Cause   *Cause
-	Path    string
-	Result  Result
+	// Changes are the numbers of resources the run changed, or plans to change, parsed from the OpenTofu/Terraform
+	// output, if any were reported.
+	Changes *ResourceChanges
+	// Cost is the monthly cost of the resources of the run, estimated by Infracost, if the costs are estimated.
+	Cost   *CostEstimate
+	Path   string
+	Result Result
+	// Retries is the number of times the run was retried.
+	Retries int
 	mu      sync.RWMutex
 }
 
+// MockedOutputs captures the outputs of a dependency of a unit that were mocked instead of being fetched.
+type MockedOutputs struct {
+	// Path is the path of the unit.
+	Path string
+	// Dependency is the name of the dependency block.
+	Dependency string
+	// Outputs are the names of the mocked outputs.
+	Outputs []string
+}
+
 // Result captures the result of a run.
 type Result string
 
@@ -60,9 +78,21 @@ const (
 	ReasonErrorIgnored    Reason = "error ignored"
 	ReasonRunError        Reason = "run error"
 	ReasonExcludeDir      Reason = "--queue-exclude-dir"
+	ReasonExcludeTag      Reason = "--queue-exclude-tag"
+	ReasonIncludeTag      Reason = "--queue-include-tag"
+	ReasonQueueFilter     Reason = "--queue-filter"
+	ReasonIncludeChanged  Reason = "--queue-include-changed"
+	ReasonQueueShard      Reason = "--queue-shard"
+	ReasonIncludeUnit     Reason = "--queue-include-unit"
 	ReasonExcludeBlock    Reason = "exclude block"
 	ReasonExcludeExternal Reason = "--queue-exclude-external"
 	ReasonAncestorError   Reason = "ancestor error"
+	ReasonDependencyError Reason = "dependency error"
+	ReasonRunHalted       Reason = "run halted"
+	ReasonTimeout         Reason = "timeout"
+	ReasonNotApproved     Reason = "group not approved"
+	ReasonApplyWindow     Reason = "apply window"
+	ReasonUnchanged       Reason = "unchanged"
 )
 
 // NewReport creates a new report.
@@ -234,6 +264,14 @@ func (r *Report) EndRun(path string, endOptions ...EndOption) error {
 	return nil
 }
 
+// AddMockedOutputs records outputs of a dependency that were mocked for a unit, to be listed in the summary.
+func (r *Report) AddMockedOutputs(mocked MockedOutputs) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.mockedOutputs = append(r.mockedOutputs, mocked)
+}
+
 func (r *Report) SortRuns() {
 	slices.SortFunc(r.Runs, func(a, b *Run) int {
 		return a.Started.Compare(b.Started)
@@ -289,6 +327,38 @@ func WithCauseAncestorExit(name string) EndOption {
 	return withCause(name)
 }
 
+// WithCauseRunHalted sets the cause of a run to the name of the unit whose failure halted the run.
+//
+// This function is a wrapper around withCause, just to make sure that authors always use consistent
+// reasons for causes.
+func WithCauseRunHalted(name string) EndOption {
+	return withCause(name)
+}
+
+// WithCauseNotApproved sets the cause of a run to the group of the unit that was not approved, e.g. `group 2`.
+//
+// This function is a wrapper around withCause, just to make sure that authors always use consistent
+// reasons for causes.
+func WithCauseNotApproved(group string) EndOption {
+	return withCause(group)
+}
+
+// WithCauseApplyWindow sets the cause of a run to the name of the closed apply window the unit was skipped by.
+//
+// This function is a wrapper around withCause, just to make sure that authors always use consistent
+// reasons for causes.
+func WithCauseApplyWindow(name string) EndOption {
+	return withCause(name)
+}
+
+// WithCauseTimeout sets the cause of a run to the timeout the unit exceeded, e.g. `30m0s`.
+//
+// This function is a wrapper around withCause, just to make sure that authors always use consistent
+// reasons for causes.
+func WithCauseTimeout(timeout string) EndOption {
+	return withCause(timeout)
+}
+
 // WithCauseRunError sets the cause of a run to the name of a particular run error.
 //
 // This function is a wrapper around withCause, just to make sure that authors always use consistent
@@ -297,6 +367,22 @@ func WithCauseRunError(name string) EndOption {
 	return withCause(name)
 }
 
+// WithCauseDependencyError sets the cause of a run to the class of the failure to fetch the outputs of one of its
+// dependencies, e.g. `not applied` or `auth`.
+//
+// This function is a wrapper around withCause, just to make sure that authors always use consistent
+// reasons for causes.
+func WithCauseDependencyError(class string) EndOption {
+	return withCause(class)
+}
+
+// WithRetry counts a retry of a run.
+func WithRetry() EndOption {
+	return func(run *Run) {
+		run.Retries++
+	}
+}
+
 // withCause sets the cause of a run to the name of a particular cause.
 func withCause(name string) EndOption {
 	return func(run *Run) {
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/csv"
 	"encoding/json"
+	"encoding/xml"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -741,13 +742,72 @@ const ExpectedSchema = `{
           "error ignored",
           "run error",
           "--queue-exclude-dir",
+          "--queue-exclude-tag",
+          "--queue-include-tag",
+          "--queue-filter",
+          "--queue-include-changed",
+          "--queue-shard",
+          "--queue-include-unit",
           "exclude block",
-          "ancestor error"
+          "ancestor error",
+          "dependency error",
+          "run halted",
+          "timeout",
+          "group not approved",
+          "apply window",
+          "unchanged"
         ]
       },
       "Cause": {
         "type": "string"
       },
+      "Changes": {
+        "properties": {
+          "Add": {
+            "type": "integer"
+          },
+          "Change": {
+            "type": "integer"
+          },
+          "Destroy": {
+            "type": "integer"
+          },
+          "Import": {
+            "type": "integer"
+          }
+        },
+        "additionalProperties": false,
+        "type": "object",
+        "required": [
+          "Add",
+          "Change",
+          "Destroy"
+        ]
+      },
+      "Cost": {
+        "properties": {
+          "Currency": {
+            "type": "string"
+          },
+          "MonthlyCost": {
+            "type": "number"
+          },
+          "PastMonthlyCost": {
+            "type": "number"
+          },
+          "DiffMonthlyCost": {
+            "type": "number"
+          }
+        },
+        "additionalProperties": false,
+        "type": "object",
+        "required": [
+          "Currency",
+          "MonthlyCost",
+          "PastMonthlyCost",
+          "DiffMonthlyCost"
+        ]
+      },
       "Name": {
         "type": "string"
       },
@@ -759,6 +819,9 @@ const ExpectedSchema = `{
           "early exit",
           "excluded"
         ]
+      },
+      "Retries": {
+        "type": "integer"
       }
     },
     "additionalProperties": false,
@@ -934,6 +997,81 @@ func TestWriteSummary(t *testing.T) {
    Failed       2
    Early Exits  2
    Excluded     2
+`,
+		},
+		{
+			name: "cost estimates",
+			setup: func(r *report.Report) {
+				app := newRun(t, filepath.Join(tmp, "app"))
+				r.AddRun(app)
+				app.SetCost(&report.CostEstimate{Currency: "USD", MonthlyCost: 150, PastMonthlyCost: 100, DiffMonthlyCost: 50})
+				r.EndRun(app.Path)
+
+				db := newRun(t, filepath.Join(tmp, "db"))
+				r.AddRun(db)
+				db.SetCost(&report.CostEstimate{Currency: "USD", MonthlyCost: 80, PastMonthlyCost: 100, DiffMonthlyCost: -20})
+				r.EndRun(db.Path)
+			},
+			expected: `
+❯❯ Run Summary  2 units  x
+   ────────────────────────────
+   Succeeded    2
+   Monthly Cost  +30.00 USD (230.00 USD total)
+`,
+		},
+		{
+			name: "mocked outputs",
+			setup: func(r *report.Report) {
+				r.WithWorkingDir(tmp)
+
+				run := newRun(t, filepath.Join(tmp, "app"))
+				r.AddRun(run)
+				r.EndRun(run.Path)
+
+				r.AddMockedOutputs(report.MockedOutputs{
+					Path:       filepath.Join(tmp, "app"),
+					Dependency: "vpc",
+					Outputs:    []string{"subnet_ids", "vpc_id"},
+				})
+			},
+			expected: `
+❯❯ Run Summary  1 units  x
+   ────────────────────────────
+   Succeeded    1
+   Mocked Outputs (1)
+      app  dependency.vpc: subnet_ids, vpc_id
+`,
+		},
+		{
+			name: "dependency errors",
+			setup: func(r *report.Report) {
+				r.WithWorkingDir(tmp)
+
+				appRun := newRun(t, filepath.Join(tmp, "app"))
+				r.AddRun(appRun)
+				r.EndRun(
+					appRun.Path,
+					report.WithResult(report.ResultFailed),
+					report.WithReason(report.ReasonDependencyError),
+					report.WithCauseDependencyError("auth"),
+				)
+
+				apiRun := newRun(t, filepath.Join(tmp, "api"))
+				r.AddRun(apiRun)
+				r.EndRun(
+					apiRun.Path,
+					report.WithResult(report.ResultFailed),
+					report.WithReason(report.ReasonDependencyError),
+					report.WithCauseDependencyError("not applied"),
+				)
+			},
+			expected: `
+❯❯ Run Summary  2 units  x
+   ────────────────────────────
+   Failed       2
+   Dependency Errors (2)
+      api  not applied
+      app  auth
 `,
 		},
 	}
@@ -1301,6 +1439,246 @@ func TestWriteUnitLevelSummary(t *testing.T) {
 }
 
 // newRun creates a new run, and asserts that it doesn't error.
+func TestReadDurations(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range []report.Format{report.FormatCSV, report.FormatJSON} {
+		t.Run(string(format), func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			r := report.NewReport().WithWorkingDir(dir).WithFormat(format)
+
+			slowRun := newRun(t, filepath.Join(dir, "slow"))
+			slowRun.Started = time.Now().Add(-time.Hour)
+			r.AddRun(slowRun)
+			r.EndRun(slowRun.Path, report.WithResult(report.ResultFailed))
+
+			fastRun := newRun(t, filepath.Join(dir, "fast"))
+			fastRun.Started = time.Now().Add(-time.Minute)
+			r.AddRun(fastRun)
+			r.EndRun(fastRun.Path)
+
+			excludedRun := newRun(t, filepath.Join(dir, "excluded"))
+			r.AddRun(excludedRun)
+			r.EndRun(excludedRun.Path, report.WithResult(report.ResultExcluded))
+
+			reportFile := filepath.Join(dir, "report."+string(format))
+			require.NoError(t, r.WriteToFile(reportFile))
+
+			durations, err := report.ReadDurations(reportFile, format, dir)
+			require.NoError(t, err)
+
+			assert.Len(t, durations, 2)
+			assert.InDelta(t, time.Hour.Seconds(), durations[slowRun.Path].Seconds(), 1)
+			assert.InDelta(t, time.Minute.Seconds(), durations[fastRun.Path].Seconds(), 1)
+		})
+	}
+}
+
+func TestReadResults(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range []report.Format{report.FormatCSV, report.FormatJSON} {
+		t.Run(string(format), func(t *testing.T) {
+			t.Parallel()
+
+			dir := t.TempDir()
+			r := report.NewReport().WithWorkingDir(dir).WithFormat(format)
+
+			expected := map[string]report.Result{}
+
+			for name, result := range map[string]report.Result{
+				"vpc":  report.ResultSucceeded,
+				"db":   report.ResultFailed,
+				"app":  report.ResultEarlyExit,
+				"logs": report.ResultExcluded,
+			} {
+				run := newRun(t, filepath.Join(dir, name))
+				r.AddRun(run)
+				r.EndRun(run.Path, report.WithResult(result))
+
+				expected[run.Path] = result
+			}
+
+			reportFile := filepath.Join(dir, "report."+string(format))
+			require.NoError(t, r.WriteToFile(reportFile))
+
+			results, err := report.ReadResults(reportFile, format, dir)
+			require.NoError(t, err)
+			assert.Equal(t, expected, results)
+		})
+	}
+}
+
+func TestParseResourceChanges(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		line     string
+		expected report.ResourceChanges
+		ok       bool
+	}{
+		{line: "Plan: 2 to add, 1 to change, 0 to destroy.", expected: report.ResourceChanges{Add: 2, Change: 1}, ok: true},
+		{line: "Plan: 1 to import, 2 to add, 0 to change, 3 to destroy.", expected: report.ResourceChanges{Import: 1, Add: 2, Destroy: 3}, ok: true},
+		{line: "\x1b[1mPlan:\x1b[0m 4 to add, 0 to change, 0 to destroy.", expected: report.ResourceChanges{Add: 4}, ok: true},
+		{line: "Apply complete! Resources: 3 added, 2 changed, 1 destroyed.", expected: report.ResourceChanges{Add: 3, Change: 2, Destroy: 1}, ok: true},
+		{line: "Destroy complete! Resources: 5 destroyed.", expected: report.ResourceChanges{Destroy: 5}, ok: true},
+		{line: "No changes. Your infrastructure matches the configuration.", ok: true},
+		{line: "Initializing the backend..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			t.Parallel()
+
+			changes, ok := report.ParseResourceChanges(tt.line)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, changes)
+		})
+	}
+}
+
+func TestChangesWriter(t *testing.T) {
+	t.Parallel()
+
+	run := newRun(t, filepath.Join(t.TempDir(), "unit"))
+
+	var out bytes.Buffer
+
+	w := report.NewChangesWriter(&out, run)
+
+	output := "Plan: 2 to add, 0 to change, 0 to destroy.\n\nApply complete! Resources: 1 added, 0 cha"
+
+	_, err := w.Write([]byte(output))
+	require.NoError(t, err)
+	assert.Equal(t, &report.ResourceChanges{Add: 2}, run.Changes, "the incomplete line is not parsed yet")
+
+	_, err = w.Write([]byte("nged, 0 destroyed.\n"))
+	require.NoError(t, err)
+	assert.Equal(t, &report.ResourceChanges{Add: 1}, run.Changes)
+
+	assert.Equal(t, output+"nged, 0 destroyed.\n", out.String())
+}
+
+func TestWriteHTML(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	r := report.NewReport().WithWorkingDir(dir)
+
+	successRun := newRun(t, filepath.Join(dir, "success-run"))
+	successRun.Changes = &report.ResourceChanges{Add: 3, Change: 2, Destroy: 1}
+	r.AddRun(successRun)
+	r.EndRun(successRun.Path, report.WithRetry())
+
+	failedRun := newRun(t, filepath.Join(dir, "failed-run"))
+	r.AddRun(failedRun)
+	r.EndRun(
+		failedRun.Path,
+		report.WithResult(report.ResultFailed),
+		report.WithReason(report.ReasonRunError),
+		report.WithCauseRunError("exit status 1 <script>"),
+	)
+
+	var buf bytes.Buffer
+	require.NoError(t, r.WriteHTML(&buf))
+
+	html := buf.String()
+
+	assert.Contains(t, html, "<td>success-run</td>")
+	assert.Contains(t, html, `<td class="result succeeded">succeeded</td>`)
+	assert.Contains(t, html, `<td class="number">3</td>`)
+	assert.Contains(t, html, "<td>failed-run</td>")
+	assert.Contains(t, html, `<td class="result failed">failed</td>`)
+	assert.Contains(t, html, "exit status 1 &lt;script&gt;", "the causes are escaped")
+	assert.NotContains(t, html, "<link", "the report is self-contained")
+}
+
+func TestWriteJUnit(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	r := report.NewReport().WithWorkingDir(dir)
+
+	successRun := newRun(t, filepath.Join(dir, "success-run"))
+	successRun.Changes = &report.ResourceChanges{Add: 3, Change: 2, Destroy: 1}
+	r.AddRun(successRun)
+	r.EndRun(successRun.Path)
+
+	failedRun := newRun(t, filepath.Join(dir, "failed-run"))
+	r.AddRun(failedRun)
+	r.EndRun(
+		failedRun.Path,
+		report.WithResult(report.ResultFailed),
+		report.WithReason(report.ReasonRunError),
+		report.WithCauseRunError("exit status 1 <stderr>"),
+	)
+
+	earlyExitRun := newRun(t, filepath.Join(dir, "early-exit-run"))
+	r.AddRun(earlyExitRun)
+	r.EndRun(
+		earlyExitRun.Path,
+		report.WithResult(report.ResultEarlyExit),
+		report.WithReason(report.ReasonAncestorError),
+		report.WithCauseAncestorExit(failedRun.Path),
+	)
+
+	var buf bytes.Buffer
+	require.NoError(t, r.WriteJUnit(&buf))
+
+	var suites struct {
+		Suites []struct {
+			Name  string `xml:"name,attr"`
+			Cases []struct {
+				Failure *struct {
+					Message string `xml:"message,attr"`
+					Text    string `xml:",chardata"`
+				} `xml:"failure"`
+				Skipped *struct {
+					Message string `xml:"message,attr"`
+					Text    string `xml:",chardata"`
+				} `xml:"skipped"`
+				Name      string `xml:"name,attr"`
+				SystemOut string `xml:"system-out"`
+			} `xml:"testcase"`
+			Tests    int `xml:"tests,attr"`
+			Failures int `xml:"failures,attr"`
+			Skipped  int `xml:"skipped,attr"`
+		} `xml:"testsuite"`
+		Tests int `xml:"tests,attr"`
+	}
+
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &suites))
+	require.Len(t, suites.Suites, 1)
+
+	suite := suites.Suites[0]
+	assert.Equal(t, 3, suites.Tests)
+	assert.Equal(t, 3, suite.Tests)
+	assert.Equal(t, 1, suite.Failures)
+	assert.Equal(t, 1, suite.Skipped)
+
+	cases := map[string]int{}
+	for i, testCase := range suite.Cases {
+		cases[testCase.Name] = i
+	}
+
+	success := suite.Cases[cases["success-run"]]
+	assert.Nil(t, success.Failure)
+	assert.Nil(t, success.Skipped)
+	assert.Equal(t, "3 to add, 2 to change, 1 to destroy", success.SystemOut)
+
+	failed := suite.Cases[cases["failed-run"]]
+	require.NotNil(t, failed.Failure)
+	assert.Equal(t, "failed: run error", failed.Failure.Message)
+	assert.Equal(t, "exit status 1 <stderr>", failed.Failure.Text)
+
+	earlyExit := suite.Cases[cases["early-exit-run"]]
+	require.NotNil(t, earlyExit.Skipped)
+	assert.Equal(t, "early exit: ancestor error", earlyExit.Skipped.Message)
+	assert.Equal(t, "failed-run", earlyExit.Skipped.Text, "the causes are relative to the working dir")
+}
+
 func newRun(t *testing.T, name string) *report.Run {
 	t.Helper()
 
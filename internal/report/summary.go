@@ -13,11 +13,14 @@ import (
 
 // Summary formats data from a report for output as a summary.
 type Summary struct {
-	firstRunStart        *time.Time
-	lastRunEnd           *time.Time
-	padder               string
-	workingDir           string
-	runs                 []*Run
+	firstRunStart *time.Time
+	lastRunEnd    *time.Time
+	padder        string
+	workingDir    string
+	runs          []*Run
+	mockedOutputs []MockedOutputs
+	// cost is the total of the cost estimates of the runs, if any run is estimated.
+	cost                 *CostEstimate
 	UnitsSucceeded       int
 	UnitsFailed          int
 	EarlyExits           int
@@ -42,6 +45,7 @@ func (r *Report) Summarize() *Summary {
 		showUnitLevelSummary: r.showUnitLevelSummary,
 		padder:               ".",
 		runs:                 r.Runs,
+		mockedOutputs:        r.mockedOutputs,
 	}
 
 	if os.Getenv(envTmpUndocumentedReportPadder) != "" {
@@ -78,6 +82,16 @@ func (s *Summary) Update(run *Run) {
 		s.Excluded++
 	}
 
+	if run.Cost != nil {
+		if s.cost == nil {
+			s.cost = &CostEstimate{Currency: run.Cost.Currency}
+		}
+
+		s.cost.MonthlyCost += run.Cost.MonthlyCost
+		s.cost.PastMonthlyCost += run.Cost.PastMonthlyCost
+		s.cost.DiffMonthlyCost += run.Cost.DiffMonthlyCost
+	}
+
 	if s.firstRunStart == nil || run.Started.Before(*s.firstRunStart) {
 		s.firstRunStart = &run.Started
 	}
@@ -190,7 +204,15 @@ func (s *Summary) Write(w io.Writer) error {
 		}
 	}
 
-	return nil
+	if err := s.writeCost(w, colorizer); err != nil {
+		return err
+	}
+
+	if err := s.writeDependencyErrors(w, colorizer); err != nil {
+		return err
+	}
+
+	return s.writeMockedOutputs(w, colorizer)
 }
 
 const (
@@ -203,6 +225,9 @@ const (
 	failureLabel               = "Failed"
 	earlyExitLabel             = "Early Exits"
 	excludeLabel               = "Excluded"
+	monthlyCostLabel           = "Monthly Cost"
+	mockedOutputsLabel         = "Mocked Outputs"
+	dependencyErrorsLabel      = "Dependency Errors"
 	separatorLineLength        = 28
 	durationAlignmentOffset    = 4
 	headerUnitCountSpacing     = 2
@@ -343,6 +368,109 @@ func (s *Summary) writeUnitLevelSummary(w io.Writer, colorizer *Colorizer) error
 		}
 	}
 
+	if err := s.writeCost(w, colorizer); err != nil {
+		return err
+	}
+
+	if err := s.writeDependencyErrors(w, colorizer); err != nil {
+		return err
+	}
+
+	return s.writeMockedOutputs(w, colorizer)
+}
+
+// Cost returns the total of the cost estimates of the runs, or nil if no run is estimated.
+func (s *Summary) Cost() *CostEstimate {
+	return s.cost
+}
+
+// writeCost writes the change of the monthly cost the runs make, and the total monthly cost, if any run is estimated.
+func (s *Summary) writeCost(w io.Writer, colorizer *Colorizer) error {
+	if s.cost == nil {
+		return nil
+	}
+
+	value := fmt.Sprintf("%+.2f %s (%.2f %s total)", s.cost.DiffMonthlyCost, s.cost.Currency, s.cost.MonthlyCost, s.cost.Currency)
+
+	return s.writeSummaryEntry(w, monthlyCostLabel, value, colorizer)
+}
+
+// writeDependencyErrors writes the units that failed to fetch the outputs of a dependency, with the class of the
+// failure, so a dependency that isn't applied yet can be told from broken credentials at a glance.
+func (s *Summary) writeDependencyErrors(w io.Writer, colorizer *Colorizer) error {
+	var runs []*Run
+
+	for _, run := range s.runs {
+		if run.Reason != nil && *run.Reason == ReasonDependencyError {
+			runs = append(runs, run)
+		}
+	}
+
+	if len(runs) == 0 {
+		return nil
+	}
+
+	slices.SortFunc(runs, func(a, b *Run) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	if _, err := fmt.Fprintf(w, "%s%s\n", prefix, colorizer.failureColorizer(fmt.Sprintf("%s (%d)", dependencyErrorsLabel, len(runs)))); err != nil {
+		return err
+	}
+
+	for _, run := range runs {
+		name := run.Path
+		if s.workingDir != "" {
+			name = strings.TrimPrefix(name, s.workingDir+string(os.PathSeparator))
+		}
+
+		cause := ""
+		if run.Cause != nil {
+			cause = string(*run.Cause)
+		}
+
+		_, err := fmt.Fprintf(
+			w, "%s%s  %s\n",
+			strings.Repeat(prefix, unitPrefixMultiplier),
+			colorizer.failureUnitColorizer(name),
+			cause,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeMockedOutputs writes the dependency outputs that were mocked, with a line per dependency of a unit.
+func (s *Summary) writeMockedOutputs(w io.Writer, colorizer *Colorizer) error {
+	if len(s.mockedOutputs) == 0 {
+		return nil
+	}
+
+	if _, err := fmt.Fprintf(w, "%s%s\n", prefix, colorizer.exitColorizer(fmt.Sprintf("%s (%d)", mockedOutputsLabel, len(s.mockedOutputs)))); err != nil {
+		return err
+	}
+
+	for _, mocked := range s.mockedOutputs {
+		name := mocked.Path
+		if s.workingDir != "" {
+			name = strings.TrimPrefix(name, s.workingDir+string(os.PathSeparator))
+		}
+
+		_, err := fmt.Fprintf(
+			w, "%s%s  dependency.%s: %s\n",
+			strings.Repeat(prefix, unitPrefixMultiplier),
+			colorizer.exitUnitColorizer(name),
+			mocked.Dependency,
+			strings.Join(mocked.Outputs, ", "),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -20,13 +20,19 @@ type JSONRun struct {
 	// Ended is the time when the run ended.
 	Ended time.Time `json:"Ended" jsonschema:"required"`
 	// Reason is the reason for the run result, if any.
-	Reason *string `json:"Reason,omitempty" jsonschema:"enum=retry succeeded,enum=error ignored,enum=run error,enum=--queue-exclude-dir,enum=exclude block,enum=ancestor error"`
+	Reason *string `json:"Reason,omitempty" jsonschema:"enum=retry succeeded,enum=error ignored,enum=run error,enum=--queue-exclude-dir,enum=--queue-exclude-tag,enum=--queue-include-tag,enum=--queue-filter,enum=--queue-include-changed,enum=--queue-shard,enum=--queue-include-unit,enum=exclude block,enum=ancestor error,enum=dependency error,enum=run halted,enum=timeout,enum=group not approved,enum=apply window,enum=unchanged"`
 	// Cause is the cause of the run result, if any.
 	Cause *string `json:"Cause,omitempty"`
+	// Changes are the numbers of resources the run changed, or plans to change, if OpenTofu/Terraform reported any.
+	Changes *ResourceChanges `json:"Changes,omitempty"`
+	// Cost is the monthly cost of the resources of the run, estimated by Infracost, if the costs are estimated.
+	Cost *CostEstimate `json:"Cost,omitempty"`
 	// Name is the name of the run.
 	Name string `json:"Name" jsonschema:"required"`
 	// Result is the result of the run.
 	Result string `json:"Result" jsonschema:"required,enum=succeeded,enum=failed,enum=early exit,enum=excluded"`
+	// Retries is the number of times the run was retried, if any.
+	Retries int `json:"Retries,omitempty"`
 }
 
 // WriteToFile writes the report to a file.
@@ -103,7 +109,7 @@ func (r *Report) WriteCSV(w io.Writer) error {
 		if run.Cause != nil {
 			cause = string(*run.Cause)
 
-			if reason == string(ReasonAncestorError) && r.workingDir != "" {
+			if (reason == string(ReasonAncestorError) || reason == string(ReasonRunHalted)) && r.workingDir != "" {
 				cause = strings.TrimPrefix(cause, r.workingDir+string(os.PathSeparator))
 			}
 		}
@@ -142,6 +148,9 @@ func (r *Report) WriteJSON(w io.Writer) error {
 			Started: run.Started,
 			Ended:   run.Ended,
 			Result:  string(run.Result),
+			Changes: run.Changes,
+			Cost:    run.Cost,
+			Retries: run.Retries,
 		}
 
 		if run.Reason != nil {
@@ -151,7 +160,7 @@ func (r *Report) WriteJSON(w io.Writer) error {
 
 		if run.Cause != nil {
 			cause := string(*run.Cause)
-			if run.Reason != nil && *run.Reason == ReasonAncestorError && r.workingDir != "" {
+			if run.Reason != nil && (*run.Reason == ReasonAncestorError || *run.Reason == ReasonRunHalted) && r.workingDir != "" {
 				cause = strings.TrimPrefix(cause, r.workingDir+string(os.PathSeparator))
 			}
 
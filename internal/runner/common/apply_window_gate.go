@@ -0,0 +1,121 @@
+package common
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/tf"
+)
+
+// ApplyWindowGate enforces the `apply_window` blocks of the configs of the units of a stack on the runs of apply and
+// destroy of run --all. A unit can only run while all the windows matching it are open.
+type ApplyWindowGate struct {
+	// unitWindows are the windows of each unit, by path.
+	unitWindows map[string]config.ApplyWindows
+	// now returns the current time, replaced in tests.
+	now func() time.Time
+}
+
+// NewApplyWindowGate returns the gate of the apply windows of the given units, whose paths are matched relative to
+// the given working dir. Commands other than apply and destroy don't change the infrastructure, so they are never
+// gated.
+func NewApplyWindowGate(units Units, workingDir, command string) (*ApplyWindowGate, error) {
+	gate := &ApplyWindowGate{
+		unitWindows: map[string]config.ApplyWindows{},
+		now:         time.Now,
+	}
+
+	if command != tf.CommandNameApply && command != tf.CommandNameDestroy {
+		return gate, nil
+	}
+
+	for _, unit := range units {
+		if len(unit.Config.ApplyWindows) == 0 {
+			continue
+		}
+
+		rel, err := filepath.Rel(workingDir, unit.Path)
+		if err != nil {
+			return nil, errors.New(err)
+		}
+
+		for _, window := range unit.Config.ApplyWindows {
+			if window.Matches(filepath.ToSlash(rel), unit.Config.Tags) {
+				gate.unitWindows[unit.Path] = append(gate.unitWindows[unit.Path], window)
+			}
+		}
+	}
+
+	return gate, nil
+}
+
+// WithNow sets the function returning the current time of the gate.
+func (gate *ApplyWindowGate) WithNow(now func() time.Time) *ApplyWindowGate {
+	gate.now = now
+
+	return gate
+}
+
+// Wait returns once all the windows of the given unit are open. If one of them is closed, and its outside mode is
+// skip, it returns the window, the unit being skipped. Otherwise, it waits for the window to open, or for the context
+// to be done.
+func (gate *ApplyWindowGate) Wait(ctx context.Context, l log.Logger, unit *Unit) (*config.ApplyWindow, error) {
+	for {
+		window, opensAt, err := gate.closedWindow(unit)
+		if err != nil || window == nil {
+			return nil, err
+		}
+
+		if window.OutsideMode() == config.ApplyWindowOutsideSkip {
+			l.Warnf("Skipping unit %s, as its apply window %s is closed until %s", unit.Path, window.Name, opensAt.Format(time.RFC3339))
+			return window, nil
+		}
+
+		l.Infof("Unit %s waits for its apply window %s to open at %s", unit.Path, window.Name, opensAt.Format(time.RFC3339))
+
+		timer := time.NewTimer(opensAt.Sub(gate.now()))
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// closedWindow returns a window of the given unit that is closed, with the time it next opens at, or nil if all of
+// them are open. The closed windows skipping the unit are returned first, so the unit doesn't wait for a window only
+// to be skipped by another.
+func (gate *ApplyWindowGate) closedWindow(unit *Unit) (*config.ApplyWindow, time.Time, error) {
+	var (
+		now     = gate.now()
+		closed  *config.ApplyWindow
+		opensAt time.Time
+	)
+
+	for _, window := range gate.unitWindows[unit.Path] {
+		open, windowOpensAt, err := window.OpenAt(now)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+
+		if open {
+			continue
+		}
+
+		if window.OutsideMode() == config.ApplyWindowOutsideSkip {
+			return window, windowOpensAt, nil
+		}
+
+		if closed == nil {
+			closed, opensAt = window, windowOpensAt
+		}
+	}
+
+	return closed, opensAt, nil
+}
@@ -0,0 +1,97 @@
+package common_test
+
+import (
+	"context"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+)
+
+func TestApplyWindowGate(t *testing.T) {
+	t.Parallel()
+
+	workingDir := t.TempDir()
+	wait := config.ApplyWindowOutsideWait
+	windows := config.ApplyWindows{
+		{Name: "weeknights", Schedule: "0 22 * * 1-5", Duration: "6h", Tags: []string{"production"}},
+		{Name: "mornings", Schedule: "0 8 * * *", Duration: "1h", Paths: []string{"shared/**"}, Outside: &wait},
+	}
+
+	newUnit := func(path string, tags []string) *common.Unit {
+		return &common.Unit{
+			Path:   filepath.Join(workingDir, path),
+			Config: config.TerragruntConfig{ApplyWindows: windows, Tags: tags},
+		}
+	}
+
+	prod := newUnit("prod/vpc", []string{"production"})
+	shared := newUnit("shared/dns", nil)
+	dev := newUnit("dev/vpc", nil)
+
+	l := logger.CreateLogger()
+
+	// Wednesday noon.
+	noon := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	gate, err := common.NewApplyWindowGate(common.Units{prod, shared, dev}, workingDir, "apply")
+	require.NoError(t, err)
+
+	gate.WithNow(func() time.Time { return noon })
+
+	window, err := gate.Wait(t.Context(), l, prod)
+	require.NoError(t, err)
+	require.NotNil(t, window)
+	assert.Equal(t, "weeknights", window.Name, "prod is skipped outside its window")
+
+	window, err = gate.Wait(t.Context(), l, dev)
+	require.NoError(t, err)
+	assert.Nil(t, window, "dev has no window")
+
+	ctx, cancel := context.WithTimeout(t.Context(), 100*time.Millisecond)
+	defer cancel()
+
+	_, err = gate.Wait(ctx, l, shared)
+	require.ErrorIs(t, err, context.DeadlineExceeded, "shared waits for its window")
+
+	// The window of shared opens once it waited.
+	var calls atomic.Int32
+
+	gate.WithNow(func() time.Time {
+		if calls.Add(1) == 1 {
+			return time.Date(2025, 1, 1, 7, 59, 59, 0, time.UTC)
+		}
+
+		return time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+	})
+
+	window, err = gate.Wait(t.Context(), l, shared)
+	require.NoError(t, err)
+	assert.Nil(t, window)
+}
+
+func TestApplyWindowGatePlan(t *testing.T) {
+	t.Parallel()
+
+	workingDir := t.TempDir()
+	unit := &common.Unit{
+		Path: filepath.Join(workingDir, "prod", "vpc"),
+		Config: config.TerragruntConfig{ApplyWindows: config.ApplyWindows{
+			{Name: "never", Schedule: "0 0 1 1 *", Duration: "1m"},
+		}},
+	}
+
+	gate, err := common.NewApplyWindowGate(common.Units{unit}, workingDir, "plan")
+	require.NoError(t, err)
+
+	window, err := gate.Wait(t.Context(), logger.CreateLogger(), unit)
+	require.NoError(t, err)
+	assert.Nil(t, window, "plan doesn't change the infrastructure, so it isn't gated")
+}
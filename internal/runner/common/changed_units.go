@@ -0,0 +1,109 @@
+package common
+
+import (
+	"path/filepath"
+
+	"github.com/gruntwork-io/terragrunt/tf"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// UnitsAffectedByChanges returns the paths of the units affected by changes of the given files, given by absolute
+// paths, along with the units depending on them, directly or not. A unit is affected by a changed file if:
+//
+//   - The file is in the dir of the unit, and not in the dir of a unit nested in it.
+//   - The config of the unit includes the file, or reads it with an HCL function, as recorded by didReadFile.
+//   - The source of the unit is a local module dir containing the file.
+func UnitsAffectedByChanges(units Units, changedFiles []string, didReadFile func(file, unitPath string) bool) map[string]bool {
+	affected := make(map[string]bool)
+
+	moduleDirs := make(map[string]string, len(units))
+
+	for _, unit := range units {
+		if dir := localSourceDir(unit); dir != "" {
+			moduleDirs[unit.Path] = dir
+		}
+	}
+
+	for _, file := range changedFiles {
+		file = filepath.Clean(file)
+
+		var owner *Unit
+
+		for _, unit := range units {
+			if util.HasPathPrefix(file, unit.Path) && (owner == nil || len(unit.Path) > len(owner.Path)) {
+				owner = unit
+			}
+
+			if affected[unit.Path] {
+				continue
+			}
+
+			if didReadFile(file, unit.Path) || unit.includesFile(file) {
+				affected[unit.Path] = true
+			} else if dir, ok := moduleDirs[unit.Path]; ok && util.HasPathPrefix(file, dir) {
+				affected[unit.Path] = true
+			}
+		}
+
+		if owner != nil {
+			affected[owner.Path] = true
+		}
+	}
+
+	dependents := make(map[string]Units)
+
+	for _, unit := range units {
+		for _, dependency := range unit.Dependencies {
+			dependents[dependency.Path] = append(dependents[dependency.Path], unit)
+		}
+	}
+
+	queue := make([]string, 0, len(affected))
+	for path := range affected {
+		queue = append(queue, path)
+	}
+
+	for len(queue) > 0 {
+		path := queue[0]
+		queue = queue[1:]
+
+		for _, dependent := range dependents[path] {
+			if !affected[dependent.Path] {
+				affected[dependent.Path] = true
+				queue = append(queue, dependent.Path)
+			}
+		}
+	}
+
+	return affected
+}
+
+// includesFile returns true if the config of the unit includes the given file.
+func (unit *Unit) includesFile(file string) bool {
+	for _, include := range unit.Config.ProcessedIncludes {
+		includePath := include.Path
+		if !filepath.IsAbs(includePath) {
+			includePath = filepath.Join(unit.Path, includePath)
+		}
+
+		if filepath.Clean(includePath) == file {
+			return true
+		}
+	}
+
+	return false
+}
+
+// localSourceDir returns the dir of the module of the unit if its source is local, or an empty string otherwise.
+func localSourceDir(unit *Unit) string {
+	if unit.Config.Terraform == nil || unit.Config.Terraform.Source == nil {
+		return ""
+	}
+
+	sourceURL, err := tf.ToSourceURL(*unit.Config.Terraform.Source, unit.Path)
+	if err != nil || !tf.IsLocalSource(sourceURL) {
+		return ""
+	}
+
+	return filepath.Clean(filepath.FromSlash(sourceURL.Path))
+}
@@ -0,0 +1,99 @@
+package common_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+)
+
+func TestUnitsAffectedByChanges(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+
+	newUnit := func(path string, dependencies ...*common.Unit) *common.Unit {
+		return &common.Unit{Path: filepath.Join(rootDir, path), Dependencies: dependencies}
+	}
+
+	vpcSource := "../../modules//vpc"
+	docsSource := "git::https://github.com/acme/modules.git//docs"
+
+	vpc := newUnit("live/vpc")
+	vpc.Config.Terraform = &config.TerraformConfig{Source: &vpcSource}
+	nested := newUnit("live/vpc/endpoints")
+	db := newUnit("live/db", vpc)
+	app := newUnit("live/app", db)
+	dns := newUnit("live/dns")
+	dns.Config.ProcessedIncludes = config.IncludeConfigsMap{"root": {Name: "root", Path: filepath.Join(rootDir, "root.hcl")}}
+	docs := newUnit("live/docs")
+	docs.Config.Terraform = &config.TerraformConfig{Source: &docsSource}
+
+	units := common.Units{vpc, nested, db, app, dns, docs}
+
+	readFiles := map[string]string{filepath.Join(rootDir, "live", "common.yaml"): docs.Path}
+	didReadFile := func(file, unitPath string) bool {
+		return readFiles[file] == unitPath
+	}
+
+	testCases := []struct {
+		name     string
+		files    []string
+		expected []string
+	}{
+		{
+			name:     "unit dir",
+			files:    []string{"live/db/terragrunt.hcl"},
+			expected: []string{db.Path, app.Path},
+		},
+		{
+			name:     "nested unit dir",
+			files:    []string{"live/vpc/endpoints/terragrunt.hcl"},
+			expected: []string{nested.Path},
+		},
+		{
+			name:     "local module",
+			files:    []string{"modules/vpc/main.tf"},
+			expected: []string{vpc.Path, db.Path, app.Path},
+		},
+		{
+			name:     "other local module",
+			files:    []string{"modules/dns/main.tf"},
+			expected: nil,
+		},
+		{
+			name:     "included config",
+			files:    []string{"root.hcl"},
+			expected: []string{dns.Path},
+		},
+		{
+			name:     "read file",
+			files:    []string{"live/common.yaml"},
+			expected: []string{docs.Path},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			files := make([]string, 0, len(tc.files))
+			for _, file := range tc.files {
+				files = append(files, filepath.Join(rootDir, file))
+			}
+
+			var actual []string
+
+			for path, ok := range common.UnitsAffectedByChanges(units, files, didReadFile) {
+				if ok {
+					actual = append(actual, path)
+				}
+			}
+
+			assert.ElementsMatch(t, tc.expected, actual)
+		})
+	}
+}
@@ -3,7 +3,9 @@ package common
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/gruntwork-io/terragrunt/config"
 	"github.com/gruntwork-io/terragrunt/util"
 	"github.com/pkg/errors"
 )
@@ -81,3 +83,61 @@ type DependencyNotFoundWhileCrossLinkingError struct {
 func (err DependencyNotFoundWhileCrossLinkingError) Error() string {
 	return fmt.Sprintf("Unit %v specifies a dependency on unit %v, but could not find that unit while cross-linking dependencies. This is most likely a bug in Terragrunt. Please report it.", err.Unit, err.Dependency)
 }
+
+type InvalidQueueFilterError struct {
+	Expr   string
+	Reason string
+	Pos    int
+}
+
+func (err InvalidQueueFilterError) Error() string {
+	return fmt.Sprintf("Invalid queue filter %q at position %d: %s", err.Expr, err.Pos+1, err.Reason)
+}
+
+type InvalidQueueShardError struct {
+	Value  string
+	Reason string
+}
+
+func (err InvalidQueueShardError) Error() string {
+	return fmt.Sprintf("Invalid queue shard %q: %s", err.Value, err.Reason)
+}
+
+type RunHaltedError struct {
+	Unit       *Unit
+	FailedUnit *Unit
+	// MaxFailures is the --queue-max-failures the failure of FailedUnit reached, if the run was halted by it rather than
+	// by the halt-run failure policy of FailedUnit.
+	MaxFailures int
+}
+
+func (err RunHaltedError) Error() string {
+	if err.MaxFailures > 0 {
+		return fmt.Sprintf("Unit %s did not run, as the run was halted by the failure of unit %s, %d units having failed, reaching --queue-max-failures.", err.Unit.Path, err.FailedUnit.Path, err.MaxFailures)
+	}
+
+	return fmt.Sprintf("Unit %s did not run, as the run was halted by the failure of unit %s, whose failure policy is %s.", err.Unit.Path, err.FailedUnit.Path, config.FailurePolicyHaltRun)
+}
+
+type UnitTimeoutError struct {
+	Err     error
+	Unit    string
+	Timeout time.Duration
+}
+
+func (err UnitTimeoutError) Error() string {
+	return fmt.Sprintf("Unit %s timed out after %s: %v", err.Unit, err.Timeout, err.Err)
+}
+
+func (err UnitTimeoutError) Unwrap() error {
+	return err.Err
+}
+
+type GroupNotApprovedError struct {
+	Unit  *Unit
+	Group int
+}
+
+func (err GroupNotApprovedError) Error() string {
+	return fmt.Sprintf("Unit %s did not run, as group %d of the run was not approved.", err.Unit.Path, err.Group)
+}
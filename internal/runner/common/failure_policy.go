@@ -0,0 +1,58 @@
+package common
+
+import (
+	"context"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/cloner"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/shell"
+)
+
+const (
+	// The on_failure command gets the same context as the hooks, along with the error of the unit.
+	failureCtxTFPathEnvName  = "TG_CTX_TF_PATH"
+	failureCtxCommandEnvName = "TG_CTX_COMMAND"
+	failureCtxErrorEnvName   = "TG_CTX_ERROR"
+)
+
+// FailurePolicy returns the mode of the failure policy of the unit, see `config.FailurePolicyConfig`: the mode of its
+// failure_policy block, or else the mode of --failure-policy, halt-dependents by default.
+func (unit *Unit) FailurePolicy() string {
+	if unit.Config.FailurePolicy != nil && unit.Config.FailurePolicy.Mode != nil {
+		return *unit.Config.FailurePolicy.Mode
+	}
+
+	if unit.TerragruntOptions != nil && unit.TerragruntOptions.FailurePolicy != "" {
+		return unit.TerragruntOptions.FailurePolicy
+	}
+
+	return config.FailurePolicyHaltDependents
+}
+
+// runOnFailure runs the on_failure command of the failure_policy block of the unit, if any, in the dir of the unit,
+// once the unit failed with the given error. A failure of the command is logged, the unit failing anyway.
+func (unit *Unit) runOnFailure(ctx context.Context, opts *options.TerragruntOptions, unitErr error) {
+	if unit.Config.FailurePolicy == nil || len(unit.Config.FailurePolicy.OnFailure) == 0 {
+		return
+	}
+
+	command := unit.Config.FailurePolicy.OnFailure
+
+	unit.Logger.Infof("Unit %s failed, running its on_failure command %s", unit.Path, command[0])
+
+	hookOpts := *opts
+	hookOpts.Env = cloner.Clone(opts.Env)
+
+	if hookOpts.Env == nil {
+		hookOpts.Env = map[string]string{}
+	}
+
+	hookOpts.Env[failureCtxTFPathEnvName] = opts.TFPath
+	hookOpts.Env[failureCtxCommandEnvName] = opts.TerraformCommand
+	hookOpts.Env[failureCtxErrorEnvName] = unitErr.Error()
+
+	if _, err := shell.RunCommandWithOutput(ctx, unit.Logger, &hookOpts, unit.Path, false, false, command[0], command[1:]...); err != nil {
+		unit.Logger.Errorf("Error running the on_failure command of unit %s: %v", unit.Path, err)
+	}
+}
@@ -0,0 +1,166 @@
+package common
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// ParallelismLimiter enforces the `parallelism_limit` blocks of the configs of the units of a stack, on top of the
+// global parallelism. A limit is shared by all the units defining a limit with the same name, usually included from
+// a root config, and matching its selectors. If the units define the limit differently, the lowest max is used.
+//
+// It also enforces the `mutex_groups` attributes of the configs, each mutex group being a limit of a single unit
+// shared by all the units that are members of the group.
+type ParallelismLimiter struct {
+	// limits are the max number of concurrent units of each limit.
+	limits map[limitKey]int
+	// running are the number of running units of each limit.
+	running map[limitKey]int
+	// unitLimits are the limits of each unit, by path.
+	unitLimits map[string][]limitKey
+	// released is closed, and replaced, whenever units release their limits, to wake up the units waiting for them.
+	released chan struct{}
+	mu       sync.Mutex
+}
+
+// limitKey identifies a limit of the limiter. Mutex groups are kept apart from the parallelism limits, so a group
+// never shares the slots of a limit with the same name.
+type limitKey struct {
+	name       string
+	mutexGroup bool
+}
+
+// NewParallelismLimiter returns the limiter of the parallelism limits of the given units, whose paths are matched
+// relative to the given working dir.
+func NewParallelismLimiter(units Units, workingDir string) (*ParallelismLimiter, error) {
+	limiter := &ParallelismLimiter{
+		limits:     map[limitKey]int{},
+		running:    map[limitKey]int{},
+		unitLimits: map[string][]limitKey{},
+		released:   make(chan struct{}),
+	}
+
+	relPath := func(path string) (string, error) {
+		rel, err := filepath.Rel(workingDir, path)
+		if err != nil {
+			return "", errors.New(err)
+		}
+
+		return filepath.ToSlash(rel), nil
+	}
+
+	for _, unit := range units {
+		for _, group := range unit.Config.MutexGroups {
+			key := limitKey{name: group, mutexGroup: true}
+			limiter.limits[key] = 1
+
+			// A unit listing a group twice holds its single slot once.
+			if !slices.Contains(limiter.unitLimits[unit.Path], key) {
+				limiter.unitLimits[unit.Path] = append(limiter.unitLimits[unit.Path], key)
+			}
+		}
+
+		if len(unit.Config.ParallelismLimits) == 0 {
+			continue
+		}
+
+		unitPath, err := relPath(unit.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		dependencyPaths := make([]string, 0, len(unit.Dependencies))
+
+		for _, dependency := range unit.Dependencies {
+			dependencyPath, err := relPath(dependency.Path)
+			if err != nil {
+				return nil, err
+			}
+
+			dependencyPaths = append(dependencyPaths, dependencyPath)
+		}
+
+		for _, limit := range unit.Config.ParallelismLimits {
+			key := limitKey{name: limit.Name}
+
+			if limitMax, ok := limiter.limits[key]; !ok || limit.Max < limitMax {
+				limiter.limits[key] = limit.Max
+			}
+
+			if limit.Matches(unitPath, unit.Config.Tags, dependencyPaths) {
+				limiter.unitLimits[unit.Path] = append(limiter.unitLimits[unit.Path], key)
+			}
+		}
+	}
+
+	return limiter, nil
+}
+
+// TryAcquire acquires a slot of each limit of the given unit, and returns true, if all of them have one free.
+// Otherwise, no slot is acquired, so units waiting for several limits can't deadlock.
+func (limiter *ParallelismLimiter) TryAcquire(unit *Unit) bool {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	return limiter.tryAcquire(unit)
+}
+
+// Acquire waits until a slot of each limit of the given unit is free and acquires them, or until the context is done.
+func (limiter *ParallelismLimiter) Acquire(ctx context.Context, unit *Unit) error {
+	for {
+		limiter.mu.Lock()
+
+		if limiter.tryAcquire(unit) {
+			limiter.mu.Unlock()
+			return nil
+		}
+
+		released := limiter.released
+		limiter.mu.Unlock()
+
+		select {
+		case <-released:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Release releases the slots of the limits of the given unit, acquired by TryAcquire or Acquire.
+func (limiter *ParallelismLimiter) Release(unit *Unit) {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	keys := limiter.unitLimits[unit.Path]
+	if len(keys) == 0 {
+		return
+	}
+
+	for _, key := range keys {
+		limiter.running[key]--
+	}
+
+	close(limiter.released)
+	limiter.released = make(chan struct{})
+}
+
+// tryAcquire must be called with mu locked.
+func (limiter *ParallelismLimiter) tryAcquire(unit *Unit) bool {
+	keys := limiter.unitLimits[unit.Path]
+
+	for _, key := range keys {
+		if limiter.running[key] >= limiter.limits[key] {
+			return false
+		}
+	}
+
+	for _, key := range keys {
+		limiter.running[key]++
+	}
+
+	return true
+}
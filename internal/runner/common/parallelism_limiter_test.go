@@ -0,0 +1,140 @@
+package common_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+)
+
+func TestParallelismLimiter(t *testing.T) {
+	t.Parallel()
+
+	workingDir := t.TempDir()
+	limits := config.ParallelismLimits{
+		{Name: "networking", Max: 1, Paths: []string{"networking/**"}},
+		{Name: "eks", Max: 2, Tags: []string{"eks"}, DependsOn: []string{"clusters/*"}},
+	}
+
+	newUnit := func(path string, tags []string, dependencies ...*common.Unit) *common.Unit {
+		return &common.Unit{
+			Path:         filepath.Join(workingDir, path),
+			Config:       config.TerragruntConfig{ParallelismLimits: limits, Tags: tags},
+			Dependencies: dependencies,
+		}
+	}
+
+	vpc := newUnit("networking/vpc", nil)
+	dns := newUnit("networking/dns", nil)
+	cluster := newUnit("clusters/main", []string{"eks"})
+	app := newUnit("apps/api", nil, cluster)
+	worker := newUnit("apps/worker", nil, cluster)
+	unlimited := newUnit("apps/docs", nil)
+
+	limiter, err := common.NewParallelismLimiter(common.Units{vpc, dns, cluster, app, worker, unlimited}, workingDir)
+	require.NoError(t, err)
+
+	assert.True(t, limiter.TryAcquire(vpc))
+	assert.False(t, limiter.TryAcquire(dns), "networking allows a single unit")
+
+	assert.True(t, limiter.TryAcquire(cluster))
+	assert.True(t, limiter.TryAcquire(app))
+	assert.False(t, limiter.TryAcquire(worker), "eks allows two units")
+
+	assert.True(t, limiter.TryAcquire(unlimited))
+	assert.True(t, limiter.TryAcquire(unlimited))
+
+	limiter.Release(vpc)
+	assert.True(t, limiter.TryAcquire(dns))
+
+	acquired := make(chan error)
+
+	go func() {
+		acquired <- limiter.Acquire(t.Context(), worker)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("worker acquired eks while two units hold it")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	limiter.Release(app)
+	require.NoError(t, <-acquired)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	require.ErrorIs(t, limiter.Acquire(ctx, app), context.Canceled)
+}
+
+func TestParallelismLimiterLowestMax(t *testing.T) {
+	t.Parallel()
+
+	workingDir := t.TempDir()
+
+	first := &common.Unit{
+		Path: filepath.Join(workingDir, "a"),
+		Config: config.TerragruntConfig{ParallelismLimits: config.ParallelismLimits{
+			{Name: "all", Max: 3, Paths: []string{"**"}},
+		}},
+	}
+	second := &common.Unit{
+		Path: filepath.Join(workingDir, "b"),
+		Config: config.TerragruntConfig{ParallelismLimits: config.ParallelismLimits{
+			{Name: "all", Max: 1, Paths: []string{"**"}},
+		}},
+	}
+
+	limiter, err := common.NewParallelismLimiter(common.Units{first, second}, workingDir)
+	require.NoError(t, err)
+
+	assert.True(t, limiter.TryAcquire(first))
+	assert.False(t, limiter.TryAcquire(second))
+}
+
+func TestParallelismLimiterMutexGroups(t *testing.T) {
+	t.Parallel()
+
+	workingDir := t.TempDir()
+
+	newUnit := func(path string, groups ...string) *common.Unit {
+		return &common.Unit{
+			Path: filepath.Join(workingDir, path),
+			Config: config.TerragruntConfig{
+				MutexGroups: groups,
+				// A parallelism limit with the name of a group doesn't share its slots.
+				ParallelismLimits: config.ParallelismLimits{{Name: "aws-account-123", Max: 10, Paths: []string{"**"}}},
+			},
+		}
+	}
+
+	vpc := newUnit("accounts/123/vpc", "aws-account-123", "aws-account-123")
+	dns := newUnit("accounts/123/dns", "aws-account-123")
+	cluster := newUnit("clusters/prod", "k8s-cluster-prod", "aws-account-456")
+	app := newUnit("apps/api", "k8s-cluster-prod")
+	docs := newUnit("apps/docs")
+
+	limiter, err := common.NewParallelismLimiter(common.Units{vpc, dns, cluster, app, docs}, workingDir)
+	require.NoError(t, err)
+
+	assert.True(t, limiter.TryAcquire(vpc))
+	assert.False(t, limiter.TryAcquire(dns), "aws-account-123 allows a single unit")
+
+	assert.True(t, limiter.TryAcquire(cluster))
+	assert.False(t, limiter.TryAcquire(app), "k8s-cluster-prod allows a single unit")
+
+	assert.True(t, limiter.TryAcquire(docs))
+
+	limiter.Release(vpc)
+	assert.True(t, limiter.TryAcquire(dns))
+
+	limiter.Release(cluster)
+	assert.True(t, limiter.TryAcquire(app))
+}
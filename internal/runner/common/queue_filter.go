@@ -0,0 +1,365 @@
+package common
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/bmatcuk/doublestar"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// Fields of the units that queue filters can match.
+const (
+	QueueFilterFieldTag  = "tag"
+	QueueFilterFieldEnv  = "env"
+	QueueFilterFieldPath = "path"
+	QueueFilterFieldName = "name"
+)
+
+var queueFilterFields = []string{QueueFilterFieldTag, QueueFilterFieldEnv, QueueFilterFieldPath, QueueFilterFieldName}
+
+// QueueFilter is a parsed `--queue-filter` expression, e.g. `tag == "networking" && env != "prod"`, selecting the
+// units of the queue to run by their metadata. Comparisons match a field of the unit against a Unix-style glob, and
+// are combined with `&&`, `||`, `!` and parentheses.
+type QueueFilter struct {
+	root queueFilterNode
+	expr string
+}
+
+// ParseQueueFilter parses the given queue filter expression.
+func ParseQueueFilter(expr string) (*QueueFilter, error) {
+	parser := &queueFilterParser{expr: expr}
+
+	root, err := parser.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := parser.next(); tok.kind != queueFilterTokenEOF {
+		return nil, parser.errorf(tok, "unexpected %s", tok)
+	}
+
+	return &QueueFilter{root: root, expr: expr}, nil
+}
+
+// String returns the expression the filter was parsed from.
+func (filter *QueueFilter) String() string {
+	return filter.expr
+}
+
+// Matches returns true if the given unit, whose path is matched relative to the given working dir, matches the filter.
+func (filter *QueueFilter) Matches(unit *Unit, workingDir string) bool {
+	unitPath := unit.Path
+	if rel, err := filepath.Rel(workingDir, unit.Path); err == nil {
+		unitPath = rel
+	}
+
+	unitPath = filepath.ToSlash(unitPath)
+
+	return filter.root.matches(func(field string) []string {
+		switch field {
+		case QueueFilterFieldTag:
+			return unit.Config.Tags
+		case QueueFilterFieldEnv:
+			return []string{unit.Config.Environment}
+		case QueueFilterFieldPath:
+			return []string{unitPath}
+		case QueueFilterFieldName:
+			return []string{path.Base(unitPath)}
+		}
+
+		return nil
+	})
+}
+
+// queueFilterNode is a node of the syntax tree of a queue filter, matched against the values of the fields of a unit.
+type queueFilterNode interface {
+	matches(values func(field string) []string) bool
+}
+
+type queueFilterAnd struct {
+	left, right queueFilterNode
+}
+
+func (node queueFilterAnd) matches(values func(field string) []string) bool {
+	return node.left.matches(values) && node.right.matches(values)
+}
+
+type queueFilterOr struct {
+	left, right queueFilterNode
+}
+
+func (node queueFilterOr) matches(values func(field string) []string) bool {
+	return node.left.matches(values) || node.right.matches(values)
+}
+
+type queueFilterNot struct {
+	node queueFilterNode
+}
+
+func (node queueFilterNot) matches(values func(field string) []string) bool {
+	return !node.node.matches(values)
+}
+
+// queueFilterComparison matches if any value of the field matches the pattern, e.g. any of the tags of the unit, or,
+// when negated, if none does.
+type queueFilterComparison struct {
+	field   string
+	pattern string
+	negated bool
+}
+
+func (node queueFilterComparison) matches(values func(field string) []string) bool {
+	matched := slices.ContainsFunc(values(node.field), func(value string) bool {
+		ok, _ := doublestar.Match(node.pattern, value)
+		return ok
+	})
+
+	return matched != node.negated
+}
+
+type queueFilterTokenKind int
+
+const (
+	queueFilterTokenEOF queueFilterTokenKind = iota
+	queueFilterTokenInvalid
+	queueFilterTokenIdent
+	queueFilterTokenString
+	queueFilterTokenEqual
+	queueFilterTokenNotEqual
+	queueFilterTokenAnd
+	queueFilterTokenOr
+	queueFilterTokenNot
+	queueFilterTokenLeftParen
+	queueFilterTokenRightParen
+)
+
+type queueFilterToken struct {
+	value string
+	kind  queueFilterTokenKind
+	pos   int
+}
+
+func (tok queueFilterToken) String() string {
+	switch tok.kind {
+	case queueFilterTokenEOF:
+		return "end of expression"
+	case queueFilterTokenString:
+		return fmt.Sprintf("string %q", tok.value)
+	case queueFilterTokenInvalid:
+		return fmt.Sprintf("%q", tok.value)
+	default:
+		return `"` + tok.value + `"`
+	}
+}
+
+// queueFilterParser is a recursive descent parser of queue filters, by precedence from lowest to highest:
+//
+//	or         = and { "||" and }
+//	and        = unary { "&&" unary }
+//	unary      = "!" unary | "(" or ")" | comparison
+//	comparison = field ( "==" | "!=" ) string
+type queueFilterParser struct {
+	peeked *queueFilterToken
+	expr   string
+	pos    int
+}
+
+func (parser *queueFilterParser) parseOr() (queueFilterNode, error) {
+	left, err := parser.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for parser.peek().kind == queueFilterTokenOr {
+		parser.next()
+
+		right, err := parser.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = queueFilterOr{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (parser *queueFilterParser) parseAnd() (queueFilterNode, error) {
+	left, err := parser.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for parser.peek().kind == queueFilterTokenAnd {
+		parser.next()
+
+		right, err := parser.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = queueFilterAnd{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (parser *queueFilterParser) parseUnary() (queueFilterNode, error) {
+	switch tok := parser.next(); tok.kind {
+	case queueFilterTokenNot:
+		node, err := parser.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return queueFilterNot{node: node}, nil
+
+	case queueFilterTokenLeftParen:
+		node, err := parser.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if tok := parser.next(); tok.kind != queueFilterTokenRightParen {
+			return nil, parser.errorf(tok, `expected ")", got %s`, tok)
+		}
+
+		return node, nil
+
+	case queueFilterTokenIdent:
+		return parser.parseComparison(tok)
+
+	default:
+		return nil, parser.errorf(tok, "expected a field, got %s", tok)
+	}
+}
+
+func (parser *queueFilterParser) parseComparison(field queueFilterToken) (queueFilterNode, error) {
+	if !slices.Contains(queueFilterFields, field.value) {
+		return nil, parser.errorf(field, "unknown field %q, expected one of %s", field.value, strings.Join(queueFilterFields, ", "))
+	}
+
+	op := parser.next()
+	if op.kind != queueFilterTokenEqual && op.kind != queueFilterTokenNotEqual {
+		return nil, parser.errorf(op, `expected "==" or "!=", got %s`, op)
+	}
+
+	pattern := parser.next()
+	if pattern.kind != queueFilterTokenString {
+		return nil, parser.errorf(pattern, "expected a quoted string, got %s", pattern)
+	}
+
+	// doublestar only reports malformed patterns when matching reaches them, while path.Match checks the whole
+	// pattern.
+	if _, err := path.Match(pattern.value, ""); err != nil {
+		return nil, parser.errorf(pattern, "invalid glob %s", pattern.value)
+	}
+
+	return queueFilterComparison{
+		field:   field.value,
+		pattern: pattern.value,
+		negated: op.kind == queueFilterTokenNotEqual,
+	}, nil
+}
+
+func (parser *queueFilterParser) peek() queueFilterToken {
+	if parser.peeked == nil {
+		tok := parser.scan()
+		parser.peeked = &tok
+	}
+
+	return *parser.peeked
+}
+
+func (parser *queueFilterParser) next() queueFilterToken {
+	tok := parser.peek()
+	parser.peeked = nil
+
+	return tok
+}
+
+// scan reads the next token of the expression. Unterminated strings and unknown characters are returned as invalid
+// tokens, reported by the parser as unexpected.
+func (parser *queueFilterParser) scan() queueFilterToken {
+	expr := parser.expr
+
+	for parser.pos < len(expr) && (expr[parser.pos] == ' ' || expr[parser.pos] == '\t' || expr[parser.pos] == '\n') {
+		parser.pos++
+	}
+
+	start := parser.pos
+
+	if start >= len(expr) {
+		return queueFilterToken{kind: queueFilterTokenEOF, pos: start}
+	}
+
+	operators := []struct {
+		value string
+		kind  queueFilterTokenKind
+	}{
+		{"==", queueFilterTokenEqual},
+		{"!=", queueFilterTokenNotEqual},
+		{"&&", queueFilterTokenAnd},
+		{"||", queueFilterTokenOr},
+		{"!", queueFilterTokenNot},
+		{"(", queueFilterTokenLeftParen},
+		{")", queueFilterTokenRightParen},
+	}
+
+	for _, op := range operators {
+		if strings.HasPrefix(expr[start:], op.value) {
+			parser.pos += len(op.value)
+			return queueFilterToken{kind: op.kind, value: op.value, pos: start}
+		}
+	}
+
+	switch char := expr[start]; {
+	case char == '"':
+		var value strings.Builder
+
+		for parser.pos++; parser.pos < len(expr); parser.pos++ {
+			switch expr[parser.pos] {
+			case '"':
+				parser.pos++
+				return queueFilterToken{kind: queueFilterTokenString, value: value.String(), pos: start}
+			case '\\':
+				if parser.pos+1 < len(expr) && (expr[parser.pos+1] == '"' || expr[parser.pos+1] == '\\') {
+					parser.pos++
+				}
+			}
+
+			value.WriteByte(expr[parser.pos])
+		}
+
+		return queueFilterToken{kind: queueFilterTokenInvalid, value: expr[start:], pos: start}
+
+	case char == '_' || char >= 'a' && char <= 'z' || char >= 'A' && char <= 'Z':
+		for parser.pos < len(expr) {
+			char := expr[parser.pos]
+			if char != '_' && (char < 'a' || char > 'z') && (char < 'A' || char > 'Z') && (char < '0' || char > '9') {
+				break
+			}
+
+			parser.pos++
+		}
+
+		return queueFilterToken{kind: queueFilterTokenIdent, value: expr[start:parser.pos], pos: start}
+	}
+
+	parser.pos = len(expr)
+
+	return queueFilterToken{kind: queueFilterTokenInvalid, value: expr[start:], pos: start}
+}
+
+func (parser *queueFilterParser) errorf(tok queueFilterToken, format string, args ...any) error {
+	return errors.New(InvalidQueueFilterError{
+		Expr:   parser.expr,
+		Pos:    tok.pos,
+		Reason: fmt.Sprintf(format, args...),
+	})
+}
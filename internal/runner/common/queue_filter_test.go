@@ -0,0 +1,91 @@
+package common_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+)
+
+func TestQueueFilterMatches(t *testing.T) {
+	t.Parallel()
+
+	workingDir := t.TempDir()
+
+	newUnit := func(path, env string, tags ...string) *common.Unit {
+		return &common.Unit{
+			Path:   filepath.Join(workingDir, path),
+			Config: config.TerragruntConfig{Environment: env, Tags: tags},
+		}
+	}
+
+	vpc := newUnit("prod/networking/vpc", "prod", "networking")
+	devVpc := newUnit("dev/networking/vpc", "dev", "networking", "team-core")
+	api := newUnit("dev/apps/api", "dev")
+
+	testCases := []struct {
+		expr     string
+		expected []*common.Unit
+	}{
+		{expr: `tag == "networking"`, expected: []*common.Unit{vpc, devVpc}},
+		{expr: `tag != "networking"`, expected: []*common.Unit{api}},
+		{expr: `tag == "networking" && env != "prod"`, expected: []*common.Unit{devVpc}},
+		{expr: `path == "dev/**" && !(tag == "team-*")`, expected: []*common.Unit{api}},
+		{expr: `name == "vpc" || env == "dev" && tag == "networking"`, expected: []*common.Unit{vpc, devVpc}},
+		{expr: `(name == "vpc" || env == "dev") && tag == "networking"`, expected: []*common.Unit{vpc, devVpc}},
+		{expr: `env == "dev" && (name == "api" || tag == "team-core")`, expected: []*common.Unit{devVpc, api}},
+		{expr: `name == "api"`, expected: []*common.Unit{api}},
+		{expr: `path == "*/networking/*"`, expected: []*common.Unit{vpc, devVpc}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			t.Parallel()
+
+			filter, err := common.ParseQueueFilter(tc.expr)
+			require.NoError(t, err)
+
+			var matched []*common.Unit
+
+			for _, unit := range []*common.Unit{vpc, devVpc, api} {
+				if filter.Matches(unit, workingDir) {
+					matched = append(matched, unit)
+				}
+			}
+
+			assert.Equal(t, tc.expected, matched)
+		})
+	}
+}
+
+func TestParseQueueFilterInvalid(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		expr   string
+		reason string
+	}{
+		{expr: ``, reason: "position 1: expected a field, got end of expression"},
+		{expr: `team == "core"`, reason: `unknown field "team"`},
+		{expr: `tag = "core"`, reason: `position 5: expected "==" or "!=", got "= \"core\""`},
+		{expr: `tag == core`, reason: `expected a quoted string, got "core"`},
+		{expr: `tag == "core`, reason: `expected a quoted string, got "\"core"`},
+		{expr: `(tag == "core"`, reason: `expected ")", got end of expression`},
+		{expr: `tag == "core" env == "prod"`, reason: `unexpected "env"`},
+		{expr: `path == "apps/["`, reason: "invalid glob apps/["},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := common.ParseQueueFilter(tc.expr)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.reason)
+		})
+	}
+}
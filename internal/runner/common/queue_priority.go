@@ -0,0 +1,103 @@
+package common
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/gruntwork-io/terragrunt/config"
+)
+
+// QueuePriority returns the priority of running a unit with the given config in the queue: its `queue_priority`
+// attribute, or else the highest of the priorities of its tags, by tag, or else 0. Of the units ready to run, the
+// units with the highest priority are dispatched first.
+func QueuePriority(cfg *config.TerragruntConfig, tagPriorities map[string]int) int {
+	if cfg == nil {
+		return 0
+	}
+
+	if cfg.QueuePriority != nil {
+		return *cfg.QueuePriority
+	}
+
+	priority, found := 0, false
+
+	for _, tag := range cfg.Tags {
+		if tagPriority, ok := tagPriorities[tag]; ok && (!found || tagPriority > priority) {
+			priority, found = tagPriority, true
+		}
+	}
+
+	return priority
+}
+
+// QueuePriority returns the priority of the unit in the queue, see QueuePriority.
+func (unit *Unit) QueuePriority() int {
+	var tagPriorities map[string]int
+
+	if unit.TerragruntOptions != nil {
+		tagPriorities = unit.TerragruntOptions.QueueTagPriorities
+	}
+
+	return QueuePriority(&unit.Config, tagPriorities)
+}
+
+// PrioritySemaphore limits the number of units running at once, like a buffered channel, except that a freed slot
+// goes to the waiting unit with the highest priority, the first one to wait on ties, rather than to any of them.
+type PrioritySemaphore struct {
+	waiting []*prioritySemaphoreWaiter
+	free    int
+	mu      sync.Mutex
+}
+
+type prioritySemaphoreWaiter struct {
+	acquired chan struct{}
+	priority int
+}
+
+// NewPrioritySemaphore returns a semaphore of the given number of slots.
+func NewPrioritySemaphore(size int) *PrioritySemaphore {
+	return &PrioritySemaphore{free: size}
+}
+
+// Acquire waits until a slot is free and acquires it.
+func (semaphore *PrioritySemaphore) Acquire(priority int) {
+	semaphore.mu.Lock()
+
+	if semaphore.free > 0 && len(semaphore.waiting) == 0 {
+		semaphore.free--
+		semaphore.mu.Unlock()
+
+		return
+	}
+
+	waiter := &prioritySemaphoreWaiter{acquired: make(chan struct{}), priority: priority}
+	semaphore.waiting = append(semaphore.waiting, waiter)
+	semaphore.mu.Unlock()
+
+	<-waiter.acquired
+}
+
+// Release releases a slot acquired by Acquire, handing it over to the waiting unit with the highest priority, if any.
+func (semaphore *PrioritySemaphore) Release() {
+	semaphore.mu.Lock()
+	defer semaphore.mu.Unlock()
+
+	if len(semaphore.waiting) == 0 {
+		semaphore.free++
+		return
+	}
+
+	// The waiters are in the order they started to wait in, so the first one of the highest priority wins ties.
+	next := 0
+
+	for i, waiter := range semaphore.waiting {
+		if waiter.priority > semaphore.waiting[next].priority {
+			next = i
+		}
+	}
+
+	waiter := semaphore.waiting[next]
+	semaphore.waiting = slices.Delete(semaphore.waiting, next, next+1)
+
+	close(waiter.acquired)
+}
@@ -0,0 +1,66 @@
+package common_test
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+)
+
+func TestQueuePriority(t *testing.T) {
+	t.Parallel()
+
+	priority := -1
+	tagPriorities := map[string]int{"critical": 10, "slow": 3}
+
+	assert.Equal(t, 0, common.QueuePriority(nil, tagPriorities))
+	assert.Equal(t, 0, common.QueuePriority(&config.TerragruntConfig{Tags: []string{"network"}}, tagPriorities))
+	assert.Equal(t, 10, common.QueuePriority(&config.TerragruntConfig{Tags: []string{"slow", "critical"}}, tagPriorities))
+	assert.Equal(t, -1, common.QueuePriority(&config.TerragruntConfig{QueuePriority: &priority, Tags: []string{"critical"}}, tagPriorities))
+	assert.Equal(t, -5, common.QueuePriority(&config.TerragruntConfig{Tags: []string{"cleanup"}}, map[string]int{"cleanup": -5}))
+}
+
+func TestPrioritySemaphore(t *testing.T) {
+	t.Parallel()
+
+	semaphore := common.NewPrioritySemaphore(1)
+	semaphore.Acquire(0)
+
+	var (
+		waitGroup sync.WaitGroup
+		mu        sync.Mutex
+		acquired  []int
+	)
+
+	for _, priority := range []int{1, 5, 3, 5} {
+		waitGroup.Add(1)
+
+		go func() {
+			defer waitGroup.Done()
+
+			semaphore.Acquire(priority)
+
+			mu.Lock()
+			acquired = append(acquired, priority)
+			mu.Unlock()
+
+			semaphore.Release()
+		}()
+
+		// Let the unit start waiting before the next one.
+		time.Sleep(20 * time.Millisecond)
+	}
+
+	semaphore.Release()
+	waitGroup.Wait()
+
+	assert.Equal(t, []int{5, 5, 3, 1}, acquired)
+
+	// The slot is free again.
+	semaphore.Acquire(0)
+	semaphore.Release()
+}
@@ -0,0 +1,69 @@
+package common
+
+// QueueSelection selects the given units, by path, along with the units they depend on and the units depending on
+// them, up to the given depths, for the `--queue-include-unit`, `--queue-include-dependencies` and
+// `--queue-include-dependents` flags. A depth of 0 selects none of them, and a negative depth selects all the levels.
+type QueueSelection struct {
+	Units             []string
+	DependenciesDepth int
+	DependentsDepth   int
+}
+
+// Includes returns the paths of the given units that are selected.
+func (selection *QueueSelection) Includes(units Units) map[string]bool {
+	included := make(map[string]bool)
+
+	var selected Units
+
+	for _, unit := range units {
+		if unit.FindUnitInPath(selection.Units) {
+			included[unit.Path] = true
+			selected = append(selected, unit)
+		}
+	}
+
+	walkUnits(selected, selection.DependenciesDepth, included, func(unit *Unit) Units {
+		return unit.Dependencies
+	})
+
+	dependents := make(map[string]Units)
+
+	for _, unit := range units {
+		for _, dependency := range unit.Dependencies {
+			dependents[dependency.Path] = append(dependents[dependency.Path], unit)
+		}
+	}
+
+	walkUnits(selected, selection.DependentsDepth, included, func(unit *Unit) Units {
+		return dependents[unit.Path]
+	})
+
+	return included
+}
+
+// walkUnits adds the units reached from the given units through the given edges, up to the given depth, to included.
+func walkUnits(units Units, depth int, included map[string]bool, next func(unit *Unit) Units) {
+	visited := make(map[string]bool, len(units))
+
+	for _, unit := range units {
+		visited[unit.Path] = true
+	}
+
+	for level := 1; len(units) > 0 && (depth < 0 || level <= depth); level++ {
+		var reached Units
+
+		for _, unit := range units {
+			for _, nextUnit := range next(unit) {
+				if visited[nextUnit.Path] {
+					continue
+				}
+
+				visited[nextUnit.Path] = true
+				included[nextUnit.Path] = true
+				reached = append(reached, nextUnit)
+			}
+		}
+
+		units = reached
+	}
+}
@@ -0,0 +1,80 @@
+package common_test
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+)
+
+func TestQueueSelectionIncludes(t *testing.T) {
+	t.Parallel()
+
+	// A chain of units, vpc <- db <- app <- dns, with db also depending on kms, and logs on its own.
+	vpc := &common.Unit{Path: "/live/vpc"}
+	kms := &common.Unit{Path: "/live/kms"}
+	db := &common.Unit{Path: "/live/db", Dependencies: common.Units{vpc, kms}}
+	app := &common.Unit{Path: "/live/app", Dependencies: common.Units{db}}
+	dns := &common.Unit{Path: "/live/dns", Dependencies: common.Units{app}}
+	logs := &common.Unit{Path: "/live/logs"}
+
+	units := common.Units{app, db, dns, kms, logs, vpc}
+
+	testCases := []struct {
+		name      string
+		selection common.QueueSelection
+		expected  []string
+	}{
+		{
+			name:      "units only",
+			selection: common.QueueSelection{Units: []string{"/live/db"}},
+			expected:  []string{"/live/db"},
+		},
+		{
+			name:      "all dependencies",
+			selection: common.QueueSelection{Units: []string{"/live/app"}, DependenciesDepth: -1},
+			expected:  []string{"/live/app", "/live/db", "/live/kms", "/live/vpc"},
+		},
+		{
+			name:      "dependencies one level deep",
+			selection: common.QueueSelection{Units: []string{"/live/app"}, DependenciesDepth: 1},
+			expected:  []string{"/live/app", "/live/db"},
+		},
+		{
+			name:      "all dependents",
+			selection: common.QueueSelection{Units: []string{"/live/vpc"}, DependentsDepth: -1},
+			expected:  []string{"/live/app", "/live/db", "/live/dns", "/live/vpc"},
+		},
+		{
+			name:      "dependents two levels deep",
+			selection: common.QueueSelection{Units: []string{"/live/vpc"}, DependentsDepth: 2},
+			expected:  []string{"/live/app", "/live/db", "/live/vpc"},
+		},
+		{
+			name:      "dependencies and dependents",
+			selection: common.QueueSelection{Units: []string{"/live/db"}, DependenciesDepth: -1, DependentsDepth: 1},
+			expected:  []string{"/live/app", "/live/db", "/live/kms", "/live/vpc"},
+		},
+		{
+			name:      "several units",
+			selection: common.QueueSelection{Units: []string{"/live/db", "/live/logs"}, DependentsDepth: 1},
+			expected:  []string{"/live/app", "/live/db", "/live/logs"},
+		},
+		{
+			name:      "no matching unit",
+			selection: common.QueueSelection{Units: []string{"/live/missing"}, DependenciesDepth: -1, DependentsDepth: -1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			included := tc.selection.Includes(units)
+			assert.Equal(t, tc.expected, slices.Sorted(maps.Keys(included)))
+		})
+	}
+}
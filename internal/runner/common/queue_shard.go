@@ -0,0 +1,158 @@
+package common
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// QueueShard is a parsed `--queue-shard` value, e.g. `2/4`, selecting the shard of the queue a CI job runs, so that a
+// large stack can be split across parallel jobs.
+//
+// The queue is partitioned by the groups of units connected by their dependencies: a unit is always in the same shard
+// as the units it depends on and the units depending on it, so each shard runs in dependency order on its own, without
+// waiting for the other shards. The groups are assigned to the shards the largest first, each to the shard with the
+// fewest units so far, which is deterministic given the same units, whatever the job.
+type QueueShard struct {
+	// Index is the index of the shard, from 1 to Count.
+	Index int
+	Count int
+}
+
+// ParseQueueShard parses the given queue shard, of the form `<index>/<count>`.
+func ParseQueueShard(value string) (*QueueShard, error) {
+	indexStr, countStr, ok := strings.Cut(value, "/")
+	if !ok {
+		return nil, errors.New(InvalidQueueShardError{Value: value, Reason: "expected <index>/<count>, e.g. 1/4"})
+	}
+
+	index, err := strconv.Atoi(strings.TrimSpace(indexStr))
+	if err != nil {
+		return nil, errors.New(InvalidQueueShardError{Value: value, Reason: "the index must be a number"})
+	}
+
+	count, err := strconv.Atoi(strings.TrimSpace(countStr))
+	if err != nil || count < 1 {
+		return nil, errors.New(InvalidQueueShardError{Value: value, Reason: "the count must be a positive number"})
+	}
+
+	if index < 1 || index > count {
+		return nil, errors.New(InvalidQueueShardError{Value: value, Reason: "the index must be between 1 and the count"})
+	}
+
+	return &QueueShard{Index: index, Count: count}, nil
+}
+
+// String returns the shard in the form it is parsed from.
+func (shard *QueueShard) String() string {
+	return strconv.Itoa(shard.Index) + "/" + strconv.Itoa(shard.Count)
+}
+
+// Includes returns the paths of the units of the given units in the shard. The units flagged as excluded, or assumed
+// already applied, don't count in the sizes of the groups, and are in no shard.
+func (shard *QueueShard) Includes(units Units) map[string]bool {
+	// Union-find of the units connected by their dependencies, by path.
+	parents := make(map[string]string, len(units))
+
+	var find func(path string) string
+
+	find = func(path string) string {
+		parent, ok := parents[path]
+		if !ok || parent == path {
+			parents[path] = path
+			return path
+		}
+
+		root := find(parent)
+		parents[path] = root
+
+		return root
+	}
+
+	union := func(a, b string) {
+		rootA, rootB := find(a), find(b)
+		if rootA == rootB {
+			return
+		}
+
+		// The smallest path is the root, so the groups are the same whatever the order of the units.
+		if rootB < rootA {
+			rootA, rootB = rootB, rootA
+		}
+
+		parents[rootB] = rootA
+	}
+
+	for _, unit := range units {
+		find(unit.Path)
+
+		for _, dependency := range unit.Dependencies {
+			union(unit.Path, dependency.Path)
+		}
+	}
+
+	type unitGroup struct {
+		root  string
+		paths []string
+	}
+
+	groupsByRoot := make(map[string]*unitGroup)
+
+	for _, unit := range units {
+		if unit.FlagExcluded || unit.AssumeAlreadyApplied {
+			continue
+		}
+
+		root := find(unit.Path)
+
+		group, ok := groupsByRoot[root]
+		if !ok {
+			group = &unitGroup{root: root}
+			groupsByRoot[root] = group
+		}
+
+		group.paths = append(group.paths, unit.Path)
+	}
+
+	groups := make([]*unitGroup, 0, len(groupsByRoot))
+	for _, group := range groupsByRoot {
+		groups = append(groups, group)
+	}
+
+	slices.SortFunc(groups, func(a, b *unitGroup) int {
+		if diff := cmp.Compare(len(b.paths), len(a.paths)); diff != 0 {
+			return diff
+		}
+
+		return strings.Compare(a.root, b.root)
+	})
+
+	sizes := make([]int, shard.Count)
+	included := make(map[string]bool)
+
+	for _, group := range groups {
+		// The shard with the fewest units, the first one on ties.
+		index := 0
+
+		for i, size := range sizes {
+			if size < sizes[index] {
+				index = i
+			}
+		}
+
+		sizes[index] += len(group.paths)
+
+		if index+1 != shard.Index {
+			continue
+		}
+
+		for _, path := range group.paths {
+			included[path] = true
+		}
+	}
+
+	return included
+}
@@ -0,0 +1,60 @@
+package common_test
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+)
+
+func TestParseQueueShard(t *testing.T) {
+	t.Parallel()
+
+	shard, err := common.ParseQueueShard("2/4")
+	require.NoError(t, err)
+	assert.Equal(t, &common.QueueShard{Index: 2, Count: 4}, shard)
+	assert.Equal(t, "2/4", shard.String())
+
+	for _, value := range []string{"", "2", "0/4", "5/4", "1/0", "one/4", "1/four"} {
+		_, err := common.ParseQueueShard(value)
+		require.Error(t, err, value)
+	}
+}
+
+func TestQueueShardIncludes(t *testing.T) {
+	t.Parallel()
+
+	// Three groups of connected units: vpc, db and app, of 3 units, dns and cdn, of 2 units, and logs, of 1 unit.
+	vpc := &common.Unit{Path: "/live/vpc"}
+	db := &common.Unit{Path: "/live/db", Dependencies: common.Units{vpc}}
+	app := &common.Unit{Path: "/live/app", Dependencies: common.Units{db}}
+	dns := &common.Unit{Path: "/live/dns"}
+	cdn := &common.Unit{Path: "/live/cdn", Dependencies: common.Units{dns}}
+	logs := &common.Unit{Path: "/live/logs"}
+	excluded := &common.Unit{Path: "/live/excluded", FlagExcluded: true}
+
+	units := common.Units{app, cdn, db, dns, excluded, logs, vpc}
+
+	shards := make([][]string, 2)
+
+	for i := range shards {
+		included := (&common.QueueShard{Index: i + 1, Count: 2}).Includes(units)
+		shards[i] = slices.Sorted(maps.Keys(included))
+	}
+
+	assert.Equal(t, []string{"/live/app", "/live/db", "/live/vpc"}, shards[0])
+	assert.Equal(t, []string{"/live/cdn", "/live/dns", "/live/logs"}, shards[1])
+
+	// The shards don't depend on the order of the units.
+	slices.Reverse(units)
+
+	included := (&common.QueueShard{Index: 2, Count: 2}).Includes(units)
+	assert.Equal(t, shards[1], slices.Sorted(maps.Keys(included)))
+
+	// More shards than groups leave the last shards empty.
+	assert.Empty(t, (&common.QueueShard{Index: 4, Count: 4}).Includes(units))
+}
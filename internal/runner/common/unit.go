@@ -115,6 +115,17 @@ func (unit *Unit) FindUnitInPath(targetDirs []string) bool {
 	return slices.Contains(targetDirs, unit.Path)
 }
 
+// HasAnyTag returns true if the unit is tagged with at least one of the given tags
+func (unit *Unit) HasAnyTag(tags []string) bool {
+	for _, tag := range unit.Config.Tags {
+		if slices.Contains(tags, tag) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // getDependenciesForUnit Get the list of units this unit depends on
 func (unit *Unit) getDependenciesForUnit(unitsMap UnitsMap, terragruntConfigPaths []string) (Units, error) {
 	dependencies := Units{}
@@ -197,7 +208,7 @@ func (unitsMap UnitsMap) CrossLinkDependencies(canonicalTerragruntConfigPaths []
 // WriteDot is used to emit a GraphViz compatible definition
 // for a directed graph. It can be used to dump a .dot file.
 // This is a similar implementation to terraform's digraph https://github.com/hashicorp/terraform/blob/v1.5.7/internal/dag/dag.go
-// adding some styling to units that are excluded from the execution in *-all commands
+// adding some styling to units that are excluded from the execution in *-all commands, and labeling the tagged units
 func (units Units) WriteDot(l log.Logger, w io.Writer, opts *options.TerragruntOptions) error {
 	if _, err := w.Write([]byte("digraph {\n")); err != nil {
 		return errors.New(err)
@@ -213,10 +224,19 @@ func (units Units) WriteDot(l log.Logger, w io.Writer, opts *options.TerragruntO
 	prefix := filepath.Dir(opts.TerragruntConfigPath) + "/"
 
 	for _, source := range units {
-		// apply a different coloring for excluded nodes
-		style := ""
+		// apply a different coloring for excluded nodes, and label the nodes with their tags
+		attrs := []string{}
 		if source.FlagExcluded {
-			style = "[color=red]"
+			attrs = append(attrs, "color=red")
+		}
+
+		if len(source.Config.Tags) > 0 {
+			attrs = append(attrs, fmt.Sprintf("xlabel=%q", strings.Join(source.Config.Tags, ", ")))
+		}
+
+		style := ""
+		if len(attrs) > 0 {
+			style = "[" + strings.Join(attrs, ", ") + "]"
 		}
 
 		nodeLine := fmt.Sprintf("\t\"%s\" %s;\n",
@@ -252,6 +272,11 @@ func (units Units) CheckForCycles() error {
 	for _, unit := range units {
 		err := checkForCyclesUsingDepthFirstSearch(unit, &visitedPaths, &currentTraversalPaths)
 		if err != nil {
+			var cycleErr DependencyCycleError
+			if errors.As(err, &cycleErr) {
+				return config.NewDependencyCycleDiagnosticsError(err, units.cycleEdges(cycleErr))
+			}
+
 			return err
 		}
 	}
@@ -259,6 +284,61 @@ func (units Units) CheckForCycles() error {
 	return nil
 }
 
+// cycleEdges returns the edges of the given dependency cycle, with the `dependency` or `dependencies` block creating
+// each of them.
+func (units Units) cycleEdges(cycle DependencyCycleError) []config.DependencyCycleEdge {
+	// The cycle starts at the first occurrence of the path it ends with.
+	start := slices.Index(cycle, cycle[len(cycle)-1])
+
+	dependents := make(map[string]int)
+
+	for _, unit := range units {
+		for _, dependency := range unit.Dependencies {
+			dependents[dependency.Path]++
+		}
+	}
+
+	edges := make([]config.DependencyCycleEdge, 0, len(cycle)-start-1)
+
+	for i := start; i < len(cycle)-1; i++ {
+		edge := config.DependencyCycleEdge{
+			From:       cycle[i],
+			To:         cycle[i+1],
+			Dependents: dependents[cycle[i]],
+		}
+
+		if unit := units.findByPath(cycle[i]); unit != nil {
+			configPath := config.GetDefaultConfigPath(unit.Path)
+			if unit.TerragruntOptions != nil && unit.TerragruntOptions.TerragruntConfigPath != "" {
+				configPath = unit.TerragruntOptions.TerragruntConfigPath
+			}
+
+			edge.Declaration = unit.Config.FindDependencyDeclaration(configPath, cycle[i+1])
+		}
+
+		edges = append(edges, edge)
+	}
+
+	return edges
+}
+
+// findByPath returns the unit with the given path, looking up the dependencies of the units too.
+func (units Units) findByPath(path string) *Unit {
+	for _, unit := range units {
+		if unit.Path == path {
+			return unit
+		}
+
+		for _, dependency := range unit.Dependencies {
+			if dependency.Path == path {
+				return dependency
+			}
+		}
+	}
+
+	return nil
+}
+
 // SortedKeys Return the keys for the given map in sorted order. This is used to ensure we always iterate over maps of units
 // in a consistent order (Go does not guarantee iteration order for maps, and usually makes it random)
 func (unitsMap UnitsMap) SortedKeys() []string {
@@ -0,0 +1,177 @@
+package common
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+const fingerprintCheckContextKey ctxKey = iota
+
+type ctxKey byte
+
+// fingerprintLockFile is the lock file of the providers, the only hidden file of the working dir in the fingerprint, as
+// it pins the versions of the providers.
+const fingerprintLockFile = ".terraform.lock.hcl"
+
+// fingerprintSkippedSuffixes are the suffixes of the files of the working dir left out of the fingerprint, as the runs
+// of the unit write them.
+var fingerprintSkippedSuffixes = []string{".tfstate", ".tfstate.backup", ".tfplan"}
+
+// FingerprintCheck checks the fingerprint of a unit, once its config is parsed, against its fingerprint of its last
+// successful apply, to skip the unit if it is unchanged.
+type FingerprintCheck struct {
+	// configPath is the config of the unit, as the runs of other units, e.g. to fetch the outputs of the dependencies
+	// of the unit, are made with the same context.
+	configPath string
+	// previous is the fingerprint of the last successful apply of the unit, if any.
+	previous string
+	// fingerprint is the fingerprint of the unit computed by the check.
+	fingerprint string
+	// skippable is false if the unit must run even if it is unchanged, e.g. as one of its dependencies changed.
+	skippable bool
+	skipped   bool
+}
+
+// NewFingerprintCheck returns the check of the fingerprint of the unit of the given config against the given
+// fingerprint of its last successful apply. The unit is only skipped if skippable is set.
+func NewFingerprintCheck(configPath, previous string, skippable bool) *FingerprintCheck {
+	return &FingerprintCheck{
+		configPath: configPath,
+		previous:   previous,
+		skippable:  skippable,
+	}
+}
+
+// ContextWithFingerprintCheck returns a new context containing the given check.
+func ContextWithFingerprintCheck(ctx context.Context, check *FingerprintCheck) context.Context {
+	return context.WithValue(ctx, fingerprintCheckContextKey, check)
+}
+
+// FingerprintCheckFromContext returns the check of the fingerprint of the unit if the given context contains it.
+func FingerprintCheckFromContext(ctx context.Context) *FingerprintCheck {
+	if val := ctx.Value(fingerprintCheckContextKey); val != nil {
+		if val, ok := val.(*FingerprintCheck); ok {
+			return val
+		}
+	}
+
+	return nil
+}
+
+// Skip computes the fingerprint of the unit of the given config, run with the given options, and returns true if the
+// unit is skippable and its fingerprint is the one of its last successful apply. It returns false for the runs of the
+// other units.
+func (check *FingerprintCheck) Skip(configPath string, opts *options.TerragruntOptions, cfg *config.TerragruntConfig) (bool, error) {
+	if configPath != check.configPath {
+		return false, nil
+	}
+
+	fingerprint, err := UnitFingerprint(opts, cfg)
+	if err != nil {
+		return false, err
+	}
+
+	check.fingerprint = fingerprint
+	check.skipped = check.skippable && check.previous != "" && fingerprint == check.previous
+
+	return check.skipped, nil
+}
+
+// Fingerprint returns the fingerprint of the unit, or an empty string if the run of the unit failed before computing
+// it.
+func (check *FingerprintCheck) Fingerprint() string {
+	return check.fingerprint
+}
+
+// Skipped returns true if the unit was skipped as it is unchanged.
+func (check *FingerprintCheck) Skipped() bool {
+	return check.skipped
+}
+
+// UnitFingerprint returns the fingerprint of a unit, once its source is downloaded, its files generated and its inputs
+// merged: the hash of its inputs, of the `-var` and `-var-file` arguments, of the version of OpenTofu/Terraform, and of
+// the files of its working dir, including the lock file of the providers. The state and plan files, the other hidden
+// files and directories, e.g. `.terraform`, and the directories of the nested units are skipped.
+func UnitFingerprint(opts *options.TerragruntOptions, cfg *config.TerragruntConfig) (string, error) {
+	hash := sha256.New()
+
+	inputs, err := json.Marshal(cfg.Inputs)
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	fmt.Fprintf(hash, "inputs\x00%d\x00", len(inputs))
+	hash.Write(inputs)
+
+	for _, arg := range opts.TerraformCliArgs {
+		if strings.HasPrefix(arg, "-var") {
+			fmt.Fprintf(hash, "arg\x00%s\x00", arg)
+		}
+	}
+
+	if opts.TerraformVersion != nil {
+		fmt.Fprintf(hash, "version\x00%s\x00%s\x00", opts.TerraformImplementation, opts.TerraformVersion)
+	}
+
+	configName := filepath.Base(opts.TerragruntConfigPath)
+
+	err = filepath.WalkDir(opts.WorkingDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if path == opts.WorkingDir {
+			return nil
+		}
+
+		if d.IsDir() {
+			if strings.HasPrefix(d.Name(), ".") || util.FileExists(filepath.Join(path, configName)) {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if (strings.HasPrefix(d.Name(), ".") && d.Name() != fingerprintLockFile) || !d.Type().IsRegular() {
+			return nil
+		}
+
+		for _, suffix := range fingerprintSkippedSuffixes {
+			if strings.HasSuffix(d.Name(), suffix) {
+				return nil
+			}
+		}
+
+		rel, err := filepath.Rel(opts.WorkingDir, path)
+		if err != nil {
+			return err
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(hash, "file\x00%s\x00%d\x00", filepath.ToSlash(rel), len(content))
+		hash.Write(content)
+
+		return nil
+	})
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,147 @@
+package common_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/go-version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+func TestUnitFingerprint(t *testing.T) {
+	t.Parallel()
+
+	workingDir := t.TempDir()
+
+	for path, content := range map[string]string{
+		"terragrunt.hcl":         "",
+		"main.tf":                `resource "null_resource" "this" {}`,
+		"modules/x/main.tf":      "",
+		".terraform.lock.hcl":    `provider "registry.opentofu.org/hashicorp/null" {}`,
+		".terraform/plugins/foo": "",
+		"terraform.tfstate":      "{}",
+		"nested/terragrunt.hcl":  "",
+		"nested/main.tf":         "",
+		"backend_generated.tf":   `terraform { backend "local" {} }`,
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(workingDir, path)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(workingDir, path), []byte(content), 0644))
+	}
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(workingDir, "terragrunt.hcl"))
+	require.NoError(t, err)
+
+	opts.WorkingDir = workingDir
+	opts.TerraformCliArgs = []string{"apply", "-var-file=prod.tfvars"}
+	opts.TerraformImplementation = options.OpenTofuImpl
+	opts.TerraformVersion = version.Must(version.NewVersion("1.10.0"))
+
+	cfg := &config.TerragruntConfig{Inputs: map[string]any{"name": "app", "count": 2}}
+
+	fingerprint, err := common.UnitFingerprint(opts, cfg)
+	require.NoError(t, err)
+
+	same, err := common.UnitFingerprint(opts, &config.TerragruntConfig{Inputs: map[string]any{"count": 2, "name": "app"}})
+	require.NoError(t, err)
+	assert.Equal(t, fingerprint, same, "the order of the inputs doesn't matter")
+
+	// The state, the hidden files other than the lock file, and the nested units don't change the fingerprint.
+	for _, path := range []string{"terraform.tfstate", ".terraform/plugins/foo", "nested/main.tf"} {
+		require.NoError(t, os.WriteFile(filepath.Join(workingDir, path), []byte("changed"), 0644))
+	}
+
+	unchanged, err := common.UnitFingerprint(opts, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, fingerprint, unchanged)
+
+	changes := map[string]func(t *testing.T) (*options.TerragruntOptions, *config.TerragruntConfig){
+		"inputs": func(t *testing.T) (*options.TerragruntOptions, *config.TerragruntConfig) {
+			return opts, &config.TerragruntConfig{Inputs: map[string]any{"name": "app", "count": 3}}
+		},
+		"var file argument": func(t *testing.T) (*options.TerragruntOptions, *config.TerragruntConfig) {
+			changed := opts.Clone()
+			changed.TerraformCliArgs = []string{"apply", "-var-file=stage.tfvars"}
+
+			return changed, cfg
+		},
+		"version": func(t *testing.T) (*options.TerragruntOptions, *config.TerragruntConfig) {
+			changed := opts.Clone()
+			changed.TerraformVersion = version.Must(version.NewVersion("1.11.0"))
+
+			return changed, cfg
+		},
+		"source": func(t *testing.T) (*options.TerragruntOptions, *config.TerragruntConfig) {
+			changed := opts.Clone()
+			changed.WorkingDir = t.TempDir()
+
+			require.NoError(t, os.WriteFile(filepath.Join(changed.WorkingDir, "main.tf"), []byte(""), 0644))
+
+			return changed, cfg
+		},
+	}
+
+	for name, change := range changes {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			changedOpts, changedCfg := change(t)
+
+			changed, err := common.UnitFingerprint(changedOpts, changedCfg)
+			require.NoError(t, err)
+			assert.NotEqual(t, fingerprint, changed)
+		})
+	}
+}
+
+func TestFingerprintCheck(t *testing.T) {
+	t.Parallel()
+
+	workingDir := t.TempDir()
+	configPath := filepath.Join(workingDir, "terragrunt.hcl")
+
+	opts, err := options.NewTerragruntOptionsForTest(configPath)
+	require.NoError(t, err)
+
+	opts.WorkingDir = workingDir
+
+	cfg := &config.TerragruntConfig{Inputs: map[string]any{"name": "app"}}
+
+	fingerprint, err := common.UnitFingerprint(opts, cfg)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name       string
+		configPath string
+		previous   string
+		skippable  bool
+		expected   bool
+	}{
+		{name: "unchanged", configPath: configPath, previous: fingerprint, skippable: true, expected: true},
+		{name: "changed", configPath: configPath, previous: "other", skippable: true},
+		{name: "never applied", configPath: configPath, skippable: true},
+		{name: "not skippable", configPath: configPath, previous: fingerprint},
+		{name: "other unit", configPath: filepath.Join(workingDir, "other", "terragrunt.hcl"), previous: fingerprint, skippable: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			check := common.NewFingerprintCheck(configPath, tc.previous, tc.skippable)
+			ctx := common.ContextWithFingerprintCheck(t.Context(), check)
+
+			skip, err := common.FingerprintCheckFromContext(ctx).Skip(tc.configPath, opts, cfg)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, skip)
+			assert.Equal(t, tc.expected, check.Skipped())
+		})
+	}
+
+	assert.Nil(t, common.FingerprintCheckFromContext(t.Context()))
+}
@@ -0,0 +1,149 @@
+package common
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// ContentHash returns the hash of the files of the unit, and of the configs it includes, by their path relative to the
+// unit. Hidden files and directories, e.g. `.terragrunt-cache` or `.terraform`, and the files of the given units nested
+// in the unit are skipped, so only changes to the content of the unit change its hash.
+func (unit *Unit) ContentHash(units Units) (string, error) {
+	unitPaths := make(map[string]bool, len(units))
+	for _, other := range units {
+		unitPaths[other.Path] = true
+	}
+
+	hash := sha256.New()
+
+	addFile := func(path string) error {
+		rel, err := filepath.Rel(unit.Path, path)
+		if err != nil {
+			return errors.New(err)
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return errors.New(err)
+		}
+
+		fmt.Fprintf(hash, "%s\x00%d\x00", filepath.ToSlash(rel), len(content))
+		hash.Write(content)
+
+		return nil
+	}
+
+	err := filepath.WalkDir(unit.Path, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if path == unit.Path {
+			return nil
+		}
+
+		if strings.HasPrefix(d.Name(), ".") || (d.IsDir() && unitPaths[path]) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		return addFile(path)
+	})
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	includePaths := []string{}
+
+	for _, include := range unit.Config.ProcessedIncludes {
+		includePath, err := util.CanonicalPath(include.Path, unit.Path)
+		if err != nil {
+			return "", err
+		}
+
+		if !strings.HasPrefix(includePath, unit.Path+string(filepath.Separator)) {
+			includePaths = append(includePaths, includePath)
+		}
+	}
+
+	slices.Sort(includePaths)
+
+	for _, includePath := range includePaths {
+		if err := addFile(includePath); err != nil {
+			return "", err
+		}
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// ConfigHash returns the hash of the Terragrunt config of the unit, of the configs it includes, and of its inputs, if
+// its config was parsed with them. Unlike ContentHash, the other files of the unit are left out, so the files written
+// into the unit by its runs, e.g. the outputs of its generate blocks or its backend config, don't change its hash.
+func (unit *Unit) ConfigHash() (string, error) {
+	hash := sha256.New()
+
+	addFile := func(path string) error {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return errors.New(err)
+		}
+
+		fmt.Fprintf(hash, "file\x00%s\x00%d\x00", filepath.Base(path), len(content))
+		hash.Write(content)
+
+		return nil
+	}
+
+	if err := addFile(unit.TerragruntOptions.TerragruntConfigPath); err != nil {
+		return "", err
+	}
+
+	includePaths := []string{}
+
+	for _, include := range unit.Config.ProcessedIncludes {
+		includePath, err := util.CanonicalPath(include.Path, unit.Path)
+		if err != nil {
+			return "", err
+		}
+
+		includePaths = append(includePaths, includePath)
+	}
+
+	slices.Sort(includePaths)
+
+	for _, includePath := range includePaths {
+		if err := addFile(includePath); err != nil {
+			return "", err
+		}
+	}
+
+	if unit.Config.Inputs != nil {
+		inputs, err := json.Marshal(unit.Config.Inputs)
+		if err != nil {
+			return "", errors.New(err)
+		}
+
+		fmt.Fprintf(hash, "inputs\x00%d\x00", len(inputs))
+		hash.Write(inputs)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/internal/experiment"
 	"github.com/gruntwork-io/terragrunt/internal/report"
 	"github.com/gruntwork-io/terragrunt/options"
@@ -42,6 +43,20 @@ func NewUnitRunner(unit *Unit) *UnitRunner {
 func (runner *UnitRunner) runTerragrunt(ctx context.Context, opts *options.TerragruntOptions, r *report.Report) error {
 	runner.Unit.Logger.Debugf("Running %s", runner.Unit.Path)
 
+	if opts.Experiments.Evaluate(experiment.Report) {
+		run, err := report.NewRun(runner.Unit.Path)
+		if err != nil {
+			return err
+		}
+
+		if err := r.AddRun(run); err != nil {
+			return err
+		}
+
+		// Record the numbers of changed resources OpenTofu/Terraform reports in the run.
+		opts.Writer = report.NewChangesWriter(opts.Writer, run)
+	}
+
 	opts.Writer = NewUnitWriter(opts.Writer)
 
 	defer func() {
@@ -50,18 +65,29 @@ func (runner *UnitRunner) runTerragrunt(ctx context.Context, opts *options.Terra
 		runner.Unit.FlushOutput() //nolint:errcheck
 	}()
 
-	if opts.Experiments.Evaluate(experiment.Report) {
-		run, err := report.NewRun(runner.Unit.Path)
-		if err != nil {
-			return err
-		}
+	runCtx := ctx
 
-		if err := r.AddRun(run); err != nil {
-			return err
+	timeout := runner.Unit.RunTimeout()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+
+		runCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	if err := opts.RunTerragrunt(runCtx, runner.Unit.Logger, opts, r); err != nil {
+		// A command interrupted at the deadline fails with its own error, so the deadline of the context is checked
+		// rather than the error.
+		if timeout > 0 && errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+			err = runner.Unit.timedOut(runCtx, opts, r, timeout, err)
 		}
+
+		runner.Unit.runOnFailure(ctx, opts, err)
+
+		return err
 	}
 
-	return opts.RunTerragrunt(ctx, runner.Unit.Logger, opts, r)
+	return nil
 }
 
 // Run a unit right now by executing the runTerragrunt command of its TerragruntOptions field.
@@ -3,8 +3,12 @@ package common_test
 import (
 	"bytes"
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/gruntwork-io/terragrunt/config"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
 
 	"github.com/stretchr/testify/require"
@@ -13,6 +17,7 @@ import (
 	"github.com/gruntwork-io/terragrunt/internal/runner/common"
 	"github.com/gruntwork-io/terragrunt/options"
 	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/shell"
 	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
 
 	"github.com/stretchr/testify/assert"
@@ -66,6 +71,66 @@ func TestUnitRunner_Run_ErrorFromRunTerragrunt(t *testing.T) {
 	assert.Contains(t, err.Error(), "fail")
 }
 
+func TestUnitRunner_Run_OnFailure(t *testing.T) {
+	t.Parallel()
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	opts.Writer = &bytes.Buffer{}
+	opts.RunTerragrunt = func(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, r *report.Report) error {
+		return errors.New("fail")
+	}
+
+	unit := newMockUnit()
+	unit.Path = t.TempDir()
+	unit.TerragruntOptions = opts
+	unit.Config.FailurePolicy = &config.FailurePolicyConfig{
+		OnFailure: []string{"sh", "-c", `echo "$TG_CTX_ERROR" > failed.txt`},
+	}
+
+	runner := common.NewUnitRunner(unit)
+	err = runner.Run(t.Context(), opts, &report.Report{})
+	require.Error(t, err)
+
+	out, err := os.ReadFile(filepath.Join(unit.Path, "failed.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "fail\n", string(out))
+}
+
+func TestUnitRunner_Run_Timeout(t *testing.T) {
+	t.Parallel()
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	opts.Writer = &bytes.Buffer{}
+	opts.WorkingDir = t.TempDir()
+	opts.UnitTimeoutGracePeriod = 1
+	opts.RunTerragrunt = func(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, r *report.Report) error {
+		// Ignore the interrupt, so the command has to be killed at the end of the grace period.
+		return shell.RunCommand(ctx, l, opts, "sh", "-c", "trap '' INT; exec sleep 30")
+	}
+
+	runTimeoutSec := 1
+
+	unit := newMockUnit()
+	unit.Path = opts.WorkingDir
+	unit.TerragruntOptions = opts
+	unit.Config.RunTimeoutSec = &runTimeoutSec
+
+	start := time.Now()
+
+	runner := common.NewUnitRunner(unit)
+	err = runner.Run(t.Context(), opts, &report.Report{})
+	require.Error(t, err)
+
+	var timeoutErr common.UnitTimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	assert.Equal(t, time.Second, timeoutErr.Timeout)
+	assert.Less(t, time.Since(start), 10*time.Second, "the command should be killed at the end of the grace period")
+}
+
 func TestUnitRunner_Run_Success(t *testing.T) {
 	t.Parallel()
 
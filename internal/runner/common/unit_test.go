@@ -145,6 +145,22 @@ func TestUnits_WriteDot(t *testing.T) {
 	assert.Contains(t, out, "[color=red]")
 }
 
+func TestUnits_WriteDotTags(t *testing.T) {
+	t.Parallel()
+	units := common.Units{
+		&common.Unit{Path: "a", Config: config.TerragruntConfig{Tags: []string{"networking", "prod"}}},
+		&common.Unit{Path: "b", Config: config.TerragruntConfig{Tags: []string{"prod"}}, FlagExcluded: true},
+	}
+	var buf bytes.Buffer
+	opts := &options.TerragruntOptions{TerragruntConfigPath: "/foo/terragrunt.hcl"}
+	l := logger.CreateLogger()
+	err := units.WriteDot(l, &buf, opts)
+	require.NoError(t, err)
+	out := buf.String()
+	assert.Contains(t, out, `"a" [xlabel="networking, prod"];`)
+	assert.Contains(t, out, `"b" [color=red, xlabel="prod"];`)
+}
+
 func TestUnits_CheckForCycles(t *testing.T) {
 	t.Parallel()
 	unitA := &common.Unit{Path: "a"}
@@ -0,0 +1,53 @@
+package common
+
+import (
+	"context"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/experiment"
+	"github.com/gruntwork-io/terragrunt/internal/report"
+	"github.com/gruntwork-io/terragrunt/options"
+)
+
+// RunTimeout returns the timeout on running the unit, set by its `run_timeout_sec` attribute, or else by
+// --unit-timeout. A zero timeout means the unit runs without timeout.
+func (unit *Unit) RunTimeout() time.Duration {
+	if unit.Config.RunTimeoutSec != nil {
+		return time.Duration(*unit.Config.RunTimeoutSec) * time.Second
+	}
+
+	if unit.TerragruntOptions != nil {
+		return time.Duration(unit.TerragruntOptions.UnitTimeout) * time.Second
+	}
+
+	return 0
+}
+
+// timedOut returns the error of the unit that failed at its timeout, with the given context whose deadline is the
+// timeout, recording the timeout as the reason of its failure in the report. The commands still running at the
+// deadline are interrupted, giving OpenTofu/Terraform the chance to release the state lock, and killed after the grace
+// period. Terragrunt doesn't release the lock of a killed command itself, it only logs how to release it.
+func (unit *Unit) timedOut(ctx context.Context, opts *options.TerragruntOptions, r *report.Report, timeout time.Duration, unitErr error) error {
+	unit.Logger.Errorf("Unit %s timed out after %s", unit.Path, timeout)
+
+	// A killed OpenTofu/Terraform has no chance to release the lock, which then has to be released manually.
+	if deadline, ok := ctx.Deadline(); ok && opts.UnitTimeoutGracePeriod > 0 {
+		if gracePeriod := time.Duration(opts.UnitTimeoutGracePeriod) * time.Second; !time.Now().Before(deadline.Add(gracePeriod)) {
+			unit.Logger.Warnf("OpenTofu/Terraform was killed in unit %s, the state may still be locked: release the lock with `terragrunt force-unlock` if so", unit.Path)
+		}
+	}
+
+	if opts.Experiments.Evaluate(experiment.Report) {
+		if err := r.EndRun(
+			unit.Path,
+			report.WithResult(report.ResultFailed),
+			report.WithReason(report.ReasonTimeout),
+			report.WithCauseTimeout(timeout.String()),
+		); err != nil {
+			unit.Logger.Errorf("Error ending run for unit %s: %v", unit.Path, err)
+		}
+	}
+
+	return errors.New(UnitTimeoutError{Err: unitErr, Unit: unit.Path, Timeout: timeout})
+}
@@ -2,6 +2,7 @@ package configstack
 
 import (
 	"context"
+	"slices"
 
 	"github.com/gruntwork-io/terragrunt/config"
 	"github.com/gruntwork-io/terragrunt/internal/runner/common"
@@ -16,14 +17,22 @@ func Build(ctx context.Context, l log.Logger, terragruntOptions *options.Terragr
 
 	err := telemetry.TelemeterFromContext(ctx).Collect(ctx, "find_files_in_path", map[string]any{
 		"working_dir": terragruntOptions.WorkingDir,
+		"stack_dirs":  terragruntOptions.StackDirs,
 	}, func(_ context.Context) error {
-		result, err := config.FindConfigFilesInPath(terragruntOptions.WorkingDir, terragruntOptions)
-		if err != nil {
-			return err
+		// The units of all the stacks are resolved together, so the dependencies between them aren't external.
+		for _, dir := range stackDirs(terragruntOptions) {
+			result, err := config.FindConfigFilesInPath(dir, terragruntOptions)
+			if err != nil {
+				return err
+			}
+
+			for _, file := range result {
+				if !slices.Contains(terragruntConfigFiles, file) {
+					terragruntConfigFiles = append(terragruntConfigFiles, file)
+				}
+			}
 		}
 
-		terragruntConfigFiles = result
-
 		return nil
 	})
 
@@ -38,3 +47,13 @@ func Build(ctx context.Context, l log.Logger, terragruntOptions *options.Terragr
 
 	return runner, nil
 }
+
+// stackDirs returns the dirs to find the units of the stack in: the dirs of the stacks passed in the stack CLI flag, if
+// any, or the working dir.
+func stackDirs(opts *options.TerragruntOptions) []string {
+	if len(opts.StackDirs) > 0 {
+		return opts.StackDirs
+	}
+
+	return []string{opts.WorkingDir}
+}
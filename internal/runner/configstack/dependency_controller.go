@@ -1,10 +1,17 @@
 package configstack
 
 import (
+	"cmp"
 	"context"
+	"fmt"
+	"maps"
+	"slices"
 	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 
+	"github.com/gruntwork-io/terragrunt/config"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/internal/experiment"
 	"github.com/gruntwork-io/terragrunt/internal/report"
@@ -32,6 +39,9 @@ type DependencyController struct {
 	DependencyDone chan *DependencyController
 	Dependencies   map[string]*DependencyController
 	NotifyWhenDone []*DependencyController
+	// skippedWindow is the closed apply window the unit was skipped for, either its own or the one of a dependency, so
+	// that its dependents are skipped too, rather than run against a unit that wasn't run.
+	skippedWindow *config.ApplyWindow
 }
 
 // NewDependencyController Create a new dependency controller for the given unit.
@@ -45,18 +55,68 @@ func NewDependencyController(unit *common.Unit) *DependencyController {
 }
 
 // runUnitWhenReady a unit once all of its dependencies have finished executing.
-func (ctrl *DependencyController) runUnitWhenReady(ctx context.Context, opts *options.TerragruntOptions, r *report.Report, semaphore chan struct{}) {
+func (ctrl *DependencyController) runUnitWhenReady(ctx context.Context, opts *options.TerragruntOptions, r *report.Report, semaphore *common.PrioritySemaphore, limiter *common.ParallelismLimiter, windows *common.ApplyWindowGate, halt *runHalt, gate *stageGate) {
+	defer gate.finished(ctrl.Runner.Unit)
+
+	var skippedDependency *DependencyController
+
 	err := telemetry.TelemeterFromContext(ctx).Collect(ctx, "wait_for_unit_ready", map[string]any{
 		"path":             ctrl.Runner.Unit.Path,
 		"terraformCommand": ctrl.Runner.Unit.TerragruntOptions.TerraformCommand,
 	}, func(_ context.Context) error {
-		return ctrl.waitForDependencies(opts, r)
+		var err error
+
+		skippedDependency, err = ctrl.waitForDependencies(opts, r)
+
+		return err
 	})
 
-	semaphore <- struct{}{} // Add one to the buffered channel. Will block if parallelism limit is met
-	defer func() {
-		<-semaphore // Remove one from the buffered channel
-	}()
+	// The units whose dependencies were skipped outside their apply windows are skipped too.
+	if err == nil && skippedDependency != nil {
+		ctrl.dependencySkipped(opts, r, skippedDependency)
+		ctrl.unitFinished(nil, r, opts.Experiments.Evaluate(experiment.Report))
+
+		return
+	}
+
+	// The group of the unit is approved before the unit holds any slot, as the units of the previous groups have to
+	// finish first.
+	if err == nil && !gate.wait(ctx, ctrl.Runner.Unit.Logger, opts, ctrl.Runner.Unit) {
+		err = ctrl.runNotApproved(opts, r, gate.levels[ctrl.Runner.Unit.Path]+1)
+	}
+
+	// The units outside their apply windows are skipped, or wait for the windows to open, before they hold any slot.
+	if err == nil {
+		window, windowErr := windows.Wait(ctx, ctrl.Runner.Unit.Logger, ctrl.Runner.Unit)
+		if window != nil {
+			ctrl.skippedWindow = window
+			ctrl.outsideApplyWindow(opts, r, window)
+			ctrl.unitFinished(nil, r, opts.Experiments.Evaluate(experiment.Report))
+
+			return
+		}
+
+		err = windowErr
+	}
+
+	// The parallelism limits of the unit are acquired before the global parallelism, so units waiting for their limits
+	// don't hold slots other units could run in.
+	if err == nil {
+		if err = limiter.Acquire(ctx, ctrl.Runner.Unit); err == nil {
+			defer limiter.Release(ctrl.Runner.Unit)
+		}
+	}
+
+	// Will block if parallelism limit is met, the freed slots going to the waiting units of the highest priority.
+	semaphore.Acquire(ctrl.Runner.Unit.QueuePriority())
+	defer semaphore.Release()
+
+	// The units that haven't started yet when the run is halted don't run.
+	if err == nil {
+		if halted := halt.halted.Load(); halted != nil {
+			err = ctrl.runHalted(opts, r, halted)
+		}
+	}
 
 	if err == nil {
 		err = telemetry.TelemeterFromContext(ctx).Collect(ctx, "run_unit", map[string]any{
@@ -65,14 +125,19 @@ func (ctrl *DependencyController) runUnitWhenReady(ctx context.Context, opts *op
 		}, func(ctx context.Context) error {
 			return ctrl.Runner.Run(ctx, opts, r)
 		})
+
+		if err != nil {
+			halt.unitFailed(ctrl.Runner.Unit)
+		}
 	}
 
 	ctrl.unitFinished(err, r, opts.Experiments.Evaluate(experiment.Report))
 }
 
 // waitForDependencies for all of this unit's dependencies to finish executing. Return an error if any of those dependencies complete
-// with an error. Return immediately if this unit has no dependencies.
-func (ctrl *DependencyController) waitForDependencies(opts *options.TerragruntOptions, r *report.Report) error {
+// with an error, or one of the dependencies skipped outside their apply windows. Return immediately if this unit has no
+// dependencies.
+func (ctrl *DependencyController) waitForDependencies(opts *options.TerragruntOptions, r *report.Report) (*DependencyController, error) {
 	ctrl.Runner.Unit.Logger.Debugf("Unit %s must wait for %d dependencies to finish", ctrl.Runner.Unit.Path, len(ctrl.Dependencies))
 
 	handleDependencyError := func(doneDependency *DependencyController) error {
@@ -81,6 +146,12 @@ func (ctrl *DependencyController) waitForDependencies(opts *options.TerragruntOp
 			return nil
 		}
 
+		// A dependency that exited early didn't run, so its failure policy doesn't apply.
+		if doneDependency.Runner.Unit.FailurePolicy() == config.FailurePolicyContinue && !isEarlyExit(doneDependency.Runner.Err) {
+			ctrl.Runner.Unit.Logger.Errorf("Dependency %s of unit %s just finished with an error. Normally, unit %s would exit early, however, because the failure policy of %s is %s, unit %s will run anyway.", doneDependency.Runner.Unit.Path, ctrl.Runner.Unit.Path, ctrl.Runner.Unit.Path, doneDependency.Runner.Unit.Path, config.FailurePolicyContinue, ctrl.Runner.Unit.Path)
+			return nil
+		}
+
 		ctrl.Runner.Unit.Logger.Errorf("Dependency %s of unit %s just finished with an error. Unit %s will have to return an error too.", doneDependency.Runner.Unit.Path, ctrl.Runner.Unit.Path, ctrl.Runner.Unit.Path)
 
 		if opts.Experiments.Evaluate(experiment.Report) {
@@ -104,20 +175,169 @@ func (ctrl *DependencyController) waitForDependencies(opts *options.TerragruntOp
 		return common.ProcessingUnitDependencyError{Unit: ctrl.Runner.Unit, Dependency: doneDependency.Runner.Unit, Err: doneDependency.Runner.Err}
 	}
 
+	var skippedDependency *DependencyController
+
 	for len(ctrl.Dependencies) > 0 {
 		doneDependency := <-ctrl.DependencyDone
 		delete(ctrl.Dependencies, doneDependency.Runner.Unit.Path)
 
-		if doneDependency.Runner.Err != nil {
+		switch {
+		case doneDependency.Runner.Err != nil:
 			if err := handleDependencyError(doneDependency); err != nil {
-				return err
+				return nil, err
+			}
+		case doneDependency.skippedWindow != nil:
+			ctrl.Runner.Unit.Logger.Debugf("Dependency %s of unit %s was skipped outside its apply window. Unit %s must wait on %d more dependencies.", doneDependency.Runner.Unit.Path, ctrl.Runner.Unit.Path, ctrl.Runner.Unit.Path, len(ctrl.Dependencies))
+
+			if skippedDependency == nil {
+				skippedDependency = doneDependency
 			}
-		} else {
+		default:
 			ctrl.Runner.Unit.Logger.Debugf("Dependency %s of unit %s just finished successfully. Unit %s must wait on %d more dependencies.", doneDependency.Runner.Unit.Path, ctrl.Runner.Unit.Path, ctrl.Runner.Unit.Path, len(ctrl.Dependencies))
 		}
 	}
 
-	return nil
+	return skippedDependency, nil
+}
+
+// runHalt halts a run once a unit with the halt-run failure policy failed, or once --queue-max-failures units failed,
+// the units that haven't started yet not running.
+type runHalt struct {
+	// halted is the error of the units that don't run once the run is halted, without their unit, if it is halted.
+	halted      atomic.Pointer[common.RunHaltedError]
+	failures    atomic.Int64
+	maxFailures int
+}
+
+// unitFailed records the failure of the given unit, halting the run if the failure policy of the unit is halt-run, or
+// if the failure reaches the max failures.
+func (halt *runHalt) unitFailed(unit *common.Unit) {
+	failures := halt.failures.Add(1)
+
+	if unit.FailurePolicy() == config.FailurePolicyHaltRun {
+		unit.Logger.Errorf("Unit %s failed, halting the run as its failure policy is %s.", unit.Path, config.FailurePolicyHaltRun)
+		halt.halted.CompareAndSwap(nil, &common.RunHaltedError{FailedUnit: unit})
+
+		return
+	}
+
+	if halt.maxFailures > 0 && failures >= int64(halt.maxFailures) {
+		if halt.halted.CompareAndSwap(nil, &common.RunHaltedError{FailedUnit: unit, MaxFailures: halt.maxFailures}) {
+			unit.Logger.Errorf("Unit %s failed, halting the run as %d units failed, reaching --queue-max-failures.", unit.Path, failures)
+		}
+	}
+}
+
+// runHalted records that the unit exits early, as the run was halted by the failure of a unit, and returns the error
+// of the unit.
+func (ctrl *DependencyController) runHalted(opts *options.TerragruntOptions, r *report.Report, halted *common.RunHaltedError) error {
+	failedUnit := halted.FailedUnit
+
+	ctrl.Runner.Unit.Logger.Errorf("Unit %s will not run, as the run was halted by the failure of unit %s.", ctrl.Runner.Unit.Path, failedUnit.Path)
+
+	if opts.Experiments.Evaluate(experiment.Report) {
+		run, err := r.EnsureRun(ctrl.Runner.Unit.Path)
+		if err != nil {
+			ctrl.Runner.Unit.Logger.Errorf("Error ensuring run for unit %s: %v", ctrl.Runner.Unit.Path, err)
+			return err
+		}
+
+		if err := r.EndRun(
+			run.Path,
+			report.WithResult(report.ResultEarlyExit),
+			report.WithReason(report.ReasonRunHalted),
+			report.WithCauseRunHalted(failedUnit.Path),
+		); err != nil {
+			ctrl.Runner.Unit.Logger.Errorf("Error ending run for unit %s: %v", ctrl.Runner.Unit.Path, err)
+		}
+	}
+
+	return common.RunHaltedError{Unit: ctrl.Runner.Unit, FailedUnit: failedUnit, MaxFailures: halted.MaxFailures}
+}
+
+// runNotApproved records that the unit exits early, as the given group of the unit was not approved, and returns the
+// error of the unit.
+func (ctrl *DependencyController) runNotApproved(opts *options.TerragruntOptions, r *report.Report, group int) error {
+	ctrl.Runner.Unit.Logger.Errorf("Unit %s will not run, as its group %d was not approved.", ctrl.Runner.Unit.Path, group)
+
+	if opts.Experiments.Evaluate(experiment.Report) {
+		run, err := r.EnsureRun(ctrl.Runner.Unit.Path)
+		if err != nil {
+			ctrl.Runner.Unit.Logger.Errorf("Error ensuring run for unit %s: %v", ctrl.Runner.Unit.Path, err)
+			return err
+		}
+
+		if err := r.EndRun(
+			run.Path,
+			report.WithResult(report.ResultEarlyExit),
+			report.WithReason(report.ReasonNotApproved),
+			report.WithCauseNotApproved(fmt.Sprintf("group %d", group)),
+		); err != nil {
+			ctrl.Runner.Unit.Logger.Errorf("Error ending run for unit %s: %v", ctrl.Runner.Unit.Path, err)
+		}
+	}
+
+	return common.GroupNotApprovedError{Unit: ctrl.Runner.Unit, Group: group}
+}
+
+// outsideApplyWindow records that the unit is excluded from the run, as the given apply window of the unit is closed.
+func (ctrl *DependencyController) outsideApplyWindow(opts *options.TerragruntOptions, r *report.Report, window *config.ApplyWindow) {
+	if !opts.Experiments.Evaluate(experiment.Report) {
+		return
+	}
+
+	run, err := r.EnsureRun(ctrl.Runner.Unit.Path)
+	if err != nil {
+		ctrl.Runner.Unit.Logger.Errorf("Error ensuring run for unit %s: %v", ctrl.Runner.Unit.Path, err)
+		return
+	}
+
+	if err := r.EndRun(
+		run.Path,
+		report.WithResult(report.ResultExcluded),
+		report.WithReason(report.ReasonApplyWindow),
+		report.WithCauseApplyWindow(window.Name),
+	); err != nil {
+		ctrl.Runner.Unit.Logger.Errorf("Error ending run for unit %s: %v", ctrl.Runner.Unit.Path, err)
+	}
+}
+
+// dependencySkipped records that the unit exits early, as the given dependency was skipped outside its apply window, so
+// running the unit would run it against a dependency that wasn't run.
+func (ctrl *DependencyController) dependencySkipped(opts *options.TerragruntOptions, r *report.Report, dependency *DependencyController) {
+	ctrl.skippedWindow = dependency.skippedWindow
+
+	ctrl.Runner.Unit.Logger.Warnf("Unit %s will not run, as its dependency %s was skipped outside its apply window %s.", ctrl.Runner.Unit.Path, dependency.Runner.Unit.Path, ctrl.skippedWindow.Name)
+
+	if !opts.Experiments.Evaluate(experiment.Report) {
+		return
+	}
+
+	run, err := r.EnsureRun(ctrl.Runner.Unit.Path)
+	if err != nil {
+		ctrl.Runner.Unit.Logger.Errorf("Error ensuring run for unit %s: %v", ctrl.Runner.Unit.Path, err)
+		return
+	}
+
+	if err := r.EndRun(
+		run.Path,
+		report.WithResult(report.ResultEarlyExit),
+		report.WithReason(report.ReasonApplyWindow),
+		report.WithCauseAncestorExit(dependency.Runner.Unit.Path),
+	); err != nil {
+		ctrl.Runner.Unit.Logger.Errorf("Error ending run for unit %s: %v", ctrl.Runner.Unit.Path, err)
+	}
+}
+
+// isEarlyExit returns true if the given error of a unit means that it exited early, without running.
+func isEarlyExit(err error) bool {
+	var (
+		dependencyErr  common.ProcessingUnitDependencyError
+		haltedErr      common.RunHaltedError
+		notApprovedErr common.GroupNotApprovedError
+	)
+
+	return errors.As(err, &dependencyErr) || errors.As(err, &haltedErr) || errors.As(err, &notApprovedErr)
 }
 
 // unitFinished Record that a unit has finished executing and notify all of this unit's dependencies
@@ -163,9 +383,7 @@ func (ctrl *DependencyController) unitFinished(unitErr error, r *report.Report,
 		if reportExperiment {
 			if err := r.EndRun(
 				ctrl.Runner.Unit.Path,
-				report.WithResult(report.ResultFailed),
-				report.WithReason(report.ReasonRunError),
-				report.WithCauseRunError(unitErr.Error()),
+				runErrorEndOptions(unitErr)...,
 			); err != nil {
 				// If we can't find the run, then it never started,
 				// So we should start it and then end it as a failed run.
@@ -185,9 +403,7 @@ func (ctrl *DependencyController) unitFinished(unitErr error, r *report.Report,
 
 					if err := r.EndRun(
 						run.Path,
-						report.WithResult(report.ResultFailed),
-						report.WithReason(report.ReasonRunError),
-						report.WithCauseRunError(unitErr.Error()),
+						runErrorEndOptions(unitErr)...,
 					); err != nil {
 						ctrl.Runner.Unit.Logger.Errorf("Error ending run for unit %s: %v", ctrl.Runner.Unit.Path, err)
 					}
@@ -206,6 +422,35 @@ func (ctrl *DependencyController) unitFinished(unitErr error, r *report.Report,
 	}
 }
 
+// runErrorEndOptions returns the options ending the run of a unit failed with the given error. A failure to fetch the
+// outputs of a dependency is reported with its class as the cause, e.g. `not applied` or `auth`, rather than the error,
+// and a timeout of the unit with the timeout as the cause.
+func runErrorEndOptions(unitErr error) []report.EndOption {
+	var outputErr config.DependencyOutputError
+	if errors.As(unitErr, &outputErr) {
+		return []report.EndOption{
+			report.WithResult(report.ResultFailed),
+			report.WithReason(report.ReasonDependencyError),
+			report.WithCauseDependencyError(string(outputErr.Class)),
+		}
+	}
+
+	var timeoutErr common.UnitTimeoutError
+	if errors.As(unitErr, &timeoutErr) {
+		return []report.EndOption{
+			report.WithResult(report.ResultFailed),
+			report.WithReason(report.ReasonTimeout),
+			report.WithCauseTimeout(timeoutErr.Timeout.String()),
+		}
+	}
+
+	return []report.EndOption{
+		report.WithResult(report.ResultFailed),
+		report.WithReason(report.ReasonRunError),
+		report.WithCauseRunError(unitErr.Error()),
+	}
+}
+
 // RunningUnits is a map of unit path to DependencyController, representing the units that are currently running or
 type RunningUnits map[string]*DependencyController
 
@@ -355,16 +600,45 @@ func (units RunningUnits) RemoveFlagExcluded(r *report.Report, reportExperiment
 func (units RunningUnits) runUnits(ctx context.Context, opts *options.TerragruntOptions, r *report.Report, parallelism int) error {
 	var (
 		waitGroup sync.WaitGroup
-		semaphore = make(chan struct{}, parallelism) // Make a semaphore from a buffered channel
+		semaphore = common.NewPrioritySemaphore(parallelism)
 	)
 
+	runningUnits := make(common.Units, 0, len(units))
 	for _, unit := range units {
+		runningUnits = append(runningUnits, unit.Runner.Unit)
+	}
+
+	limiter, err := common.NewParallelismLimiter(runningUnits, opts.WorkingDir)
+	if err != nil {
+		return err
+	}
+
+	windows, err := common.NewApplyWindowGate(runningUnits, opts.WorkingDir, opts.TerraformCommand)
+	if err != nil {
+		return err
+	}
+
+	halt := &runHalt{maxFailures: opts.QueueMaxFailures}
+
+	gate := newStageGate(units, opts)
+
+	// The units are started in the order of their priorities, so the units of the highest priority are the first to
+	// wait for a slot of the parallelism.
+	orderedUnits := slices.SortedFunc(maps.Values(units), func(a, b *DependencyController) int {
+		if diff := cmp.Compare(b.Runner.Unit.QueuePriority(), a.Runner.Unit.QueuePriority()); diff != 0 {
+			return diff
+		}
+
+		return strings.Compare(a.Runner.Unit.Path, b.Runner.Unit.Path)
+	})
+
+	for _, unit := range orderedUnits {
 		waitGroup.Add(1)
 
 		go func(unit *DependencyController) {
 			defer waitGroup.Done()
 
-			unit.runUnitWhenReady(ctx, opts, r, semaphore)
+			unit.runUnitWhenReady(ctx, opts, r, semaphore, limiter, windows, halt, gate)
 		}(unit)
 	}
 
@@ -354,7 +354,32 @@ func (runner *Runner) ResolveTerraformModules(ctx context.Context, l log.Logger,
 		return nil, err
 	}
 
-	return withUnitsExcluded, nil
+	withUnitsTagged, err := runner.telemetryFlagTaggedUnits(ctx, l, withUnitsExcluded)
+	if err != nil {
+		return nil, err
+	}
+
+	withUnitsFiltered, err := runner.telemetryFlagFilteredUnits(ctx, l, withUnitsTagged)
+	if err != nil {
+		return nil, err
+	}
+
+	withUnitsChanged, err := runner.telemetryFlagChangedUnits(ctx, l, withUnitsFiltered)
+	if err != nil {
+		return nil, err
+	}
+
+	withUnitsSelected, err := runner.telemetryFlagSelectedUnits(ctx, l, withUnitsChanged)
+	if err != nil {
+		return nil, err
+	}
+
+	withUnitsSharded, err := runner.telemetryFlagShardedUnits(ctx, l, withUnitsSelected)
+	if err != nil {
+		return nil, err
+	}
+
+	return withUnitsSharded, nil
 }
 
 // telemetryResolveUnits resolves Terraform units from the given Terragrunt configuration paths
@@ -497,6 +522,92 @@ func (runner *Runner) telemetryFlagExcludedDirs(ctx context.Context, l log.Logge
 	return withUnitsExcluded, err
 }
 
+// telemetryFlagTaggedUnits flags units that are excluded by the tags passed in the queue tag CLI flags
+func (runner *Runner) telemetryFlagTaggedUnits(ctx context.Context, l log.Logger, withUnitsExcluded common.Units) (common.Units, error) {
+	var withUnitsTagged common.Units
+
+	err := telemetry.TelemeterFromContext(ctx).Collect(ctx, "flag_tagged_units", map[string]any{
+		"working_dir": runner.Stack.TerragruntOptions.WorkingDir,
+	}, func(_ context.Context) error {
+		withUnitsTagged = flagTaggedUnits(l, runner.Stack.TerragruntOptions, runner.Stack.Report, withUnitsExcluded)
+		return nil
+	})
+
+	return withUnitsTagged, err
+}
+
+// telemetryFlagFilteredUnits flags units that don't match the expressions passed in the queue filter CLI flag
+func (runner *Runner) telemetryFlagFilteredUnits(ctx context.Context, l log.Logger, withUnitsTagged common.Units) (common.Units, error) {
+	var withUnitsFiltered common.Units
+
+	err := telemetry.TelemeterFromContext(ctx).Collect(ctx, "flag_filtered_units", map[string]any{
+		"working_dir": runner.Stack.TerragruntOptions.WorkingDir,
+	}, func(_ context.Context) error {
+		var err error
+
+		withUnitsFiltered, err = flagFilteredUnits(l, runner.Stack.TerragruntOptions, runner.Stack.Report, withUnitsTagged)
+
+		return err
+	})
+
+	return withUnitsFiltered, err
+}
+
+// telemetryFlagChangedUnits flags units that aren't affected by the changes since the git ref passed in the queue
+// include changed CLI flag
+func (runner *Runner) telemetryFlagChangedUnits(ctx context.Context, l log.Logger, withUnitsFiltered common.Units) (common.Units, error) {
+	var withUnitsChanged common.Units
+
+	err := telemetry.TelemeterFromContext(ctx).Collect(ctx, "flag_changed_units", map[string]any{
+		"working_dir": runner.Stack.TerragruntOptions.WorkingDir,
+		"ref":         runner.Stack.TerragruntOptions.QueueIncludeChanged,
+	}, func(ctx context.Context) error {
+		var err error
+
+		withUnitsChanged, err = flagChangedUnits(ctx, l, runner.Stack.TerragruntOptions, runner.Stack.Report, withUnitsFiltered)
+
+		return err
+	})
+
+	return withUnitsChanged, err
+}
+
+// telemetryFlagSelectedUnits flags units that aren't selected by the queue include unit CLI flags
+func (runner *Runner) telemetryFlagSelectedUnits(ctx context.Context, l log.Logger, withUnitsChanged common.Units) (common.Units, error) {
+	var withUnitsSelected common.Units
+
+	err := telemetry.TelemeterFromContext(ctx).Collect(ctx, "flag_selected_units", map[string]any{
+		"working_dir":  runner.Stack.TerragruntOptions.WorkingDir,
+		"units":        runner.Stack.TerragruntOptions.QueueIncludeUnits,
+		"dependencies": runner.Stack.TerragruntOptions.QueueIncludeDependencies,
+		"dependents":   runner.Stack.TerragruntOptions.QueueIncludeDependents,
+	}, func(_ context.Context) error {
+		withUnitsSelected = flagSelectedUnits(l, runner.Stack.TerragruntOptions, runner.Stack.Report, withUnitsChanged)
+
+		return nil
+	})
+
+	return withUnitsSelected, err
+}
+
+// telemetryFlagShardedUnits flags units that aren't in the shard passed in the queue shard CLI flag
+func (runner *Runner) telemetryFlagShardedUnits(ctx context.Context, l log.Logger, withUnitsChanged common.Units) (common.Units, error) {
+	var withUnitsSharded common.Units
+
+	err := telemetry.TelemeterFromContext(ctx).Collect(ctx, "flag_sharded_units", map[string]any{
+		"working_dir": runner.Stack.TerragruntOptions.WorkingDir,
+		"shard":       runner.Stack.TerragruntOptions.QueueShard,
+	}, func(_ context.Context) error {
+		var err error
+
+		withUnitsSharded, err = flagShardedUnits(l, runner.Stack.TerragruntOptions, runner.Stack.Report, withUnitsChanged)
+
+		return err
+	})
+
+	return withUnitsSharded, err
+}
+
 // Go through each of the given Terragrunt configuration files and resolve the unit that configuration file represents
 // into a Unit struct. Note that this method will NOT fill in the Dependencies field of the Unit
 // struct (see the crosslinkDependencies method for that). Return a map from unit path to Unit struct.
@@ -655,6 +766,13 @@ func (runner *Runner) createParsingContext(ctx context.Context, l log.Logger, op
 			config.DependencyBlock,
 			config.FeatureFlagsBlock,
 			config.ErrorsBlock,
+			config.TagsAttr,
+			config.ParallelismLimitsBlock,
+			config.FailurePolicyBlock,
+			config.RunTimeoutAttr,
+			config.QueuePriorityAttr,
+			config.ApplyWindowsBlock,
+			config.MutexGroupsAttr,
 		)
 }
 
@@ -781,7 +899,7 @@ func (runner *Runner) resolveExternalDependenciesForUnits(ctx context.Context, l
 
 			shouldApply := false
 			if !runner.Stack.TerragruntOptions.IgnoreExternalDependencies {
-				shouldApply, err = confirmShouldApplyExternalDependency(ctx, unit, l, externalDependency, unitOpts)
+				shouldApply, err = confirmShouldApplyExternalDependency(ctx, unit, l, externalDependency, unitOpts, recursionLevel+1)
 				if err != nil {
 					return externalDependencies, err
 				}
@@ -878,8 +996,22 @@ func (runner *Runner) Units() common.Units {
 // Note that we skip the prompt for `run --all destroy` calls. Given the destructive and irreversible nature of destroy, we don't
 // want to provide any risk to the user of accidentally destroying an external dependency unless explicitly included
 // with the --queue-include-external or --queue-include-dir flags.
-func confirmShouldApplyExternalDependency(ctx context.Context, unit *common.Unit, l log.Logger, dependency *common.Unit, opts *options.TerragruntOptions) (bool, error) {
-	if opts.IncludeExternalDependencies {
+// The depth is the number of external dependencies between the units in the working dir and the given dependency,
+// which is 1 for a dependency of a unit in the working dir. Dependencies deeper than --queue-include-external-depth are
+// never included, and when --queue-include-external-file is set, only the dependencies it lists are included without
+// asking.
+func confirmShouldApplyExternalDependency(ctx context.Context, unit *common.Unit, l log.Logger, dependency *common.Unit, opts *options.TerragruntOptions, depth int) (bool, error) {
+	if opts.IncludeExternalDependenciesDepth > 0 && depth > opts.IncludeExternalDependenciesDepth {
+		l.Debugf("Unit %s, which is a dependency of unit %s, is deeper than the --queue-include-external-depth of %d, so will not run this command against it.", dependency.Path, unit.Path, opts.IncludeExternalDependenciesDepth)
+		return false, nil
+	}
+
+	if opts.IncludeExternalDependenciesFile != "" {
+		if dependency.FindUnitInPath(opts.IncludeExternalDirs) {
+			l.Debugf("Unit %s, which is a dependency of unit %s, is listed in the --queue-include-external-file, so automatically including it.", dependency.Path, unit.Path)
+			return true, nil
+		}
+	} else if opts.IncludeExternalDependencies {
 		l.Debugf("The --queue-include-external flag is set, so automatically including all external dependencies, and will run this command against unit %s, which is a dependency of unit %s.", dependency.Path, unit.Path)
 		return true, nil
 	}
@@ -1185,6 +1317,204 @@ func flagExcludedDirs(l log.Logger, opts *options.TerragruntOptions, r *report.R
 	return units
 }
 
+// flagTaggedUnits iterates over a unit slice and flags as excluded all the units that don't have any of the tags listed
+// in the queue-include-tag CLI flag, along with the units that have any of the tags listed in the queue-exclude-tag CLI
+// flag. Unless in strict include mode, the dependencies of the units with an included tag remain in the queue.
+func flagTaggedUnits(l log.Logger, opts *options.TerragruntOptions, r *report.Report, units common.Units) common.Units {
+	if len(opts.IncludeTags) == 0 && len(opts.ExcludeTags) == 0 {
+		return units
+	}
+
+	if len(opts.IncludeTags) > 0 {
+		included := make(map[string]bool)
+
+		for _, unit := range units {
+			if !unit.HasAnyTag(opts.IncludeTags) {
+				continue
+			}
+
+			included[unit.Path] = true
+
+			if !opts.StrictInclude {
+				for _, dependency := range unit.Dependencies {
+					included[dependency.Path] = true
+				}
+			}
+		}
+
+		for _, unit := range units {
+			if !included[unit.Path] && !unit.FlagExcluded {
+				unit.FlagExcluded = true
+				reportExcludedUnit(l, opts, r, unit.Path, report.ReasonIncludeTag)
+			}
+		}
+	}
+
+	for _, unit := range units {
+		if unit.HasAnyTag(opts.ExcludeTags) && !unit.FlagExcluded {
+			unit.FlagExcluded = true
+			reportExcludedUnit(l, opts, r, unit.Path, report.ReasonExcludeTag)
+		}
+	}
+
+	return units
+}
+
+// flagFilteredUnits iterates over a unit slice and flags as excluded all the units that don't match all the expressions
+// listed in the queue-filter CLI flag. Unless queue-strict-include is set, the dependencies of the matching units are
+// kept, as with queue-include-tag.
+func flagFilteredUnits(l log.Logger, opts *options.TerragruntOptions, r *report.Report, units common.Units) (common.Units, error) {
+	if len(opts.QueueFilters) == 0 {
+		return units, nil
+	}
+
+	filters := make([]*common.QueueFilter, 0, len(opts.QueueFilters))
+
+	for _, expr := range opts.QueueFilters {
+		filter, err := common.ParseQueueFilter(expr)
+		if err != nil {
+			return nil, err
+		}
+
+		filters = append(filters, filter)
+	}
+
+	included := make(map[string]bool)
+
+	for _, unit := range units {
+		matches := true
+
+		for _, filter := range filters {
+			if !filter.Matches(unit, opts.WorkingDir) {
+				matches = false
+				break
+			}
+		}
+
+		if !matches {
+			continue
+		}
+
+		included[unit.Path] = true
+
+		if !opts.StrictInclude {
+			for _, dependency := range unit.Dependencies {
+				included[dependency.Path] = true
+			}
+		}
+	}
+
+	for _, unit := range units {
+		if !included[unit.Path] && !unit.FlagExcluded {
+			unit.FlagExcluded = true
+			reportExcludedUnit(l, opts, r, unit.Path, report.ReasonQueueFilter)
+		}
+	}
+
+	return units, nil
+}
+
+// flagChangedUnits iterates over a unit slice and flags as excluded all the units that aren't affected by the changes
+// of the git repository of the working dir since the ref passed in the queue-include-changed CLI flag, nor depend on
+// an affected unit.
+func flagChangedUnits(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, r *report.Report, units common.Units) (common.Units, error) {
+	if opts.QueueIncludeChanged == "" {
+		return units, nil
+	}
+
+	changedFiles, err := shell.GitChangedFiles(ctx, l, opts, opts.WorkingDir, opts.QueueIncludeChanged)
+	if err != nil {
+		return nil, err
+	}
+
+	l.Debugf("Files changed since %s: %v", opts.QueueIncludeChanged, changedFiles)
+
+	affected := common.UnitsAffectedByChanges(units, changedFiles, opts.DidReadFile)
+
+	for _, unit := range units {
+		if !affected[unit.Path] && !unit.FlagExcluded {
+			unit.FlagExcluded = true
+			reportExcludedUnit(l, opts, r, unit.Path, report.ReasonIncludeChanged)
+		}
+	}
+
+	return units, nil
+}
+
+// flagSelectedUnits iterates over a unit slice and flags as excluded all the units that aren't in the dirs passed in the
+// queue-include-unit CLI flag, nor among their dependencies and dependents up to the depths passed in the
+// queue-include-dependencies and queue-include-dependents CLI flags. Unlike queue-include-dir, the dependencies of the
+// units are only kept if queue-include-dependencies is set.
+func flagSelectedUnits(l log.Logger, opts *options.TerragruntOptions, r *report.Report, units common.Units) common.Units {
+	if len(opts.QueueIncludeUnits) == 0 {
+		return units
+	}
+
+	selection := &common.QueueSelection{
+		Units:             opts.QueueIncludeUnits,
+		DependenciesDepth: opts.QueueIncludeDependencies,
+		DependentsDepth:   opts.QueueIncludeDependents,
+	}
+
+	included := selection.Includes(units)
+
+	for _, unit := range units {
+		if !included[unit.Path] && !unit.FlagExcluded {
+			unit.FlagExcluded = true
+			reportExcludedUnit(l, opts, r, unit.Path, report.ReasonIncludeUnit)
+		}
+	}
+
+	return units
+}
+
+// flagShardedUnits iterates over a unit slice and flags as excluded all the units that aren't in the shard passed in
+// the queue-shard CLI flag. It comes last, so the shards are made of the units left by the other flags.
+func flagShardedUnits(l log.Logger, opts *options.TerragruntOptions, r *report.Report, units common.Units) (common.Units, error) {
+	if opts.QueueShard == "" {
+		return units, nil
+	}
+
+	shard, err := common.ParseQueueShard(opts.QueueShard)
+	if err != nil {
+		return nil, err
+	}
+
+	included := shard.Includes(units)
+
+	for _, unit := range units {
+		if !included[unit.Path] && !unit.FlagExcluded && !unit.AssumeAlreadyApplied {
+			unit.FlagExcluded = true
+			reportExcludedUnit(l, opts, r, unit.Path, report.ReasonQueueShard)
+		}
+	}
+
+	l.Infof("Shard %s of the queue includes %d of the units", shard, len(included))
+
+	return units, nil
+}
+
+// reportExcludedUnit records the unit at the given path as excluded from the run for the given reason.
+func reportExcludedUnit(l log.Logger, opts *options.TerragruntOptions, r *report.Report, unitPath string, reason report.Reason) {
+	if !opts.Experiments.Evaluate(experiment.Report) {
+		return
+	}
+
+	run, err := r.EnsureRun(unitPath)
+	if err != nil {
+		l.Errorf("Error ensuring run for unit %s: %v", unitPath, err)
+		return
+	}
+
+	if err := r.EndRun(
+		run.Path,
+		report.WithResult(report.ResultExcluded),
+		report.WithReason(reason),
+	); err != nil {
+		l.Errorf("Error ending run for unit %s: %v", unitPath, err)
+	}
+}
+
 // SetTerragruntConfig sets the report for the stack.
 func (runner *Runner) SetTerragruntConfig(config *config.TerragruntConfig) {
 	runner.Stack.ChildTerragruntConfig = config
@@ -62,6 +62,57 @@ func TestFindStackInSubfolders(t *testing.T) {
 	}
 }
 
+func TestFindStackInSubfoldersWithStackDirs(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+
+	units := map[string]string{
+		"prod/app": `
+dependencies {
+  paths = ["../../shared/vpc"]
+}
+`,
+		"shared/vpc": ``,
+		"other/logs": ``,
+	}
+
+	for path, content := range units {
+		unitDir := filepath.Join(rootDir, path)
+		require.NoError(t, os.MkdirAll(unitDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(unitDir, config.DefaultTerragruntConfigPath), []byte(content), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(unitDir, "main.tf"), []byte(""), 0644))
+	}
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(rootDir, config.DefaultTerragruntConfigPath))
+	require.NoError(t, err)
+
+	opts.WorkingDir = rootDir
+	opts.StackDirs = []string{filepath.Join(rootDir, "prod"), filepath.Join(rootDir, "shared")}
+
+	runner, err := configstack.Build(t.Context(), logger.CreateLogger(), opts)
+	require.NoError(t, err)
+
+	unitsByPath := map[string]*common.Unit{}
+
+	for _, unit := range runner.GetStack().Units {
+		relPath, err := filepath.Rel(rootDir, unit.Path)
+		require.NoError(t, err)
+
+		unitsByPath[filepath.ToSlash(relPath)] = unit
+	}
+
+	// The units of other stacks are left out, and the dependency between the stacks isn't external.
+	require.Len(t, unitsByPath, 2)
+	require.Contains(t, unitsByPath, "prod/app")
+	require.Contains(t, unitsByPath, "shared/vpc")
+
+	vpc := unitsByPath["shared/vpc"]
+	require.False(t, vpc.FlagExcluded)
+	require.False(t, vpc.AssumeAlreadyApplied)
+	require.Equal(t, common.Units{vpc}, unitsByPath["prod/app"].Dependencies)
+}
+
 func TestGetUnitRunGraphApplyOrder(t *testing.T) {
 	t.Parallel()
 
@@ -329,21 +380,29 @@ func TestResolveTerraformModulesReadConfigFromParentConfig(t *testing.T) {
 		require.NoError(t, err)
 
 		localsConfigs[name] = map[string]any{
+			"assert":                        any(nil),
 			"dependencies":                  any(nil),
 			"download_dir":                  "",
+			"environment":                   "",
 			"generate":                      map[string]any{},
 			"iam_assume_role_duration":      any(nil),
 			"iam_assume_role_session_name":  "",
 			"iam_role":                      "",
 			"iam_web_identity_token":        "",
 			"inputs":                        any(nil),
+			"inputs_overrides":              any(nil),
 			"locals":                        cfg.Locals,
+			"mutex_groups":                  any(nil),
+			"queue_priority":                any(nil),
 			"retry_max_attempts":            any(nil),
 			"retry_sleep_interval_sec":      any(nil),
 			"retryable_errors":              any(nil),
+			"run_timeout_sec":               any(nil),
+			"tags":                          any(nil),
 			"terraform_binary":              "",
 			"terraform_version_constraint":  "",
 			"terragrunt_version_constraint": "",
+			"weight":                        any(nil),
 		}
 	}
 
@@ -1357,3 +1416,186 @@ func TestCircularDependencies(t *testing.T) {
 		t.Errorf("Expected %v, got %v", expected, result)
 	}
 }
+
+func TestResolveTerraformModulesWithTags(t *testing.T) {
+	t.Parallel()
+
+	units := map[string]string{
+		"vpc": `tags = ["networking", "prod"]`,
+		"app": `
+tags = ["prod", "team-core"]
+
+dependencies {
+  paths = ["../vpc"]
+}
+`,
+		"dev": `tags = ["dev", "team-core"]`,
+	}
+
+	testCases := []struct {
+		expectedExcluded map[string]bool
+		name             string
+		includeTags      []string
+		excludeTags      []string
+		strictInclude    bool
+	}{
+		{
+			name:             "no tags",
+			expectedExcluded: map[string]bool{"vpc": false, "app": false, "dev": false},
+		},
+		{
+			name:             "include tag",
+			includeTags:      []string{"team-core"},
+			expectedExcluded: map[string]bool{"vpc": false, "app": false, "dev": false},
+		},
+		{
+			name:             "include tag strict",
+			includeTags:      []string{"team-core"},
+			strictInclude:    true,
+			expectedExcluded: map[string]bool{"vpc": true, "app": false, "dev": false},
+		},
+		{
+			name:             "include tag with dependency",
+			includeTags:      []string{"prod"},
+			expectedExcluded: map[string]bool{"vpc": false, "app": false, "dev": true},
+		},
+		{
+			name:             "exclude tag",
+			excludeTags:      []string{"networking"},
+			expectedExcluded: map[string]bool{"vpc": true, "app": false, "dev": false},
+		},
+		{
+			name:             "include and exclude tags",
+			includeTags:      []string{"team-core"},
+			excludeTags:      []string{"dev"},
+			expectedExcluded: map[string]bool{"vpc": false, "app": false, "dev": true},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// The units resolved as dependencies are cached by path, so each case resolves its own units.
+			rootDir := t.TempDir()
+
+			configPaths := []string{}
+
+			for name, content := range units {
+				unitDir := filepath.Join(rootDir, name)
+				require.NoError(t, os.MkdirAll(unitDir, 0755))
+
+				configPath := filepath.Join(unitDir, config.DefaultTerragruntConfigPath)
+				require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+				require.NoError(t, os.WriteFile(filepath.Join(unitDir, "main.tf"), []byte(""), 0644))
+
+				configPaths = append(configPaths, configPath)
+			}
+
+			opts, err := options.NewTerragruntOptionsForTest(filepath.Join(rootDir, config.DefaultTerragruntConfigPath))
+			require.NoError(t, err)
+
+			opts.WorkingDir = rootDir
+			opts.IncludeTags = tc.includeTags
+			opts.ExcludeTags = tc.excludeTags
+			opts.StrictInclude = tc.strictInclude
+
+			l := logger.CreateLogger()
+
+			stack := configstack.NewRunner(l, opts)
+			actualUnits, err := stack.ResolveTerraformModules(t.Context(), l, configPaths)
+			require.NoError(t, err)
+			require.Len(t, actualUnits, len(tc.expectedExcluded))
+
+			for _, unit := range actualUnits {
+				name := filepath.Base(unit.Path)
+				require.Equalf(t, tc.expectedExcluded[name], unit.FlagExcluded, "unexpected exclusion of unit %s", name)
+			}
+		})
+	}
+}
+
+func TestResolveTerraformModulesIncludeExternalDepthAndFile(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	units := map[string]string{
+		"live/app": `
+dependencies {
+  paths = ["../../shared/vpc"]
+}
+`,
+		"shared/vpc": `
+dependencies {
+  paths = ["../network"]
+}
+`,
+		"shared/network": ``,
+	}
+
+	for name, content := range units {
+		unitDir := filepath.Join(rootDir, name)
+		require.NoError(t, os.MkdirAll(unitDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(unitDir, config.DefaultTerragruntConfigPath), []byte(content), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(unitDir, "main.tf"), []byte(""), 0644))
+	}
+
+	testCases := []struct {
+		expectedAssumeApplied map[string]bool
+		name                  string
+		includeExternalDirs   []string
+		depth                 int
+		includeExternal       bool
+	}{
+		{
+			name:                  "include all",
+			includeExternal:       true,
+			expectedAssumeApplied: map[string]bool{"app": false, "vpc": false, "network": false},
+		},
+		{
+			name:                  "max depth",
+			includeExternal:       true,
+			depth:                 1,
+			expectedAssumeApplied: map[string]bool{"app": false, "vpc": false, "network": true},
+		},
+		{
+			name:                  "include file",
+			includeExternal:       true,
+			includeExternalDirs:   []string{filepath.Join(rootDir, "shared", "network")},
+			expectedAssumeApplied: map[string]bool{"app": false, "vpc": true, "network": false},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			workingDir := filepath.Join(rootDir, "live")
+
+			opts, err := options.NewTerragruntOptionsForTest(filepath.Join(workingDir, config.DefaultTerragruntConfigPath))
+			require.NoError(t, err)
+
+			opts.WorkingDir = workingDir
+			opts.NonInteractive = true
+			opts.IncludeExternalDependencies = tc.includeExternal
+			opts.IncludeExternalDependenciesDepth = tc.depth
+
+			if tc.includeExternalDirs != nil {
+				opts.IncludeExternalDependenciesFile = ".terragrunt-include-external"
+				opts.IncludeExternalDirs = tc.includeExternalDirs
+			}
+
+			l := logger.CreateLogger()
+
+			stack := configstack.NewRunner(l, opts)
+			actualUnits, err := stack.ResolveTerraformModules(t.Context(), l, []string{filepath.Join(workingDir, "app", config.DefaultTerragruntConfigPath)})
+			require.NoError(t, err)
+			require.Len(t, actualUnits, len(tc.expectedAssumeApplied))
+
+			for _, unit := range actualUnits {
+				name := filepath.Base(unit.Path)
+				require.Equalf(t, tc.expectedAssumeApplied[name], unit.AssumeAlreadyApplied, "unexpected inclusion of unit %s", name)
+			}
+		})
+	}
+}
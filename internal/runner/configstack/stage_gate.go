@@ -0,0 +1,222 @@
+package configstack
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gruntwork-io/terragrunt/internal/report"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/shell"
+	"github.com/gruntwork-io/terragrunt/tf"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// stageGate gates the run of each dependency level of `run --all apply`, i.e. each group of units displayed in the run
+// queue, on the approval of the group, once all the units of the previous groups finished. The first group is approved
+// by the confirmation of the run, and the groups up to --approve-groups are approved without prompting.
+type stageGate struct {
+	levels   map[string]int
+	groups   []common.Units
+	done     []sync.WaitGroup
+	gates    []*groupGate
+	rejected atomic.Bool
+}
+
+// groupGate is the approval of a group, asked once by the first unit of the group ready to run.
+type groupGate struct {
+	once     sync.Once
+	approved bool
+}
+
+// newStageGate returns the stage gate of the given units, or nil if stage gating isn't enabled, see --stage-gate and
+// --approve-groups. It must be created before the units run, as running them consumes their dependencies.
+func newStageGate(units RunningUnits, opts *options.TerragruntOptions) *stageGate {
+	if opts.TerraformCommand != tf.CommandNameApply || (!opts.StageGate && opts.ApproveGroups <= 0) {
+		return nil
+	}
+
+	gate := &stageGate{levels: make(map[string]int, len(units))}
+
+	var level func(unit *DependencyController) int
+
+	level = func(unit *DependencyController) int {
+		if lvl, ok := gate.levels[unit.Runner.Unit.Path]; ok {
+			return lvl
+		}
+
+		lvl := 0
+
+		for _, dep := range unit.Dependencies {
+			lvl = max(lvl, level(dep)+1)
+		}
+
+		gate.levels[unit.Runner.Unit.Path] = lvl
+
+		return lvl
+	}
+
+	for _, unit := range units {
+		lvl := level(unit)
+
+		for len(gate.groups) <= lvl {
+			gate.groups = append(gate.groups, common.Units{})
+		}
+
+		gate.groups[lvl] = append(gate.groups[lvl], unit.Runner.Unit)
+	}
+
+	gate.done = make([]sync.WaitGroup, len(gate.groups))
+	gate.gates = make([]*groupGate, len(gate.groups))
+
+	for lvl, group := range gate.groups {
+		gate.done[lvl].Add(len(group))
+		gate.gates[lvl] = &groupGate{}
+	}
+
+	return gate
+}
+
+// wait waits for the group of the given unit to be approved, and returns false if it wasn't.
+func (gate *stageGate) wait(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, unit *common.Unit) bool {
+	if gate == nil {
+		return true
+	}
+
+	lvl := gate.levels[unit.Path]
+	if lvl == 0 {
+		return true
+	}
+
+	group := gate.gates[lvl]
+
+	group.once.Do(func() {
+		gate.done[lvl-1].Wait()
+
+		group.approved = !gate.rejected.Load() && gate.approve(ctx, l, opts, lvl)
+		if !group.approved {
+			gate.rejected.Store(true)
+		}
+	})
+
+	return group.approved
+}
+
+// approve returns true if the group of the given level is approved, prompting for the approval once the changes the
+// group plans to make are displayed, unless it is approved by --approve-groups.
+func (gate *stageGate) approve(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, lvl int) bool {
+	// Groups are numbered from 1, as in the run queue.
+	groupNum := lvl + 1
+
+	if groupNum <= opts.ApproveGroups {
+		l.Infof("Group %d is approved by --approve-groups.", groupNum)
+
+		return true
+	}
+
+	l.Infof("The units of group %d plan to make the following changes:\n%s", groupNum, gate.planGroup(ctx, opts, gate.groups[lvl]))
+
+	// Without a prompt, the rollout stops at the groups approved by --approve-groups.
+	if opts.NonInteractive && opts.ApproveGroups > 0 {
+		l.Warnf("Group %d is not approved by --approve-groups, stopping the run.", groupNum)
+
+		return false
+	}
+
+	approved, err := shell.PromptUserForYesNo(ctx, l, fmt.Sprintf("Are you sure you want to run 'terragrunt apply' in the units of group %d?", groupNum), opts)
+	if err != nil {
+		l.Errorf("Error prompting for the approval of group %d: %v", groupNum, err)
+
+		return false
+	}
+
+	return approved
+}
+
+// planGroup plans the changes of the given units, and returns a summary of them, the changes of each unit on a line.
+func (gate *stageGate) planGroup(ctx context.Context, opts *options.TerragruntOptions, units common.Units) string {
+	var (
+		lines     = make([]string, len(units))
+		waitGroup sync.WaitGroup
+		semaphore = make(chan struct{}, opts.Parallelism)
+	)
+
+	for i, unit := range units {
+		waitGroup.Add(1)
+
+		go func() {
+			defer waitGroup.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			path, err := filepath.Rel(opts.WorkingDir, unit.Path)
+			if err != nil {
+				path = unit.Path
+			}
+
+			lines[i] = fmt.Sprintf("  - %s: %s", path, planUnit(ctx, unit))
+		}()
+	}
+
+	waitGroup.Wait()
+
+	slices.Sort(lines)
+
+	return strings.Join(lines, "\n")
+}
+
+// planUnit runs plan in the given unit, its output discarded, and returns the numbers of resources it plans to change.
+func planUnit(ctx context.Context, unit *common.Unit) string {
+	if unit.AssumeAlreadyApplied {
+		return "assumed already applied"
+	}
+
+	run, err := report.NewRun(unit.Path)
+	if err != nil {
+		return "plan failed: " + err.Error()
+	}
+
+	r := report.NewReport()
+	if err := r.AddRun(run); err != nil {
+		return "plan failed: " + err.Error()
+	}
+
+	planOpts := unit.TerragruntOptions.Clone()
+	planOpts.TerraformCommand = tf.CommandNamePlan
+	planOpts.TerraformCliArgs = []string{tf.CommandNamePlan}
+	planOpts.Writer = report.NewChangesWriter(io.Discard, run)
+	planOpts.ErrWriter = io.Discard
+
+	// The arguments of apply are passed to plan, but the approval.
+	if args := unit.TerragruntOptions.TerraformCliArgs; len(args) > 1 {
+		planOpts.TerraformCliArgs = append(planOpts.TerraformCliArgs, util.RemoveElementFromList(args[1:], "-auto-approve")...)
+	}
+
+	if err := planOpts.RunTerragrunt(ctx, unit.Logger, planOpts, r); err != nil {
+		return "plan failed: " + err.Error()
+	}
+
+	if run.Changes == nil {
+		return "changes unknown"
+	}
+
+	return fmt.Sprintf("%d to add, %d to change, %d to destroy", run.Changes.Add, run.Changes.Change, run.Changes.Destroy)
+}
+
+// finished records that the given unit finished, letting the next group be approved once all the units of its group
+// finished.
+func (gate *stageGate) finished(unit *common.Unit) {
+	if gate == nil {
+		return
+	}
+
+	gate.done[gate.levels[unit.Path]].Done()
+}
@@ -2,10 +2,13 @@ package configstack_test
 
 import (
 	"bytes"
+	"context"
 	"errors"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gruntwork-io/terragrunt/internal/report"
 	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
@@ -15,6 +18,8 @@ import (
 	"github.com/gruntwork-io/terragrunt/internal/runner/common"
 	"github.com/gruntwork-io/terragrunt/internal/runner/configstack"
 	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/tf"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -243,6 +248,36 @@ func TestCheckForCycles(t *testing.T) {
 	}
 }
 
+func TestCheckForCyclesDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	l := logger.CreateLogger()
+
+	// vpc -> app -> vpc, with the app depended on by the web and api units
+	vpc := &common.Unit{Path: "/stack/vpc", Config: config.TerragruntConfig{
+		Dependencies: &config.ModuleDependencies{Paths: []string{"../app"}},
+	}, Logger: l}
+	app := &common.Unit{Path: "/stack/app", Dependencies: common.Units{vpc}, Config: config.TerragruntConfig{
+		Dependencies: &config.ModuleDependencies{Paths: []string{"../vpc"}},
+	}, Logger: l}
+	vpc.Dependencies = common.Units{app}
+	web := &common.Unit{Path: "/stack/web", Dependencies: common.Units{app}, Logger: l}
+	api := &common.Unit{Path: "/stack/api", Dependencies: common.Units{app}, Logger: l}
+
+	err := common.Units{vpc, app, web, api}.CheckForCycles()
+
+	var diagnosticsErr config.DependencyCycleDiagnosticsError
+
+	require.ErrorAs(t, err, &diagnosticsErr)
+	require.Len(t, diagnosticsErr.Edges, 2)
+	assert.Equal(t, "dependencies in /stack/vpc/terragrunt.hcl", diagnosticsErr.Edges[0].Declaration.String())
+	assert.Equal(t, 3, diagnosticsErr.Edges[1].Dependents)
+
+	// Both edges only order the units, so the edge from the app, depended on by the most units, is suggested.
+	assert.Equal(t, 1, diagnosticsErr.SuggestedEdge)
+	assert.Contains(t, err.Error(), "/stack/app -> /stack/vpc: dependencies in /stack/app/terragrunt.hcl")
+}
+
 func TestRunUnitsNoUnits(t *testing.T) {
 	t.Parallel()
 
@@ -1090,6 +1125,356 @@ func TestRunUnitsMultipleUnitsWithDependenciesOneFailureIgnoreDependencyErrors(t
 	assert.True(t, cRan)
 }
 
+func TestRunUnitsMultipleUnitsWithDependenciesOneFailureContinuePolicy(t *testing.T) {
+	t.Parallel()
+
+	l := logger.CreateLogger()
+
+	continuePolicy := config.FailurePolicyContinue
+
+	aRan := false
+	unitA := &common.Unit{
+		Path:              "a",
+		Dependencies:      common.Units{},
+		Config:            config.TerragruntConfig{},
+		Logger:            l,
+		TerragruntOptions: optionsWithMockTerragruntCommand(t, "a", nil, &aRan),
+	}
+
+	bRan := false
+	expectedErrB := errors.New("Expected error for unit b")
+	unitB := &common.Unit{
+		Path:              "b",
+		Dependencies:      common.Units{unitA},
+		Config:            config.TerragruntConfig{FailurePolicy: &config.FailurePolicyConfig{Mode: &continuePolicy}},
+		Logger:            l,
+		TerragruntOptions: optionsWithMockTerragruntCommand(t, "b", expectedErrB, &bRan),
+	}
+
+	cRan := false
+	unitC := &common.Unit{
+		Path:              "c",
+		Dependencies:      common.Units{unitB},
+		Config:            config.TerragruntConfig{},
+		Logger:            l,
+		TerragruntOptions: optionsWithMockTerragruntCommand(t, "c", nil, &cRan),
+	}
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	opts.Parallelism = options.DefaultParallelism
+	runner := configstack.Runner{
+		Stack: &common.Stack{
+			Units:  common.Units{unitA, unitB, unitC},
+			Report: report.NewReport(),
+		},
+	}
+	err = runner.RunUnits(t.Context(), opts)
+
+	assertMultiErrorContains(t, err, expectedErrB)
+
+	assert.True(t, aRan)
+	assert.True(t, bRan)
+	assert.True(t, cRan, "c runs anyway, as the failure policy of b is continue")
+}
+
+func TestRunUnitsMultipleUnitsOneFailureHaltRunPolicy(t *testing.T) {
+	t.Parallel()
+
+	l := logger.CreateLogger()
+
+	haltRunPolicy := config.FailurePolicyHaltRun
+	bStarted := make(chan struct{})
+	aFailed := make(chan struct{})
+
+	aRan := false
+	expectedErrA := errors.New("Expected error for unit a")
+	terragruntOptionsA := optionsWithMockTerragruntCommand(t, "a", expectedErrA, &aRan)
+	terragruntOptionsA.RunTerragrunt = func(_ context.Context, _ log.Logger, _ *options.TerragruntOptions, _ *report.Report) error {
+		aRan = true
+		<-bStarted
+		close(aFailed)
+
+		return expectedErrA
+	}
+	unitA := &common.Unit{
+		Path:              "a",
+		Dependencies:      common.Units{},
+		Config:            config.TerragruntConfig{FailurePolicy: &config.FailurePolicyConfig{Mode: &haltRunPolicy}},
+		Logger:            l,
+		TerragruntOptions: terragruntOptionsA,
+	}
+
+	// a only fails once b started, and b only finishes once a failed, so c, which depends on b, starts once the run
+	// is halted.
+	bRan := false
+	terragruntOptionsB := optionsWithMockTerragruntCommand(t, "b", nil, &bRan)
+	terragruntOptionsB.RunTerragrunt = func(_ context.Context, _ log.Logger, _ *options.TerragruntOptions, _ *report.Report) error {
+		bRan = true
+		close(bStarted)
+		<-aFailed
+		time.Sleep(100 * time.Millisecond)
+
+		return nil
+	}
+	unitB := &common.Unit{
+		Path:              "b",
+		Dependencies:      common.Units{},
+		Config:            config.TerragruntConfig{},
+		Logger:            l,
+		TerragruntOptions: terragruntOptionsB,
+	}
+
+	cRan := false
+	unitC := &common.Unit{
+		Path:              "c",
+		Dependencies:      common.Units{unitB},
+		Config:            config.TerragruntConfig{},
+		Logger:            l,
+		TerragruntOptions: optionsWithMockTerragruntCommand(t, "c", nil, &cRan),
+	}
+
+	expectedErrC := common.RunHaltedError{Unit: unitC, FailedUnit: unitA}
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	opts.Parallelism = options.DefaultParallelism
+	runner := configstack.Runner{
+		Stack: &common.Stack{
+			Units:  common.Units{unitA, unitB, unitC},
+			Report: report.NewReport(),
+		},
+	}
+	err = runner.RunUnits(t.Context(), opts)
+
+	assertMultiErrorContains(t, err, expectedErrA, expectedErrC)
+
+	assert.True(t, aRan)
+	assert.True(t, bRan)
+	assert.False(t, cRan, "c doesn't run, as the run was halted by the failure of a")
+}
+
+func TestRunUnitsMultipleUnitsOneFailureQueueMaxFailures(t *testing.T) {
+	t.Parallel()
+
+	l := logger.CreateLogger()
+
+	bStarted := make(chan struct{})
+	aFailed := make(chan struct{})
+
+	aRan := false
+	expectedErrA := errors.New("Expected error for unit a")
+	terragruntOptionsA := optionsWithMockTerragruntCommand(t, "a", expectedErrA, &aRan)
+	terragruntOptionsA.RunTerragrunt = func(_ context.Context, _ log.Logger, _ *options.TerragruntOptions, _ *report.Report) error {
+		aRan = true
+		<-bStarted
+		close(aFailed)
+
+		return expectedErrA
+	}
+	unitA := &common.Unit{
+		Path:              "a",
+		Dependencies:      common.Units{},
+		Config:            config.TerragruntConfig{},
+		Logger:            l,
+		TerragruntOptions: terragruntOptionsA,
+	}
+
+	// a only fails once b started, and b only finishes once a failed, so c, which depends on b, starts once the run
+	// is halted.
+	bRan := false
+	terragruntOptionsB := optionsWithMockTerragruntCommand(t, "b", nil, &bRan)
+	terragruntOptionsB.RunTerragrunt = func(_ context.Context, _ log.Logger, _ *options.TerragruntOptions, _ *report.Report) error {
+		bRan = true
+		close(bStarted)
+		<-aFailed
+		time.Sleep(100 * time.Millisecond)
+
+		return nil
+	}
+	unitB := &common.Unit{
+		Path:              "b",
+		Dependencies:      common.Units{},
+		Config:            config.TerragruntConfig{},
+		Logger:            l,
+		TerragruntOptions: terragruntOptionsB,
+	}
+
+	cRan := false
+	unitC := &common.Unit{
+		Path:              "c",
+		Dependencies:      common.Units{unitB},
+		Config:            config.TerragruntConfig{},
+		Logger:            l,
+		TerragruntOptions: optionsWithMockTerragruntCommand(t, "c", nil, &cRan),
+	}
+
+	expectedErrC := common.RunHaltedError{Unit: unitC, FailedUnit: unitA, MaxFailures: 1}
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	opts.Parallelism = options.DefaultParallelism
+	opts.QueueMaxFailures = 1
+	runner := configstack.Runner{
+		Stack: &common.Stack{
+			Units:  common.Units{unitA, unitB, unitC},
+			Report: report.NewReport(),
+		},
+	}
+	err = runner.RunUnits(t.Context(), opts)
+
+	assertMultiErrorContains(t, err, expectedErrA, expectedErrC)
+
+	assert.True(t, aRan)
+	assert.True(t, bRan)
+	assert.False(t, cRan, "c doesn't run, as the failure of a reached --queue-max-failures")
+}
+
+func TestRunUnitsMultipleUnitsWithDependenciesApproveGroups(t *testing.T) {
+	t.Parallel()
+
+	l := logger.CreateLogger()
+
+	aRan := false
+	unitA := &common.Unit{
+		Path:              "a",
+		Dependencies:      common.Units{},
+		Config:            config.TerragruntConfig{},
+		Logger:            l,
+		TerragruntOptions: optionsWithMockTerragruntCommand(t, "a", nil, &aRan),
+	}
+
+	bRan := false
+	unitB := &common.Unit{
+		Path:              "b",
+		Dependencies:      common.Units{unitA},
+		Config:            config.TerragruntConfig{},
+		Logger:            l,
+		TerragruntOptions: optionsWithMockTerragruntCommand(t, "b", nil, &bRan),
+	}
+
+	// c is only planned, to display the changes of its group before the run stops.
+	var cPlanned, cRan bool
+
+	terragruntOptionsC := optionsWithMockTerragruntCommand(t, "c", nil, &cRan)
+	terragruntOptionsC.TerraformCommand = tf.CommandNameApply
+	terragruntOptionsC.TerraformCliArgs = []string{tf.CommandNameApply, "-input=false", "-auto-approve"}
+	terragruntOptionsC.RunTerragrunt = func(_ context.Context, _ log.Logger, opts *options.TerragruntOptions, _ *report.Report) error {
+		if opts.TerraformCommand != tf.CommandNamePlan {
+			cRan = true
+			return nil
+		}
+
+		cPlanned = true
+
+		assert.Equal(t, []string{tf.CommandNamePlan, "-input=false"}, []string(opts.TerraformCliArgs))
+
+		_, err := opts.Writer.Write([]byte("Plan: 1 to add, 0 to change, 0 to destroy.\n"))
+
+		return err
+	}
+
+	// The runs of the report, including the one recording the plan, need absolute paths.
+	unitC := &common.Unit{
+		Path:              filepath.Join(t.TempDir(), "c"),
+		Dependencies:      common.Units{unitB},
+		Config:            config.TerragruntConfig{},
+		Logger:            l,
+		TerragruntOptions: terragruntOptionsC,
+	}
+
+	expectedErrC := common.GroupNotApprovedError{Unit: unitC, Group: 3}
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	opts.Parallelism = options.DefaultParallelism
+	opts.TerraformCommand = tf.CommandNameApply
+	opts.NonInteractive = true
+	opts.ApproveGroups = 2
+
+	runner := configstack.Runner{
+		Stack: &common.Stack{
+			Units:  common.Units{unitA, unitB, unitC},
+			Report: report.NewReport(),
+		},
+	}
+	err = runner.RunUnits(t.Context(), opts)
+
+	assertMultiErrorContains(t, err, expectedErrC)
+
+	assert.True(t, aRan)
+	assert.True(t, bRan)
+	assert.True(t, cPlanned)
+	assert.False(t, cRan, "c doesn't run, as its group isn't approved by --approve-groups")
+}
+
+func TestRunUnitsMultipleUnitsWithDependenciesOutsideApplyWindow(t *testing.T) {
+	t.Parallel()
+
+	l := logger.CreateLogger()
+	workingDir := t.TempDir()
+
+	// The window of a only opens on leap days, so a is skipped.
+	windows := config.ApplyWindows{{Name: "leap-days", Schedule: "0 0 29 2 *", Duration: "1m", Paths: []string{"a"}}}
+
+	aRan := false
+	terragruntOptionsA := optionsWithMockTerragruntCommand(t, "a", nil, &aRan)
+	terragruntOptionsA.TerraformCommand = tf.CommandNameApply
+	unitA := &common.Unit{
+		Path:              filepath.Join(workingDir, "a"),
+		Dependencies:      common.Units{},
+		Config:            config.TerragruntConfig{ApplyWindows: windows},
+		Logger:            l,
+		TerragruntOptions: terragruntOptionsA,
+	}
+
+	bRan := false
+	terragruntOptionsB := optionsWithMockTerragruntCommand(t, "b", nil, &bRan)
+	terragruntOptionsB.TerraformCommand = tf.CommandNameApply
+	unitB := &common.Unit{
+		Path:              filepath.Join(workingDir, "b"),
+		Dependencies:      common.Units{unitA},
+		Config:            config.TerragruntConfig{ApplyWindows: windows},
+		Logger:            l,
+		TerragruntOptions: terragruntOptionsB,
+	}
+
+	cRan := false
+	terragruntOptionsC := optionsWithMockTerragruntCommand(t, "c", nil, &cRan)
+	terragruntOptionsC.TerraformCommand = tf.CommandNameApply
+	unitC := &common.Unit{
+		Path:              filepath.Join(workingDir, "c"),
+		Dependencies:      common.Units{},
+		Config:            config.TerragruntConfig{ApplyWindows: windows},
+		Logger:            l,
+		TerragruntOptions: terragruntOptionsC,
+	}
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	opts.Parallelism = options.DefaultParallelism
+	opts.TerraformCommand = tf.CommandNameApply
+	opts.WorkingDir = workingDir
+
+	runner := configstack.Runner{
+		Stack: &common.Stack{
+			Units:  common.Units{unitA, unitB, unitC},
+			Report: report.NewReport(),
+		},
+	}
+	err = runner.RunUnits(t.Context(), opts)
+	require.NoError(t, err)
+
+	assert.False(t, aRan, "a is skipped outside its apply window")
+	assert.False(t, bRan, "b is skipped, as its dependency a wasn't applied")
+	assert.True(t, cRan)
+}
+
 func TestRunUnitsReverseOrderMultipleUnitsWithDependenciesOneFailure(t *testing.T) {
 	t.Parallel()
 
@@ -2,9 +2,11 @@
 package runner
 
 import (
+	"cmp"
 	"context"
 	"path/filepath"
 	"slices"
+	"strings"
 
 	"github.com/gruntwork-io/terragrunt/config"
 	"github.com/gruntwork-io/terragrunt/shell"
@@ -14,6 +16,7 @@ import (
 
 	"github.com/gruntwork-io/terragrunt/internal/runner/runnerpool"
 
+	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/internal/experiment"
 	"github.com/gruntwork-io/terragrunt/pkg/log"
 
@@ -23,13 +26,98 @@ import (
 // FindStackInSubfolders finds all the Terraform modules in the subfolders of the working directory of the given TerragruntOptions and
 // assemble them into a Stack object that can be applied or destroyed in a single command
 func FindStackInSubfolders(ctx context.Context, l log.Logger, terragruntOptions *options.TerragruntOptions, opts ...common.Option) (common.StackRunner, error) {
+	var (
+		stack common.StackRunner
+		err   error
+	)
+
 	if terragruntOptions.Experiments.Evaluate(experiment.RunnerPool) {
+		if len(terragruntOptions.StackDirs) > 0 {
+			return nil, errors.Errorf("the --stack flag is not supported with the %s experiment", experiment.RunnerPool)
+		}
+
+		if len(terragruntOptions.IncludeTags) > 0 || len(terragruntOptions.ExcludeTags) > 0 {
+			return nil, errors.Errorf("the --queue-include-tag and --queue-exclude-tag flags are not supported with the %s experiment", experiment.RunnerPool)
+		}
+
+		if len(terragruntOptions.QueueFilters) > 0 {
+			return nil, errors.Errorf("the --queue-filter flag is not supported with the %s experiment", experiment.RunnerPool)
+		}
+
+		if terragruntOptions.QueueIncludeChanged != "" {
+			return nil, errors.Errorf("the --queue-include-changed flag is not supported with the %s experiment", experiment.RunnerPool)
+		}
+
+		if terragruntOptions.StageGate || terragruntOptions.ApproveGroups > 0 {
+			return nil, errors.Errorf("the --stage-gate and --approve-groups flags are not supported with the %s experiment", experiment.RunnerPool)
+		}
+
+		if terragruntOptions.QueueShard != "" {
+			return nil, errors.Errorf("the --queue-shard flag is not supported with the %s experiment", experiment.RunnerPool)
+		}
+
+		if len(terragruntOptions.QueueIncludeUnits) > 0 {
+			return nil, errors.Errorf("the --queue-include-unit, --queue-include-dependencies and --queue-include-dependents flags are not supported with the %s experiment", experiment.RunnerPool)
+		}
+
+		if terragruntOptions.IncludeExternalDependenciesDepth > 0 || terragruntOptions.IncludeExternalDependenciesFile != "" {
+			return nil, errors.Errorf("the --queue-include-external-depth and --queue-include-external-file flags are not supported with the %s experiment", experiment.RunnerPool)
+		}
+
 		l.Infof("Using runner pool for stack %s", terragruntOptions.WorkingDir)
 
-		return runnerpool.Build(ctx, l, terragruntOptions, opts...)
+		stack, err = runnerpool.Build(ctx, l, terragruntOptions, opts...)
+	} else {
+		stack, err = configstack.Build(ctx, l, terragruntOptions, opts...)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if terragruntOptions.DependencyPolicyFile != "" {
+		if err := checkDependencyPolicy(terragruntOptions, stack.GetStack().Units); err != nil {
+			return nil, err
+		}
+	}
+
+	return stack, nil
+}
+
+// checkDependencyPolicy returns an error listing the dependencies of the given units violating the dependency policy
+// of the given options.
+func checkDependencyPolicy(opts *options.TerragruntOptions, units common.Units) error {
+	policy, err := config.ParseDependencyPolicyFile(opts.DependencyPolicyFile)
+	if err != nil {
+		return err
+	}
+
+	policyUnit := func(unit *common.Unit) config.DependencyPolicyUnit {
+		path := unit.Path
+		if rel, err := filepath.Rel(opts.WorkingDir, unit.Path); err == nil {
+			path = rel
+		}
+
+		return config.DependencyPolicyUnit{Path: filepath.ToSlash(path), Tags: unit.Config.Tags}
+	}
+
+	var violations []config.DependencyPolicyViolation
+
+	for _, unit := range units {
+		for _, dep := range unit.Dependencies {
+			violations = append(violations, policy.Check(policyUnit(unit), policyUnit(dep))...)
+		}
+	}
+
+	if len(violations) > 0 {
+		slices.SortFunc(violations, func(a, b config.DependencyPolicyViolation) int {
+			return cmp.Or(strings.Compare(a.From, b.From), strings.Compare(a.To, b.To), strings.Compare(a.Rule, b.Rule))
+		})
+
+		return errors.New(config.DependencyPolicyViolationsError{Path: opts.DependencyPolicyFile, Violations: violations})
 	}
 
-	return configstack.Build(ctx, l, terragruntOptions, opts...)
+	return nil
 }
 
 // FindWhereWorkingDirIsIncluded - find where working directory is included, flow:
@@ -0,0 +1,69 @@
+package runner_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/internal/experiment"
+	"github.com/gruntwork-io/terragrunt/internal/runner"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+)
+
+func TestFindStackInSubfoldersRejectsQueueFlagsWithRunnerPool(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		setFlag  func(opts *options.TerragruntOptions)
+		expected string
+	}{
+		{
+			name:     "queue-include-unit",
+			setFlag:  func(opts *options.TerragruntOptions) { opts.QueueIncludeUnits = []string{"app"} },
+			expected: "the --queue-include-unit, --queue-include-dependencies and --queue-include-dependents flags are not supported",
+		},
+		{
+			name: "queue-include-dependencies",
+			setFlag: func(opts *options.TerragruntOptions) {
+				opts.QueueIncludeUnits = []string{"app"}
+				opts.QueueIncludeDependencies = 1
+				opts.QueueIncludeDependents = -1
+			},
+			expected: "the --queue-include-unit, --queue-include-dependencies and --queue-include-dependents flags are not supported",
+		},
+		{
+			name:     "queue-include-external-depth",
+			setFlag:  func(opts *options.TerragruntOptions) { opts.IncludeExternalDependenciesDepth = 2 },
+			expected: "the --queue-include-external-depth and --queue-include-external-file flags are not supported",
+		},
+		{
+			name:     "queue-include-external-file",
+			setFlag:  func(opts *options.TerragruntOptions) { opts.IncludeExternalDependenciesFile = ".terragrunt-include-external" },
+			expected: "the --queue-include-external-depth and --queue-include-external-file flags are not supported",
+		},
+		{
+			name:     "queue-shard",
+			setFlag:  func(opts *options.TerragruntOptions) { opts.QueueShard = "1/2" },
+			expected: "the --queue-shard flag is not supported",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts, err := options.NewTerragruntOptionsForTest(t.TempDir())
+			require.NoError(t, err)
+			require.NoError(t, opts.Experiments.EnableExperiment(experiment.RunnerPool))
+
+			tc.setFlag(opts)
+
+			_, err = runner.FindStackInSubfolders(t.Context(), logger.CreateLogger(), opts)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expected)
+		})
+	}
+}
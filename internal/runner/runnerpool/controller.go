@@ -19,12 +19,17 @@ import (
 // UnitRunner defines a function type that executes a Unit within a given context and returns an error.
 type UnitRunner func(ctx context.Context, u *common.Unit) error
 
+// errUnitSkipped is returned by the UnitRunner of a unit that is skipped, e.g. outside its apply window, so the units
+// waiting for it are skipped too, rather than run against a unit that didn't run, without failing the run.
+var errUnitSkipped = errors.New("unit skipped")
+
 // Controller orchestrates concurrent execution over a DAG.
 type Controller struct {
 	q           *queue.Queue
 	runner      UnitRunner
 	readyCh     chan struct{}
 	unitsMap    map[string]*common.Unit
+	limiter     *common.ParallelismLimiter
 	concurrency int
 }
 
@@ -49,6 +54,13 @@ func WithMaxConcurrency(concurrency int) ControllerOption {
 	}
 }
 
+// WithParallelismLimiter sets the limiter of the parallelism limits of the units for the Controller.
+func WithParallelismLimiter(limiter *common.ParallelismLimiter) ControllerOption {
+	return func(dr *Controller) {
+		dr.limiter = limiter
+	}
+}
+
 // NewController creates a new Controller with the given options and a pre-built queue.
 func NewController(q *queue.Queue, units []*common.Unit, opts ...ControllerOption) *Controller {
 	dr := &Controller{
@@ -104,6 +116,14 @@ func (dr *Controller) Run(ctx context.Context, l log.Logger) error {
 		l.Debugf("Runner Pool Controller: found %d readyEntries tasks", len(readyEntries))
 
 		for _, e := range readyEntries {
+			limitedUnit := dr.unitsMap[e.Config.Path]
+
+			// Entries whose parallelism limits are all taken are left ready, to be scheduled once a unit releases them.
+			if dr.limiter != nil && limitedUnit != nil && !dr.limiter.TryAcquire(limitedUnit) {
+				l.Debugf("Runner Pool Controller: %s is waiting for its parallelism limits", e.Config.Path)
+				continue
+			}
+
 			// log debug which entry is running
 			l.Debugf("Runner Pool Controller: running %s", e.Config.Path)
 			e.Status = queue.StatusRunning
@@ -113,6 +133,10 @@ func (dr *Controller) Run(ctx context.Context, l log.Logger) error {
 
 			go func(ent *queue.Entry) {
 				defer func() {
+					if dr.limiter != nil && limitedUnit != nil {
+						dr.limiter.Release(limitedUnit)
+					}
+
 					<-sem
 					wg.Done()
 					select {
@@ -132,6 +156,18 @@ func (dr *Controller) Run(ctx context.Context, l log.Logger) error {
 				}
 
 				err := dr.runner(ctx, unit)
+				if errors.Is(err, errUnitSkipped) {
+					l.Debugf("Runner Pool Controller: %s skipped", ent.Config.Path)
+
+					for _, waiting := range dr.q.SkipEntry(ent) {
+						l.Warnf("Unit %s will not run, as unit %s it waits for was skipped.", waiting.Config.Path, ent.Config.Path)
+					}
+
+					results.Store(ent.Config.Path, nil)
+
+					return
+				}
+
 				results.Store(ent.Config.Path, err)
 
 				if err != nil {
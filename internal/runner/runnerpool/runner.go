@@ -21,6 +21,7 @@ import (
 	"github.com/gruntwork-io/terragrunt/config"
 	"github.com/gruntwork-io/terragrunt/config/hclparse"
 	"github.com/gruntwork-io/terragrunt/internal/discovery"
+	"github.com/gruntwork-io/terragrunt/internal/experiment"
 	"github.com/gruntwork-io/terragrunt/internal/queue"
 	"github.com/gruntwork-io/terragrunt/internal/report"
 	"github.com/gruntwork-io/terragrunt/options"
@@ -36,7 +37,11 @@ type Runner struct {
 
 // NewRunnerPoolStack creates a new stack from discovered units.
 func NewRunnerPoolStack(l log.Logger, terragruntOptions *options.TerragruntOptions, discovered discovery.DiscoveredConfigs, opts ...common.Option) (common.StackRunner, error) {
-	q, queueErr := queue.NewQueue(discovered)
+	q, queueErr := queue.NewQueue(
+		discovered,
+		queue.WithPriorities(unitPriorities(terragruntOptions, discovered)),
+		queue.WithWeights(unitWeights(l, terragruntOptions, discovered)),
+	)
 	if queueErr != nil {
 		return nil, queueErr
 	}
@@ -135,10 +140,25 @@ func (r *Runner) Run(ctx context.Context, l log.Logger, opts *options.Terragrunt
 		defer r.summarizePlanAllErrors(l, r.planErrorBuffers)
 	}
 
+	windows, err := common.NewApplyWindowGate(r.Stack.Units, opts.WorkingDir, terraformCmd)
+	if err != nil {
+		return err
+	}
+
 	taskRun := func(ctx context.Context, u *common.Unit) error {
+		window, err := windows.Wait(ctx, u.Logger, u)
+		if err != nil {
+			return err
+		}
+
+		if window != nil {
+			r.outsideApplyWindow(u, window)
+			return errUnitSkipped
+		}
+
 		unitRunner := common.NewUnitRunner(u)
 
-		err := unitRunner.Run(ctx, u.TerragruntOptions, r.Stack.Report)
+		err = unitRunner.Run(ctx, u.TerragruntOptions, r.Stack.Report)
 		if err != nil {
 			return err
 		}
@@ -146,17 +166,52 @@ func (r *Runner) Run(ctx context.Context, l log.Logger, opts *options.Terragrunt
 		return nil
 	}
 	r.queue.FailFast = opts.FailFast
+	r.queue.MaxFailures = opts.QueueMaxFailures
 	r.queue.IgnoreDependencyOrder = opts.IgnoreDependencyOrder
+
+	r.queue.FailurePolicies = make(map[string]string, len(r.Stack.Units))
+	for _, u := range r.Stack.Units {
+		r.queue.FailurePolicies[u.Path] = u.FailurePolicy()
+	}
+
+	limiter, err := common.NewParallelismLimiter(r.Stack.Units, opts.WorkingDir)
+	if err != nil {
+		return err
+	}
+
 	controller := NewController(
 		r.queue,
 		r.Stack.Units,
 		WithRunner(taskRun),
 		WithMaxConcurrency(opts.Parallelism),
+		WithParallelismLimiter(limiter),
 	)
 
 	return controller.Run(ctx, l)
 }
 
+// outsideApplyWindow records in the report that the given unit is skipped, as its apply window is closed.
+func (r *Runner) outsideApplyWindow(u *common.Unit, window *config.ApplyWindow) {
+	if !u.TerragruntOptions.Experiments.Evaluate(experiment.Report) {
+		return
+	}
+
+	run, err := r.Stack.Report.EnsureRun(u.Path)
+	if err != nil {
+		u.Logger.Errorf("Error ensuring run for unit %s: %v", u.Path, err)
+		return
+	}
+
+	if err := r.Stack.Report.EndRun(
+		run.Path,
+		report.WithResult(report.ResultExcluded),
+		report.WithReason(report.ReasonApplyWindow),
+		report.WithCauseApplyWindow(window.Name),
+	); err != nil {
+		u.Logger.Errorf("Error ending run for unit %s: %v", u.Path, err)
+	}
+}
+
 // handleApplyDestroy handles logic for apply and destroy commands.
 func (r *Runner) handleApplyDestroy(l log.Logger, opts *options.TerragruntOptions) {
 	if opts.RunAllAutoApprove {
@@ -0,0 +1,63 @@
+package runnerpool
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/discovery"
+	"github.com/gruntwork-io/terragrunt/internal/report"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+// unitWeights returns the expected durations of the runs of the discovered units, by path, so the queue starts the
+// longest running units of each dependency level first. The weight of a unit is its `weight` attribute, in seconds,
+// or else how long it took in the last run written to the report file, if there is one.
+func unitWeights(l log.Logger, opts *options.TerragruntOptions, discovered discovery.DiscoveredConfigs) map[string]time.Duration {
+	weights := map[string]time.Duration{}
+
+	if opts.ReportFile != "" {
+		reportFile := opts.ReportFile
+		if !filepath.IsAbs(reportFile) {
+			reportFile = filepath.Join(opts.WorkingDir, reportFile)
+		}
+
+		format := opts.ReportFormat
+		if format == "" {
+			format = report.FormatCSV
+		}
+
+		durations, err := report.ReadDurations(reportFile, format, opts.WorkingDir)
+
+		switch {
+		case err == nil:
+			weights = durations
+		case !os.IsNotExist(err):
+			l.Warnf("Failed to read the durations of the last run from %s, ordering the units without them: %v", reportFile, err)
+		}
+	}
+
+	for _, cfg := range discovered {
+		if cfg.Parsed != nil && cfg.Parsed.Weight != nil {
+			weights[cfg.Path] = time.Duration(*cfg.Parsed.Weight) * time.Second
+		}
+	}
+
+	return weights
+}
+
+// unitPriorities returns the priorities of the discovered units, by path, so the queue starts the highest priority
+// units of each dependency level first, see common.QueuePriority.
+func unitPriorities(opts *options.TerragruntOptions, discovered discovery.DiscoveredConfigs) map[string]int {
+	priorities := map[string]int{}
+
+	for _, cfg := range discovered {
+		if priority := common.QueuePriority(cfg.Parsed, opts.QueueTagPriorities); priority != 0 {
+			priorities[cfg.Path] = priority
+		}
+	}
+
+	return priorities
+}
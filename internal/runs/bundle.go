@@ -0,0 +1,198 @@
+package runs
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// RedactedValue replaces the values of the env vars holding secrets in the run bundles.
+const RedactedValue = "REDACTED"
+
+// maxBundleLineSize is the max size of an invocation in a run bundle, whose output can be large.
+const maxBundleLineSize = 64 * 1024 * 1024
+
+// secretEnvNameRe matches the names of the env vars holding secrets, e.g. AWS_SECRET_ACCESS_KEY or GITHUB_TOKEN.
+var secretEnvNameRe = regexp.MustCompile(`(?i)(secret|token|password|passwd|credential|private|session|auth|key)`)
+
+// Invocation is the record of an invocation of OpenTofu/Terraform in a run bundle.
+type Invocation struct {
+	StartedAt time.Time `json:"started_at"`
+	// Env is the env of the invocation, with the values of the env vars holding secrets redacted.
+	Env        map[string]string `json:"env"`
+	WorkingDir string            `json:"working_dir"`
+	// ContentHash is the SHA256 hash of the content of the working dir when the invocation started.
+	ContentHash string   `json:"content_hash"`
+	Command     string   `json:"command"`
+	Stdout      string   `json:"stdout"`
+	Stderr      string   `json:"stderr"`
+	Error       string   `json:"error,omitempty"`
+	Args        []string `json:"args"`
+	Sequence    int      `json:"sequence"`
+	ExitCode    int      `json:"exit_code"`
+	DurationMS  int64    `json:"duration_ms"`
+}
+
+// Recorder records the invocations of a run into a run bundle, a JSON Lines file with an invocation per line, in the
+// order they finished in.
+type Recorder struct {
+	path     string
+	sequence int
+	mu       sync.Mutex
+}
+
+// NewRecorder returns a recorder of the invocations of a run into the bundle at the given path, replacing the bundle
+// of a previous run.
+func NewRecorder(path string) (*Recorder, error) {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return nil, errors.New(err)
+	}
+
+	if err := os.WriteFile(path, nil, 0600); err != nil { //nolint:mnd
+		return nil, errors.New(err)
+	}
+
+	return &Recorder{path: path}, nil
+}
+
+// Path returns the path of the run bundle.
+func (recorder *Recorder) Path() string {
+	return recorder.path
+}
+
+// Record appends the given invocation to the run bundle, numbering it.
+func (recorder *Recorder) Record(invocation *Invocation) error {
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+
+	recorder.sequence++
+	invocation.Sequence = recorder.sequence
+
+	data, err := json.Marshal(invocation)
+	if err != nil {
+		return errors.New(err)
+	}
+
+	file, err := os.OpenFile(recorder.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600) //nolint:mnd
+	if err != nil {
+		return errors.New(err)
+	}
+	defer file.Close() //nolint:errcheck
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// ReadBundle returns the invocations recorded in the run bundle at the given path, in the order they were recorded in.
+func ReadBundle(path string) ([]*Invocation, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, errors.New(err)
+	}
+	defer file.Close() //nolint:errcheck
+
+	invocations := []*Invocation{}
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, maxBundleLineSize)
+
+	for line := 1; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		invocation := &Invocation{}
+		if err := json.Unmarshal(scanner.Bytes(), invocation); err != nil {
+			return nil, errors.Errorf("invalid invocation at line %d of run bundle %s: %w", line, path, err)
+		}
+
+		invocations = append(invocations, invocation)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, errors.New(err)
+	}
+
+	return invocations, nil
+}
+
+// RedactEnv returns the given env, with the values of the env vars whose names look like they hold secrets replaced
+// with RedactedValue, and the other values passed through the given redact function, e.g. to redact the values marked
+// as sensitive.
+func RedactEnv(env map[string]string, redact func(string) string) map[string]string {
+	redacted := make(map[string]string, len(env))
+
+	for name, value := range env {
+		if secretEnvNameRe.MatchString(name) {
+			redacted[name] = RedactedValue
+			continue
+		}
+
+		redacted[name] = redact(value)
+	}
+
+	return redacted
+}
+
+// ContentHash returns the SHA256 hash of the paths and contents of the files in the given dir, leaving out the
+// `.terraform` dirs, holding the providers and modules installed by init.
+func ContentHash(dir string) (string, error) {
+	hash := sha256.New()
+
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if entry.Name() == ".terraform" {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close() //nolint:errcheck
+
+		hash.Write([]byte(filepath.ToSlash(relPath) + "\x00"))
+
+		if _, err := io.Copy(hash, file); err != nil {
+			return err
+		}
+
+		hash.Write([]byte{0})
+
+		return nil
+	})
+	if err != nil {
+		return "", errors.New(err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
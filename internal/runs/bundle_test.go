@@ -0,0 +1,90 @@
+package runs_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/internal/runs"
+)
+
+func TestRecorder(t *testing.T) {
+	t.Parallel()
+
+	bundle := filepath.Join(t.TempDir(), "bundles", "run.jsonl")
+
+	recorder, err := runs.NewRecorder(bundle)
+	require.NoError(t, err)
+
+	require.NoError(t, recorder.Record(&runs.Invocation{Command: "tofu", Args: []string{"init"}, WorkingDir: "/live/vpc"}))
+	require.NoError(t, recorder.Record(&runs.Invocation{Command: "tofu", Args: []string{"plan"}, WorkingDir: "/live/vpc", Stdout: "No changes.\n", ExitCode: 2}))
+
+	invocations, err := runs.ReadBundle(bundle)
+	require.NoError(t, err)
+	require.Len(t, invocations, 2)
+
+	assert.Equal(t, 1, invocations[0].Sequence)
+	assert.Equal(t, []string{"init"}, invocations[0].Args)
+	assert.Equal(t, 2, invocations[1].Sequence)
+	assert.Equal(t, "No changes.\n", invocations[1].Stdout)
+	assert.Equal(t, 2, invocations[1].ExitCode)
+
+	// A new recorder replaces the bundle of the previous run.
+	_, err = runs.NewRecorder(bundle)
+	require.NoError(t, err)
+
+	invocations, err = runs.ReadBundle(bundle)
+	require.NoError(t, err)
+	assert.Empty(t, invocations)
+}
+
+func TestRedactEnv(t *testing.T) {
+	t.Parallel()
+
+	env := runs.RedactEnv(map[string]string{
+		"AWS_REGION":            "eu-west-1",
+		"AWS_SECRET_ACCESS_KEY": "secret",
+		"GITHUB_TOKEN":          "token",
+		"TF_VAR_db_password":    "hunter2",
+		"TF_VAR_name":           "app-sensitive",
+	}, func(value string) string {
+		return strings.ReplaceAll(value, "sensitive", "******")
+	})
+
+	assert.Equal(t, map[string]string{
+		"AWS_REGION":            "eu-west-1",
+		"AWS_SECRET_ACCESS_KEY": runs.RedactedValue,
+		"GITHUB_TOKEN":          runs.RedactedValue,
+		"TF_VAR_db_password":    runs.RedactedValue,
+		"TF_VAR_name":           "app-******",
+	}, env)
+}
+
+func TestContentHash(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.tf"), []byte(`resource "null_resource" "a" {}`), 0644))
+
+	hash, err := runs.ContentHash(dir)
+	require.NoError(t, err)
+
+	// The providers and modules installed by init don't change the hash.
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".terraform", "providers"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".terraform", "providers", "null"), []byte("binary"), 0644))
+
+	unchanged, err := runs.ContentHash(dir)
+	require.NoError(t, err)
+	assert.Equal(t, hash, unchanged)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.tf"), []byte(`resource "null_resource" "b" {}`), 0644))
+
+	changed, err := runs.ContentHash(dir)
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, changed)
+}
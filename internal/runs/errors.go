@@ -0,0 +1,23 @@
+package runs
+
+import "fmt"
+
+// RunNotFoundError is returned when there is no record of the run with the given ID.
+type RunNotFoundError struct {
+	ID  string
+	Dir string
+}
+
+func (err RunNotFoundError) Error() string {
+	return fmt.Sprintf("no run %q found in %s", err.ID, err.Dir)
+}
+
+// RunNotRunningError is returned when aborting a run that already finished.
+type RunNotRunningError struct {
+	ID     string
+	Status Status
+}
+
+func (err RunNotRunningError) Error() string {
+	return fmt.Sprintf("run %s can't be aborted, as it is %s", err.ID, err.Status)
+}
@@ -0,0 +1,218 @@
+// Package runs provides durable records of the runs of run --all, so long-lived runs can be inspected, and aborted,
+// from other processes while they are running, and the run bundles recording the invocations of OpenTofu/Terraform of
+// a run, to replay them when diagnosing it.
+package runs
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// Status is the status of a run.
+type Status string
+
+// Outcome is the outcome of a unit of a run.
+type Outcome string
+
+// DefaultDirName is the name of the dir in the download dir the records of the runs are stored in by default.
+const DefaultDirName = "runs"
+
+const (
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+	StatusAborted   Status = "aborted"
+
+	OutcomePending   Outcome = "pending"
+	OutcomeRunning   Outcome = "running"
+	OutcomeSucceeded Outcome = "succeeded"
+	OutcomeFailed    Outcome = "failed"
+
+	recordExt = ".json"
+	abortExt  = ".abort"
+
+	idRandomBytes = 3
+)
+
+// Run is the record of a run of run --all.
+type Run struct {
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	ID         string     `json:"id"`
+	Command    string     `json:"command"`
+	WorkingDir string     `json:"working_dir"`
+	Status     Status     `json:"status"`
+	Args       []string   `json:"args"`
+	Units      []*Unit    `json:"units"`
+	PID        int        `json:"pid"`
+}
+
+// Unit is the record of a unit of a run.
+type Unit struct {
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	// Path is the path of the unit relative to the working dir of the run.
+	Path    string  `json:"path"`
+	Outcome Outcome `json:"outcome"`
+	Error   string  `json:"error,omitempty"`
+}
+
+// NewID returns a new run ID, made of the time it is created at, so IDs sort by time, and of a random suffix.
+func NewID() (string, error) {
+	suffix := make([]byte, idRandomBytes)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", errors.New(err)
+	}
+
+	return time.Now().UTC().Format("20060102T150405") + "-" + hex.EncodeToString(suffix), nil
+}
+
+// Unit returns the record of the unit at the given path, or nil if the run has none.
+func (run *Run) Unit(path string) *Unit {
+	for _, unit := range run.Units {
+		if unit.Path == path {
+			return unit
+		}
+	}
+
+	return nil
+}
+
+// Store stores the records of the runs as JSON files in a dir, along with the requests to abort them.
+type Store struct {
+	Dir string
+}
+
+// NewStore returns a store of the records of the runs in the given dir.
+func NewStore(dir string) *Store {
+	return &Store{Dir: dir}
+}
+
+// Save saves the record of the given run. The record is written to a temporary file renamed over the record, so
+// readers never see a partially written record.
+func (store *Store) Save(run *Run) error {
+	data, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return errors.New(err)
+	}
+
+	if err := os.MkdirAll(store.Dir, os.ModePerm); err != nil {
+		return errors.New(err)
+	}
+
+	path := store.path(run.ID, recordExt)
+	tmpPath := path + ".tmp"
+
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil { //nolint:mnd
+		return errors.New(err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// Load loads the record of the run with the given ID.
+func (store *Store) Load(id string) (*Run, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) {
+		return nil, errors.New(RunNotFoundError{ID: id, Dir: store.Dir})
+	}
+
+	data, err := os.ReadFile(store.path(id, recordExt))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errors.New(RunNotFoundError{ID: id, Dir: store.Dir})
+		}
+
+		return nil, errors.New(err)
+	}
+
+	run := &Run{}
+	if err := json.Unmarshal(data, run); err != nil {
+		return nil, errors.Errorf("invalid record of run %s: %w", id, err)
+	}
+
+	return run, nil
+}
+
+// List returns the records of all the runs, the latest first.
+func (store *Store) List() ([]*Run, error) {
+	entries, err := os.ReadDir(store.Dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, errors.New(err)
+	}
+
+	runs := []*Run{}
+
+	for _, entry := range entries {
+		id, ok := strings.CutSuffix(entry.Name(), recordExt)
+		if !ok || entry.IsDir() {
+			continue
+		}
+
+		run, err := store.Load(id)
+		if err != nil {
+			return nil, err
+		}
+
+		runs = append(runs, run)
+	}
+
+	slices.SortFunc(runs, func(a, b *Run) int {
+		return b.StartedAt.Compare(a.StartedAt)
+	})
+
+	return runs, nil
+}
+
+// RequestAbort requests the run with the given ID to be aborted. The request is picked up by the process running it.
+func (store *Store) RequestAbort(id string) error {
+	run, err := store.Load(id)
+	if err != nil {
+		return err
+	}
+
+	if run.Status != StatusRunning {
+		return errors.New(RunNotRunningError{ID: id, Status: run.Status})
+	}
+
+	if err := os.WriteFile(store.path(id, abortExt), nil, 0644); err != nil { //nolint:mnd
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// AbortRequested returns true if the run with the given ID was requested to be aborted.
+func (store *Store) AbortRequested(id string) bool {
+	_, err := os.Stat(store.path(id, abortExt))
+
+	return err == nil
+}
+
+// ClearAbort removes the request to abort the run with the given ID, once it is aborted.
+func (store *Store) ClearAbort(id string) error {
+	if err := os.Remove(store.path(id, abortExt)); err != nil && !os.IsNotExist(err) {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+func (store *Store) path(id, ext string) string {
+	return filepath.Join(store.Dir, id+ext)
+}
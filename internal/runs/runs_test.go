@@ -0,0 +1,66 @@
+package runs_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/internal/runs"
+)
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	store := runs.NewStore(t.TempDir())
+
+	list, err := store.List()
+	require.NoError(t, err)
+	assert.Empty(t, list)
+
+	started := time.Now().UTC().Round(0)
+
+	older := &runs.Run{ID: "older", Status: runs.StatusSucceeded, StartedAt: started.Add(-time.Hour)}
+	latest := &runs.Run{
+		ID:        "latest",
+		Command:   "apply",
+		Status:    runs.StatusRunning,
+		StartedAt: started,
+		Units:     []*runs.Unit{{Path: "vpc", Outcome: runs.OutcomeRunning}},
+	}
+
+	require.NoError(t, store.Save(older))
+	require.NoError(t, store.Save(latest))
+
+	loaded, err := store.Load("latest")
+	require.NoError(t, err)
+	assert.Equal(t, latest, loaded)
+	assert.Equal(t, runs.OutcomeRunning, loaded.Unit("vpc").Outcome)
+
+	list, err = store.List()
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "latest", list[0].ID)
+	assert.Equal(t, "older", list[1].ID)
+
+	var notFound runs.RunNotFoundError
+
+	_, err = store.Load("missing")
+	require.ErrorAs(t, err, &notFound)
+
+	_, err = store.Load("../latest")
+	require.ErrorAs(t, err, &notFound)
+
+	// Only running runs can be aborted.
+	var notRunning runs.RunNotRunningError
+
+	require.ErrorAs(t, store.RequestAbort("older"), &notRunning)
+	assert.False(t, store.AbortRequested("older"))
+
+	require.NoError(t, store.RequestAbort("latest"))
+	assert.True(t, store.AbortRequested("latest"))
+
+	require.NoError(t, store.ClearAbort("latest"))
+	assert.False(t, store.AbortRequested("latest"))
+}
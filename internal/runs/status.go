@@ -0,0 +1,162 @@
+package runs
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"html/template"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// statusShutdownTimeout is how long the status server waits for the requests being served when it is closed.
+const statusShutdownTimeout = 5 * time.Second
+
+// statusReadHeaderTimeout is how long the status server waits for the headers of a request.
+const statusReadHeaderTimeout = 10 * time.Second
+
+//go:embed templates/status.html
+var statusTemplateText string
+
+var statusTemplate = template.Must(template.New("status").Funcs(template.FuncMap{
+	"duration": func(ms int64) string {
+		return (time.Duration(ms) * time.Millisecond).Round(time.Second).String()
+	},
+}).Parse(statusTemplateText))
+
+// LiveStatus is the status of a run while it is running, as served by the status server.
+type LiveStatus struct {
+	StartedAt  time.Time     `json:"started_at"`
+	FinishedAt *time.Time    `json:"finished_at,omitempty"`
+	ID         string        `json:"id"`
+	Command    string        `json:"command"`
+	WorkingDir string        `json:"working_dir"`
+	Status     Status        `json:"status"`
+	Units      []*UnitStatus `json:"units"`
+	Queue      QueueStatus   `json:"queue"`
+	DurationMS int64         `json:"duration_ms"`
+}
+
+// QueueStatus is the number of units of a run with each outcome.
+type QueueStatus struct {
+	Pending   int `json:"pending"`
+	Running   int `json:"running"`
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+}
+
+// UnitStatus is the status of a unit of a run while it is running.
+type UnitStatus struct {
+	Unit
+	// DurationMS is how long the unit ran for, or has been running for if it is still running.
+	DurationMS int64 `json:"duration_ms"`
+}
+
+// NewLiveStatus returns the status of the given run at the given time, copying the records of its units, so the status
+// can be served while the run goes on.
+func NewLiveStatus(run *Run, now time.Time) *LiveStatus {
+	status := &LiveStatus{
+		StartedAt:  run.StartedAt,
+		FinishedAt: run.FinishedAt,
+		ID:         run.ID,
+		Command:    run.Command,
+		WorkingDir: run.WorkingDir,
+		Status:     run.Status,
+		Units:      make([]*UnitStatus, 0, len(run.Units)),
+		DurationMS: elapsed(&run.StartedAt, run.FinishedAt, now),
+	}
+
+	for _, unit := range run.Units {
+		switch unit.Outcome {
+		case OutcomePending:
+			status.Queue.Pending++
+		case OutcomeRunning:
+			status.Queue.Running++
+		case OutcomeSucceeded:
+			status.Queue.Succeeded++
+		case OutcomeFailed:
+			status.Queue.Failed++
+		}
+
+		status.Units = append(status.Units, &UnitStatus{
+			Unit:       *unit,
+			DurationMS: elapsed(unit.StartedAt, unit.FinishedAt, now),
+		})
+	}
+
+	return status
+}
+
+// elapsed returns the milliseconds between the given start and finish, or the given time if it didn't finish, or 0 if
+// it didn't start.
+func elapsed(startedAt, finishedAt *time.Time, now time.Time) int64 {
+	if startedAt == nil || startedAt.IsZero() {
+		return 0
+	}
+
+	if finishedAt != nil {
+		now = *finishedAt
+	}
+
+	return now.Sub(*startedAt).Milliseconds()
+}
+
+// StatusServer serves the live status of a run over HTTP, as JSON at /status, and as an HTML page at /.
+type StatusServer struct {
+	server   *http.Server
+	listener net.Listener
+}
+
+// ServeStatus starts serving the status returned by the given function on the given address, until the returned
+// server is closed.
+func ServeStatus(address string, status func() *LiveStatus) (*StatusServer, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, errors.Errorf("failed to serve the status of the run on %s: %w", address, err)
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /status", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(status()) //nolint:errcheck
+	})
+
+	mux.HandleFunc("GET /{$}", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+		statusTemplate.Execute(w, status()) //nolint:errcheck
+	})
+
+	server := &StatusServer{
+		server:   &http.Server{Handler: mux, ReadHeaderTimeout: statusReadHeaderTimeout},
+		listener: listener,
+	}
+
+	go server.server.Serve(listener) //nolint:errcheck
+
+	return server, nil
+}
+
+// Addr returns the address the status is served on.
+func (server *StatusServer) Addr() string {
+	return server.listener.Addr().String()
+}
+
+// Close stops serving the status, waiting for the requests being served.
+func (server *StatusServer) Close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), statusShutdownTimeout)
+	defer cancel()
+
+	if err := server.server.Shutdown(ctx); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
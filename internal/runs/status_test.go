@@ -0,0 +1,95 @@
+package runs_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/internal/runs"
+)
+
+func TestNewLiveStatus(t *testing.T) {
+	t.Parallel()
+
+	startedAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	vpcStartedAt := startedAt.Add(time.Second)
+	vpcFinishedAt := startedAt.Add(31 * time.Second)
+	appStartedAt := startedAt.Add(32 * time.Second)
+
+	run := &runs.Run{
+		StartedAt: startedAt,
+		ID:        "20250101T100000-abcdef",
+		Command:   "apply",
+		Status:    runs.StatusRunning,
+		Units: []*runs.Unit{
+			{Path: "app", Outcome: runs.OutcomeRunning, StartedAt: &appStartedAt},
+			{Path: "logs", Outcome: runs.OutcomePending},
+			{Path: "vpc", Outcome: runs.OutcomeSucceeded, StartedAt: &vpcStartedAt, FinishedAt: &vpcFinishedAt},
+		},
+	}
+
+	status := runs.NewLiveStatus(run, startedAt.Add(time.Minute))
+
+	assert.Equal(t, int64(60000), status.DurationMS)
+	assert.Equal(t, runs.QueueStatus{Pending: 1, Running: 1, Succeeded: 1}, status.Queue)
+
+	require.Len(t, status.Units, 3)
+	assert.Equal(t, int64(28000), status.Units[0].DurationMS, "app is still running")
+	assert.Equal(t, int64(0), status.Units[1].DurationMS, "logs didn't start")
+	assert.Equal(t, int64(30000), status.Units[2].DurationMS, "vpc finished")
+}
+
+func TestServeStatus(t *testing.T) {
+	t.Parallel()
+
+	run := &runs.Run{
+		StartedAt: time.Now(),
+		ID:        "20250101T100000-abcdef",
+		Command:   "plan",
+		Status:    runs.StatusRunning,
+		Units:     []*runs.Unit{{Path: "vpc", Outcome: runs.OutcomeFailed, Error: "exit status 1"}},
+	}
+
+	server, err := runs.ServeStatus("127.0.0.1:0", func() *runs.LiveStatus {
+		return runs.NewLiveStatus(run, time.Now())
+	})
+	require.NoError(t, err)
+
+	defer server.Close() //nolint:errcheck
+
+	resp, err := http.Get("http://" + server.Addr() + "/status")
+	require.NoError(t, err)
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+	status := &runs.LiveStatus{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(status))
+	assert.Equal(t, run.ID, status.ID)
+	assert.Equal(t, 1, status.Queue.Failed)
+	require.Len(t, status.Units, 1)
+	assert.Equal(t, "exit status 1", status.Units[0].Error)
+
+	page, err := http.Get("http://" + server.Addr() + "/")
+	require.NoError(t, err)
+
+	defer page.Body.Close() //nolint:errcheck
+
+	body, err := io.ReadAll(page.Body)
+	require.NoError(t, err)
+	assert.Contains(t, string(body), "Terragrunt Run 20250101T100000-abcdef")
+	assert.Contains(t, string(body), `<td class="outcome failed">failed</td>`)
+
+	notFound, err := http.Get("http://" + server.Addr() + "/unknown")
+	require.NoError(t, err)
+	notFound.Body.Close() //nolint:errcheck
+
+	assert.Equal(t, http.StatusNotFound, notFound.StatusCode)
+}
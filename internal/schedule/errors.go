@@ -0,0 +1,13 @@
+package schedule
+
+import "fmt"
+
+// InvalidCronError is returned when a cron expression is malformed.
+type InvalidCronError struct {
+	Expr   string
+	Reason string
+}
+
+func (err InvalidCronError) Error() string {
+	return fmt.Sprintf("invalid cron expression %q: %s", err.Expr, err.Reason)
+}
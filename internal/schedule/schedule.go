@@ -0,0 +1,185 @@
+// Package schedule provides the cron expressions of the apply windows of the units, of the standard form
+// `<minute> <hour> <day of month> <month> <day of week>`, e.g. `0 22 * * 1-5` for 22:00 on weekdays.
+//
+// Each field is `*`, a value, a range, e.g. `1-5`, or a list of them, e.g. `1,3,5-7`, optionally with a step, e.g.
+// `*/15`. Days of week are 0 to 7, 0 and 7 both being Sunday. As in cron, when both the day of month and the day of
+// week are restricted, a time matches if either of them matches.
+package schedule
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// maxSearchYears is how far in the future Next looks for a matching time, so expressions never matching, e.g. for
+// February 30, don't loop forever.
+const maxSearchYears = 5
+
+// field is the range of the values of a field of a cron expression.
+type field struct {
+	name     string
+	min, max int
+}
+
+var fields = []field{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12},
+	{name: "day of week", min: 0, max: 7},
+}
+
+// Cron is a parsed cron expression.
+type Cron struct {
+	expr string
+	// values are the values matched by each field, indexed by value.
+	values [5][]bool
+	// anyDayOfMonth and anyDayOfWeek are true if the day of month, and the day of week, fields are `*`.
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+}
+
+// Parse parses the given cron expression.
+func Parse(expr string) (*Cron, error) {
+	parts := strings.Fields(expr)
+	if len(parts) != len(fields) {
+		return nil, errors.New(InvalidCronError{Expr: expr, Reason: "expected 5 fields, <minute> <hour> <day of month> <month> <day of week>"})
+	}
+
+	cron := &Cron{
+		expr:          expr,
+		anyDayOfMonth: parts[2] == "*",
+		anyDayOfWeek:  parts[4] == "*",
+	}
+
+	for i, part := range parts {
+		values, err := parseField(part, fields[i])
+		if err != nil {
+			return nil, errors.New(InvalidCronError{Expr: expr, Reason: err.Error()})
+		}
+
+		cron.values[i] = values
+	}
+
+	// Sunday is both 0 and 7.
+	if cron.values[4][7] {
+		cron.values[4][0] = true
+	}
+
+	return cron, nil
+}
+
+// String returns the cron expression.
+func (cron *Cron) String() string {
+	return cron.expr
+}
+
+// Matches returns true if the minute of the given time matches the expression, in the location of the time.
+func (cron *Cron) Matches(t time.Time) bool {
+	return cron.values[0][t.Minute()] && cron.values[1][t.Hour()] && cron.values[3][int(t.Month())] && cron.matchesDay(t)
+}
+
+// Next returns the first minute at or after the given time matching the expression, in the location of the time, and
+// false if none matches in the next years.
+func (cron *Cron) Next(t time.Time) (time.Time, bool) {
+	// Start at the next whole minute, unless the time is already one.
+	if truncated := t.Truncate(time.Minute); !truncated.Equal(t) {
+		t = truncated.Add(time.Minute)
+	}
+
+	limit := t.AddDate(maxSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case !cron.values[3][int(t.Month())]:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !cron.matchesDay(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case !cron.values[1][t.Hour()]:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+		case !cron.values[0][t.Minute()]:
+			t = t.Add(time.Minute)
+		default:
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func (cron *Cron) matchesDay(t time.Time) bool {
+	dayOfMonth := cron.values[2][t.Day()]
+	dayOfWeek := cron.values[4][int(t.Weekday())]
+
+	switch {
+	case cron.anyDayOfMonth && cron.anyDayOfWeek:
+		return true
+	case cron.anyDayOfMonth:
+		return dayOfWeek
+	case cron.anyDayOfWeek:
+		return dayOfMonth
+	default:
+		return dayOfMonth || dayOfWeek
+	}
+}
+
+// parseField returns the values, indexed by value, matched by the given field of a cron expression.
+func parseField(part string, f field) ([]bool, error) {
+	values := make([]bool, f.max+1)
+
+	for item := range strings.SplitSeq(part, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(item, "/")
+
+		step := 1
+
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepPart); err != nil || step < 1 {
+				return nil, errors.Errorf("invalid step %q of the %s field", stepPart, f.name)
+			}
+		}
+
+		low, high := f.min, f.max
+
+		if rangePart != "*" {
+			lowPart, highPart, isRange := strings.Cut(rangePart, "-")
+
+			var err error
+			if low, err = parseValue(lowPart, f); err != nil {
+				return nil, err
+			}
+
+			high = low
+
+			if isRange {
+				if high, err = parseValue(highPart, f); err != nil {
+					return nil, err
+				}
+			} else if hasStep {
+				high = f.max
+			}
+
+			if low > high {
+				return nil, errors.Errorf("invalid range %q of the %s field", rangePart, f.name)
+			}
+		}
+
+		for value := low; value <= high; value += step {
+			values[value] = true
+		}
+	}
+
+	return values, nil
+}
+
+func parseValue(part string, f field) (int, error) {
+	value, err := strconv.Atoi(part)
+	if err != nil || value < f.min || value > f.max {
+		return 0, errors.Errorf("invalid value %q of the %s field, expected %d to %d", part, f.name, f.min, f.max)
+	}
+
+	return value, nil
+}
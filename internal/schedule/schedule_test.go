@@ -0,0 +1,72 @@
+package schedule_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/internal/schedule"
+)
+
+func TestParseInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+	} {
+		_, err := schedule.Parse(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	t.Parallel()
+
+	// Wednesday.
+	now := time.Date(2025, 1, 1, 10, 30, 15, 0, time.UTC)
+
+	testCases := []struct {
+		expr     string
+		expected time.Time
+	}{
+		{"* * * * *", time.Date(2025, 1, 1, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2025, 1, 1, 10, 45, 0, 0, time.UTC)},
+		{"0 22 * * 1-5", time.Date(2025, 1, 1, 22, 0, 0, 0, time.UTC)},
+		{"0 8 * * 6,0", time.Date(2025, 1, 4, 8, 0, 0, 0, time.UTC)},
+		{"0 8 * * 7", time.Date(2025, 1, 5, 8, 0, 0, 0, time.UTC)},
+		{"0 0 1 */3 *", time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+		// With both days restricted, either of them matches.
+		{"0 9 15 * 5", time.Date(2025, 1, 3, 9, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tc := range testCases {
+		cron, err := schedule.Parse(tc.expr)
+		require.NoError(t, err)
+
+		next, ok := cron.Next(now)
+		require.True(t, ok, tc.expr)
+		assert.Equal(t, tc.expected, next, tc.expr)
+		assert.True(t, cron.Matches(next), tc.expr)
+	}
+}
+
+func TestCronNextNeverMatches(t *testing.T) {
+	t.Parallel()
+
+	cron, err := schedule.Parse("0 0 30 2 *")
+	require.NoError(t, err)
+
+	_, ok := cron.Next(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.False(t, ok)
+}
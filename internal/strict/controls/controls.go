@@ -49,6 +49,12 @@ const (
 
 	// BareInclude is the control that prevents the use of the `include` block without a label.
 	BareInclude = "bare-include"
+
+	// StrictConfigSchema is the control that prevents unknown blocks and attributes from being used in Terragrunt configurations.
+	StrictConfigSchema = "strict-config-schema"
+
+	// StaleDependencyOutputs is the control that prevents the use of cached dependency outputs older than the state of the dependency.
+	StaleDependencyOutputs = "stale-dependency-outputs"
 )
 
 //nolint:lll
@@ -183,6 +189,19 @@ func New() strict.Controls {
 			Error:       errors.New("Using an `include` block without a label is deprecated. Please use the `include` block with a label instead."),
 			Warning:     "Using an `include` block without a label is deprecated. Please use the `include` block with a label instead. For more information, see https://terragrunt.gruntwork.io/docs/migrate/bare-include/",
 		},
+		&Control{
+			Name:        StrictConfigSchema,
+			Description: "Throw an error when a Terragrunt configuration contains unknown blocks or attributes, instead of ignoring them when configurations are partially parsed.",
+			Category:    stageCategory,
+			Error:       errors.New("Unknown blocks and attributes are no longer supported in Terragrunt configurations."),
+			Warning:     "Found unknown blocks or attributes in Terragrunt configurations, which are ignored when discovering units and building the dependency graph. In a future version of Terragrunt, this will result in an error. Enable the `strict-config-schema` strict control to report them along with suggestions for the closest known names.",
+		},
+		&Control{
+			Name:        StaleDependencyOutputs,
+			Description: "Throw an error when the outputs of a dependency cached on disk are older than the state of the dependency, instead of fetching them again.",
+			Category:    stageCategory,
+			Error:       errors.New("The outputs of dependencies cached on disk must not be older than the state of the dependencies."),
+		},
 	}
 
 	return controls.Sort()
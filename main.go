@@ -25,6 +25,7 @@ func main() {
 		log.WithOutput(opts.ErrWriter),
 		log.WithLevel(options.DefaultLogLevel),
 		log.WithFormatter(format.NewFormatter(format.NewPrettyFormatPlaceholders())),
+		log.WithHooks(opts.Redactor),
 	)
 
 	// Immediately parse the `TG_LOG_LEVEL` environment variable, e.g. to set the TRACE level.
@@ -2,6 +2,7 @@
 package options
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -19,7 +20,10 @@ import (
 	"github.com/gruntwork-io/terragrunt/internal/cloner"
 	"github.com/gruntwork-io/terragrunt/internal/errors"
 	"github.com/gruntwork-io/terragrunt/internal/experiment"
+	"github.com/gruntwork-io/terragrunt/internal/ratelimit"
+	"github.com/gruntwork-io/terragrunt/internal/redact"
 	"github.com/gruntwork-io/terragrunt/internal/report"
+	"github.com/gruntwork-io/terragrunt/internal/runs"
 	"github.com/gruntwork-io/terragrunt/internal/strict"
 	"github.com/gruntwork-io/terragrunt/internal/strict/controls"
 	"github.com/gruntwork-io/terragrunt/pkg/log"
@@ -54,6 +58,13 @@ const (
 
 	DefaultIAMAssumeRoleDuration = 3600
 
+	// DefaultUnitTimeoutGracePeriod is the time in seconds OpenTofu/Terraform is given to exit once interrupted at the
+	// timeout of a unit, before it is killed.
+	DefaultUnitTimeoutGracePeriod = 30
+
+	// DefaultApprovalGateTimeout is the time in seconds `run --all apply` waits for the approval of its approval gate.
+	DefaultApprovalGateTimeout = 3600
+
 	minCommandLength = 2
 
 	defaultExcludesFile = ".terragrunt-excludes"
@@ -104,10 +115,15 @@ type TerragruntOptions struct {
 	ErrWriter io.Writer
 	// Version of terragrunt
 	TerragruntVersion *version.Version `clone:"shadowcopy"`
+	// FrozenTimestamp is the base timestamp captured once per run, returned by the `timestamp` function of all the
+	// units, so the time based values are consistent across a run.
+	FrozenTimestamp time.Time `clone:"shadowcopy"`
 	// FeatureFlags is a map of feature flags to enable.
 	FeatureFlags *xsync.MapOf[string, string] `clone:"shadowcopy"`
 	// Options to use engine for running IaC operations.
 	Engine *EngineOptions
+	// Options to dispatch the IaC operations of the unit to a remote runner agent.
+	Executor *ExecutorOptions
 	// Telemetry are telemetry options.
 	Telemetry *telemetry.Options
 	// Attributes to override in AWS provider nested within modules as part of the aws-provider-patch command.
@@ -118,12 +134,23 @@ type TerragruntOptions struct {
 	TerraformVersion *version.Version `clone:"shadowcopy"`
 	// ReadFiles is a map of files to the Units that read them using HCL functions in the unit.
 	ReadFiles *xsync.MapOf[string, []string] `clone:"shadowcopy"`
+	// Redactor redacts the sensitive values of the configurations from the logs and the output of the run.
+	Redactor *redact.Redactor `clone:"shadowcopy"`
+	// SpeculativeOutputs are the outputs the units of run --all plan --speculative-plan plan to have, in the format of
+	// `output -json`, by config path, used as the outputs of the dependencies of the units planned after them.
+	SpeculativeOutputs *xsync.MapOf[string, []byte] `clone:"shadowcopy"`
+	// RateLimiter throttles the aggregate rate of the calls the units make to cloud APIs, shared by all the units.
+	RateLimiter *ratelimit.Limiter `clone:"shadowcopy"`
+	// RunBundle records the invocations of OpenTofu/Terraform of all the units into the run bundle of --record-bundle.
+	RunBundle *runs.Recorder `clone:"shadowcopy"`
 	// Errors is a configuration for error handling.
 	Errors *ErrorsConfig
 	// Map to replace terraform source locations.
 	SourceMap map[string]string
 	// Environment variables at runtime
 	Env map[string]string
+	// Map of custom HCL function names to the paths of the plugin executables implementing them.
+	FunctionPlugins map[string]string
 	// StackAction is the action that should be performed on the stack.
 	StackAction string
 	// IAM Role options that should be used when authenticating to AWS.
@@ -163,6 +190,12 @@ type TerragruntOptions struct {
 	EngineCachePath string
 	// The command and arguments that can be used to fetch authentication configurations.
 	AuthProviderCmd string
+	// FeatureFlagSource is the address of the external source to resolve feature flags from.
+	FeatureFlagSource string
+	// FeatureFlagSourceToken is the bearer token sent to HTTP feature flag sources.
+	FeatureFlagSourceToken string
+	// AgeIdentityFile is the path to the age identities used to decrypt the encrypted values of the configs.
+	AgeIdentityFile string
 	// Folder to store JSON representation of output files.
 	JSONOutputFolder string
 	// Folder to store output files.
@@ -187,12 +220,70 @@ type TerragruntOptions struct {
 	ReportFormat report.Format
 	// Path to the report schema file.
 	ReportSchemaFile string
+	// Path to the HTML report file.
+	ReportHTMLFile string
+	// Path to the JUnit XML report file.
+	ReportJUnitFile string
+	// Directory the records of the runs of run --all are stored in.
+	RunsDir string
+	// Path of the run bundle to record the invocations of OpenTofu/Terraform of the run into.
+	RecordBundle string
+	// Address to serve the live status of the run of run --all on.
+	StatusAddress string
 	// CLI args that are intended for Terraform (i.e. all the CLI args except the --terragrunt ones)
 	TerraformCliArgs cli.Args
 	// Unix-style glob of directories to include when running *-all commands
 	IncludeDirs []string
 	// Unix-style glob of directories to exclude when running *-all commands
 	ExcludeDirs []string
+	// Tags of the units to include when running *-all commands
+	IncludeTags []string
+	// Tags of the units to exclude when running *-all commands
+	ExcludeTags []string
+	// Expressions filtering the units to run by their metadata when running *-all commands
+	QueueFilters []string
+	// Git ref to compare the working tree with, to only include the units affected by the changes since it when running
+	// *-all commands
+	QueueIncludeChanged string
+	// StackDirs are the dirs of the stacks whose units to run together when running *-all commands, rather than the
+	// units in the working dir, the dependencies between the units of different stacks being resolved across them
+	StackDirs []string
+	// QueueIncludeUnits are the dirs of the units to include when running *-all commands, along with their
+	// dependencies and dependents up to QueueIncludeDependencies and QueueIncludeDependents levels deep
+	QueueIncludeUnits []string
+	// QueueIncludeDependencies is how many levels of the dependencies of QueueIncludeUnits to include, all of them if
+	// negative
+	QueueIncludeDependencies int
+	// QueueIncludeDependents is how many levels of the dependents of QueueIncludeUnits to include, all of them if
+	// negative
+	QueueIncludeDependents int
+	// QueueShard is the shard of the units to run when running *-all commands, of the form <index>/<count>, so that a
+	// large stack can be split across parallel CI jobs
+	QueueShard string
+	// QueueTagPriorities are the priorities of the units in the queue of *-all commands, by tag, for the units that
+	// don't set a queue_priority attribute
+	QueueTagPriorities map[string]int
+	// FailurePolicy is the mode of the failure policy of the units that don't set one in their failure_policy block,
+	// i.e. what happens to the other units when a unit fails in *-all commands: continue, halt-dependents or halt-run
+	FailurePolicy string
+	// UnitTimeout is the timeout in seconds on running each unit in *-all commands, 0 for no timeout. Can be overridden
+	// with the run_timeout_sec attribute of a unit.
+	UnitTimeout int
+	// UnitTimeoutGracePeriod is the time in seconds a command is given to exit once interrupted as it timed out, before
+	// it is killed
+	UnitTimeoutGracePeriod int
+	// StageGate pauses `run --all apply` before each group of units of the run queue, until the group is approved
+	StageGate bool
+	// ApproveGroups is the number of groups of the run queue of `run --all apply` approved without prompting, enabling
+	// StageGate
+	ApproveGroups int
+	// ApprovalGate is the address of the external approval gate `run --all apply` waits for the approval of before
+	// running, see `approval.NewGate`
+	ApprovalGate string
+	// ApprovalGateToken is the token authenticating Terragrunt with the approval gate
+	ApprovalGateToken string
+	// ApprovalGateTimeout is the time in seconds `run --all apply` waits for the approval of the approval gate
+	ApprovalGateTimeout int
 	// RetryableErrors is an array of regular expressions with RE2 syntax that qualify for retrying
 	RetryableErrors []string
 	// Files with variables to be used in modules scaffolding.
@@ -217,6 +308,8 @@ type TerragruntOptions struct {
 	RetryMaxAttempts int
 	// Parallelism limits the number of commands to run concurrently during *-all commands
 	Parallelism int
+	// DependencyFetchParallelism limits the number of dependency outputs of a unit fetched concurrently
+	DependencyFetchParallelism int
 	// When searching the directory tree, this is the max folders to check before exiting with an error.
 	MaxFoldersToCheck int
 	// The port of the Terragrunt Provider Cache server.
@@ -255,6 +348,23 @@ type TerragruntOptions struct {
 	ExcludeByDefault bool
 	// This is an experimental feature, used to speed up dependency processing by getting the output from the state
 	FetchDependencyOutputFromState bool
+	// Cache the outputs of dependencies on disk, so they are reused across runs until the dependency is applied again
+	DependencyOutputCache bool
+	// If set to true, never cache the outputs of dependencies on disk, even if DependencyOutputCache is set
+	NoDependencyOutputCache bool
+	// The age recipients to encrypt the outputs of dependencies cached on disk to
+	DependencyOutputCacheAgeRecipients []string
+	// The AWS KMS key to encrypt the outputs of dependencies cached on disk with
+	DependencyOutputCacheKMSKeyID string
+	// DependencyOffline resolves the outputs of dependencies only from the outputs cached on disk and the mock outputs,
+	// without accessing the backends of the dependencies.
+	DependencyOffline bool
+	// The timeout in seconds on fetching the outputs of each dependency, 0 for no timeout
+	DependencyFetchTimeout int
+	// The path to the dependency policy file the dependencies between the units of the stack are checked against
+	DependencyPolicyFile string
+	// Record the outputs of dependencies to their mock outputs files
+	RecordDependencyMockOutputs bool
 	// True if is required to show dependent modules and confirm action
 	CheckDependentModules bool
 	// True if is required not to show dependent modules and confirm action
@@ -267,12 +377,21 @@ type TerragruntOptions struct {
 	EngineEnabled bool
 	// Whether we should automatically run terraform init if necessary when executing other commands
 	AutoInit bool
+	// ForceInit makes the next run of the command run init first, even if it doesn't seem to be needed. It is set when
+	// an error matches a retry block with the `retry-with-init` action.
+	ForceInit bool
 	// Allows to skip the output of all dependencies.
 	SkipOutput bool
 	// Whether we should prompt the user for confirmation or always assume "yes"
 	NonInteractive bool
 	// If set to true, apply all external dependencies when running *-all commands
 	IncludeExternalDependencies bool
+	// The max levels of external dependencies to include when running *-all commands, 0 for no limit
+	IncludeExternalDependenciesDepth int
+	// Path to a file listing the external dependencies to include without asking when running *-all commands
+	IncludeExternalDependenciesFile string
+	// The dirs of the external dependencies listed in IncludeExternalDependenciesFile
+	IncludeExternalDirs []string
 	// Skip checksum check for engine package.
 	EngineSkipChecksumCheck bool
 	// If set to true, skip any external dependencies when running *-all commands
@@ -281,6 +400,9 @@ type TerragruntOptions struct {
 	IgnoreDependencyOrder bool
 	// If set to true, continue running *-all commands even if a dependency has errors.
 	IgnoreDependencyErrors bool
+	// Number of units of run --all failing after which no more units are dispatched, the running units finishing. 0
+	// dispatches all the units.
+	QueueMaxFailures int
 	// Whether we should automatically run terraform with -auto-apply in run --all mode.
 	RunAllAutoApprove bool
 	// If set to true, delete the contents of the temporary folder before downloading Terraform source code into it
@@ -293,6 +415,16 @@ type TerragruntOptions struct {
 	HCLValidateShowConfigPath bool
 	// HCLValidateJSONOutput outputs the hcl validate result as a JSON string.
 	HCLValidateJSONOutput bool
+	// ValidateInputsStrict makes Terragrunt fail, instead of warning, when the inputs of a unit don't match the
+	// variables of its module.
+	ValidateInputsStrict bool
+	// PromptMissingInputs prompts the user for the values of the required variables of the module of a unit that are
+	// not set, when running a single unit in an interactive session.
+	PromptMissingInputs bool
+	// HCLLintFormat is the output format of the hcl lint findings.
+	HCLLintFormat string
+	// HCLLintConfigFile is the path to the lint config file, overriding the built-in rules and defining custom rules.
+	HCLLintConfigFile string
 	// If true, logs will be displayed in formatter key/value, by default logs are formatted in human-readable formatter.
 	DisableLogFormatting bool
 	// Headless is set when Terragrunt is running in headless mode.
@@ -307,6 +439,63 @@ type TerragruntOptions struct {
 	NoStackValidate bool
 	// RunAll runs the provided OpenTofu/Terraform command against a stack.
 	RunAll bool
+	// Watch runs the command of run --all again on the changed units and their dependents whenever their files change.
+	Watch bool
+	// Resume skips the units of run --all that succeeded in the last run of the same command, unless they changed since.
+	Resume bool
+	// RetryFailed skips the units of run --all except for the units that failed, or exited early, in the last run
+	// written to the report file.
+	RetryFailed bool
+	// SkipUnchanged skips the units of run --all whose fingerprint, and the fingerprints of whose dependencies, are the
+	// ones of their last successful apply.
+	SkipUnchanged bool
+	// FromArtifacts is the dir of the plans saved by run --all plan to apply with run --all apply.
+	FromArtifacts string
+	// DetailedExitCode makes run --all plan exit with 0 if no unit has changes, with 2 if some units have changes, and
+	// with 1 if some units failed, following DetailedExitCodePolicy.
+	DetailedExitCode bool
+	// DetailedExitCodePolicy is the policy of DetailedExitCode when some units have changes and others failed, all-clean
+	// or any-changes.
+	DetailedExitCodePolicy string
+	// PreventDestroyPolicy is what run --all destroy does with the units that set prevent_destroy before destroying
+	// anything: fail, or skip them along with the units they depend on. If empty, they fail when their turn comes.
+	PreventDestroyPolicy string
+	// SpeculativePlan plans the units of run --all plan with the outputs their dependencies plan to have, rather than
+	// with their current outputs.
+	SpeculativePlan bool
+	// PlanSummary outputs the summary of the changes planned by the units of run --all plan once the run finished.
+	PlanSummary bool
+	// PlanSummaryFile is the path of the JSON file the summary of the changes planned by run --all plan is written to.
+	PlanSummaryFile string
+	// CostEstimate estimates the monthly costs of the JSON plans of the units of run --all plan with Infracost once the
+	// run finished, adding them to the report.
+	CostEstimate bool
+	// CostEstimateBudget is the max increase of the total monthly cost estimated by CostEstimate, the run failing when
+	// it is exceeded. If empty, the increase isn't limited.
+	CostEstimateBudget string
+	// InfracostPath is the path of the Infracost binary run by CostEstimate, infracost on PATH by default.
+	InfracostPath string
+	// DriftCheck plans the units of run --all plan with `-refresh-only`, detecting the units whose infrastructure
+	// drifted from their state, and exits with 2 if any of them drifted, following DetailedExitCodePolicy.
+	DriftCheck bool
+	// DriftReportFile is the path of the JSON file the drift detected by DriftCheck is written to.
+	DriftReportFile string
+	// DriftWebhook is the URL the drift report of DriftCheck is posted to when any unit drifted.
+	DriftWebhook string
+	// QueueDryRun outputs the execution plan of run --all, without running anything.
+	QueueDryRun bool
+	// QueueDryRunFormat is the format of the execution plan of QueueDryRun, table or json.
+	QueueDryRunFormat string
+	// UnitLogFile is the path template of the files the logs of each unit of run --all are written to, e.g.
+	// `logs/{run_id}/{unit}.log`, relative to the working dir.
+	UnitLogFile string
+	// UnitLogFileLevel is the log level of the files of UnitLogFile, the log level of the console by default.
+	UnitLogFileLevel string
+	// UnitLogPrefix is the template of the prefix of the logs of each unit of run --all on the console, e.g. `{name}`.
+	UnitLogPrefix string
+	// UnitLogSuppressNoChanges replaces the output of the units of run --all with no changes by a single line on the
+	// console.
+	UnitLogSuppressNoChanges bool
 	// Graph runs the provided OpenTofu/Terraform against the graph of dependencies for the unit in the current working directory.
 	Graph bool
 	// BackendBootstrap automatically bootstraps backend infrastructure before attempting to use it.
@@ -317,6 +506,16 @@ type TerragruntOptions struct {
 	ForceBackendDelete bool
 	// ForceBackendMigrate forces the backend to be migrated, even if the bucket is not versioned.
 	ForceBackendMigrate bool
+	// LockBackendMigrateSource locks the state left at its previous backend once `backend migrate` migrated it.
+	LockBackendMigrateSource bool
+	// DeleteLockTables are the lock tables `backend migrate-locks` deletes, once no unit is locked with them.
+	DeleteLockTables []string
+	// ForceDeleteLockTable forces `backend migrate-locks` to delete the lock tables holding the items of states other
+	// than the ones of the units of the stack.
+	ForceDeleteLockTable bool
+	// BackendDriftPolicy is the policy `backend check` applies to the buckets that drifted from their config, instead of
+	// their `bucket_drift_policy`.
+	BackendDriftPolicy string
 	// SummaryDisable disables the summary output at the end of a run.
 	SummaryDisable bool
 	// SummaryPerUnit enables showing duration information for each unit in the summary.
@@ -397,6 +596,8 @@ func NewTerragruntOptionsWithWriters(stdout, stderr io.Writer) *TerragruntOption
 		Env:                            map[string]string{},
 		Source:                         "",
 		SourceMap:                      map[string]string{},
+		FunctionPlugins:                map[string]string{},
+		FrozenTimestamp:                time.Now().UTC().Truncate(time.Second),
 		SourceUpdate:                   false,
 		IgnoreDependencyErrors:         false,
 		IgnoreDependencyOrder:          false,
@@ -414,6 +615,9 @@ func NewTerragruntOptionsWithWriters(stdout, stderr io.Writer) *TerragruntOption
 		ModulesThatInclude:             []string{},
 		StrictInclude:                  false,
 		Parallelism:                    DefaultParallelism,
+		DependencyFetchParallelism:     DefaultParallelism,
+		UnitTimeoutGracePeriod:         DefaultUnitTimeoutGracePeriod,
+		ApprovalGateTimeout:            DefaultApprovalGateTimeout,
 		Check:                          false,
 		Diff:                           false,
 		FetchDependencyOutputFromState: false,
@@ -430,6 +634,8 @@ func NewTerragruntOptionsWithWriters(stdout, stderr io.Writer) *TerragruntOption
 		JSONOutputFolder:           "",
 		FeatureFlags:               xsync.NewMapOf[string, string](),
 		ReadFiles:                  xsync.NewMapOf[string, []string](),
+		Redactor:                   redact.New(),
+		RateLimiter:                ratelimit.New(),
 		StrictControls:             controls.New(),
 		Experiments:                experiment.NewExperiments(),
 		Telemetry:                  new(telemetry.Options),
@@ -688,6 +894,14 @@ type EngineOptions struct {
 	Type    string
 }
 
+// ExecutorOptions Options for the remote executor running the IaC operations of the unit.
+type ExecutorOptions struct {
+	Meta       map[string]any
+	Address    string
+	ForwardEnv []string
+	Insecure   bool
+}
+
 // ErrorsConfig extracted errors handling configuration.
 type ErrorsConfig struct {
 	Retry  map[string]*RetryConfig
@@ -696,10 +910,78 @@ type ErrorsConfig struct {
 
 // RetryConfig represents the configuration for retrying specific errors.
 type RetryConfig struct {
-	Name             string
-	RetryableErrors  []*ErrorsPattern
-	MaxAttempts      int
-	SleepIntervalSec int
+	Name                string
+	Action              RetryAction
+	Backoff             RetryBackoff
+	RetryableErrors     []*ErrorsPattern
+	ExitCodes           []int
+	MaxAttempts         int
+	SleepIntervalSec    int
+	MaxSleepIntervalSec int
+}
+
+// RetryAction is the action taken on the errors matching a retry block.
+type RetryAction string
+
+const (
+	// RetryActionRetry retries the failed operation.
+	RetryActionRetry RetryAction = "retry"
+	// RetryActionRetryWithInit runs init before retrying the failed operation, e.g. for errors caused by a stale
+	// provider or module cache.
+	RetryActionRetryWithInit RetryAction = "retry-with-init"
+	// RetryActionFail fails immediately, regardless of the other retry blocks matching the error.
+	RetryActionFail RetryAction = "fail"
+	// RetryActionWarnAndContinue logs a warning and continues as if the operation succeeded.
+	RetryActionWarnAndContinue RetryAction = "warn-and-continue"
+)
+
+// RetryActions are the valid actions of a retry block, in the order the blocks are evaluated.
+var RetryActions = []RetryAction{RetryActionFail, RetryActionWarnAndContinue, RetryActionRetryWithInit, RetryActionRetry}
+
+// RetryBackoff is the strategy used to compute the time to wait between retries.
+type RetryBackoff string
+
+const (
+	// RetryBackoffConstant waits the sleep interval between all the retries.
+	RetryBackoffConstant RetryBackoff = "constant"
+	// RetryBackoffExponential doubles the sleep interval after each retry, up to the max sleep interval.
+	RetryBackoffExponential RetryBackoff = "exponential"
+)
+
+// RetryBackoffs are the valid backoff strategies of a retry block.
+var RetryBackoffs = []RetryBackoff{RetryBackoffConstant, RetryBackoffExponential}
+
+// SleepIntervalForAttempt returns the time to wait, in seconds, before retrying the given attempt.
+func (retryConfig *RetryConfig) SleepIntervalForAttempt(currentAttempt int) int {
+	sleep := retryConfig.SleepIntervalSec
+	if retryConfig.Backoff != RetryBackoffExponential {
+		return sleep
+	}
+
+	for range currentAttempt - 1 {
+		sleep *= 2
+
+		if retryConfig.MaxSleepIntervalSec > 0 && sleep >= retryConfig.MaxSleepIntervalSec {
+			return retryConfig.MaxSleepIntervalSec
+		}
+	}
+
+	return sleep
+}
+
+// matches returns true if the given error, with the given message, matches the patterns or exit codes of the block.
+func (retryConfig *RetryConfig) matches(err error, errStr string) bool {
+	if matchesAnyRegexpPattern(errStr, retryConfig.RetryableErrors) {
+		return true
+	}
+
+	if len(retryConfig.ExitCodes) == 0 {
+		return false
+	}
+
+	exitCode, exitCodeErr := util.GetExitCode(err)
+
+	return exitCodeErr == nil && slices.Contains(retryConfig.ExitCodes, exitCode)
 }
 
 // IgnoreConfig represents the configuration for ignoring specific errors.
@@ -768,7 +1050,35 @@ func (opts *TerragruntOptions) RunWithErrorHandling(ctx context.Context, l log.L
 			return nil
 		}
 
+		if action.ShouldContinue {
+			l.Warnf("Continuing despite error matching retry block %s: %s", action.RetryBlockName, extractErrorMessage(err))
+
+			if opts.Experiments.Evaluate(experiment.Report) {
+				run, err := r.GetRun(opts.WorkingDir)
+				if err != nil {
+					return err
+				}
+
+				if err := r.EndRun(
+					run.Path,
+					report.WithResult(report.ResultSucceeded),
+					report.WithReason(report.ReasonErrorIgnored),
+					report.WithCauseRetryBlock(action.RetryBlockName),
+				); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}
+
 		if action.ShouldRetry {
+			if action.RetryWithInit {
+				l.Infof("Running init before retrying, as required by retry block %s", action.RetryBlockName)
+
+				opts.ForceInit = true
+			}
+
 			l.Warnf(
 				"Encountered retryable error: %s\nAttempt %d of %d. Waiting %d second(s) before retrying...",
 				action.RetryMessage,
@@ -789,6 +1099,7 @@ func (opts *TerragruntOptions) RunWithErrorHandling(ctx context.Context, l log.L
 					report.WithResult(report.ResultSucceeded),
 					report.WithReason(report.ReasonRetrySucceeded),
 					report.WithCauseRetryBlock(action.RetryBlockName),
+					report.WithRetry(),
 				); err != nil {
 					return err
 				}
@@ -842,6 +1153,10 @@ type ErrorAction struct {
 	RetrySleepSecs  int
 	ShouldIgnore    bool
 	ShouldRetry     bool
+	// RetryWithInit is set if init must be run before retrying.
+	RetryWithInit bool
+	// ShouldContinue is set if the error must be logged and the run continued as if it succeeded.
+	ShouldContinue bool
 }
 
 // ProcessError evaluates an error against the configuration and returns the appropriate action
@@ -871,27 +1186,67 @@ func (c *ErrorsConfig) ProcessError(l log.Logger, err error, currentAttempt int)
 		}
 	}
 
-	// Then check retry rules
-	for _, retryBlock := range c.Retry {
-		isRetryable := matchesAnyRegexpPattern(errStr, retryBlock.RetryableErrors)
-		if isRetryable {
-			if currentAttempt >= retryBlock.MaxAttempts {
-				return nil, errors.New(fmt.Sprintf("max retry attempts (%d) reached for error: %v",
-					retryBlock.MaxAttempts, err))
-			}
+	// Then check retry rules, the blocks failing the run first, so they take precedence over the blocks retrying it.
+	for _, retryBlock := range c.sortedRetryBlocks() {
+		if !retryBlock.matches(err, errStr) {
+			continue
+		}
+
+		action.RetryBlockName = retryBlock.Name
+
+		switch retryBlock.Action {
+		case RetryActionFail:
+			l.Debugf("Error matches retry block %s, failing without retrying", retryBlock.Name)
 
-			action.RetryMessage = retryBlock.Name
-			action.ShouldRetry = true
-			action.RetryAttempts = retryBlock.MaxAttempts
-			action.RetrySleepSecs = retryBlock.SleepIntervalSec
+			return nil, err
+		case RetryActionWarnAndContinue:
+			action.ShouldContinue = true
 
 			return action, nil
+		case RetryActionRetryWithInit:
+			action.RetryWithInit = true
+		}
+
+		if currentAttempt >= retryBlock.MaxAttempts {
+			return nil, errors.New(fmt.Sprintf("max retry attempts (%d) reached for error: %v",
+				retryBlock.MaxAttempts, err))
 		}
+
+		action.RetryMessage = retryBlock.Name
+		action.ShouldRetry = true
+		action.RetryAttempts = retryBlock.MaxAttempts
+		action.RetrySleepSecs = retryBlock.SleepIntervalForAttempt(currentAttempt)
+
+		return action, nil
 	}
 
 	return nil, err
 }
 
+// sortedRetryBlocks returns the retry blocks in the order they are evaluated: by action, in the order of RetryActions,
+// then by name.
+func (c *ErrorsConfig) sortedRetryBlocks() []*RetryConfig {
+	blocks := slices.Collect(maps.Values(c.Retry))
+
+	slices.SortFunc(blocks, func(a, b *RetryConfig) int {
+		if order := cmp.Compare(retryActionOrder(a.Action), retryActionOrder(b.Action)); order != 0 {
+			return order
+		}
+
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	return blocks
+}
+
+func retryActionOrder(action RetryAction) int {
+	if action == "" {
+		action = RetryActionRetry
+	}
+
+	return slices.Index(RetryActions, action)
+}
+
 func extractErrorMessage(err error) string {
 	// fetch the error string and remove any ASCII escape sequences
 	multilineText := log.RemoveAllASCISeq(err.Error())
@@ -0,0 +1,193 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/runner"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+)
+
+// Snapshot is the dependency graph of a stack along with the hash of the content of each unit, to be compared with
+// the snapshot of the stack at another point in time, e.g. at another git ref.
+type Snapshot struct {
+	Graph *Graph
+	// Hashes are the hashes of the content of the units, by unit path.
+	Hashes map[string]string
+}
+
+// Edge is a dependency of a unit on another unit.
+type Edge struct {
+	// From is the path of the unit depending on the unit at To.
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Diff is the difference between the snapshots of a stack.
+type Diff struct {
+	// AddedUnits are the paths of the units of the head snapshot missing from the base snapshot.
+	AddedUnits []string `json:"added_units"`
+	// RemovedUnits are the paths of the units of the base snapshot missing from the head snapshot.
+	RemovedUnits []string `json:"removed_units"`
+	// ChangedUnits are the paths of the units of both snapshots with a different content.
+	ChangedUnits []string `json:"changed_units"`
+	AddedEdges   []Edge   `json:"added_edges"`
+	RemovedEdges []Edge   `json:"removed_edges"`
+}
+
+// BuildSnapshot discovers the units of the stack in the working dir of the given options and returns their dependency
+// graph along with the hash of the content of each unit.
+func BuildSnapshot(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) (*Snapshot, error) {
+	stack, err := runner.FindStackInSubfolders(ctx, l, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	units := stack.GetStack().Units
+
+	snapshot := &Snapshot{
+		Graph:  fromUnits(units, opts),
+		Hashes: make(map[string]string, len(units)),
+	}
+
+	prefix := filepath.Dir(opts.TerragruntConfigPath) + "/"
+
+	for _, unit := range units {
+		hash, err := unit.ContentHash(units)
+		if err != nil {
+			return nil, err
+		}
+
+		snapshot.Hashes[strings.TrimPrefix(unit.Path, prefix)] = hash
+	}
+
+	return snapshot, nil
+}
+
+// DiffSnapshots returns the units and the edges added, removed or changed between the given snapshots, sorted by path.
+func DiffSnapshots(base, head *Snapshot) *Diff {
+	diff := &Diff{
+		AddedUnits:   []string{},
+		RemovedUnits: []string{},
+		ChangedUnits: []string{},
+		AddedEdges:   []Edge{},
+		RemovedEdges: []Edge{},
+	}
+
+	for _, path := range slices.Sorted(maps.Keys(head.Hashes)) {
+		baseHash, ok := base.Hashes[path]
+
+		switch {
+		case !ok:
+			diff.AddedUnits = append(diff.AddedUnits, path)
+		case baseHash != head.Hashes[path]:
+			diff.ChangedUnits = append(diff.ChangedUnits, path)
+		}
+	}
+
+	for _, path := range slices.Sorted(maps.Keys(base.Hashes)) {
+		if _, ok := head.Hashes[path]; !ok {
+			diff.RemovedUnits = append(diff.RemovedUnits, path)
+		}
+	}
+
+	baseEdges, headEdges := base.Graph.edges(), head.Graph.edges()
+
+	for _, edge := range headEdges {
+		if !slices.Contains(baseEdges, edge) {
+			diff.AddedEdges = append(diff.AddedEdges, edge)
+		}
+	}
+
+	for _, edge := range baseEdges {
+		if !slices.Contains(headEdges, edge) {
+			diff.RemovedEdges = append(diff.RemovedEdges, edge)
+		}
+	}
+
+	return diff
+}
+
+// edges returns the edges of the graph, sorted by the path of the units depending on others, then by the path of the
+// units depended on.
+func (graph *Graph) edges() []Edge {
+	edges := []Edge{}
+
+	for _, unit := range graph.Units {
+		for _, dep := range unit.Dependencies {
+			edges = append(edges, Edge{From: unit.Path, To: dep.Path})
+		}
+	}
+
+	return edges
+}
+
+// IsEmpty returns true if the snapshots the diff is computed from are the same.
+func (diff *Diff) IsEmpty() bool {
+	return len(diff.AddedUnits) == 0 && len(diff.RemovedUnits) == 0 && len(diff.ChangedUnits) == 0 &&
+		len(diff.AddedEdges) == 0 && len(diff.RemovedEdges) == 0
+}
+
+// WriteJSON writes the diff as JSON.
+func (diff *Diff) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(diff); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// WriteText writes the diff as text, with a line per unit or edge prefixed by `+` if it is added, `-` if it is removed,
+// or `~` if it is changed.
+func (diff *Diff) WriteText(w io.Writer) error {
+	var sb strings.Builder
+
+	if diff.IsEmpty() {
+		sb.WriteString("No changes to the units or their dependencies.\n")
+	}
+
+	if len(diff.AddedUnits)+len(diff.RemovedUnits)+len(diff.ChangedUnits) > 0 {
+		sb.WriteString("Units:\n")
+
+		for _, path := range diff.AddedUnits {
+			fmt.Fprintf(&sb, "  + %s\n", path)
+		}
+
+		for _, path := range diff.RemovedUnits {
+			fmt.Fprintf(&sb, "  - %s\n", path)
+		}
+
+		for _, path := range diff.ChangedUnits {
+			fmt.Fprintf(&sb, "  ~ %s\n", path)
+		}
+	}
+
+	if len(diff.AddedEdges)+len(diff.RemovedEdges) > 0 {
+		sb.WriteString("Dependencies:\n")
+
+		for _, edge := range diff.AddedEdges {
+			fmt.Fprintf(&sb, "  + %s -> %s\n", edge.From, edge.To)
+		}
+
+		for _, edge := range diff.RemovedEdges {
+			fmt.Fprintf(&sb, "  - %s -> %s\n", edge.From, edge.To)
+		}
+	}
+
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
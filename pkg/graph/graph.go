@@ -0,0 +1,318 @@
+// Package graph provides a machine-readable representation of the dependency graph of the units of a stack, so it can
+// be consumed by external tooling, such as CI sharding or visualization tools, without scraping the logs of Terragrunt.
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+	"github.com/gruntwork-io/terragrunt/internal/runner"
+	"github.com/gruntwork-io/terragrunt/internal/runner/common"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+const (
+	// DependencyKindBlock is the kind of the edges created by a `dependency` block.
+	DependencyKindBlock = "dependency"
+
+	// DependencyKindDependencies is the kind of the edges created by the `paths` of a `dependencies` block.
+	DependencyKindDependencies = "dependencies"
+
+	// GroupByDirectory groups the units by the directory they are in.
+	GroupByDirectory = "directory"
+
+	// GroupByTag groups the units by their first tag.
+	GroupByTag = "tag"
+)
+
+// GroupBys are the valid ways to group the units of a rendered graph.
+var GroupBys = []string{GroupByDirectory, GroupByTag}
+
+// Graph is the dependency graph of the units of a stack.
+type Graph struct {
+	Units []Unit `json:"units"`
+}
+
+// Unit is a node of the graph.
+type Unit struct {
+	// Path is the path of the unit, relative to the working dir.
+	Path string `json:"path"`
+	// Source is the `source` of the `terraform` block of the unit, if any.
+	Source string `json:"source,omitempty"`
+	// Tags are the tags of the unit.
+	Tags []string `json:"tags,omitempty"`
+	// Excluded is true if the unit is excluded from the run.
+	Excluded bool `json:"excluded"`
+	// Dependencies are the edges from the unit to the units it depends on.
+	Dependencies []Dependency `json:"dependencies"`
+}
+
+// Dependency is an edge of the graph.
+type Dependency struct {
+	// Path is the path of the unit depended on, relative to the working dir.
+	Path string `json:"path"`
+	// Kind is the kind of the declaration creating the edge, DependencyKindBlock or DependencyKindDependencies.
+	Kind string `json:"kind"`
+	// Name is the name of the `dependency` block creating the edge, if any.
+	Name string `json:"name,omitempty"`
+}
+
+// Build discovers the units of the stack in the working dir of the given options and returns their dependency graph.
+func Build(ctx context.Context, l log.Logger, opts *options.TerragruntOptions) (*Graph, error) {
+	stack, err := runner.FindStackInSubfolders(ctx, l, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return fromUnits(stack.GetStack().Units, opts), nil
+}
+
+// fromUnits returns the graph of the given units, with paths relative to the dir of the config path of the options,
+// sorted by path.
+func fromUnits(units common.Units, opts *options.TerragruntOptions) *Graph {
+	prefix := filepath.Dir(opts.TerragruntConfigPath) + "/"
+	relPath := func(path string) string {
+		return strings.TrimPrefix(path, prefix)
+	}
+
+	graph := &Graph{Units: make([]Unit, 0, len(units))}
+
+	for _, unit := range units {
+		graphUnit := Unit{
+			Path:         relPath(unit.Path),
+			Tags:         unit.Config.Tags,
+			Excluded:     unit.FlagExcluded,
+			Dependencies: make([]Dependency, 0, len(unit.Dependencies)),
+		}
+
+		if unit.Config.Terraform != nil && unit.Config.Terraform.Source != nil {
+			graphUnit.Source = *unit.Config.Terraform.Source
+		}
+
+		for _, target := range unit.Dependencies {
+			dependency := Dependency{Path: relPath(target.Path), Kind: DependencyKindDependencies}
+
+			if name, ok := dependencyBlockName(unit, target.Path); ok {
+				dependency.Kind = DependencyKindBlock
+				dependency.Name = name
+			}
+
+			graphUnit.Dependencies = append(graphUnit.Dependencies, dependency)
+		}
+
+		slices.SortFunc(graphUnit.Dependencies, func(a, b Dependency) int {
+			return strings.Compare(a.Path, b.Path)
+		})
+
+		graph.Units = append(graph.Units, graphUnit)
+	}
+
+	// Sort the units, and their dependencies, so the graph is stable across runs.
+	slices.SortFunc(graph.Units, func(a, b Unit) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return graph
+}
+
+// dependencyBlockName returns the name of the `dependency` block of the unit targeting the unit in the given dir, if
+// any.
+func dependencyBlockName(unit *common.Unit, targetDir string) (string, bool) {
+	for _, dep := range unit.Config.TerragruntDependencies {
+		if dir, ok := dep.TargetDir(unit.Path); ok && dir == util.CleanPath(targetDir) {
+			return dep.Name, true
+		}
+	}
+
+	return "", false
+}
+
+// WriteJSON writes the graph as JSON.
+func (graph *Graph) WriteJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	if err := encoder.Encode(graph); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// group is a set of units rendered together, e.g. as a cluster of a DOT graph.
+type group struct {
+	// Name is the directory or the tag of the units of the group, empty for the units that aren't grouped.
+	Name  string
+	Units []Unit
+}
+
+// groups returns the units of the graph grouped by the given GroupByDirectory or GroupByTag, sorted by name. The units
+// in the working dir, or without tags, are in a group without name, first. All the units are in a single group
+// without name if groupBy is empty.
+func (graph *Graph) groups(groupBy string) []group {
+	groups := []group{{}}
+	indexes := map[string]int{"": 0}
+
+	for _, unit := range graph.Units {
+		var name string
+
+		switch groupBy {
+		case GroupByDirectory:
+			if dir := filepath.ToSlash(filepath.Dir(unit.Path)); dir != "." {
+				name = dir
+			}
+		case GroupByTag:
+			if len(unit.Tags) > 0 {
+				name = unit.Tags[0]
+			}
+		}
+
+		index, ok := indexes[name]
+		if !ok {
+			index = len(groups)
+			indexes[name] = index
+			groups = append(groups, group{Name: name})
+		}
+
+		groups[index].Units = append(groups[index].Units, unit)
+	}
+
+	slices.SortStableFunc(groups[1:], func(a, b group) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return groups
+}
+
+// WriteDot writes the graph in DOT language, with the units of each group, by the given GroupByDirectory or
+// GroupByTag, in a cluster labeled with the name of the group. Excluded units are colored in red, and the units are
+// labeled with their tags, as done for the ungrouped graph.
+func (graph *Graph) WriteDot(w io.Writer, groupBy string) error {
+	var sb strings.Builder
+
+	sb.WriteString("digraph {\n")
+
+	writeUnit := func(unit Unit, indent string) {
+		attrs := []string{}
+		if unit.Excluded {
+			attrs = append(attrs, "color=red")
+		}
+
+		if len(unit.Tags) > 0 {
+			attrs = append(attrs, fmt.Sprintf("xlabel=%q", strings.Join(unit.Tags, ", ")))
+		}
+
+		style := ""
+		if len(attrs) > 0 {
+			style = "[" + strings.Join(attrs, ", ") + "]"
+		}
+
+		fmt.Fprintf(&sb, "%s%q %s;\n", indent, unit.Path, style)
+	}
+
+	for i, group := range graph.groups(groupBy) {
+		if group.Name == "" {
+			for _, unit := range group.Units {
+				writeUnit(unit, "\t")
+			}
+
+			continue
+		}
+
+		fmt.Fprintf(&sb, "\tsubgraph \"cluster_%d\" {\n", i)
+		fmt.Fprintf(&sb, "\t\tlabel = %q;\n", group.Name)
+
+		for _, unit := range group.Units {
+			writeUnit(unit, "\t\t")
+		}
+
+		sb.WriteString("\t}\n")
+	}
+
+	for _, unit := range graph.Units {
+		for _, dep := range unit.Dependencies {
+			fmt.Fprintf(&sb, "\t%q -> %q;\n", unit.Path, dep.Path)
+		}
+	}
+
+	sb.WriteString("}\n")
+
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// WriteMermaid writes the graph as a Mermaid flowchart, with the units of each group, by the given GroupByDirectory
+// or GroupByTag, if any, in a subgraph. Excluded units are styled with the `excluded` class, and the edges created by
+// `dependency` blocks are labeled with the name of the block.
+func (graph *Graph) WriteMermaid(w io.Writer, groupBy string) error {
+	ids := make(map[string]string, len(graph.Units))
+	for i, unit := range graph.Units {
+		ids[unit.Path] = fmt.Sprintf("unit%d", i)
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("flowchart LR\n")
+	sb.WriteString("\tclassDef excluded stroke:red\n")
+
+	for i, group := range graph.groups(groupBy) {
+		indent := "\t"
+
+		if group.Name != "" {
+			fmt.Fprintf(&sb, "\tsubgraph group%d[%q]\n", i, group.Name)
+
+			indent = "\t\t"
+		}
+
+		for _, unit := range group.Units {
+			label := unit.Path
+			if len(unit.Tags) > 0 {
+				label += "<br/>" + strings.Join(unit.Tags, ", ")
+			}
+
+			fmt.Fprintf(&sb, "%s%s[%q]\n", indent, ids[unit.Path], label)
+		}
+
+		if group.Name != "" {
+			sb.WriteString("\tend\n")
+		}
+	}
+
+	for _, unit := range graph.Units {
+		if unit.Excluded {
+			fmt.Fprintf(&sb, "\tclass %s excluded\n", ids[unit.Path])
+		}
+	}
+
+	for _, unit := range graph.Units {
+		for _, dep := range unit.Dependencies {
+			targetID, ok := ids[dep.Path]
+			if !ok {
+				continue
+			}
+
+			if dep.Name != "" {
+				fmt.Fprintf(&sb, "\t%s -->|%s| %s\n", ids[unit.Path], dep.Name, targetID)
+			} else {
+				fmt.Fprintf(&sb, "\t%s --> %s\n", ids[unit.Path], targetID)
+			}
+		}
+	}
+
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,126 @@
+package graph_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gruntwork-io/terragrunt/config"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/graph"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+)
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+
+	rootDir := t.TempDir()
+	units := map[string]string{
+		"alb": `
+terraform {
+  source = "../modules/alb"
+}
+`,
+		"db": `
+tags = ["data"]
+`,
+		"ecs": `
+dependency "lb" {
+  config_path  = "../alb"
+  skip_outputs = true
+}
+
+dependencies {
+  paths = ["../db"]
+}
+`,
+	}
+
+	for name, content := range units {
+		require.NoError(t, os.MkdirAll(filepath.Join(rootDir, name), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(rootDir, name, config.DefaultTerragruntConfigPath), []byte(content), 0644))
+		require.NoError(t, os.WriteFile(filepath.Join(rootDir, name, "main.tf"), []byte(""), 0644))
+	}
+
+	opts, err := options.NewTerragruntOptionsForTest(filepath.Join(rootDir, config.DefaultTerragruntConfigPath))
+	require.NoError(t, err)
+
+	opts.WorkingDir = rootDir
+
+	g, err := graph.Build(t.Context(), logger.CreateLogger(), opts)
+	require.NoError(t, err)
+
+	assert.Equal(t, []graph.Unit{
+		{Path: "alb", Source: "../modules/alb", Dependencies: []graph.Dependency{}},
+		{Path: "db", Tags: []string{"data"}, Dependencies: []graph.Dependency{}},
+		{Path: "ecs", Dependencies: []graph.Dependency{
+			{Path: "alb", Kind: graph.DependencyKindBlock, Name: "lb"},
+			{Path: "db", Kind: graph.DependencyKindDependencies},
+		}},
+	}, g.Units)
+
+	var mermaid bytes.Buffer
+
+	require.NoError(t, g.WriteMermaid(&mermaid, ""))
+	assert.Contains(t, mermaid.String(), "flowchart LR\n")
+	assert.Contains(t, mermaid.String(), `|lb|`)
+}
+
+func TestWriteGrouped(t *testing.T) {
+	t.Parallel()
+
+	g := &graph.Graph{Units: []graph.Unit{
+		{Path: "global/iam", Dependencies: []graph.Dependency{}},
+		{Path: "prod/app", Tags: []string{"app"}, Dependencies: []graph.Dependency{
+			{Path: "prod/vpc", Kind: graph.DependencyKindBlock, Name: "vpc"},
+		}},
+		{Path: "prod/vpc", Tags: []string{"network"}, Excluded: true, Dependencies: []graph.Dependency{}},
+		{Path: "root", Dependencies: []graph.Dependency{}},
+	}}
+
+	var dot bytes.Buffer
+
+	require.NoError(t, g.WriteDot(&dot, graph.GroupByDirectory))
+	assert.Equal(t, `digraph {
+	"root" ;
+	subgraph "cluster_1" {
+		label = "global";
+		"global/iam" ;
+	}
+	subgraph "cluster_2" {
+		label = "prod";
+		"prod/app" [xlabel="app"];
+		"prod/vpc" [color=red, xlabel="network"];
+	}
+	"prod/app" -> "prod/vpc";
+}
+`, dot.String())
+
+	var mermaid bytes.Buffer
+
+	require.NoError(t, g.WriteMermaid(&mermaid, graph.GroupByTag))
+	assert.Equal(t, `flowchart LR
+	classDef excluded stroke:red
+	unit0["global/iam"]
+	unit3["root"]
+	subgraph group1["app"]
+		unit1["prod/app<br/>app"]
+	end
+	subgraph group2["network"]
+		unit2["prod/vpc<br/>network"]
+	end
+	class unit2 excluded
+	unit1 -->|vpc| unit2
+`, mermaid.String())
+
+	var html bytes.Buffer
+
+	require.NoError(t, g.WriteHTML(&html))
+	assert.Contains(t, html.String(), "<!DOCTYPE html>")
+	assert.Contains(t, html.String(), `"path":"prod/vpc"`)
+	assert.Contains(t, html.String(), `"excluded":true`)
+}
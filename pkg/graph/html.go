@@ -0,0 +1,166 @@
+package graph
+
+import (
+	"html/template"
+	"io"
+
+	"github.com/gruntwork-io/terragrunt/internal/errors"
+)
+
+// htmlTemplate is a standalone page exploring the graph, embedded as JSON, without any external resources. Each unit is
+// listed with the tree of the units it depends on, collapsed by default, and the units can be searched by path or tag.
+var htmlTemplate = template.Must(template.New("graph").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Terragrunt dependency graph</title>
+<style>
+  body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #1f2328; }
+  header { display: flex; gap: 1em; align-items: center; margin-bottom: 1em; }
+  input[type=search] { flex: 1; max-width: 40em; padding: 0.4em; font-size: 1em; }
+  ul { list-style: none; padding-left: 1.5em; margin: 0; }
+  #units { padding-left: 0; }
+  li { margin: 0.15em 0; }
+  .unit { cursor: default; }
+  .toggle { display: inline-block; width: 1em; cursor: pointer; user-select: none; color: #656d76; }
+  .path { font-family: ui-monospace, SFMono-Regular, Menlo, monospace; }
+  .excluded > .unit .path { color: #cf222e; text-decoration: line-through; }
+  .tag { font-size: 0.8em; background: #ddf4ff; border-radius: 1em; padding: 0 0.6em; margin-left: 0.4em; }
+  .name { font-size: 0.8em; color: #656d76; margin-left: 0.4em; }
+  .collapsed > ul { display: none; }
+  .match > .unit .path { background: #fff8c5; }
+  .summary { color: #656d76; }
+</style>
+</head>
+<body>
+<header>
+  <input id="search" type="search" placeholder="Search units by path or tag" autofocus>
+  <button id="expand">Expand next level</button>
+  <button id="collapse">Collapse all</button>
+  <span id="summary" class="summary"></span>
+</header>
+<ul id="units"></ul>
+<script>
+const graph = {{.}};
+const units = new Map(graph.units.map(unit => [unit.path, unit]));
+
+// renderUnit returns the item of the given unit, with the tree of its dependencies rendered when it's first expanded.
+// The ancestors are tracked to stop at dependency cycles.
+function renderUnit(unit, name, ancestors) {
+  const item = document.createElement("li");
+  item.className = "collapsed" + (unit.excluded ? " excluded" : "");
+  item.dataset.path = unit.path;
+
+  const line = document.createElement("span");
+  line.className = "unit";
+
+  const toggle = document.createElement("span");
+  toggle.className = "toggle";
+  toggle.textContent = unit.dependencies.length > 0 ? "▸" : "";
+  line.appendChild(toggle);
+
+  const path = document.createElement("span");
+  path.className = "path";
+  path.textContent = unit.path;
+  line.appendChild(path);
+
+  if (name) {
+    const label = document.createElement("span");
+    label.className = "name";
+    label.textContent = "dependency." + name;
+    line.appendChild(label);
+  }
+
+  for (const tag of unit.tags || []) {
+    const label = document.createElement("span");
+    label.className = "tag";
+    label.textContent = tag;
+    line.appendChild(label);
+  }
+
+  item.appendChild(line);
+
+  item.expand = function (expanded) {
+    if (unit.dependencies.length === 0) {
+      return;
+    }
+
+    if (expanded && !item.querySelector("ul")) {
+      const children = document.createElement("ul");
+      const childAncestors = new Set(ancestors).add(unit.path);
+
+      for (const dep of unit.dependencies) {
+        const target = units.get(dep.path) || { path: dep.path, dependencies: [] };
+
+        if (childAncestors.has(dep.path)) {
+          continue;
+        }
+
+        children.appendChild(renderUnit(target, dep.name, childAncestors));
+      }
+
+      item.appendChild(children);
+    }
+
+    item.classList.toggle("collapsed", !expanded);
+    toggle.textContent = expanded ? "▾" : "▸";
+  };
+
+  toggle.addEventListener("click", () => item.expand(item.classList.contains("collapsed")));
+
+  return item;
+}
+
+const list = document.getElementById("units");
+
+for (const unit of graph.units) {
+  list.appendChild(renderUnit(unit, "", new Set()));
+}
+
+function filter() {
+  const query = document.getElementById("search").value.trim().toLowerCase();
+  let shown = 0;
+
+  for (const item of list.children) {
+    const unit = units.get(item.dataset.path);
+    const matches = query === "" ||
+      unit.path.toLowerCase().includes(query) ||
+      (unit.tags || []).some(tag => tag.toLowerCase().includes(query));
+
+    item.hidden = !matches;
+    item.classList.toggle("match", matches && query !== "");
+    shown += matches ? 1 : 0;
+  }
+
+  document.getElementById("summary").textContent = shown + " of " + graph.units.length + " units";
+}
+
+// expandAll expands the rendered subtrees of the visible units by one level, or collapses them all, as expanding the
+// trees of large stacks at once would render a lot of units.
+function expandAll(expanded) {
+  for (const item of list.querySelectorAll("li")) {
+    if (!item.closest("[hidden]")) {
+      item.expand(expanded);
+    }
+  }
+}
+
+document.getElementById("search").addEventListener("input", filter);
+document.getElementById("expand").addEventListener("click", () => expandAll(true));
+document.getElementById("collapse").addEventListener("click", () => expandAll(false));
+
+filter();
+</script>
+</body>
+</html>
+`))
+
+// WriteHTML writes the graph as a standalone HTML page, to explore the dependencies of the units in a browser without
+// any other tooling.
+func (graph *Graph) WriteHTML(w io.Writer) error {
+	if err := htmlTemplate.Execute(w, graph); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
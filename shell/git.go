@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"net/url"
+	"path/filepath"
 	"strings"
 
 	"github.com/gruntwork-io/terragrunt/internal/cache"
@@ -53,6 +54,62 @@ func GitTopLevelDir(ctx context.Context, l log.Logger, terragruntOptions *option
 	return cmdOutput, nil
 }
 
+// GitWorktreeAdd checks out the given ref of the git repository at repoDir in a new worktree at the given dir, detached
+// from any branch, so the files of the ref can be read without changing the working tree of the repository.
+func GitWorktreeAdd(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, repoDir, dir, ref string) error {
+	if _, err := RunCommandWithOutput(ctx, l, opts, repoDir, true, false, "git", "worktree", "add", "--detach", "--quiet", dir, ref); err != nil {
+		return errors.Errorf("failed to check out %s: %w", ref, err)
+	}
+
+	return nil
+}
+
+// GitWorktreeRemove removes the worktree at the given dir, added with GitWorktreeAdd, from the git repository at
+// repoDir.
+func GitWorktreeRemove(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, repoDir, dir string) error {
+	if _, err := RunCommandWithOutput(ctx, l, opts, repoDir, true, false, "git", "worktree", "remove", "--force", dir); err != nil {
+		return errors.New(err)
+	}
+
+	return nil
+}
+
+// GitChangedFiles returns the absolute paths of the files of the git repository at repoDir changed since the commit
+// the current HEAD branched off the given ref, including the uncommitted and untracked files, and the deleted ones.
+func GitChangedFiles(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, repoDir, ref string) ([]string, error) {
+	topLevelDir, err := GitTopLevelDir(ctx, l, opts, repoDir)
+	if err != nil {
+		return nil, err
+	}
+
+	mergeBase, err := RunCommandWithOutput(ctx, l, opts, repoDir, true, false, "git", "merge-base", ref, "HEAD")
+	if err != nil {
+		return nil, errors.Errorf("failed to find the merge base of %s and HEAD: %w", ref, err)
+	}
+
+	diff, err := RunCommandWithOutput(ctx, l, opts, repoDir, true, false, "git", "diff", "--name-only", "--no-renames", "-z", strings.TrimSpace(mergeBase.Stdout.String()))
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	untracked, err := RunCommandWithOutput(ctx, l, opts, repoDir, true, false, "git", "ls-files", "--others", "--exclude-standard", "--full-name", "-z")
+	if err != nil {
+		return nil, errors.New(err)
+	}
+
+	var files []string
+
+	for _, output := range []string{diff.Stdout.String(), untracked.Stdout.String()} {
+		for _, file := range strings.Split(output, "\x00") {
+			if file != "" {
+				files = append(files, filepath.Join(topLevelDir, filepath.FromSlash(file)))
+			}
+		}
+	}
+
+	return files, nil
+}
+
 // GitRepoTags fetches git repository tags from passed url.
 func GitRepoTags(ctx context.Context, l log.Logger, opts *options.TerragruntOptions, gitRepo *url.URL) ([]string, error) {
 	repoPath := gitRepo.String()
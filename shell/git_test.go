@@ -0,0 +1,69 @@
+package shell_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/shell"
+	"github.com/gruntwork-io/terragrunt/test/helpers/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitChangedFiles(t *testing.T) {
+	t.Parallel()
+
+	repoDir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = repoDir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	writeFile := func(path, content string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(repoDir, path)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(repoDir, path), []byte(content), 0644))
+	}
+
+	git("init", "--quiet", "--initial-branch=main")
+	writeFile("live/vpc/terragrunt.hcl", "")
+	writeFile("live/db/terragrunt.hcl", "")
+	writeFile("live/app/terragrunt.hcl", "")
+	git("add", "-A")
+	git("commit", "--quiet", "-m", "initial")
+
+	git("checkout", "--quiet", "-b", "feature")
+	writeFile("live/vpc/terragrunt.hcl", "# changed")
+	git("commit", "--quiet", "-am", "change vpc")
+	require.NoError(t, os.Remove(filepath.Join(repoDir, "live", "db", "terragrunt.hcl")))
+	writeFile("live/dns/terragrunt.hcl", "")
+
+	opts, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err)
+
+	l := logger.CreateLogger()
+
+	files, err := shell.GitChangedFiles(t.Context(), l, opts, repoDir, "main")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join(repoDir, "live", "vpc", "terragrunt.hcl"),
+		filepath.Join(repoDir, "live", "db", "terragrunt.hcl"),
+		filepath.Join(repoDir, "live", "dns", "terragrunt.hcl"),
+	}, files)
+
+	files, err = shell.GitChangedFiles(t.Context(), l, opts, repoDir, "HEAD")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join(repoDir, "live", "db", "terragrunt.hcl"),
+		filepath.Join(repoDir, "live", "dns", "terragrunt.hcl"),
+	}, files)
+
+	_, err = shell.GitChangedFiles(t.Context(), l, opts, repoDir, "missing")
+	require.Error(t, err)
+}
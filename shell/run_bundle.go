@@ -0,0 +1,55 @@
+package shell
+
+import (
+	"slices"
+	"time"
+
+	"github.com/gruntwork-io/terragrunt/internal/runs"
+	"github.com/gruntwork-io/terragrunt/options"
+	"github.com/gruntwork-io/terragrunt/pkg/log"
+	"github.com/gruntwork-io/terragrunt/util"
+)
+
+// newInvocation returns the record of the invocation of the given command, about to start in the given dir, with the
+// hash of the content of the dir before the command changes it.
+func newInvocation(l log.Logger, opts *options.TerragruntOptions, commandDir, command string, args []string) *runs.Invocation {
+	contentHash, err := runs.ContentHash(commandDir)
+	if err != nil {
+		l.Debugf("Failed to hash the content of %s for the run bundle: %v", commandDir, err)
+	}
+
+	args = slices.Clone(args)
+	for i, arg := range args {
+		args[i] = opts.Redactor.Redact(arg)
+	}
+
+	return &runs.Invocation{
+		StartedAt:   time.Now(),
+		Env:         runs.RedactEnv(opts.Env, opts.Redactor.Redact),
+		WorkingDir:  commandDir,
+		ContentHash: contentHash,
+		Command:     command,
+		Args:        args,
+	}
+}
+
+// recordInvocation records the given invocation, with its output and error, into the run bundle. A failure to record
+// it is logged, and doesn't fail the command.
+func recordInvocation(l log.Logger, opts *options.TerragruntOptions, invocation *runs.Invocation, output *util.CmdOutput, err error) {
+	invocation.DurationMS = time.Since(invocation.StartedAt).Milliseconds()
+	invocation.Stdout = opts.Redactor.Redact(output.Stdout.String())
+	invocation.Stderr = opts.Redactor.Redact(output.Stderr.String())
+
+	if err != nil {
+		invocation.Error = opts.Redactor.Redact(err.Error())
+		invocation.ExitCode = 1
+
+		if exitCode, exitErr := util.GetExitCode(err); exitErr == nil {
+			invocation.ExitCode = exitCode
+		}
+	}
+
+	if err := opts.RunBundle.Record(invocation); err != nil {
+		l.Warnf("Failed to record the invocation of %s in %s into the run bundle %s: %v", invocation.Command, invocation.WorkingDir, opts.RunBundle.Path(), err)
+	}
+}
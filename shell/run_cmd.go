@@ -10,6 +10,7 @@ import (
 
 	"github.com/gruntwork-io/terragrunt/engine"
 	"github.com/gruntwork-io/terragrunt/internal/os/exec"
+	"github.com/gruntwork-io/terragrunt/internal/runs"
 	"github.com/gruntwork-io/terragrunt/pkg/log"
 
 	"github.com/gruntwork-io/terragrunt/telemetry"
@@ -59,6 +60,12 @@ func RunCommandWithOutput(
 		commandDir = opts.WorkingDir
 	}
 
+	// Record the invocations of OpenTofu/Terraform into the run bundle of --record-bundle, if any.
+	var invocation *runs.Invocation
+	if command == opts.TFPath && opts.RunBundle != nil {
+		invocation = newInvocation(l, opts, commandDir, command, args)
+	}
+
 	err := telemetry.TelemeterFromContext(ctx).Collect(ctx, "run_"+command, map[string]any{
 		"command": command,
 		"args":    fmt.Sprintf("%v", args),
@@ -67,8 +74,8 @@ func RunCommandWithOutput(
 		l.Debugf("Running command: %s %s", command, strings.Join(args, " "))
 
 		var (
-			cmdStderr = io.MultiWriter(opts.ErrWriter, &output.Stderr)
-			cmdStdout = io.MultiWriter(opts.Writer, &output.Stdout)
+			cmdStderr = io.MultiWriter(opts.Redactor.Writer(opts.ErrWriter), &output.Stderr)
+			cmdStdout = io.MultiWriter(opts.Redactor.Writer(opts.Writer), &output.Stdout)
 		)
 
 		// Pass the traceparent to the child process if it is available in the context.
@@ -86,6 +93,29 @@ func RunCommandWithOutput(
 		}
 
 		if command == opts.TFPath {
+			// If the unit has a remote executor, dispatch the command to its runner agent.
+			if opts.Executor != nil {
+				l.Debugf("Using remote executor %s to run command: %s %s", opts.Executor.Address, command, strings.Join(args, " "))
+
+				cmdOutput, err := engine.RunRemote(ctx, l, &engine.ExecutionOptions{
+					TerragruntOptions: opts,
+					CmdStdout:         cmdStdout,
+					CmdStderr:         cmdStderr,
+					WorkingDir:        commandDir,
+					SuppressStdout:    suppressStdout,
+					AllocatePseudoTty: needsPTY,
+					Command:           command,
+					Args:              args,
+				})
+				if err != nil {
+					return errors.New(err)
+				}
+
+				output = *cmdOutput
+
+				return nil
+			}
+
 			// If the engine is enabled and the command is IaC executable, use the engine to run the command.
 			if opts.Engine != nil && opts.EngineEnabled {
 				l.Debugf("Using engine to run command: %s %s", command, strings.Join(args, " "))
@@ -121,6 +151,7 @@ func RunCommandWithOutput(
 			exec.WithUsePTY(needsPTY),
 			exec.WithEnv(opts.Env),
 			exec.WithForwardSignalDelay(SignalForwardingDelay),
+			exec.WithKillDelay(time.Duration(opts.UnitTimeoutGracePeriod)*time.Second),
 		)
 
 		if err := cmd.Start(); err != nil { //nolint:contextcheck
@@ -154,5 +185,9 @@ func RunCommandWithOutput(
 		return nil
 	})
 
+	if invocation != nil {
+		recordInvocation(l, opts, invocation, &output, err)
+	}
+
 	return &output, err
 }
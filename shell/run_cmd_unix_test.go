@@ -4,6 +4,7 @@
 package shell_test
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"strconv"
@@ -48,3 +49,22 @@ func TestRunCommandWithOutputInterrupt(t *testing.T) {
 	expectedErr := fmt.Sprintf("Failed to execute \"%s 5\" in .\n\nexit status %d", cmdPath, expectedWait)
 	assert.EqualError(t, actualErr, expectedErr)
 }
+
+func TestRunCommandWithOutputRedactsSensitiveValues(t *testing.T) {
+	t.Parallel()
+
+	terragruntOptions, err := options.NewTerragruntOptionsForTest("")
+	require.NoError(t, err, "Unexpected error creating NewTerragruntOptionsForTest: %v", err)
+
+	stdout := new(bytes.Buffer)
+	terragruntOptions.Writer = stdout
+	terragruntOptions.Redactor.Add("hunter2")
+
+	l := logger.CreateLogger()
+
+	output, err := shell.RunCommandWithOutput(t.Context(), l, terragruntOptions, "", false, false, "echo", "password=hunter2")
+	require.NoError(t, err)
+
+	assert.Equal(t, "password=[REDACTED]\n", stdout.String(), "Sensitive values are redacted from the output")
+	assert.Equal(t, "password=hunter2\n", output.Stdout.String(), "Captured output is not redacted")
+}
@@ -63,6 +63,11 @@ func RunCommandWithOutput(ctx context.Context, l log.Logger, opts *options.Terra
 		opts.Writer, opts.ErrWriter = logTFOutput(l, opts, args)
 	}
 
+	// The API calls OpenTofu/Terraform makes can't be throttled, so its runs are, to stagger their bursts of calls.
+	if err := opts.RateLimiter.WaitProviders(ctx); err != nil {
+		return nil, err
+	}
+
 	output, err := shell.RunCommandWithOutput(ctx, l, opts, "", false, needsPTY, opts.TFPath, args...)
 
 	if err != nil && util.ListContainsElement(args, FlagNameDetailedExitCode) {
@@ -46,6 +46,7 @@ const (
 	// TF flags.
 
 	FlagNameDetailedExitCode = "-detailed-exitcode"
+	FlagNameRefreshOnly      = "-refresh-only"
 	FlagNameHelpLong         = "-help"
 	FlagNameHelpShort        = "-h"
 	FlagNameVersion          = "-version"